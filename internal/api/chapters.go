@@ -17,6 +17,13 @@ func (c *Client) GetEpisodeChapters(episodeID int, pagination PaginationParams)
 	return GetPaginated[models.Chapter](c, path, pagination.ToMap())
 }
 
+// GetAllEpisodeChapters retrieves every chapter of an episode, following pagination.
+// API: GET /v2/episodes/{episode_id}/chapters
+func (c *Client) GetAllEpisodeChapters(episodeID int) ([]models.Chapter, error) {
+	path := fmt.Sprintf("/episodes/%d/chapters", episodeID)
+	return GetAllPaginated[models.Chapter](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
+
 // ChapterParams contains the parameters for creating or updating a chapter.
 type ChapterParams struct {
 	StartsAt *int
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -24,8 +25,29 @@ const (
 
 	// maxResponseSize is the maximum allowed API response body size (10 MB).
 	maxResponseSize = 10 << 20
+
+	// maxIdleConnsPerHost keeps enough connections open for concurrent bulk
+	// operations (downloads, paginated exports) against the same host.
+	maxIdleConnsPerHost = 16
 )
 
+// Transport is the HTTP transport shared by the API client and the other
+// HTTP helpers (downloads, link checks), so connections are reused across
+// requests instead of being redialed.
+var Transport http.RoundTripper = NewTransport()
+
+// NewTransport returns a transport tuned for bulk operations: HTTP/2 when
+// the server supports it, keep-alives, and a larger idle pool per host.
+func NewTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.IdleConnTimeout = 90 * time.Second
+	t.DisableKeepAlives = false
+	return t
+}
+
 
 type Client struct {
 	BaseURL    string
@@ -33,6 +55,75 @@ type Client struct {
 	token      string
 	HTTPClient *http.Client
 	UserAgent  string
+
+	// Strict rejects responses with fields the models do not declare and
+	// reports expected fields missing from responses through OnWarning.
+	Strict    bool
+	OnWarning func(msg string)
+
+	// Pages controls how multi-page loops handle pages that fail.
+	Pages PagePolicy
+
+	// Context, when set, cancels requests and the waits between retries of
+	// a page, e.g. on Ctrl-C. Nil means context.Background.
+	Context context.Context
+
+	// ReadOnly refuses every request but GET and HEAD before it is sent,
+	// so the client cannot change anything on the account.
+	ReadOnly bool
+
+	// Scopes are the token's scopes, when it lists them (see
+	// InspectToken). OnScopeWarning is called before each request that
+	// changes data if they do not allow it, since the API rejects it with
+	// a bare 403.
+	Scopes         []string
+	OnScopeWarning func(msg string)
+
+	// BeforeWrite, when set, is called before each request that changes
+	// data and may return a snapshot of the values it is about to change.
+	// OnWrite is called after each such request succeeds, e.g. to keep a
+	// journal of changes.
+	BeforeWrite func(method, path string, fields map[string]string) map[string]string
+	OnWrite     func(w Write)
+
+	// Queue, when set, is offered each request that changes data and
+	// could not be sent because the API was unreachable, e.g. to send it
+	// later. It reports whether it took the request; taken requests fail
+	// with a QueuedError.
+	Queue func(w Write) bool
+
+	// Tokens, when set, replaces the client token: requests rotate over
+	// the pool's tokens, moving on when one hits the rate limit.
+	Tokens *TokenPool
+
+	// Headers are added to every request to the API host, e.g. tenant or
+	// tracing headers a gateway requires. They replace the common headers
+	// of the same name, such as User-Agent. Downloads from other hosts do
+	// not get them.
+	Headers http.Header
+
+	// Locale, e.g. "it_IT", is sent to endpoints that return localized
+	// names, such as categories and languages, unless a call gives its own.
+	Locale string
+
+	// idempotencyKey, when set, is sent by every POST request instead of a
+	// fresh key; see WithIdempotencyKey.
+	idempotencyKey string
+}
+
+// Write describes a request that changes data. Path excludes the API
+// version, e.g. "/episodes/123"; Fields are its form fields and Body its
+// JSON body, if any. Before is the snapshot returned by BeforeWrite. Key
+// is the idempotency key a POST was sent with. Upload is set for requests
+// that also carry a file, which Fields and Body do not record.
+type Write struct {
+	Method string
+	Path   string
+	Fields map[string]string
+	Body   []byte
+	Before map[string]string
+	Key    string
+	Upload bool
 }
 
 // NewClient creates a new Spreaker API client with the given OAuth token.
@@ -43,7 +134,8 @@ func NewClient(token string) *Client {
 		APIVersion: DefaultAPIVersion,
 		token:      token,
 		HTTPClient: &http.Client{
-			Timeout: DefaultTimeout,
+			Timeout:   DefaultTimeout,
+			Transport: Transport,
 		},
 		UserAgent: "spreaker-cli/1.0",
 	}
@@ -96,6 +188,37 @@ func (e *APIError) IsRateLimited() bool {
 	return e.StatusCode == http.StatusTooManyRequests
 }
 
+// ReadOnlyError is returned for a request refused by a read-only client.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("read-only mode: refusing to send %s %s", e.Method, e.Path)
+}
+
+// QueuedError is returned for a request that changes data and could not
+// be sent because the API was unreachable, and was taken by Client.Queue.
+type QueuedError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("API unreachable, queued %s %s: run 'spreaker queue flush' when back online", e.Method, e.Path)
+}
+
+func (e *QueuedError) Unwrap() error { return e.Err }
+
+// IsNetworkError reports whether err means the API could not be reached,
+// as opposed to an error response or a cancelled request.
+func IsNetworkError(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
+}
+
 // -----------------------------------------------------------------------------
 // API Response Wrapper
 // -----------------------------------------------------------------------------
@@ -128,9 +251,34 @@ func (c *Client) buildURL(path string) string {
 	return fmt.Sprintf("%s/%s%s", strings.TrimRight(c.BaseURL, "/"), c.APIVersion, path)
 }
 
+// checkWritable returns a ReadOnlyError when a read-only client is asked
+// to send a request that could change data.
+func (c *Client) checkWritable(method, urlStr string) error {
+	if !c.ReadOnly || method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+	path := urlStr
+	if u, err := url.Parse(urlStr); err == nil {
+		path = u.Path
+	}
+	return &ReadOnlyError{Method: method, Path: path}
+}
+
+// context returns the context requests are made with.
+func (c *Client) context() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+	return c.Context
+}
+
 // newRequest creates a new HTTP request with common headers set.
 func (c *Client) newRequest(method, urlStr string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequestWithContext(context.TODO(), method, urlStr, body)
+	if err := c.checkWritable(method, urlStr); err != nil {
+		return nil, err
+	}
+	c.checkScope(method, urlStr)
+	req, err := http.NewRequestWithContext(c.context(), method, urlStr, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -143,6 +291,10 @@ func (c *Client) newRequest(method, urlStr string, body io.Reader) (*http.Reques
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
+	if c.isAPIHost(req.URL) {
+		c.setHeaders(req)
+		c.setIdempotencyKey(req)
+	}
 
 	return req, nil
 }
@@ -150,21 +302,18 @@ func (c *Client) newRequest(method, urlStr string, body io.Reader) (*http.Reques
 // do executes an HTTP request and handles the response.
 // It unmarshals the response into the provided result pointer.
 func (c *Client) do(req *http.Request, result interface{}) error {
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read the response body with a size cap to prevent memory exhaustion.
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
+	// Cap the response body size to prevent memory exhaustion.
+	body := limitBody(resp.Body)
 
 	// Check for error responses (4xx, 5xx)
 	if resp.StatusCode >= 400 {
-		return c.parseErrorResponse(resp.StatusCode, body)
+		return c.readErrorResponse(resp.StatusCode, body)
 	}
 
 	// If no result is expected, we're done
@@ -172,20 +321,82 @@ func (c *Client) do(req *http.Request, result interface{}) error {
 		return nil
 	}
 
+	// Strict mode compares the raw body against the model, so it needs the
+	// whole body; otherwise decode straight from the stream.
+	if !c.Strict {
+		if err := decodeEnvelope(body, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+		return nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
 	// Parse the response wrapper
 	var apiResp apiResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := json.Unmarshal(data, &apiResp); err != nil {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	// Unmarshal the inner response into the result
-	if err := json.Unmarshal(apiResp.Response, result); err != nil {
+	if err := c.decode(endpoint(req), apiResp.Response, result); err != nil {
 		return fmt.Errorf("failed to parse response data: %w", err)
 	}
 
 	return nil
 }
 
+// doWrite executes a request that changes data, reporting it to the
+// BeforeWrite and OnWrite hooks, and offering it to Queue when the API
+// cannot be reached.
+func (c *Client) doWrite(req *http.Request, fields map[string]string, body []byte, result interface{}) error {
+	return c.sendWrite(req, c.newWrite(req, fields, body), result)
+}
+
+// newWrite describes req for the write hooks.
+func (c *Client) newWrite(req *http.Request, fields map[string]string, body []byte) Write {
+	return Write{
+		Method: req.Method,
+		Path:   strings.TrimPrefix(req.URL.Path, "/"+c.APIVersion),
+		Fields: fields,
+		Body:   body,
+		Key:    req.Header.Get(IdempotencyHeader),
+	}
+}
+
+// sendWrite executes the request w describes. Uploads are never offered
+// to Queue: the file is not part of w, so it could not be sent later.
+func (c *Client) sendWrite(req *http.Request, w Write, result interface{}) error {
+	if c.BeforeWrite != nil {
+		w.Before = c.BeforeWrite(w.Method, w.Path, w.Fields)
+	}
+	if err := c.do(req, result); err != nil {
+		if c.Queue != nil && !w.Upload && IsNetworkError(err) && c.Queue(w) {
+			return &QueuedError{Method: w.Method, Path: w.Path, Err: err}
+		}
+		return err
+	}
+	if c.OnWrite != nil {
+		c.OnWrite(w)
+	}
+	return nil
+}
+
+// endpoint describes a request for error and warning messages, e.g. "GET /v2/me".
+func endpoint(req *http.Request) string {
+	return req.Method + " " + req.URL.Path
+}
+
+// readErrorResponse reads an error response body and parses it. A body that
+// cannot be read still yields an APIError with the status code.
+func (c *Client) readErrorResponse(statusCode int, body io.Reader) error {
+	data, _ := io.ReadAll(body)
+	return c.parseErrorResponse(statusCode, data)
+}
+
 // parseErrorResponse extracts error information from an API error response.
 func (c *Client) parseErrorResponse(statusCode int, body []byte) error {
 	apiErr := &APIError{StatusCode: statusCode}
@@ -229,9 +440,10 @@ func (c *Client) Get(path string, params map[string]string, result interface{})
 func (c *Client) Post(path string, body interface{}, result interface{}) error {
 	// Serialize body to JSON
 	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
+		var err error
+		if jsonBody, err = json.Marshal(body); err != nil {
 			return fmt.Errorf("failed to serialize request body: %w", err)
 		}
 		bodyReader = bytes.NewReader(jsonBody)
@@ -246,7 +458,7 @@ func (c *Client) Post(path string, body interface{}, result interface{}) error {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return c.do(req, result)
+	return c.doWrite(req, nil, jsonBody, result)
 }
 
 // This is used for endpoints that accept form fields data (multipart/form-data), like episode uploads.
@@ -273,12 +485,17 @@ func (c *Client) PostForm(path string, fields map[string]string, result interfac
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	return c.do(req, result)
+	return c.doWrite(req, fields, nil, result)
 }
 
 // PostFormWithFile performs a POST request with form data including a file upload.
 // This is used for uploading episode audio files.
 func (c *Client) PostFormWithFile(path string, fields map[string]string, fileField, filePath string, result interface{}) error {
+	// Refuse before reading the whole file into memory.
+	if err := c.checkWritable(http.MethodPost, c.buildURL(path)); err != nil {
+		return err
+	}
+
 	// Open the file
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -320,7 +537,9 @@ func (c *Client) PostFormWithFile(path string, fields map[string]string, fileFie
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	return c.do(req, result)
+	w := c.newWrite(req, fields, nil)
+	w.Upload = true
+	return c.sendWrite(req, w, result)
 }
 
 // Delete performs a DELETE request.
@@ -330,7 +549,7 @@ func (c *Client) Delete(path string, result interface{}) error {
 		return err
 	}
 
-	return c.do(req, result)
+	return c.doWrite(req, nil, nil, result)
 }
 
 // Put performs a PUT request (used for some Spreaker endpoints like follow/favorite).
@@ -340,7 +559,7 @@ func (c *Client) Put(path string, result interface{}) error {
 		return err
 	}
 
-	return c.do(req, result)
+	return c.doWrite(req, nil, nil, result)
 }
 
 // -----------------------------------------------------------------------------
@@ -357,7 +576,11 @@ type PaginatedResult[T any] struct {
 // GetPaginated performs a GET request and parses a paginated response.
 // T is the type of items in the list.
 func GetPaginated[T any](c *Client, path string, params map[string]string) (*PaginatedResult[T], error) {
-	// Build URL with query parameters
+	return getPaginatedURL[T](c, c.buildPaginatedURL(path, params))
+}
+
+// buildPaginatedURL builds the URL of the first page of a paginated endpoint.
+func (c *Client) buildPaginatedURL(path string, params map[string]string) string {
 	urlStr := c.buildURL(path)
 	if len(params) > 0 {
 		query := url.Values{}
@@ -366,29 +589,80 @@ func GetPaginated[T any](c *Client, path string, params map[string]string) (*Pag
 		}
 		urlStr = urlStr + "?" + query.Encode()
 	}
+	return urlStr
+}
+
+// GetAllPaginated fetches a paginated endpoint, following next_url links
+// until every page has been read or limit items were collected (0 = no limit).
+// Failed pages are handled according to c.Pages: unless it is fail-fast, the
+// items fetched before a page that kept failing are returned together with
+// a *PartialError.
+func GetAllPaginated[T any](c *Client, path string, params map[string]string, limit int) ([]T, error) {
+	return collectPages[T](c, c.buildPaginatedURL(path, params), limit)
+}
 
+// checkNextURL ensures a next_url points at the configured API host,
+// since the request carries the bearer token.
+func (c *Client) checkNextURL(next string) error {
+	u, err := url.Parse(next)
+	if err != nil {
+		return fmt.Errorf("invalid next_url: %w", err)
+	}
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != base.Scheme || u.Host != base.Host {
+		return fmt.Errorf("next_url %q does not match API host %q", next, base.Host)
+	}
+	return nil
+}
+
+// getPaginatedURL fetches and parses a single page from a fully built URL.
+func getPaginatedURL[T any](c *Client, urlStr string) (*PaginatedResult[T], error) {
 	req, err := c.newRequest(http.MethodGet, urlStr, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	body := limitBody(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		return nil, c.readErrorResponse(resp.StatusCode, body)
+	}
+
+	if c.Strict {
+		return decodePageStrict[T](c, endpoint(req), body)
+	}
+
+	items, nextURL, err := decodePage[T](body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to parse paginated response: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, c.parseErrorResponse(resp.StatusCode, body)
+	return &PaginatedResult[T]{
+		Items:   items,
+		NextURL: nextURL,
+		HasMore: nextURL != "",
+	}, nil
+}
+
+// decodePageStrict reads the whole page so strict mode can compare the raw
+// items against the model.
+func decodePageStrict[T any](c *Client, endpoint string, body io.Reader) (*PaginatedResult[T], error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	var apiResp apiResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
+	if err := json.Unmarshal(data, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -398,7 +672,7 @@ func GetPaginated[T any](c *Client, path string, params map[string]string) (*Pag
 	}
 
 	var items []T
-	if err := json.Unmarshal(paginated.Items, &items); err != nil {
+	if err := c.decode(endpoint, paginated.Items, &items); err != nil {
 		return nil, fmt.Errorf("failed to parse items: %w", err)
 	}
 
@@ -413,6 +687,9 @@ func GetPaginated[T any](c *Client, path string, params map[string]string) (*Pag
 // Convenience: Pagination Parameters
 // -----------------------------------------------------------------------------
 
+// MaxPageLimit is the largest page size accepted by the Spreaker API.
+const MaxPageLimit = 100
+
 type PaginationParams struct {
 	Limit  int 
 	Offset int 
@@ -2,8 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -83,6 +87,26 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_SharesTransport(t *testing.T) {
+	a, b := NewClient("a"), NewClient("b")
+	if a.HTTPClient.Transport != Transport || b.HTTPClient.Transport != Transport {
+		t.Error("clients should share the package Transport")
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	tr := NewTransport()
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 should be enabled")
+	}
+	if tr.DisableKeepAlives {
+		t.Error("keep-alives should be enabled")
+	}
+	if tr.MaxIdleConnsPerHost != maxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", tr.MaxIdleConnsPerHost, maxIdleConnsPerHost)
+	}
+}
+
 func TestNewClientWithOptions(t *testing.T) {
 	t.Run("overrides baseURL and timeout", func(t *testing.T) {
 		c := NewClientWithOptions("tok", "https://custom.api", 5*time.Second)
@@ -344,6 +368,125 @@ func TestPut_Method(t *testing.T) {
 	c.Put("/test", nil)
 }
 
+func TestReadOnly(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": nil})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	c.ReadOnly = true
+
+	if err := c.Get("/me", nil, nil); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+
+	writes := map[string]func() error{
+		"POST":      func() error { return c.Post("/shows", nil, nil) },
+		"POST form": func() error { return c.PostForm("/shows/1", map[string]string{"title": "x"}, nil) },
+		"POST file": func() error { return c.PostFormWithFile("/shows/1/episodes", nil, "media_file", "missing.mp3", nil) },
+		"PUT":       func() error { return c.Put("/users/1/followings/2", nil) },
+		"DELETE":    func() error { return c.Delete("/episodes/3", nil) },
+	}
+	for name, write := range writes {
+		var roErr *ReadOnlyError
+		if err := write(); !errors.As(err, &roErr) {
+			t.Errorf("%s: error = %v, want ReadOnlyError", name, err)
+		}
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Errorf("server received %v, want only GET", methods)
+	}
+	if got := (&ReadOnlyError{Method: "DELETE", Path: "/v2/episodes/3"}).Error(); got != "read-only mode: refusing to send DELETE /v2/episodes/3" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestWriteHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/episodes/9" && r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": nil})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv).WithIdempotencyKey("key")
+	var writes []Write
+	c.BeforeWrite = func(method, path string, fields map[string]string) map[string]string {
+		if method == http.MethodPost {
+			return map[string]string{"title": "Old"}
+		}
+		return nil
+	}
+	c.OnWrite = func(w Write) { writes = append(writes, w) }
+
+	c.Get("/me", nil, nil)
+	c.PostForm("/episodes/3", map[string]string{"title": "New"}, nil)
+	c.Put("/users/1/likes/3", nil)
+	c.Delete("/episodes/9", nil) // fails: not reported
+
+	want := []Write{
+		{Method: http.MethodPost, Path: "/episodes/3", Fields: map[string]string{"title": "New"}, Before: map[string]string{"title": "Old"}, Key: "key"},
+		{Method: http.MethodPut, Path: "/users/1/likes/3"},
+	}
+	if !reflect.DeepEqual(writes, want) {
+		t.Errorf("writes = %+v, want %+v", writes, want)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	c := testClient(t, srv)
+	srv.Close() // unreachable from now on
+
+	var queued []Write
+	c.Queue = func(w Write) bool {
+		if w.Method == http.MethodDelete {
+			return false
+		}
+		queued = append(queued, w)
+		return true
+	}
+
+	var qErr *QueuedError
+	if err := c.Post("/episodes/3/messages", map[string]string{"text": "hi"}, nil); !errors.As(err, &qErr) {
+		t.Fatalf("Post() error = %v, want QueuedError", err)
+	}
+	if !IsNetworkError(qErr) {
+		t.Error("QueuedError does not wrap the network error")
+	}
+	if err := c.Delete("/episodes/3", nil); err == nil || errors.As(err, &qErr) || !IsNetworkError(err) {
+		t.Errorf("Delete() error = %v, want the network error", err)
+	}
+
+	if len(queued) != 1 || queued[0].Path != "/episodes/3/messages" || string(queued[0].Body) != `{"text":"hi"}` {
+		t.Errorf("queued = %+v", queued)
+	}
+	if IsNetworkError(&APIError{StatusCode: 500}) {
+		t.Error("IsNetworkError(APIError) = true")
+	}
+
+	// An upload's file is not part of the Write, so it is never queued.
+	audio := filepath.Join(t.TempDir(), "episode.mp3")
+	if err := os.WriteFile(audio, []byte("audio"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	queued = nil
+	err := c.PostFormWithFile("/episodes/3", nil, "media_file", audio, nil)
+	if errors.As(err, &qErr) || !IsNetworkError(err) {
+		t.Errorf("PostFormWithFile() error = %v, want the network error", err)
+	}
+	if len(queued) != 0 {
+		t.Errorf("upload offered to the queue: %+v", queued)
+	}
+}
+
 func TestGetPaginated(t *testing.T) {
 	srv := spreakerServer(t, 200, map[string]interface{}{
 		"items": []map[string]interface{}{
@@ -394,3 +537,147 @@ func TestGetPaginated_NoMore(t *testing.T) {
 		t.Error("HasMore should be false when next_url is empty")
 	}
 }
+
+func TestGetAllPaginated_FollowsNextURL(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		page := map[string]interface{}{
+			"items":    []map[string]int{{"id": 1}, {"id": 2}},
+			"next_url": srv.URL + "/v2/items?page=2",
+		}
+		if r.URL.Query().Get("page") == "2" {
+			page = map[string]interface{}{
+				"items":    []map[string]int{{"id": 3}},
+				"next_url": "",
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": page})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	items, err := GetAllPaginated[item](c, "/items", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[2].ID != 3 {
+		t.Errorf("items = %v, want 3 items ending with id 3", items)
+	}
+
+	limited, err := GetAllPaginated[item](c, "/items", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("got %d items, want 2", len(limited))
+	}
+}
+
+func TestGetAllPaginated_RejectsForeignNextURL(t *testing.T) {
+	srv := spreakerServer(t, 200, map[string]interface{}{
+		"items":    []map[string]int{{"id": 1}},
+		"next_url": "https://evil.example.com/v2/items?page=2",
+	})
+	defer srv.Close()
+
+	c := testClient(t, srv)
+
+	type item struct {
+		ID int `json:"id"`
+	}
+	if _, err := GetAllPaginated[item](c, "/items", nil, 0); err == nil {
+		t.Fatal("expected error for next_url on a different host")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Custom headers
+// ---------------------------------------------------------------------------
+
+func TestCustomHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("X-Tenant = %q, want acme", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "gateway-probe" {
+			t.Errorf("User-Agent = %q, want gateway-probe", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]string{}})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	c.Headers = http.Header{"x-tenant": {"acme"}, "User-Agent": {"gateway-probe"}}
+	var result map[string]string
+	if err := c.Get("/test", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other hosts, such as download CDNs, do not get the headers.
+	req, err := c.newRequest(http.MethodGet, "https://cdn.example.com/a.mp3", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("X-Tenant") != "" {
+		t.Errorf("custom header sent to another host")
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	name, value, err := ParseHeader(" X-Trace-Id :  abc:123 ")
+	if err != nil || name != "X-Trace-Id" || value != "abc:123" {
+		t.Errorf("ParseHeader() = %q, %q, %v", name, value, err)
+	}
+	for _, bad := range []string{"no-colon", ": value", "Bad Name: x", "authorization: Bearer x", "X-Config.Key: x"} {
+		if _, _, err := ParseHeader(bad); err == nil {
+			t.Errorf("ParseHeader(%q) succeeded", bad)
+		}
+	}
+	if err := CheckHeader("X-Tenant", "a\r\nInjected: 1"); err == nil {
+		t.Error("CheckHeader accepted a multi-line value")
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyHeader))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]string{}})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	fixed := c.WithIdempotencyKey("retry-key")
+	for _, call := range []func() error{
+		func() error { return c.PostForm("/test", nil, nil) },
+		func() error { return c.PostForm("/test", nil, nil) },
+		func() error { return fixed.PostForm("/test", nil, nil) },
+		func() error { return fixed.Post("/test", map[string]string{"a": "b"}, nil) },
+		func() error { return c.Get("/test", nil, nil) },
+	} {
+		if err := call(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(keys[0]) != 36 || keys[0] == keys[1] {
+		t.Errorf("POST keys = %q, %q, want distinct UUIDs", keys[0], keys[1])
+	}
+	if keys[2] != "retry-key" || keys[3] != "retry-key" {
+		t.Errorf("WithIdempotencyKey keys = %q, %q", keys[2], keys[3])
+	}
+	if keys[4] != "" {
+		t.Errorf("GET sent idempotency key %q", keys[4])
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// -----------------------------------------------------------------------------
+// API Compatibility
+// -----------------------------------------------------------------------------
+
+// compatProbePath is a small public endpoint used to probe the API.
+const compatProbePath = "/show-languages"
+
+// versionHeaders are response headers that may carry the server's API version.
+var versionHeaders = []string{"X-Api-Version", "Api-Version", "X-Spreaker-Api-Version"}
+
+// APICompatibility is the result of probing the API with the client's
+// pinned version.
+type APICompatibility struct {
+	BaseURL       string `json:"base_url"`
+	PinnedVersion string `json:"pinned_version"`
+
+	// ServerVersion is the API version reported in the response headers,
+	// if the server reports one.
+	ServerVersion string `json:"server_version,omitempty"`
+
+	StatusCode int `json:"status_code"`
+
+	// Deprecation and Sunset are the RFC 8594 headers announcing that the
+	// pinned version is deprecated and when it will be removed.
+	Deprecation string `json:"deprecation,omitempty"`
+	Sunset      string `json:"sunset,omitempty"`
+
+	// Current is true when the pinned paths answer successfully and are
+	// not marked deprecated.
+	Current bool `json:"current"`
+}
+
+// CheckCompatibility probes a public endpoint under the pinned API version
+// (no token needed) and reports what the server says about it.
+func (c *Client) CheckCompatibility() (*APICompatibility, error) {
+	req, err := c.newRequest(http.MethodGet, c.buildURL(compatProbePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("Authorization")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, limitBody(resp.Body))
+
+	compat := &APICompatibility{
+		BaseURL:       c.BaseURL,
+		PinnedVersion: c.APIVersion,
+		StatusCode:    resp.StatusCode,
+		Deprecation:   resp.Header.Get("Deprecation"),
+		Sunset:        resp.Header.Get("Sunset"),
+	}
+	for _, h := range versionHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			compat.ServerVersion = v
+			break
+		}
+	}
+	compat.Current = resp.StatusCode < 300 && compat.Deprecation == "" && compat.Sunset == ""
+	return compat, nil
+}
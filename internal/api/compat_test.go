@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		headers     map[string]string
+		wantCurrent bool
+		wantServer  string
+	}{
+		{"current", 200, nil, true, ""},
+		{"server version header", 200, map[string]string{"X-Api-Version": "2.4"}, true, "2.4"},
+		{"deprecated", 200, map[string]string{"Deprecation": "true"}, false, ""},
+		{"sunset", 200, map[string]string{"Sunset": "Wed, 01 Jul 2026 00:00:00 GMT"}, false, ""},
+		{"gone", 410, nil, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2"+compatProbePath {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "" {
+					t.Error("probe should not send the token")
+				}
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			compat, err := testClient(t, srv).CheckCompatibility()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if compat.Current != tt.wantCurrent {
+				t.Errorf("Current = %v, want %v", compat.Current, tt.wantCurrent)
+			}
+			if compat.ServerVersion != tt.wantServer {
+				t.Errorf("ServerVersion = %q, want %q", compat.ServerVersion, tt.wantServer)
+			}
+			if compat.PinnedVersion != "v2" || compat.StatusCode != tt.status {
+				t.Errorf("compat = %+v", compat)
+			}
+		})
+	}
+}
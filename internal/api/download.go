@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Downloads
+// -----------------------------------------------------------------------------
+
+const (
+	// DownloadTimeout bounds a single download attempt; audio files are
+	// much larger than API responses.
+	DownloadTimeout = 10 * time.Minute
+
+	// downloadAttempts is how often a download is tried before giving up.
+	downloadAttempts = 3
+)
+
+// downloadBackoff is the wait before the second attempt; it doubles after
+// each failure. A variable so tests can shorten it.
+var downloadBackoff = time.Second
+
+// DownloadProgress is called as a download is written, with the bytes
+// written so far and the total size (-1 when the server does not send it).
+type DownloadProgress func(written, total int64)
+
+// DownloadToFile downloads url to path using the client's transport and
+// User-Agent. The bearer token is only sent to the API host, never to media
+// CDNs. Network errors, 429 and 5xx responses are retried with backoff.
+// The file is written to a temporary path and renamed on success, so a
+// failed download never leaves a partial file behind.
+func (c *Client) DownloadToFile(ctx context.Context, rawURL, path string, progress DownloadProgress) error {
+	var err error
+	backoff := downloadBackoff
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		var retry bool
+		retry, err = c.downloadOnce(ctx, rawURL, path, progress)
+		if err == nil || !retry || attempt == downloadAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// downloadOnce makes a single download attempt and reports whether a
+// failure is worth retrying.
+func (c *Client) downloadOnce(ctx context.Context, rawURL, path string, progress DownloadProgress) (bool, error) {
+	req, err := c.newRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Del("Accept")
+	if !c.isAPIHost(req.URL) {
+		req.Header.Del("Authorization")
+	}
+
+	httpClient := &http.Client{Timeout: DownloadTimeout, Transport: c.HTTPClient.Transport}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("failed to download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.part")
+	if err != nil {
+		return false, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	var w io.Writer = tmp
+	if progress != nil {
+		w = &progressWriter{w: tmp, total: resp.ContentLength, progress: progress}
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		tmp.Close()
+		var pathErr *os.PathError
+		// Read errors (dropped connections) are retried, write errors are not.
+		return !errors.As(err, &pathErr), fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+	return false, nil
+}
+
+// MediaInfo describes a media file as its server reports it, without
+// downloading it.
+type MediaInfo struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"` // bytes, 0 when unknown
+}
+
+// GetMediaInfo asks for the type and size of the file at rawURL with a
+// HEAD request. Like downloads, media CDNs never get the bearer token.
+func (c *Client) GetMediaInfo(ctx context.Context, rawURL string) (*MediaInfo, error) {
+	req, err := c.newRequest(http.MethodHead, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Del("Accept")
+	if !c.isAPIHost(req.URL) {
+		req.Header.Del("Authorization")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	info := &MediaInfo{URL: rawURL, ContentType: resp.Header.Get("Content-Type")}
+	if resp.ContentLength > 0 {
+		info.Size = resp.ContentLength
+	}
+	return info, nil
+}
+
+// Bitrate estimates the average bitrate in kbit/s of a file lasting
+// durationMs, or returns 0 when the size or duration is unknown.
+func (m *MediaInfo) Bitrate(durationMs int) int {
+	if m.Size <= 0 || durationMs <= 0 {
+		return 0
+	}
+	return int(m.Size * 8 / int64(durationMs))
+}
+
+// isAPIHost reports whether u points at the configured API host.
+func (c *Client) isAPIHost(u *url.URL) bool {
+	base, err := url.Parse(c.BaseURL)
+	return err == nil && u.Scheme == base.Scheme && u.Host == base.Host
+}
+
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress DownloadProgress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.progress(p.written, p.total)
+	return n, err
+}
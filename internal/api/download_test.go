@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadToFile(t *testing.T) {
+	backoff := downloadBackoff
+	downloadBackoff = 0
+	defer func() { downloadBackoff = backoff }()
+
+	tests := []struct {
+		name         string
+		statuses     []int // per attempt; the last one repeats
+		apiHost      bool
+		wantErr      bool
+		wantAttempts int
+		wantAuth     bool
+	}{
+		{"api host gets token", []int{200}, true, false, 1, true},
+		{"media host gets no token", []int{200}, false, false, 1, false},
+		{"retries server errors", []int{503, 502, 200}, false, false, 3, false},
+		{"retries rate limit", []int{429, 200}, false, false, 2, false},
+		{"gives up after attempts", []int{500}, false, true, downloadAttempts, false},
+		{"does not retry not found", []int{404}, false, true, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			var gotAuth, gotUA string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[len(tt.statuses)-1]
+				if attempts < len(tt.statuses) {
+					status = tt.statuses[attempts]
+				}
+				attempts++
+				gotAuth = r.Header.Get("Authorization")
+				gotUA = r.Header.Get("User-Agent")
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					w.Write([]byte("audio"))
+				}
+			}))
+			defer srv.Close()
+
+			c := NewClient("test-token")
+			if tt.apiHost {
+				c.BaseURL = srv.URL
+			}
+
+			path := filepath.Join(t.TempDir(), "episode.mp3")
+			var written int64
+			err := c.DownloadToFile(context.Background(), srv.URL+"/file.mp3", path, func(n, total int64) {
+				written = n
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if (gotAuth != "") != tt.wantAuth {
+				t.Errorf("Authorization = %q, wantAuth %v", gotAuth, tt.wantAuth)
+			}
+			if gotUA != c.UserAgent {
+				t.Errorf("User-Agent = %q, want %q", gotUA, c.UserAgent)
+			}
+
+			entries, _ := os.ReadDir(filepath.Dir(path))
+			if tt.wantErr {
+				if len(entries) != 0 {
+					t.Errorf("failed download left files behind: %v", entries)
+				}
+				return
+			}
+			if len(entries) != 1 {
+				t.Errorf("want only the downloaded file, got %v", entries)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil || string(data) != "audio" {
+				t.Errorf("file = %q, %v", data, err)
+			}
+			if written != int64(len("audio")) {
+				t.Errorf("progress written = %d, want %d", written, len("audio"))
+			}
+		})
+	}
+}
+
+func TestGetMediaInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("media host got the token")
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("Content-Length", "960000")
+	}))
+	defer srv.Close()
+
+	c := NewClient("token")
+	info, err := c.GetMediaInfo(context.Background(), srv.URL+"/ep.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ContentType != "audio/mpeg" || info.Size != 960000 {
+		t.Errorf("info = %+v", info)
+	}
+	if got := info.Bitrate(60000); got != 128 {
+		t.Errorf("Bitrate() = %d, want 128", got)
+	}
+}
@@ -234,6 +234,49 @@ func (c *Client) UpdateEpisode(episodeID int, params UpdateEpisodeParams) (*mode
 	return &resp.Episode, nil
 }
 
+// UpdateEpisodeImage uploads a new cover image for an episode.
+// API: POST /v2/episodes/{episode_id}
+func (c *Client) UpdateEpisodeImage(episodeID int, imageFile string) (*models.Episode, error) {
+	if err := c.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	if imageFile == "" {
+		return nil, fmt.Errorf("image_file is required")
+	}
+
+	path := fmt.Sprintf("/episodes/%d", episodeID)
+
+	var resp models.EpisodeResponse
+	if err := c.PostFormWithFile(path, map[string]string{}, "image_file", imageFile, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Episode, nil
+}
+
+// ReplaceEpisodeAudio uploads a new audio file for an existing episode,
+// which keeps its ID, URLs and statistics.
+// API: POST /v2/episodes/{episode_id}
+func (c *Client) ReplaceEpisodeAudio(episodeID int, mediaFile string) (*models.Episode, error) {
+	if err := c.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	if mediaFile == "" {
+		return nil, fmt.Errorf("media_file is required")
+	}
+
+	path := fmt.Sprintf("/episodes/%d", episodeID)
+
+	var resp models.EpisodeResponse
+	if err := c.PostFormWithFile(path, map[string]string{}, "media_file", mediaFile, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Episode, nil
+}
+
 // DeleteEpisode deletes an episode.
 // API: DELETE /v2/episodes/{episode_id}
 func (c *Client) DeleteEpisode(episodeID int) error {
@@ -245,6 +288,14 @@ func (c *Client) DeleteEpisode(episodeID int) error {
 	return c.Delete(path, nil)
 }
 
+// EpisodeActionResult is the outcome of a like, bookmark or similar action
+// on one of several episodes. Status is "done" or "failed".
+type EpisodeActionResult struct {
+	EpisodeID int    `json:"episode_id"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+}
+
 // LikeEpisode adds an episode to the user's likes.
 // API: PUT /v2/users/{user_id}/likes/{episode_id}
 func (c *Client) LikeEpisode(userID, episodeID int) error {
@@ -292,6 +343,13 @@ func (c *Client) GetEpisodeLikes(episodeID int, pagination PaginationParams) (*P
 	return GetPaginated[models.User](c, path, pagination.ToMap())
 }
 
+// GetAllEpisodeLikes retrieves every user who liked an episode, following pagination.
+// API: GET /v2/episodes/{episode_id}/likes
+func (c *Client) GetAllEpisodeLikes(episodeID int) ([]models.User, error) {
+	path := fmt.Sprintf("/episodes/%d/likes", episodeID)
+	return GetAllPaginated[models.User](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
+
 // GetLikedEpisodes retrieves the user's liked episodes.
 // API: GET /v2/users/{user_id}/likes
 func (c *Client) GetLikedEpisodes(userID int, pagination PaginationParams) (*PaginatedResult[models.Episode], error) {
@@ -335,6 +393,7 @@ func (c *Client) GetEpisodeDownloadURL(episodeID int) (string, error) {
             return http.ErrUseLastResponse 
         },
         Timeout: c.HTTPClient.Timeout,
+        Transport: c.HTTPClient.Transport,
     }
 
     req, err := c.newRequest(http.MethodGet, urlStr, nil)
@@ -412,4 +471,33 @@ func (c *Client) GetUserEpisodes(userID int, pagination PaginationParams) (*Pagi
 func (c *Client) GetShowEpisodes(showID int, pagination PaginationParams) (*PaginatedResult[models.Episode], error) {
 	path := fmt.Sprintf("/shows/%d/episodes", showID)
 	return GetPaginated[models.Episode](c, path, pagination.ToMap())
-}
\ No newline at end of file
+}
+
+// GetAllShowEpisodes retrieves every episode of a show, following pagination.
+// API: GET /v2/shows/{show_id}/episodes
+func (c *Client) GetAllShowEpisodes(showID int) ([]models.Episode, error) {
+	path := fmt.Sprintf("/shows/%d/episodes", showID)
+	return GetAllPaginated[models.Episode](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
+
+// GetAllShowEditableEpisodes retrieves every episode of a show the user can
+// edit, including drafts and scheduled episodes, following pagination.
+// API: GET /v2/shows/{show_id}/episodes?filter=editable
+func (c *Client) GetAllShowEditableEpisodes(showID int) ([]models.Episode, error) {
+	path := fmt.Sprintf("/shows/%d/episodes", showID)
+	params := PaginationParams{Limit: MaxPageLimit}.ToMap()
+	params["filter"] = "editable"
+	return GetAllPaginated[models.Episode](c, path, params, 0)
+}
+
+// GetShowEpisodesUpTo retrieves up to limit episodes of a show (0 = all),
+// following pagination.
+// API: GET /v2/shows/{show_id}/episodes
+func (c *Client) GetShowEpisodesUpTo(showID, limit int) ([]models.Episode, error) {
+	path := fmt.Sprintf("/shows/%d/episodes", showID)
+	pageLimit := MaxPageLimit
+	if limit > 0 && limit < pageLimit {
+		pageLimit = limit
+	}
+	return GetAllPaginated[models.Episode](c, path, PaginationParams{Limit: pageLimit}.ToMap(), limit)
+}
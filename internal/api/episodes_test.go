@@ -1,6 +1,12 @@
 package api
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -93,3 +99,79 @@ func TestCreateDraftEpisode_Validation(t *testing.T) {
 		}
 	})
 }
+
+// ---------------------------------------------------------------------------
+// UpdateEpisodeImage
+// ---------------------------------------------------------------------------
+
+func TestUpdateEpisodeImage(t *testing.T) {
+	t.Run("missing image", func(t *testing.T) {
+		c := NewClient("tok")
+		_, err := c.UpdateEpisodeImage(1, "")
+		if err == nil {
+			t.Fatal("expected image_file error")
+		}
+	})
+
+	t.Run("uploads image", func(t *testing.T) {
+		srv := spreakerServer(t, 200, map[string]interface{}{
+			"episode": map[string]interface{}{"episode_id": 7, "title": "T"},
+		})
+		defer srv.Close()
+
+		img := filepath.Join(t.TempDir(), "cover.jpg")
+		if err := os.WriteFile(img, []byte("jpeg"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		episode, err := testClient(t, srv).UpdateEpisodeImage(7, img)
+		if err != nil {
+			t.Fatalf("UpdateEpisodeImage() error = %v", err)
+		}
+		if episode.EpisodeID != 7 {
+			t.Errorf("EpisodeID = %d, want 7", episode.EpisodeID)
+		}
+	})
+}
+
+func TestReplaceEpisodeAudio(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		c := NewClient("tok")
+		if _, err := c.ReplaceEpisodeAudio(1, ""); err == nil {
+			t.Fatal("expected media_file error")
+		}
+	})
+
+	t.Run("uploads audio", func(t *testing.T) {
+		var method, path, file, content string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			if f, header, err := r.FormFile("media_file"); err == nil {
+				data, _ := io.ReadAll(f)
+				file, content = header.Filename, string(data)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"response":{"episode":{"episode_id":7,"title":"T"}}}`)
+		}))
+		defer srv.Close()
+
+		audio := filepath.Join(t.TempDir(), "fixed.mp3")
+		if err := os.WriteFile(audio, []byte("new audio"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		episode, err := testClient(t, srv).ReplaceEpisodeAudio(7, audio)
+		if err != nil {
+			t.Fatalf("ReplaceEpisodeAudio() error = %v", err)
+		}
+		if episode.EpisodeID != 7 {
+			t.Errorf("EpisodeID = %d, want 7", episode.EpisodeID)
+		}
+		if method != http.MethodPost || path != "/v2/episodes/7" {
+			t.Errorf("request = %s %s, want POST /v2/episodes/7", method, path)
+		}
+		if file != "fixed.mp3" || content != "new audio" {
+			t.Errorf("media_file = %q with %q, want fixed.mp3 with the new audio", file, content)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// reservedHeaders are set by the client itself and cannot be replaced by
+// custom headers.
+var reservedHeaders = []string{"Authorization", "Content-Length", "Content-Type", "Host", IdempotencyHeader}
+
+// ParseHeader parses a custom header given as "Name: value".
+func ParseHeader(s string) (name, value string, err error) {
+	name, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid header %q (expected Name: value)", s)
+	}
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if err := CheckHeader(name, value); err != nil {
+		return "", "", err
+	}
+	return name, value, nil
+}
+
+// CheckHeader reports whether name and value can be sent as a custom
+// header: name must be a valid header name the client does not set itself,
+// and value must fit on one line.
+func CheckHeader(name, value string) error {
+	if name == "" || strings.IndexFunc(name, func(r rune) bool { return !isTokenChar(r) }) >= 0 {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	for _, h := range reservedHeaders {
+		if strings.EqualFold(name, h) {
+			return fmt.Errorf("header %s cannot be set: the client sets it", h)
+		}
+	}
+	if strings.ContainsAny(value, "\r\n\x00") {
+		return fmt.Errorf("invalid value for header %s: must be a single line", name)
+	}
+	return nil
+}
+
+// isTokenChar reports whether r may appear in a header name (RFC 9110
+// token). Dots are excluded as well, as config keys are dotted.
+func isTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-^_`|~", r)
+}
+
+// setHeaders adds the client's custom headers to req.
+func (c *Client) setHeaders(req *http.Request) {
+	for name, values := range c.Headers {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+}
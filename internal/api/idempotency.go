@@ -0,0 +1,44 @@
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyHeader carries a key that identifies a POST request across
+// retries, so a server that honors it creates the resource only once.
+// Spreaker does not document the header; against the API it is harmless,
+// and gateways that deduplicate requests rely on it.
+const IdempotencyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random key in UUID form.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// WithIdempotencyKey returns a copy of the client whose POST requests all
+// carry key instead of a fresh key each, so that retrying a request after
+// a timeout, or sending a queued request, reuses the key of the first try.
+func (c *Client) WithIdempotencyKey(key string) *Client {
+	cc := *c
+	cc.idempotencyKey = key
+	return &cc
+}
+
+// setIdempotencyKey gives a POST request its idempotency key. The key
+// stays on the request, so rate-limited resends carry it too.
+func (c *Client) setIdempotencyKey(req *http.Request) {
+	if req.Method != http.MethodPost {
+		return
+	}
+	key := c.idempotencyKey
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	req.Header.Set(IdempotencyHeader, key)
+}
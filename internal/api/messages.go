@@ -17,6 +17,43 @@ func (c *Client) GetEpisodeMessages(episodeID int, pagination PaginationParams)
 	return GetPaginated[models.Message](c, path, pagination.ToMap())
 }
 
+// GetAllEpisodeMessages retrieves every message of an episode, following pagination.
+// API: GET /v2/episodes/{episode_id}/messages
+func (c *Client) GetAllEpisodeMessages(episodeID int) ([]models.Message, error) {
+	path := fmt.Sprintf("/episodes/%d/messages", episodeID)
+	return GetAllPaginated[models.Message](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
+
+// GetEpisodeMessage retrieves a single message of an episode.
+// The API has no single-message endpoint, so this pages through the
+// episode's messages (newest first) until the message is found.
+// API: GET /v2/episodes/{episode_id}/messages
+func (c *Client) GetEpisodeMessage(episodeID, messageID int) (*models.Message, error) {
+	path := fmt.Sprintf("/episodes/%d/messages", episodeID)
+	page, err := GetPaginated[models.Message](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap())
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		for i := range page.Items {
+			if page.Items[i].MessageID == messageID {
+				return &page.Items[i], nil
+			}
+		}
+		if !page.HasMore {
+			return nil, fmt.Errorf("message %d not found on episode %d", messageID, episodeID)
+		}
+		if err := c.checkNextURL(page.NextURL); err != nil {
+			return nil, err
+		}
+		page, err = getPaginatedURL[models.Message](c, page.NextURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 // CreateMessage leaves a new message on an episode.
 // API: POST /v2/episodes/{episode_id}/messages
 // Parameters:
@@ -56,6 +93,7 @@ func (c *Client) DeleteMessage(episodeID, messageID int) error {
 }
 
 // ReportMessageAbuse reports a message as spam or as violating Spreaker's terms.
+// The endpoint takes no parameters and returns an empty response.
 // API: POST /v2/episodes/{episode_id}/messages/{message_id}/report-abuse
 func (c *Client) ReportMessageAbuse(episodeID, messageID int) error {
 	if err := c.CheckAuth(); err != nil {
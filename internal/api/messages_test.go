@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ---------------------------------------------------------------------------
+// GetEpisodeMessage
+// ---------------------------------------------------------------------------
+
+func TestGetEpisodeMessage(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/episodes/7/messages" {
+			t.Errorf("path = %s, want /v2/episodes/7/messages", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		page := map[string]interface{}{
+			"items":    []map[string]interface{}{{"message_id": 1, "text": "first"}},
+			"next_url": srv.URL + "/v2/episodes/7/messages?page=2",
+		}
+		if r.URL.Query().Get("page") == "2" {
+			page = map[string]interface{}{
+				"items":    []map[string]interface{}{{"message_id": 2, "text": "second"}},
+				"next_url": "",
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": page})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+
+	t.Run("found on later page", func(t *testing.T) {
+		m, err := c.GetEpisodeMessage(7, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Text != "second" {
+			t.Errorf("Text = %q, want %q", m.Text, "second")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := c.GetEpisodeMessage(7, 99); err == nil {
+			t.Fatal("expected error for missing message")
+		}
+	})
+}
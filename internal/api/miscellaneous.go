@@ -11,12 +11,10 @@ import (
 // GetShowCategories retrieves all available show categories.
 // API: GET /v2/show-categories
 // Parameters:
-//   - locale: Optional locale for category names (e.g., "it_IT" for Italian)
+//   - locale: Optional locale for category names (e.g., "it_IT" for Italian);
+//     empty uses the client's Locale
 func (c *Client) GetShowCategories(locale string) ([]models.Category, error) {
-	params := make(map[string]string)
-	if locale != "" {
-		params["c"] = locale
-	}
+	params := c.localeParams(locale)
 
 	var resp models.CategoriesResponse
 	if err := c.Get("/show-categories", params, &resp); err != nil {
@@ -44,12 +42,10 @@ func (c *Client) GetGooglePlayCategories() ([]models.GooglePlayCategory, error)
 // GetShowLanguages retrieves all available show languages.
 // API: GET /v2/show-languages
 // Parameters:
-//   - locale: Optional locale for language names (e.g., "it_IT" for Italian)
+//   - locale: Optional locale for language names (e.g., "it_IT" for Italian);
+//     empty uses the client's Locale
 func (c *Client) GetShowLanguages(locale string) (map[string]string, error) {
-	params := make(map[string]string)
-	if locale != "" {
-		params["c"] = locale
-	}
+	params := c.localeParams(locale)
 
 	var resp models.LanguagesResponse
 	if err := c.Get("/show-languages", params, &resp); err != nil {
@@ -59,6 +55,19 @@ func (c *Client) GetShowLanguages(locale string) (map[string]string, error) {
 	return resp.Languages, nil
 }
 
+// localeParams returns the query parameters selecting locale, or the
+// client's Locale when locale is empty, for endpoints with localized names.
+func (c *Client) localeParams(locale string) map[string]string {
+	params := make(map[string]string)
+	if locale == "" {
+		locale = c.Locale
+	}
+	if locale != "" {
+		params["c"] = locale
+	}
+	return params
+}
+
 // GetShowLanguagesList is a convenience method that returns languages as a slice
 func (c *Client) GetShowLanguagesList(locale string) ([]models.Language, error) {
 	langMap, err := c.GetShowLanguages(locale)
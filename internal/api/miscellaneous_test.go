@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetShowCategories_Locale(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Get("c"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{"categories": []interface{}{}}})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	c.GetShowCategories("")
+	c.Locale = "it_IT"
+	c.GetShowCategories("")
+	c.GetShowCategories("es_ES")
+
+	want := []string{"", "it_IT", "es_ES"}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d locale = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
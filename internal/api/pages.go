@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Pagination Policy
+// -----------------------------------------------------------------------------
+
+// pageAttempts is how often a page is requested before it counts as failed.
+const pageAttempts = 3
+
+// pageBackoff is the wait before the second attempt of a page; it doubles
+// after each failure. A variable so tests can shorten it.
+var pageBackoff = time.Second
+
+// PagePolicy controls how GetAllPaginated reacts to a page that fails.
+//
+// By default a failed page is retried; if it still fails, the loop stops and
+// returns the items collected so far together with a *PartialError, so a
+// single bad page no longer discards every page before it.
+type PagePolicy struct {
+	// FailFast restores the old behavior: the first failed request aborts
+	// the loop and no items are returned.
+	FailFast bool
+
+	// StateFile, if set, persists the cursor of a loop when one of its
+	// pages fails. A later run with the same file resumes at the page that
+	// failed instead of starting over. The cursor is removed once a loop
+	// completes or stops at its limit, so a rerun starts from the top.
+	StateFile string
+}
+
+// PageFailure describes a page that could not be fetched.
+type PageFailure struct {
+	Endpoint string `json:"endpoint"`
+	Page     int    `json:"page"`
+	Error    string `json:"error"`
+}
+
+// PartialError is returned with the items that were fetched when a
+// multi-page loop could not be completed, or was resumed from a state file
+// so the items of the pages fetched by an earlier run are missing.
+type PartialError struct {
+	Items    int
+	Failures []PageFailure
+	Err      error
+
+	// StateFile is where the loop can be resumed from, if any.
+	StateFile string
+
+	// Resumed is the number of pages an earlier run fetched, whose items
+	// are not included because the loop resumed after them.
+	Resumed int
+}
+
+func (e *PartialError) Error() string {
+	if len(e.Failures) == 0 {
+		return fmt.Sprintf("results are incomplete: resumed after page %d, so the items of the pages fetched by an earlier run are not included", e.Resumed)
+	}
+	msg := fmt.Sprintf("results are incomplete: page %d of %s failed after %d item(s): %v",
+		e.Failures[0].Page, e.Failures[0].Endpoint, e.Items, e.Err)
+	if e.Resumed > 0 {
+		msg += fmt.Sprintf("; resumed after page %d, earlier pages are not included", e.Resumed)
+	}
+	if e.StateFile != "" {
+		msg += fmt.Sprintf(" (run again with --page-state %s to resume)", e.StateFile)
+	}
+	return msg
+}
+
+func (e *PartialError) Unwrap() error { return e.Err }
+
+// PageCursor is the persisted position of a multi-page loop.
+type PageCursor struct {
+	NextURL   string    `json:"next_url"`
+	Pages     int       `json:"pages"`
+	Items     int       `json:"items"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// pageState is the content of a state file: cursors keyed by the loop's
+// endpoint, so one file can serve every loop of a command.
+type pageState struct {
+	Cursors map[string]PageCursor `json:"cursors"`
+}
+
+// stateMu serializes state file updates; loops may run concurrently.
+var stateMu sync.Mutex
+
+// collectPages follows next_url links from firstURL under the client's
+// PagePolicy until every page has been read or limit items were collected.
+func collectPages[T any](c *Client, firstURL string, limit int) ([]T, error) {
+	policy := c.Pages
+	key, err := stateKey(firstURL)
+	if err != nil {
+		return nil, err
+	}
+
+	next := firstURL
+	pages, resumed := 0, 0
+	var items []T
+	if policy.StateFile != "" {
+		cursor, ok, err := loadCursor(policy.StateFile, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok && cursor.NextURL != "" {
+			next, pages, resumed = cursor.NextURL, cursor.Pages, cursor.Pages
+		}
+	}
+
+	for next != "" && (limit == 0 || len(items) < limit) {
+		if next != firstURL {
+			if err := c.checkNextURL(next); err != nil {
+				return nil, err
+			}
+		}
+
+		page, err := fetchPage[T](c, next)
+		if err != nil {
+			if policy.FailFast {
+				return nil, err
+			}
+			partial := &PartialError{
+				Items:    len(items),
+				Failures: []PageFailure{{Endpoint: key, Page: pages + 1, Error: err.Error()}},
+				Err:      err,
+				Resumed:  resumed,
+			}
+			if policy.StateFile != "" {
+				if serr := saveCursor(policy.StateFile, key, &PageCursor{NextURL: next, Pages: pages, Items: len(items)}); serr == nil {
+					partial.StateFile = policy.StateFile
+				}
+			}
+			return truncate(items, limit), partial
+		}
+
+		items = append(items, page.Items...)
+		pages++
+		next = page.NextURL
+	}
+
+	// Completed, or stopped at limit: the next run starts from the top.
+	if policy.StateFile != "" {
+		if err := saveCursor(policy.StateFile, key, nil); err != nil {
+			return nil, err
+		}
+	}
+	if resumed > 0 {
+		return truncate(items, limit), &PartialError{Items: len(items), Resumed: resumed}
+	}
+	return truncate(items, limit), nil
+}
+
+// fetchPage requests one page, retrying network errors, rate limits and
+// server errors unless the policy is fail-fast.
+func fetchPage[T any](c *Client, pageURL string) (*PaginatedResult[T], error) {
+	attempts := pageAttempts
+	if c.Pages.FailFast {
+		attempts = 1
+	}
+
+	backoff := pageBackoff
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var page *PaginatedResult[T]
+		if page, err = getPaginatedURL[T](c, pageURL); err == nil {
+			return page, nil
+		}
+		if !retryablePageError(err) || attempt == attempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-c.context().Done():
+			return nil, c.context().Err()
+		}
+		backoff *= 2
+	}
+	return nil, err
+}
+
+// retryablePageError reports whether a failed page may succeed if requested
+// again: API errors other than 429 and 5xx are permanent.
+func retryablePageError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return !errors.Is(err, ErrResponseTooLarge)
+}
+
+func truncate[T any](items []T, limit int) []T {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
+
+// stateKey identifies a loop by its first page, without scheme and host,
+// e.g. "/v2/shows/1/episodes?limit=100".
+func stateKey(firstURL string) (string, error) {
+	u, err := url.Parse(firstURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	return u.RequestURI(), nil
+}
+
+func readState(path string) (*pageState, error) {
+	state := &pageState{Cursors: make(map[string]PageCursor)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read page state: %w", err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid page state %s: %w", path, err)
+	}
+	if state.Cursors == nil {
+		state.Cursors = make(map[string]PageCursor)
+	}
+	return state, nil
+}
+
+func loadCursor(path, key string) (PageCursor, bool, error) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	state, err := readState(path)
+	if err != nil {
+		return PageCursor{}, false, err
+	}
+	cursor, ok := state.Cursors[key]
+	return cursor, ok, nil
+}
+
+// saveCursor stores the cursor of a loop, or removes it when cursor is nil.
+// The file itself is removed once no loop is left to resume.
+func saveCursor(path, key string, cursor *PageCursor) error {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	state, err := readState(path)
+	if err != nil {
+		return err
+	}
+	if cursor == nil {
+		if _, ok := state.Cursors[key]; !ok {
+			return nil
+		}
+		delete(state.Cursors, key)
+	} else {
+		cursor.UpdatedAt = time.Now().UTC()
+		state.Cursors[key] = *cursor
+	}
+
+	if len(state.Cursors) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot remove page state: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("cannot write page state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
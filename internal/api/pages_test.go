@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// pagedServer serves three pages of one item each at /v2/items?page=N.
+// fail maps a page number to the statuses returned for its attempts; the
+// last status repeats.
+func pagedServer(t *testing.T, fail map[int][]int) (*httptest.Server, map[int]int) {
+	t.Helper()
+	requests := make(map[int]int)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		attempt := requests[page]
+		requests[page]++
+
+		if statuses, ok := fail[page]; ok {
+			status := statuses[len(statuses)-1]
+			if attempt < len(statuses) {
+				status = statuses[attempt]
+			}
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+		}
+
+		next := ""
+		if page < 3 {
+			next = fmt.Sprintf("%s/v2/items?page=%d", srv.URL, page+1)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": map[string]interface{}{
+				"items":    []map[string]int{{"id": page}},
+				"next_url": next,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+type pagedItem struct {
+	ID int `json:"id"`
+}
+
+func TestGetAllPaginated_Policy(t *testing.T) {
+	backoff := pageBackoff
+	pageBackoff = 0
+	defer func() { pageBackoff = backoff }()
+
+	tests := []struct {
+		name        string
+		fail        map[int][]int
+		failFast    bool
+		wantItems   int
+		wantPartial bool
+		wantErr     bool
+		wantTries   int // requests for page 2
+	}{
+		{"all pages", nil, false, 3, false, false, 1},
+		{"retries server error", map[int][]int{2: {503, 200}}, false, 3, false, false, 2},
+		{"keeps pages before failure", map[int][]int{2: {500}}, false, 1, true, true, pageAttempts},
+		{"no retry on not found", map[int][]int{2: {404}}, false, 1, true, true, 1},
+		{"fail fast", map[int][]int{2: {503, 200}}, true, 0, false, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := pagedServer(t, tt.fail)
+			c := testClient(t, srv)
+			c.Pages.FailFast = tt.failFast
+
+			items, err := GetAllPaginated[pagedItem](c, "/items", nil, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var partial *PartialError
+			if errors.As(err, &partial) != tt.wantPartial {
+				t.Errorf("err = %v, want partial %v", err, tt.wantPartial)
+			}
+			if partial != nil && (partial.Failures[0].Page != 2 || partial.Items != 1) {
+				t.Errorf("failure = %+v after %d item(s), want page 2 after 1", partial.Failures[0], partial.Items)
+			}
+			if len(items) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(items), tt.wantItems)
+			}
+			if requests[2] != tt.wantTries {
+				t.Errorf("page 2 requested %d time(s), want %d", requests[2], tt.wantTries)
+			}
+		})
+	}
+}
+
+func TestGetAllPaginated_ResumesFromStateFile(t *testing.T) {
+	backoff := pageBackoff
+	pageBackoff = 0
+	defer func() { pageBackoff = backoff }()
+
+	state := filepath.Join(t.TempDir(), "pages.json")
+
+	srv, _ := pagedServer(t, map[int][]int{3: {500}})
+	c := testClient(t, srv)
+	c.Pages.StateFile = state
+
+	items, err := GetAllPaginated[pagedItem](c, "/items", nil, 0)
+	var partial *PartialError
+	if !errors.As(err, &partial) || len(items) != 2 {
+		t.Fatalf("first run: %d items, err = %v; want 2 items and a partial error", len(items), err)
+	}
+	if partial.StateFile != state {
+		t.Errorf("StateFile = %q, want %q", partial.StateFile, state)
+	}
+
+	// The server recovers: the second run starts at the failed page.
+	srv2, requests := pagedServer(t, nil)
+	c2 := testClient(t, srv2)
+	c2.Pages.StateFile = state
+	rewriteState(t, state, srv.URL, srv2.URL)
+
+	items, err = GetAllPaginated[pagedItem](c2, "/items", nil, 0)
+	if !errors.As(err, &partial) || partial.Resumed != 2 || len(partial.Failures) != 0 {
+		t.Fatalf("second run: err = %v, want a partial error for resuming after page 2", err)
+	}
+	if len(items) != 1 || items[0].ID != 3 {
+		t.Errorf("second run items = %+v, want only page 3", items)
+	}
+	if requests[1] != 0 || requests[2] != 0 {
+		t.Errorf("second run refetched pages 1-2: %v", requests)
+	}
+	if _, err := os.Stat(state); !os.IsNotExist(err) {
+		t.Errorf("state file not removed after completion: %v", err)
+	}
+}
+
+func TestGetAllPaginated_LimitForgetsCursor(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "pages.json")
+	srv, requests := pagedServer(t, nil)
+	c := testClient(t, srv)
+	c.Pages.StateFile = state
+
+	// Two runs stopped by a limit both start at the first page.
+	for run := 1; run <= 2; run++ {
+		items, err := GetAllPaginated[pagedItem](c, "/items", nil, 1)
+		if err != nil || len(items) != 1 || items[0].ID != 1 {
+			t.Fatalf("run %d: items = %+v, err = %v; want page 1", run, items, err)
+		}
+	}
+	if requests[1] != 2 || requests[2] != 0 {
+		t.Errorf("requests = %v, want page 1 twice", requests)
+	}
+	if _, err := os.Stat(state); !os.IsNotExist(err) {
+		t.Errorf("state file written for a listing stopped at its limit: %v", err)
+	}
+}
+
+func TestGetAllPaginated_CancelStopsRetry(t *testing.T) {
+	backoff := pageBackoff
+	pageBackoff = time.Hour
+	defer func() { pageBackoff = backoff }()
+
+	srv, _ := pagedServer(t, map[int][]int{1: {503}})
+	c := testClient(t, srv)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Context = ctx
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := GetAllPaginated[pagedItem](c, "/items", nil, 0)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry wait ignored the cancelled context")
+	}
+}
+
+// rewriteState points cursors recorded against one test server at another.
+func rewriteState(t *testing.T, path, from, to string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state pageState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	for k, cursor := range state.Cursors {
+		cursor.NextURL = to + cursor.NextURL[len(from):]
+		state.Cursors[k] = cursor
+	}
+	data, _ = json.Marshal(state)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+// Concurrent Prefetch
+// -----------------------------------------------------------------------------
+
+// DefaultConcurrency is how many per-episode requests reports run at once.
+// It keeps reports over shows with hundreds of episodes fast without
+// tripping the API's rate limits.
+const DefaultConcurrency = 8
+
+// FetchAll calls fetch for every key with at most workers calls in flight
+// and returns the results in the order of keys, whatever order the calls
+// complete in. After a failure no new calls are started; once the running
+// ones finish, the error of the earliest failed key is returned, so the
+// reported error does not depend on scheduling.
+func FetchAll[K, V any](ctx context.Context, keys []K, workers int, fetch func(K) (V, error)) ([]V, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// stop is cancelled by the first failure, or with the caller's context.
+	stop, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, key := range keys {
+		if !acquire(stop, sem) {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, key K) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := fetch(key)
+			if err != nil {
+				errs[i] = err
+				cancel()
+				return
+			}
+			results[i] = v
+		}(i, key)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// acquire takes a worker slot, or reports false once ctx is done.
+func acquire(ctx context.Context, sem chan struct{}) bool {
+	select {
+	case sem <- struct{}{}:
+		if ctx.Err() != nil {
+			<-sem
+			return false
+		}
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
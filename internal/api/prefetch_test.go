@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchAll_OrderAndBound(t *testing.T) {
+	keys := make([]int, 50)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	var running, peak int32
+	got, err := FetchAll(context.Background(), keys, 4, func(k int) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		// Later keys finish first.
+		time.Sleep(time.Duration(50-k) * 50 * time.Microsecond)
+		atomic.AddInt32(&running, -1)
+		return fmt.Sprint(k), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range got {
+		if v != fmt.Sprint(i) {
+			t.Fatalf("result %d = %q, want results in key order", i, v)
+		}
+	}
+	if peak > 4 {
+		t.Errorf("%d calls in flight, want at most 4", peak)
+	}
+}
+
+func TestFetchAll_Errors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+
+	var calls int32
+	_, err := FetchAll(context.Background(), []int{0, 1, 2, 3, 4, 5, 6, 7}, 2, func(k int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		switch k {
+		case 1:
+			time.Sleep(5 * time.Millisecond)
+			return 0, errA
+		case 2:
+			return 0, errB
+		}
+		return k, nil
+	})
+	if !errors.Is(err, errA) {
+		t.Errorf("err = %v, want the error of the earliest failed key", err)
+	}
+	if calls >= 8 {
+		t.Errorf("%d calls made, want no new calls after a failure", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := FetchAll(ctx, []int{1, 2}, 2, func(k int) (int, error) { return k, nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled context: err = %v, want context.Canceled", err)
+	}
+}
+
+// BenchmarkFetchAll compares sequential and concurrent per-episode requests
+// with 5ms of simulated API latency, for a show with 100 episodes.
+func BenchmarkFetchAll(b *testing.B) {
+	keys := make([]int, 100)
+	fetch := func(k int) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return k, nil
+	}
+
+	for _, workers := range []int{1, DefaultConcurrency} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := FetchAll(context.Background(), keys, workers, fetch); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// -----------------------------------------------------------------------------
+// HTTP Dump and Replay
+// -----------------------------------------------------------------------------
+
+// Redacted replaces secrets in recorded exchanges.
+const Redacted = "REDACTED"
+
+// maxRecordedBody caps how much of a body is written to a dump, so that
+// uploads and audio downloads do not end up in bug reports.
+const maxRecordedBody = 1 << 20
+
+// sensitiveHeaders are never written to a dump.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// Exchange is one recorded request/response pair.
+type Exchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized request of an exchange.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized response of an exchange. Bodies that
+// are not valid UTF-8 are stored base64-encoded; Truncated bodies were
+// larger than the dump limit and cannot be replayed in full.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Base64     bool        `json:"base64,omitempty"`
+	Truncated  bool        `json:"truncated,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that writes every exchange to a
+// directory as numbered JSON files (0001.json, 0002.json, ...), with the
+// token and credential headers redacted. Headers names further request
+// headers to redact, such as custom headers a gateway authenticates with.
+type Recorder struct {
+	Base    http.RoundTripper
+	Dir     string
+	Token   string
+	Headers []string
+
+	mu  sync.Mutex
+	seq int
+	err error
+}
+
+// NewRecorder creates a recorder that wraps base and writes to dir, which
+// is created if needed.
+func NewRecorder(base http.RoundTripper, dir, token string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("cannot create dump directory: %w", err)
+	}
+	return &Recorder{Base: base, Dir: dir, Token: token}, nil
+}
+
+// Err returns the first error that occurred writing the dump, if any.
+// Recording errors never fail the request itself.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.seq++
+	seq := r.seq
+	r.mu.Unlock()
+
+	ex := Exchange{Request: RecordedRequest{
+		Method: req.Method,
+		URL:    r.redact(req.URL.String()),
+		Header: r.sanitizeHeader(req.Header),
+	}}
+
+	// Small request bodies are buffered so they can be both sent and
+	// recorded; large ones (file uploads) are only noted.
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.ContentLength >= 0 && req.ContentLength <= maxRecordedBody {
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			ex.Request.Body = r.redact(string(body))
+		} else {
+			ex.Request.Body = fmt.Sprintf("[%d bytes omitted]", req.ContentLength)
+		}
+	}
+
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ex.Response.StatusCode = resp.StatusCode
+	ex.Response.Header = r.sanitizeHeader(resp.Header)
+
+	// The response is streamed to the caller and recorded as it is read,
+	// so large downloads are not held in memory.
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		onClose: func(body []byte, truncated bool) {
+			r.write(seq, ex, body, truncated)
+		},
+	}
+	return resp, nil
+}
+
+func (r *Recorder) write(seq int, ex Exchange, body []byte, truncated bool) {
+	ex.Response.Truncated = truncated
+	if utf8.Valid(body) {
+		ex.Response.Body = r.redact(string(body))
+	} else {
+		ex.Response.Body = base64.StdEncoding.EncodeToString(body)
+		ex.Response.Base64 = true
+	}
+
+	data, err := json.MarshalIndent(ex, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(r.Dir, fmt.Sprintf("%04d.json", seq)), append(data, '\n'), 0600)
+	}
+	if err != nil {
+		r.mu.Lock()
+		if r.err == nil {
+			r.err = fmt.Errorf("failed to write HTTP dump: %w", err)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *Recorder) sanitizeHeader(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, v := range h {
+		out[k] = append([]string(nil), v...)
+	}
+	for _, k := range slices.Concat(sensitiveHeaders, r.Headers) {
+		if out.Get(k) != "" {
+			out.Set(k, Redacted)
+		}
+	}
+	return out
+}
+
+func (r *Recorder) redact(s string) string {
+	if r.Token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, r.Token, Redacted)
+}
+
+// recordingBody captures up to maxRecordedBody bytes of a response body
+// while it is read and hands them over once the body is closed.
+type recordingBody struct {
+	io.ReadCloser
+	buf       bytes.Buffer
+	truncated bool
+	onClose   func(body []byte, truncated bool)
+	once      sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if room := maxRecordedBody - b.buf.Len(); room >= n {
+			b.buf.Write(p[:n])
+		} else {
+			b.buf.Write(p[:room])
+			b.truncated = true
+		}
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.buf.Bytes(), b.truncated) })
+	return err
+}
+
+// Replayer is an http.RoundTripper that answers requests from a directory
+// written by Recorder instead of the network. Requests are matched on
+// method, path and query, ignoring the host; repeated requests are answered
+// with the recorded responses in order, and the last one once they run out.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges map[string][]Exchange
+}
+
+// NewReplayer loads the exchanges recorded in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "[0-9][0-9][0-9][0-9]*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recorded responses in %s", dir)
+	}
+	sort.Strings(files)
+
+	r := &Replayer{exchanges: make(map[string][]Exchange)}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var ex Exchange
+		if err := json.Unmarshal(data, &ex); err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", filepath.Base(file), err)
+		}
+		key, err := replayKey(ex.Request.Method, ex.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recording %s: %w", filepath.Base(file), err)
+		}
+		r.exchanges[key] = append(r.exchanges[key], ex)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key, err := replayKey(req.Method, req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	queue := r.exchanges[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+	ex := queue[0]
+	if len(queue) > 1 {
+		r.exchanges[key] = queue[1:]
+	}
+	r.mu.Unlock()
+
+	body := []byte(ex.Response.Body)
+	if ex.Response.Base64 {
+		if body, err = base64.StdEncoding.DecodeString(ex.Response.Body); err != nil {
+			return nil, fmt.Errorf("invalid recorded body for %s: %w", key, err)
+		}
+	}
+
+	header := ex.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", ex.Response.StatusCode, http.StatusText(ex.Response.StatusCode)),
+		StatusCode:    ex.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// replayKey identifies a request by method, path and normalized query.
+func replayKey(method, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	key := method + " " + u.Path
+	if len(q) > 0 {
+		key += "?" + q.Encode()
+	}
+	return key, nil
+}
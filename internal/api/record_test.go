@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RedactsAndReplays(t *testing.T) {
+	srv := spreakerServer(t, 200, map[string]interface{}{
+		"show": map[string]interface{}{"show_id": 7, "title": "Recorded Show"},
+	})
+	defer srv.Close()
+
+	dir := t.TempDir()
+	c := testClient(t, srv)
+	c.Headers = http.Header{"X-Api-Key": {"gateway-secret"}, "X-Trace": {"trace-1"}}
+	rec, err := NewRecorder(c.HTTPClient.Transport, dir, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Headers = []string{"x-api-key"}
+	c.HTTPClient.Transport = rec
+
+	if _, err := c.GetShow(7); err != nil {
+		t.Fatalf("GetShow: %v", err)
+	}
+	if err := rec.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "0001.json"))
+	if err != nil {
+		t.Fatalf("dump not written: %v", err)
+	}
+	if strings.Contains(string(data), "test-token") {
+		t.Errorf("dump contains the token:\n%s", data)
+	}
+	if !strings.Contains(string(data), Redacted) {
+		t.Errorf("dump does not redact the Authorization header:\n%s", data)
+	}
+	if strings.Contains(string(data), "gateway-secret") || !strings.Contains(string(data), "trace-1") {
+		t.Errorf("dump does not redact only the given custom header:\n%s", data)
+	}
+
+	// Replay against a different host, with the server gone.
+	srv.Close()
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := NewClient(Redacted)
+	offline.BaseURL = "https://replay.invalid"
+	offline.HTTPClient.Transport = replayer
+
+	show, err := offline.GetShow(7)
+	if err != nil {
+		t.Fatalf("replayed GetShow: %v", err)
+	}
+	if show.Title != "Recorded Show" {
+		t.Errorf("Title = %q, want %q", show.Title, "Recorded Show")
+	}
+
+	if _, err := offline.GetShow(8); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("unrecorded request error = %v, want no recorded response", err)
+	}
+}
+
+func TestRecorder_LargeBodies(t *testing.T) {
+	big := strings.Repeat("x", maxRecordedBody+10)
+	srv := spreakerServer(t, 200, big)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(http.DefaultTransport, dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/upload", strings.NewReader(big))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := replayer.exchanges["POST /upload"][0]
+	if !strings.Contains(ex.Request.Body, "bytes omitted") {
+		t.Errorf("request body recorded in full (%d bytes)", len(ex.Request.Body))
+	}
+	if !ex.Response.Truncated || len(ex.Response.Body) != maxRecordedBody {
+		t.Errorf("response body: truncated=%v len=%d, want truncated at %d", ex.Response.Truncated, len(ex.Response.Body), maxRecordedBody)
+	}
+}
+
+func TestReplayKey(t *testing.T) {
+	tests := []struct {
+		method, url, want string
+	}{
+		{"GET", "https://api.spreaker.com/v2/shows/1", "GET /v2/shows/1"},
+		{"GET", "http://127.0.0.1:8080/v2/shows/1?b=2&a=1", "GET /v2/shows/1?a=1&b=2"},
+		{"DELETE", "https://api.spreaker.com/v2/episodes/3", "DELETE /v2/episodes/3"},
+	}
+	for _, tt := range tests {
+		got, err := replayKey(tt.method, tt.url)
+		if err != nil || got != tt.want {
+			t.Errorf("replayKey(%q, %q) = %q, %v; want %q", tt.method, tt.url, got, err, tt.want)
+		}
+	}
+}
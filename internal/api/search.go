@@ -6,6 +6,12 @@ import (
 	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
+// SearchResults holds the shows and episodes found by a combined search.
+type SearchResults struct {
+	Shows    []models.Show    `json:"shows"`
+	Episodes []models.Episode `json:"episodes"`
+}
+
 // SearchParams contains parameters for search queries.
 type SearchParams struct {
 	Query  string
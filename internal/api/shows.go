@@ -150,4 +150,11 @@ func (c *Client) RemoveShowFromFavorites(userID, showID int) error {
 func (c *Client) GetFavoriteShows(userID int, pagination PaginationParams) (*PaginatedResult[models.Show], error) {
 	path := fmt.Sprintf("/users/%d/favorites", userID)
 	return GetPaginated[models.Show](c, path, pagination.ToMap())
-}
\ No newline at end of file
+}
+
+// GetAllFavoriteShows retrieves every favorite show of a user, following pagination.
+// API: GET /v2/users/{user_id}/favorites
+func (c *Client) GetAllFavoriteShows(userID int) ([]models.Show, error) {
+	path := fmt.Sprintf("/users/%d/favorites", userID)
+	return GetAllPaginated[models.Show](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
@@ -196,6 +196,24 @@ func (c *Client) GetShowEpisodesPlayTotals(showID int, params StatisticsParams,
 	return GetPaginated[models.EpisodePlayTotals](c, path, queryParams)
 }
 
+// GetAllShowEpisodesPlayTotals retrieves play totals for every episode in a show,
+// following pagination until all pages are fetched.
+// API: GET /v2/shows/{show_id}/episodes/statistics/plays/totals
+func (c *Client) GetAllShowEpisodesPlayTotals(showID int, params StatisticsParams) ([]models.EpisodePlayTotals, error) {
+	if err := c.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/shows/%d/episodes/statistics/plays/totals", showID)
+
+	queryParams := params.ToMap()
+	for k, v := range (PaginationParams{Limit: MaxPageLimit}).ToMap() {
+		queryParams[k] = v
+	}
+
+	return GetAllPaginated[models.EpisodePlayTotals](c, path, queryParams, 0)
+}
+
 // -----------------------------------------------------------------------------
 // Likes Statistics (Time-series)
 // -----------------------------------------------------------------------------
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// -----------------------------------------------------------------------------
+// Streaming Response Decoding
+// -----------------------------------------------------------------------------
+
+// ErrResponseTooLarge is returned when a response body exceeds maxResponseSize.
+var ErrResponseTooLarge = fmt.Errorf("response body exceeds %d MB", maxResponseSize>>20)
+
+// limitedReader reads at most n bytes and fails with ErrResponseTooLarge if
+// the body is longer, instead of silently truncating it like io.LimitReader.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func limitBody(r io.Reader) io.Reader {
+	return &limitedReader{r: r, n: maxResponseSize}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// Probe one more byte to tell an exact fit from an oversized body.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// envelope decodes the {"response": ...} wrapper straight into a result
+// pointer, without an intermediate json.RawMessage copy.
+type envelope struct {
+	Response interface{} `json:"response"`
+}
+
+// decodeEnvelope decodes a single (non-list) response from r into result.
+func decodeEnvelope(r io.Reader, result interface{}) error {
+	return json.NewDecoder(r).Decode(&envelope{Response: result})
+}
+
+// decodePage decodes a paginated response from r one item at a time, so
+// only the decoded items are held in memory, not the raw body as well.
+func decodePage[T any](r io.Reader) ([]T, string, error) {
+	dec := json.NewDecoder(r)
+
+	var items []T
+	var nextURL string
+	found := false
+
+	err := decodeObject(dec, func(key string) error {
+		if key != "response" {
+			return skipValue(dec)
+		}
+		found = true
+		return decodeObject(dec, func(key string) error {
+			switch key {
+			case "items":
+				return decodeArray(dec, func() error {
+					var item T
+					if err := dec.Decode(&item); err != nil {
+						return err
+					}
+					items = append(items, item)
+					return nil
+				})
+			case "next_url":
+				return dec.Decode(&nextURL)
+			default:
+				return skipValue(dec)
+			}
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if !found {
+		return nil, "", errors.New(`missing "response" object`)
+	}
+	return items, nextURL, nil
+}
+
+// decodeObject walks a JSON object (or null), calling field for each key
+// with the decoder positioned at its value.
+func decodeObject(dec *json.Decoder, field func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := field(tok.(string)); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing '}'
+	return err
+}
+
+// decodeArray walks a JSON array (or null), calling elem for each element.
+func decodeArray(dec *json.Decoder, elem func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := elem(); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token() // closing ']'
+	return err
+}
+
+func skipValue(dec *json.Decoder) error {
+	var skip json.RawMessage
+	return dec.Decode(&skip)
+}
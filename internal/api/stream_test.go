@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestLimitedReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr error
+	}{
+		{"under limit", maxResponseSize - 1, nil},
+		{"exact fit", maxResponseSize, nil},
+		{"over limit", maxResponseSize + 1, ErrResponseTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := limitBody(strings.NewReader(strings.Repeat("x", tt.size)))
+			n, err := io.Copy(io.Discard, r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && n != int64(tt.size) {
+				t.Errorf("read %d bytes, want %d", n, tt.size)
+			}
+		})
+	}
+}
+
+func TestDecodePage(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantIDs  []int
+		wantNext string
+		wantErr  bool
+	}{
+		{
+			name:     "items and next_url",
+			body:     `{"response":{"items":[{"id":1},{"id":2}],"next_url":"https://api.spreaker.com/v2/next"}}`,
+			wantIDs:  []int{1, 2},
+			wantNext: "https://api.spreaker.com/v2/next",
+		},
+		{
+			name:    "unknown keys are skipped",
+			body:    `{"meta":{"a":[1,2]},"response":{"total":2,"items":[{"id":3,"extra":{"x":1}}],"next_url":null}}`,
+			wantIDs: []int{3},
+		},
+		{
+			name: "null items",
+			body: `{"response":{"items":null}}`,
+		},
+		{
+			name:    "missing response",
+			body:    `{"other":{}}`,
+			wantErr: true,
+		},
+		{
+			name:    "items not an array",
+			body:    `{"response":{"items":{"id":1}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated body",
+			body:    `{"response":{"items":[{"id":1},`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, next, err := decodePage[item](strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var ids []int
+			for _, it := range items {
+				ids = append(ids, it.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+			if next != tt.wantNext {
+				t.Errorf("next = %q, want %q", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestGetPaginated_ResponseTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"response":{"items":[{"title":"%s"}]}}`, strings.Repeat("x", maxResponseSize))
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	_, err := GetPaginated[models.Episode](c, "/episodes", nil)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("err = %v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestGet_StreamsIntoResult(t *testing.T) {
+	srv := spreakerServer(t, 200, map[string]interface{}{
+		"episode": map[string]interface{}{"episode_id": 7, "title": "Seven"},
+	})
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	var result models.EpisodeResponse
+	if err := c.Get("/episodes/7", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Episode.EpisodeID != 7 || result.Episode.Title != "Seven" {
+		t.Errorf("episode = %+v", result.Episode)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Benchmarks
+// ---------------------------------------------------------------------------
+
+// pageServer serves pages of MaxPageLimit episodes until pages are exhausted.
+func pageServer(b *testing.B, pages int) *httptest.Server {
+	b.Helper()
+
+	episodes := make([]models.Episode, MaxPageLimit)
+	for i := range episodes {
+		episodes[i] = models.Episode{
+			EpisodeID:   i + 1,
+			Title:       fmt.Sprintf("Episode %d", i+1),
+			Description: strings.Repeat("A long episode description. ", 40),
+			Tags:        []string{"news", "tech"},
+		}
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		next := ""
+		if page < pages {
+			next = fmt.Sprintf("%s/v2/episodes?page=%d", srv.URL, page+1)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": map[string]interface{}{"items": episodes, "next_url": next},
+		})
+	}))
+	return srv
+}
+
+func benchmarkGetAllPaginated(b *testing.B, strict bool) {
+	srv := pageServer(b, 10)
+	defer srv.Close()
+
+	c := NewClient("test-token")
+	c.BaseURL = srv.URL
+	c.Strict = strict
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items, err := GetAllPaginated[models.Episode](c, "/episodes", nil, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(items) != 10*MaxPageLimit {
+			b.Fatalf("got %d items", len(items))
+		}
+	}
+}
+
+// BenchmarkGetAllPaginated measures the streaming decoder used by default.
+func BenchmarkGetAllPaginated(b *testing.B) {
+	benchmarkGetAllPaginated(b, false)
+}
+
+// BenchmarkGetAllPaginated_Buffered measures strict mode, which still reads
+// whole bodies, as a baseline for the streaming decoder.
+func BenchmarkGetAllPaginated_Buffered(b *testing.B) {
+	benchmarkGetAllPaginated(b, true)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Token Introspection
+// -----------------------------------------------------------------------------
+
+// TokenInfo is what a token reveals about itself. Spreaker's OAuth tokens
+// are opaque and never expire, so only tokens in JWT form, such as those
+// issued by a gateway in front of the API, carry an expiry and scopes.
+type TokenInfo struct {
+	// JWT is set when the token is a JSON Web Token.
+	JWT bool `json:"jwt"`
+
+	// Expires is when the token stops working, if it says.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// Scopes are the token's scopes, or nil if it does not list them.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// InspectToken reads the expiry and scopes of a JWT from its claims,
+// without verifying its signature. Other tokens give an empty TokenInfo.
+func InspectToken(token string) TokenInfo {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenInfo{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return TokenInfo{}
+	}
+	var claims struct {
+		Exp    json.Number     `json:"exp"`
+		Scope  json.RawMessage `json:"scope"`
+		Scopes json.RawMessage `json:"scopes"`
+		Scp    json.RawMessage `json:"scp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return TokenInfo{}
+	}
+
+	info := TokenInfo{JWT: true}
+	if exp, err := claims.Exp.Float64(); err == nil && exp > 0 {
+		t := time.Unix(int64(exp), 0).UTC()
+		info.Expires = &t
+	}
+	for _, raw := range []json.RawMessage{claims.Scope, claims.Scopes, claims.Scp} {
+		if scopes, ok := parseScopes(raw); ok {
+			info.Scopes = scopes
+			break
+		}
+	}
+	return info
+}
+
+// parseScopes reads a scope claim, either a space-separated string as in
+// OAuth 2.0 or a list of strings.
+func parseScopes(raw json.RawMessage) ([]string, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.Fields(s), true
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, true
+	}
+	return nil, false
+}
+
+// CanWrite reports whether the token may change data: it has a scope such
+// as "write", "podcasts:write" or "full", or does not list its scopes.
+func (t TokenInfo) CanWrite() bool {
+	if t.Scopes == nil {
+		return true
+	}
+	for _, s := range t.Scopes {
+		s = strings.ToLower(s)
+		if s == "write" || s == "full" || s == "*" ||
+			strings.HasSuffix(s, ":write") || strings.HasSuffix(s, ".write") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkScope reports, through OnScopeWarning, a request that changes data
+// sent with a token whose scopes do not allow it.
+func (c *Client) checkScope(method, urlStr string) {
+	if c.OnScopeWarning == nil || c.Scopes == nil || method == http.MethodGet || method == http.MethodHead {
+		return
+	}
+	if (TokenInfo{Scopes: c.Scopes}).CanWrite() {
+		return
+	}
+	c.OnScopeWarning(fmt.Sprintf("the token's scopes (%s) do not include write access; the API will likely refuse %s requests with 403 Forbidden",
+		strings.Join(c.Scopes, ", "), method))
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testJWT(claims map[string]interface{}) string {
+	payload, _ := json.Marshal(claims)
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestInspectToken(t *testing.T) {
+	exp := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	info := InspectToken(testJWT(map[string]interface{}{"exp": exp.Unix(), "scope": "read profile"}))
+	if !info.JWT || info.Expires == nil || !info.Expires.Equal(exp) {
+		t.Errorf("InspectToken() = %+v, want expiry %s", info, exp)
+	}
+	if want := []string{"read", "profile"}; !reflect.DeepEqual(info.Scopes, want) {
+		t.Errorf("Scopes = %q, want %q", info.Scopes, want)
+	}
+	if info.CanWrite() {
+		t.Error("CanWrite() = true without a write scope")
+	}
+
+	info = InspectToken(testJWT(map[string]interface{}{"scp": []string{"podcasts:read", "podcasts:write"}}))
+	if info.Expires != nil || !info.CanWrite() {
+		t.Errorf("InspectToken() with scp list = %+v", info)
+	}
+
+	for _, token := range []string{"opaque-spreaker-token", "a.b.c", ""} {
+		if info := InspectToken(token); info.JWT || !info.CanWrite() {
+			t.Errorf("InspectToken(%q) = %+v, want an empty TokenInfo", token, info)
+		}
+	}
+}
+
+func TestClientScopeWarning(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{}})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	c.Scopes = []string{"read"}
+	var warnings []string
+	c.OnScopeWarning = func(msg string) { warnings = append(warnings, msg) }
+
+	if err := c.Get("/me", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("GET warned: %q", warnings)
+	}
+	if err := c.Post("/episodes/3/messages", map[string]string{"text": "hi"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "write access") {
+		t.Errorf("warnings = %q, want one about write access", warnings)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+// Token Rotation
+// -----------------------------------------------------------------------------
+
+// DefaultRateLimitCooldown is how long a rate-limited token is rested when
+// the API does not say, with a Retry-After header, when to try again.
+const DefaultRateLimitCooldown = time.Minute
+
+// TokenPool spreads requests over several tokens of the same account, so
+// long exports go on with another token when one hits the rate limit.
+//
+// Each token's budget is tracked: requests go to a token that is not
+// resting after a 429, preferring the one rate-limited least often, then the
+// one used least.
+type TokenPool struct {
+	mu     sync.Mutex
+	tokens []*pooledToken
+	now    func() time.Time
+}
+
+type pooledToken struct {
+	value       string
+	requests    int
+	rateLimits  int
+	restedUntil time.Time
+}
+
+// NewTokenPool creates a pool of tokens. Empty and repeated tokens are
+// skipped.
+func NewTokenPool(tokens ...string) *TokenPool {
+	p := &TokenPool{now: time.Now}
+	seen := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		p.tokens = append(p.tokens, &pooledToken{value: t})
+	}
+	return p
+}
+
+// Len returns the number of tokens in the pool.
+func (p *TokenPool) Len() int {
+	return len(p.tokens)
+}
+
+// pick returns the token for the next request: the best of those not
+// resting, or, when all are, the one that rests the shortest.
+func (p *TokenPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var best *pooledToken
+	for _, t := range p.tokens {
+		if best == nil || t.betterThan(best, now) {
+			best = t
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	best.requests++
+	return best.value
+}
+
+// betterThan reports whether t should be used rather than other: tokens
+// not resting come first, then the one that hit the rate limit the fewest
+// times and the least used, or among resting ones, the one that rests the
+// shortest.
+func (t *pooledToken) betterThan(other *pooledToken, now time.Time) bool {
+	resting, otherResting := t.restedUntil.After(now), other.restedUntil.After(now)
+	switch {
+	case resting != otherResting:
+		return !resting
+	case resting:
+		return t.restedUntil.Before(other.restedUntil)
+	case t.rateLimits != other.rateLimits:
+		return t.rateLimits < other.rateLimits
+	default:
+		return t.requests < other.requests
+	}
+}
+
+// rest records that token hit the rate limit and rests it for wait. It
+// reports whether another token is available meanwhile.
+func (p *TokenPool) rest(token string, wait time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	available := false
+	for _, t := range p.tokens {
+		if t.value == token {
+			t.rateLimits++
+			t.restedUntil = now.Add(wait)
+			continue
+		}
+		if !t.restedUntil.After(now) {
+			available = true
+		}
+	}
+	return available
+}
+
+// send performs req, with a token of the pool when the client has one. A
+// request that hits the rate limit is sent again with another token while
+// one is available and the body can be replayed.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.Tokens == nil || c.Tokens.Len() == 0 {
+		return c.HTTPClient.Do(req)
+	}
+
+	for {
+		token := c.Tokens.pick()
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if !c.Tokens.rest(token, retryAfter(resp)) || !canReplay {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter returns the wait a 429 response asks for in its Retry-After
+// header, in seconds, or DefaultRateLimitCooldown.
+func retryAfter(resp *http.Response) time.Duration {
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	return DefaultRateLimitCooldown
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenRotation(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		seen = append(seen, auth+" "+string(body))
+		if auth == "Bearer limited" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{}})
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+	c.Tokens = NewTokenPool("limited", "spare", "limited", "")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Tokens.now = func() time.Time { return now }
+
+	if err := c.Post("/episodes/3/messages", map[string]string{"text": "hi"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`Bearer limited {"text":"hi"}`, `Bearer spare {"text":"hi"}`}
+	if len(seen) != 2 || seen[0] != want[0] || seen[1] != want[1] {
+		t.Fatalf("requests = %q, want %q", seen, want)
+	}
+
+	// The limited token rests for Retry-After, so the spare one is used.
+	seen = nil
+	if err := c.Get("/me", nil, nil); err != nil || len(seen) != 1 || seen[0] != "Bearer spare " {
+		t.Errorf("while resting: requests = %q, err = %v", seen, err)
+	}
+
+	// Once every token is resting, the 429 is returned.
+	c.Tokens = NewTokenPool("limited")
+	c.Tokens.now = func() time.Time { return now }
+	err := c.Get("/me", nil, nil)
+	if apiErr, ok := err.(*APIError); !ok || !apiErr.IsRateLimited() {
+		t.Errorf("single limited token: error = %v, want 429", err)
+	}
+}
+
+func TestTokenPool_Pick(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := NewTokenPool("a", "b")
+	p.now = func() time.Time { return now }
+
+	if got := []string{p.pick(), p.pick(), p.pick()}; got[0] != "a" || got[1] != "b" || got[2] != "a" {
+		t.Errorf("picks = %v, want the least used token each time", got)
+	}
+
+	if !p.rest("a", time.Minute) {
+		t.Error("rest(a) reports no other token available")
+	}
+	if p.rest("b", 2*time.Minute) {
+		t.Error("rest(b) reports a token available while both rest")
+	}
+	if got := p.pick(); got != "a" {
+		t.Errorf("all resting: pick() = %q, want the one resting the shortest", got)
+	}
+	now = now.Add(3 * time.Minute)
+	if got := p.pick(); got != "b" {
+		t.Errorf("after resting: pick() = %q, want the least used", got)
+	}
+
+	// a is rested again and hit the rate limit more often than b, so b is
+	// preferred even once it has been used more.
+	p.rest("a", time.Minute)
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < 3; i++ {
+		if got := p.pick(); got != "b" {
+			t.Errorf("pick %d = %q, want the token rate-limited least often", i, got)
+		}
+	}
+}
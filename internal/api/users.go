@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
@@ -45,6 +46,13 @@ func (c *Client) GetUserShows(userID int, pagination PaginationParams) (*Paginat
 	return GetPaginated[models.Show](c, path, pagination.ToMap())	
 }
 
+// GetAllUserShows retrieves every show of a user, following pagination.
+// API: GET /v2/users/{user_id}/shows
+func (c *Client) GetAllUserShows(userID int) ([]models.Show, error) {
+	path := fmt.Sprintf("/users/%d/shows", userID)
+	return GetAllPaginated[models.Show](c, path, PaginationParams{Limit: MaxPageLimit}.ToMap(), 0)
+}
+
 // GetMyShows is a convenience method to get the authenticated user's shows.
 // It first retrieves the current user's ID, then fetches their shows.
 func (c *Client) GetMyShows(pagination PaginationParams) (*PaginatedResult[models.Show], error) {
@@ -69,6 +77,31 @@ func (c *Client) GetUserFollowings(userID int, pagination PaginationParams) (*Pa
 	return GetPaginated[models.User](c, path, pagination.ToMap())
 }
 
+// GetUserSocialGraph fetches a user's complete follower and following lists.
+// Both lists are paginated to the end and fetched concurrently.
+func (c *Client) GetUserSocialGraph(userID int) (followers, followings []models.User, err error) {
+	params := PaginationParams{Limit: MaxPageLimit}.ToMap()
+
+	var followingsErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		followings, followingsErr = GetAllPaginated[models.User](c, fmt.Sprintf("/users/%d/followings", userID), params, 0)
+	}()
+
+	followers, err = GetAllPaginated[models.User](c, fmt.Sprintf("/users/%d/followers", userID), params, 0)
+	wg.Wait()
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch followers: %w", err)
+	}
+	if followingsErr != nil {
+		return nil, nil, fmt.Errorf("failed to fetch followings: %w", followingsErr)
+	}
+	return followers, followings, nil
+}
+
 // FollowUser follows a user.
 // API: PUT /v2/users/{user_id}/followings/{following_id}
 // Parameters:
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// Response Validation
+// -----------------------------------------------------------------------------
+
+// decode unmarshals an API response body into v.
+//
+// In strict mode, fields the model does not declare are an error
+// (DisallowUnknownFields), and expected fields absent from the response are
+// reported through OnWarning. Together they surface Spreaker API changes
+// before they silently break output.
+func (c *Client) decode(endpoint string, data []byte, v interface{}) error {
+	if !c.Strict {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("strict mode: %s: %w", endpoint, err)
+	}
+
+	if c.OnWarning != nil {
+		_, missing, err := CompareShape(data, v)
+		if err == nil {
+			for _, field := range missing {
+				c.OnWarning(fmt.Sprintf("strict mode: %s: response is missing field %q", endpoint, field))
+			}
+		}
+	}
+	return nil
+}
+
+// CompareShape compares a JSON document against the shape of v (a model
+// pointer or value) and returns the dotted paths of fields present in the
+// JSON but not declared by the model (unknown), and of fields declared
+// without omitempty but absent from the JSON (missing). Array elements are
+// reported with a "[]" suffix, e.g. "items[].title".
+func CompareShape(data []byte, v interface{}) (unknown, missing []string, err error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	u := make(map[string]bool)
+	m := make(map[string]bool)
+	compareValue(doc, reflect.TypeOf(v), "", u, m)
+
+	return sortedKeys(u), sortedKeys(m), nil
+}
+
+var (
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+func compareValue(doc interface{}, t reflect.Type, path string, unknown, missing map[string]bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || doc == nil || t == rawMessageType || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+
+		for key, value := range obj {
+			f, ok := lookupField(fields, key)
+			if !ok {
+				unknown[joinPath(path, key)] = true
+				continue
+			}
+			compareValue(value, f.typ, joinPath(path, f.name), unknown, missing)
+		}
+
+		for _, f := range fields {
+			if f.omitempty {
+				continue
+			}
+			if _, ok := lookupKey(obj, f.name); !ok {
+				missing[joinPath(path, f.name)] = true
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return
+		}
+		for _, elem := range arr {
+			compareValue(elem, t.Elem(), path+"[]", unknown, missing)
+		}
+	}
+}
+
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// jsonFields lists the JSON fields of a struct type, flattening embedded
+// structs the way encoding/json does.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			typ:       sf.Type,
+			omitempty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// lookupField finds the field for a JSON key, preferring an exact match and
+// falling back to a case-insensitive one like encoding/json.
+func lookupField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+func lookupKey(obj map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := obj[name]; ok {
+		return v, true
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ShapeCheck is the result of validating one endpoint's response against its model.
+type ShapeCheck struct {
+	Endpoint string   `json:"endpoint"`
+	Unknown  []string `json:"unknown_fields,omitempty"`
+	Missing  []string `json:"missing_fields,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// OK reports whether the response matched the model exactly.
+func (s ShapeCheck) OK() bool {
+	return s.Error == "" && len(s.Unknown) == 0 && len(s.Missing) == 0
+}
+
+// ListPage is the shape of a paginated response, for use with CheckShape.
+type ListPage[T any] struct {
+	Items   []T    `json:"items"`
+	NextURL string `json:"next_url"`
+}
+
+// CheckShape fetches path and compares the response against model, which is
+// also populated so callers can chain probes (e.g. reuse a returned ID).
+// API errors are recorded in the result rather than returned.
+func (c *Client) CheckShape(path string, params map[string]string, model interface{}) ShapeCheck {
+	check := ShapeCheck{Endpoint: "GET /" + c.APIVersion + path}
+
+	var raw json.RawMessage
+	if err := c.Get(path, params, &raw); err != nil {
+		check.Error = err.Error()
+		return check
+	}
+
+	unknown, missing, err := CompareShape(raw, model)
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Unknown, check.Missing = unknown, missing
+
+	if err := json.Unmarshal(raw, model); err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestCompareShape(t *testing.T) {
+	type item struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		Note  string `json:"note,omitempty"`
+	}
+	type page struct {
+		Items []item `json:"items"`
+		Next  string `json:"next_url"`
+	}
+
+	data := []byte(`{"items":[{"id":1,"title":"a","extra":true},{"id":2}],"next_url":null,"version":2}`)
+
+	unknown, missing, err := CompareShape(data, &page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"items[].extra", "version"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown = %v, want %v", unknown, want)
+	}
+	if want := []string{"items[].title"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/users/1":
+			w.Write([]byte(`{"response":{"user":{"user_id":1,"fullname":"A","new_field":1}}}`))
+		default:
+			w.Write([]byte(`{"response":{"user":{"user_id":1}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := testClient(t, srv)
+
+	// Lenient by default
+	if _, err := c.GetUser(1); err != nil {
+		t.Fatalf("non-strict decode failed: %v", err)
+	}
+
+	var warnings []string
+	c.Strict = true
+	c.OnWarning = func(msg string) { warnings = append(warnings, msg) }
+
+	_, err := c.GetUser(1)
+	if err == nil || !strings.Contains(err.Error(), "new_field") {
+		t.Errorf("strict decode error = %v, want unknown field new_field", err)
+	}
+
+	if _, err := c.GetUser(2); err != nil {
+		t.Fatalf("strict decode of partial response failed: %v", err)
+	}
+	found := false
+	for _, w := range warnings {
+		if strings.Contains(w, `"user.fullname"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("warnings = %v, want missing user.fullname", warnings)
+	}
+}
+
+func TestCheckShape(t *testing.T) {
+	srv := spreakerServer(t, 200, map[string]interface{}{
+		"show": map[string]interface{}{"show_id": 5, "title": "T", "brand_new": "x"},
+	})
+	defer srv.Close()
+
+	c := testClient(t, srv)
+
+	var resp models.ShowResponse
+	check := c.CheckShape("/shows/5", nil, &resp)
+	if check.Error != "" {
+		t.Fatal(check.Error)
+	}
+	if check.OK() {
+		t.Error("check should report differences")
+	}
+	if !reflect.DeepEqual(check.Unknown, []string{"show.brand_new"}) {
+		t.Errorf("unknown = %v", check.Unknown)
+	}
+	if resp.Show.ShowID != 5 {
+		t.Errorf("model not populated: %+v", resp.Show)
+	}
+}
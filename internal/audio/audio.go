@@ -0,0 +1,283 @@
+/*
+Package audio checks audio files against podcast loudness and silence
+norms.
+
+Analyze runs ffmpeg over the file once, with the ebur128, astats and
+silencedetect filters, and reads their measurements from its log: the
+integrated loudness and loudness range (EBU R128), the sample and true
+peaks, full-scale samples, and the silence at both ends. Check compares
+them with Norms, by default the -16 LUFS and -1 dBTP most podcast
+platforms recommend.
+
+Process prepares raw recordings for upload: it runs pre-processing Steps
+(trimming silence, normalizing loudness, adding an intro or an outro)
+through ffmpeg and encodes the result as MP3.
+*/
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFFmpeg is the ffmpeg program run when none is given.
+const DefaultFFmpeg = "ffmpeg"
+
+// Silence detection: quieter than silenceThreshold for at least
+// silenceMinimum counts as silence.
+const (
+	silenceThreshold = "-50dB"
+	silenceMinimum   = 0.5
+)
+
+// clipLevel is the sample peak, in dBFS, at which peak samples count as
+// clipped: full scale, allowing for rounding in the log.
+const clipLevel = -0.01
+
+// Analysis holds the measurements of an audio file. Durations are in
+// seconds, levels in LUFS, LU, dBTP and dBFS.
+type Analysis struct {
+	File            string  `json:"file"`
+	Duration        float64 `json:"duration_seconds"`
+	IntegratedLUFS  float64 `json:"integrated_lufs"`
+	LoudnessRange   float64 `json:"loudness_range_lu"`
+	TruePeak        float64 `json:"true_peak_dbtp"`
+	SamplePeak      float64 `json:"sample_peak_dbfs"`
+	ClippedSamples  int     `json:"clipped_samples"`
+	LeadingSilence  float64 `json:"leading_silence_seconds"`
+	TrailingSilence float64 `json:"trailing_silence_seconds"`
+}
+
+// Analyze measures the audio file at path with the ffmpeg program, or
+// DefaultFFmpeg if empty. The file is decoded in full, which takes a few
+// seconds per hour of audio.
+func Analyze(ctx context.Context, ffmpeg, path string) (*Analysis, error) {
+	if ffmpeg == "" {
+		ffmpeg = DefaultFFmpeg
+	}
+	filters := fmt.Sprintf("silencedetect=noise=%s:d=%g,astats=metadata=0,ebur128=peak=sample+true:framelog=quiet", silenceThreshold, silenceMinimum)
+
+	log, err := run(ctx, ffmpeg, "-i", path, "-vn", "-af", filters, "-f", "null", "-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", path, err)
+	}
+
+	a, err := parse(log)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	a.File = path
+	return a, nil
+}
+
+var (
+	durationRE     = regexp.MustCompile(`Duration: (\d+):(\d\d):(\d\d(?:\.\d+)?)`)
+	silenceStartRE = regexp.MustCompile(`silence_start: (-?[\d.]+)`)
+	silenceEndRE   = regexp.MustCompile(`silence_end: ([\d.]+) \| silence_duration: ([\d.]+)`)
+	valueRE        = regexp.MustCompile(`^\s*(I|LRA|Peak):\s+(-?[\d.]+|-inf)`)
+	astatsRE       = regexp.MustCompile(`\] (Peak count|Peak level dB): (-?[\d.]+|-inf)`)
+)
+
+// parse reads the measurements from ffmpeg's log.
+func parse(log string) (*Analysis, error) {
+	a := &Analysis{}
+	if m := durationRE.FindStringSubmatch(log); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		sec, _ := strconv.ParseFloat(m[3], 64)
+		a.Duration = float64(h*3600+min*60) + sec
+	}
+
+	var (
+		silenceStart = -1.0
+		section      string
+		overall      bool
+		peakCount    int
+		foundI       bool
+	)
+	sc := bufio.NewScanner(strings.NewReader(log))
+	for sc.Scan() {
+		line := sc.Text()
+
+		if m := silenceStartRE.FindStringSubmatch(line); m != nil {
+			silenceStart, _ = strconv.ParseFloat(m[1], 64)
+			if silenceStart <= 0.01 {
+				silenceStart = 0
+			}
+			continue
+		}
+		if m := silenceEndRE.FindStringSubmatch(line); m != nil {
+			end, _ := strconv.ParseFloat(m[1], 64)
+			length, _ := strconv.ParseFloat(m[2], 64)
+			if silenceStart == 0 {
+				a.LeadingSilence = length
+			}
+			if a.Duration > 0 && end >= a.Duration-0.05 {
+				a.TrailingSilence = length
+			}
+			silenceStart = -1
+			continue
+		}
+
+		// astats prints a section per channel, then an Overall one.
+		if strings.HasSuffix(line, "] Overall") {
+			overall = true
+			continue
+		}
+		if m := astatsRE.FindStringSubmatch(line); m != nil && overall {
+			if m[1] == "Peak count" {
+				n, _ := strconv.ParseFloat(m[2], 64)
+				peakCount = int(n)
+			}
+			continue
+		}
+
+		// The ebur128 summary: "Integrated loudness:", "Loudness range:",
+		// "Sample peak:" and "True peak:" sections of "Name: value" lines.
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, ":") {
+			section = trimmed
+			continue
+		}
+		m := valueRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		v := parseLevel(m[2])
+		switch {
+		case m[1] == "I" && section == "Integrated loudness:":
+			a.IntegratedLUFS, foundI = v, true
+		case m[1] == "LRA" && section == "Loudness range:":
+			a.LoudnessRange = v
+		case m[1] == "Peak" && section == "Sample peak:":
+			a.SamplePeak = v
+		case m[1] == "Peak" && section == "True peak:":
+			a.TruePeak = v
+		}
+	}
+	if !foundI {
+		return nil, errors.New("no loudness measurement in the ffmpeg output (ffmpeg 4.4 or later is needed)")
+	}
+
+	// Silence running to the end of the file, when ffmpeg does not report
+	// its end.
+	if silenceStart >= 0 && a.Duration > silenceStart {
+		if silenceStart == 0 {
+			a.LeadingSilence = a.Duration
+		}
+		a.TrailingSilence = a.Duration - silenceStart
+	}
+	if a.SamplePeak >= clipLevel {
+		a.ClippedSamples = peakCount
+	}
+	return a, nil
+}
+
+// silenceLevel stands for the -inf level of digital silence, which JSON
+// cannot encode: below anything a 24-bit file can hold.
+const silenceLevel = -144.0
+
+// parseLevel parses a level in dB.
+func parseLevel(s string) float64 {
+	if s == "-inf" {
+		return silenceLevel
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// run runs ffmpeg and returns its log.
+func run(ctx context.Context, ffmpeg string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffmpeg, append([]string{"-hide_banner", "-nostats"}, args...)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s not found: install ffmpeg (https://ffmpeg.org) or pass --ffmpeg", ffmpeg)
+		}
+		return "", fmt.Errorf("%s: %w%s", ffmpeg, err, lastLine(stderr.String()))
+	}
+	return stderr.String(), nil
+}
+
+// lastLine returns the last non-empty line of a program's output, as
+// ": line", for error messages.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return ": " + last
+	}
+	return ""
+}
+
+// Norms are the limits Check holds an analysis to.
+type Norms struct {
+	TargetLUFS    float64
+	LUFSTolerance float64
+	MaxTruePeak   float64
+	MaxSilence    time.Duration
+}
+
+// DefaultNorms are the common podcast recommendations: -16 LUFS ±1 for
+// stereo, peaks at or below -1 dBTP, and no more than 2 seconds of silence
+// at either end.
+var DefaultNorms = Norms{TargetLUFS: -16, LUFSTolerance: 1, MaxTruePeak: -1, MaxSilence: 2 * time.Second}
+
+// Check names.
+const (
+	CheckLoudness        = "loudness"
+	CheckTruePeak        = "true_peak"
+	CheckClipping        = "clipping"
+	CheckLeadingSilence  = "leading_silence"
+	CheckTrailingSilence = "trailing_silence"
+)
+
+// Problem is a measurement outside the norms: Value is what was measured,
+// Expected the limit it should meet.
+type Problem struct {
+	Check    string `json:"check"`
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+}
+
+// Report is an analysis with the problems Check found.
+type Report struct {
+	*Analysis
+	Problems []Problem `json:"problems"`
+}
+
+// Check compares an analysis with the norms.
+func Check(a *Analysis, n Norms) *Report {
+	r := &Report{Analysis: a, Problems: []Problem{}}
+	add := func(check, value, expected string) {
+		r.Problems = append(r.Problems, Problem{Check: check, Value: value, Expected: expected})
+	}
+
+	if math.Abs(a.IntegratedLUFS-n.TargetLUFS) > n.LUFSTolerance {
+		add(CheckLoudness, fmt.Sprintf("%.1f LUFS", a.IntegratedLUFS),
+			fmt.Sprintf("%.1f to %.1f LUFS", n.TargetLUFS-n.LUFSTolerance, n.TargetLUFS+n.LUFSTolerance))
+	}
+	if a.TruePeak > n.MaxTruePeak {
+		add(CheckTruePeak, fmt.Sprintf("%.1f dBTP", a.TruePeak), fmt.Sprintf("≤ %.1f dBTP", n.MaxTruePeak))
+	}
+	if a.ClippedSamples > 0 {
+		add(CheckClipping, fmt.Sprintf("%d samples", a.ClippedSamples), "0 samples")
+	}
+	maxSilence := n.MaxSilence.Seconds()
+	if a.LeadingSilence > maxSilence {
+		add(CheckLeadingSilence, fmt.Sprintf("%.1fs", a.LeadingSilence), fmt.Sprintf("≤ %.1fs", maxSilence))
+	}
+	if a.TrailingSilence > maxSilence {
+		add(CheckTrailingSilence, fmt.Sprintf("%.1fs", a.TrailingSilence), fmt.Sprintf("≤ %.1fs", maxSilence))
+	}
+	return r
+}
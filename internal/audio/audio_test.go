@@ -0,0 +1,97 @@
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	log, err := os.ReadFile(filepath.Join("testdata", "ffmpeg.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := parse(string(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Analysis{
+		Duration:        1800.5,
+		IntegratedLUFS:  -19.4,
+		LoudnessRange:   6.1,
+		TruePeak:        0.4,
+		SamplePeak:      0,
+		ClippedSamples:  14,
+		LeadingSilence:  3.2,
+		TrailingSilence: 1.2,
+	}
+	if !reflect.DeepEqual(a, want) {
+		t.Errorf("parse() = %+v\nwant %+v", a, want)
+	}
+
+	if _, err := parse("Duration: 00:00:01.00\n"); err == nil {
+		t.Error("parse() without an ebur128 summary: want error")
+	}
+}
+
+func TestParse_SilenceToTheEnd(t *testing.T) {
+	log := "  Duration: 00:01:00.00, start: 0\n" +
+		"[silencedetect @ 0x1] silence_start: 55.5\n" +
+		"[Parsed_ebur128_2 @ 0x2] Summary:\n  Integrated loudness:\n    I:         -16.0 LUFS\n" +
+		"  True peak:\n    Peak:       -inf dBFS\n"
+	a, err := parse(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.TrailingSilence != 4.5 || a.LeadingSilence != 0 || a.TruePeak != silenceLevel {
+		t.Errorf("parse() = %+v", a)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	a := &Analysis{IntegratedLUFS: -19.4, TruePeak: 0.4, SamplePeak: 0, ClippedSamples: 14, LeadingSilence: 3.2, TrailingSilence: 1.2}
+	r := Check(a, DefaultNorms)
+	want := []Problem{
+		{Check: CheckLoudness, Value: "-19.4 LUFS", Expected: "-17.0 to -15.0 LUFS"},
+		{Check: CheckTruePeak, Value: "0.4 dBTP", Expected: "≤ -1.0 dBTP"},
+		{Check: CheckClipping, Value: "14 samples", Expected: "0 samples"},
+		{Check: CheckLeadingSilence, Value: "3.2s", Expected: "≤ 2.0s"},
+	}
+	if !reflect.DeepEqual(r.Problems, want) {
+		t.Errorf("Check() = %+v\nwant %+v", r.Problems, want)
+	}
+
+	good := &Analysis{IntegratedLUFS: -16.3, TruePeak: -1.5, SamplePeak: -2}
+	if r := Check(good, DefaultNorms); len(r.Problems) != 0 {
+		t.Errorf("Check() of a good file = %+v", r.Problems)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+	log, err := filepath.Abs(filepath.Join("testdata", "ffmpeg.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := filepath.Join(t.TempDir(), "ffmpeg")
+	if err := os.WriteFile(fake, []byte("#!/bin/sh\ncat '"+log+"' >&2\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := Analyze(context.Background(), fake, "episode.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.File != "episode.mp3" || a.IntegratedLUFS != -19.4 {
+		t.Errorf("Analyze() = %+v", a)
+	}
+
+	if _, err := Analyze(context.Background(), filepath.Join(t.TempDir(), "missing"), "episode.mp3"); err == nil {
+		t.Error("Analyze() with a missing ffmpeg: want error")
+	}
+}
@@ -0,0 +1,229 @@
+package audio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Pre-processing steps.
+const (
+	StepTrimSilence = "trim-silence"
+	StepNormalize   = "normalize"
+	StepAddIntro    = "add-intro"
+	StepAddOutro    = "add-outro"
+)
+
+// Steps are the accepted pre-processing step names.
+var Steps = []string{StepTrimSilence, StepNormalize, StepAddIntro, StepAddOutro}
+
+// Pre-processing defaults: silence is trimmed down to keptSilence seconds,
+// and normalize keeps true peaks below normalizePeak dBTP, under the -1
+// dBTP of DefaultNorms.
+const (
+	keptSilence   = 0.3
+	normalizePeak = -1.5
+)
+
+// Step is a pre-processing step, written "name" or "name:arg":
+//
+//	trim-silence[:THRESHOLD]  trim the silence at both ends, quieter than
+//	                          THRESHOLD (default -50dB)
+//	normalize[:LUFS]          normalize the loudness to LUFS (default -16LUFS)
+//	add-intro:FILE            play FILE before the episode
+//	add-outro:FILE            play FILE after the episode
+type Step struct {
+	Name string
+	Arg  string
+}
+
+func (s Step) String() string {
+	if s.Arg == "" {
+		return s.Name
+	}
+	return s.Name + ":" + s.Arg
+}
+
+// ParseStep parses a step such as "normalize:-16LUFS".
+func ParseStep(spec string) (Step, error) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	s := Step{Name: strings.ToLower(strings.TrimSpace(name)), Arg: strings.TrimSpace(arg)}
+	switch s.Name {
+	case StepTrimSilence:
+		if s.Arg != "" {
+			if _, err := parseDB(s.Arg); err != nil {
+				return s, fmt.Errorf("invalid step %q: threshold must be in dB, e.g. -50dB", spec)
+			}
+		}
+	case StepNormalize:
+		if s.Arg != "" {
+			if _, err := parseLUFS(s.Arg); err != nil {
+				return s, fmt.Errorf("invalid step %q: target must be in LUFS, e.g. -16LUFS", spec)
+			}
+		}
+	case StepAddIntro, StepAddOutro:
+		if s.Arg == "" {
+			return s, fmt.Errorf("invalid step %q: needs an audio file, e.g. %s:intro.mp3", spec, s.Name)
+		}
+	default:
+		return s, fmt.Errorf("unknown step %q (must be %s)", spec, strings.Join(Steps, ", "))
+	}
+	return s, nil
+}
+
+// ParseSteps parses a list of steps.
+func ParseSteps(specs []string) ([]Step, error) {
+	steps := make([]Step, 0, len(specs))
+	for _, spec := range specs {
+		s, err := ParseStep(spec)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// parseDB parses a level such as "-50dB" into "-50dB", the form ffmpeg takes.
+func parseDB(s string) (string, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSuffix(s, "dB"), "db"), 64)
+	if err != nil || v > 0 {
+		return "", fmt.Errorf("invalid level %q", s)
+	}
+	return fmt.Sprintf("%gdB", v), nil
+}
+
+// parseLUFS parses a loudness such as "-16LUFS" or "-16".
+func parseLUFS(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToUpper(s), "LUFS"), 64)
+	if err != nil || v < -70 || v > -5 {
+		return 0, fmt.Errorf("invalid loudness %q", s)
+	}
+	return v, nil
+}
+
+// Process runs the steps over the audio file in, in order, and writes the
+// result to out as a 192 kbps MP3. Steps work on lossless intermediate
+// files, so the audio is encoded once.
+func Process(ctx context.Context, ffmpeg, in, out string, steps []Step) error {
+	if ffmpeg == "" {
+		ffmpeg = DefaultFFmpeg
+	}
+	dir, err := os.MkdirTemp("", "spreaker-preprocess-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	current := in
+	for i, step := range steps {
+		next := filepath.Join(dir, fmt.Sprintf("step%d.wav", i+1))
+		if err := runStep(ctx, ffmpeg, step, current, next); err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+		current = next
+	}
+
+	if _, err := run(ctx, ffmpeg, "-y", "-i", current, "-vn", "-c:a", "libmp3lame", "-b:a", "192k", out); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", out, err)
+	}
+	return nil
+}
+
+// wav are the output options of intermediate files: 32-bit float, so
+// steps cannot clip each other's output.
+var wav = []string{"-vn", "-c:a", "pcm_f32le"}
+
+func runStep(ctx context.Context, ffmpeg string, step Step, in, out string) error {
+	var args []string
+	switch step.Name {
+	case StepTrimSilence:
+		threshold := "-50dB"
+		if step.Arg != "" {
+			threshold, _ = parseDB(step.Arg)
+		}
+		// silenceremove trims the start; reversing trims the end too.
+		trim := fmt.Sprintf("silenceremove=start_periods=1:start_threshold=%s:start_silence=%g", threshold, keptSilence)
+		args = []string{"-i", in, "-af", trim + ",areverse," + trim + ",areverse"}
+	case StepNormalize:
+		target := DefaultNorms.TargetLUFS
+		if step.Arg != "" {
+			target, _ = parseLUFS(step.Arg)
+		}
+		filter, err := loudnormFilter(ctx, ffmpeg, in, target)
+		if err != nil {
+			return err
+		}
+		// loudnorm resamples to 192 kHz; go back to a usual rate.
+		args = []string{"-i", in, "-af", filter, "-ar", "44100"}
+	case StepAddIntro, StepAddOutro:
+		first, second := step.Arg, in
+		if step.Name == StepAddOutro {
+			first, second = in, step.Arg
+		}
+		if _, err := os.Stat(step.Arg); err != nil {
+			return err
+		}
+		// The clips are brought to one format, which concat requires.
+		format := "aformat=sample_fmts=fltp:sample_rates=44100:channel_layouts=stereo"
+		args = []string{"-i", first, "-i", second, "-filter_complex",
+			fmt.Sprintf("[0:a]%s[a0];[1:a]%s[a1];[a0][a1]concat=n=2:v=0:a=1[out]", format, format),
+			"-map", "[out]"}
+	default:
+		return fmt.Errorf("unknown step %q", step.Name)
+	}
+
+	args = append(append(append([]string{"-y"}, args...), wav...), out)
+	_, err := run(ctx, ffmpeg, args...)
+	return err
+}
+
+// loudnormFilter measures the loudness of in with a first loudnorm pass and
+// returns the filter of the second, linear pass, which changes the gain
+// without compressing the dynamics when it can.
+func loudnormFilter(ctx context.Context, ffmpeg, in string, target float64) (string, error) {
+	base := fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=11", target, normalizePeak)
+	log, err := run(ctx, ffmpeg, "-i", in, "-vn", "-af", base+":print_format=json", "-f", "null", "-")
+	if err != nil {
+		return "", err
+	}
+	m, err := parseLoudnorm(log)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true",
+		base, m.InputI, m.InputTP, m.InputLRA, m.InputThresh, m.TargetOffset), nil
+}
+
+// loudnormMeasurement is the JSON loudnorm prints after a first pass.
+type loudnormMeasurement struct {
+	InputI       string `json:"input_i"`
+	InputTP      string `json:"input_tp"`
+	InputLRA     string `json:"input_lra"`
+	InputThresh  string `json:"input_thresh"`
+	TargetOffset string `json:"target_offset"`
+}
+
+// parseLoudnorm reads the JSON block loudnorm prints at the end of the log.
+func parseLoudnorm(log string) (*loudnormMeasurement, error) {
+	start := strings.LastIndex(log, "{")
+	end := strings.LastIndex(log, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no loudness measurement in the ffmpeg output")
+	}
+	var m loudnormMeasurement
+	if err := json.Unmarshal([]byte(log[start:end+1]), &m); err != nil {
+		return nil, fmt.Errorf("invalid loudness measurement: %w", err)
+	}
+	for _, v := range []string{m.InputI, m.InputTP, m.InputLRA, m.InputThresh, m.TargetOffset} {
+		if f, err := strconv.ParseFloat(v, 64); err != nil || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid loudness measurement %q (is the audio silent?)", v)
+		}
+	}
+	return &m, nil
+}
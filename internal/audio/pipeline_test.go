@@ -0,0 +1,123 @@
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseSteps(t *testing.T) {
+	steps, err := ParseSteps([]string{"trim-silence", " normalize:-16LUFS", "Add-Intro:intro.mp3", "trim-silence:-60dB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Step{
+		{Name: StepTrimSilence},
+		{Name: StepNormalize, Arg: "-16LUFS"},
+		{Name: StepAddIntro, Arg: "intro.mp3"},
+		{Name: StepTrimSilence, Arg: "-60dB"},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("ParseSteps() = %+v\nwant %+v", steps, want)
+	}
+	if got := want[1].String(); got != "normalize:-16LUFS" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, spec := range []string{"reverb", "normalize:loud", "normalize:-100", "trim-silence:10dB", "add-outro", "add-intro:"} {
+		if _, err := ParseStep(spec); err == nil {
+			t.Errorf("ParseStep(%q): want error", spec)
+		}
+	}
+}
+
+func TestParseLoudnorm(t *testing.T) {
+	log := `[Parsed_loudnorm_0 @ 0x1]
+{
+	"input_i" : "-23.54",
+	"input_tp" : "-7.96",
+	"input_lra" : "0.00",
+	"input_thresh" : "-34.17",
+	"output_i" : "-16.58",
+	"target_offset" : "0.58"
+}
+`
+	m, err := parseLoudnorm(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &loudnormMeasurement{InputI: "-23.54", InputTP: "-7.96", InputLRA: "0.00", InputThresh: "-34.17", TargetOffset: "0.58"}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("parseLoudnorm() = %+v\nwant %+v", m, want)
+	}
+
+	silent := strings.Replace(log, `"-23.54"`, `"-inf"`, 1)
+	if _, err := parseLoudnorm(silent); err == nil {
+		t.Error("parseLoudnorm() of silent audio: want error")
+	}
+	if _, err := parseLoudnorm("no json here"); err == nil {
+		t.Error("parseLoudnorm() without a measurement: want error")
+	}
+}
+
+func TestProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	// The fake ffmpeg logs its arguments, prints a loudnorm measurement and
+	// creates its output file, the last argument.
+	script := `#!/bin/sh
+echo "$@" >> '` + calls + `'
+echo '{ "input_i" : "-20.00", "input_tp" : "-3.00", "input_lra" : "5.00", "input_thresh" : "-30.00", "target_offset" : "0.10" }' >&2
+for last; do :; done
+[ "$last" = "-" ] || : > "$last"
+`
+	fake := filepath.Join(dir, "ffmpeg")
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	intro := filepath.Join(dir, "intro.mp3")
+	if err := os.WriteFile(intro, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []Step{{Name: StepTrimSilence}, {Name: StepNormalize, Arg: "-19LUFS"}, {Name: StepAddIntro, Arg: intro}}
+	out := filepath.Join(dir, "out.mp3")
+	if err := Process(context.Background(), fake, "raw.wav", out, steps); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("output not written: %v", err)
+	}
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("ffmpeg ran %d times, want 5:\n%s", len(lines), data)
+	}
+	for i, want := range []string{
+		"-i raw.wav -af silenceremove=start_periods=1:start_threshold=-50dB",
+		"loudnorm=I=-19:TP=-1.5:LRA=11:print_format=json -f null -",
+		"loudnorm=I=-19:TP=-1.5:LRA=11:measured_I=-20.00:measured_TP=-3.00:measured_LRA=5.00:measured_thresh=-30.00:offset=0.10:linear=true",
+		"-i " + intro + " -i ",
+		"libmp3lame",
+	} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("call %d = %q, want it to contain %q", i+1, lines[i], want)
+		}
+	}
+
+	missing := []Step{{Name: StepAddOutro, Arg: filepath.Join(dir, "missing.mp3")}}
+	if err := Process(context.Background(), fake, "raw.wav", out, missing); err == nil {
+		t.Error("Process() with a missing outro: want error")
+	}
+}
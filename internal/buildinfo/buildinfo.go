@@ -0,0 +1,55 @@
+/*
+Package buildinfo describes the running binary: its release version and the
+VCS and toolchain details the Go linker embeds.
+*/
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Info is the build information of the running binary.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Read returns the build information, with version being the release
+// version set through -ldflags ("dev" for local builds).
+func Read(version string) Info {
+	info := Info{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		fromSettings(&info, bi.Settings)
+	}
+	return info
+}
+
+func fromSettings(info *Info, settings []debug.BuildSetting) {
+	for _, s := range settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Commit = s.Value
+		case "vcs.time":
+			info.Date = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+}
+
+// ShortCommit returns the first 12 characters of the commit hash.
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
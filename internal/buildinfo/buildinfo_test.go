@@ -0,0 +1,37 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	info := Read("v1.2.3")
+	if info.Version != "v1.2.3" {
+		t.Errorf("Version = %q, want v1.2.3", info.Version)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+	if info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+		t.Errorf("Platform = %q", info.Platform)
+	}
+}
+
+func TestFromSettings(t *testing.T) {
+	var info Info
+	fromSettings(&info, []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+		{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+		{Key: "vcs.modified", Value: "true"},
+		{Key: "GOOS", Value: "linux"},
+	})
+
+	if info.Commit != "0123456789abcdef0123" || info.Date != "2026-01-02T03:04:05Z" || !info.Modified {
+		t.Errorf("fromSettings() = %+v", info)
+	}
+	if got := info.ShortCommit(); got != "0123456789ab" {
+		t.Errorf("ShortCommit() = %q", got)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Package bundle writes debug bundles: zip archives of what maintainers need
+to investigate a bug report, such as the configuration, recent activity and
+recorded HTTP exchanges.
+
+Every file added is scrubbed of the secrets the Writer was given, and of
+anything that looks like a bearer token, so credentials cannot leak through
+config values, logs or traces.
+*/
+package bundle
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+// minSecretLength is the length below which a secret is not scrubbed, as
+// replacing very short strings would mangle unrelated text.
+const minSecretLength = 4
+
+var bearerRE = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+
+// Writer adds scrubbed files to a zip archive.
+type Writer struct {
+	zw      *zip.Writer
+	secrets []string
+	files   []string
+}
+
+// NewWriter returns a Writer that writes the archive to w and scrubs
+// secrets from every file.
+func NewWriter(w io.Writer, secrets []string) *Writer {
+	var keep []string
+	for _, s := range secrets {
+		if len(s) >= minSecretLength {
+			keep = append(keep, s)
+		}
+	}
+	// Longer secrets first, so one containing another is fully replaced.
+	sort.Slice(keep, func(i, j int) bool { return len(keep[i]) > len(keep[j]) })
+	return &Writer{zw: zip.NewWriter(w), secrets: keep}
+}
+
+// Scrub replaces secrets and bearer tokens in data with REDACTED.
+func Scrub(data []byte, secrets []string) []byte {
+	s := string(data)
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, api.Redacted)
+	}
+	return []byte(bearerRE.ReplaceAllString(s, "${1}"+api.Redacted))
+}
+
+// Add adds a file named name with data.
+func (w *Writer) Add(name string, data []byte) error {
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(Scrub(data, w.secrets)); err != nil {
+		return err
+	}
+	w.files = append(w.files, name)
+	return nil
+}
+
+// AddJSON adds v as an indented JSON file.
+func (w *Writer) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.Add(name, append(data, '\n'))
+}
+
+// AddDir adds the regular files under dir, keeping their relative paths
+// below prefix.
+func (w *Writer) AddDir(prefix, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return w.Add(path.Join(prefix, filepath.ToSlash(rel)), data)
+	})
+}
+
+// Files returns the names of the files added so far.
+func (w *Writer) Files() []string {
+	return w.files
+}
+
+// Close finishes the archive.
+func (w *Writer) Close() error {
+	return w.zw.Close()
+}
@@ -0,0 +1,63 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScrub(t *testing.T) {
+	got := Scrub([]byte("token=abcd1234 Authorization: Bearer xyz.987-_ ok"), []string{"abcd1234"})
+	if want := "token=REDACTED Authorization: Bearer REDACTED ok"; string(got) != want {
+		t.Errorf("Scrub() = %q, want %q", got, want)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "0001.json"), []byte(`{"q":"secret-token"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	// Short secrets are not scrubbed: "ab" would mangle unrelated text.
+	w := NewWriter(&buf, []string{"secret", "secret-token", "ab"})
+	if err := w.AddJSON("a.json", map[string]string{"about": "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddDir("http", dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"a.json":             "{\n  \"about\": \"REDACTED\"\n}\n",
+		"http/sub/0001.json": `{"q":"REDACTED"}`,
+	}
+	if len(zr.File) != len(want) {
+		t.Fatalf("files = %v, want %d", w.Files(), len(want))
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if string(data) != want[f.Name] {
+			t.Errorf("%s = %q, want %q", f.Name, data, want[f.Name])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// AudioHash is the checksum of the audio file last uploaded to an episode.
+type AudioHash struct {
+	SHA256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	File       string    `json:"file"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// AudioHashes maps episode IDs to the checksums of their uploaded audio,
+// as the API does not report one.
+type AudioHashes struct {
+	Episodes map[int]AudioHash `json:"episodes"`
+}
+
+// LoadAudioHashes reads the audio checksums at path. A missing file yields
+// no checksums.
+func LoadAudioHashes(path string) (*AudioHashes, error) {
+	h := &AudioHashes{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read audio checksums: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, h); err != nil {
+			return nil, fmt.Errorf("invalid audio checksums %s: %w", path, err)
+		}
+	}
+	if h.Episodes == nil {
+		h.Episodes = make(map[int]AudioHash)
+	}
+	return h, nil
+}
+
+// Save writes the checksums to path, replacing the file atomically.
+func (h *AudioHashes) Save(path string) error {
+	return writeJSON(path, h, "audio checksums")
+}
+
+// HashFile returns the SHA-256 checksum of the file at path.
+func HashFile(path string) (AudioHash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return AudioHash{}, err
+	}
+	defer f.Close()
+
+	sum := sha256.New()
+	n, err := io.Copy(sum, f)
+	if err != nil {
+		return AudioHash{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return AudioHash{SHA256: hex.EncodeToString(sum.Sum(nil)), Size: n, File: path}, nil
+}
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAudioHashes(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "ep.mp3")
+	if err := os.WriteFile(audio, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := HashFile(audio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash.SHA256 != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" || hash.Size != 5 {
+		t.Errorf("HashFile() = %+v", hash)
+	}
+
+	path := filepath.Join(dir, "catalog", "audio-hashes.json")
+	hashes, err := LoadAudioHashes(path)
+	if err != nil || len(hashes.Episodes) != 0 {
+		t.Fatalf("LoadAudioHashes() on a missing file = %+v, %v", hashes, err)
+	}
+	hashes.Episodes[7] = hash
+	if err := hashes.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadAudioHashes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Episodes[7].SHA256 != hash.SHA256 {
+		t.Errorf("loaded = %+v", loaded.Episodes)
+	}
+}
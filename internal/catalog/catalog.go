@@ -0,0 +1,205 @@
+/*
+Package catalog keeps a local copy of a show's daily statistics, so date
+ranges and moving averages can be queried without calling the API again.
+
+Each show is stored in its own JSON file, one entry per day. Syncing merges
+newly fetched days into the file, so it only needs to request the days
+since the previous sync.
+
+Episode messages are stored per show too, replaced as a whole on each sync
+as messages can be deleted, and searched with Search.
+
+AudioHashes records the checksum of the audio last uploaded to each
+episode, so replacing it with an identical file can be avoided.
+*/
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Groupings supported by Query.
+const (
+	GroupDay   = "day"
+	GroupWeek  = "week"
+	GroupMonth = "month"
+)
+
+// Day holds one day of statistics for a show. Followers are those of the
+// show's author, as the API only reports followers per user.
+type Day struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Plays     int    `json:"plays"`
+	Downloads int    `json:"downloads"`
+	Likes     int    `json:"likes"`
+	Followers int    `json:"followers"`
+}
+
+// ShowStats is the stored statistics of one show, with days in date order.
+type ShowStats struct {
+	ShowID   int       `json:"show_id"`
+	SyncedAt time.Time `json:"synced_at,omitzero"`
+	Days     []Day     `json:"days"`
+}
+
+// Load reads the catalog file at path. A missing file yields an empty
+// catalog for showID.
+func Load(path string, showID int) (*ShowStats, error) {
+	s := &ShowStats{ShowID: showID, Days: []Day{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats catalog: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid stats catalog %s: %w", path, err)
+	}
+	if s.Days == nil {
+		s.Days = []Day{}
+	}
+	return s, nil
+}
+
+// Save writes the catalog to path, replacing the file atomically.
+func (s *ShowStats) Save(path string) error {
+	return writeJSON(path, s, "stats catalog")
+}
+
+// LastDate returns the most recent stored day, or "" when nothing is stored.
+func (s *ShowStats) LastDate() string {
+	if len(s.Days) == 0 {
+		return ""
+	}
+	return s.Days[len(s.Days)-1].Date
+}
+
+// Merge stores days, replacing any stored day with the same date, and
+// returns the number of days that were not stored before.
+func (s *ShowStats) Merge(days []Day) (added int) {
+	index := make(map[string]int, len(s.Days))
+	for i, d := range s.Days {
+		index[d.Date] = i
+	}
+	for _, d := range days {
+		if i, ok := index[d.Date]; ok {
+			s.Days[i] = d
+			continue
+		}
+		index[d.Date] = len(s.Days)
+		s.Days = append(s.Days, d)
+		added++
+	}
+	sort.Slice(s.Days, func(i, j int) bool { return s.Days[i].Date < s.Days[j].Date })
+	return added
+}
+
+// BuildDays joins daily play, likes and followers statistics into one Day
+// per date. Every date from from to to (inclusive) is included, so days
+// without activity are stored as zeros rather than fetched again.
+func BuildDays(from, to time.Time, plays []models.PlayStatistics, likes []models.LikesStatistics, followers []models.FollowersStatistics) []Day {
+	byDate := make(map[string]*Day)
+	var days []Day
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		days = append(days, Day{Date: d.Format(report.DateLayout)})
+	}
+	for i := range days {
+		byDate[days[i].Date] = &days[i]
+	}
+
+	for _, p := range plays {
+		if d := byDate[p.Date.String()]; d != nil {
+			d.Plays += p.PlaysCount
+			d.Downloads += p.DownloadsCount
+		}
+	}
+	for _, l := range likes {
+		if d := byDate[l.Date.String()]; d != nil {
+			d.Likes += l.LikesCount
+		}
+	}
+	for _, f := range followers {
+		if d := byDate[f.Date.String()]; d != nil {
+			d.Followers += f.FollowersCount
+		}
+	}
+	return days
+}
+
+// Row is one period of a Query result.
+type Row struct {
+	Date      string   `json:"date"` // first day of the period
+	Plays     int      `json:"plays"`
+	Downloads int      `json:"downloads"`
+	Likes     int      `json:"likes"`
+	Followers int      `json:"followers"`
+	PlaysAvg  *float64 `json:"plays_moving_average,omitempty"`
+}
+
+// Query sums the stored days from from to to (inclusive; empty for no
+// bound) into day, week (starting Monday) or month periods. With window
+// above 0, each row also carries the average plays of the window periods
+// ending with it, once that many periods are available.
+func (s *ShowStats) Query(from, to, group string, window int) ([]Row, error) {
+	var rows []Row
+	for _, d := range s.Days {
+		if (from != "" && d.Date < from) || (to != "" && d.Date > to) {
+			continue
+		}
+		key, err := periodStart(d.Date, group)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 || rows[len(rows)-1].Date != key {
+			rows = append(rows, Row{Date: key})
+		}
+		r := &rows[len(rows)-1]
+		r.Plays += d.Plays
+		r.Downloads += d.Downloads
+		r.Likes += d.Likes
+		r.Followers += d.Followers
+	}
+
+	if window > 0 {
+		sum := 0
+		for i := range rows {
+			sum += rows[i].Plays
+			if i >= window {
+				sum -= rows[i-window].Plays
+			}
+			if i >= window-1 {
+				avg := float64(sum) / float64(window)
+				rows[i].PlaysAvg = &avg
+			}
+		}
+	}
+	return rows, nil
+}
+
+// periodStart returns the first day of the period date falls in.
+func periodStart(date, group string) (string, error) {
+	t, err := time.Parse(report.DateLayout, date)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q in stats catalog", date)
+	}
+	switch group {
+	case GroupDay, "":
+		return date, nil
+	case GroupWeek:
+		offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+		return t.AddDate(0, 0, -offset).Format(report.DateLayout), nil
+	case GroupMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).Format(report.DateLayout), nil
+	}
+	return "", fmt.Errorf("invalid group %q (must be one of: day, week, month)", group)
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog", "stats-1.json")
+
+	s, err := Load(path, 1)
+	if err != nil {
+		t.Fatalf("Load missing file: %v", err)
+	}
+	if s.ShowID != 1 || len(s.Days) != 0 || s.LastDate() != "" {
+		t.Fatalf("empty catalog = %+v", s)
+	}
+
+	s.Merge([]Day{{Date: "2024-01-02", Plays: 5}, {Date: "2024-01-01", Plays: 3}})
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path, 1)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded.Days) != 2 || loaded.LastDate() != "2024-01-02" {
+		t.Errorf("loaded days = %+v", loaded.Days)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	s := &ShowStats{Days: []Day{{Date: "2024-01-01", Plays: 1}, {Date: "2024-01-02", Plays: 2}}}
+
+	// The last synced day is fetched again and replaced.
+	added := s.Merge([]Day{{Date: "2024-01-02", Plays: 7}, {Date: "2024-01-03", Plays: 4}})
+
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+	want := []Day{{Date: "2024-01-01", Plays: 1}, {Date: "2024-01-02", Plays: 7}, {Date: "2024-01-03", Plays: 4}}
+	if len(s.Days) != len(want) {
+		t.Fatalf("days = %+v, want %+v", s.Days, want)
+	}
+	for i := range want {
+		if s.Days[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, s.Days[i], want[i])
+		}
+	}
+}
+
+func TestBuildDays(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+
+	days := BuildDays(from, to,
+		[]models.PlayStatistics{{Date: statsDay("2024-01-01 00:00:00"), PlaysCount: 10, DownloadsCount: 2}},
+		[]models.LikesStatistics{{Date: statsDay("2024-01-03"), LikesCount: 1}, {Date: statsDay("2023-12-31"), LikesCount: 9}},
+		[]models.FollowersStatistics{{Date: statsDay("2024-01-02"), FollowersCount: 4}},
+	)
+
+	want := []Day{
+		{Date: "2024-01-01", Plays: 10, Downloads: 2},
+		{Date: "2024-01-02", Followers: 4},
+		{Date: "2024-01-03", Likes: 1},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("days = %+v, want %+v", days, want)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, days[i], want[i])
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	s := &ShowStats{}
+	// Monday 1 Jan 2024 to Wednesday 10 Jan 2024, with plays equal to the day.
+	for d := 1; d <= 10; d++ {
+		s.Merge([]Day{{Date: time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), Plays: d, Likes: 1}})
+	}
+
+	t.Run("day range", func(t *testing.T) {
+		rows, err := s.Query("2024-01-03", "2024-01-05", GroupDay, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 3 || rows[0].Date != "2024-01-03" || rows[2].Plays != 5 || rows[0].PlaysAvg != nil {
+			t.Errorf("rows = %+v", rows)
+		}
+	})
+
+	t.Run("week", func(t *testing.T) {
+		rows, err := s.Query("", "", GroupWeek, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 2 || rows[0].Date != "2024-01-01" || rows[0].Plays != 28 || rows[1].Date != "2024-01-08" || rows[1].Likes != 3 {
+			t.Errorf("rows = %+v", rows)
+		}
+	})
+
+	t.Run("month", func(t *testing.T) {
+		rows, err := s.Query("", "", GroupMonth, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 || rows[0].Date != "2024-01-01" || rows[0].Plays != 55 {
+			t.Errorf("rows = %+v", rows)
+		}
+	})
+
+	t.Run("moving average", func(t *testing.T) {
+		rows, err := s.Query("", "2024-01-04", GroupDay, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows[1].PlaysAvg != nil {
+			t.Errorf("average before a full window = %v, want none", *rows[1].PlaysAvg)
+		}
+		if rows[2].PlaysAvg == nil || *rows[2].PlaysAvg != 2 || rows[3].PlaysAvg == nil || *rows[3].PlaysAvg != 3 {
+			t.Errorf("rows = %+v", rows)
+		}
+	})
+
+	t.Run("invalid group", func(t *testing.T) {
+		if _, err := s.Query("", "", "year", 0); err == nil {
+			t.Error("expected error for invalid group")
+		}
+	})
+}
+
+// statsDay parses a statistics day for test fixtures.
+func statsDay(s string) models.Date {
+	d, err := models.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
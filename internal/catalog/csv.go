@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/report"
+)
+
+// csvColumns are the columns MergeCSV understands besides date.
+var csvColumns = []string{"plays", "downloads", "likes", "followers"}
+
+// MergeCSV stores the daily statistics read from a CSV file, such as an
+// export of the Spreaker dashboard, and returns the number of days that were
+// not stored before.
+//
+// The first row names the columns: date (YYYY-MM-DD, optionally followed by
+// a time) and any of plays, downloads, likes and followers, in any order and
+// case. Other columns are ignored. Columns that are missing, and empty
+// cells, keep the stored value of that day.
+func (s *ShowStats) MergeCSV(r io.Reader) (added int, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, errors.New("CSV file is empty")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	dateCol := -1
+	cols := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "date" {
+			dateCol = i
+			continue
+		}
+		for _, c := range csvColumns {
+			if name == c {
+				cols[c] = i
+			}
+		}
+	}
+	if dateCol < 0 {
+		return 0, errors.New("CSV has no date column")
+	}
+	if len(cols) == 0 {
+		return 0, fmt.Errorf("CSV has none of the columns %s", strings.Join(csvColumns, ", "))
+	}
+
+	stored := make(map[string]Day, len(s.Days))
+	for _, d := range s.Days {
+		stored[d.Date] = d
+	}
+
+	var days []Day
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		date, err := csvDate(field(record, dateCol))
+		if err != nil {
+			return 0, fmt.Errorf("CSV line %d: %w", line, err)
+		}
+		d, ok := stored[date]
+		if !ok {
+			d = Day{Date: date}
+		}
+		for name, i := range cols {
+			cell := field(record, i)
+			if cell == "" {
+				continue
+			}
+			n, err := strconv.Atoi(cell)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("CSV line %d: invalid %s %q", line, name, cell)
+			}
+			switch name {
+			case "plays":
+				d.Plays = n
+			case "downloads":
+				d.Downloads = n
+			case "likes":
+				d.Likes = n
+			case "followers":
+				d.Followers = n
+			}
+		}
+		stored[date] = d
+		days = append(days, d)
+	}
+
+	return s.Merge(days), nil
+}
+
+// field returns the trimmed cell i of record, or "" for a short row.
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// csvDate returns the YYYY-MM-DD day of a CSV date cell, which may carry a
+// time after the day.
+func csvDate(cell string) (string, error) {
+	day, _, _ := strings.Cut(cell, " ")
+	day, _, _ = strings.Cut(day, "T")
+	if _, err := time.Parse(report.DateLayout, day); err != nil {
+		return "", fmt.Errorf("invalid date %q (must be YYYY-MM-DD)", cell)
+	}
+	return day, nil
+}
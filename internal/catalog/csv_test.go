@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeCSV(t *testing.T) {
+	s := &ShowStats{Days: []Day{{Date: "2024-01-01", Plays: 1, Likes: 5}}}
+
+	input := "Date,Likes,PLAYS,Country\n" +
+		"2024-01-02 00:00:00,2,7,IT\n" +
+		"2024-01-01,,3,IT\n"
+	added, err := s.MergeCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("MergeCSV: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+
+	// The empty likes cell keeps the stored likes.
+	want := []Day{{Date: "2024-01-01", Plays: 3, Likes: 5}, {Date: "2024-01-02", Plays: 7, Likes: 2}}
+	if len(s.Days) != len(want) {
+		t.Fatalf("days = %+v, want %+v", s.Days, want)
+	}
+	for i := range want {
+		if s.Days[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, s.Days[i], want[i])
+		}
+	}
+}
+
+func TestMergeCSV_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "empty"},
+		{"no date column", "day,plays\n2024-01-01,1\n", "no date column"},
+		{"no stats column", "date,country\n2024-01-01,IT\n", "none of the columns"},
+		{"bad date", "date,plays\n01/02/2024,1\n", "line 2: invalid date"},
+		{"bad number", "date,plays\n2024-01-01,1\n2024-01-02,many\n", "line 3: invalid plays"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &ShowStats{Days: []Day{}}
+			_, err := s.MergeCSV(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+			if len(s.Days) != 0 {
+				t.Errorf("days stored despite the error: %+v", s.Days)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// snippetContext is the number of characters kept before the first match
+// in a search snippet.
+const snippetContext = 20
+
+// Message is a stored episode message with the title of its episode, so
+// search results can be read without another lookup.
+type Message struct {
+	models.Message
+	EpisodeTitle string `json:"episode_title"`
+}
+
+// ShowMessages is the stored messages of one show's episodes.
+type ShowMessages struct {
+	ShowID    int       `json:"show_id"`
+	ShowTitle string    `json:"show_title"`
+	SyncedAt  time.Time `json:"synced_at,omitzero"`
+	Messages  []Message `json:"messages"`
+}
+
+// LoadMessages reads the messages file at path. A missing file yields no
+// messages for showID.
+func LoadMessages(path string, showID int) (*ShowMessages, error) {
+	s := &ShowMessages{ShowID: showID, Messages: []Message{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read messages catalog: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid messages catalog %s: %w", path, err)
+	}
+	if s.Messages == nil {
+		s.Messages = []Message{}
+	}
+	return s, nil
+}
+
+// Save writes the messages to path, replacing the file atomically.
+func (s *ShowMessages) Save(path string) error {
+	return writeJSON(path, s, "messages catalog")
+}
+
+// Match is a message found by Search.
+type Match struct {
+	ShowID    int    `json:"show_id"`
+	ShowTitle string `json:"show_title"`
+	Message
+
+	// Snippet is the part of the text around the first match.
+	Snippet string `json:"snippet"`
+}
+
+// Search returns the messages of shows whose text, author or episode title
+// contains every term of query, ignoring case, newest first. Terms are
+// separated by spaces; "quoted words" are matched as one phrase.
+func Search(shows []*ShowMessages, query string) []Match {
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for _, show := range shows {
+		for _, m := range show.Messages {
+			text := strings.ToLower(m.Text)
+			fields := text + "\n" + strings.ToLower(m.AuthorFullname+"\n"+m.AuthorUsername+"\n"+m.EpisodeTitle)
+			if !containsAll(fields, terms) {
+				continue
+			}
+			matches = append(matches, Match{
+				ShowID:    show.ShowID,
+				ShowTitle: show.ShowTitle,
+				Message:   m,
+				Snippet:   snippet(m.Text, terms),
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt.Equal(matches[j].CreatedAt.Time) {
+			return matches[i].CreatedAt.After(matches[j].CreatedAt.Time)
+		}
+		return matches[i].MessageID > matches[j].MessageID
+	})
+	return matches
+}
+
+// queryTerms splits a query into lowercase terms, keeping quoted phrases
+// together.
+func queryTerms(query string) []string {
+	var terms []string
+	for i, part := range strings.Split(strings.ToLower(query), `"`) {
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		terms = append(terms, strings.Fields(part)...)
+	}
+	return terms
+}
+
+func containsAll(s string, terms []string) bool {
+	for _, t := range terms {
+		if !strings.Contains(s, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// snippet returns text on one line, starting a little before the first
+// term found in it. Text without a match, e.g. when only the author
+// matched, is returned from the start.
+func snippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+
+	first := -1
+	for _, t := range terms {
+		if i := strings.Index(lower, t); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	// Lowercasing can change byte lengths; only use offsets that still fit.
+	if first <= snippetContext || len(lower) != len(text) {
+		return text
+	}
+
+	start := first - snippetContext
+	for start > 0 && !unicode.IsSpace(rune(text[start-1])) {
+		start--
+	}
+	return "..." + text[start:]
+}
+
+// writeJSON writes v to path as indented JSON, replacing the file
+// atomically. what names the file in errors.
+func writeJSON(path string, v any, what string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
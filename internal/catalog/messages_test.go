@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestMessagesLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog", "messages-1.json")
+
+	s, err := LoadMessages(path, 1)
+	if err != nil {
+		t.Fatalf("LoadMessages missing file: %v", err)
+	}
+	if s.ShowID != 1 || len(s.Messages) != 0 {
+		t.Fatalf("empty catalog = %+v", s)
+	}
+
+	s.Messages = append(s.Messages, Message{Message: models.Message{MessageID: 7, Text: "hi"}, EpisodeTitle: "Ep"})
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := LoadMessages(path, 1)
+	if err != nil {
+		t.Fatalf("LoadMessages: %v", err)
+	}
+	if len(loaded.Messages) != 1 || loaded.Messages[0].MessageID != 7 || loaded.Messages[0].EpisodeTitle != "Ep" {
+		t.Errorf("loaded messages = %+v", loaded.Messages)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	msg := func(id int, date, author, text string) Message {
+		return Message{Message: models.Message{MessageID: id, CreatedAt: apiTime(date), AuthorUsername: author, Text: text}, EpisodeTitle: "Pilot"}
+	}
+	shows := []*ShowMessages{
+		{ShowID: 1, ShowTitle: "One", Messages: []Message{
+			msg(1, "2024-01-01 10:00:00", "ann", "Where do I ask for a REFUND?"),
+			msg(2, "2024-01-03 10:00:00", "bob", "Great episode, but the sound quality was poor and I would like my refund back"),
+		}},
+		{ShowID: 2, ShowTitle: "Two", Messages: []Message{
+			msg(3, "2024-01-02 10:00:00", "refund_fan", "Loved it"),
+			msg(4, "2024-01-04 10:00:00", "cat", "No money talk here"),
+		}},
+	}
+
+	got := Search(shows, "refund")
+	if len(got) != 3 || got[0].MessageID != 2 || got[1].MessageID != 3 || got[2].MessageID != 1 {
+		t.Fatalf("Search(refund) = %+v", got)
+	}
+	if got[0].ShowTitle != "One" || got[1].ShowID != 2 {
+		t.Errorf("show of matches = %+v, %+v", got[0], got[1])
+	}
+	if got[0].Snippet != "...and I would like my refund back" {
+		t.Errorf("snippet = %q", got[0].Snippet)
+	}
+	if got[1].Snippet != "Loved it" {
+		t.Errorf("snippet without text match = %q", got[1].Snippet)
+	}
+
+	if got := Search(shows, "refund poor"); len(got) != 1 || got[0].MessageID != 2 {
+		t.Errorf("Search(refund poor) = %+v", got)
+	}
+	if got := Search(shows, `"my  refund"`); len(got) != 1 || got[0].MessageID != 2 {
+		t.Errorf("Search of phrase = %+v", got)
+	}
+	if got := Search(shows, `"refund my"`); len(got) != 0 {
+		t.Errorf("Search of missing phrase = %+v", got)
+	}
+	if got := Search(shows, "  "); got != nil {
+		t.Errorf("Search of empty query = %+v", got)
+	}
+}
+
+// apiTime parses an API timestamp for test fixtures.
+func apiTime(s string) models.CustomTime {
+	t, err := models.ParseTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
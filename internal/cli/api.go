@@ -0,0 +1,112 @@
+/*
+api.go - Diagnostics for the Spreaker API itself
+
+These commands check that the API still matches what this CLI expects.
+*/
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func newAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Diagnose the Spreaker API",
+		Long: `Diagnose the Spreaker API.
+
+Examples:
+  spreaker api check    # Validate key endpoints against the CLI's models`,
+	}
+
+	cmd.AddCommand(
+		newAPICheckCmd(),
+	)
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// api check
+// -----------------------------------------------------------------------------
+
+// apiCheckExitCode is the exit code used when responses differ from the models.
+const apiCheckExitCode = 2
+
+func newAPICheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Validate key endpoint responses against the CLI's models",
+		Long: `Probe key Spreaker endpoints with your account and compare each response
+with the models this CLI decodes it into.
+
+Fields returned by the API but unknown to the CLI, and expected fields the
+API no longer returns, are listed per endpoint. Your first show and its
+latest episode are used for the show and episode probes.
+
+The command exits with status 2 when any response differs from its model.`,
+		Args: cobra.NoArgs,
+		RunE: runAPICheck,
+	}
+}
+
+func runAPICheck(cmd *cobra.Command, args []string) error {
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	if err := client.CheckAuth(); err != nil {
+		return err
+	}
+
+	var checks []api.ShapeCheck
+	one := map[string]string{"limit": "1"}
+
+	var me models.UserResponse
+	checks = append(checks, client.CheckShape("/me", nil, &me))
+
+	var categories models.CategoriesResponse
+	checks = append(checks, client.CheckShape("/show-categories", nil, &categories))
+
+	if me.User.UserID != 0 {
+		var shows api.ListPage[models.Show]
+		checks = append(checks, client.CheckShape(fmt.Sprintf("/users/%d/shows", me.User.UserID), one, &shows))
+		checks = append(checks, client.CheckShape(fmt.Sprintf("/users/%d/statistics", me.User.UserID), nil, &models.UserOverallStatisticsResponse{}))
+
+		if len(shows.Items) > 0 {
+			showID := shows.Items[0].ShowID
+			var episodes api.ListPage[models.Episode]
+			checks = append(checks, client.CheckShape(fmt.Sprintf("/shows/%d", showID), nil, &models.ShowResponse{}))
+			checks = append(checks, client.CheckShape(fmt.Sprintf("/shows/%d/episodes", showID), one, &episodes))
+			checks = append(checks, client.CheckShape(fmt.Sprintf("/shows/%d/statistics", showID), nil, &models.ShowOverallStatisticsResponse{}))
+
+			if len(episodes.Items) > 0 {
+				episodeID := episodes.Items[0].EpisodeID
+				checks = append(checks, client.CheckShape(fmt.Sprintf("/episodes/%d", episodeID), nil, &models.EpisodeResponse{}))
+				checks = append(checks, client.CheckShape(fmt.Sprintf("/episodes/%d/statistics", episodeID), nil, &models.EpisodeOverallStatisticsResponse{}))
+			}
+		}
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintShapeChecks(checks)
+
+	failed := 0
+	for _, c := range checks {
+		if !c.OK() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &ExitError{
+			Code: apiCheckExitCode,
+			Err:  fmt.Errorf("%d of %d endpoint(s) differ from the CLI's models", failed, len(checks)),
+		}
+	}
+	return nil
+}
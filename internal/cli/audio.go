@@ -0,0 +1,211 @@
+/*
+audio.go - Local audio file checks and pre-processing
+
+Commands that inspect audio files before they are uploaded, and the
+pre-processing of uploads, through the ffmpeg integration of the
+internal/audio package.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+)
+
+// qcExitCode is returned by audio check when the file is outside the
+// podcast norms, so CI jobs can hold back the upload.
+const qcExitCode = 2
+
+func newAudioCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audio",
+		Short: "Check audio files before uploading them",
+		Long: `Check local audio files before uploading them. The checks run ffmpeg,
+which must be installed.
+
+Examples:
+  spreaker audio check episode.mp3
+  spreaker audio check episode.mp3 --target-lufs -19 --output json`,
+	}
+
+	cmd.AddCommand(newAudioCheckCmd())
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// audio check
+// -----------------------------------------------------------------------------
+
+func newAudioCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check <file>",
+		Short: "Report loudness, peaks, silence and clipping of an audio file",
+		Long: `Measure an audio file with ffmpeg and compare it with podcast norms:
+
+  - integrated loudness (EBU R128) within --lufs-tolerance of --target-lufs
+  - true peak at or below --max-true-peak
+  - no clipped (full-scale) samples
+  - at most --max-silence of silence at the start and at the end
+
+The defaults, -16 LUFS ±1 and -1 dBTP, are what Apple Podcasts and most
+platforms recommend for stereo; mono shows often target -19 LUFS.
+
+The command exits with status 2 when the file is outside the norms. Pass
+--qc to "episodes upload" to run the same check before uploading.
+
+Examples:
+  spreaker audio check episode.mp3
+  spreaker audio check episode.mp3 --target-lufs -19
+  spreaker audio check episode.mp3 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAudioCheck,
+	}
+
+	addNormsFlags(cmd)
+
+	return cmd
+}
+
+// addNormsFlags adds the flags of the podcast norms audio files are
+// checked against, and of the ffmpeg program.
+func addNormsFlags(cmd *cobra.Command) {
+	d := audio.DefaultNorms
+	cmd.Flags().Float64("target-lufs", d.TargetLUFS, "Integrated loudness to aim for, in LUFS")
+	cmd.Flags().Float64("lufs-tolerance", d.LUFSTolerance, "Loudness difference allowed from --target-lufs, in LU")
+	cmd.Flags().Float64("max-true-peak", d.MaxTruePeak, "Highest true peak allowed, in dBTP")
+	cmd.Flags().Duration("max-silence", d.MaxSilence, "Longest silence allowed at the start and at the end")
+	cmd.Flags().String("ffmpeg", audio.DefaultFFmpeg, "ffmpeg program")
+}
+
+func runAudioCheck(cmd *cobra.Command, args []string) error {
+	r, err := checkAudio(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	getFormatter(cmd).PrintAudioCheck(r)
+	if len(r.Problems) > 0 {
+		return &ExitError{
+			Code: qcExitCode,
+			Err:  fmt.Errorf("%s is outside the podcast norms (%d problem(s))", args[0], len(r.Problems)),
+		}
+	}
+	return nil
+}
+
+// checkAudio analyzes a file and checks it against the norms of the flags.
+func checkAudio(cmd *cobra.Command, file string) (*audio.Report, error) {
+	if _, err := os.Stat(file); err != nil {
+		return nil, err
+	}
+
+	var norms audio.Norms
+	norms.TargetLUFS, _ = cmd.Flags().GetFloat64("target-lufs")
+	norms.LUFSTolerance, _ = cmd.Flags().GetFloat64("lufs-tolerance")
+	norms.MaxTruePeak, _ = cmd.Flags().GetFloat64("max-true-peak")
+	norms.MaxSilence, _ = cmd.Flags().GetDuration("max-silence")
+	ffmpeg, _ := cmd.Flags().GetString("ffmpeg")
+	if norms.LUFSTolerance < 0 {
+		return nil, fmt.Errorf("invalid lufs-tolerance %g: must not be negative", norms.LUFSTolerance)
+	}
+
+	formatter := getFormatter(cmd)
+	spinner := formatter.StartSpinner(i18n.T("Analyzing %s...", file))
+	a, err := audio.Analyze(cmd.Context(), ffmpeg, file)
+	if err != nil {
+		formatter.CancelSpinner(spinner)
+		return nil, fmt.Errorf("analysis failed: %w", err)
+	}
+	formatter.StopSpinner(spinner, true, i18n.T("Analyzed %s", file))
+	return audio.Check(a, norms), nil
+}
+
+// uploadQC checks a file before episodes upload --qc. A file outside the
+// norms is reported, and the upload goes ahead only if the user agrees, or
+// with --force.
+func uploadQC(cmd *cobra.Command, formatter *output.Formatter, file string) (bool, error) {
+	r, err := checkAudio(cmd, file)
+	if err != nil {
+		return false, fmt.Errorf("audio check failed: %w", err)
+	}
+	if len(r.Problems) == 0 {
+		return true, nil
+	}
+	formatter.PrintAudioCheck(r)
+	formatter.PrintWarning(i18n.T("%s is outside the podcast norms", file))
+
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return true, nil
+	}
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput || !isInteractive() {
+		return false, fmt.Errorf("%s is outside the podcast norms; pass --force to upload it anyway", file)
+	}
+	if !confirmAction(i18n.T("Upload anyway?") + " [y/N]: ") {
+		formatter.PrintMessage(i18n.T("Cancelled."))
+		return false, nil
+	}
+	return true, nil
+}
+
+// uploadSteps returns the pre-processing steps of an upload to a show:
+// those of --preprocess, or else the show's preprocess default, and none
+// with --no-preprocess. Relative intro and outro files of the config are
+// looked up in the config directory.
+func uploadSteps(cmd *cobra.Command, showID int) ([]audio.Step, error) {
+	if off, _ := cmd.Flags().GetBool("no-preprocess"); off {
+		return nil, nil
+	}
+	if cmd.Flags().Changed("preprocess") {
+		specs, _ := cmd.Flags().GetStringSlice("preprocess")
+		return audio.ParseSteps(specs)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	steps, err := audio.ParseSteps(cfg.ShowDefaultsFor(showID).Preprocess)
+	if err != nil {
+		return nil, fmt.Errorf("shows.%d.preprocess: %w", showID, err)
+	}
+	for i, s := range steps {
+		if (s.Name == audio.StepAddIntro || s.Name == audio.StepAddOutro) && !filepath.IsAbs(s.Arg) {
+			if steps[i].Arg, err = config.DataFilePath(s.Arg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return steps, nil
+}
+
+// preprocessUpload runs the steps over an audio file and returns the
+// processed file, in a temporary directory that cleanup removes.
+func preprocessUpload(cmd *cobra.Command, formatter *output.Formatter, file string, steps []audio.Step) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "spreaker-upload-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	base := filepath.Base(file)
+	out := filepath.Join(dir, base[:len(base)-len(filepath.Ext(base))]+".mp3")
+	ffmpeg, _ := cmd.Flags().GetString("ffmpeg")
+
+	spinner := formatter.StartSpinner(i18n.T("Pre-processing %s...", file))
+	if err := audio.Process(cmd.Context(), ffmpeg, file, out, steps); err != nil {
+		formatter.CancelSpinner(spinner)
+		cleanup()
+		return "", nil, fmt.Errorf("failed to pre-process %s: %w", file, err)
+	}
+	formatter.StopSpinner(spinner, true, i18n.T("Pre-processed %s", file))
+	return out, cleanup, nil
+}
@@ -0,0 +1,183 @@
+/*
+auth.go - Credential inspection commands
+
+auth status shows which account the stored token acts as, where the token
+comes from and, for tokens that carry them, its expiry and scopes.
+
+The account a token acts as is cached by a hash of the token, in memory
+and on disk for identity_ttl, so commands acting as the user of a --token
+or SPREAKER_TOKEN, and the warning naming the other account a --token acts
+as, don't ask the API every time. --refresh-identity skips the disk cache.
+*/
+package cli
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/identity"
+)
+
+// identityFile caches the account each token used acts as.
+const identityFile = "identities.json"
+
+// identities holds the accounts resolved during this run, by token hash.
+var (
+	identitiesMu sync.Mutex
+	identities   = map[string]identity.Identity{}
+)
+
+// tokenExpiryWarning is how long before its expiry a token is reported as
+// expiring soon.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+func newAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Inspect your credentials",
+		Long: `Inspect the credentials commands use.
+
+Available subcommands:
+  - status: Show the token's account, source, expiry and scopes`,
+	}
+
+	cmd.AddCommand(newAuthStatusCmd())
+
+	return cmd
+}
+
+func newAuthStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the token's account, source, expiry and scopes",
+		Long: `Show the account the token acts as, where the token comes from (--token,
+SPREAKER_TOKEN or the config file), and its expiry, time until it must be
+refreshed and scopes.
+
+Spreaker's own tokens don't expire and don't list scopes, so these are
+"not reported" for them. Tokens in JWT form, e.g. issued by a gateway in
+front of the API, carry them in their claims. A token without write access
+is reported here, and commands that change data warn before sending their
+request instead of failing with a bare 403 Forbidden.
+
+Examples:
+  spreaker auth status
+  spreaker auth status -o json`,
+		Args: cobra.NoArgs,
+		RunE: runAuthStatus,
+	}
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	token, source := tokenSource(cmd)
+	if token == "" {
+		return errors.New("not authenticated. Run 'spreaker login' first")
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	user, err := client.GetMe()
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	info := api.InspectToken(token)
+	formatter.PrintAuthStatus(user, source, info)
+
+	if info.Expires != nil {
+		if left := time.Until(*info.Expires); left <= 0 {
+			formatter.PrintWarning(i18n.T("The token has expired; run 'spreaker login' with a new one"))
+		} else if left < tokenExpiryWarning {
+			formatter.PrintWarning(i18n.T("The token expires in less than %d days; refresh it soon", int(tokenExpiryWarning.Hours()/24)))
+		}
+	}
+	if !info.CanWrite() {
+		formatter.PrintWarning(i18n.T("The token has no write scope: commands that change data will be refused"))
+	}
+	return nil
+}
+
+// tokenSource returns the token commands use and where it comes from.
+func tokenSource(cmd *cobra.Command) (token, source string) {
+	if token, _ := cmd.Flags().GetString("token"); token != "" {
+		return token, i18n.T("--token flag")
+	}
+	if token := os.Getenv("SPREAKER_TOKEN"); token != "" {
+		return token, i18n.T("SPREAKER_TOKEN environment variable")
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg.Token == "" {
+		return "", ""
+	}
+	return cfg.Token, i18n.T("config file %s", config.ConfigFilePath())
+}
+
+// resolveIdentity returns the account token acts as: from this run's
+// cache, then from the identity cache if resolved within identity_ttl and
+// --refresh-identity is not set, or else from the API, caching it.
+func resolveIdentity(cmd *cobra.Command, client *api.Client, token string) (identity.Identity, error) {
+	identitiesMu.Lock()
+	defer identitiesMu.Unlock()
+	if id, ok := identities[identity.Key(token)]; ok {
+		return id, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return identity.Identity{}, err
+	}
+	path, err := config.DataFilePath(identityFile)
+	if err != nil {
+		return identity.Identity{}, err
+	}
+	cache, err := identity.Load(path)
+	if err != nil {
+		// A broken cache is replaced.
+		cache = &identity.Cache{}
+	}
+	ttl := cfg.IdentityCacheTTL()
+	if refresh, _ := cmd.Flags().GetBool("refresh-identity"); refresh {
+		ttl = 0
+	}
+	id, ok := cache.Get(token, ttl)
+	if !ok {
+		user, err := client.GetMe()
+		if err != nil {
+			return identity.Identity{}, err
+		}
+		id = identity.Identity{UserID: user.UserID, Username: user.Username, Fullname: user.Fullname, Resolved: time.Now().UTC()}
+		if cfg.IdentityCacheTTL() > 0 {
+			cache.Put(token, id)
+			_ = cache.Save(path)
+		}
+	}
+	identities[identity.Key(token)] = id
+	return id, nil
+}
+
+// impersonationWarning returns the warning naming the account token,
+// given with --token, acts as when it is another account than the
+// logged-in one, defaultUserID, or "" when it is the same. getClient
+// prints it before the command prompts or changes anything, so edits
+// don't land on the wrong account unnoticed.
+func impersonationWarning(cmd *cobra.Command, client *api.Client, token string, defaultUserID int) string {
+	id, err := resolveIdentity(cmd, client, token)
+	if err != nil {
+		return i18n.T("could not check which account --token acts as: %v", err)
+	}
+	if id.UserID == defaultUserID {
+		return ""
+	}
+	return i18n.T("ACTING AS @%s (%s, user %d) WITH --token", id.Username, id.Fullname, id.UserID) +
+		". " + i18n.T("This is not your logged-in account (user %d).", defaultUserID)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/identity"
+)
+
+// identityServer serves /v2/me as user 102, counting the calls.
+func identityServer(t *testing.T, meCalls *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/me" {
+			*meCalls++
+			fmt.Fprint(w, `{"response":{"user":{"user_id":102,"username":"bob","fullname":"Bob Bianchi"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":{}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// forgetIdentities empties the identities resolved in this run.
+func forgetIdentities() {
+	identitiesMu.Lock()
+	defer identitiesMu.Unlock()
+	identities = map[string]identity.Identity{}
+}
+
+func TestImpersonationWarning(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+	t.Cleanup(forgetIdentities)
+
+	meCalls := 0
+	srv := identityServer(t, &meCalls)
+	client := api.NewClientWithOptions("other-token", srv.URL, 0)
+
+	msg := impersonationWarning(&cobra.Command{}, client, "other-token", 101)
+	if !strings.Contains(msg, "ACTING AS @bob (Bob Bianchi, user 102)") || !strings.Contains(msg, "user 101") {
+		t.Errorf("want a warning naming @bob and user 101, got %q", msg)
+	}
+	if msg := impersonationWarning(&cobra.Command{}, client, "other-token", 102); msg != "" {
+		t.Errorf("warning for the logged-in account: %q", msg)
+	}
+	if meCalls != 1 {
+		t.Errorf("GET /me called %d times, want 1 with the identity cached", meCalls)
+	}
+}
+
+func TestGetMyUserID(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Setenv("SPREAKER_TOKEN", "env-token")
+	t.Cleanup(viper.Reset)
+	t.Cleanup(forgetIdentities)
+
+	meCalls := 0
+	srv := identityServer(t, &meCalls)
+	client := api.NewClientWithOptions("env-token", srv.URL, 0)
+
+	run := func(args ...string) int {
+		forgetIdentities()
+		cmd := newRootCmd("test")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		userID, err := getMyUserID(cmd, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return userID
+	}
+
+	for _, args := range [][]string{nil, nil, {"--refresh-identity"}} {
+		if got := run(args...); got != 102 {
+			t.Errorf("getMyUserID(%v) = %d, want 102", args, got)
+		}
+	}
+	if meCalls != 2 {
+		t.Errorf("GET /me called %d times, want 2: once, then for --refresh-identity", meCalls)
+	}
+}
@@ -8,10 +8,17 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"time"
 	
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/transcribe"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newChaptersCmd() *cobra.Command {
@@ -28,7 +35,8 @@ Examples:
   spreaker chapters add 12345 --starts-at 30000 --title "Introduction"
   spreaker chapters update 12345 67890 --title "New Title"
   spreaker chapters delete 12345 67890
-  spreaker chapters delete-all 12345`,
+  spreaker chapters delete-all 12345
+  spreaker chapters from-transcript 12345 transcript.vtt`,
 	}
 
 	cmd.AddCommand(
@@ -37,6 +45,7 @@ Examples:
 		newChaptersUpdateCmd(),
 		newChaptersDeleteCmd(),
 		newChaptersDeleteAllCmd(),
+		newChaptersFromTranscriptCmd(),
 	)
 
 	return cmd
@@ -84,15 +93,16 @@ func runChaptersList(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No chapters found for this episode.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No chapters found for this episode."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintChapters(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more chapters available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more chapters available, use --limit to see more)"))
 	}
 
 	return nil
@@ -176,7 +186,7 @@ func runChaptersAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage(fmt.Sprintf("Chapter added successfully (ID: %d).", chapter.ChapterID))
+	formatter.PrintMessage(i18n.T("Chapter added successfully (ID: %d).", chapter.ChapterID))
 	return nil
 }
 
@@ -252,7 +262,7 @@ func runChaptersUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("Chapter updated successfully.")
+	formatter.PrintMessage(i18n.T("Chapter updated successfully."))
 	return nil
 }
 
@@ -294,7 +304,7 @@ func runChaptersDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("Chapter deleted successfully.")
+	formatter.PrintMessage(i18n.T("Chapter deleted successfully."))
 	return nil
 }
 
@@ -328,26 +338,174 @@ func runChaptersDeleteAll(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	force, _ := cmd.Flags().GetBool("force")
-	if !force {
-		prompt := fmt.Sprintf("Are you sure you want to delete all chapters from episode %d? [y/N]: ", episodeID)
-		if !confirmAction(prompt) {
-			formatter := getFormatter(cmd)
-			formatter.PrintMessage("Cancelled.")
-			return nil
-		}
-	}
-
 	client, err := getClient(cmd)
 	if err != nil {
 		return err
 	}
 
+	prompt := fmt.Sprintf("Are you sure you want to delete all chapters from episode %d?", episodeID)
+	if ok, err := confirm(cmd, actionDelete, prompt, episodeTitle(client, episodeID)); !ok {
+		return err
+	}
+
 	if err := client.DeleteAllChapters(episodeID); err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("All chapters deleted successfully.")
+	formatter.PrintMessage(i18n.T("All chapters deleted successfully."))
 	return nil
 }
+
+// -----------------------------------------------------------------------------
+// chapters from-transcript
+// -----------------------------------------------------------------------------
+
+func newChaptersFromTranscriptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "from-transcript <episode-id> <transcript.vtt>",
+		Short: "Create chapters from a WebVTT transcript",
+		Long: `Propose chapters from a WebVTT transcript, such as one written by
+"episodes transcribe", and create the ones you keep.
+
+Cues starting with --marker (default "Chapter:") start a chapter titled
+with the rest of the cue, so a host can say "Chapter: listener questions"
+while recording. Without marker cues, chapters start at the longest
+silences between cues, preferring silences before a short cue that sounds
+like a heading, and are at least --min-length long.
+
+The proposed chapters are listed and, in a terminal, you pick the ones to
+create. --force creates them all without asking; --dry-run only lists them.
+An episode that has chapters needs --replace, which deletes them first.
+
+Examples:
+  spreaker chapters from-transcript 12345 transcript.vtt --dry-run
+  spreaker chapters from-transcript 12345 transcript.vtt --min-length 5m --max 8
+  spreaker chapters from-transcript 12345 transcript.vtt --marker "Segment:" --replace`,
+		Args: cobra.ExactArgs(2),
+		RunE: runChaptersFromTranscript,
+	}
+
+	cmd.Flags().String("marker", "Chapter:", "Prefix of cues that start a chapter")
+	cmd.Flags().Duration("min-gap", 2*time.Second, "Shortest silence that can end a chapter")
+	cmd.Flags().Duration("min-length", 3*time.Minute, "Shortest chapter found from silences")
+	cmd.Flags().Int("max", 0, "Most chapters found from silences (0 = no limit)")
+	cmd.Flags().Bool("replace", false, "Delete the episode's chapters first")
+	cmd.Flags().Bool("dry-run", false, "Only list the proposed chapters")
+	cmd.Flags().BoolP("force", "f", false, "Create all proposed chapters without asking")
+
+	return cmd
+}
+
+func runChaptersFromTranscript(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+	marker, _ := cmd.Flags().GetString("marker")
+	minGap, _ := cmd.Flags().GetDuration("min-gap")
+	minLength, _ := cmd.Flags().GetDuration("min-length")
+	max, _ := cmd.Flags().GetInt("max")
+	replace, _ := cmd.Flags().GetBool("replace")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if max < 0 {
+		return fmt.Errorf("invalid max %d: must not be negative", max)
+	}
+
+	f, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	cues, err := transcribe.ParseVTT(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[1], err)
+	}
+
+	formatter := getFormatter(cmd)
+	chapters := transcribe.DetectChapters(cues, transcribe.ChapterOptions{Marker: marker, MinGap: minGap, MinLength: minLength, Max: max})
+	if len(chapters) == 0 {
+		formatter.PrintMessage(i18n.T("No chapters found in the transcript."))
+		return nil
+	}
+	if dryRun {
+		formatter.PrintChapters(chapters)
+		return nil
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	existing, err := client.GetAllEpisodeChapters(episodeID)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && !replace {
+		return fmt.Errorf("episode %d already has %d chapters; pass --replace to delete them first", episodeID, len(existing))
+	}
+
+	formatter.PrintChapters(chapters)
+	chapters, err = chooseChapters(cmd, chapters)
+	if err != nil {
+		return err
+	}
+	if len(chapters) == 0 {
+		formatter.PrintMessage(i18n.T("No chapters chosen."))
+		return nil
+	}
+
+	if len(existing) > 0 {
+		prompt := fmt.Sprintf("Delete the %d chapters of episode %d?", len(existing), episodeID)
+		if ok, err := confirm(cmd, actionDelete, prompt, episodeTitle(client, episodeID)); !ok {
+			return err
+		}
+		if err := client.DeleteAllChapters(episodeID); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range chapters {
+		startsAt := c.StartsAt
+		if _, err := client.AddChapter(episodeID, api.ChapterParams{StartsAt: &startsAt, Title: c.Title}); err != nil {
+			return fmt.Errorf("created %d of %d chapters: %w", i, len(chapters), err)
+		}
+	}
+
+	formatter.PrintSuccess(i18n.T("Created %d chapters on episode %d", len(chapters), episodeID))
+	return nil
+}
+
+// chooseChapters lets the user pick the proposed chapters to create, all
+// selected by default. --force chooses them all; without a terminal, or
+// with --no-input, --force is needed.
+func chooseChapters(cmd *cobra.Command, chapters []models.Chapter) ([]models.Chapter, error) {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return chapters, nil
+	}
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput || !isInteractive() {
+		return nil, fmt.Errorf("the proposed chapters need review; pass --force to create them all")
+	}
+
+	options := make([]string, len(chapters))
+	byOption := make(map[string]models.Chapter, len(chapters))
+	for i, c := range chapters {
+		options[i] = fmt.Sprintf("%-9s %s", time.Duration(c.StartsAt)*time.Millisecond, c.Title)
+		byOption[options[i]] = c
+	}
+	chosen, err := pterm.DefaultInteractiveMultiselect.
+		WithOptions(options).
+		WithDefaultOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Select the chapters to create"))
+	if err != nil {
+		return nil, fmt.Errorf("chapter selection cancelled: %w", err)
+	}
+
+	kept := make([]models.Chapter, 0, len(chosen))
+	for _, option := range chosen {
+		kept = append(kept, byOption[option])
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].StartsAt < kept[j].StartsAt })
+	return kept, nil
+}
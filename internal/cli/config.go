@@ -12,14 +12,36 @@ The parent command just groups related functionality.
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"net/url"
+	"io/fs"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
 	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/output"
 )
 
+// The config package checks custom headers and preprocess steps with the
+// rules of the api and audio packages, which it does not import.
+func init() {
+	config.CheckHeader = api.CheckHeader
+	config.CheckPreprocess = func(steps []string) error {
+		_, err := audio.ParseSteps(steps)
+		return err
+	}
+}
+
 func newConfigCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
@@ -36,7 +58,10 @@ Configuration is stored in a YAML file at:
 	cmd.AddCommand(
 		newConfigShowCmd(),
 		newConfigSetCmd(),
+		newConfigUnsetCmd(),
+		newConfigKeysCmd(),
 		newConfigPathCmd(),
+		newConfigValidateCmd(),
 	)
 
 	return cmd
@@ -58,41 +83,217 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage(fmt.Sprintf("Config file: %s", config.ConfigFilePath()))
-
-	// Mask the token for security.
-	tokenDisplay := "(not set)"
-	if cfg.Token != "" {
-		if len(cfg.Token) > 4 {
-			tokenDisplay = "****" + cfg.Token[len(cfg.Token)-4:]
-		} else {
-			tokenDisplay = "****"
-		}
-	}
+	formatter.PrintMessage(i18n.T("Config file: %s", config.ConfigFilePath()))
 
-	formatter.PrintKeyValue([][2]string{
-		{"token:", tokenDisplay},
+	pairs := [][2]string{
+		{"token:", maskSecret(cfg.Token)},
+		{"rotation_tokens:", maskSecrets(cfg.RotationTokens)},
+		{"identity_ttl:", cfg.IdentityTTL},
 		{"default_show_id:", fmt.Sprintf("%d", cfg.DefaultShowID)},
+		{"network_shows:", joinInts(cfg.NetworkShows)},
 		{"output_format:", cfg.OutputFormat},
 		{"api_url:", cfg.APIURL},
-	})
+		{"language:", cfg.Language},
+		{"locale:", cfg.Locale},
+		{"date_format:", cfg.DateFormat},
+		{"relative_dates:", strconv.FormatBool(cfg.RelativeDates)},
+		{"truncate:", strconv.FormatBool(cfg.Truncate)},
+		{"max_width:", strconv.Itoa(cfg.MaxWidth)},
+		{"number_format:", cfg.NumberFormat},
+		{"timezone:", cfg.Timezone},
+		{"pager:", cfg.Pager},
+		{"read_only:", strconv.FormatBool(cfg.ReadOnly)},
+		{"offline_queue:", strconv.FormatBool(cfg.OfflineQueue)},
+		{"hooks.episode_published:", cfg.Hooks.EpisodePublished},
+		{"confirm.delete:", cfg.Confirm.Policy("delete")},
+		{"confirm.update:", cfg.Confirm.Policy("update")},
+		{"confirm.upload:", cfg.Confirm.Policy("upload")},
+		{"smtp.host:", cfg.SMTP.Host},
+		{"smtp.port:", fmt.Sprintf("%d", cfg.SMTP.Port)},
+		{"smtp.username:", cfg.SMTP.Username},
+		{"smtp.password:", maskSecret(cfg.SMTP.Password)},
+		{"smtp.from:", cfg.SMTP.From},
+		{"smtp.to:", strings.Join(cfg.SMTP.To, ",")},
+	}
+
+	showIDs := make([]string, 0, len(cfg.Shows))
+	for id := range cfg.Shows {
+		showIDs = append(showIDs, id)
+	}
+	sort.Strings(showIDs)
+	for _, id := range showIDs {
+		d := cfg.Shows[id]
+		prefix := "shows." + id + "."
+		if len(d.Tags) > 0 {
+			pairs = append(pairs, [2]string{prefix + "tags:", strings.Join(d.Tags, ",")})
+		}
+		if d.Explicit != nil {
+			pairs = append(pairs, [2]string{prefix + "explicit:", strconv.FormatBool(*d.Explicit)})
+		}
+		if d.Downloadable != nil {
+			pairs = append(pairs, [2]string{prefix + "downloadable:", strconv.FormatBool(*d.Downloadable)})
+		}
+		if d.DescriptionFooter != "" {
+			pairs = append(pairs, [2]string{prefix + "description_footer:", d.DescriptionFooter})
+		}
+		if len(d.Preprocess) > 0 {
+			pairs = append(pairs, [2]string{prefix + "preprocess:", strings.Join(d.Preprocess, ",")})
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Campaigns))
+	for name := range cfg.Campaigns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := cfg.Campaigns[name]
+		pairs = append(pairs, [2]string{"campaigns." + name + ":", c.From + ".." + c.To})
+	}
+
+	headers := make([]string, 0, len(cfg.CustomHeaders))
+	for name := range cfg.CustomHeaders {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	for _, name := range headers {
+		pairs = append(pairs, [2]string{"custom_headers." + name + ":", cfg.CustomHeaders[name]})
+	}
+
+	formatter.PrintKeyValue(pairs)
 	return nil
 }
 
+// maskSecrets masks each of a list of tokens, comma-separated.
+func maskSecrets(secrets []string) string {
+	if len(secrets) == 0 {
+		return "(not set)"
+	}
+	masked := make([]string, len(secrets))
+	for i, s := range secrets {
+		masked[i] = maskSecret(s)
+	}
+	return strings.Join(masked, ",")
+}
+
+// joinInts formats a list of IDs comma-separated.
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
+
+// maskSecret hides a token or password in config show, keeping the last
+// four characters of long values so they can be told apart.
+func maskSecret(secret string) string {
+	switch {
+	case secret == "":
+		return "(not set)"
+	case len(secret) > 4:
+		return "****" + secret[len(secret)-4:]
+	default:
+		return "****"
+	}
+}
+
 // newConfigSetCmd creates the "config set" subcommand.
 func newConfigSetCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
-		Long: `Set a configuration value. Available keys:
+		Long: `Set a configuration value. Run "spreaker config keys" to list every key
+with its type and default. Available keys:
 
   default_show_id  Your default show ID (used when no show ID is specified)
-  output_format    Output format: table, json, plain
+  network_shows    Shows covered by "spreaker network" (comma-separated IDs;
+                   default: all your shows)
+  output_format    Output format: table, wide, json, plain, ci
   api_url          API base URL (for debugging/testing)
+  language         Language for messages and table headers: en, it
+  locale           Locale of API data such as category and language names,
+                   e.g. it_IT (default: the API's)
+  date_format      Go time layout for dates in tables (e.g. "02 Jan 2006 15:04")
+  relative_dates   Show dates in tables as "3 days ago": true or false
+  truncate         Truncate long text such as titles in table cells: true
+                   (default) or false
+  max_width        Width long text is truncated at in table cells (default
+                   0: each column's width, grown to fit the terminal)
+  number_format    Counts in tables: raw (default), grouped (1,234,567) or
+                   human (1.2M)
+  timezone         Time zone for dates in tables and stats date ranges:
+                   an IANA name or "local"
+  pager            Program used to page long table output (default: $PAGER,
+                   then less); "off" disables paging
+  read_only        Refuse API requests that change data: true or false
+  offline_queue    Queue changes made while the API is unreachable, for
+                   "spreaker queue flush": true or false
+  rotation_tokens  Further tokens of the same account (comma-separated);
+                   requests rotate over them and the login token, moving
+                   on when one is rate limited
+  identity_ttl     How long the account of a --token or SPREAKER_TOKEN stays
+                   cached, e.g. 24h (default) or 30m; 0 asks the API each run
+
+  hooks.episode_published
+                   Shell command or webhook URL run after an episode upload.
+                   Commands receive the episode JSON on stdin; webhooks
+                   receive it as a POST body.
+
+  confirm.delete  Confirm deletions: always (default), never, or title
+                  (type the show or episode title, e.g. before deleting
+                  a show)
+  confirm.update  Confirm show and episode updates: always, never
+                  (default), or title
+  confirm.upload  Confirm episode uploads and publishes: always, never
+                  (default), or title
+
+  shows.<show-id>.tags                Tags added to every upload (comma-separated)
+  shows.<show-id>.explicit            Default for --explicit: true or false
+  shows.<show-id>.downloadable        Default for --downloadable: true or false
+  shows.<show-id>.description_footer  Text appended to every episode description
+  shows.<show-id>.preprocess          ffmpeg steps run on the audio file before
+                                      upload (comma-separated): trim-silence,
+                                      normalize[:LUFS], add-intro:FILE,
+                                      add-outro:FILE
+
+  smtp.host       SMTP server for "report weekly --smtp"
+  smtp.port       SMTP server port (default 587)
+  smtp.username   SMTP user name; empty sends without authentication
+  smtp.password   SMTP password
+  smtp.from       Sender address of report emails
+  smtp.to         Recipient addresses of report emails (comma-separated)
+
+  campaigns.<name>       Date window for "stats ... --campaign <name>",
+                         as FROM..TO
+  campaigns.<name>.from  First day of an existing campaign (YYYY-MM-DD)
+  campaigns.<name>.to    Last day of an existing campaign (YYYY-MM-DD)
+
+  custom_headers.<header>  Header added to every API request, e.g. a tenant
+                           or tracing header a gateway requires
 
 Examples:
   spreaker config set default_show_id 12345
-  spreaker config set output_format json`,
+  spreaker config set network_shows "12345,67890"
+  spreaker config set output_format json
+  spreaker config set language it
+  spreaker config set locale it_IT
+  spreaker config set relative_dates true
+  spreaker config set truncate false
+  spreaker config set timezone Europe/Rome
+  spreaker config set pager "less -S"
+  spreaker config set read_only true
+  spreaker config set offline_queue true
+  spreaker config set rotation_tokens "$TOKEN_2,$TOKEN_3"
+  spreaker config set hooks.episode_published "./scripts/announce.sh"
+  spreaker config set hooks.episode_published https://example.com/hooks/spreaker
+  spreaker config set confirm.delete title
+  spreaker config set shows.12345.tags "tech,weekly"
+  spreaker config set shows.12345.description_footer "Support the show: https://example.com"
+  spreaker config set shows.12345.preprocess "trim-silence,normalize:-16LUFS,add-intro:intro.mp3"
+  spreaker config set smtp.to "me@example.com,cohost@example.com"
+  spreaker config set campaigns.launch 2024-03-01..2024-03-31
+  spreaker config set custom_headers.X-Tenant acme`,
 		Args: cobra.ExactArgs(2),
 		RunE: runConfigSet,
 	}
@@ -115,32 +316,351 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		}
 		cfg.DefaultShowID = id
 
+	case "network_shows":
+		cfg.NetworkShows = nil
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			id, err := parseShowID(part)
+			if err != nil {
+				return err
+			}
+			cfg.NetworkShows = append(cfg.NetworkShows, id)
+		}
+
 	case "output_format":
-		if value != "table" && value != "json" && value != "plain" {
-			return fmt.Errorf("invalid format: %s (must be table, json, or plain)", value)
+		if value != "table" && value != "wide" && value != "json" && value != "plain" && value != "ci" {
+			return fmt.Errorf("invalid format: %s (must be table, wide, json, plain, or ci)", value)
 		}
 		cfg.OutputFormat = value
 
 	case "api_url":
-		u, err := url.Parse(value)
-		if err != nil || u.Scheme != "https" {
-			return fmt.Errorf("api_url must be a valid HTTPS URL, got %q", value)
+		if !config.ValidAPIURL(value) {
+			return fmt.Errorf("api_url must be a valid HTTPS URL (HTTP only for local hosts), got %q", value)
 		}
 		cfg.APIURL = value
 
+	case "language":
+		if !i18n.IsSupported(value) {
+			return fmt.Errorf("invalid language: %s (must be one of: %s)", value, strings.Join(i18n.SupportedLanguages(), ", "))
+		}
+		cfg.Language = value
+
+	case "locale":
+		if !config.ValidLocale(value) {
+			return fmt.Errorf("invalid locale: %s (must look like it_IT or en)", value)
+		}
+		cfg.Locale = value
+
+	case "date_format":
+		cfg.DateFormat = value
+
+	case "relative_dates":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %s (must be true or false)", key, value)
+		}
+		cfg.RelativeDates = b
+
+	case "truncate":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %s (must be true or false)", key, value)
+		}
+		cfg.Truncate = b
+
+	case "max_width":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %s (must be a width in characters, or 0)", key, value)
+		}
+		cfg.MaxWidth = n
+
+	case "number_format":
+		if !slices.Contains(config.NumberFormats, value) {
+			return fmt.Errorf("invalid number format: %s (must be %s)", value, strings.Join(config.NumberFormats, ", "))
+		}
+		cfg.NumberFormat = value
+
+	case "timezone":
+		if _, err := output.LoadLocation(value); err != nil {
+			return fmt.Errorf("invalid timezone: %s (must be an IANA name like Europe/Rome, or local)", value)
+		}
+		cfg.Timezone = value
+
+	case "pager":
+		cfg.Pager = value
+
+	case "read_only":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %s (must be true or false)", key, value)
+		}
+		cfg.ReadOnly = b
+
+	case "offline_queue":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %s (must be true or false)", key, value)
+		}
+		cfg.OfflineQueue = b
+
+	case "rotation_tokens":
+		cfg.RotationTokens = nil
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				cfg.RotationTokens = append(cfg.RotationTokens, token)
+			}
+		}
+
+	case "identity_ttl":
+		if ttl, err := time.ParseDuration(value); err != nil || ttl < 0 {
+			return fmt.Errorf("invalid value for %s: %s (must be a duration like 24h or 30m, or 0)", key, value)
+		}
+		cfg.IdentityTTL = value
+
+	case "hooks.episode_published":
+		cfg.Hooks.EpisodePublished = value
+
+	case "confirm.delete", "confirm.update", "confirm.upload":
+		if !slices.Contains(config.ConfirmPolicies, value) {
+			return fmt.Errorf("invalid policy for %s: %s (must be %s)", key, value, strings.Join(config.ConfirmPolicies, ", "))
+		}
+		switch key {
+		case "confirm.delete":
+			cfg.Confirm.Delete = value
+		case "confirm.update":
+			cfg.Confirm.Update = value
+		default:
+			cfg.Confirm.Upload = value
+		}
+
+	case "smtp.host":
+		cfg.SMTP.Host = value
+
+	case "smtp.port":
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port: %s (must be between 1 and 65535)", value)
+		}
+		cfg.SMTP.Port = port
+
+	case "smtp.username":
+		cfg.SMTP.Username = value
+
+	case "smtp.password":
+		cfg.SMTP.Password = value
+
+	case "smtp.from":
+		cfg.SMTP.From = value
+
+	case "smtp.to":
+		cfg.SMTP.To = nil
+		for _, to := range strings.Split(value, ",") {
+			if to = strings.TrimSpace(to); to != "" {
+				cfg.SMTP.To = append(cfg.SMTP.To, to)
+			}
+		}
+
+	default:
+		switch {
+		case strings.HasPrefix(key, "shows."):
+			err = setShowDefault(cfg, key, value)
+		case strings.HasPrefix(key, "campaigns."):
+			err = setCampaign(cfg, key, value)
+		case strings.HasPrefix(key, "custom_headers."):
+			err = setCustomHeader(cfg, key, value)
+		default:
+			err = fmt.Errorf("unknown key: %s", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return err
+	}
+
+	switch key {
+	case "smtp.password":
+		value = "****"
+	case "rotation_tokens":
+		value = maskSecrets(cfg.RotationTokens)
+	}
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Set %s = %s", key, value))
+	return nil
+}
+
+// setCustomHeader sets a "custom_headers.<header>" header. Names are
+// stored in lowercase, as the config file does not keep their case.
+func setCustomHeader(cfg *config.Config, key, value string) error {
+	name := strings.TrimPrefix(key, "custom_headers.")
+	if err := api.CheckHeader(name, value); err != nil {
+		return err
+	}
+	if cfg.CustomHeaders == nil {
+		cfg.CustomHeaders = make(map[string]string)
+	}
+	cfg.CustomHeaders[strings.ToLower(name)] = value
+	return nil
+}
+
+// setShowDefault sets a "shows.<show-id>.<field>" upload default.
+func setShowDefault(cfg *config.Config, key, value string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("unknown key: %s (expected shows.<show-id>.<field>)", key)
+	}
+	showID, err := parseShowID(parts[1])
+	if err != nil {
+		return err
+	}
+	id := strconv.Itoa(showID)
+
+	if cfg.Shows == nil {
+		cfg.Shows = make(map[string]config.ShowDefaults)
+	}
+	d := cfg.Shows[id]
+
+	switch parts[2] {
+	case "tags":
+		d.Tags = nil
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				d.Tags = append(d.Tags, t)
+			}
+		}
+	case "explicit", "downloadable":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %s (must be true or false)", key, value)
+		}
+		if parts[2] == "explicit" {
+			d.Explicit = &b
+		} else {
+			d.Downloadable = &b
+		}
+	case "description_footer":
+		d.DescriptionFooter = value
+	case "preprocess":
+		d.Preprocess = nil
+		for _, step := range strings.Split(value, ",") {
+			if step = strings.TrimSpace(step); step == "" {
+				continue
+			}
+			if _, err := audio.ParseStep(step); err != nil {
+				return err
+			}
+			d.Preprocess = append(d.Preprocess, step)
+		}
+	default:
+		return fmt.Errorf("unknown key: %s (show fields: tags, explicit, downloadable, description_footer, preprocess)", key)
+	}
+
+	cfg.Shows[id] = d
+	return nil
+}
+
+// setCampaign sets "campaigns.<name>" to a FROM..TO window, or one date of
+// it with "campaigns.<name>.from" or ".to". Save rejects incomplete windows.
+func setCampaign(cfg *config.Config, key, value string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+		return fmt.Errorf("unknown key: %s (expected campaigns.<name> or campaigns.<name>.<field>)", key)
+	}
+	name := strings.ToLower(parts[1])
+
+	if cfg.Campaigns == nil {
+		cfg.Campaigns = make(map[string]config.Campaign)
+	}
+	c := cfg.Campaigns[name]
+
+	field := ""
+	if len(parts) == 3 {
+		field = parts[2]
+	}
+	switch field {
+	case "":
+		from, to, ok := strings.Cut(value, "..")
+		if !ok {
+			return fmt.Errorf("invalid value for %s: %s (must be FROM..TO, e.g. 2024-03-01..2024-03-31)", key, value)
+		}
+		c.From, c.To = strings.TrimSpace(from), strings.TrimSpace(to)
+	case "from":
+		c.From = value
+	case "to":
+		c.To = value
 	default:
-		return fmt.Errorf("unknown key: %s", key)
+		return fmt.Errorf("unknown key: %s (campaign fields: from, to)", key)
+	}
+
+	cfg.Campaigns[name] = c
+	return nil
+}
+
+// newConfigUnsetCmd creates the "config unset" subcommand.
+func newConfigUnsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Reset a configuration value to its default",
+		Long: `Reset a configuration value to its default.
+
+"shows.<show-id>.<field>" clears a single upload default of a show, and
+"shows.<show-id>" removes all of them. "campaigns.<name>" removes a
+campaign.
+
+Examples:
+  spreaker config unset default_show_id
+  spreaker config unset hooks.episode_published
+  spreaker config unset shows.12345.description_footer
+  spreaker config unset shows.12345
+  spreaker config unset campaigns.launch`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigUnset,
 	}
+}
 
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := config.Unset(cfg, key); err != nil {
+		return err
+	}
 	if err := config.Save(cfg); err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Set %s = %s", key, value))
+	formatter.PrintSuccess(i18n.T("Unset %s", key))
 	return nil
 }
 
+// newConfigKeysCmd creates the "config keys" subcommand.
+func newConfigKeysCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "keys",
+		Short: "List all configuration keys",
+		Long: `List every supported configuration key with its type, default value
+and description.
+
+Examples:
+  spreaker config keys
+  spreaker config keys -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			getFormatter(cmd).PrintConfigKeys(config.Keys())
+			return nil
+		},
+	}
+}
+
 // newConfigPathCmd creates the "config path" subcommand.
 func newConfigPathCmd() *cobra.Command {
 	return &cobra.Command{
@@ -152,3 +672,104 @@ func newConfigPathCmd() *cobra.Command {
 		},
 	}
 }
+
+// apiReachableTimeout bounds the api_url check of "config validate".
+const apiReachableTimeout = 10 * time.Second
+
+// newConfigValidateCmd creates the "config validate" subcommand.
+func newConfigValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for problems",
+		Long: `Check the config file and the effective configuration for problems:
+  - values of the wrong type, e.g. "default_show_id: abc"
+  - invalid values for output_format, language, timezone, api_url,
+    the shows.<show-id> upload defaults and campaigns.<name> windows
+  - unknown keys, e.g. typos (warnings)
+  - an api_url that cannot be reached (skipped with --offline)
+
+Exits with a non-zero status when there are errors, or any problem
+with --strict. "config set" and "login" run the same value checks and
+refuse to save a broken configuration.
+
+Examples:
+  spreaker config validate
+  spreaker config validate --offline
+  spreaker config validate --strict -o json`,
+		Args: cobra.NoArgs,
+		RunE: runConfigValidate,
+	}
+
+	cmd.Flags().Bool("offline", false, "Do not check that api_url is reachable")
+
+	return cmd
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	offline, _ := cmd.Flags().GetBool("offline")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	path := config.ConfigFilePath()
+	problems, err := config.ValidateFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		problems = append(problems, config.Problem{Key: "(file)", Message: err.Error(), Severity: config.SeverityError})
+	} else {
+		valueProblems := cfg.Validate()
+		problems = append(problems, valueProblems...)
+		if !offline && cfg.APIURL != "" && !hasProblem(valueProblems, "api_url") {
+			if err := checkAPIReachable(cmd.Context(), cfg.APIURL); err != nil {
+				problems = append(problems, config.Problem{Key: "api_url", Message: err.Error(), Severity: config.SeverityError})
+			}
+		}
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintMessage(i18n.T("Config file: %s", path))
+
+	if len(problems) == 0 {
+		formatter.PrintSuccess(i18n.T("Configuration is valid"))
+		return nil
+	}
+	formatter.PrintConfigProblems(problems)
+
+	if config.HasErrors(problems) || strict {
+		return fmt.Errorf("configuration has %d problem(s)", len(problems))
+	}
+	return nil
+}
+
+func hasProblem(problems []config.Problem, key string) bool {
+	for _, p := range problems {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAPIReachable makes an unauthenticated request to the API host. Any
+// HTTP response, even an error status, means the host is reachable.
+func checkAPIReachable(ctx context.Context, apiURL string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiReachableTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Transport: api.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("not reachable: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
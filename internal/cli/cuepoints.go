@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
 	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
@@ -76,8 +77,8 @@ func runCuepointsList(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(cuepoints) == 0 {
-		formatter.PrintMessage("No cuepoints found for this episode.")
+	if len(cuepoints) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No cuepoints found for this episode."))
 		return nil
 	}
 
@@ -140,9 +141,9 @@ func runCuepointsSet(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 	if len(cuepoints) == 0 {
-		formatter.PrintMessage("All cuepoints deleted successfully.")
+		formatter.PrintMessage(i18n.T("All cuepoints deleted successfully."))
 	} else {
-		formatter.PrintMessage(fmt.Sprintf("Successfully set %d cuepoint(s).", len(cuepoints)))
+		formatter.PrintMessage(i18n.T("Successfully set %d cuepoint(s).", len(cuepoints)))
 	}
 	return nil
 }
@@ -181,6 +182,6 @@ func runCuepointsDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("All cuepoints deleted successfully.")
+	formatter.PrintMessage(i18n.T("All cuepoints deleted successfully."))
 	return nil
 }
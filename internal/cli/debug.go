@@ -0,0 +1,226 @@
+/*
+debug.go - Debug bundle
+
+debug bundle collects what a bug report needs into one zip file: build and
+environment details, the configuration, recent changes and, optionally, an
+HTTP dump, with tokens and other secrets removed.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/buildinfo"
+	"github.com/G10xy/spreaker-and-go/internal/bundle"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+	"github.com/G10xy/spreaker-and-go/internal/spool"
+)
+
+// debugJournalEntries is how many of the latest journal entries a bundle
+// includes by default.
+const debugJournalEntries = 50
+
+// debugEnvironment is the environment summary of a bundle.
+type debugEnvironment struct {
+	OS          string            `json:"os"`
+	Arch        string            `json:"arch"`
+	CPUs        int               `json:"cpus"`
+	ConfigFile  string            `json:"config_file"`
+	ConfigFound bool              `json:"config_found"`
+	Interactive bool              `json:"interactive"`
+	Variables   map[string]string `json:"variables"`
+}
+
+func newDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Tools for reporting bugs",
+	}
+
+	cmd.AddCommand(newDebugBundleCmd())
+
+	return cmd
+}
+
+func newDebugBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Collect a zip file to attach to a bug report",
+		Long: `Collect what maintainers need to investigate a bug into one zip file:
+
+  version.json       version, commit and Go toolchain
+  environment.json   operating system, config location, terminal, and the
+                     SPREAKER_*, locale and terminal environment variables
+  config.yaml        the config file, with secret values replaced
+  config-problems.json  problems 'spreaker config validate' would report
+  journal.json       the latest changes made through the CLI
+  queue.json         changes waiting in the offline queue
+  http/              the HTTP dump given with --http, if any
+
+Tokens, rotation tokens, the SMTP password, hook commands and custom
+header values are replaced by REDACTED wherever they appear, as is any
+bearer token. Journal entries and dumps can still hold titles and other
+show data: check the bundle before sharing it publicly.
+
+To include what a failing command sent and received, run it again with
+--dump-http first:
+
+  spreaker episodes list 12345 --dump-http ./dump
+  spreaker debug bundle --http ./dump
+
+Examples:
+  spreaker debug bundle
+  spreaker debug bundle --out bug-42.zip --journal 10`,
+		Args: cobra.NoArgs,
+		RunE: runDebugBundle,
+	}
+
+	cmd.Flags().String("out", "", "Path of the zip file to write (default: spreaker-debug-<time>.zip)")
+	cmd.Flags().String("http", "", "Directory of an HTTP dump written with --dump-http to include")
+	cmd.Flags().Int("journal", debugJournalEntries, "Number of the latest journal entries to include")
+
+	return cmd
+}
+
+func runDebugBundle(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	httpDir, _ := cmd.Flags().GetString("http")
+	entries, _ := cmd.Flags().GetInt("journal")
+	if out == "" {
+		out = fmt.Sprintf("spreaker-debug-%s.zip", time.Now().Format("20060102-150405"))
+	}
+	if httpDir != "" {
+		if info, err := os.Stat(httpDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("HTTP dump directory not found: %s", httpDir)
+		}
+	}
+
+	// A broken config is worth reporting too: keep going with what loaded.
+	cfg, cfgErr := config.Load()
+	secrets := cfg.Secrets()
+	if token, _ := cmd.Flags().GetString("token"); token != "" {
+		secrets = append(secrets, token)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	w := bundle.NewWriter(f, secrets)
+
+	err = writeDebugBundle(cmd, w, cfg, cfgErr, httpDir, entries)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(out)
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Debug bundle written to %s (%d files)", out, len(w.Files())))
+	formatter.PrintMessage(i18n.T("Check its content before sharing it publicly."))
+	return nil
+}
+
+func writeDebugBundle(cmd *cobra.Command, w *bundle.Writer, cfg *config.Config, cfgErr error, httpDir string, entries int) error {
+	if err := w.AddJSON("version.json", buildinfo.Read(cmd.Root().Version)); err != nil {
+		return err
+	}
+	if err := w.AddJSON("environment.json", debugEnv()); err != nil {
+		return err
+	}
+
+	path := config.ConfigFilePath()
+	data, err := config.RedactedFile(path)
+	if err != nil {
+		data = []byte(fmt.Sprintf("# %v\n", err))
+	}
+	if data != nil {
+		if err := w.Add("config.yaml", data); err != nil {
+			return err
+		}
+	}
+	// The checks of config validate, without its API probe.
+	problems, _ := config.ValidateFile(path)
+	if cfgErr != nil {
+		problems = append(problems, config.Problem{Key: "(file)", Message: cfgErr.Error(), Severity: config.SeverityError})
+	} else {
+		problems = append(problems, cfg.Validate()...)
+	}
+	if problems == nil {
+		problems = []config.Problem{}
+	}
+	if err := w.AddJSON("config-problems.json", problems); err != nil {
+		return err
+	}
+
+	if path, err := config.DataFilePath(journalFile); err == nil {
+		j, err := journal.Load(path)
+		if err != nil {
+			return err
+		}
+		if len(j.Entries) > entries {
+			j.Entries = j.Entries[len(j.Entries)-entries:]
+		}
+		if err := w.AddJSON("journal.json", j); err != nil {
+			return err
+		}
+	}
+	if path, err := config.DataFilePath(queueFile); err == nil {
+		q, err := spool.Load(path)
+		if err != nil {
+			return err
+		}
+		if err := w.AddJSON("queue.json", q); err != nil {
+			return err
+		}
+	}
+
+	if httpDir != "" {
+		return w.AddDir("http", httpDir)
+	}
+	return nil
+}
+
+// debugEnv summarizes the environment the CLI runs in. Only variables that
+// affect it are included, and those holding credentials are redacted.
+func debugEnv() debugEnvironment {
+	env := debugEnvironment{
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		CPUs:        runtime.NumCPU(),
+		ConfigFile:  config.ConfigFilePath(),
+		Interactive: isInteractive(),
+		Variables:   make(map[string]string),
+	}
+	_, err := os.Stat(env.ConfigFile)
+	env.ConfigFound = err == nil
+
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		switch {
+		case strings.HasPrefix(name, "SPREAKER_"):
+			if config.IsSecretKey(strings.ToLower(strings.TrimPrefix(name, "SPREAKER_"))) {
+				value = api.Redacted
+			}
+		case name == "LANG", name == "TERM", name == "NO_COLOR", name == "PAGER", strings.HasPrefix(name, "LC_"):
+		default:
+			continue
+		}
+		env.Variables[name] = value
+	}
+	return env
+}
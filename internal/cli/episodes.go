@@ -5,23 +5,40 @@ This file contains all commands related to podcast episodes:
   - list: List episodes of a show
   - get: Get details of a specific episode
   - upload: Upload a new episode
+  - replace-audio: Upload a corrected audio file to an episode
   - delete: Delete an episode
+  - delete-many: Delete the episodes of a show that match filters
+  - hide, unhide: Set the visibility of several episodes at once
+  - like, unlike, bookmark, unbookmark: Like or bookmark one or more episodes
+  - waveform: Download the waveform of an episode
+  - calendar: Export the publishing calendar as iCalendar
 */
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
 	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/filename"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/ical"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newEpisodesCmd() *cobra.Command {
@@ -42,16 +59,25 @@ Examples:
 		newEpisodesListCmd(),
 		newEpisodesGetCmd(),
 		newEpisodesUploadCmd(),
+		newEpisodesReplaceAudioCmd(),
 		newEpisodesUpdateCmd(),
+		newEpisodesSuggestTagsCmd(),
 		newEpisodesDraftCmd(),
 		newEpisodesDeleteCmd(),
+		newEpisodesDeleteManyCmd(),
+		newEpisodesHideCmd(),
+		newEpisodesUnhideCmd(),
 		newEpisodesDownloadCmd(),
+		newEpisodesWaveformCmd(),
+		newEpisodesTranscribeCmd(),
 		newEpisodesDownloadAllCmd(),
 		newEpisodesLikesCmd(),
+		newEpisodesLikedByCmd(),
 		newEpisodesLikeCmd(),
 		newEpisodesUnlikeCmd(),
 		newEpisodesBookmarkCmd(),
 		newEpisodesUnbookmarkCmd(),
+		newEpisodesCalendarCmd(),
 	)
 
 	return cmd
@@ -68,11 +94,28 @@ func newEpisodesListCmd() *cobra.Command {
 		Long: `List episodes of a show.
 
 If no show-id is provided, uses the default_show_id from your config.
-Set a default with: spreaker config set default_show_id <id>`,
+Set a default with: spreaker config set default_show_id <id>
+
+Without either, you are asked to pick one of your shows when running in a
+terminal. Pass --no-input to fail instead.
+
+--min-duration and --max-duration list only episodes of that length; all
+the show's episodes are then searched for the first --limit matches.
+--with-total-duration ends the table with the number of episodes listed and
+their total and average duration.
+
+Examples:
+  spreaker episodes list 12345
+  spreaker episodes list 12345 --min-duration 45m --with-total-duration
+  spreaker episodes list 12345 --max-duration 10m --limit 100`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: runEpisodesList,
 	}
 
 	cmd.Flags().IntP("limit", "l", 20, "Maximum number of episodes to list")
+	cmd.Flags().Duration("min-duration", 0, "Only list episodes at least this long, e.g. 30m")
+	cmd.Flags().Duration("max-duration", 0, "Only list episodes at most this long, e.g. 1h30m")
+	cmd.Flags().Bool("with-total-duration", false, "End the table with the total duration of the listed episodes")
 
 	return cmd
 }
@@ -83,40 +126,53 @@ func runEpisodesList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Determine show ID: from argument or default config
-	var showID int
-	if len(args) > 0 {
-		showID, err = parseShowID(args[0])
+	// Determine show ID: from argument, default config, or interactive picker
+	showID, err := resolveShowID(cmd, client, args)
+	if err != nil {
+		return err
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	minDuration, _ := cmd.Flags().GetDuration("min-duration")
+	maxDuration, _ := cmd.Flags().GetDuration("max-duration")
+	if minDuration < 0 || maxDuration < 0 {
+		return errors.New("--min-duration and --max-duration must not be negative")
+	}
+	if maxDuration > 0 && minDuration > maxDuration {
+		return fmt.Errorf("--min-duration %s is longer than --max-duration %s", minDuration, maxDuration)
+	}
+	filter := episodeFilter{MinDuration: minDuration, MaxDuration: maxDuration}
+
+	var result *api.PaginatedResult[models.Episode]
+	if filter.MinDuration > 0 || filter.MaxDuration > 0 {
+		// The API can't filter by duration: search every episode.
+		episodes, err := client.GetAllShowEpisodes(showID)
 		if err != nil {
 			return err
 		}
-	} else {
-		// Try to use default show ID from config
-		cfg, _ := config.Load()
-		if cfg.DefaultShowID == 0 {
-			return fmt.Errorf("no show ID provided and no default_show_id configured\n" +
-				"Either provide a show ID or run: spreaker config set default_show_id <id>")
+		result = &api.PaginatedResult[models.Episode]{Items: filter.Select(episodes)}
+		if limit > 0 && len(result.Items) > limit {
+			result.Items, result.HasMore = result.Items[:limit], true
 		}
-		showID = cfg.DefaultShowID
-	}
-
-	limit, _ := cmd.Flags().GetInt("limit")
-	result, err := client.GetShowEpisodes(showID, api.PaginationParams{Limit: limit})
-	if err != nil {
+	} else if result, err = client.GetShowEpisodes(showID, api.PaginationParams{Limit: limit}); err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No episodes found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
+	if total, _ := cmd.Flags().GetBool("with-total-duration"); total {
+		formatter.PrintEpisodesDuration(result.Items)
+	}
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more episodes available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more episodes available, use --limit to see more)"))
 	}
 
 	return nil
@@ -127,12 +183,24 @@ func runEpisodesList(cmd *cobra.Command, args []string) error {
 // -----------------------------------------------------------------------------
 
 func newEpisodesGetCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "get <episode-id>",
 		Short: "Get details of a specific episode",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runEpisodesGet,
+		Long: `Get details of a specific episode.
+
+With --full, the audio file is also inspected, without downloading it, for
+its type, size and average bitrate, and the URL of its waveform is shown.
+
+Examples:
+  spreaker episodes get 67890
+  spreaker episodes get 67890 --full`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesGet,
 	}
+
+	cmd.Flags().Bool("full", false, "Also show the technical details of the audio file")
+
+	return cmd
 }
 
 func runEpisodesGet(cmd *cobra.Command, args []string) error {
@@ -152,17 +220,34 @@ func runEpisodesGet(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
+	if full, _ := cmd.Flags().GetBool("full"); full {
+		media, err := episodeMedia(cmd, client, episodeID)
+		if err != nil {
+			formatter.PrintWarning(i18n.T("Audio file details unavailable: %v", err))
+		}
+		formatter.PrintEpisodeFull(episode, media)
+		return nil
+	}
 	formatter.PrintEpisode(episode)
 	return nil
 }
 
+// episodeMedia inspects the audio file of an episode.
+func episodeMedia(cmd *cobra.Command, client *api.Client, episodeID int) (*api.MediaInfo, error) {
+	downloadURL, err := client.GetEpisodeDownloadURL(episodeID)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetMediaInfo(cmd.Context(), downloadURL)
+}
+
 // -----------------------------------------------------------------------------
 // episodes upload
 // -----------------------------------------------------------------------------
 
 func newEpisodesUploadCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "upload <show-id> <audio-file>",
+		Use:   "upload [show-id] <audio-file>",
 		Short: "Upload a new episode",
 		Long: `Upload a new episode to a show.
 
@@ -175,9 +260,42 @@ Examples:
     --title "Episode 42: The Answer" \
     --description "In this episode we discuss everything." \
     --tags "science,philosophy" \
-    --explicit`,
-		Args: cobra.ExactArgs(2),
-		RunE: runEpisodesUpload,
+    --explicit
+
+  spreaker episodes upload 12345 ./raw.wav --title "Episode 2" \
+    --preprocess "trim-silence,normalize:-16LUFS,add-intro:intro.mp3"
+
+Per-show defaults from the config (shows.<show-id>.*) are applied first:
+default tags are added to --tags, the description footer is appended to
+--description, and --explicit/--downloadable use the show default unless
+given explicitly.
+
+If show-id is omitted, the default_show_id from your config is used, or you
+are asked to pick one of your shows when running in a terminal (unless
+--no-input is set).
+
+Before uploading, the show is checked for an episode with the same title,
+or with the same --external-id when one is given (stored as an
+"external_id:<id>" tag). If one exists the upload is aborted, so retried
+CI jobs don't publish twice; --skip-duplicate reports the existing episode
+and exits successfully instead, and --allow-duplicate skips the check.
+
+With confirm.upload set to always or title, you are asked to confirm the
+upload (title: type the show's title); --force skips the confirmation.
+
+The show's preprocess steps (shows.<show-id>.preprocess) are run on the
+file with ffmpeg before it is uploaded, e.g. to trim silence, normalize the
+loudness and add an intro, and the result is uploaded as MP3. --preprocess
+runs other steps instead, and --no-preprocess uploads the file as it is.
+
+With --qc, the file to upload is first checked with ffmpeg as "audio check"
+does. If it is outside the podcast norms the report is shown and you are
+asked whether to upload anyway; --force uploads without asking.
+
+With --notify, a desktop notification tells when the upload finished or
+failed.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: notifyDone(runEpisodesUpload),
 	}
 
 	// Required flag
@@ -189,16 +307,24 @@ Examples:
 	cmd.Flags().StringSlice("tags", nil, "Tags (comma-separated)")
 	cmd.Flags().Bool("explicit", false, "Mark as explicit content")
 	cmd.Flags().Bool("downloadable", true, "Allow downloads")
+	cmd.Flags().String("external-id", "", "Stable ID used to detect re-uploads (stored as a tag)")
+	cmd.Flags().Bool("allow-duplicate", false, "Upload even if the show already has this episode")
+	cmd.Flags().Bool("skip-duplicate", false, "Exit successfully without uploading if the show already has this episode")
+	cmd.Flags().Bool("force", false, "Skip confirmation prompt (see confirm.upload)")
+	cmd.Flags().Bool("qc", false, "Check loudness, peaks, silence and clipping before uploading")
+	cmd.Flags().StringSlice("preprocess", nil, "ffmpeg steps run on the file before uploading, instead of the show's (comma-separated)")
+	cmd.Flags().Bool("no-preprocess", false, "Upload the file without the show's preprocess steps")
+	cmd.MarkFlagsMutuallyExclusive("preprocess", "no-preprocess")
+	addNormsFlags(cmd)
+	addNotifyFlag(cmd)
 
 	return cmd
 }
 
 func runEpisodesUpload(cmd *cobra.Command, args []string) error {
-	showID, err := parseShowID(args[0])
-	if err != nil {
-		return err
-	}
-	audioFile := args[1]
+	// The audio file is always the last argument; a show ID may precede it.
+	audioFile := args[len(args)-1]
+	showArgs := args[:len(args)-1]
 
 	// Verify file exists before making API call
 	// This gives a better error message than a failed upload
@@ -212,33 +338,327 @@ func runEpisodesUpload(cmd *cobra.Command, args []string) error {
 	tags, _ := cmd.Flags().GetStringSlice("tags")
 	explicit, _ := cmd.Flags().GetBool("explicit")
 	downloadable, _ := cmd.Flags().GetBool("downloadable")
+	externalID, _ := cmd.Flags().GetString("external-id")
+	allowDuplicate, _ := cmd.Flags().GetBool("allow-duplicate")
+	skipDuplicate, _ := cmd.Flags().GetBool("skip-duplicate")
 
-	client, err := getClient(cmd)
-	if err != nil {
-		return err
+	if externalID != "" {
+		tags = append(tags, externalIDTag(externalID))
 	}
 
-	formatter := getFormatter(cmd)
-	spinner := formatter.StartSpinner(fmt.Sprintf("Uploading %s...", audioFile))
-
-	episode, err := client.UploadEpisode(showID, api.UploadEpisodeParams{
+	params := api.UploadEpisodeParams{
 		Title:           title,
 		MediaFile:       audioFile,
 		Description:     description,
 		Tags:            tags,
 		Explicit:        explicit,
 		DownloadEnabled: downloadable,
-	})
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	showID, err := resolveShowID(cmd, client, showArgs)
 	if err != nil {
-		formatter.StopSpinner(spinner, false, err.Error())
+		return err
+	}
+	applyShowDefaults(cmd, showID, &params)
+
+	formatter := getFormatter(cmd)
+
+	if !allowDuplicate {
+		dup, err := showDuplicate(cmd.Context(), client, showID, title, externalID)
+		if err != nil {
+			return fmt.Errorf("failed to check for duplicate episodes: %w", err)
+		}
+		if dup != nil {
+			if skipDuplicate {
+				formatter.PrintWarning(i18n.T("Episode already exists (ID %d), skipping upload", dup.EpisodeID))
+				formatter.PrintEpisode(dup)
+				return nil
+			}
+			return fmt.Errorf("show %d already has episode %d %q; use --allow-duplicate to upload anyway or --skip-duplicate to skip", showID, dup.EpisodeID, dup.Title)
+		}
+	}
+
+	steps, err := uploadSteps(cmd, showID)
+	if err != nil {
+		return err
+	}
+	if len(steps) > 0 {
+		processed, cleanup, err := preprocessUpload(cmd, formatter, audioFile, steps)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		params.MediaFile = processed
+	}
+	if qc, _ := cmd.Flags().GetBool("qc"); qc {
+		if ok, err := uploadQC(cmd, formatter, params.MediaFile); !ok {
+			return err
+		}
+	}
+
+	prompt := fmt.Sprintf("Upload %s to show %d?", audioFile, showID)
+	if ok, err := confirm(cmd, actionUpload, prompt, showTitle(client, showID)); !ok {
+		return err
+	}
+
+	spinner := formatter.StartSpinner(fmt.Sprintf("Uploading %s...", audioFile))
+
+	var dupCheck func() (*models.Episode, error)
+	if !allowDuplicate {
+		dupCheck = func() (*models.Episode, error) {
+			return showDuplicate(cmd.Context(), client, showID, title, externalID)
+		}
+	}
+
+	episode, err := uploadEpisode(client, showID, params, dupCheck)
+	if err != nil {
+		formatter.CancelSpinner(spinner)
 		return err
 	}
 
 	formatter.StopSpinner(spinner, true, "Episode uploaded!")
+	// Record the checksum, so replace-audio can tell whether a later file
+	// differs. It is the checksum of the file given, before pre-processing.
+	hash, err := catalog.HashFile(audioFile)
+	if err == nil {
+		err = recordAudioHash(episode.EpisodeID, hash)
+	}
+	if err != nil {
+		formatter.PrintWarning(i18n.T("Could not record the audio checksum: %v", err))
+	}
 	formatter.PrintEpisode(episode)
+
+	runEpisodePublishedHook(cmd, formatter, episode)
+	return nil
+}
+
+// uploadEpisode uploads an episode, retrying once when the upload timed
+// out or the connection dropped. Both tries send the same idempotency key.
+// A lost response does not mean a lost upload, so before retrying,
+// findExisting, if not nil, looks for the episode the first try may have
+// created; without it, as with --allow-duplicate, there is no retry.
+func uploadEpisode(client *api.Client, showID int, params api.UploadEpisodeParams, findExisting func() (*models.Episode, error)) (*models.Episode, error) {
+	client = client.WithIdempotencyKey(api.NewIdempotencyKey())
+
+	episode, err := client.UploadEpisode(showID, params)
+	if err == nil || !api.IsNetworkError(err) || findExisting == nil {
+		return episode, err
+	}
+
+	existing, checkErr := findExisting()
+	if checkErr != nil {
+		return nil, fmt.Errorf("%w (could not check whether the episode was created: %v)", err, checkErr)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	return client.UploadEpisode(showID, params)
+}
+
+// externalIDTag is the tag that records an upload's --external-id.
+func externalIDTag(id string) string {
+	return "external_id:" + id
+}
+
+// showDuplicate returns the episode of a show that an upload would
+// duplicate, or nil. An external ID is looked for in the tags and
+// description, which episode lists do not carry, so each episode is then
+// fetched on its own.
+func showDuplicate(ctx context.Context, client *api.Client, showID int, title, externalID string) (*models.Episode, error) {
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return nil, err
+	}
+	if externalID != "" {
+		if episodes, err = episodeDetails(ctx, client, episodes, api.DefaultConcurrency); err != nil {
+			return nil, err
+		}
+	}
+	return findDuplicateEpisode(episodes, title, externalID), nil
+}
+
+// findDuplicateEpisode returns the episode that an upload would duplicate:
+// with an external ID, the episode carrying its tag (or mentioning it in
+// the description); otherwise the episode with the same title, ignoring
+// case and surrounding spaces.
+func findDuplicateEpisode(episodes []models.Episode, title, externalID string) *models.Episode {
+	for i, e := range episodes {
+		if externalID != "" {
+			tag := externalIDTag(externalID)
+			for _, t := range e.Tags {
+				if strings.EqualFold(t, tag) {
+					return &episodes[i]
+				}
+			}
+			if strings.Contains(e.Description, tag) {
+				return &episodes[i]
+			}
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(e.Title), strings.TrimSpace(title)) {
+			return &episodes[i]
+		}
+	}
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// episodes replace-audio
+// -----------------------------------------------------------------------------
+
+// audioHashesFile stores the checksums of uploaded audio, under the data
+// directory.
+var audioHashesFile = filepath.Join(catalogDir, "audio-hashes.json")
+
+func newEpisodesReplaceAudioCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replace-audio <episode-id> <audio-file>",
+		Short: "Replace the audio file of an episode",
+		Long: `Upload a corrected audio file to an existing episode. Unlike deleting
+the episode and uploading it again, the episode keeps its ID, URLs, plays
+and likes.
+
+The checksum of the audio uploaded to each episode by this CLI is kept
+locally. If the new file is identical to the last upload, nothing is
+uploaded; use --allow-unchanged to upload it anyway. Episodes uploaded
+elsewhere have no checksum to compare, so their file is always uploaded.
+
+As with "episodes upload", the show's preprocess steps run on the file
+before it is uploaded, unless --preprocess or --no-preprocess is given.
+The checksum is that of the file given, before pre-processing.
+
+Examples:
+  spreaker episodes replace-audio 67890 ./episode-42-fixed.mp3
+  spreaker episodes replace-audio 67890 ./episode-42-fixed.mp3 --force --notify`,
+		Args: cobra.ExactArgs(2),
+		RunE: notifyDone(runEpisodesReplaceAudio),
+	}
+
+	cmd.Flags().Bool("allow-unchanged", false, "Upload even if the file is identical to the last upload")
+	cmd.Flags().Bool("force", false, "Skip confirmation prompt (see confirm.upload)")
+	cmd.Flags().StringSlice("preprocess", nil, "ffmpeg steps run on the file before uploading, instead of the show's (comma-separated)")
+	cmd.Flags().Bool("no-preprocess", false, "Upload the file without the show's preprocess steps")
+	cmd.MarkFlagsMutuallyExclusive("preprocess", "no-preprocess")
+	cmd.Flags().String("ffmpeg", audio.DefaultFFmpeg, "ffmpeg program")
+	addNotifyFlag(cmd)
+
+	return cmd
+}
+
+func runEpisodesReplaceAudio(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+	audioFile := args[1]
+	if _, err := os.Stat(audioFile); os.IsNotExist(err) {
+		return fmt.Errorf("file not found: %s", audioFile)
+	}
+	allowUnchanged, _ := cmd.Flags().GetBool("allow-unchanged")
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Hash the file, which may be large, while the episode is fetched.
+	type hashResult struct {
+		hash catalog.AudioHash
+		err  error
+	}
+	hashed := make(chan hashResult, 1)
+	go func() {
+		hash, err := catalog.HashFile(audioFile)
+		hashed <- hashResult{hash, err}
+	}()
+
+	episode, err := client.GetEpisode(episodeID)
+	result := <-hashed
+	if err != nil {
+		return err
+	}
+	if result.err != nil {
+		return result.err
+	}
+
+	path, err := config.DataFilePath(audioHashesFile)
+	if err != nil {
+		return err
+	}
+	hashes, err := catalog.LoadAudioHashes(path)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	previous, known := hashes.Episodes[episodeID]
+	switch {
+	case !known:
+		formatter.PrintWarning(i18n.T("No checksum recorded for episode %d, so it cannot be compared with the new file", episodeID))
+	case previous.SHA256 == result.hash.SHA256 && !allowUnchanged:
+		formatter.PrintWarning(i18n.T("%s is identical to the audio uploaded to episode %d on %s; nothing to upload", audioFile, episodeID, previous.UploadedAt.Format(time.DateOnly)))
+		return nil
+	}
+
+	steps, err := uploadSteps(cmd, episode.ShowID)
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Replace the audio of episode %d (%s) with %s?", episodeID, episode.Title, audioFile)
+	if ok, err := confirm(cmd, actionUpload, prompt, func() (string, error) { return episode.Title, nil }); !ok {
+		return err
+	}
+
+	mediaFile := audioFile
+	if len(steps) > 0 {
+		processed, cleanup, err := preprocessUpload(cmd, formatter, audioFile, steps)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		mediaFile = processed
+	}
+
+	spinner := formatter.StartSpinner(fmt.Sprintf("Uploading %s...", audioFile))
+	updated, err := client.ReplaceEpisodeAudio(episodeID, mediaFile)
+	if err != nil {
+		formatter.CancelSpinner(spinner)
+		return err
+	}
+	formatter.StopSpinner(spinner, true, "Audio replaced!")
+
+	if err := recordAudioHash(episodeID, result.hash); err != nil {
+		formatter.PrintWarning(i18n.T("Could not record the audio checksum: %v", err))
+	}
+	formatter.PrintEpisode(updated)
+	return nil
+}
+
+// recordAudioHash records the checksum of the audio just uploaded to an
+// episode.
+func recordAudioHash(episodeID int, hash catalog.AudioHash) error {
+	path, err := config.DataFilePath(audioHashesFile)
+	if err != nil {
+		return err
+	}
+	hashes, err := catalog.LoadAudioHashes(path)
+	if err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(hash.File); err == nil {
+		hash.File = abs
+	}
+	hash.UploadedAt = time.Now().UTC()
+	hashes.Episodes[episodeID] = hash
+	return hashes.Save(path)
+}
+
 // -----------------------------------------------------------------------------
 // episodes delete
 // -----------------------------------------------------------------------------
@@ -254,41 +674,449 @@ WARNING: This action cannot be undone.`,
 		RunE: runEpisodesDelete,
 	}
 
-	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runEpisodesDelete(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to delete episode %d?", episodeID)
+	if ok, err := confirm(cmd, actionDelete, prompt, episodeTitle(client, episodeID)); !ok {
+		return err
+	}
+
+	if err := client.DeleteEpisode(episodeID); err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Episode %d deleted", episodeID))
+	return nil
+}
+
+
+// -----------------------------------------------------------------------------
+// episodes delete-many
+// -----------------------------------------------------------------------------
+
+func newEpisodesDeleteManyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-many <show-id>",
+		Short: "Delete the episodes of a show that match filters",
+		Long: `Delete every episode of a show that matches all the given filters, instead
+of looping over "episodes delete" in a shell script.
+
+The matching episodes are listed first. To go ahead you have to type
+"delete N episodes" (N being their number), unless --force is set. Before
+deleting, their metadata is archived as JSON in the archive directory next
+to the config file (or to --archive), so titles, descriptions and tags are
+not lost. Episodes are then deleted at most --rate per second.
+
+WARNING: This action cannot be undone.
+
+Filters (at least one is required):
+  --older-than  Published longer ago than this age: Nd, Nw, Nm or Ny
+                (days, weeks, months, years), e.g. 2y
+  --tag         Has this tag (repeat or comma-separate for any of several)
+
+Examples:
+  spreaker episodes delete-many 12345 --older-than 2y --tag archive --dry-run
+  spreaker episodes delete-many 12345 --older-than 18m
+  spreaker episodes delete-many 12345 --tag test --force --rate 1`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesDeleteMany,
+	}
+
+	cmd.Flags().String("older-than", "", "Only episodes published longer ago than this age (e.g. 90d, 2y)")
+	cmd.Flags().StringSlice("tag", nil, "Only episodes with one of these tags")
+	cmd.Flags().Bool("dry-run", false, "List the matching episodes without deleting them")
+	cmd.Flags().String("archive", "", "Archive file for the deleted episodes' metadata (default: in the config directory)")
+	cmd.Flags().Float64("rate", 2, "Maximum deletions per second")
+	cmd.Flags().BoolP("force", "f", false, "Skip the typed confirmation")
+
+	return cmd
+}
+
+func runEpisodesDeleteMany(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	archivePath, _ := cmd.Flags().GetString("archive")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if olderThan == "" && len(tags) == 0 {
+		return errors.New("at least one filter is required (--older-than, --tag)")
+	}
+	if rate <= 0 {
+		return fmt.Errorf("invalid --rate %v (must be greater than 0)", rate)
+	}
+	filter := episodeFilter{Tags: tags}
+	if olderThan != "" {
+		if filter.PublishedBefore, err = ageCutoff(olderThan, time.Now()); err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	formatter := getFormatter(cmd)
+
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+	selected := filter.Select(episodes)
+	if len(selected) == 0 {
+		formatter.PrintMessage(i18n.T("No episodes match the filters."))
+		return nil
+	}
+
+	formatter.PrintEpisodes(selected)
+	if dryRun {
+		formatter.PrintMessage(i18n.T("Dry run: %d of %d episodes would be deleted", len(selected), len(episodes)))
+		return nil
+	}
+
+	if !force {
+		if noInput, _ := cmd.Flags().GetBool("no-input"); noInput {
+			return errors.New("typed confirmation required; pass --force to skip it")
+		}
+		prompt := fmt.Sprintf("This deletes %d of the %d episodes of show %d.", len(selected), len(episodes), showID)
+		if !confirmTitle(prompt, fmt.Sprintf("delete %d episodes", len(selected))) {
+			formatter.PrintMessage(i18n.T("Cancelled."))
+			return nil
+		}
+	}
+
+	if archivePath == "" {
+		name := fmt.Sprintf("show-%d-%s.json", showID, time.Now().UTC().Format("20060102-150405"))
+		if archivePath, err = config.DataFilePath(filepath.Join("archive", name)); err != nil {
+			return err
+		}
+	}
+	if err := archiveEpisodes(archivePath, selected); err != nil {
+		return err
+	}
+	formatter.PrintMessage(i18n.T("Archived the metadata of %d episodes to %s", len(selected), archivePath))
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	var deleted, failed int
+	for i, ep := range selected {
+		if i > 0 {
+			select {
+			case <-ticker.C:
+			case <-cmd.Context().Done():
+				return cmd.Context().Err()
+			}
+		}
+		formatter.PrintMessage(i18n.T("[%d/%d] Deleting: %s", i+1, len(selected), ep.Title))
+		if err := client.DeleteEpisode(ep.EpisodeID); err != nil {
+			formatter.PrintError(errors.New(i18n.T("[%d/%d] Failed to delete episode %d: %v", i+1, len(selected), ep.EpisodeID, err)))
+			failed++
+			continue
+		}
+		deleted++
+	}
+
+	formatter.PrintMessage("")
+	formatter.PrintMessage(i18n.T("  Deleted: %d", deleted))
+	if failed > 0 {
+		formatter.PrintMessage(i18n.T("  Failed:  %d", failed))
+		return fmt.Errorf("%d of %d episodes could not be deleted", failed, len(selected))
+	}
+	formatter.PrintSuccess(i18n.T("Deleted %d episodes", deleted))
+	return nil
+}
+
+// episodeFilter selects episodes for bulk operations. Zero fields match
+// every episode.
+type episodeFilter struct {
+	// PublishedBefore matches episodes published before it; episodes
+	// never published don't match.
+	PublishedBefore time.Time
+
+	// Tags matches episodes with any of the tags, ignoring case.
+	Tags []string
+
+	// MinDuration and MaxDuration match episodes at least and at most
+	// that long.
+	MinDuration time.Duration
+	MaxDuration time.Duration
+}
+
+// Select returns the episodes that match every set filter.
+func (f episodeFilter) Select(episodes []models.Episode) []models.Episode {
+	var selected []models.Episode
+	for _, e := range episodes {
+		if !f.PublishedBefore.IsZero() && (e.PublishedAt == nil || !e.PublishedAt.Before(f.PublishedBefore)) {
+			continue
+		}
+		if len(f.Tags) > 0 && !hasAnyTag(e.Tags, f.Tags) {
+			continue
+		}
+		d := time.Duration(e.Duration) * time.Millisecond
+		if (f.MinDuration > 0 && d < f.MinDuration) || (f.MaxDuration > 0 && d > f.MaxDuration) {
+			continue
+		}
+		selected = append(selected, e)
+	}
+	return selected
+}
+
+// hasAnyTag reports whether tags holds one of want, ignoring case.
+func hasAnyTag(tags, want []string) bool {
+	for _, t := range tags {
+		for _, w := range want {
+			if strings.EqualFold(strings.TrimSpace(t), strings.TrimSpace(w)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ageCutoff returns the time an age such as "90d", "6w", "18m" or "2y"
+// before now.
+func ageCutoff(age string, now time.Time) (time.Time, error) {
+	v := strings.ToLower(strings.TrimSpace(age))
+	if len(v) < 2 {
+		return time.Time{}, fmt.Errorf("invalid age %q: use Nd, Nw, Nm or Ny", age)
+	}
+	n, err := strconv.Atoi(v[:len(v)-1])
+	if err != nil || n <= 0 {
+		return time.Time{}, fmt.Errorf("invalid age %q: use Nd, Nw, Nm or Ny", age)
+	}
+	switch v[len(v)-1] {
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	case 'y':
+		return now.AddDate(-n, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid age %q: use Nd, Nw, Nm or Ny", age)
+}
+
+// archiveEpisodes writes the metadata of episodes to path as JSON.
+func archiveEpisodes(path string, episodes []models.Episode) error {
+	data, err := json.MarshalIndent(episodes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to archive episodes: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to archive episodes: %w", err)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// episodes hide / unhide
+// -----------------------------------------------------------------------------
+
+func newEpisodesHideCmd() *cobra.Command {
+	return newEpisodesVisibilityCmd("hide", true)
+}
+
+func newEpisodesUnhideCmd() *cobra.Command {
+	return newEpisodesVisibilityCmd("unhide", false)
+}
+
+// newEpisodesVisibilityCmd builds "episodes hide" (hidden true) or
+// "episodes unhide" (hidden false).
+func newEpisodesVisibilityCmd(name string, hidden bool) *cobra.Command {
+	short, verb := "Hide episodes of a show", "Hide"
+	if !hidden {
+		short, verb = "Make hidden episodes of a show public again", "Unhide"
+	}
+
+	cmd := &cobra.Command{
+		Use:   name + " <show-id>",
+		Short: short,
+		Long: fmt.Sprintf(`%s the episodes of a show given by --ids, or every episode that matches
+all the given filters, e.g. to keep a private archive or to take seasonal
+content offline and back.
+
+Episodes that already have the wanted visibility are left alone. The
+others are updated --concurrency at a time; a failed update is reported
+and the others go on, and a summary is printed at the end.
+
+Filters:
+  --older-than  Published longer ago than this age: Nd, Nw, Nm or Ny
+                (days, weeks, months, years), e.g. 2y
+  --tag         Has this tag (repeat or comma-separate for any of several)
+
+With confirm.update set to always or title, you are asked to confirm;
+--force skips the confirmation.
+
+Examples:
+  spreaker episodes %[2]s 12345 --ids 67890,67891
+  spreaker episodes %[2]s 12345 --tag christmas --dry-run
+  spreaker episodes %[2]s 12345 --older-than 2y`, verb, name),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEpisodesSetHidden(cmd, args, hidden)
+		},
+	}
+
+	cmd.Flags().IntSlice("ids", nil, "IDs of the episodes (comma-separated)")
+	cmd.Flags().String("older-than", "", "Only episodes published longer ago than this age (e.g. 90d, 2y)")
+	cmd.Flags().StringSlice("tag", nil, "Only episodes with one of these tags")
+	cmd.Flags().Bool("dry-run", false, "List the episodes that would change without updating them")
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of episodes to update at once")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt (see confirm.update)")
+
+	return cmd
+}
+
+func runEpisodesSetHidden(cmd *cobra.Command, args []string, hidden bool) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	ids, _ := cmd.Flags().GetIntSlice("ids")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	if len(ids) == 0 && olderThan == "" && len(tags) == 0 {
+		return errors.New("give the episodes with --ids, or at least one filter (--older-than, --tag)")
+	}
+	if len(ids) > 0 && (olderThan != "" || len(tags) > 0) {
+		return errors.New("--ids cannot be combined with filters")
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+	filter := episodeFilter{Tags: tags}
+	if olderThan != "" {
+		if filter.PublishedBefore, err = ageCutoff(olderThan, time.Now()); err != nil {
+			return fmt.Errorf("--older-than: %w", err)
+		}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	formatter := getFormatter(cmd)
+
+	episodes, err := client.GetAllShowEditableEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+	var selected []models.Episode
+	if len(ids) > 0 {
+		if selected, err = episodesByID(episodes, ids); err != nil {
+			return fmt.Errorf("show %d: %w", showID, err)
+		}
+	} else {
+		selected = filter.Select(episodes)
+	}
+
+	var pending []models.Episode
+	for _, e := range selected {
+		if e.Hidden != hidden {
+			pending = append(pending, e)
+		}
+	}
+	unchanged := len(selected) - len(pending)
+	if len(pending) == 0 {
+		formatter.PrintMessage(i18n.T("No episodes to change (%d already have this visibility).", unchanged))
+		return nil
+	}
+
+	formatter.PrintEpisodes(pending)
+	if dryRun {
+		formatter.PrintMessage(i18n.T("Dry run: %d episodes would change, %d already have this visibility", len(pending), unchanged))
+		return nil
+	}
 
-	return cmd
-}
+	prompt := fmt.Sprintf("Hide %d episodes of show %d?", len(pending), showID)
+	if !hidden {
+		prompt = fmt.Sprintf("Make %d episodes of show %d public?", len(pending), showID)
+	}
+	if ok, err := confirm(cmd, actionUpdate, prompt, showTitle(client, showID)); !ok {
+		return err
+	}
 
-func runEpisodesDelete(cmd *cobra.Command, args []string) error {
-	episodeID, err := parseEpisodeID(args[0])
+	// Failures are returned as values, so one failed update does not stop
+	// the others.
+	errs, err := api.FetchAll(cmd.Context(), pending, concurrency, func(e models.Episode) (error, error) {
+		_, err := client.UpdateEpisode(e.EpisodeID, api.UpdateEpisodeParams{Hidden: &hidden})
+		return err, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	force, _ := cmd.Flags().GetBool("force")
-	if !force {
-		prompt := fmt.Sprintf("Are you sure you want to delete episode %d? [y/N]: ", episodeID)
-		if !confirmAction(prompt) {
-			formatter := getFormatter(cmd)
-			formatter.PrintMessage("Cancelled.")
-			return nil
+	var failed int
+	for i, err := range errs {
+		if err != nil {
+			formatter.PrintError(errors.New(i18n.T("Failed to update episode %d: %v", pending[i].EpisodeID, err)))
+			failed++
 		}
 	}
 
-	client, err := getClient(cmd)
-	if err != nil {
-		return err
+	formatter.PrintMessage("")
+	formatter.PrintMessage(i18n.T("  Changed:   %d", len(pending)-failed))
+	formatter.PrintMessage(i18n.T("  Unchanged: %d", unchanged))
+	if failed > 0 {
+		formatter.PrintMessage(i18n.T("  Failed:    %d", failed))
+		return fmt.Errorf("%d of %d episodes could not be updated", failed, len(pending))
 	}
-
-	if err := client.DeleteEpisode(episodeID); err != nil {
-		return err
+	if hidden {
+		formatter.PrintSuccess(i18n.T("Hid %d episodes", len(pending)))
+	} else {
+		formatter.PrintSuccess(i18n.T("Made %d episodes public", len(pending)))
 	}
-
-	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Episode %d deleted", episodeID))
 	return nil
 }
 
+// episodesByID returns the episodes with the given IDs, in the order of
+// ids, or an error naming the first ID not found.
+func episodesByID(episodes []models.Episode, ids []int) ([]models.Episode, error) {
+	byID := make(map[int]models.Episode, len(episodes))
+	for _, e := range episodes {
+		byID[e.EpisodeID] = e
+	}
+
+	selected := make([]models.Episode, 0, len(ids))
+	for _, id := range ids {
+		e, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("no episode with ID %d", id)
+		}
+		selected = append(selected, e)
+	}
+	return selected, nil
+}
 
 // -----------------------------------------------------------------------------
 // episodes download
@@ -300,7 +1128,9 @@ func newEpisodesDownloadCmd() *cobra.Command {
 		Short: "Download an episode's audio file",
 		Long: `Download an episode's audio file to your local machine.
 
-By default, the file is saved with the episode title as filename.
+By default, the file is saved with the episode title as filename, made
+safe for Windows, macOS and Linux. If that file exists, "title (2).mp3"
+and so on is used instead.
 Use --output to specify a custom filename or path.
 Use --url-only to just print the download URL without downloading.
 
@@ -349,12 +1179,12 @@ func runEpisodesDownload(cmd *cobra.Command, args []string) error {
 	// Determine output filename
 	outputPath, _ := cmd.Flags().GetString("output")
 	if outputPath == "" {
-		episode, err := client.GetEpisode(episodeID)
-		if err != nil {
-			outputPath = fmt.Sprintf("episode_%d.mp3", episodeID)
-		} else {
-			outputPath = sanitizeFilename(episode.Title) + ".mp3"
+		name := fmt.Sprintf("episode_%d", episodeID)
+		if episode, err := client.GetEpisode(episodeID); err == nil {
+			name = filename.Sanitize(episode.Title, name)
 		}
+		// Never overwrite a file the user did not name.
+		outputPath = filename.Unique(".", name, ".mp3")
 	}
 	outputPath = filepath.Clean(outputPath)
 
@@ -366,10 +1196,11 @@ func runEpisodesDownload(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	spinner := formatter.StartSpinner(fmt.Sprintf("Downloading episode %d to %s...", episodeID, outputPath))
+	msg := fmt.Sprintf("Downloading episode %d to %s...", episodeID, outputPath)
+	spinner := formatter.StartSpinner(msg)
 
-	if err := downloadFile(downloadURL, outputPath); err != nil {
-		formatter.StopSpinner(spinner, false, fmt.Sprintf("Download failed: %v", err))
+	if err := client.DownloadToFile(cmd.Context(), downloadURL, outputPath, downloadProgress(spinner, msg)); err != nil {
+		formatter.CancelSpinner(spinner)
 		return fmt.Errorf("download failed: %w", err)
 	}
 
@@ -377,63 +1208,79 @@ func runEpisodesDownload(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// downloadFile downloads a file from the given URL to the specified path.
-func downloadFile(downloadURL, destPath string) error {
-	out, err := os.Create(destPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+// downloadProgress shows the download percentage in the spinner text.
+// Without a spinner (no color, quiet) progress is not reported.
+func downloadProgress(spinner *pterm.SpinnerPrinter, msg string) api.DownloadProgress {
+	if spinner == nil {
+		return nil
 	}
-	defer out.Close()
-
-	client := &http.Client{Timeout: 10 * time.Minute}
-	resp, err := client.Get(downloadURL)
-	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+	last := -1
+	return func(written, total int64) {
+		if total <= 0 {
+			return
+		}
+		if pct := int(written * 100 / total); pct != last {
+			last = pct
+			spinner.UpdateText(fmt.Sprintf("%s %d%%", msg, pct))
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("server returned status %d", resp.StatusCode)
-	}
+// -----------------------------------------------------------------------------
+// episodes waveform
+// -----------------------------------------------------------------------------
 
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+func newEpisodesWaveformCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "waveform <episode-id>",
+		Short: "Download the waveform of an episode",
+		Long: `Download the waveform of an episode: a JSON file of the audio's peak
+levels, which players and editors draw as the progress bar. The file is
+saved as Spreaker serves it.
+
+Examples:
+  spreaker episodes waveform 67890
+  spreaker episodes waveform 67890 --out site/assets/ep42-waveform.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesWaveform,
 	}
 
-	return nil
-}
+	cmd.Flags().String("out", "waveform.json", "Path of the JSON file to write")
 
-func sanitizeFilename(name string) string {
-	replacer := strings.NewReplacer(
-		"/", "-",
-		"\\", "-",
-		":", "-",
-		"*", "",
-		"?", "",
-		"\"", "",
-		"<", "",
-		">", "",
-		"|", "",
-		"\n", " ",
-		"\r", "",
-		"\t", " ",
-	)
+	return cmd
+}
 
-	sanitized := replacer.Replace(name)
+func runEpisodesWaveform(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+	out, _ := cmd.Flags().GetString("out")
 
-	sanitized = strings.TrimSpace(sanitized)
-	sanitized = strings.Trim(sanitized, ".")
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
 
-	if len(sanitized) > 200 {
-		sanitized = sanitized[:200]
+	episode, err := client.GetEpisode(episodeID)
+	if err != nil {
+		return err
+	}
+	if episode.WaveformURL == "" {
+		return fmt.Errorf("episode %d has no waveform (status: %s)", episodeID, episode.EncodingStatus)
 	}
 
-	if sanitized == "" {
-		sanitized = "episode"
+	if dir := filepath.Dir(out); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := client.DownloadToFile(cmd.Context(), episode.WaveformURL, out, nil); err != nil {
+		return fmt.Errorf("failed to download waveform: %w", err)
 	}
 
-	return sanitized
+	getFormatter(cmd).PrintSuccess(i18n.T("Waveform saved to %s", out))
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -447,28 +1294,123 @@ func newEpisodesDownloadAllCmd() *cobra.Command {
 		Long: `Download all episodes of a show to your local machine.
 
 By default, episodes are saved to a directory named after the show title.
-Files that already exist are skipped (resume capability).
+Files that already exist are skipped (resume capability), unless their size
+differs from the one the server reports for the episode: a file cut short
+by an interrupted run is downloaded again. --verify-size=false skips every
+existing file without asking the server.
+Episodes with the same title are saved as "title (2).mp3" and so on.
+
+With --metadata, each audio file gets a JSON sidecar with the same name
+holding the episode's full metadata and chapters, and show.json describes
+the show, so the directory is a self-describing archive.
+
+If a page of the episode list keeps failing, the episodes listed before it
+are still downloaded and the command exits with status 3. The listing
+position is kept in .spreaker-pages.json in the output directory, so the
+next run continues with the page that failed. Use --fail-fast to abort
+on the first failed page instead.
+
+With --notify, a desktop notification tells when the downloads finished
+or failed.
 
 Examples:
   spreaker episodes download-all 12345
 
+  spreaker episodes download-all 12345 --notify
+
   spreaker episodes download-all 12345 --output-dir ~/podcasts/myshow
 
   spreaker episodes download-all 12345 --limit 10
 
+  # Archive with metadata: episode.json next to each episode.mp3, and show.json
+  spreaker episodes download-all 12345 --metadata
+
   # Force re-download of existing files
   spreaker episodes download-all 12345 --no-skip-existing`,
 		Args: cobra.ExactArgs(1),
-		RunE: runEpisodesDownloadAll,
+		RunE: notifyDone(runEpisodesDownloadAll),
 	}
 
 	cmd.Flags().StringP("output-dir", "O", "", "Output directory (default: ./<show-title>/)")
 	cmd.Flags().Bool("skip-existing", true, "Skip episodes that already exist locally")
+	cmd.Flags().Bool("verify-size", true, "Re-download existing files whose size differs from the server's")
 	cmd.Flags().IntP("limit", "l", 0, "Maximum number of episodes to download (0 = all)")
+	cmd.Flags().Bool("metadata", false, "Write a JSON metadata sidecar next to each episode, and show.json")
+	addNotifyFlag(cmd)
 
 	return cmd
 }
 
+// downloadStateFile holds the listing cursor of an interrupted download-all
+// in its output directory.
+const downloadStateFile = ".spreaker-pages.json"
+
+// remoteSize returns the download URL of an episode and the size of its
+// audio file from a HEAD request, 0 when the server does not tell.
+func remoteSize(ctx context.Context, client *api.Client, episodeID int) (string, int64, error) {
+	downloadURL, err := client.GetEpisodeDownloadURL(episodeID)
+	if err != nil {
+		return "", 0, err
+	}
+	media, err := client.GetMediaInfo(ctx, downloadURL)
+	if err != nil {
+		return downloadURL, 0, err
+	}
+	return downloadURL, media.Size, nil
+}
+
+// showMetadataFile describes the show in a download-all --metadata directory.
+const showMetadataFile = "show.json"
+
+// episodeSidecar is the metadata written next to each episode by
+// download-all --metadata.
+type episodeSidecar struct {
+	*models.Episode
+	Chapters []models.Chapter `json:"chapters"`
+	File     string           `json:"file"`
+}
+
+// sidecarPath returns the path of the metadata sidecar of an audio file.
+func sidecarPath(audioPath string) string {
+	return strings.TrimSuffix(audioPath, filepath.Ext(audioPath)) + ".json"
+}
+
+// writeSidecar fetches the full metadata and chapters of an episode and
+// writes them next to its audio file. The audio matters more than its
+// metadata, so a failure is only a warning.
+func writeSidecar(client *api.Client, formatter *output.Formatter, episodeID int, audioPath string) {
+	episode, err := client.GetEpisode(episodeID)
+	if err != nil {
+		formatter.PrintWarning(i18n.T("No metadata for episode %d: %v", episodeID, err))
+		return
+	}
+	chapters, err := client.GetAllEpisodeChapters(episodeID)
+	if err != nil {
+		formatter.PrintWarning(i18n.T("No metadata for episode %d: %v", episodeID, err))
+		return
+	}
+	if chapters == nil {
+		chapters = []models.Chapter{}
+	}
+
+	sidecar := episodeSidecar{Episode: episode, Chapters: chapters, File: filepath.Base(audioPath)}
+	if err := writeJSONFile(sidecarPath(audioPath), sidecar); err != nil {
+		formatter.PrintWarning(err.Error())
+	}
+}
+
+// writeJSONFile writes v to path as indented JSON.
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
 func runEpisodesDownloadAll(cmd *cobra.Command, args []string) error {
 	showID, err := parseShowID(args[0])
 	if err != nil {
@@ -491,7 +1433,7 @@ func runEpisodesDownloadAll(cmd *cobra.Command, args []string) error {
 	// Determine output directory
 	outputDir, _ := cmd.Flags().GetString("output-dir")
 	if outputDir == "" {
-		outputDir = sanitizeFilename(show.Title)
+		outputDir = filename.Sanitize(show.Title, fmt.Sprintf("show_%d", showID))
 	}
 	outputDir = filepath.Clean(outputDir)
 
@@ -501,117 +1443,129 @@ func runEpisodesDownloadAll(cmd *cobra.Command, args []string) error {
 	}
 
 	skipExisting, _ := cmd.Flags().GetBool("skip-existing")
+	verifySize, _ := cmd.Flags().GetBool("verify-size")
 	limit, _ := cmd.Flags().GetInt("limit")
+	metadata, _ := cmd.Flags().GetBool("metadata")
 
-	formatter.PrintMessage(fmt.Sprintf("Fetching episodes for show: %s", show.Title))
-
-	// Fetch all episodes using pagination
-	var allEpisodes []struct {
-		ID    int
-		Title string
+	if metadata {
+		if err := writeJSONFile(filepath.Join(outputDir, showMetadataFile), show); err != nil {
+			return err
+		}
 	}
 
-	pageLimit := 100
-	if limit > 0 && limit < pageLimit {
-		pageLimit = limit
-	}
+	formatter.PrintMessage(i18n.T("Fetching episodes for show: %s", show.Title))
 
-	result, err := client.GetShowEpisodes(showID, api.PaginationParams{Limit: pageLimit})
-	if err != nil {
-		return fmt.Errorf("failed to fetch episodes: %w", err)
+	// Remember how far the listing got, so an interrupted run resumes there.
+	if client.Pages.StateFile == "" {
+		client.Pages.StateFile = filepath.Join(outputDir, downloadStateFile)
 	}
 
-	for _, ep := range result.Items {
-		allEpisodes = append(allEpisodes, struct {
-			ID    int
-			Title string
-		}{ID: ep.EpisodeID, Title: ep.Title})
-		if limit > 0 && len(allEpisodes) >= limit {
-			break
-		}
+	// Fetch all episodes using pagination. Unless --fail-fast is set, a page
+	// that keeps failing stops the listing but the episodes found before it
+	// are still downloaded.
+	allEpisodes, err := client.GetShowEpisodesUpTo(showID, limit)
+	// The episodes of the pages a resumed listing skips were downloaded by
+	// the run that failed, so resuming is noted rather than failing.
+	var resumed *api.PartialError
+	if errors.As(err, &resumed) && len(resumed.Failures) == 0 {
+		formatter.PrintMessage(i18n.T("Resuming the episode list after page %d of an earlier run.", resumed.Resumed))
+		err = nil
 	}
-
-	// Continue fetching if there are more episodes and we haven't hit the limit
-	for result.HasMore && (limit == 0 || len(allEpisodes) < limit) {
-		nextLimit := pageLimit
-		if limit > 0 && limit-len(allEpisodes) < nextLimit {
-			nextLimit = limit - len(allEpisodes)
-		}
-
-		result, err = client.GetShowEpisodes(showID, api.PaginationParams{
-			Limit:  nextLimit,
-			Offset: result.Items[len(result.Items)-1].EpisodeID,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to fetch episodes: %w", err)
-		}
-
-		for _, ep := range result.Items {
-			allEpisodes = append(allEpisodes, struct {
-				ID    int
-				Title string
-			}{ID: ep.EpisodeID, Title: ep.Title})
-			if limit > 0 && len(allEpisodes) >= limit {
-				break
-			}
-		}
+	partial, err := splitPartial(err)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
 	}
 
 	if len(allEpisodes) == 0 {
-		formatter.PrintMessage("No episodes found.")
+		if partial != nil {
+			return partial
+		}
+		formatter.PrintMessage(i18n.T("No episodes found."))
 		return nil
 	}
 
-	formatter.PrintMessage(fmt.Sprintf("Found %d episodes to download", len(allEpisodes)))
+	formatter.PrintMessage(i18n.T("Found %d episodes to download", len(allEpisodes)))
 
 	// Download statistics
 	var downloaded, skipped, failed int
 
+	// Episodes with the same title get "title (2).mp3" and so on. Only
+	// names given out in this run count as taken, so a rerun maps each
+	// episode to the same file and --skip-existing can find it.
+	names := filename.NewSet()
+	if metadata {
+		// An episode titled "show" would get show.json as its sidecar.
+		names.Claim(outputDir, strings.TrimSuffix(showMetadataFile, ".json"), ".mp3", nil)
+	}
 	for i, ep := range allEpisodes {
-		filename := sanitizeFilename(ep.Title) + ".mp3"
-		filePath := filepath.Join(outputDir, filename)
+		filePath := names.Claim(outputDir, filename.Sanitize(ep.Title, fmt.Sprintf("episode_%d", ep.EpisodeID)), ".mp3", nil)
+		name := filepath.Base(filePath)
 
-		
+		var downloadURL string
 		if skipExisting {
-			if _, err := os.Stat(filePath); err == nil {
-				formatter.PrintMessage(fmt.Sprintf("[%d/%d] Skipping (exists): %s", i+1, len(allEpisodes), filename))
-				skipped++
-				continue
+			if local, err := os.Stat(filePath); err == nil {
+				complete := true
+				if verifySize {
+					// A file cut short by an interrupted run is downloaded
+					// again. Without a size from the server, it is kept.
+					var remote int64
+					downloadURL, remote, err = remoteSize(cmd.Context(), client, ep.EpisodeID)
+					switch {
+					case err != nil:
+						formatter.PrintWarning(i18n.T("[%d/%d] Could not check the size of %s: %v", i+1, len(allEpisodes), name, err))
+					case remote > 0 && remote != local.Size():
+						formatter.PrintMessage(i18n.T("[%d/%d] Size differs (%d bytes, expected %d): %s", i+1, len(allEpisodes), local.Size(), remote, name))
+						complete = false
+					}
+				}
+				if complete {
+					formatter.PrintMessage(i18n.T("[%d/%d] Skipping (exists): %s", i+1, len(allEpisodes), name))
+					skipped++
+					if metadata && !filename.Exists(sidecarPath(filePath)) {
+						writeSidecar(client, formatter, ep.EpisodeID, filePath)
+					}
+					continue
+				}
 			}
 		}
 
-		formatter.PrintMessage(fmt.Sprintf("[%d/%d] Downloading: %s", i+1, len(allEpisodes), filename))
+		formatter.PrintMessage(i18n.T("[%d/%d] Downloading: %s", i+1, len(allEpisodes), name))
 
-		
-		downloadURL, err := client.GetEpisodeDownloadURL(ep.ID)
-		if err != nil {
-			formatter.PrintMessage(fmt.Sprintf("  Failed to get download URL: %v", err))
-			failed++
-			continue
+		if downloadURL == "" {
+			if downloadURL, err = client.GetEpisodeDownloadURL(ep.EpisodeID); err != nil {
+				formatter.PrintError(errors.New(i18n.T("[%d/%d] Failed to get download URL for %s: %v", i+1, len(allEpisodes), name, err)))
+				failed++
+				continue
+			}
 		}
 
-
-		if err := downloadFile(downloadURL, filePath); err != nil {
-			formatter.PrintMessage(fmt.Sprintf("  Download failed: %v", err))
+		if err := client.DownloadToFile(cmd.Context(), downloadURL, filePath, nil); err != nil {
+			formatter.PrintError(errors.New(i18n.T("[%d/%d] Download failed for %s: %v", i+1, len(allEpisodes), name, err)))
 			failed++
 			continue
 		}
+		if metadata {
+			writeSidecar(client, formatter, ep.EpisodeID, filePath)
+		}
 
 		downloaded++
 	}
 
 	
 	formatter.PrintMessage("")
-	formatter.PrintMessage("Download complete!")
-	formatter.PrintMessage(fmt.Sprintf("  Downloaded: %d", downloaded))
+	formatter.PrintMessage(i18n.T("Download complete!"))
+	formatter.PrintMessage(i18n.T("  Downloaded: %d", downloaded))
 	if skipped > 0 {
-		formatter.PrintMessage(fmt.Sprintf("  Skipped:    %d", skipped))
+		formatter.PrintMessage(i18n.T("  Skipped:    %d", skipped))
 	}
 	if failed > 0 {
-		formatter.PrintMessage(fmt.Sprintf("  Failed:     %d", failed))
+		formatter.PrintMessage(i18n.T("  Failed:     %d", failed))
 	}
-	formatter.PrintMessage(fmt.Sprintf("  Location:   %s", outputDir))
+	formatter.PrintMessage(i18n.T("  Location:   %s", outputDir))
 
+	if partial != nil {
+		return partial
+	}
 	return nil
 }
 
@@ -639,6 +1593,7 @@ Examples:
 	cmd.Flags().Bool("explicit", false, "Mark as explicit content")
 	cmd.Flags().Bool("downloadable", false, "Allow downloads")
 	cmd.Flags().Bool("hidden", false, "Hide the episode")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 
 	return cmd
 }
@@ -681,13 +1636,17 @@ func runEpisodesUpdate(cmd *cobra.Command, args []string) error {
 		params.Hidden = &val
 	}
 
+	if ok, err := confirm(cmd, actionUpdate, fmt.Sprintf("Update episode %d?", episodeID), episodeTitle(client, episodeID)); !ok {
+		return err
+	}
+
 	episode, err := client.UpdateEpisode(episodeID, params)
 	if err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess("Episode updated")
+	formatter.PrintSuccess(i18n.T("Episode updated"))
 	formatter.PrintEpisode(episode)
 	return nil
 }
@@ -757,7 +1716,7 @@ func runEpisodesDraft(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Draft episode created with ID %d", episode.EpisodeID))
+	formatter.PrintSuccess(i18n.T("Draft episode created with ID %d", episode.EpisodeID))
 	formatter.PrintEpisode(episode)
 	return nil
 }
@@ -784,7 +1743,7 @@ func runEpisodesLikes(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -797,34 +1756,51 @@ func runEpisodesLikes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No liked episodes.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No liked episodes."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more episodes available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more episodes available, use --limit to see more)"))
 	}
 
 	return nil
 }
 
 // -----------------------------------------------------------------------------
-// episodes like
+// episodes liked-by
 // -----------------------------------------------------------------------------
 
-func newEpisodesLikeCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "like <episode-id>",
-		Short: "Like an episode",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runEpisodesLike,
+func newEpisodesLikedByCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "liked-by <episode-id>",
+		Short: "List the users who liked an episode",
+		Long: `List the users who liked an episode.
+
+Use --all to fetch every page instead of the first --limit users, and
+--csv to also write the list to a CSV file (same columns as
+users export-followers). For JSON, use --output json.
+
+Examples:
+  spreaker episodes liked-by 67890
+  spreaker episodes liked-by 67890 --all --csv likers.csv
+  spreaker episodes liked-by 67890 --all --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesLikedBy,
 	}
+
+	cmd.Flags().IntP("limit", "l", 20, "Maximum number of users to list")
+	cmd.Flags().Bool("all", false, "Fetch all users, following pagination")
+	cmd.Flags().String("csv", "", "Also write the users to this CSV file")
+
+	return cmd
 }
 
-func runEpisodesLike(cmd *cobra.Command, args []string) error {
+func runEpisodesLikedBy(cmd *cobra.Command, args []string) error {
 	episodeID, err := parseEpisodeID(args[0])
 	if err != nil {
 		return err
@@ -835,75 +1811,134 @@ func runEpisodesLike(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
-	if err != nil {
-		return err
-	}
-
-	if err := client.LikeEpisode(userID, episodeID); err != nil {
-		return err
-	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	csvPath, _ := cmd.Flags().GetString("csv")
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Liked episode %d", episodeID))
-	return nil
-}
 
-// -----------------------------------------------------------------------------
-// episodes unlike
-// -----------------------------------------------------------------------------
-
-func newEpisodesUnlikeCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "unlike <episode-id>",
-		Short: "Unlike an episode",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runEpisodesUnlike,
+	var users []models.User
+	var partial error
+	hasMore := false
+	if all {
+		users, err = client.GetAllEpisodeLikes(episodeID)
+		if partial, err = splitPartial(err); err != nil {
+			return err
+		}
+	} else {
+		result, err := client.GetEpisodeLikes(episodeID, api.PaginationParams{Limit: limit})
+		if err != nil {
+			return err
+		}
+		users, hasMore = result.Items, result.HasMore
+		formatter.SetPage(result.NextURL, result.HasMore)
 	}
-}
 
-func runEpisodesUnlike(cmd *cobra.Command, args []string) error {
-	episodeID, err := parseEpisodeID(args[0])
-	if err != nil {
-		return err
+	if csvPath != "" {
+		if err := writeUsersCSV(csvPath, users); err != nil {
+			return err
+		}
+		formatter.PrintSuccess(i18n.T("Exported %d users to %s", len(users), csvPath))
 	}
 
-	client, err := getClient(cmd)
-	if err != nil {
-		return err
+	if len(users) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No likes found."))
+		return nil
 	}
 
-	userID, err := getMyUserID()
-	if err != nil {
-		return err
-	}
+	formatter.PrintUsers(users)
 
-	if err := client.UnlikeEpisode(userID, episodeID); err != nil {
-		return err
+	if hasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more users available, use --limit or --all to see more)"))
 	}
 
-	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Unliked episode %d", episodeID))
-	return nil
+	return partial
 }
 
 // -----------------------------------------------------------------------------
-// episodes bookmark
+// episodes like / unlike / bookmark / unbookmark
 // -----------------------------------------------------------------------------
 
+// episodeAction is something the user does to episodes one at a time
+// through the API, such as liking them.
+type episodeAction struct {
+	name  string // command name, e.g. "like"
+	short string
+	one   string // success message for one episode, e.g. "Liked episode %d"
+	many  string // success message for several, e.g. "Liked %d episodes"
+	run   func(client *api.Client, userID, episodeID int) error
+}
+
+func newEpisodesLikeCmd() *cobra.Command {
+	return newEpisodesActionCmd(episodeAction{"like", "Like episodes", "Liked episode %d", "Liked %d episodes", (*api.Client).LikeEpisode})
+}
+
+func newEpisodesUnlikeCmd() *cobra.Command {
+	return newEpisodesActionCmd(episodeAction{"unlike", "Unlike episodes", "Unliked episode %d", "Unliked %d episodes", (*api.Client).UnlikeEpisode})
+}
+
 func newEpisodesBookmarkCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "bookmark <episode-id>",
-		Short: "Bookmark an episode",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runEpisodesBookmark,
+	return newEpisodesActionCmd(episodeAction{"bookmark", "Bookmark episodes", "Bookmarked episode %d", "Bookmarked %d episodes", (*api.Client).BookmarkEpisode})
+}
+
+func newEpisodesUnbookmarkCmd() *cobra.Command {
+	return newEpisodesActionCmd(episodeAction{"unbookmark", "Remove episodes from bookmarks", "Removed episode %d from bookmarks", "Removed %d episodes from bookmarks", (*api.Client).UnbookmarkEpisode})
+}
+
+// newEpisodesActionCmd builds the command of an episode action, taking
+// one or more episodes.
+func newEpisodesActionCmd(action episodeAction) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action.name + " [episode-id...]",
+		Short: action.short,
+		Long: fmt.Sprintf(`%s. Give the episode IDs as arguments, with --ids, or on stdin,
+one or more per line, e.g. piped from another command.
+
+Several episodes are handled --concurrency at a time in one run; a failed
+one is reported and the others go on, and a table with the outcome of each
+episode is printed at the end.
+
+Examples:
+  spreaker episodes %[2]s 67890
+  spreaker episodes %[2]s --ids 67890,67891,67892
+  spreaker episodes list 12345 -o plain | cut -f1 | spreaker episodes %[2]s`, action.short, action.name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEpisodesAction(cmd, args, action)
+		},
 	}
+
+	cmd.Flags().IntSlice("ids", nil, "IDs of the episodes (comma-separated)")
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of episodes to handle at once")
+
+	return cmd
 }
 
-func runEpisodesBookmark(cmd *cobra.Command, args []string) error {
-	episodeID, err := parseEpisodeID(args[0])
-	if err != nil {
-		return err
+func runEpisodesAction(cmd *cobra.Command, args []string, action episodeAction) error {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+	var ids []int
+	for _, arg := range args {
+		id, err := parseEpisodeID(arg)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	flagIDs, _ := cmd.Flags().GetIntSlice("ids")
+	ids = append(ids, flagIDs...)
+	if len(ids) == 0 {
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			return errors.New("give the episode IDs as arguments, with --ids, or on stdin")
+		}
+		var err error
+		if ids, err = readIDs(os.Stdin, "episode ID"); err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return errors.New("no episode IDs on stdin")
+		}
 	}
 
 	client, err := getClient(cmd)
@@ -911,54 +1946,123 @@ func runEpisodesBookmark(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
 
-	if err := client.BookmarkEpisode(userID, episodeID); err != nil {
+	formatter := getFormatter(cmd)
+
+	if len(ids) == 1 {
+		if err := action.run(client, userID, ids[0]); err != nil {
+			return err
+		}
+		formatter.PrintSuccess(i18n.T(action.one, ids[0]))
+		return nil
+	}
+
+	// Failures are returned as values, so one failed episode does not stop
+	// the others.
+	results, err := api.FetchAll(cmd.Context(), ids, concurrency, func(id int) (api.EpisodeActionResult, error) {
+		result := api.EpisodeActionResult{EpisodeID: id, Status: "done"}
+		if err := action.run(client, userID, id); err != nil {
+			result.Status, result.Reason = "failed", err.Error()
+		}
+		return result, nil
+	})
+	if err != nil {
 		return err
 	}
 
-	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Bookmarked episode %d", episodeID))
+	formatter.PrintEpisodeActions(results)
+
+	var failed int
+	for _, r := range results {
+		if r.Status == "failed" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d episodes failed", failed, len(results))
+	}
+	formatter.PrintSuccess(i18n.T(action.many, len(results)))
 	return nil
 }
 
 // -----------------------------------------------------------------------------
-// episodes unbookmark
+// episodes calendar
 // -----------------------------------------------------------------------------
 
-func newEpisodesUnbookmarkCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "unbookmark <episode-id>",
-		Short: "Remove an episode from bookmarks",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runEpisodesUnbookmark,
+func newEpisodesCalendarCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar [show-id]",
+		Short: "Export the publishing calendar of a show as iCalendar",
+		Long: `Write an iCalendar (.ics) file with an event for each published and
+scheduled episode of a show, at its publish or scheduled time. Drafts
+without a date are left out.
+
+Published episodes are confirmed events and scheduled ones are tentative,
+so they can still move. Each episode keeps the same event ID across
+exports, so regenerating the file, e.g. from cron to a location a calendar
+app subscribes to, updates events rather than duplicating them.
+
+Use --out - to write the calendar to stdout.
+
+Examples:
+  spreaker episodes calendar 12345 --out schedule.ics
+  spreaker episodes calendar --out - > schedule.ics`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runEpisodesCalendar,
 	}
+
+	cmd.Flags().String("out", "schedule.ics", "Path of the iCalendar file to write, or - for stdout")
+
+	return cmd
 }
 
-func runEpisodesUnbookmark(cmd *cobra.Command, args []string) error {
-	episodeID, err := parseEpisodeID(args[0])
+func runEpisodesCalendar(cmd *cobra.Command, args []string) error {
+	outPath, _ := cmd.Flags().GetString("out")
+
+	client, err := getClient(cmd)
 	if err != nil {
 		return err
 	}
 
-	client, err := getClient(cmd)
+	showID, err := resolveShowID(cmd, client, args)
 	if err != nil {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	show, err := client.GetShow(showID)
 	if err != nil {
 		return err
 	}
+	episodes, err := client.GetAllShowEditableEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
 
-	if err := client.UnbookmarkEpisode(userID, episodeID); err != nil {
-		return err
+	events := ical.Events(episodes)
+	now := time.Now()
+
+	if outPath == "-" {
+		return ical.Write(os.Stdout, show.Title, events, now)
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	if err := ical.Write(f, show.Title, events, now); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Removed episode %d from bookmarks", episodeID))
+	formatter.PrintSuccess(i18n.T("Exported %d episodes to %s", len(events), outPath))
 	return nil
 }
@@ -0,0 +1,198 @@
+/*
+episodes_tags.go - Tag suggestions
+
+suggest-tags extracts candidate tags from an episode's title and
+description through the internal/text package.
+*/
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/text"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// -----------------------------------------------------------------------------
+// episodes suggest-tags
+// -----------------------------------------------------------------------------
+
+func newEpisodesSuggestTagsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suggest-tags <episode-id>",
+		Short: "Suggest tags from an episode's title and description",
+		Long: `Suggest tags for an episode from the keywords of its title and
+description. Title words count more than description words, common words
+are ignored, and words that recur together are suggested as phrases. Tags
+the episode already has are not suggested.
+
+With --history, keywords matching a tag you used before on any of your
+episodes are ranked higher and take that tag's spelling, so your tags stay
+consistent.
+
+With --apply, the chosen suggestions are added to the episode's tags: in a
+terminal you pick them from a list, otherwise (or with --no-input) every
+listed suggestion is added.
+
+Examples:
+  spreaker episodes suggest-tags 67890
+  spreaker episodes suggest-tags 67890 --history --limit 5
+  spreaker episodes suggest-tags 67890 --history --apply`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesSuggestTags,
+	}
+
+	cmd.Flags().IntP("limit", "l", 10, "Maximum number of suggestions")
+	cmd.Flags().Bool("history", false, "Prefer tags used before on your episodes")
+	cmd.Flags().Bool("apply", false, "Add the chosen suggestions to the episode's tags")
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of shows and episodes to fetch at once for --history")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runEpisodesSuggestTags(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	history, _ := cmd.Flags().GetBool("history")
+	apply, _ := cmd.Flags().GetBool("apply")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if limit < 1 {
+		return fmt.Errorf("invalid limit %d: must be at least 1", limit)
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	episode, err := client.GetEpisode(episodeID)
+	if err != nil {
+		return err
+	}
+
+	var known []string
+	if history {
+		if known, err = myTags(cmd, client, concurrency); err != nil {
+			return err
+		}
+	}
+
+	suggestions := text.SuggestTags(episode.Title, episode.Description, known, episode.Tags, limit)
+
+	formatter := getFormatter(cmd)
+	if len(suggestions) == 0 {
+		formatter.PrintMessage(i18n.T("No tags to suggest."))
+		return nil
+	}
+	if !apply {
+		formatter.PrintTagSuggestions(suggestions)
+		return nil
+	}
+
+	chosen, err := chooseTags(cmd, suggestions)
+	if err != nil {
+		return err
+	}
+	if len(chosen) == 0 {
+		formatter.PrintMessage(i18n.T("No tags chosen."))
+		return nil
+	}
+
+	tags := append(append([]string(nil), episode.Tags...), chosen...)
+	prompt := fmt.Sprintf("Add %d tags to episode %d?", len(chosen), episodeID)
+	if ok, err := confirm(cmd, actionUpdate, prompt, func() (string, error) { return episode.Title, nil }); !ok {
+		return err
+	}
+
+	updated, err := client.UpdateEpisode(episodeID, api.UpdateEpisodeParams{Tags: &tags})
+	if err != nil {
+		return err
+	}
+
+	formatter.PrintSuccess(i18n.T("Added %d tags to episode %d", len(chosen), episodeID))
+	formatter.PrintEpisode(updated)
+	return nil
+}
+
+// chooseTags lets the user pick suggestions, tags used before selected by
+// default. Without a terminal, or with --no-input, all are chosen.
+func chooseTags(cmd *cobra.Command, suggestions []text.Suggestion) ([]string, error) {
+	options := make([]string, len(suggestions))
+	var defaults []string
+	for i, s := range suggestions {
+		options[i] = s.Tag
+		if s.Known {
+			defaults = append(defaults, s.Tag)
+		}
+	}
+
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput || !isInteractive() {
+		return options, nil
+	}
+
+	chosen, err := pterm.DefaultInteractiveMultiselect.
+		WithOptions(options).
+		WithDefaultOptions(defaults).
+		WithMaxHeight(15).
+		Show(i18n.T("Select the tags to add"))
+	if err != nil {
+		return nil, fmt.Errorf("tag selection cancelled: %w", err)
+	}
+	return chosen, nil
+}
+
+// myTags returns the tags of every episode of the user's shows.
+func myTags(cmd *cobra.Command, client *api.Client, concurrency int) ([]string, error) {
+	userID, err := getMyUserID(cmd, client)
+	if err != nil {
+		return nil, err
+	}
+	shows, err := client.GetAllUserShows(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(shows))
+	for i, show := range shows {
+		ids[i] = show.ShowID
+	}
+	episodes, err := api.FetchAll(cmd.Context(), ids, concurrency, func(id int) ([]models.Episode, error) {
+		episodes, err := client.GetAllShowEpisodes(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch episodes of show %d: %w", id, err)
+		}
+		return episodes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Episode lists lack tags.
+	var all []models.Episode
+	for _, list := range episodes {
+		all = append(all, list...)
+	}
+	all, err = episodeDetails(cmd.Context(), client, all, concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, e := range all {
+		tags = append(tags, e.Tags...)
+	}
+	return tags, nil
+}
@@ -0,0 +1,492 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestFindDuplicateEpisode(t *testing.T) {
+	episodes := []models.Episode{
+		{EpisodeID: 1, Title: "Episode 41"},
+		{EpisodeID: 2, Title: "Episode 42", Tags: []string{"tech", "external_id:build-42"}},
+		{EpisodeID: 3, Title: "Bonus", Description: "Notes\nexternal_id:build-7"},
+	}
+
+	tests := []struct {
+		name       string
+		title      string
+		externalID string
+		want       int
+	}{
+		{"same title", "Episode 41", "", 1},
+		{"title ignores case and spaces", "  episode 42 ", "", 2},
+		{"new title", "Episode 43", "", 0},
+		{"external id in tags", "Other", "build-42", 2},
+		{"external id in description", "Other", "build-7", 3},
+		{"external id wins over title", "Episode 41", "build-99", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findDuplicateEpisode(episodes, tt.title, tt.externalID)
+			gotID := 0
+			if got != nil {
+				gotID = got.EpisodeID
+			}
+			if gotID != tt.want {
+				t.Errorf("findDuplicateEpisode(%q, %q) = %d, want %d", tt.title, tt.externalID, gotID, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgeCutoff(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		age  string
+		want string
+	}{
+		{"90d", "2024-01-01"},
+		{"2w", "2024-03-17"},
+		{"1m", "2024-03-02"}, // February 31st normalizes to March 2nd
+		{"2Y", "2022-03-31"},
+	}
+	for _, tt := range tests {
+		got, err := ageCutoff(tt.age, now)
+		if err != nil || got.Format(time.DateOnly) != tt.want {
+			t.Errorf("ageCutoff(%q) = %v, %v; want %s", tt.age, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "y", "2", "-1y", "2h", "ten d"} {
+		if _, err := ageCutoff(bad, now); err == nil {
+			t.Errorf("ageCutoff(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestEpisodeFilter_Select(t *testing.T) {
+	at := func(day string) *models.CustomTime {
+		d, _ := time.Parse(time.DateOnly, day)
+		return &models.CustomTime{Time: d}
+	}
+	episodes := []models.Episode{
+		{EpisodeID: 1, PublishedAt: at("2020-01-01"), Tags: []string{"Archive"}, Duration: 30 * 60 * 1000},
+		{EpisodeID: 2, PublishedAt: at("2020-01-01"), Tags: []string{"news"}, Duration: 5 * 60 * 1000},
+		{EpisodeID: 3, PublishedAt: at("2024-01-01"), Tags: []string{"archive"}, Duration: 60 * 60 * 1000},
+		{EpisodeID: 4, Tags: []string{"archive"}}, // draft
+	}
+	cutoff := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter episodeFilter
+		want   []int
+	}{
+		{"older than", episodeFilter{PublishedBefore: cutoff}, []int{1, 2}},
+		{"tag", episodeFilter{Tags: []string{"archive"}}, []int{1, 3, 4}},
+		{"both", episodeFilter{PublishedBefore: cutoff, Tags: []string{"archive", "other"}}, []int{1}},
+		{"min duration", episodeFilter{MinDuration: 30 * time.Minute}, []int{1, 3}},
+		{"duration range", episodeFilter{MinDuration: time.Minute, MaxDuration: 45 * time.Minute}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, e := range tt.filter.Select(episodes) {
+				got = append(got, e.EpisodeID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Select() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Select() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEpisodesByID(t *testing.T) {
+	episodes := []models.Episode{{EpisodeID: 1}, {EpisodeID: 2}, {EpisodeID: 3}}
+
+	got, err := episodesByID(episodes, []int{3, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].EpisodeID != 3 || got[1].EpisodeID != 1 {
+		t.Errorf("episodesByID() = %+v, want episodes 3 and 1", got)
+	}
+	if _, err := episodesByID(episodes, []int{1, 9}); err == nil {
+		t.Error("episodesByID() with an unknown ID: want error")
+	}
+}
+
+func TestUploadEpisodeRetry(t *testing.T) {
+	audio := filepath.Join(t.TempDir(), "episode.mp3")
+	if err := os.WriteFile(audio, []byte("audio"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	params := api.UploadEpisodeParams{Title: "Episode 42", MediaFile: audio}
+
+	tests := []struct {
+		name     string
+		existing *models.Episode
+		wantID   int
+		wantPost int
+	}{
+		{"created by the lost try", &models.Episode{EpisodeID: 7}, 7, 1},
+		{"not created", nil, 8, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keys = append(keys, r.Header.Get(api.IdempotencyHeader))
+				if len(keys) == 1 {
+					// Drop the connection, as a timed-out upload would.
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"response":{"episode":{"episode_id":8}}}`)
+			}))
+			defer srv.Close()
+			client := api.NewClientWithOptions("token", srv.URL, 0)
+
+			episode, err := uploadEpisode(client, 1, params, func() (*models.Episode, error) {
+				return tt.existing, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if episode.EpisodeID != tt.wantID || len(keys) != tt.wantPost {
+				t.Errorf("got episode %d after %d POSTs, want %d after %d", episode.EpisodeID, len(keys), tt.wantID, tt.wantPost)
+			}
+			if len(keys) == 2 && (keys[0] == "" || keys[0] != keys[1]) {
+				t.Errorf("idempotency keys = %q, want the same key on retry", keys)
+			}
+		})
+	}
+}
+
+func TestShowDuplicate(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/shows/1/episodes":
+			// Lists carry the compact episode, without tags or description.
+			fmt.Fprint(w, `{"response":{"items":[
+				{"episode_id":4,"type":"RECORDED","title":"Episode 41","duration":60000,"show_id":1},
+				{"episode_id":5,"type":"RECORDED","title":"Episode 42","duration":60000,"show_id":1}
+			],"next_url":null}}`)
+		case "/v2/episodes/4":
+			mu.Lock()
+			fetched = append(fetched, r.URL.Path)
+			mu.Unlock()
+			fmt.Fprint(w, `{"response":{"episode":{"episode_id":4,"title":"Episode 41","tags":["news"]}}}`)
+		case "/v2/episodes/5":
+			mu.Lock()
+			fetched = append(fetched, r.URL.Path)
+			mu.Unlock()
+			fmt.Fprint(w, `{"response":{"episode":{"episode_id":5,"title":"Episode 42","tags":["news","external_id:build-9"]}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := api.NewClientWithOptions("token", srv.URL, 0)
+
+	dup, err := showDuplicate(context.Background(), client, 1, "Retitled", "build-9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dup == nil || dup.EpisodeID != 5 {
+		t.Errorf("duplicate by external ID = %+v, want episode 5", dup)
+	}
+
+	// A title needs no per-episode requests.
+	fetched = nil
+	dup, err = showDuplicate(context.Background(), client, 1, "episode 41", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dup == nil || dup.EpisodeID != 4 || len(fetched) != 0 {
+		t.Errorf("duplicate by title = %+v after fetching %v, want episode 4 from the list", dup, fetched)
+	}
+}
+
+func TestEpisodesLikeMany(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+	t.Cleanup(forgetIdentities)
+
+	var mu sync.Mutex
+	var liked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/me":
+			fmt.Fprint(w, `{"response":{"user":{"user_id":7,"username":"alice"}}}`)
+		case r.URL.Path == "/v2/users/7/likes/3":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"response":{"error":{"messages":["Episode not found"]}}}`)
+		case r.Method == http.MethodPut:
+			mu.Lock()
+			liked = append(liked, r.URL.Path)
+			mu.Unlock()
+			fmt.Fprint(w, `{"response":{}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("SPREAKER_TOKEN", "token")
+	t.Setenv("SPREAKER_API_URL", srv.URL)
+
+	cmd := newRootCmd("test")
+	cmd.SetArgs([]string{"episodes", "like", "1", "--ids", "2,3", "-o", "plain"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	if err == nil || err.Error() != "1 of 3 episodes failed" {
+		t.Errorf("error = %v, want 1 of 3 episodes failed", err)
+	}
+	slices.Sort(liked)
+	if want := []string{"/v2/users/7/likes/1", "/v2/users/7/likes/2"}; !slices.Equal(liked, want) {
+		t.Errorf("liked %v, want %v", liked, want)
+	}
+}
+
+func TestEpisodesUnhide(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+
+	var updated []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/shows/1/episodes":
+			// Hidden episodes are only in the editable listing.
+			items := `{"episode_id":4,"title":"Public","hidden":false}`
+			if r.URL.Query().Get("filter") == "editable" {
+				items += `,{"episode_id":5,"title":"Hidden","hidden":true}`
+			}
+			fmt.Fprintf(w, `{"response":{"items":[%s],"next_url":null}}`, items)
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/episodes/5":
+			updated = append(updated, r.URL.Path)
+			fmt.Fprint(w, `{"response":{"episode":{"episode_id":5,"hidden":false}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("SPREAKER_TOKEN", "token")
+	t.Setenv("SPREAKER_API_URL", srv.URL)
+
+	cmd := newRootCmd("test")
+	cmd.SetArgs([]string{"episodes", "unhide", "1", "--ids", "5", "--force", "-o", "plain"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/v2/episodes/5"}; !slices.Equal(updated, want) {
+		t.Errorf("updated %v, want %v", updated, want)
+	}
+}
+
+func TestWriteSidecar(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/episodes/5":
+			fmt.Fprint(w, `{"response":{"episode":{"episode_id":5,"title":"Five","published_at":"2024-03-01 09:30:00"}}}`)
+		case "/v2/episodes/5/chapters":
+			fmt.Fprint(w, `{"response":{"items":[{"chapter_id":1,"starts_at":0,"title":"Intro"}],"next_url":null}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := api.NewClientWithOptions("token", srv.URL, 0)
+
+	audio := filepath.Join(t.TempDir(), "Five.mp3")
+	writeSidecar(client, output.New("plain", false), 5, audio)
+
+	data, err := os.ReadFile(sidecarPath(audio))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		EpisodeID   int              `json:"episode_id"`
+		PublishedAt string           `json:"published_at"`
+		Chapters    []models.Chapter `json:"chapters"`
+		File        string           `json:"file"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.EpisodeID != 5 || got.PublishedAt == "" || len(got.Chapters) != 1 || got.File != "Five.mp3" {
+		t.Errorf("sidecar = %s", data)
+	}
+}
+
+func TestAddTranscriptLink(t *testing.T) {
+	link := "https://example.com/t/42.vtt"
+	tests := []struct {
+		description, want string
+		changed           bool
+	}{
+		{"", "Transcript: " + link, true},
+		{"Show notes.\n", "Show notes.\n\nTranscript: " + link, true},
+		{"Show notes.\n\nTranscript: " + link, "Show notes.\n\nTranscript: " + link, false},
+	}
+	for _, tt := range tests {
+		got, changed := addTranscriptLink(tt.description, link)
+		if got != tt.want || changed != tt.changed {
+			t.Errorf("addTranscriptLink(%q) = %q, %v; want %q, %v", tt.description, got, changed, tt.want, tt.changed)
+		}
+	}
+}
+
+func TestUploadSteps(t *testing.T) {
+	viper.Reset()
+	dir := t.TempDir()
+	t.Setenv("SPREAKER_CONFIG_DIR", dir)
+	t.Cleanup(viper.Reset)
+
+	cfg := &config.Config{Shows: map[string]config.ShowDefaults{
+		"12": {Preprocess: []string{"trim-silence", "add-intro:intro.mp3", "add-outro:/srv/outro.mp3"}},
+	}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+
+	steps, err := uploadSteps(newEpisodesUploadCmd(), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []audio.Step{
+		{Name: audio.StepTrimSilence},
+		{Name: audio.StepAddIntro, Arg: filepath.Join(dir, "intro.mp3")},
+		{Name: audio.StepAddOutro, Arg: "/srv/outro.mp3"},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("uploadSteps() = %+v\nwant %+v", steps, want)
+	}
+
+	// Shows without steps upload the file as it is.
+	if steps, err := uploadSteps(newEpisodesUploadCmd(), 13); err != nil || len(steps) != 0 {
+		t.Errorf("uploadSteps() without defaults = %+v, %v", steps, err)
+	}
+
+	// --preprocess replaces the show's steps, --no-preprocess drops them.
+	cmd := newEpisodesUploadCmd()
+	cmd.Flags().Set("preprocess", "normalize:-19LUFS")
+	if steps, _ := uploadSteps(cmd, 12); !reflect.DeepEqual(steps, []audio.Step{{Name: audio.StepNormalize, Arg: "-19LUFS"}}) {
+		t.Errorf("uploadSteps() with --preprocess = %+v", steps)
+	}
+	cmd = newEpisodesUploadCmd()
+	cmd.Flags().Set("no-preprocess", "true")
+	if steps, _ := uploadSteps(cmd, 12); len(steps) != 0 {
+		t.Errorf("uploadSteps() with --no-preprocess = %+v", steps)
+	}
+}
+
+func TestEpisodesReplaceAudio(t *testing.T) {
+	viper.Reset()
+	dir := t.TempDir()
+	t.Setenv("SPREAKER_CONFIG_DIR", dir)
+	t.Cleanup(viper.Reset)
+
+	cfg := &config.Config{Shows: map[string]config.ShowDefaults{"1": {Preprocess: []string{"trim-silence"}}}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploads []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/v2/episodes/5" {
+			f, _, err := r.FormFile("media_file")
+			if err != nil {
+				t.Errorf("upload without media_file: %v", err)
+			} else {
+				data, _ := io.ReadAll(f)
+				uploads = append(uploads, string(data))
+			}
+		}
+		fmt.Fprint(w, `{"response":{"episode":{"episode_id":5,"show_id":1,"title":"Pilot"}}}`)
+	}))
+	defer srv.Close()
+	t.Setenv("SPREAKER_TOKEN", "token")
+	t.Setenv("SPREAKER_API_URL", srv.URL)
+
+	audioFile := filepath.Join(dir, "fixed.mp3")
+	if err := os.WriteFile(audioFile, []byte("take 1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run := func(flags ...string) error {
+		viper.Reset()
+		cmd := newRootCmd("test")
+		cmd.SetArgs(append([]string{"episodes", "replace-audio", "5", audioFile, "--force", "-o", "plain"}, flags...))
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		return cmd.Execute()
+	}
+
+	// The show's preprocess steps run first: without ffmpeg nothing is sent.
+	if err := run("--ffmpeg", filepath.Join(dir, "no-ffmpeg")); err == nil || !strings.Contains(err.Error(), "failed to pre-process") {
+		t.Errorf("error = %v, want the pre-processing failure", err)
+	}
+	if len(uploads) != 0 {
+		t.Fatalf("uploaded %q without pre-processing", uploads)
+	}
+
+	steps := []struct {
+		name    string
+		content string
+		flags   []string
+		want    []string
+	}{
+		{"first upload", "take 1", nil, []string{"take 1"}},
+		{"unchanged file skipped", "take 1", nil, []string{"take 1"}},
+		{"unchanged file allowed", "take 1", []string{"--allow-unchanged"}, []string{"take 1", "take 1"}},
+		{"changed file", "take 2", nil, []string{"take 1", "take 1", "take 2"}},
+	}
+	for _, s := range steps {
+		if err := os.WriteFile(audioFile, []byte(s.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := run(append([]string{"--no-preprocess"}, s.flags...)...); err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if !slices.Equal(uploads, s.want) {
+			t.Errorf("%s: uploads = %q, want %q", s.name, uploads, s.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+episodes_transcribe.go - Transcripts
+
+transcribe downloads an episode's audio and runs it through a transcription
+engine of the internal/transcribe package, configured in the transcribe
+section of the config file.
+*/
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/transcribe"
+)
+
+// openAITimeout bounds an OpenAI transcription request, upload included.
+const openAITimeout = 15 * time.Minute
+
+// transcriptLinkLabel starts the line --link adds to episode descriptions.
+const transcriptLinkLabel = "Transcript: "
+
+// -----------------------------------------------------------------------------
+// episodes transcribe
+// -----------------------------------------------------------------------------
+
+func newEpisodesTranscribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transcribe <episode-id>",
+		Short: "Transcribe an episode to a WebVTT file",
+		Long: `Download an episode's audio and transcribe it to a WebVTT file.
+
+Engines:
+  whisper-cpp   runs whisper.cpp locally (transcribe.whisper_command,
+                default whisper-cli, with the model transcribe.whisper_model)
+  openai        sends the audio to the OpenAI transcription API
+                (transcribe.openai_api_key or OPENAI_API_KEY); files over
+                25 MB are refused
+
+--engine defaults to transcribe.engine. The Spreaker API cannot store
+transcripts, so publish the file yourself; with --link, a "Transcript: URL"
+line is added to the episode description once it is online.
+
+Examples:
+  spreaker episodes transcribe 67890 --engine whisper-cpp --model ~/models/ggml-base.bin
+  spreaker episodes transcribe 67890 --engine openai --language it --out ep42.vtt
+  spreaker episodes transcribe 67890 --link https://example.com/transcripts/ep42.vtt`,
+		Args: cobra.ExactArgs(1),
+		RunE: runEpisodesTranscribe,
+	}
+
+	cmd.Flags().String("engine", "", "Transcription engine: whisper-cpp or openai (default: transcribe.engine)")
+	cmd.Flags().String("out", "transcript.vtt", "Path of the WebVTT file to write")
+	cmd.Flags().String("model", "", "whisper.cpp model file, or OpenAI model name")
+	cmd.Flags().String("language", "", "Spoken language as an ISO 639-1 code (default: transcribe.language, else detected)")
+	cmd.Flags().String("link", "", "URL the transcript is published at, added to the episode description")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runEpisodesTranscribe(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+	out, _ := cmd.Flags().GetString("out")
+	link, _ := cmd.Flags().GetString("link")
+	if link != "" {
+		if u, err := url.Parse(link); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid --link %q: must be an http(s) URL", link)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	engineName, engine, err := transcribeEngine(cmd, cfg.Transcribe)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	formatter := getFormatter(cmd)
+
+	episode, err := client.GetEpisode(episodeID)
+	if err != nil {
+		return err
+	}
+	downloadURL, err := client.GetEpisodeDownloadURL(episodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get download URL: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "spreaker-transcribe-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	audio := filepath.Join(dir, fmt.Sprintf("episode_%d.mp3", episodeID))
+
+	msg := fmt.Sprintf("Downloading episode %d...", episodeID)
+	spinner := formatter.StartSpinner(msg)
+	if err := client.DownloadToFile(cmd.Context(), downloadURL, audio, downloadProgress(spinner, msg)); err != nil {
+		formatter.CancelSpinner(spinner)
+		return fmt.Errorf("download failed: %w", err)
+	}
+	formatter.StopSpinner(spinner, true, fmt.Sprintf("Downloaded episode %d", episodeID))
+
+	if d := filepath.Dir(out); d != "." {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", d, err)
+		}
+	}
+	spinner = formatter.StartSpinner(i18n.T("Transcribing with %s...", engineName))
+	if err := engine.Transcribe(cmd.Context(), audio, out); err != nil {
+		formatter.CancelSpinner(spinner)
+		return fmt.Errorf("transcription failed: %w", err)
+	}
+	formatter.StopSpinner(spinner, true, i18n.T("Transcript saved to %s", out))
+
+	if link == "" {
+		return nil
+	}
+	description, changed := addTranscriptLink(episode.Description, link)
+	if !changed {
+		formatter.PrintMessage(i18n.T("The description of episode %d already links the transcript.", episodeID))
+		return nil
+	}
+	prompt := fmt.Sprintf("Add the transcript link to the description of episode %d?", episodeID)
+	if ok, err := confirm(cmd, actionUpdate, prompt, func() (string, error) { return episode.Title, nil }); !ok {
+		return err
+	}
+	if _, err := client.UpdateEpisode(episodeID, api.UpdateEpisodeParams{Description: &description}); err != nil {
+		return err
+	}
+	formatter.PrintSuccess(i18n.T("Added the transcript link to episode %d", episodeID))
+	return nil
+}
+
+// transcribeEngine builds the engine chosen by --engine or transcribe.engine,
+// with flags taking precedence over the config.
+func transcribeEngine(cmd *cobra.Command, cfg config.TranscribeConfig) (string, transcribe.Engine, error) {
+	name, _ := cmd.Flags().GetString("engine")
+	if name == "" {
+		name = cfg.Engine
+	}
+	model, _ := cmd.Flags().GetString("model")
+	language, _ := cmd.Flags().GetString("language")
+	if language == "" {
+		language = cfg.Language
+	}
+
+	switch name {
+	case config.EngineWhisperCpp:
+		if model == "" {
+			model = cfg.WhisperModel
+		}
+		return name, transcribe.WhisperCpp{Command: cfg.WhisperCommand, Model: model, Language: language}, nil
+	case config.EngineOpenAI:
+		if model == "" {
+			model = cfg.OpenAIModel
+		}
+		key := cfg.OpenAIKey
+		if key == "" {
+			key = os.Getenv("OPENAI_API_KEY")
+		}
+		return name, transcribe.OpenAI{
+			Client:   &http.Client{Timeout: openAITimeout, Transport: api.Transport},
+			Key:      key,
+			Model:    model,
+			Language: language,
+		}, nil
+	case "":
+		return "", nil, fmt.Errorf("no transcription engine: pass --engine or set transcribe.engine (%s)", strings.Join(config.TranscribeEngines, ", "))
+	default:
+		return "", nil, fmt.Errorf("invalid engine %q (must be %s)", name, strings.Join(config.TranscribeEngines, ", "))
+	}
+}
+
+// addTranscriptLink appends a "Transcript: URL" line to a description. A
+// description that already contains the URL is returned unchanged.
+func addTranscriptLink(description, link string) (string, bool) {
+	if strings.Contains(description, link) {
+		return description, false
+	}
+	line := transcriptLinkLabel + link
+	if strings.TrimSpace(description) == "" {
+		return line, true
+	}
+	return strings.TrimRight(description, "\n ") + "\n\n" + line, true
+}
@@ -6,9 +6,12 @@ Commands for discovering podcasts by category.
 package cli
 
 import (
+	"strconv"
+
 	"github.com/spf13/cobra"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
 )
 
 func newExploreCmd() *cobra.Command {
@@ -17,11 +20,13 @@ func newExploreCmd() *cobra.Command {
 		Short: "Discover podcasts by category",
 		Long: `Discover podcasts by browsing categories.
 
-Use 'spreaker misc categories' to see available category IDs.
+Categories are given by ID or name; use 'spreaker misc categories' to see
+them.
 
 Examples:
-  spreaker explore category 14
-  spreaker explore category 14 --limit 50`,
+  spreaker explore category Technology
+  spreaker explore category "true crime" --limit 50
+  spreaker explore category 14`,
 	}
 
 	cmd.AddCommand(newExploreCategoryCmd())
@@ -31,11 +36,21 @@ Examples:
 
 func newExploreCategoryCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "category <category-id>",
+		Use:   "category <category-id|name>",
 		Short: "List shows in a category",
 		Long: `List shows in a specific category, ranked by popularity and quality.
 
-Use 'spreaker misc categories' to see available category IDs.`,
+The category is given by ID or by name. Names are looked up in the cached
+categories (see 'spreaker misc categories'), ignoring case, and may be
+partial or contain a typo. When a name matches several categories you are
+asked to choose one; with --no-input, or without a terminal, it is an
+error listing them.
+
+Examples:
+  spreaker explore category Technology
+  spreaker explore category tech
+  spreaker explore category "Cronaca nera" --locale it_IT
+  spreaker explore category 14`,
 		Args: cobra.ExactArgs(1),
 		RunE: runExploreCategory,
 	}
@@ -46,9 +61,15 @@ Use 'spreaker misc categories' to see available category IDs.`,
 }
 
 func runExploreCategory(cmd *cobra.Command, args []string) error {
-	categoryID, err := parseShowID(args[0]) // Reusing parseShowID for category ID
-	if err != nil {
-		return err
+	categoryID, err := strconv.Atoi(args[0])
+	if err != nil || categoryID <= 0 {
+		locale, err := apiLocale(cmd)
+		if err != nil {
+			return err
+		}
+		if categoryID, err = resolveCategory(cmd, args[0], locale); err != nil {
+			return err
+		}
 	}
 
 	client, err := getClient(cmd)
@@ -64,15 +85,16 @@ func runExploreCategory(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No shows found in this category.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No shows found in this category."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintExploreShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more shows available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more shows available, use --limit to see more)"))
 	}
 
 	return nil
@@ -4,10 +4,19 @@ helpers.go - Shared utility functions for CLI commands
 package cli
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -15,7 +24,11 @@ import (
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
 	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/hooks"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/monitor"
 	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 // getClient creates an API client using token from flag, env, or config.
@@ -24,7 +37,12 @@ func getClient(cmd *cobra.Command) (*api.Client, error) {
 	token, _ := cmd.Flags().GetString("token")
 
 	if token != "" {
-		fmt.Fprintln(os.Stderr, "WARNING: Passing tokens via --token exposes them in process listings. Use SPREAKER_TOKEN env var or 'spreaker login' instead.")
+		fmt.Fprintln(os.Stderr, "Warning: Passing tokens via --token exposes them in process listings. Use SPREAKER_TOKEN env var or 'spreaker login' instead.")
+	}
+
+	// Replayed requests never reach the API, so no token is needed.
+	if token == "" && replayTransport != nil {
+		token = api.Redacted
 	}
 
 	// Fall back to config (which also checks env vars)
@@ -42,22 +60,149 @@ func getClient(cmd *cobra.Command) (*api.Client, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return api.NewClientWithOptions(token, cfg.APIURL, 0), nil
+	client := api.NewClientWithOptions(token, cfg.APIURL, 0)
+
+	// Extra tokens of the account take turns with the main one, so a
+	// rate-limited token does not stop long exports.
+	if len(cfg.RotationTokens) > 0 && replayTransport == nil {
+		client.Tokens = api.NewTokenPool(append([]string{token}, cfg.RotationTokens...)...)
+	}
+	if client.Headers, err = customHeaders(cmd, cfg); err != nil {
+		return nil, err
+	}
+	if client.Locale, err = apiLocale(cmd); err != nil {
+		return nil, err
+	}
+	if err := setupHTTPDump(cmd, client, token); err != nil {
+		return nil, err
+	}
+
+	// --strict surfaces Spreaker API changes: unknown fields fail the
+	// command, missing ones are reported as warnings.
+	if strict, _ := cmd.Flags().GetBool("strict"); strict {
+		client.Strict = true
+		client.OnWarning = func(msg string) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+		}
+	}
+
+	// Tokens that list their scopes warn once before a change they do not
+	// allow, which the API would refuse with a bare 403.
+	if info := api.InspectToken(token); info.Scopes != nil {
+		client.Scopes = info.Scopes
+		var once sync.Once
+		client.OnScopeWarning = func(msg string) {
+			once.Do(func() { fmt.Fprintf(os.Stderr, "Warning: %s\n", msg) })
+		}
+	}
+
+	// Read-only mode refuses POST, PUT and DELETE before they are sent.
+	readOnly, _ := cmd.Flags().GetBool("read-only")
+	client.ReadOnly = readOnly || cfg.ReadOnly
+
+	// Changes are journaled for "spreaker undo", and queued while the API
+	// is unreachable if offline_queue is set, except replayed ones, which
+	// never reach the API.
+	if replayTransport == nil {
+		recordChanges(cmd, client)
+		if cfg.OfflineQueue {
+			client.Queue = queueOffline(cmd)
+		}
+	}
+
+	client.Context = cmd.Context()
+
+	// A --token of another account than the logged-in one is named up
+	// front, before any prompt or spinner, so it cannot edit the wrong
+	// shows unnoticed.
+	if flagToken, _ := cmd.Flags().GetString("token"); flagToken != "" && cfg.UserID != 0 && replayTransport == nil {
+		if msg := impersonationWarning(cmd, client, flagToken, cfg.UserID); msg != "" {
+			getFormatter(cmd).PrintWarning(msg)
+		}
+	}
+
+	client.Pages.FailFast, _ = cmd.Flags().GetBool("fail-fast")
+	client.Pages.StateFile, _ = cmd.Flags().GetString("page-state")
+
+	return client, nil
+}
+
+// customHeaders returns the headers of the custom_headers config key,
+// overridden by those of --header.
+func customHeaders(cmd *cobra.Command, cfg *config.Config) (http.Header, error) {
+	flags, _ := cmd.Flags().GetStringArray("header")
+	if len(cfg.CustomHeaders) == 0 && len(flags) == 0 {
+		return nil, nil
+	}
+
+	headers := make(http.Header)
+	for name, value := range cfg.CustomHeaders {
+		if err := api.CheckHeader(name, value); err != nil {
+			return nil, fmt.Errorf("custom_headers: %w", err)
+		}
+		headers.Set(name, value)
+	}
+	for _, flag := range flags {
+		name, value, err := api.ParseHeader(flag)
+		if err != nil {
+			return nil, fmt.Errorf("--header: %w", err)
+		}
+		headers.Set(name, value)
+	}
+	return headers, nil
+}
+
+// apiLocale returns the locale of localized API data: --locale, else the
+// locale config key, else "" for the API default.
+func apiLocale(cmd *cobra.Command) (string, error) {
+	locale, _ := cmd.Flags().GetString("locale")
+	if locale == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return "", fmt.Errorf("failed to load config: %w", err)
+		}
+		return cfg.Locale, nil
+	}
+	if !config.ValidLocale(locale) {
+		return "", fmt.Errorf("invalid locale %q (must look like it_IT or en)", locale)
+	}
+	return locale, nil
+}
+
+// partialExitCode is the exit status of a command whose multi-page results
+// are incomplete because a page kept failing.
+const partialExitCode = 3
+
+// splitPartial separates incomplete multi-page results from a fatal error,
+// so a command can still print the items it got. The returned partial error
+// carries partialExitCode and should be returned once output is done.
+func splitPartial(err error) (partial, fatal error) {
+	var p *api.PartialError
+	if errors.As(err, &p) {
+		return &ExitError{Code: partialExitCode, Err: err}, nil
+	}
+	return nil, err
 }
 
 // getFormatter creates an output formatter using format from flag or config.
+// It also activates the configured display language.
 func getFormatter(cmd *cobra.Command) *output.Formatter {
 	format, _ := cmd.Flags().GetString("output")
 
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+	}
+
 	// Fall back to configured default
 	if format == "" {
-		cfg, err := config.Load()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
-		}
 		format = cfg.OutputFormat
 	}
 
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
 	color := resolveColor(cmd, format)
 	if !color {
 		pterm.DisableColor()
@@ -65,13 +210,101 @@ func getFormatter(cmd *cobra.Command) *output.Formatter {
 		pterm.EnableColor()
 	}
 
-	return output.New(format, color)
+	formatter := output.New(format, color)
+	withMeta, _ := cmd.Flags().GetBool("with-meta")
+	formatter.SetWithMeta(withMeta)
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	formatter.SetColumns(columns)
+	// Commands with their own --sort, such as tags stats, order their
+	// output themselves.
+	if by, _ := cmd.Flags().GetString("sort"); by != "" && cmd.LocalNonPersistentFlags().Lookup("sort") == nil {
+		spec, err := output.ParseSort(by)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, keeping the default order\n", err)
+		} else {
+			formatter.SetSort(&spec)
+		}
+	}
+	numbers := cfg.NumberFormat
+	if human, _ := cmd.Flags().GetBool("human"); human {
+		numbers = output.NumbersHuman
+	}
+	formatter.SetNumberFormat(numbers)
+	totals, _ := cmd.Flags().GetBool("totals")
+	formatter.SetTotals(totals)
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	formatter.SetQuiet(quiet)
+
+	loc, err := output.LoadLocation(cfg.Timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid timezone %q in config, using defaults\n", cfg.Timezone)
+	}
+	formatter.SetDateOptions(cfg.DateFormat, cfg.RelativeDates, loc)
+
+	maxWidth := cfg.MaxWidth
+	if cmd.Flags().Changed("max-width") {
+		maxWidth, _ = cmd.Flags().GetInt("max-width")
+	}
+	formatter.SetTruncation(cfg.Truncate, maxWidth, terminalWidth())
+
+	if usePager(cmd, format, cfg) {
+		if pager == nil {
+			pager = output.NewPager(output.ResolvePager(cfg.Pager))
+		}
+		formatter.SetPager(pager)
+	}
+	return formatter
+}
+
+// terminalWidth returns the width of the terminal stdout writes to, or 0.
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// pager is shared by every formatter of a command run and closed by Execute.
+var pager *output.Pager
+
+// noPagerAnnotation marks commands (and their subcommands) whose output
+// must never be paged, such as long-running servers.
+const noPagerAnnotation = "no-pager"
+
+// usePager reports whether table output should go through the pager:
+// only on an interactive terminal, and not when disabled by --no-pager,
+// the pager config key or noPagerAnnotation.
+func usePager(cmd *cobra.Command, format string, cfg *config.Config) bool {
+	if format != "" && format != "table" && format != "wide" {
+		return false
+	}
+	if noPager, _ := cmd.Flags().GetBool("no-pager"); noPager {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[noPagerAnnotation]; ok {
+			return false
+		}
+	}
+	if !isInteractive() {
+		return false
+	}
+	return output.ResolvePager(cfg.Pager) != ""
+}
+
+// closePager waits for the user to quit the pager, if one was started.
+func closePager() {
+	if pager != nil {
+		pager.Close()
+		pager = nil
+	}
 }
 
 // resolveColor determines whether color output should be enabled.
 func resolveColor(cmd *cobra.Command, format string) bool {
 	// Only table format gets color
-	if format != "" && format != "table" {
+	if format != "" && format != "table" && format != "wide" {
 		return false
 	}
 
@@ -95,10 +328,30 @@ func resolveColor(cmd *cobra.Command, format string) bool {
 }
 
 
-// getMyUserID returns the authenticated user's ID from cached config,
-// avoiding an extra API round-trip to /v2/users/self.
-func getMyUserID() (int, error) {
-	return config.GetUserID()
+// getMyUserID returns the ID of the user client acts as without an extra
+// API round-trip where it can: the ID cached in config at login for the
+// login token, and the cached identity (see resolveIdentity) for a token
+// given with --token or SPREAKER_TOKEN, which may be another account's.
+func getMyUserID(cmd *cobra.Command, client *api.Client) (int, error) {
+	token, _ := cmd.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv("SPREAKER_TOKEN")
+	}
+	refresh, _ := cmd.Flags().GetBool("refresh-identity")
+	if token == "" && !refresh {
+		return config.GetUserID()
+	}
+	if token == "" {
+		if token, _ = tokenSource(cmd); token == "" {
+			return 0, errors.New("not authenticated. Run 'spreaker login' first")
+		}
+	}
+
+	id, err := resolveIdentity(cmd, client, token)
+	if err != nil {
+		return 0, fmt.Errorf("could not get the account of the token: %w", err)
+	}
+	return id.UserID, nil
 }
 
 func parseShowID(arg string) (int, error) {
@@ -129,6 +382,30 @@ func parseIntArg(arg string, fieldName string) (int, error) {
     return n, nil
 }
 
+// readIDs reads IDs separated by commas, spaces or newlines, e.g. piped
+// from another command. Lines starting with # are skipped.
+func readIDs(r io.Reader, fieldName string) ([]int, error) {
+	var ids []int
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, field := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			id, err := parseIntArg(field, fieldName)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IDs: %w", err)
+	}
+	return ids, nil
+}
+
 // validateFilter checks that the filter flag value is one of the allowed values.
 func validateFilter(filter string) error {
 	if filter == "" {
@@ -142,13 +419,255 @@ func validateFilter(filter string) error {
 	}
 }
 
+// desktopNotify shows a desktop notification; tests replace it.
+var desktopNotify = monitor.Desktop
+
+// addNotifyFlag registers --notify on a long-running command.
+func addNotifyFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("notify", false, "Show a desktop notification when the command finishes or fails")
+}
+
+// notifyDone wraps the RunE of a command with --notify, so a desktop
+// notification reports whether it finished or failed and the user can
+// leave the terminal meanwhile. Notification failures are warnings.
+func notifyDone(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := run(cmd, args)
+		if notify, _ := cmd.Flags().GetBool("notify"); !notify {
+			return err
+		}
+
+		title := i18n.T("spreaker %s finished", cmd.Name())
+		message := i18n.T("Done")
+		if err != nil {
+			title = i18n.T("spreaker %s failed", cmd.Name())
+			message = err.Error()
+		}
+		if nerr := desktopNotify(title, message); nerr != nil {
+			getFormatter(cmd).PrintWarning(i18n.T("notify: %v", nerr))
+		}
+		return err
+	}
+}
+
+// runEpisodePublishedHook invokes the configured hooks.episode_published target.
+// Hook failures are reported as warnings: the episode is already live. A
+// hidden or scheduled episode is not live yet, so the hook is skipped.
+func runEpisodePublishedHook(cmd *cobra.Command, formatter *output.Formatter, episode *models.Episode) {
+	cfg, err := config.Load()
+	if err != nil || cfg.Hooks.EpisodePublished == "" {
+		return
+	}
+	if !episodeLive(episode, time.Now()) {
+		formatter.PrintMessage(i18n.T("Episode %d is hidden or scheduled, so the episode_published hook was not run", episode.EpisodeID))
+		return
+	}
+
+	if err := hooks.Run(cmd.Context(), hooks.EventEpisodePublished, cfg.Hooks.EpisodePublished, episode); err != nil {
+		formatter.PrintWarning(i18n.T("episode_published hook: %v", err))
+	}
+}
+
+// episodeLive reports whether an episode is public at now: not hidden,
+// and not scheduled for later.
+func episodeLive(episode *models.Episode, now time.Time) bool {
+	if episode.Hidden {
+		return false
+	}
+	return episode.AutoPublishedAt == nil || episode.AutoPublishedAt.IsZero() || !episode.AutoPublishedAt.After(now)
+}
+
+// applyShowDefaults merges the show's configured upload defaults into params.
+// Flags given explicitly take precedence; default tags are added to flag tags.
+func applyShowDefaults(cmd *cobra.Command, showID int, params *api.UploadEpisodeParams) {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	d := cfg.ShowDefaultsFor(showID)
+
+	for _, tag := range d.Tags {
+		if !slices.Contains(params.Tags, tag) {
+			params.Tags = append(params.Tags, tag)
+		}
+	}
+	if d.Explicit != nil && !cmd.Flags().Changed("explicit") {
+		params.Explicit = *d.Explicit
+	}
+	if d.Downloadable != nil && !cmd.Flags().Changed("downloadable") {
+		params.DownloadEnabled = *d.Downloadable
+	}
+	if d.DescriptionFooter != "" {
+		if params.Description == "" {
+			params.Description = d.DescriptionFooter
+		} else {
+			params.Description += "\n\n" + d.DescriptionFooter
+		}
+	}
+}
+
+// resolveShowID returns the show ID from the first argument, or the configured
+// default_show_id. Without either, it lets the user pick one of their shows
+// when attached to a terminal, unless --no-input is set.
+func resolveShowID(cmd *cobra.Command, client *api.Client, args []string) (int, error) {
+	if len(args) > 0 {
+		return parseShowID(args[0])
+	}
+
+	cfg, _ := config.Load()
+	if cfg.DefaultShowID != 0 {
+		return cfg.DefaultShowID, nil
+	}
+
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	if noInput || !isInteractive() {
+		return 0, fmt.Errorf("no show ID provided and no default_show_id configured\n" +
+			"Either provide a show ID or run: spreaker config set default_show_id <id>")
+	}
+
+	return pickShow(client)
+}
+
+// isInteractive reports whether both stdin and stdout are terminals.
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// pickShow lets the user choose one of their shows interactively.
+func pickShow(client *api.Client) (int, error) {
+	result, err := client.GetMyShows(api.PaginationParams{Limit: api.MaxPageLimit})
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Items) == 0 {
+		return 0, fmt.Errorf("you have no shows. Create one with: spreaker shows create --title <title>")
+	}
+
+	options := make([]string, len(result.Items))
+	ids := make(map[string]int, len(result.Items))
+	for i, show := range result.Items {
+		options[i] = fmt.Sprintf("%s (%d)", show.Title, show.ShowID)
+		ids[options[i]] = show.ShowID
+	}
+
+	closePager()
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Select a show"))
+	if err != nil {
+		return 0, fmt.Errorf("show selection cancelled: %w", err)
+	}
+	return ids[selected], nil
+}
+
+// Actions with a confirm.<action> policy.
+const (
+	actionDelete = "delete"
+	actionUpdate = "update"
+	actionUpload = "upload"
+)
+
+// confirmInput is where answers to confirmation prompts are read from. It is
+// shared by every prompt, so lines piped in and buffered past one answer are
+// left for the next prompt rather than lost.
+var confirmInput = bufio.NewReader(os.Stdin)
+
+// confirm asks the user whether to go ahead with an action, as set by its
+// confirm.<action> policy, unless --force is set: "always" asks yes or no,
+// "title" has the user type the title returned by title, and "never" does
+// not ask. Without a title func the title policy asks yes or no. With
+// --no-input a needed confirmation is an error. On a refusal it prints
+// "Cancelled." and returns false.
+func confirm(cmd *cobra.Command, action, prompt string, title func() (string, error)) (bool, error) {
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		return true, nil
+	}
+
+	cfg, _ := config.Load()
+	policy := cfg.Confirm.Policy(action)
+	if policy == config.ConfirmNever {
+		return true, nil
+	}
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput {
+		return false, fmt.Errorf("confirmation required (confirm.%s is %s); pass --force to skip it", action, policy)
+	}
+
+	var ok bool
+	if policy == config.ConfirmTitle && title != nil {
+		want, err := title()
+		if err != nil {
+			return false, err
+		}
+		ok = confirmTitle(prompt, want)
+	} else {
+		ok = confirmAction(prompt + " [y/N]: ")
+	}
+	if !ok {
+		getFormatter(cmd).PrintMessage(i18n.T("Cancelled."))
+	}
+	return ok, nil
+}
+
+// showTitle returns a title func for confirm that fetches a show's title.
+func showTitle(client *api.Client, showID int) func() (string, error) {
+	return func() (string, error) {
+		show, err := client.GetShow(showID)
+		if err != nil {
+			return "", err
+		}
+		return show.Title, nil
+	}
+}
+
+// episodeTitle returns a title func for confirm that fetches an episode's
+// title.
+func episodeTitle(client *api.Client, episodeID int) func() (string, error) {
+	return func() (string, error) {
+		episode, err := client.GetEpisode(episodeID)
+		if err != nil {
+			return "", err
+		}
+		return episode.Title, nil
+	}
+}
+
+// episodeDetails fetches each of episodes on its own, at most concurrency
+// at once, and returns them in the same order. Episode lists only carry the
+// compact fields of each episode; the description and tags come with
+// GET /v2/episodes/{episode_id} alone.
+func episodeDetails(ctx context.Context, client *api.Client, episodes []models.Episode, concurrency int) ([]models.Episode, error) {
+	return api.FetchAll(ctx, episodes, concurrency, func(e models.Episode) (models.Episode, error) {
+		full, err := client.GetEpisode(e.EpisodeID)
+		if err != nil {
+			return models.Episode{}, fmt.Errorf("failed to fetch episode %d: %w", e.EpisodeID, err)
+		}
+		return *full, nil
+	})
+}
+
 // confirmAction prompts the user for confirmation.
 func confirmAction(prompt string) bool {
+	answer, ok := readAnswer(prompt)
+	return ok && (answer == "y" || answer == "Y")
+}
+
+// confirmTitle prompts the user to type title, ignoring surrounding spaces.
+func confirmTitle(prompt, title string) bool {
+	answer, ok := readAnswer(fmt.Sprintf("%s\nTo confirm, type \"%s\": ", prompt, title))
+	return ok && answer == strings.TrimSpace(title)
+}
+
+// readAnswer prints prompt and reads a line from confirmInput. The pager is
+// closed first: it owns the terminal while it runs, so the prompt would be
+// drawn behind it and the answer read by it.
+func readAnswer(prompt string) (string, bool) {
+	closePager()
 	pterm.FgYellow.Print(prompt)
-	var confirm string
-	if _, err := fmt.Scanln(&confirm); err != nil {
+	line, err := confirmInput.ReadString('\n')
+	if err != nil && line == "" {
 		fmt.Fprintln(os.Stderr, "No input received, aborting.")
-		return false
+		return "", false
 	}
-	return confirm == "y" || confirm == "Y"
+	return strings.TrimSpace(line), true
 }
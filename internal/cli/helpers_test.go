@@ -1,7 +1,19 @@
 package cli
 
 import (
+	"bufio"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/output"
 )
 
 func TestParseIntArg(t *testing.T) {
@@ -31,6 +43,19 @@ func TestParseIntArg(t *testing.T) {
 	}
 }
 
+func TestReadIDs(t *testing.T) {
+	ids, err := readIDs(strings.NewReader("# liked\n67890\n67891, 67892\n\n  67893 67894\n"), "episode ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{67890, 67891, 67892, 67893, 67894}; !slices.Equal(ids, want) {
+		t.Errorf("readIDs() = %v, want %v", ids, want)
+	}
+	if _, err := readIDs(strings.NewReader("67890\nabc\n"), "episode ID"); err == nil || !strings.Contains(err.Error(), "invalid episode ID: abc") {
+		t.Errorf("readIDs() error = %v, want invalid episode ID", err)
+	}
+}
+
 func TestParseShowID(t *testing.T) {
 	id, err := parseShowID("123")
 	if err != nil {
@@ -65,3 +90,233 @@ func TestParseUserID(t *testing.T) {
 		t.Errorf("got %d, want 789", id)
 	}
 }
+
+func TestApplyShowDefaults(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+
+	explicit, downloadable := true, false
+	if err := config.Save(&config.Config{
+		Shows: map[string]config.ShowDefaults{
+			"12345": {
+				Tags:              []string{"weekly", "tech"},
+				Explicit:          &explicit,
+				Downloadable:      &downloadable,
+				DescriptionFooter: "Support us!",
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+
+	cmd := newEpisodesUploadCmd()
+	cmd.Flags().Set("explicit", "false")
+
+	params := api.UploadEpisodeParams{
+		Description:     "Show notes",
+		Tags:            []string{"tech"},
+		DownloadEnabled: true,
+	}
+	applyShowDefaults(cmd, 12345, &params)
+
+	if want := []string{"tech", "weekly"}; !reflect.DeepEqual(params.Tags, want) {
+		t.Errorf("Tags = %v, want %v", params.Tags, want)
+	}
+	if params.Explicit {
+		t.Error("explicit flag given on the command line should override the show default")
+	}
+	if params.DownloadEnabled {
+		t.Error("DownloadEnabled should come from the show default")
+	}
+	if params.Description != "Show notes\n\nSupport us!" {
+		t.Errorf("Description = %q", params.Description)
+	}
+
+	// Shows without defaults are left untouched.
+	other := api.UploadEpisodeParams{Description: "x"}
+	applyShowDefaults(newEpisodesUploadCmd(), 1, &other)
+	if other.Description != "x" || other.Tags != nil {
+		t.Errorf("unexpected defaults applied: %+v", other)
+	}
+}
+
+func TestResolveShowID(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+
+	cmd := newRootCmd("test")
+	cmd.PersistentFlags().Set("no-input", "true")
+
+	id, err := resolveShowID(cmd, nil, []string{"42"})
+	if err != nil || id != 42 {
+		t.Errorf("from argument: got %d, %v; want 42", id, err)
+	}
+
+	// No argument, no default and --no-input: error instead of prompting.
+	if _, err := resolveShowID(cmd, nil, nil); err == nil {
+		t.Error("expected error without show ID, default or input")
+	}
+
+	if err := config.Save(&config.Config{DefaultShowID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+
+	id, err = resolveShowID(cmd, nil, nil)
+	if err != nil || id != 7 {
+		t.Errorf("from default: got %d, %v; want 7", id, err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+	stdin := confirmInput
+	t.Cleanup(func() { confirmInput = stdin })
+
+	newCmd := func(flags ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("force", false, "")
+		cmd.Flags().Bool("no-input", false, "")
+		cmd.Flags().String("output", "plain", "")
+		for _, f := range flags {
+			cmd.Flags().Set(f, "true")
+		}
+		return cmd
+	}
+	title := func() (string, error) { return "Tech Talk", nil }
+
+	tests := []struct {
+		name   string
+		policy config.ConfirmConfig
+		flags  []string
+		input  string
+		want   bool
+		errors bool
+	}{
+		{"default delete, yes", config.ConfirmConfig{}, nil, "y\n", true, false},
+		{"default delete, no", config.ConfirmConfig{}, nil, "n\n", false, false},
+		{"default delete, no input", config.ConfirmConfig{}, nil, "", false, false},
+		{"force", config.ConfirmConfig{}, []string{"force"}, "", true, false},
+		{"never", config.ConfirmConfig{Delete: config.ConfirmNever}, nil, "", true, false},
+		{"--no-input", config.ConfirmConfig{}, []string{"no-input"}, "", false, true},
+		{"title typed", config.ConfirmConfig{Delete: config.ConfirmTitle}, nil, " Tech Talk \n", true, false},
+		{"wrong title", config.ConfirmConfig{Delete: config.ConfirmTitle}, nil, "y\n", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := config.Save(&config.Config{Confirm: tt.policy}); err != nil {
+				t.Fatal(err)
+			}
+			viper.Reset()
+			confirmInput = bufio.NewReader(strings.NewReader(tt.input))
+
+			ok, err := confirm(newCmd(tt.flags...), actionDelete, "Delete show 1?", title)
+			if ok != tt.want || (err != nil) != tt.errors {
+				t.Errorf("confirm() = %v, %v; want %v, error %v", ok, err, tt.want, tt.errors)
+			}
+		})
+	}
+}
+
+func TestConfirm_PipedAnswers(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+	stdin := confirmInput
+	t.Cleanup(func() { confirmInput = stdin })
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("no-input", false, "")
+	cmd.Flags().String("output", "plain", "")
+
+	// Both answers arrive in one read; the second must not be lost.
+	confirmInput = bufio.NewReader(strings.NewReader("n\ny\n"))
+	var got []bool
+	for range 2 {
+		ok, err := confirm(cmd, actionDelete, "Delete?", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, ok)
+	}
+	if !slices.Equal(got, []bool{false, true}) {
+		t.Errorf("answers = %v, want [false true]", got)
+	}
+}
+
+func TestConfirmClosesPager(t *testing.T) {
+	viper.Reset()
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+	t.Cleanup(viper.Reset)
+	stdin := confirmInput
+	t.Cleanup(func() { confirmInput = stdin })
+	t.Cleanup(closePager)
+
+	// A listing printed before the prompt started the pager.
+	pager = output.NewPager("cat")
+	if _, err := pager.Write([]byte("")); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().Bool("no-input", false, "")
+	cmd.Flags().String("output", "plain", "")
+	confirmInput = bufio.NewReader(strings.NewReader("y\n"))
+
+	if ok, err := confirm(cmd, actionDelete, "Delete 2 episodes?", nil); !ok || err != nil {
+		t.Fatalf("confirm() = %v, %v", ok, err)
+	}
+	if pager != nil {
+		t.Error("pager still open when the prompt was shown")
+	}
+}
+
+func TestNotifyDone(t *testing.T) {
+	notify := desktopNotify
+	t.Cleanup(func() { desktopNotify = notify })
+
+	var titles []string
+	desktopNotify = func(title, message string) error {
+		titles = append(titles, title+": "+message)
+		return nil
+	}
+
+	failure := errors.New("upload failed: 500")
+	run := notifyDone(func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return failure
+		}
+		return nil
+	})
+	newCmd := func(notify bool) *cobra.Command {
+		cmd := &cobra.Command{Use: "upload"}
+		addNotifyFlag(cmd)
+		cmd.Flags().String("output", "plain", "")
+		if notify {
+			cmd.Flags().Set("notify", "true")
+		}
+		return cmd
+	}
+
+	if err := run(newCmd(false), nil); err != nil || len(titles) != 0 {
+		t.Fatalf("without --notify: err = %v, notifications = %v", err, titles)
+	}
+	if err := run(newCmd(true), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(newCmd(true), []string{"x"}); err != failure {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+	want := []string{"spreaker upload finished: Done", "spreaker upload failed: upload failed: 500"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("notifications = %q, want %q", titles, want)
+	}
+}
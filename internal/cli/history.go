@@ -0,0 +1,227 @@
+/*
+history.go - Change journal and undo
+
+Every request that changes data is recorded in a journal next to the
+config file, with the values it replaced when they could be fetched first.
+"history" lists the journal and "undo" reverses its last reversible entry.
+*/
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+)
+
+// journalFile is the name of the change journal in the config directory.
+const journalFile = "journal.json"
+
+var (
+	// journalMu serializes journal updates of concurrent requests.
+	journalMu sync.Mutex
+
+	// undoOf is the ID of the entry "spreaker undo" is reversing, so its
+	// request is recorded as an undo.
+	undoOf int
+)
+
+// recordChanges sets client up to record every request that changes data
+// in the journal. Updates of shows, episodes and profiles fetch the
+// resource first, so the replaced values can be restored by "undo".
+func recordChanges(cmd *cobra.Command, client *api.Client) {
+	path, err := config.DataFilePath(journalFile)
+	if err != nil {
+		return
+	}
+	command := cmd.CommandPath()
+
+	client.BeforeWrite = func(method, p string, fields map[string]string) map[string]string {
+		if !journal.IsUpdate(method, p) || len(fields) == 0 {
+			return nil
+		}
+		var resp map[string]map[string]interface{}
+		if err := client.Get(p, nil, &resp); err != nil {
+			return nil
+		}
+		// The resource is the only key of the response, e.g. "episode".
+		for _, resource := range resp {
+			return journal.Snapshot(resource, fields)
+		}
+		return nil
+	}
+
+	client.OnWrite = func(w api.Write) {
+		journalMu.Lock()
+		defer journalMu.Unlock()
+
+		j, err := journal.Load(path)
+		if err == nil {
+			j.Add(journal.Entry{
+				Time:    time.Now().UTC(),
+				Command: command,
+				Method:  w.Method,
+				Path:    w.Path,
+				Fields:  w.Fields,
+				Before:  w.Before,
+				UndoOf:  undoOf,
+			})
+			err = j.Save(path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: change not recorded for undo: %v\n", err)
+		}
+	}
+}
+
+// loadJournal reads the change journal and returns it with its path.
+func loadJournal() (*journal.Journal, string, error) {
+	path, err := config.DataFilePath(journalFile)
+	if err != nil {
+		return nil, "", err
+	}
+	j, err := journal.Load(path)
+	return j, path, err
+}
+
+// -----------------------------------------------------------------------------
+// history
+// -----------------------------------------------------------------------------
+
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the changes made with the CLI",
+		Long: fmt.Sprintf(`List the requests that changed data, newest first: the command that
+sent each one, the request, and whether "spreaker undo" can reverse it.
+
+Favorites, likes, bookmarks, follows and blocks can be undone, and so can
+show, episode and profile updates whose previous values were fetched
+before the update. Uploads and deletions cannot.
+
+The journal keeps the last %d changes.
+
+Examples:
+  spreaker history
+  spreaker history --limit 5 -o json`, journal.MaxEntries),
+		Args: cobra.NoArgs,
+		RunE: runHistory,
+	}
+
+	cmd.Flags().IntP("limit", "l", 20, "Maximum number of changes to list, 0 = all")
+
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	j, _, err := loadJournal()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]journal.Entry, 0, len(j.Entries))
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+		entries = append(entries, j.Entries[i])
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintHistory(entries)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// undo
+// -----------------------------------------------------------------------------
+
+func newUndoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Reverse the last change that can be undone",
+		Long: `Reverse the latest change in "spreaker history" that can be undone and
+was not undone yet: a favorite is removed again, a follow is dropped, an
+update puts back the previous values. Running undo again reverses the
+change before it.
+
+Changes that cannot be undone, such as uploads and deletions, are skipped.
+
+Examples:
+  spreaker undo --dry-run
+  spreaker undo`,
+		Args: cobra.NoArgs,
+		RunE: runUndo,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Print the change that would be undone without undoing it")
+
+	return cmd
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	j, path, err := loadJournal()
+	if err != nil {
+		return err
+	}
+	entry, ok := j.LastReversible()
+	if !ok {
+		return errors.New("nothing to undo")
+	}
+	reversal, _ := entry.Reverse()
+
+	formatter := getFormatter(cmd)
+	if dryRun {
+		formatter.PrintMessage(i18n.T("Would undo #%d (%s %s) with %s", entry.ID, entry.Method, entry.Path, reversal))
+		return nil
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	undoOf = entry.ID
+	defer func() { undoOf = 0 }()
+
+	switch reversal.Method {
+	case http.MethodPut:
+		err = client.Put(reversal.Path, nil)
+	case http.MethodDelete:
+		err = client.Delete(reversal.Path, nil)
+	default:
+		err = client.PostForm(reversal.Path, reversal.Fields, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Reload: the undo request was appended to the journal.
+	journalMu.Lock()
+	defer journalMu.Unlock()
+	if j, err = journal.Load(path); err != nil {
+		return err
+	}
+	if e, ok := j.Get(entry.ID); ok {
+		e.Undone = true
+	}
+	if err := j.Save(path); err != nil {
+		return err
+	}
+
+	formatter.PrintSuccess(i18n.T("Undid #%d (%s %s)", entry.ID, entry.Method, entry.Path))
+	return nil
+}
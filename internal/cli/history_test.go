@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+func TestRecordChanges(t *testing.T) {
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{
+			"episode": map[string]interface{}{"episode_id": 3, "title": "Old", "tags": []string{"a", "b"}},
+		}})
+	}))
+	defer srv.Close()
+
+	client := api.NewClientWithOptions("token", srv.URL, 0)
+	recordChanges(&cobra.Command{Use: "update"}, client)
+
+	title, tags := "New", []string{"c"}
+	if _, err := client.UpdateEpisode(3, api.UpdateEpisodeParams{Title: &title, Tags: &tags}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.LikeEpisode(1, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	j, _, err := loadJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(j.Entries) != 2 {
+		t.Fatalf("journal = %+v, want 2 entries", j.Entries)
+	}
+	update := j.Entries[0]
+	if update.Command != "update" || update.Path != "/episodes/3" {
+		t.Errorf("entry = %+v", update)
+	}
+	if want := map[string]string{"title": "Old", "tags": "a,b"}; !reflect.DeepEqual(update.Before, want) {
+		t.Errorf("Before = %v, want %v", update.Before, want)
+	}
+
+	last, ok := j.LastReversible()
+	if !ok || last.Method != http.MethodPut || last.Path != "/users/1/likes/3" {
+		t.Errorf("LastReversible() = %+v, %v; want the like", last, ok)
+	}
+}
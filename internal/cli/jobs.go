@@ -0,0 +1,530 @@
+/*
+jobs.go - Scheduled CLI commands
+
+"jobs add" schedules a spreaker command, such as an upload, for a later
+time, and "jobs run" is a small daemon that runs the commands when they
+are due, logging their output and retrying those that fail. It serves
+users without cron or another scheduler.
+*/
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/jobs"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+)
+
+// Files of the job queue and of its log, in the config directory.
+const (
+	jobsFile    = "jobs.json"
+	jobsLogFile = "jobs.log"
+)
+
+// loadJobs reads the job queue and returns it with its path.
+func loadJobs() (*jobs.Queue, string, error) {
+	path, err := config.DataFilePath(jobsFile)
+	if err != nil {
+		return nil, "", err
+	}
+	q, err := jobs.Load(path)
+	return q, path, err
+}
+
+// updateJobs changes the job queue under its lock (see jobs.Update).
+func updateJobs(fn func(q *jobs.Queue) error) error {
+	path, err := config.DataFilePath(jobsFile)
+	if err != nil {
+		return err
+	}
+	return jobs.Update(path, fn)
+}
+
+func newJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Schedule commands to run later",
+		Long: `Schedule spreaker commands to run at a later time, e.g. an upload on
+Monday morning, without cron or another scheduler.
+
+"jobs add" queues a command and "jobs run" runs queued commands when they
+are due. Keep one "jobs run" running, e.g. in a terminal multiplexer or as
+a service; run it with --once from an existing scheduler instead.
+
+Examples:
+  spreaker jobs add "episodes upload 12345 ep42.mp3 --title 'Episode 42'" --at "2024-07-01 09:00"
+  spreaker jobs list
+  spreaker jobs run`,
+	}
+
+	cmd.AddCommand(
+		newJobsAddCmd(),
+		newJobsListCmd(),
+		newJobsDropCmd(),
+		newJobsRunCmd(),
+	)
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// jobs add
+// -----------------------------------------------------------------------------
+
+func newJobsAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <command> --at <time>",
+		Short: "Schedule a command",
+		Long: `Schedule a spreaker command. Give it as one quoted argument, with or
+without the leading "spreaker", or as separate arguments after --.
+
+--at is a date and time, YYYY-MM-DD HH:MM, in the timezone of the config
+(local time if unset), or a duration from now such as 90m.
+
+The command runs with --no-input, so it never waits for an answer: pass
+--force to commands that would ask for a confirmation. Relative file
+paths are resolved from the directory "jobs run" runs in, so prefer
+absolute ones.
+
+A failed command is retried --retries times, after --retry-delay, doubled
+at each attempt. A retry runs the whole command again, so only schedule
+commands that are safe to repeat, or pass --retries 0: an upload that
+failed after the episode was created would create it a second time.
+
+Examples:
+  spreaker jobs add "episodes upload 12345 /srv/ep42.mp3 --title 'Episode 42'" --at "2024-07-01 09:00"
+  spreaker jobs add --at 2h -- episodes update 67890 --hidden=false
+  spreaker jobs add "messages post 67890 'New episode is live!'" --at "2024-07-01 09:05" --retries 5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runJobsAdd,
+	}
+
+	cmd.Flags().String("at", "", "When to run the command: YYYY-MM-DD HH:MM, or a duration from now (required)")
+	cmd.MarkFlagRequired("at")
+	cmd.Flags().Int("retries", jobs.DefaultRetries, "Times a failed command is retried")
+	cmd.Flags().Duration("retry-delay", jobs.DefaultRetryDelay, "Wait before the first retry, doubled at each retry")
+
+	return cmd
+}
+
+func runJobsAdd(cmd *cobra.Command, args []string) error {
+	at, _ := cmd.Flags().GetString("at")
+	retries, _ := cmd.Flags().GetInt("retries")
+	retryDelay, _ := cmd.Flags().GetDuration("retry-delay")
+	if retries < 0 {
+		return fmt.Errorf("invalid retries %d: must not be negative", retries)
+	}
+	if retryDelay < time.Second {
+		return fmt.Errorf("invalid retry-delay %s: must be at least 1s", retryDelay)
+	}
+
+	command, err := jobCommand(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	loc, err := output.LoadLocation(cfg.Timezone)
+	if err != nil || loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	when, err := jobs.ParseAt(at, now, loc)
+	if err != nil {
+		return err
+	}
+	if when.Before(now.Add(-time.Minute)) {
+		return fmt.Errorf("%s is in the past", at)
+	}
+
+	var j jobs.Job
+	err = updateJobs(func(q *jobs.Queue) error {
+		j = q.Add(jobs.Job{
+			Command:    command,
+			At:         when.UTC(),
+			Added:      now.UTC(),
+			Retries:    retries,
+			RetryDelay: int(retryDelay / time.Second),
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Scheduled job %d for %s", j.ID, when.In(loc).Format("2006-01-02 15:04 MST")))
+	return nil
+}
+
+// jobCommand returns the arguments of a command to schedule, given as one
+// command line or as separate arguments, and checks that it is a spreaker
+// command with valid flags.
+func jobCommand(args []string) ([]string, error) {
+	command := args
+	if len(args) == 1 {
+		var err error
+		if command, err = jobs.SplitCommand(args[0]); err != nil {
+			return nil, err
+		}
+	}
+	if len(command) > 0 && (command[0] == "spreaker" || strings.HasSuffix(command[0], "/spreaker")) {
+		command = command[1:]
+	}
+	if len(command) == 0 {
+		return nil, errors.New("no command to schedule")
+	}
+
+	// A fresh command tree, so parsing the flags leaves this one alone.
+	target, rest, err := newRootCmd("").Find(command)
+	if err != nil || !target.Runnable() || target.Parent() == nil {
+		return nil, fmt.Errorf("unknown command %q", jobs.FormatCommand(command))
+	}
+	for c := target; c.Parent() != nil; c = c.Parent() {
+		if c.Name() == "jobs" && c.Parent().Parent() == nil {
+			return nil, errors.New("jobs commands cannot be scheduled")
+		}
+	}
+	if err := target.ParseFlags(rest); err != nil {
+		return nil, fmt.Errorf("%s: %w", target.CommandPath(), err)
+	}
+	return command, nil
+}
+
+// -----------------------------------------------------------------------------
+// jobs list
+// -----------------------------------------------------------------------------
+
+func newJobsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled jobs",
+		Long: `List scheduled jobs with their status: pending (waiting for its time or
+a retry), running, done, or failed (no retries left).`,
+		Args: cobra.NoArgs,
+		RunE: runJobsList,
+	}
+}
+
+func runJobsList(cmd *cobra.Command, args []string) error {
+	q, _, err := loadJobs()
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintJobs(q.Jobs)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// jobs drop
+// -----------------------------------------------------------------------------
+
+func newJobsDropCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drop [id...]",
+		Short: "Remove scheduled jobs",
+		Long: `Remove jobs by the IDs shown by "jobs list", the finished ones (done or
+failed) with --finished, or all of them with --all.
+
+Examples:
+  spreaker jobs drop 3
+  spreaker jobs drop --finished`,
+		RunE: runJobsDrop,
+	}
+
+	cmd.Flags().Bool("finished", false, "Remove the jobs that are done or failed")
+	cmd.Flags().Bool("all", false, "Remove every job")
+	cmd.MarkFlagsMutuallyExclusive("finished", "all")
+
+	return cmd
+}
+
+func runJobsDrop(cmd *cobra.Command, args []string) error {
+	finished, _ := cmd.Flags().GetBool("finished")
+	all, _ := cmd.Flags().GetBool("all")
+	if (all || finished) == (len(args) > 0) {
+		return errors.New("give the IDs of the jobs to remove, --finished or --all")
+	}
+
+	dropped := 0
+	err := updateJobs(func(q *jobs.Queue) error {
+		switch {
+		case all:
+			dropped, q.Jobs = len(q.Jobs), nil
+		case finished:
+			kept := q.Jobs[:0]
+			for _, j := range q.Jobs {
+				if j.Status == jobs.StatusPending || j.Status == jobs.StatusRunning {
+					kept = append(kept, j)
+				}
+			}
+			dropped, q.Jobs = len(q.Jobs)-len(kept), kept
+		default:
+			for _, arg := range args {
+				id, err := parseIntArg(arg, "job ID")
+				if err != nil {
+					return err
+				}
+				if !q.Remove(id) {
+					return fmt.Errorf("no job with ID %d", id)
+				}
+				dropped++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Removed %d jobs", dropped))
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// jobs run
+// -----------------------------------------------------------------------------
+
+func newJobsRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run scheduled jobs when they are due",
+		Long: `Check the job queue every --interval and run the jobs that are due, one
+at a time, until interrupted.
+
+The output of each run is appended to a log file, jobs.log next to the
+config file unless --log is given. A job that exits with an error is
+retried later (see "jobs add --retries"); a job still running after
+--timeout is stopped and counts as failed.
+
+With --once, the due jobs are run and the command exits, with status 1 if
+any of them failed; use it to run the queue from an existing scheduler.
+
+Each job is claimed, saved as running, before it starts, so several
+"jobs run" never run the same job. A job left running by a "jobs run"
+that was killed, or that has run for longer than --timeout, counts as a
+failed attempt and is retried like any other failure.
+
+Examples:
+  spreaker jobs run
+  spreaker jobs run --interval 1m --log /var/log/spreaker-jobs.log
+  spreaker jobs run --once`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{noPagerAnnotation: ""},
+		RunE:        runJobsRun,
+	}
+
+	cmd.Flags().Duration("interval", 30*time.Second, "Time between checks of the queue")
+	cmd.Flags().Bool("once", false, "Run the due jobs and exit")
+	cmd.Flags().String("log", "", "Log file (default: jobs.log next to the config file)")
+	cmd.Flags().Duration("timeout", time.Hour, "Longest time a job may run")
+
+	return cmd
+}
+
+func runJobsRun(cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	once, _ := cmd.Flags().GetBool("once")
+	logPath, _ := cmd.Flags().GetString("log")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if interval < time.Second && !once {
+		return fmt.Errorf("invalid interval %s: must be at least 1s", interval)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("invalid timeout %s: must be positive", timeout)
+	}
+
+	if logPath == "" {
+		path, err := config.DataFilePath(jobsLogFile)
+		if err != nil {
+			return err
+		}
+		logPath = path
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open job log: %w", err)
+	}
+	defer logFile.Close()
+
+	// Jobs run this very binary.
+	program, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	if !once {
+		formatter.PrintMessage(i18n.T("Running due jobs every %s, logging to %s (Ctrl+C to stop)", interval, logPath))
+	}
+
+	ctx := cmd.Context()
+	for {
+		failed, err := runDueJobs(ctx, formatter, program, logFile, timeout)
+		if err != nil {
+			return err
+		}
+		if once {
+			if failed > 0 {
+				return fmt.Errorf("%d jobs failed", failed)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runDueJobs runs the jobs that are due, one at a time, records their
+// outcome and returns the number that failed. Each job is claimed before
+// it runs, so a job claimed or removed by another process is skipped, and
+// the queue is changed under its lock, so jobs added meanwhile are kept.
+func runDueJobs(ctx context.Context, formatter *output.Formatter, program string, log io.Writer, timeout time.Duration) (int, error) {
+	var recovered, dueJobs []jobs.Job
+	err := updateJobs(func(q *jobs.Queue) error {
+		now := time.Now()
+		recovered = q.Recover(now.UTC(), timeout, processAlive)
+		dueJobs = q.Due(now)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, j := range recovered {
+		formatter.PrintWarning(i18n.T("Job %d was left running by a runner that stopped; counted as a failed attempt", j.ID))
+	}
+
+	failed := 0
+	pid := os.Getpid()
+	for _, due := range dueJobs {
+		if ctx.Err() != nil {
+			break
+		}
+		claimed := false
+		err := updateJobs(func(q *jobs.Queue) error {
+			claimed = q.Claim(due.ID, time.Now().UTC(), pid)
+			return nil
+		})
+		if err != nil {
+			return failed, err
+		}
+		if !claimed {
+			continue
+		}
+
+		formatter.PrintMessage(i18n.T("Running job %d: %s", due.ID, due.CommandLine()))
+		runErr := runJob(ctx, program, due, log, timeout)
+
+		var j jobs.Job
+		err = updateJobs(func(q *jobs.Queue) error {
+			job := q.Get(due.ID)
+			if job == nil {
+				return nil
+			}
+			if ctx.Err() != nil {
+				// Interrupted: the job is pending again and runs next time.
+				job.Unclaim()
+			} else {
+				job.Record(time.Now().UTC(), runErr)
+			}
+			j = *job
+			return nil
+		})
+		if err != nil {
+			return failed, err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if j.ID == 0 {
+			continue
+		}
+
+		switch {
+		case runErr == nil:
+			formatter.PrintSuccess(i18n.T("Job %d done", j.ID))
+		case j.Status == jobs.StatusPending:
+			failed++
+			formatter.PrintWarning(i18n.T("Job %d failed: %v; retrying at %s", j.ID, runErr, j.At.Local().Format("2006-01-02 15:04")))
+		default:
+			failed++
+			formatter.PrintWarning(i18n.T("Job %d failed: %v; no retries left", j.ID, runErr))
+		}
+	}
+	return failed, nil
+}
+
+// processAlive reports whether the process pid is still running. On
+// Windows, finding the process is enough to tell.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// runJob runs a job's command with --no-input and appends its output to
+// the log, between a header and a result line.
+func runJob(ctx context.Context, program string, j jobs.Job, log io.Writer, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Fprintf(log, "%s job %d, attempt %d/%d: spreaker %s\n",
+		time.Now().UTC().Format(time.RFC3339), j.ID, j.Attempts+1, j.Retries+1, j.CommandLine())
+
+	var out bytes.Buffer
+	c := exec.CommandContext(ctx, program, append([]string{"--no-input"}, j.Command...)...)
+	c.Stdout, c.Stderr = &out, &out
+	err := c.Run()
+	log.Write(out.Bytes())
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		} else if line := lastOutputLine(out.String()); line != "" {
+			err = fmt.Errorf("%w: %s", err, line)
+		}
+	}
+	result := "ok"
+	if err != nil {
+		result = "failed: " + err.Error()
+	}
+	fmt.Fprintf(log, "%s job %d %s\n\n", time.Now().UTC().Format(time.RFC3339), j.ID, result)
+	return err
+}
+
+// lastOutputLine returns the last non-empty line of a command's output,
+// usually its error message.
+func lastOutputLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/jobs"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+)
+
+func TestJobCommand(t *testing.T) {
+	got, err := jobCommand([]string{`spreaker episodes upload 12345 ep.mp3 --title "Episode 42"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"episodes", "upload", "12345", "ep.mp3", "--title", "Episode 42"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("jobCommand() = %q, want %q", got, want)
+	}
+	if got, err := jobCommand([]string{"episodes", "update", "3", "--hidden=false"}); err != nil || len(got) != 4 {
+		t.Errorf("jobCommand() with separate arguments = %q, %v", got, err)
+	}
+
+	for _, args := range [][]string{
+		{"podcasts list"},
+		{"episodes"},
+		{"episodes list --no-such-flag"},
+		{"jobs run"},
+		{""},
+	} {
+		if _, err := jobCommand(args); err == nil {
+			t.Errorf("jobCommand(%q): want error", args)
+		}
+	}
+}
+
+func TestRunDueJobs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake program is a shell script")
+	}
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+
+	// The fake spreaker fails when asked to list shows.
+	program := filepath.Join(t.TempDir(), "spreaker")
+	script := "#!/bin/sh\necho \"ran $*\"\ncase \"$*\" in *shows*) echo 'Error: 503 Service Unavailable' >&2; exit 1;; esac\n"
+	if err := os.WriteFile(program, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	q, path, err := loadJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	q.Add(jobs.Job{Command: []string{"me"}, At: now.Add(-time.Minute)})
+	q.Add(jobs.Job{Command: []string{"shows", "list"}, At: now.Add(-time.Minute), Retries: 1, RetryDelay: 60})
+	q.Add(jobs.Job{Command: []string{"episodes", "list"}, At: now.Add(time.Hour)})
+	// Claimed by another jobs run, still running.
+	q.Add(jobs.Job{Command: []string{"users", "list"}, At: now.Add(-time.Minute)})
+	q.Claim(4, now, os.Getpid())
+	// Claimed by a jobs run that was killed.
+	exited := exec.Command(program, "me")
+	if err := exited.Run(); err != nil {
+		t.Fatal(err)
+	}
+	q.Add(jobs.Job{Command: []string{"search", "shows"}, At: now.Add(-time.Minute), Retries: 1, RetryDelay: 60})
+	q.Claim(5, now, exited.Process.Pid)
+	if err := q.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var log bytes.Buffer
+	failed, err := runDueJobs(context.Background(), output.New("plain", false), program, &log, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed != 1 {
+		t.Errorf("failed = %d, want 1", failed)
+	}
+	for _, want := range []string{"ran --no-input me", "job 1 ok", "job 2 failed: exit status 1: Error: 503 Service Unavailable"} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log does not contain %q:\n%s", want, log.String())
+		}
+	}
+
+	q, _, err = loadJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := q.Get(1).Status; s != jobs.StatusDone {
+		t.Errorf("job 1 status = %s, want done", s)
+	}
+	if j := q.Get(2); j.Status != jobs.StatusPending || j.Attempts != 1 || !j.At.After(now) {
+		t.Errorf("job 2 = %+v, want a pending retry", j)
+	}
+	if j := q.Get(3); j.Attempts != 0 {
+		t.Errorf("job 3 ran before its time: %+v", j)
+	}
+	if j := q.Get(4); j.Status != jobs.StatusRunning || strings.Contains(log.String(), "users") {
+		t.Errorf("job 4, claimed elsewhere, was run: %+v", j)
+	}
+	if j := q.Get(5); j.Status != jobs.StatusPending || j.Attempts != 1 || !j.At.After(now) || strings.Contains(log.String(), "search") {
+		t.Errorf("job 5, left running by a killed runner = %+v, want a pending retry", j)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+local.go - Offline queries of the local catalog
+
+local reads the data stored by the sync commands, so reports can cover
+any range without calling the API.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+)
+
+func newLocalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "local",
+		Short: "Query the local catalog without calling the API",
+		Long: `Query data stored in the local catalog by 'spreaker sync'.
+
+Example:
+  spreaker local stats 12345 --from 30d
+  spreaker local stats 12345 --group week --average 4 -o json
+  spreaker local messages search refund`,
+	}
+
+	cmd.AddCommand(newLocalStatsCmd())
+	cmd.AddCommand(newLocalMessagesCmd())
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// local stats
+// -----------------------------------------------------------------------------
+
+func newLocalStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats <show-id>",
+		Short: "Show stored daily statistics of a show",
+		Long: `Show a show's plays, downloads, likes and followers stored by
+'spreaker sync stats', summed by day, week or month.
+
+--average adds the moving average of plays over that many periods, e.g.
+--average 7 with days for a weekly trend. JSON output can feed charting
+tools directly.
+
+Example:
+  spreaker local stats 12345 --from 2024-01-01 --to 2024-03-31
+  spreaker local stats 12345 --from 90d --average 7
+  spreaker local stats 12345 --group month -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runLocalStats,
+	}
+
+	cmd.Flags().String("from", "", "Start date (YYYY-MM-DD, today, yesterday or Nd; default: first stored day)")
+	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, today, yesterday or Nd; default: last stored day)")
+	cmd.Flags().String("group", catalog.GroupDay, "Group by: day, week, or month")
+	cmd.Flags().Int("average", 0, "Add the moving average of plays over this many periods")
+
+	return cmd
+}
+
+func runLocalStats(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	group, _ := cmd.Flags().GetString("group")
+	window, _ := cmd.Flags().GetInt("average")
+	if window < 0 {
+		return fmt.Errorf("invalid average %d: must be 0 or more", window)
+	}
+
+	var bounds [2]string
+	for i, flag := range []string{"from", "to"} {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			continue
+		}
+		d, err := report.ParseDate(value, time.Now(), time.UTC)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", flag, err)
+		}
+		bounds[i] = d.Format(report.DateLayout)
+	}
+
+	path, err := catalogPath(showID)
+	if err != nil {
+		return err
+	}
+	stats, err := catalog.Load(path, showID)
+	if err != nil {
+		return err
+	}
+	if len(stats.Days) == 0 {
+		return fmt.Errorf("no statistics stored for show %d: run 'spreaker sync stats %d' first", showID, showID)
+	}
+
+	rows, err := stats.Query(bounds[0], bounds[1], group, window)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintLocalStats(rows, window)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// local messages
+// -----------------------------------------------------------------------------
+
+// messagesFile matches the file names of stored show messages.
+var messagesFile = regexp.MustCompile(`^messages-(\d+)\.json$`)
+
+func newLocalMessagesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "messages",
+		Short: "Query stored episode messages",
+		Long: `Query the episode messages stored by 'spreaker sync messages'.
+
+Example:
+  spreaker local messages search refund`,
+	}
+
+	cmd.AddCommand(newLocalMessagesSearchCmd())
+
+	return cmd
+}
+
+func newLocalMessagesSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search stored messages of your shows",
+		Long: `Search the listener messages stored by 'spreaker sync messages' across
+all synced shows, newest first.
+
+A message matches when its text, author or episode title contains every
+word of the query, ignoring case. Put words in double quotes to match them
+as a phrase.
+
+Example:
+  spreaker local messages search refund
+  spreaker local messages search 'audio "too low"' --show 12345
+  spreaker local messages search refund -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runLocalMessagesSearch,
+	}
+
+	cmd.Flags().IntSlice("show", nil, "Only search these shows (repeatable)")
+	cmd.Flags().IntP("limit", "l", 0, "Maximum number of messages (0 for all)")
+
+	return cmd
+}
+
+func runLocalMessagesSearch(cmd *cobra.Command, args []string) error {
+	showIDs, _ := cmd.Flags().GetIntSlice("show")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("invalid limit %d: must be 0 or more", limit)
+	}
+
+	shows, err := loadStoredMessages(showIDs)
+	if err != nil {
+		return err
+	}
+	if len(shows) == 0 {
+		return fmt.Errorf("no messages stored: run 'spreaker sync messages' first")
+	}
+
+	matches := catalog.Search(shows, strings.Join(args, " "))
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	formatter := getFormatter(cmd)
+	if len(matches) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No messages found."))
+		return nil
+	}
+	formatter.PrintMessageMatches(matches)
+	return nil
+}
+
+// loadStoredMessages loads the stored messages of showIDs, or of every
+// synced show when showIDs is empty.
+func loadStoredMessages(showIDs []int) ([]*catalog.ShowMessages, error) {
+	if len(showIDs) == 0 {
+		dir, err := config.DataFilePath(catalogDir)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read catalog: %w", err)
+		}
+		for _, e := range entries {
+			if m := messagesFile.FindStringSubmatch(e.Name()); m != nil {
+				id, _ := strconv.Atoi(m[1])
+				showIDs = append(showIDs, id)
+			}
+		}
+		sort.Ints(showIDs)
+	}
+
+	var shows []*catalog.ShowMessages
+	for _, id := range showIDs {
+		path, err := messagesCatalogPath(id)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil, fmt.Errorf("no messages stored for show %d: run 'spreaker sync messages %d' first", id, id)
+		}
+		show, err := catalog.LoadMessages(path, id)
+		if err != nil {
+			return nil, err
+		}
+		shows = append(shows, show)
+	}
+	return shows, nil
+}
@@ -12,44 +12,67 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
 	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
+// developerSettingsURL is where Spreaker API tokens are created.
+const developerSettingsURL = "https://www.spreaker.com/account/developers"
+
+// deviceLoginAttempts is how many rejected tokens login --device allows
+// before giving up.
+const deviceLoginAttempts = 3
+
 // newLoginCmd creates the login command.
 func newLoginCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with Spreaker",
 		Long: `Authenticate with your Spreaker account.
 
-You'll need an API token from your Spreaker developer settings.`,
+You'll need an API token from your Spreaker developer settings.
+
+On a server without a browser, use --device: it explains how to create a
+token on any other device, such as your phone or laptop, then reads it
+without echoing it, checks it and asks you to confirm the account before
+saving it. Rejected tokens can be pasted again. When stdin is not a
+terminal, the token is read from it instead, e.g. from a secrets manager.
+
+Examples:
+  spreaker login
+  spreaker login --device
+  vault read -field=token secret/spreaker | spreaker login --device`,
+		Args: cobra.NoArgs,
 		RunE: runLogin,
 	}
-}
 
+	cmd.Flags().Bool("device", false, "Log in on a machine without a browser, creating the token on another device")
+
+	return cmd
+}
 
 func runLogin(cmd *cobra.Command, args []string) error {
+	if device, _ := cmd.Flags().GetBool("device"); device {
+		return runDeviceLogin(cmd)
+	}
+
 	// Use plain fmt to avoid ANSI codes before color mode is resolved.
 	fmt.Print("Enter your Spreaker API token: ")
 
-	scanner := bufio.NewScanner(os.Stdin)
-	if !scanner.Scan() {
-		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("failed to read token: %w", err)
-		}
-		return fmt.Errorf("no input received")
-	}
-	token := strings.TrimSpace(scanner.Text())
-
-	if token == "" {
-		return fmt.Errorf("token cannot be empty")
+	token, err := readToken(os.Stdin)
+	if err != nil {
+		return err
 	}
 
 	// Validate token by making a test API call.
@@ -58,13 +81,87 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("invalid token: %w", err)
 	}
+	return saveLogin(cmd, token, user)
+}
+
+// runDeviceLogin guides the user through creating a token on another
+// device. The Spreaker API has no device authorization endpoint, so the
+// token is pasted, then verified by fetching the account it belongs to.
+func runDeviceLogin(cmd *cobra.Command) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		token, err := readToken(os.Stdin)
+		if err != nil {
+			return err
+		}
+		user, err := api.NewClient(token).GetMe()
+		if err != nil {
+			return fmt.Errorf("invalid token: %w", err)
+		}
+		return saveLogin(cmd, token, user)
+	}
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput {
+		return errors.New("login --device with --no-input needs the token on stdin")
+	}
+
+	fmt.Println(i18n.T("To log in from another device:"))
+	fmt.Println(i18n.T("  1. Open %s in a browser on any device", developerSettingsURL))
+	fmt.Println(i18n.T("  2. Register an application, if you have none, and generate an access token"))
+	fmt.Println(i18n.T("  3. Paste the token here; it is not shown as you type"))
+	fmt.Println()
+
+	for attempt := 1; ; attempt++ {
+		fmt.Print(i18n.T("Token: "))
+		b, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		token := strings.TrimSpace(string(b))
+
+		err = errors.New("token cannot be empty")
+		if token != "" {
+			var user *models.User
+			if user, err = api.NewClient(token).GetMe(); err == nil {
+				if !confirmAction(i18n.T("Log in as %s (@%s)?", user.Fullname, user.Username) + " [y/N]: ") {
+					fmt.Println(i18n.T("Cancelled."))
+					return nil
+				}
+				return saveLogin(cmd, token, user)
+			}
+		}
+		if attempt == deviceLoginAttempts {
+			return fmt.Errorf("invalid token: %w", err)
+		}
+		fmt.Println(i18n.T("The token was not accepted (%v); paste it again.", err))
+	}
+}
+
+// readToken reads a token from the first line of r.
+func readToken(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		return "", fmt.Errorf("no input received")
+	}
+	token := strings.TrimSpace(scanner.Text())
+
+	if token == "" {
+		return "", fmt.Errorf("token cannot be empty")
+	}
+	return token, nil
+}
 
+// saveLogin saves a verified token and its user's ID to the config.
+func saveLogin(cmd *cobra.Command, token string, user *models.User) error {
 	if err := config.SaveToken(token, user.UserID); err != nil {
 		return fmt.Errorf("failed to save token: %w", err)
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Logged in as %s (@%s)", user.Fullname, user.Username))
-	formatter.PrintMessage(fmt.Sprintf("Token saved to %s", config.ConfigFilePath()))
+	formatter.PrintSuccess(i18n.T("Logged in as %s (@%s)", user.Fullname, user.Username))
+	formatter.PrintMessage(i18n.T("Token saved to %s", config.ConfigFilePath()))
 	return nil
 }
@@ -0,0 +1,18 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadToken(t *testing.T) {
+	token, err := readToken(strings.NewReader("  abc123  \nignored\n"))
+	if err != nil || token != "abc123" {
+		t.Errorf("readToken() = %q, %v, want abc123", token, err)
+	}
+	for _, in := range []string{"", "   \n"} {
+		if _, err := readToken(strings.NewReader(in)); err == nil {
+			t.Errorf("readToken(%q): want error", in)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+logout.go - Logout command
+
+logout removes the stored token and, with --purge, the data cached about
+the account, so a shared or decommissioned machine keeps nothing of it.
+*/
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+)
+
+// personalData are the files and directories next to the config file that
+// hold data of the logged-in account, removed by logout --purge: the local
+// catalog of synced statistics and messages, with the audio checksums of
+// uploads, the journal of changes with its undo snapshots, the monitor
+// state, the log of scheduled job runs and the cached identities of tokens.
+var personalData = []string{catalogDir, journalFile, monitorStateFile, jobsLogFile, identityFile}
+
+func newLogoutCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the stored token",
+		Long: `Remove the stored token, with the rotation tokens and cached user ID, from
+the config file.
+
+--purge also deletes the data cached about the account next to the config
+file: the local catalog (sync stats, sync messages), the audio checksums of
+uploads, the journal of changes with its undo snapshots, the monitor state,
+the log of scheduled job runs and the cached accounts of tokens. The
+offline queue and scheduled jobs are kept, as they hold changes not made
+yet; drop them with "queue drop" and "jobs drop".
+
+--revoke explains how to revoke the token itself: the Spreaker API has no
+revocation endpoint, so it must be done in your developer settings.
+
+A token in the SPREAKER_TOKEN environment variable is not affected; you
+are reminded to unset it.
+
+Examples:
+  spreaker logout
+  spreaker logout --purge --revoke`,
+		Args: cobra.NoArgs,
+		RunE: runLogout,
+	}
+
+	cmd.Flags().Bool("revoke", false, "Also explain how to revoke the token")
+	cmd.Flags().Bool("purge", false, "Also delete the data cached about the account")
+
+	return cmd
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	formatter := getFormatter(cmd)
+
+	if cfg.Token != "" || cfg.UserID != 0 || len(cfg.RotationTokens) > 0 {
+		cfg.Token, cfg.UserID, cfg.RotationTokens = "", 0, nil
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to remove token: %w", err)
+		}
+		formatter.PrintSuccess(i18n.T("Logged out"))
+		formatter.PrintMessage(i18n.T("Token removed from %s", config.ConfigFilePath()))
+	} else {
+		formatter.PrintMessage(i18n.T("Not logged in."))
+	}
+	if os.Getenv("SPREAKER_TOKEN") != "" {
+		formatter.PrintWarning(i18n.T("SPREAKER_TOKEN is still set in the environment; unset it to stop using that token"))
+	}
+
+	if purge, _ := cmd.Flags().GetBool("purge"); purge {
+		removed, err := purgePersonalData()
+		for _, path := range removed {
+			formatter.PrintMessage(i18n.T("Deleted %s", path))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete cached data: %w", err)
+		}
+		if len(removed) == 0 {
+			formatter.PrintMessage(i18n.T("No cached data to delete."))
+		}
+	}
+
+	if revoke, _ := cmd.Flags().GetBool("revoke"); revoke {
+		formatter.PrintWarning(i18n.T("The Spreaker API cannot revoke tokens: open %s and delete the application or regenerate its token to revoke it", developerSettingsURL))
+	}
+	return nil
+}
+
+// purgePersonalData deletes the files and directories of personalData
+// that exist and returns their paths.
+func purgePersonalData() ([]string, error) {
+	var removed []string
+	for _, name := range personalData {
+		path, err := config.DataFilePath(name)
+		if err != nil {
+			return removed, err
+		}
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return removed, err
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+)
+
+func TestLogoutPurge(t *testing.T) {
+	viper.Reset()
+	dir := t.TempDir()
+	t.Setenv("SPREAKER_CONFIG_DIR", dir)
+	t.Setenv("SPREAKER_TOKEN", "")
+	t.Cleanup(viper.Reset)
+
+	if err := config.Save(&config.Config{Token: "secret", UserID: 42, RotationTokens: []string{"spare"}, DefaultShowID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	for _, name := range []string{filepath.Join(catalogDir, "stats-7.json"), journalFile, jobsLogFile, queueFile} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := newRootCmd("test")
+	cmd.SetArgs([]string{"logout", "--purge", "-o", "plain"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Reset()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Token != "" || cfg.UserID != 0 || len(cfg.RotationTokens) > 0 {
+		t.Errorf("credentials kept: %+v", cfg)
+	}
+	if cfg.DefaultShowID != 7 {
+		t.Errorf("default_show_id = %d, want 7 kept", cfg.DefaultShowID)
+	}
+	for _, name := range []string{catalogDir, journalFile, jobsLogFile} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s not deleted: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, queueFile)); err != nil {
+		t.Errorf("offline queue deleted: %v", err)
+	}
+}
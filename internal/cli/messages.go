@@ -10,6 +10,8 @@ import (
 	"github.com/spf13/cobra"
 	
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/output"
 )
 
 func newMessagesCmd() *cobra.Command {
@@ -22,6 +24,7 @@ that listeners can leave on episodes to communicate with the author.
 
 Examples:
   spreaker messages list 12345
+  spreaker messages get 12345 67890
   spreaker messages create 12345 "Great episode!"
   spreaker messages delete 12345 67890
   spreaker messages report 12345 67890`,
@@ -29,6 +32,7 @@ Examples:
 
 	cmd.AddCommand(
 		newMessagesListCmd(),
+		newMessagesGetCmd(),
 		newMessagesCreateCmd(),
 		newMessagesDeleteCmd(),
 		newMessagesReportCmd(),
@@ -78,17 +82,71 @@ func runMessagesList(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No messages found for this episode.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No messages found for this episode."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintMessages(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more messages available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more messages available, use --limit to see more)"))
 	}
 
+	if format, _ := cmd.Flags().GetString("output"); format == "" || format == "table" || format == "wide" {
+		for _, m := range result.Items {
+			if len(m.Text) > output.MessagePreviewLength {
+				formatter.PrintMessage(i18n.T("\n(long messages are truncated, use 'spreaker messages get %d <message-id>' for the full text)", episodeID))
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// messages get
+// -----------------------------------------------------------------------------
+
+func newMessagesGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <episode-id> <message-id>",
+		Short: "Show a message with its full text",
+		Long: `Show a single message of an episode with its full text and author details.
+
+Examples:
+  spreaker messages get 12345 67890
+  spreaker msg get 12345 67890 --output json`,
+		Args: cobra.ExactArgs(2),
+		RunE: runMessagesGet,
+	}
+}
+
+func runMessagesGet(cmd *cobra.Command, args []string) error {
+	episodeID, err := parseEpisodeID(args[0])
+	if err != nil {
+		return err
+	}
+
+	messageID, err := parseMessageID(args[1])
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	message, err := client.GetEpisodeMessage(episodeID, messageID)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintEpisodeMessage(message)
 	return nil
 }
 
@@ -128,7 +186,7 @@ func runMessagesCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("Message sent successfully.")
+	formatter.PrintMessage(i18n.T("Message sent successfully."))
 	return nil
 }
 
@@ -172,7 +230,7 @@ func runMessagesDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("Message deleted successfully.")
+	formatter.PrintMessage(i18n.T("Message deleted successfully."))
 	return nil
 }
 
@@ -216,6 +274,6 @@ func runMessagesReport(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintMessage("Message reported successfully. Spreaker staff will review it within 1 working day.")
+	formatter.PrintMessage(i18n.T("Message reported successfully. Spreaker staff will review it within 1 working day."))
 	return nil
 }
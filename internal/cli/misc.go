@@ -1,14 +1,31 @@
 /*
 misc.go - Miscellaneous utility commands
 
-Commands for listing categories and languages.
+Commands for listing categories and languages. Both are cached per locale
+next to the config file, so they can be listed offline and category names
+resolved to IDs without calling the API.
 */
 package cli
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/refdata"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
+// refdataFile is the name of the reference data cache in the config
+// directory.
+const refdataFile = "refdata.json"
+
 func newMiscCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "misc",
@@ -16,11 +33,14 @@ func newMiscCmd() *cobra.Command {
 		Short:   "List categories and languages",
 		Long: `List available categories and languages for shows.
 
-These are useful reference data when creating or searching for shows.`,
+These are useful reference data when creating or searching for shows.
+They are cached per locale for a week, so they can be listed offline with
+--offline; --refresh fetches them again.`,
 	}
 
 	cmd.AddCommand(
 		newMiscCategoriesCmd(),
+		newMiscCategoryIDCmd(),
 		newMiscGooglePlayCategoriesCmd(),
 		newMiscLanguagesCmd(),
 	)
@@ -40,29 +60,59 @@ func newMiscCategoriesCmd() *cobra.Command {
 
 Examples:
   spreaker misc categories
-  spreaker misc categories --locale it_IT`,
+  spreaker misc categories --locale it_IT
+  spreaker misc categories --offline
+  spreaker misc categories --tree
+  spreaker misc categories --search crime
+  spreaker misc categories --parent 11`,
 		RunE: runMiscCategories,
 	}
 
-	cmd.Flags().String("locale", "", "Locale for category names (e.g., it_IT)")
+	cmd.Flags().Bool("tree", false, "Show categories as a tree of top-level categories and subcategories")
+	cmd.Flags().String("search", "", "Only list categories whose name, or whose parent's name, contains this text")
+	cmd.Flags().Int("parent", 0, "Only list the subcategories of this top-level category ID")
+	addRefdataFlags(cmd)
 
 	return cmd
 }
 
 func runMiscCategories(cmd *cobra.Command, args []string) error {
-	client, err := getClient(cmd)
+	locale, err := apiLocale(cmd)
 	if err != nil {
 		return err
 	}
+	tree, _ := cmd.Flags().GetBool("tree")
+	search, _ := cmd.Flags().GetString("search")
+	parentID, _ := cmd.Flags().GetInt("parent")
 
-	locale, _ := cmd.Flags().GetString("locale")
-	categories, err := client.GetShowCategories(locale)
+	categories, err := cachedCategories(cmd, locale)
 	if err != nil {
 		return err
 	}
 
+	var parent *refdata.CategoryNode
+	if parentID != 0 {
+		for _, node := range refdata.CategoryTree(categories) {
+			if node.CategoryID == parentID && node.Level == 1 {
+				parent = &node
+				break
+			}
+		}
+		if parent == nil {
+			return fmt.Errorf("no top-level category with ID %d", parentID)
+		}
+	}
+	categories = refdata.FilterCategories(categories, search, parentID)
+
 	formatter := getFormatter(cmd)
-	formatter.PrintCategories(categories)
+	switch {
+	case tree && parent != nil:
+		formatter.PrintCategoryTree([]refdata.CategoryNode{{Category: parent.Category, Children: categories}})
+	case tree:
+		formatter.PrintCategoryTree(refdata.CategoryTree(categories))
+	default:
+		formatter.PrintCategories(categories)
+	}
 	return nil
 }
 
@@ -106,28 +156,192 @@ func newMiscLanguagesCmd() *cobra.Command {
 
 Examples:
   spreaker misc languages
-  spreaker misc languages --locale it_IT`,
+  spreaker misc languages --locale it_IT
+  spreaker misc languages --offline`,
 		RunE: runMiscLanguages,
 	}
 
-	cmd.Flags().String("locale", "", "Locale for language names (e.g., it_IT)")
+	addRefdataFlags(cmd)
 
 	return cmd
 }
 
 func runMiscLanguages(cmd *cobra.Command, args []string) error {
-	client, err := getClient(cmd)
+	locale, err := apiLocale(cmd)
 	if err != nil {
 		return err
 	}
 
-	locale, _ := cmd.Flags().GetString("locale")
-	languages, err := client.GetShowLanguagesList(locale)
+	var languages map[string]string
+	err = withRefdata(cmd, func(cache *refdata.Cache, opts refdata.Options) (err error) {
+		languages, err = cache.GetLanguages(locale, opts, func(locale string) (map[string]string, error) {
+			client, err := getClient(cmd)
+			if err != nil {
+				return nil, err
+			}
+			return client.GetShowLanguages(locale)
+		})
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintLanguages(languages)
+	formatter.PrintLanguages(refdata.LanguageList(languages))
 	return nil
 }
+
+// -----------------------------------------------------------------------------
+// misc category-id
+// -----------------------------------------------------------------------------
+
+func newMiscCategoryIDCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "category-id <name>",
+		Short: "Find the ID of a category by name",
+		Long: `Find the ID of a show category by its name or permalink, ignoring case.
+If no category has that name, the one whose name contains it, or is
+within a few typos of it, is shown; several such categories are an error.
+
+Names are looked up in the cached categories of --locale, so scripts can
+resolve names without calling the API each time.
+
+Examples:
+  spreaker misc category-id "True Crime"
+  spreaker misc category-id "Cronaca nera" --locale it_IT
+  spreaker misc category-id "True Crime" --offline -o plain | cut -f1`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMiscCategoryID,
+	}
+
+	addRefdataFlags(cmd)
+
+	return cmd
+}
+
+func runMiscCategoryID(cmd *cobra.Command, args []string) error {
+	locale, err := apiLocale(cmd)
+	if err != nil {
+		return err
+	}
+	categories, err := cachedCategories(cmd, locale)
+	if err != nil {
+		return err
+	}
+
+	matches, exact := refdata.FindCategory(categories, args[0])
+	switch {
+	case len(matches) == 0:
+		return fmt.Errorf("no category named %q", args[0])
+	case len(matches) > 1 && !exact:
+		return ambiguousCategory(args[0], matches)
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintCategories(matches)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Reference data cache
+// -----------------------------------------------------------------------------
+
+func addRefdataFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("offline", false, "Use cached data only, however old, without calling the API")
+	cmd.Flags().Bool("refresh", false, "Fetch the data again even if the cache is fresh")
+	cmd.MarkFlagsMutuallyExclusive("offline", "refresh")
+}
+
+// withRefdata runs fn with the reference data cache, saving it afterwards
+// if fn fetched new data.
+func withRefdata(cmd *cobra.Command, fn func(cache *refdata.Cache, opts refdata.Options) error) error {
+	path, err := config.DataFilePath(refdataFile)
+	if err != nil {
+		return err
+	}
+	cache, err := refdata.Load(path)
+	if err != nil {
+		return err
+	}
+
+	var opts refdata.Options
+	opts.Offline, _ = cmd.Flags().GetBool("offline")
+	opts.Refresh, _ = cmd.Flags().GetBool("refresh")
+
+	if err := fn(cache, opts); err != nil {
+		if errors.Is(err, refdata.ErrNotCached) {
+			return fmt.Errorf("%w; run once without --offline to cache them", err)
+		}
+		return err
+	}
+
+	// The data is still usable if the cache cannot be written.
+	if err := cache.Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+// cachedCategories returns the show categories of locale through the
+// reference data cache.
+func cachedCategories(cmd *cobra.Command, locale string) ([]models.Category, error) {
+	var categories []models.Category
+	err := withRefdata(cmd, func(cache *refdata.Cache, opts refdata.Options) (err error) {
+		categories, err = cache.GetCategories(locale, opts, func(locale string) ([]models.Category, error) {
+			client, err := getClient(cmd)
+			if err != nil {
+				return nil, err
+			}
+			return client.GetShowCategories(locale)
+		})
+		return err
+	})
+	return categories, err
+}
+
+// resolveCategory returns the ID of the category named name, as found by
+// misc category-id. When the name matches several categories, the user
+// chooses one, or without a terminal (or with --no-input) it is an error.
+func resolveCategory(cmd *cobra.Command, name, locale string) (int, error) {
+	categories, err := cachedCategories(cmd, locale)
+	if err != nil {
+		return 0, err
+	}
+
+	matches, _ := refdata.FindCategory(categories, name)
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no category named %q; see 'spreaker misc categories'", name)
+	case 1:
+		return matches[0].CategoryID, nil
+	}
+
+	if noInput, _ := cmd.Flags().GetBool("no-input"); noInput || !isInteractive() {
+		return 0, ambiguousCategory(name, matches)
+	}
+
+	options := make([]string, len(matches))
+	ids := make(map[string]int, len(matches))
+	for i, c := range matches {
+		options[i] = fmt.Sprintf("%s (%d)", c.Name, c.CategoryID)
+		ids[options[i]] = c.CategoryID
+	}
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Select a category"))
+	if err != nil {
+		return 0, fmt.Errorf("category selection cancelled: %w", err)
+	}
+	return ids[selected], nil
+}
+
+// ambiguousCategory is the error of a name matching several categories.
+func ambiguousCategory(name string, matches []models.Category) error {
+	names := make([]string, len(matches))
+	for i, c := range matches {
+		names[i] = fmt.Sprintf("%s (%d)", c.Name, c.CategoryID)
+	}
+	return fmt.Errorf("%q matches several categories: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,203 @@
+/*
+monitor.go - Keyword and tag monitoring
+
+monitor periodically searches episodes by tag or keyword and reports the
+ones not seen before, e.g. to follow mentions of a brand or a competitor.
+*/
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/hooks"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/monitor"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// monitorStateFile is the default state file, next to the config file.
+const monitorStateFile = "monitor-state.json"
+
+// notifyDesktop is the --notify value for desktop notifications.
+const notifyDesktop = "desktop"
+
+func newMonitorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "Watch tags and keywords for new episodes",
+		Long: `Periodically search episodes by tag (--tag) and keyword (--query) and
+report the ones not seen before.
+
+New episodes are always printed. With --notify they are also sent to:
+  desktop          a desktop notification (Linux, macOS and Windows)
+  https://...      a webhook, as a JSON POST (X-Spreaker-Event: monitor_match)
+  anything else    a shell command, with the JSON on stdin
+
+Seen episode IDs are kept in a state file, so a restarted monitor only
+reports what is new. The first run of a tag or keyword records its
+current results without reporting them.
+
+Examples:
+  spreaker monitor --tag "my brand" --query "competitor"
+  spreaker monitor --tag news --interval 5m --notify desktop
+  spreaker monitor --query "my show" --notify https://example.com/hooks/spreaker
+  spreaker monitor --tag "my brand" --once     # single check, e.g. from cron`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{noPagerAnnotation: ""},
+		RunE:        runMonitor,
+	}
+
+	cmd.Flags().StringSlice("tag", nil, "Tag to watch (repeatable)")
+	cmd.Flags().StringSlice("query", nil, "Search keyword to watch (repeatable)")
+	cmd.Flags().Duration("interval", 15*time.Minute, "Time between checks")
+	cmd.Flags().Bool("once", false, "Check once and exit")
+	cmd.Flags().StringSlice("notify", nil, "Also notify: desktop, a webhook URL or a shell command (repeatable)")
+	cmd.Flags().IntP("limit", "l", 50, "Number of results to check per tag or keyword")
+	cmd.Flags().String("state", "", "State file (default: monitor-state.json next to the config file)")
+
+	return cmd
+}
+
+func runMonitor(cmd *cobra.Command, args []string) error {
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	queries, _ := cmd.Flags().GetStringSlice("query")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	once, _ := cmd.Flags().GetBool("once")
+	notify, _ := cmd.Flags().GetStringSlice("notify")
+	limit, _ := cmd.Flags().GetInt("limit")
+	statePath, _ := cmd.Flags().GetString("state")
+
+	sources := monitorSources(tags, queries)
+	if len(sources) == 0 {
+		return fmt.Errorf("nothing to monitor: use --tag or --query")
+	}
+	if interval < time.Minute && !once {
+		return fmt.Errorf("invalid interval %s: must be at least 1m", interval)
+	}
+
+	if statePath == "" {
+		path, err := config.DataFilePath(monitorStateFile)
+		if err != nil {
+			return err
+		}
+		statePath = path
+	}
+	state, err := monitor.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	if !once {
+		formatter.PrintMessage(i18n.T("Monitoring %d tag(s) and keyword(s) every %s (Ctrl+C to stop)", len(sources), interval))
+	}
+
+	ctx := cmd.Context()
+	for {
+		for _, src := range sources {
+			episodes, err := searchMonitorSource(client, src, limit)
+			if err != nil {
+				if once {
+					return fmt.Errorf("%s %q: %w", src.Kind, src.Term, err)
+				}
+				// A failed check is retried at the next interval.
+				formatter.PrintWarning(i18n.T("%s %q: %v", src.Kind, src.Term, err))
+				continue
+			}
+
+			fresh, baseline := state.Update(src, episodes)
+			if baseline {
+				formatter.PrintMessage(i18n.T("Watching %s %q (%d existing episodes)", src.Kind, src.Term, len(episodes)))
+			}
+			if len(fresh) > 0 {
+				reportMonitorMatch(cmd, formatter, monitor.Match{Source: src, Episodes: fresh}, notify)
+			}
+		}
+
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+		if once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// monitorSources turns the --tag and --query values into sources,
+// skipping blanks and duplicates.
+func monitorSources(tags, queries []string) []monitor.Source {
+	var sources []monitor.Source
+	seen := make(map[string]bool)
+	add := func(kind string, terms []string) {
+		for _, term := range terms {
+			src := monitor.Source{Kind: kind, Term: strings.TrimSpace(term)}
+			if src.Term == "" || seen[src.Key()] {
+				continue
+			}
+			seen[src.Key()] = true
+			sources = append(sources, src)
+		}
+	}
+	add(monitor.KindTag, tags)
+	add(monitor.KindQuery, queries)
+	return sources
+}
+
+func searchMonitorSource(client *api.Client, src monitor.Source, limit int) ([]models.Episode, error) {
+	pagination := api.PaginationParams{Limit: limit}
+
+	var result *api.PaginatedResult[models.Episode]
+	var err error
+	if src.Kind == monitor.KindTag {
+		result, err = client.GetEpisodesByTag(src.Term, pagination)
+	} else {
+		result, err = client.SearchEpisodes(api.SearchParams{Query: src.Term}, pagination)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// reportMonitorMatch prints new episodes and sends them to the --notify
+// targets. Notification failures are warnings, so monitoring continues.
+func reportMonitorMatch(cmd *cobra.Command, formatter *output.Formatter, match monitor.Match, notify []string) {
+	title := i18n.T("%d new episode(s) for %s %q", len(match.Episodes), match.Source.Kind, match.Source.Term)
+
+	formatter.PrintMessage(title)
+	formatter.PrintEpisodes(match.Episodes)
+
+	for _, target := range notify {
+		var err error
+		if target == notifyDesktop {
+			titles := make([]string, len(match.Episodes))
+			for i, e := range match.Episodes {
+				titles[i] = e.Title
+			}
+			err = monitor.Desktop(title, strings.Join(titles, "\n"))
+		} else {
+			err = hooks.Run(cmd.Context(), hooks.EventMonitorMatch, target, match)
+		}
+		if err != nil {
+			formatter.PrintWarning(i18n.T("notify %s: %v", target, err))
+		}
+	}
+}
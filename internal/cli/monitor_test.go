@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/internal/monitor"
+)
+
+func TestMonitorSources(t *testing.T) {
+	got := monitorSources([]string{"my brand", " ", "my brand", "news"}, []string{" my brand "})
+	want := []monitor.Source{
+		{Kind: monitor.KindTag, Term: "my brand"},
+		{Kind: monitor.KindTag, Term: "news"},
+		{Kind: monitor.KindQuery, Term: "my brand"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("monitorSources() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,424 @@
+/*
+network.go - Commands across all shows of a network
+
+network treats a set of shows as one portfolio: every show of the
+authenticated user, the network_shows config key, or --shows. Each command
+fetches the shows concurrently and prints one consolidated result.
+*/
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// networkCSVHeader is the header row of "network export --format csv".
+var networkCSVHeader = []string{"show_id", "show_title", "episode_id", "title", "published_at", "duration_ms", "plays_count", "likes_count", "messages_count", "site_url"}
+
+func newNetworkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Work with all of your shows at once",
+		Long: `Treat a set of shows as one network: statistics, episode lists and
+exports covering every show, fetched concurrently.
+
+The network is every show of your account, unless the network_shows config
+key or --shows lists the shows to cover.
+
+Examples:
+  spreaker network stats --from 30d
+  spreaker network list-episodes --published-after 7d
+  spreaker network export --out network.csv
+  spreaker config set network_shows "12345,67890"`,
+	}
+
+	cmd.PersistentFlags().IntSlice("shows", nil, "Shows of the network (default: network_shows config key, else all your shows)")
+	cmd.PersistentFlags().Int("concurrency", api.DefaultConcurrency, "Number of shows to fetch at once")
+
+	cmd.AddCommand(
+		newNetworkStatsCmd(),
+		newNetworkListEpisodesCmd(),
+		newNetworkExportCmd(),
+	)
+
+	return cmd
+}
+
+// networkConcurrency returns --concurrency, checked.
+func networkConcurrency(cmd *cobra.Command) (int, error) {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return 0, fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+	return concurrency, nil
+}
+
+// networkShows returns the shows of the network: those of --shows, else
+// of the network_shows config key, else every show of the user.
+func networkShows(cmd *cobra.Command, client *api.Client, concurrency int) ([]models.Show, error) {
+	ids, _ := cmd.Flags().GetIntSlice("shows")
+	if len(ids) == 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config: %w", err)
+		}
+		ids = cfg.NetworkShows
+	}
+
+	if len(ids) == 0 {
+		userID, err := getMyUserID(cmd, client)
+		if err != nil {
+			return nil, err
+		}
+		shows, err := client.GetAllUserShows(userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(shows) == 0 {
+			return nil, fmt.Errorf("no shows found for your account")
+		}
+		return shows, nil
+	}
+
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid show ID: %d", id)
+		}
+	}
+	return api.FetchAll(cmd.Context(), ids, concurrency, func(id int) (models.Show, error) {
+		show, err := client.GetShow(id)
+		if err != nil {
+			return models.Show{}, fmt.Errorf("failed to fetch show %d: %w", id, err)
+		}
+		return *show, nil
+	})
+}
+
+// networkEpisodes fetches every episode of shows, in the order of shows.
+func networkEpisodes(cmd *cobra.Command, client *api.Client, shows []models.Show, concurrency int) ([][]models.Episode, error) {
+	return api.FetchAll(cmd.Context(), shows, concurrency, func(show models.Show) ([]models.Episode, error) {
+		episodes, err := client.GetAllShowEpisodes(show.ShowID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch episodes of show %d: %w", show.ShowID, err)
+		}
+		return episodes, nil
+	})
+}
+
+// -----------------------------------------------------------------------------
+// network stats
+// -----------------------------------------------------------------------------
+
+func newNetworkStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Compare plays and likes of every show in the network",
+		Long: `Show each show's plays, downloads and likes over --from to --to, with its
+share of the network's plays and the network total. Shows are listed
+busiest first.
+
+Examples:
+  spreaker network stats
+  spreaker network stats --from 2024-01-01 --to 2024-03-31
+  spreaker network stats --shows 12345,67890 -o json`,
+		Args: cobra.NoArgs,
+		RunE: runNetworkStats,
+	}
+
+	cmd.Flags().String("from", "30d", "Start date (YYYY-MM-DD, today, yesterday or Nd)")
+	cmd.Flags().String("to", "today", "End date (YYYY-MM-DD, today, yesterday or Nd)")
+
+	return cmd
+}
+
+func runNetworkStats(cmd *cobra.Command, args []string) error {
+	concurrency, err := networkConcurrency(cmd)
+	if err != nil {
+		return err
+	}
+
+	var window [2]string
+	now := time.Now()
+	for i, flag := range []string{"from", "to"} {
+		value, _ := cmd.Flags().GetString(flag)
+		d, err := report.ParseDate(value, now, time.UTC)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", flag, err)
+		}
+		window[i] = report.APIDay(d)
+	}
+	if window[0] > window[1] {
+		return fmt.Errorf("--from %s is after --to %s", window[0], window[1])
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	shows, err := networkShows(cmd, client, concurrency)
+	if err != nil {
+		return err
+	}
+
+	params := api.StatisticsParams{From: window[0], To: window[1], Group: "day"}
+	rows, err := api.FetchAll(cmd.Context(), shows, concurrency, func(show models.Show) (report.NetworkShow, error) {
+		plays, err := client.GetShowPlayStatistics(show.ShowID, params)
+		if err != nil {
+			return report.NetworkShow{}, fmt.Errorf("failed to fetch plays of show %d: %w", show.ShowID, err)
+		}
+		likes, err := client.GetShowLikesStatistics(show.ShowID, params)
+		if err != nil {
+			return report.NetworkShow{}, fmt.Errorf("failed to fetch likes of show %d: %w", show.ShowID, err)
+		}
+		return report.NewNetworkShow(show, plays, likes), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintNetworkStats(report.BuildNetworkStats(window[0], window[1], rows))
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// network list-episodes
+// -----------------------------------------------------------------------------
+
+func newNetworkListEpisodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-episodes",
+		Short: "List the episodes of every show in the network",
+		Long: `List the published episodes of every show in the network together, newest
+first. Use -o wide to see the show of each episode.
+
+Examples:
+  spreaker network list-episodes --published-after 7d
+  spreaker network list-episodes --published-after 2024-01-01 --limit 100 -o wide`,
+		Args: cobra.NoArgs,
+		RunE: runNetworkListEpisodes,
+	}
+
+	cmd.Flags().String("published-after", "", "Only list episodes published on or after this day (YYYY-MM-DD, today, yesterday or Nd)")
+	cmd.Flags().IntP("limit", "l", 50, "Maximum number of episodes (0 for all)")
+
+	return cmd
+}
+
+func runNetworkListEpisodes(cmd *cobra.Command, args []string) error {
+	concurrency, err := networkConcurrency(cmd)
+	if err != nil {
+		return err
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("invalid limit %d: must be 0 or more", limit)
+	}
+
+	var after time.Time
+	if value, _ := cmd.Flags().GetString("published-after"); value != "" {
+		if after, err = report.ParseDate(value, time.Now(), time.UTC); err != nil {
+			return fmt.Errorf("--published-after: %w", err)
+		}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	shows, err := networkShows(cmd, client, concurrency)
+	if err != nil {
+		return err
+	}
+	lists, err := networkEpisodes(cmd, client, shows, concurrency)
+	if err != nil {
+		return err
+	}
+
+	var episodes []models.Episode
+	for _, list := range lists {
+		for _, e := range list {
+			if e.PublishedAt == nil || e.PublishedAt.Time.Before(after) {
+				continue
+			}
+			episodes = append(episodes, e)
+		}
+	}
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].PublishedAt.Time.After(episodes[j].PublishedAt.Time)
+	})
+	if limit > 0 && len(episodes) > limit {
+		episodes = episodes[:limit]
+	}
+
+	formatter := getFormatter(cmd)
+	if len(episodes) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found."))
+		return nil
+	}
+	formatter.PrintEpisodes(episodes)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// network export
+// -----------------------------------------------------------------------------
+
+// networkExport is the JSON document written by "network export".
+type networkExport struct {
+	ExportedAt time.Time           `json:"exported_at"`
+	Shows      []networkExportShow `json:"shows"`
+}
+
+type networkExportShow struct {
+	models.Show
+	Episodes []models.Episode `json:"episodes"`
+}
+
+func newNetworkExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export every show of the network and its episodes",
+		Long: `Export the shows of the network with all of their episodes to one file.
+
+JSON holds every show with its episodes. CSV has one row per episode with
+its show ID and title, ready for a spreadsheet. The format follows the
+extension of --out unless --format is given.
+
+Examples:
+  spreaker network export
+  spreaker network export --out network.csv
+  spreaker network export --shows 12345,67890 --out catalog.json`,
+		Args: cobra.NoArgs,
+		RunE: runNetworkExport,
+	}
+
+	cmd.Flags().String("out", "network.json", "Path of the file to write")
+	cmd.Flags().String("format", "", "File format: json or csv (default: from the --out extension)")
+
+	return cmd
+}
+
+func runNetworkExport(cmd *cobra.Command, args []string) error {
+	concurrency, err := networkConcurrency(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, _ := cmd.Flags().GetString("out")
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		format = "json"
+		if strings.EqualFold(filepath.Ext(outPath), ".csv") {
+			format = "csv"
+		}
+	}
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid format %q (must be json or csv)", format)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	spinner := formatter.StartSpinner(i18n.T("Fetching the episodes of your network..."))
+
+	shows, err := networkShows(cmd, client, concurrency)
+	if err != nil {
+		formatter.CancelSpinner(spinner)
+		return err
+	}
+	lists, err := networkEpisodes(cmd, client, shows, concurrency)
+	if err != nil {
+		formatter.CancelSpinner(spinner)
+		return err
+	}
+	formatter.StopSpinner(spinner, true, i18n.T("Fetched %d shows", len(shows)))
+
+	export := networkExport{ExportedAt: time.Now().UTC(), Shows: make([]networkExportShow, len(shows))}
+	episodes := 0
+	for i, show := range shows {
+		export.Shows[i] = networkExportShow{Show: show, Episodes: lists[i]}
+		if export.Shows[i].Episodes == nil {
+			export.Shows[i].Episodes = []models.Episode{}
+		}
+		episodes += len(lists[i])
+	}
+
+	if format == "csv" {
+		err = writeNetworkCSV(outPath, export.Shows)
+	} else {
+		err = writeNetworkJSON(outPath, export)
+	}
+	if err != nil {
+		return err
+	}
+
+	formatter.PrintSuccess(i18n.T("Exported %d shows and %d episodes to %s", len(shows), episodes, outPath))
+	return nil
+}
+
+func writeNetworkJSON(path string, export networkExport) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeNetworkCSV(path string, shows []networkExportShow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(networkCSVHeader)
+	for _, show := range shows {
+		for _, e := range show.Episodes {
+			published := ""
+			if e.PublishedAt != nil {
+				published = e.PublishedAt.Time.UTC().Format(time.DateTime)
+			}
+			w.Write([]string{
+				strconv.Itoa(show.ShowID),
+				show.Title,
+				strconv.Itoa(e.EpisodeID),
+				e.Title,
+				published,
+				strconv.Itoa(e.Duration),
+				strconv.Itoa(e.PlayCount),
+				strconv.Itoa(e.LikesCount),
+				strconv.Itoa(e.MessagesCount),
+				e.SiteURL,
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
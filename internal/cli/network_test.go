@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestWriteNetworkCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "network.csv")
+	published := &models.CustomTime{Time: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)}
+	shows := []networkExportShow{
+		{Show: models.Show{ShowID: 1, Title: "Tech, weekly"}, Episodes: []models.Episode{
+			{EpisodeID: 10, Title: "Pilot", PublishedAt: published, Duration: 60000, PlayCount: 5},
+			{EpisodeID: 11, Title: "Draft"},
+		}},
+		{Show: models.Show{ShowID: 2, Title: "Empty"}, Episodes: []models.Episode{}},
+	}
+
+	if err := writeNetworkCSV(path, shows); err != nil {
+		t.Fatalf("writeNetworkCSV: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "show_id,show_title,episode_id,title,published_at,duration_ms,plays_count,likes_count,messages_count,site_url\n" +
+		"1,\"Tech, weekly\",10,Pilot,2024-03-01 09:30:00,60000,5,0,0,\n" +
+		"1,\"Tech, weekly\",11,Draft,,0,0,0,0,\n"
+	if string(data) != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+open.go - Open Spreaker pages in the browser
+
+Commands for jumping from the terminal to a show, an episode or a show's
+statistics on spreaker.com.
+*/
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func newOpenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "open",
+		Short: "Open shows, episodes and statistics in the browser",
+		Long: `Open a show or episode page, or a show's statistics in the Spreaker CMS,
+in your default browser.
+
+Use --print to only print the URL, e.g. on a machine without a browser.`,
+	}
+
+	cmd.PersistentFlags().Bool("print", false, "Print the URL instead of opening it")
+
+	cmd.AddCommand(
+		newOpenShowCmd(),
+		newOpenEpisodeCmd(),
+		newOpenStatsCmd(),
+	)
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// open show
+// -----------------------------------------------------------------------------
+
+func newOpenShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [show-id]",
+		Short: "Open a show's page",
+		Long: `Open a show's public page. If show-id is omitted, the default_show_id
+from your config is used.
+
+Examples:
+  spreaker open show 12345
+  spreaker open show --print`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			showID, err := resolveShowID(cmd, client, args)
+			if err != nil {
+				return err
+			}
+
+			show, err := client.GetShow(showID)
+			if err != nil {
+				return err
+			}
+
+			return openURL(cmd, show.PageURL())
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// open episode
+// -----------------------------------------------------------------------------
+
+func newOpenEpisodeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "episode <episode-id>",
+		Short: "Open an episode's page",
+		Long: `Open an episode's public page.
+
+Examples:
+  spreaker open episode 67890`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			episodeID, err := parseEpisodeID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			episode, err := client.GetEpisode(episodeID)
+			if err != nil {
+				return err
+			}
+
+			return openURL(cmd, episode.PageURL())
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// open stats
+// -----------------------------------------------------------------------------
+
+func newOpenStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats [show-id]",
+		Short: "Open a show's statistics in the Spreaker CMS",
+		Long: `Open a show's statistics page in the Spreaker CMS. If show-id is
+omitted, the default_show_id from your config is used.
+
+Examples:
+  spreaker open stats 12345`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			showID, err := resolveShowID(cmd, client, args)
+			if err != nil {
+				return err
+			}
+
+			show := models.Show{ShowID: showID}
+			return openURL(cmd, show.StatsURL())
+		},
+	}
+}
+
+// openURL opens url in the default browser, or prints it with --print.
+func openURL(cmd *cobra.Command, url string) error {
+	if printOnly, _ := cmd.Flags().GetBool("print"); printOnly {
+		fmt.Println(url)
+		return nil
+	}
+
+	if err := browserCommand(url).Start(); err != nil {
+		return fmt.Errorf("failed to open browser (use --print to show the URL): %w", err)
+	}
+	return nil
+}
+
+// browserCommand returns the command that opens url in the platform's
+// default browser.
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
@@ -0,0 +1,442 @@
+/*
+publish.go - Multi-step episode publishing
+
+publish runs upload, chapters, cuepoints, image and schedule from a single
+manifest file as one operation: if a step fails the new episode is deleted,
+or kept with a state file so only the failed steps are retried.
+*/
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// publishTimeLayout is the format Spreaker expects for auto_published_at.
+const publishTimeLayout = "2006-01-02 15:04:05"
+
+// Publish steps, in the order they run.
+const (
+	stepUpload    = "upload"
+	stepChapters  = "chapters"
+	stepCuepoints = "cuepoints"
+	stepImage     = "image"
+	stepSchedule  = "schedule"
+)
+
+func newPublishCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish -f <episode.yaml>",
+		Short: "Upload and configure an episode in one step",
+		Long: `Publish an episode described by a YAML manifest: upload the audio, add
+chapters and cuepoints, set the cover image and schedule it.
+
+The episode is uploaded hidden and only made public (or scheduled) by the
+last step. If any step fails, the new episode is deleted. With
+--no-rollback it is kept instead, and progress is saved next to the
+manifest (<manifest>.state) so that --resume retries only the steps that
+did not complete.
+
+Manifest:
+  show_id: 12345              # default_show_id when omitted
+  title: "Episode 42"
+  description: "Show notes"
+  tags: [tech, weekly]
+  explicit: false
+  downloadable: true
+  hidden: false
+  external_id: build-42       # see "episodes upload --external-id"
+  audio: ./episode-42.mp3     # paths are relative to the manifest
+  image: ./cover.jpg
+  publish_at: "2026-05-04 18:00:00"   # UTC; publish immediately when omitted
+  chapters:
+    - {starts_at: 0, title: Intro}
+    - {starts_at: 90000, title: News, url: "https://example.com"}
+  cuepoints:
+    - {timecode: 30000, max_ads: 1}
+
+Examples:
+  spreaker publish -f episode.yaml
+  spreaker publish -f episode.yaml --no-rollback
+  spreaker publish -f episode.yaml --resume
+  spreaker publish -f episode.yaml --notify    # desktop notification when done`,
+		Args: cobra.NoArgs,
+		RunE: notifyDone(runPublish),
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Episode manifest (YAML) (required)")
+	cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("no-rollback", false, "Keep the episode when a step fails, so the publish can be resumed")
+	cmd.Flags().Bool("resume", false, "Retry the steps left unfinished by a previous --no-rollback run")
+	cmd.Flags().Bool("allow-duplicate", false, "Publish even if the show already has this episode")
+	cmd.Flags().Bool("force", false, "Skip confirmation prompt (see confirm.upload)")
+	addNotifyFlag(cmd)
+
+	return cmd
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	manifestPath, _ := cmd.Flags().GetString("file")
+	noRollback, _ := cmd.Flags().GetBool("no-rollback")
+	resume, _ := cmd.Flags().GetBool("resume")
+	allowDuplicate, _ := cmd.Flags().GetBool("allow-duplicate")
+
+	manifest, err := loadPublishManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	statePath := manifestPath + ".state"
+	state, err := loadPublishState(statePath)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state != nil && !resume:
+		return fmt.Errorf("%s holds an unfinished publish of episode %d; rerun with --resume or delete it", statePath, state.EpisodeID)
+	case state == nil && resume:
+		return fmt.Errorf("nothing to resume: %s not found", statePath)
+	case state == nil:
+		state = &publishState{}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	showID := manifest.ShowID
+	if showID == 0 {
+		if showID, err = resolveShowID(cmd, client, nil); err != nil {
+			return err
+		}
+	}
+
+	params := manifest.uploadParams()
+	applyShowDefaults(cmd, showID, &params)
+	if manifest.Explicit != nil {
+		params.Explicit = *manifest.Explicit
+	}
+	if manifest.Downloadable != nil {
+		params.DownloadEnabled = *manifest.Downloadable
+	}
+
+	formatter := getFormatter(cmd)
+
+	if !resume && !allowDuplicate {
+		dup, err := showDuplicate(cmd.Context(), client, showID, manifest.Title, manifest.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to check for duplicate episodes: %w", err)
+		}
+		if dup != nil {
+			return fmt.Errorf("show %d already has episode %d %q; use --allow-duplicate to publish anyway", showID, dup.EpisodeID, dup.Title)
+		}
+	}
+
+	if !resume {
+		prompt := fmt.Sprintf("Publish %q to show %d?", manifest.Title, showID)
+		if ok, err := confirm(cmd, actionUpload, prompt, showTitle(client, showID)); !ok {
+			return err
+		}
+	}
+
+	steps := publishSteps(client, manifest, showID, params, state, resume)
+	failed, err := runPublishSteps(formatter, steps, state, statePath)
+	if err != nil {
+		return finishFailedPublish(client, state, statePath, failed, noRollback, err)
+	}
+
+	os.Remove(statePath)
+
+	episode, err := client.GetEpisode(state.EpisodeID)
+	if err != nil {
+		return err
+	}
+	formatter.PrintSuccess(i18n.T("Episode published!"))
+	formatter.PrintEpisode(episode)
+
+	runEpisodePublishedHook(cmd, formatter, episode)
+	return nil
+}
+
+// finishFailedPublish rolls back the episode created by a failed publish,
+// or keeps it and its state file when noRollback is set.
+func finishFailedPublish(client *api.Client, state *publishState, statePath, failed string, noRollback bool, err error) error {
+	if state.EpisodeID == 0 {
+		os.Remove(statePath)
+		return fmt.Errorf("%s step failed: %w", failed, err)
+	}
+	if noRollback {
+		return fmt.Errorf("%s step failed: %w\nepisode %d was kept; fix the problem and rerun with --resume", failed, err, state.EpisodeID)
+	}
+	if derr := client.DeleteEpisode(state.EpisodeID); derr != nil {
+		return fmt.Errorf("%s step failed: %w\nrollback failed, episode %d was not deleted: %v (progress kept in %s)", failed, err, state.EpisodeID, derr, statePath)
+	}
+	os.Remove(statePath)
+	return fmt.Errorf("%s step failed, episode %d was deleted: %w", failed, state.EpisodeID, err)
+}
+
+// -----------------------------------------------------------------------------
+// Manifest
+// -----------------------------------------------------------------------------
+
+// publishManifest describes an episode to publish.
+type publishManifest struct {
+	ShowID       int               `yaml:"show_id"`
+	Title        string            `yaml:"title"`
+	Description  string            `yaml:"description"`
+	Tags         []string          `yaml:"tags"`
+	Explicit     *bool             `yaml:"explicit"`
+	Downloadable *bool             `yaml:"downloadable"`
+	Hidden       bool              `yaml:"hidden"`
+	ExternalID   string            `yaml:"external_id"`
+	Audio        string            `yaml:"audio"`
+	Image        string            `yaml:"image"`
+	PublishAt    string            `yaml:"publish_at"`
+	Chapters     []publishChapter  `yaml:"chapters"`
+	Cuepoints    []publishCuepoint `yaml:"cuepoints"`
+}
+
+type publishChapter struct {
+	StartsAt int    `yaml:"starts_at"`
+	Title    string `yaml:"title"`
+	URL      string `yaml:"url"`
+	Image    string `yaml:"image"`
+}
+
+type publishCuepoint struct {
+	Timecode int `yaml:"timecode"`
+	MaxAds   int `yaml:"max_ads"`
+}
+
+// loadPublishManifest reads and validates a manifest. File paths in it are
+// resolved relative to the manifest's directory.
+func loadPublishManifest(path string) (*publishManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m publishManifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	if m.Title == "" {
+		return nil, fmt.Errorf("invalid manifest %s: title is required", path)
+	}
+	if m.Audio == "" {
+		return nil, fmt.Errorf("invalid manifest %s: audio is required", path)
+	}
+	if m.PublishAt != "" {
+		if _, err := time.Parse(publishTimeLayout, m.PublishAt); err != nil {
+			return nil, fmt.Errorf("invalid manifest %s: publish_at must look like %q", path, publishTimeLayout)
+		}
+	}
+	for i, ch := range m.Chapters {
+		if ch.Title == "" {
+			return nil, fmt.Errorf("invalid manifest %s: chapter %d has no title", path, i+1)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	m.Audio = resolveManifestPath(dir, m.Audio)
+	m.Image = resolveManifestPath(dir, m.Image)
+	for i := range m.Chapters {
+		m.Chapters[i].Image = resolveManifestPath(dir, m.Chapters[i].Image)
+	}
+
+	return &m, nil
+}
+
+func resolveManifestPath(dir, p string) string {
+	if p == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, p)
+}
+
+// uploadParams returns the upload parameters for the manifest. The episode
+// is uploaded hidden; the schedule step makes it public.
+func (m *publishManifest) uploadParams() api.UploadEpisodeParams {
+	tags := slices.Clone(m.Tags)
+	if m.ExternalID != "" {
+		tags = append(tags, externalIDTag(m.ExternalID))
+	}
+	return api.UploadEpisodeParams{
+		Title:           m.Title,
+		MediaFile:       m.Audio,
+		Description:     m.Description,
+		Tags:            tags,
+		DownloadEnabled: true,
+		Hidden:          true,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Steps
+// -----------------------------------------------------------------------------
+
+// publishState is the progress of a publish, saved after every step.
+type publishState struct {
+	EpisodeID int      `json:"episode_id"`
+	Completed []string `json:"completed"`
+}
+
+// loadPublishState returns the saved state at path, or nil if there is none.
+func loadPublishState(path string) (*publishState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read publish state: %w", err)
+	}
+
+	var s publishState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid publish state %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func (s *publishState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+type publishStep struct {
+	name string
+	msg  string
+	run  func() error
+}
+
+// publishSteps builds the steps needed for the manifest. When resuming, the
+// chapters step first clears chapters left over from a partial attempt.
+func publishSteps(client *api.Client, m *publishManifest, showID int, params api.UploadEpisodeParams, state *publishState, resume bool) []publishStep {
+	steps := []publishStep{{
+		name: stepUpload,
+		msg:  i18n.T("Uploading %s...", m.Audio),
+		run: func() error {
+			episode, err := client.UploadEpisode(showID, params)
+			if err != nil {
+				return err
+			}
+			state.EpisodeID = episode.EpisodeID
+			return nil
+		},
+	}}
+
+	if len(m.Chapters) > 0 {
+		steps = append(steps, publishStep{
+			name: stepChapters,
+			msg:  i18n.T("Adding %d chapter(s)...", len(m.Chapters)),
+			run: func() error {
+				if resume {
+					if err := client.DeleteAllChapters(state.EpisodeID); err != nil {
+						return err
+					}
+				}
+				for _, ch := range m.Chapters {
+					startsAt := ch.StartsAt
+					_, err := client.AddChapter(state.EpisodeID, api.ChapterParams{
+						StartsAt:    &startsAt,
+						Title:       ch.Title,
+						ExternalURL: ch.URL,
+						ImageFile:   ch.Image,
+					})
+					if err != nil {
+						return fmt.Errorf("chapter %q: %w", ch.Title, err)
+					}
+				}
+				return nil
+			},
+		})
+	}
+
+	if len(m.Cuepoints) > 0 {
+		steps = append(steps, publishStep{
+			name: stepCuepoints,
+			msg:  i18n.T("Setting %d cuepoint(s)...", len(m.Cuepoints)),
+			run: func() error {
+				cuepoints := make([]models.Cuepoint, 0, len(m.Cuepoints))
+				for _, cp := range m.Cuepoints {
+					cuepoints = append(cuepoints, models.Cuepoint{Timecode: cp.Timecode, AdsMaxCount: cp.MaxAds})
+				}
+				return client.UpdateEpisodeCuepoints(state.EpisodeID, cuepoints)
+			},
+		})
+	}
+
+	if m.Image != "" {
+		steps = append(steps, publishStep{
+			name: stepImage,
+			msg:  i18n.T("Uploading image %s...", m.Image),
+			run: func() error {
+				_, err := client.UpdateEpisodeImage(state.EpisodeID, m.Image)
+				return err
+			},
+		})
+	}
+
+	msg := i18n.T("Publishing episode...")
+	if m.PublishAt != "" {
+		msg = i18n.T("Scheduling episode for %s...", m.PublishAt)
+	}
+	steps = append(steps, publishStep{
+		name: stepSchedule,
+		msg:  msg,
+		run: func() error {
+			update := api.UpdateEpisodeParams{Hidden: &m.Hidden}
+			if m.PublishAt != "" {
+				update.AutoPublishedAt = &m.PublishAt
+			}
+			_, err := client.UpdateEpisode(state.EpisodeID, update)
+			return err
+		},
+	})
+
+	return steps
+}
+
+// runPublishSteps runs the steps not yet completed in state, saving state
+// after each one. It returns the name of the step that failed, if any.
+func runPublishSteps(formatter *output.Formatter, steps []publishStep, state *publishState, statePath string) (string, error) {
+	for _, step := range steps {
+		if slices.Contains(state.Completed, step.name) {
+			continue
+		}
+
+		spinner := formatter.StartSpinner(step.msg)
+		if err := step.run(); err != nil {
+			formatter.CancelSpinner(spinner)
+			return step.name, err
+		}
+		formatter.StopSpinner(spinner, true, i18n.T("%s step done", step.name))
+
+		state.Completed = append(state.Completed, step.name)
+		if err := state.save(statePath); err != nil {
+			return step.name, fmt.Errorf("failed to save publish state: %w", err)
+		}
+	}
+	return "", nil
+}
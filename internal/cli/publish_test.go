@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+)
+
+func writeManifest(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "episode.yaml")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPublishManifest(t *testing.T) {
+	path := writeManifest(t, `
+show_id: 12
+title: Episode 42
+audio: ep42.mp3
+image: /abs/cover.jpg
+publish_at: "2026-05-04 18:00:00"
+chapters:
+  - {starts_at: 0, title: Intro, image: intro.png}
+cuepoints:
+  - {timecode: 30000, max_ads: 1}
+`)
+	m, err := loadPublishManifest(path)
+	if err != nil {
+		t.Fatalf("loadPublishManifest() error = %v", err)
+	}
+
+	dir := filepath.Dir(path)
+	if m.Audio != filepath.Join(dir, "ep42.mp3") {
+		t.Errorf("Audio = %q, want it relative to the manifest", m.Audio)
+	}
+	if m.Image != "/abs/cover.jpg" {
+		t.Errorf("Image = %q, want absolute path unchanged", m.Image)
+	}
+	if m.Chapters[0].Image != filepath.Join(dir, "intro.png") {
+		t.Errorf("chapter Image = %q", m.Chapters[0].Image)
+	}
+	if m.ShowID != 12 || len(m.Cuepoints) != 1 || m.Cuepoints[0].MaxAds != 1 {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+}
+
+func TestLoadPublishManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"missing title", "audio: a.mp3"},
+		{"missing audio", "title: T"},
+		{"bad publish_at", "title: T\naudio: a.mp3\npublish_at: tomorrow"},
+		{"untitled chapter", "title: T\naudio: a.mp3\nchapters:\n  - {starts_at: 0}"},
+		{"unknown field", "title: T\naudio: a.mp3\ntitel: typo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loadPublishManifest(writeManifest(t, tt.data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestPublishManifest_UploadParams(t *testing.T) {
+	m := &publishManifest{Title: "T", Audio: "a.mp3", Tags: []string{"tech"}, ExternalID: "build-1"}
+	params := m.uploadParams()
+
+	if !params.Hidden {
+		t.Error("episode should be uploaded hidden until the schedule step")
+	}
+	if want := []string{"tech", "external_id:build-1"}; !reflect.DeepEqual(params.Tags, want) {
+		t.Errorf("Tags = %v, want %v", params.Tags, want)
+	}
+	if len(m.Tags) != 1 {
+		t.Error("uploadParams must not modify the manifest tags")
+	}
+}
+
+func TestRunPublishSteps(t *testing.T) {
+	formatter := output.New(string(output.FormatPlain), false)
+	formatter.SetQuiet(true)
+	statePath := filepath.Join(t.TempDir(), "episode.yaml.state")
+
+	var ran []string
+	step := func(name string, err error) publishStep {
+		return publishStep{name: name, run: func() error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+
+	state := &publishState{EpisodeID: 7, Completed: []string{stepUpload}}
+	steps := []publishStep{
+		step(stepUpload, nil),
+		step(stepChapters, nil),
+		step(stepImage, errors.New("boom")),
+		step(stepSchedule, nil),
+	}
+
+	failed, err := runPublishSteps(formatter, steps, state, statePath)
+	if err == nil || failed != stepImage {
+		t.Fatalf("runPublishSteps() = %q, %v; want image failure", failed, err)
+	}
+	if want := []string{stepChapters, stepImage}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+
+	saved, err := loadPublishState(statePath)
+	if err != nil || saved == nil {
+		t.Fatalf("loadPublishState() = %v, %v", saved, err)
+	}
+	if want := []string{stepUpload, stepChapters}; saved.EpisodeID != 7 || !reflect.DeepEqual(saved.Completed, want) {
+		t.Errorf("saved state = %+v, want episode 7 with %v completed", saved, want)
+	}
+
+	// Resuming runs only the failed step and the ones after it.
+	ran = nil
+	steps[2] = step(stepImage, nil)
+	if _, err := runPublishSteps(formatter, steps, saved, statePath); err != nil {
+		t.Fatalf("resume error = %v", err)
+	}
+	if want := []string{stepImage, stepSchedule}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("resume ran %v, want %v", ran, want)
+	}
+}
+
+func TestLoadPublishState_Missing(t *testing.T) {
+	state, err := loadPublishState(filepath.Join(t.TempDir(), "none.state"))
+	if state != nil || err != nil {
+		t.Errorf("loadPublishState() = %v, %v; want nil, nil", state, err)
+	}
+}
+
+func TestPublishHook(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour).UTC().Format(time.DateTime)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.DateTime)
+	tests := []struct {
+		name     string
+		manifest string
+		episode  string
+		wantHook bool
+	}{
+		{"public", "", `"hidden":false`, true},
+		{"hidden", "hidden: true\n", `"hidden":true`, false},
+		{"scheduled", "publish_at: \"" + future + "\"\n", `"hidden":false,"auto_published_at":"` + future + `"`, false},
+		{"scheduled in the past", "", `"hidden":false,"auto_published_at":"` + past + `"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			dir := t.TempDir()
+			t.Setenv("SPREAKER_CONFIG_DIR", dir)
+			t.Cleanup(viper.Reset)
+
+			hooked := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/hook":
+					hooked++
+				case r.Method == http.MethodGet && r.URL.Path == "/v2/shows/1/episodes":
+					fmt.Fprint(w, `{"response":{"items":[],"next_url":null}}`)
+				default:
+					fmt.Fprintf(w, `{"response":{"episode":{"episode_id":9,"show_id":1,"title":"Pilot",%s}}}`, tt.episode)
+				}
+			}))
+			defer srv.Close()
+			t.Setenv("SPREAKER_TOKEN", "token")
+			t.Setenv("SPREAKER_API_URL", srv.URL)
+
+			if err := config.Save(&config.Config{Hooks: config.HooksConfig{EpisodePublished: srv.URL + "/hook"}}); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "pilot.mp3"), []byte("audio"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			manifest := filepath.Join(dir, "episode.yaml")
+			data := "show_id: 1\ntitle: Pilot\naudio: pilot.mp3\n" + tt.manifest
+			if err := os.WriteFile(manifest, []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			viper.Reset()
+			cmd := newRootCmd("test")
+			cmd.SetArgs([]string{"publish", "-f", manifest, "--force", "-o", "plain"})
+			cmd.SilenceUsage, cmd.SilenceErrors = true, true
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if got := hooked > 0; got != tt.wantHook {
+				t.Errorf("hook ran %d times, want it run: %v", hooked, tt.wantHook)
+			}
+		})
+	}
+}
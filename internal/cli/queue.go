@@ -0,0 +1,314 @@
+/*
+queue.go - Offline queue
+
+With offline_queue set, messages, likes, favorites and metadata updates
+that fail because the API is unreachable are queued in a spool next to
+the config file. "queue flush" sends them once back online.
+*/
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+	"github.com/G10xy/spreaker-and-go/internal/spool"
+)
+
+// queueFile is the name of the offline queue in the config directory.
+const queueFile = "queue.json"
+
+// queuedExitCode is the exit status of a command whose change was queued
+// instead of sent, so scripts can tell it has not happened yet.
+const queuedExitCode = 4
+
+// queueMu serializes queue updates of concurrent requests.
+var queueMu sync.Mutex
+
+// queueOffline returns a Client.Queue func that adds the requests the
+// spool accepts to the offline queue.
+func queueOffline(cmd *cobra.Command) func(w api.Write) bool {
+	command := cmd.CommandPath()
+	return func(w api.Write) bool {
+		if !spool.Queueable(w.Method, w.Path) {
+			return false
+		}
+
+		queueMu.Lock()
+		defer queueMu.Unlock()
+
+		s, path, err := loadQueue()
+		if err == nil {
+			s.Add(spool.Request{
+				Queued:  time.Now().UTC(),
+				Command: command,
+				Method:  w.Method,
+				Path:    w.Path,
+				Fields:  w.Fields,
+				Body:    w.Body,
+				Key:     w.Key,
+			})
+			err = s.Save(path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not queue %s %s: %v\n", w.Method, w.Path, err)
+			return false
+		}
+		return true
+	}
+}
+
+// loadQueue reads the offline queue and returns it with its path.
+func loadQueue() (*spool.Spool, string, error) {
+	path, err := config.DataFilePath(queueFile)
+	if err != nil {
+		return nil, "", err
+	}
+	s, err := spool.Load(path)
+	return s, path, err
+}
+
+func newQueueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Send changes queued while offline",
+		Long: `With the offline_queue config key set, messages, likes, favorites,
+bookmarks, follows and show, episode and profile updates that fail because
+the API is unreachable are queued instead, e.g. while working on a flight.
+The command then exits with status 4.
+
+Uploads and deletions are never queued.
+
+Example:
+  spreaker config set offline_queue true
+  spreaker episodes like 67890     # offline: queued
+  spreaker queue list
+  spreaker queue flush             # back online`,
+	}
+
+	cmd.AddCommand(
+		newQueueListCmd(),
+		newQueueFlushCmd(),
+		newQueueDropCmd(),
+	)
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// queue list
+// -----------------------------------------------------------------------------
+
+func newQueueListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List queued changes",
+		Args:  cobra.NoArgs,
+		RunE:  runQueueList,
+	}
+}
+
+func runQueueList(cmd *cobra.Command, args []string) error {
+	s, _, err := loadQueue()
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintQueue(s.Requests)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// queue flush
+// -----------------------------------------------------------------------------
+
+func newQueueFlushCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "flush",
+		Short: "Send queued changes",
+		Long: `Send queued changes in the order they were made.
+
+Updates whose values the show, episode or profile already has are not
+sent again. Changes the API rejects, e.g. a like of an episode deleted
+meanwhile, are reported as conflicts and dropped from the queue. If the
+API is still unreachable, flushing stops and the remaining changes stay
+queued.
+
+Exits with status 1 if any change conflicted.`,
+		Args: cobra.NoArgs,
+		RunE: runQueueFlush,
+	}
+}
+
+func runQueueFlush(cmd *cobra.Command, args []string) error {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	s, path, err := loadQueue()
+	if err != nil {
+		return err
+	}
+	formatter := getFormatter(cmd)
+	if len(s.Requests) == 0 {
+		formatter.PrintMessage(i18n.T("The queue is empty."))
+		return nil
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	// Requests that still fail stay where they are instead of being
+	// queued again.
+	client.Queue = nil
+
+	var results []spool.Result
+	var conflicts int
+	offline := false
+	for _, r := range append([]spool.Request(nil), s.Requests...) {
+		result := spool.Result{Request: r, Status: spool.StatusPending}
+		if !offline {
+			result = flushRequest(client, r)
+			offline = result.Status == spool.StatusPending
+		}
+		if result.Status == spool.StatusConflict {
+			conflicts++
+		}
+		if result.Status != spool.StatusPending {
+			s.Remove(r.ID)
+		}
+		results = append(results, result)
+	}
+
+	if err := s.Save(path); err != nil {
+		return err
+	}
+
+	formatter.PrintQueueFlush(results)
+	if offline {
+		formatter.PrintWarning(i18n.T("The API is still unreachable; %d changes stay queued", len(s.Requests)))
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d queued changes conflicted and were dropped", conflicts)
+	}
+	return nil
+}
+
+// flushRequest sends a queued request, unless it is an update the
+// resource already has. A POST is sent with the idempotency key of its
+// first try.
+func flushRequest(client *api.Client, r spool.Request) spool.Result {
+	result := spool.Result{Request: r}
+	if r.Key != "" {
+		client = client.WithIdempotencyKey(r.Key)
+	}
+
+	var err error
+	if journal.IsUpdate(r.Method, r.Path) {
+		var resp map[string]map[string]interface{}
+		if err = client.Get(r.Path, nil, &resp); err == nil {
+			// The resource is the only key of the response, e.g. "episode".
+			for _, resource := range resp {
+				if spool.Applied(resource, r.Fields) {
+					result.Status = spool.StatusApplied
+					return result
+				}
+			}
+		}
+	}
+
+	if err == nil {
+		switch {
+		case r.Method == http.MethodPut:
+			err = client.Put(r.Path, nil)
+		case r.Method == http.MethodDelete:
+			err = client.Delete(r.Path, nil)
+		case r.Body != nil:
+			err = client.Post(r.Path, r.Body, nil)
+		default:
+			err = client.PostForm(r.Path, r.Fields, nil)
+		}
+	}
+
+	switch {
+	case err == nil:
+		result.Status = spool.StatusSent
+	case api.IsNetworkError(err):
+		result.Status = spool.StatusPending
+	default:
+		result.Status, result.Reason = spool.StatusConflict, err.Error()
+	}
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// queue drop
+// -----------------------------------------------------------------------------
+
+func newQueueDropCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drop [id...]",
+		Short: "Drop queued changes without sending them",
+		Long: `Drop queued changes by the IDs shown by "queue list", or all of them
+with --all.
+
+Examples:
+  spreaker queue drop 3
+  spreaker queue drop --all`,
+		RunE: runQueueDrop,
+	}
+
+	cmd.Flags().Bool("all", false, "Drop every queued change")
+
+	return cmd
+}
+
+func runQueueDrop(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		return errors.New("give the IDs of the changes to drop, or --all")
+	}
+
+	queueMu.Lock()
+	defer queueMu.Unlock()
+
+	s, path, err := loadQueue()
+	if err != nil {
+		return err
+	}
+
+	dropped := len(s.Requests)
+	if all {
+		s.Requests = nil
+	} else {
+		dropped = 0
+		for _, arg := range args {
+			id, err := parseIntArg(arg, "queue ID")
+			if err != nil {
+				return err
+			}
+			if !s.Remove(id) {
+				return fmt.Errorf("no queued change with ID %d", id)
+			}
+			dropped++
+		}
+	}
+
+	if err := s.Save(path); err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Dropped %d queued changes", dropped))
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/spool"
+)
+
+func TestQueueOffline(t *testing.T) {
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	client := api.NewClientWithOptions("token", srv.URL, 0)
+	client.Queue = queueOffline(&cobra.Command{Use: "like"})
+	srv.Close()
+
+	var queued *api.QueuedError
+	if err := client.LikeEpisode(1, 3); !errors.As(err, &queued) {
+		t.Fatalf("LikeEpisode() error = %v, want QueuedError", err)
+	}
+	if err := client.DeleteEpisode(3); err == nil || errors.As(err, &queued) {
+		t.Errorf("DeleteEpisode() error = %v, want a network error", err)
+	}
+
+	s, _, err := loadQueue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Requests) != 1 || s.Requests[0].Command != "like" || s.Requests[0].Path != "/users/1/likes/3" {
+		t.Errorf("queue = %+v", s.Requests)
+	}
+}
+
+func TestFlushRequest(t *testing.T) {
+	t.Setenv("SPREAKER_CONFIG_DIR", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/users/1/likes/9":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{
+				"error": map[string]interface{}{"messages": []string{"Episode not found"}},
+			}})
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{
+				"episode": map[string]interface{}{"title": "New"},
+			}})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"response": nil})
+		}
+	}))
+	defer srv.Close()
+	client := api.NewClientWithOptions("token", srv.URL, 0)
+
+	tests := []struct {
+		name    string
+		request spool.Request
+		want    string
+	}{
+		{"like", spool.Request{Method: "PUT", Path: "/users/1/likes/3"}, spool.StatusSent},
+		{"message", spool.Request{Method: "POST", Path: "/episodes/3/messages", Body: []byte(`{"text":"hi"}`)}, spool.StatusSent},
+		{"update applied", spool.Request{Method: "POST", Path: "/episodes/3", Fields: map[string]string{"title": "New"}}, spool.StatusApplied},
+		{"update", spool.Request{Method: "POST", Path: "/episodes/3", Fields: map[string]string{"title": "Newer"}}, spool.StatusSent},
+		{"deleted episode", spool.Request{Method: "PUT", Path: "/users/1/likes/9"}, spool.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flushRequest(client, tt.request); got.Status != tt.want {
+				t.Errorf("status = %q (%s), want %q", got.Status, got.Reason, tt.want)
+			}
+		})
+	}
+
+	srv.Close()
+	if got := flushRequest(client, tests[0].request); got.Status != spool.StatusPending {
+		t.Errorf("status while offline = %q, want %q", got.Status, spool.StatusPending)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+replay.go - HTTP dump and replay
+
+--dump-http writes every API request and response of a command run to a
+directory; replay runs the same command again against those recordings,
+without a network or a token, to reproduce bug reports.
+*/
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+)
+
+// dumpManifest is the file describing the recorded command run.
+const dumpManifest = "command.json"
+
+// httpDump is the recorder of the current run, shared by every client the
+// command creates so exchanges are numbered in order.
+var httpDump *api.Recorder
+
+// replayTransport answers API requests while replaying a dump.
+var replayTransport http.RoundTripper
+
+// dumpInfo is the content of command.json.
+type dumpInfo struct {
+	Args       []string  `json:"args"`
+	Version    string    `json:"version"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// setupHTTPDump installs the --dump-http recorder or the replay transport
+// on a client, if either is active.
+func setupHTTPDump(cmd *cobra.Command, client *api.Client, token string) error {
+	if replayTransport != nil {
+		client.HTTPClient.Transport = replayTransport
+		return nil
+	}
+
+	dir, _ := cmd.Flags().GetString("dump-http")
+	if dir == "" {
+		return nil
+	}
+	if httpDump == nil {
+		rec, err := api.NewRecorder(client.HTTPClient.Transport, dir, token)
+		if err != nil {
+			return err
+		}
+		// Custom headers may carry gateway credentials.
+		for name := range client.Headers {
+			rec.Headers = append(rec.Headers, name)
+		}
+		info := dumpInfo{
+			Args:       dumpArgs(os.Args[1:]),
+			Version:    cmd.Root().Version,
+			RecordedAt: time.Now().UTC(),
+		}
+		data, _ := json.MarshalIndent(info, "", "  ")
+		if err := os.WriteFile(filepath.Join(dir, dumpManifest), append(data, '\n'), 0600); err != nil {
+			return fmt.Errorf("cannot write HTTP dump: %w", err)
+		}
+		httpDump = rec
+	}
+	client.HTTPClient.Transport = httpDump
+	return nil
+}
+
+// dumpArgs removes --dump-http, --token and --header (with their values)
+// from the recorded arguments: the first would overwrite the dump on
+// replay, the others may hold credentials that must never be written to
+// disk.
+func dumpArgs(args []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			out = append(out, args[i:]...)
+			break
+		}
+		switch {
+		case arg == "--dump-http" || arg == "--token" || arg == "--header":
+			i++
+		case strings.HasPrefix(arg, "--dump-http=") || strings.HasPrefix(arg, "--token=") || strings.HasPrefix(arg, "--header="):
+		default:
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// finishHTTPDump reports where the dump was written, or why it is incomplete.
+func finishHTTPDump() {
+	if httpDump == nil {
+		return
+	}
+	if err := httpDump.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("HTTP dump written to %s", httpDump.Dir))
+}
+
+func newReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <dir> [-- args...]",
+		Short: "Re-run a command against responses recorded with --dump-http",
+		Long: `Re-run a command recorded with the global --dump-http flag, answering
+its API requests from the recorded responses instead of the network.
+
+No token or network connection is needed, which makes a dump a reproducible
+bug report. Tokens, credential headers and custom headers (custom_headers,
+--header) are redacted when the dump is written; review the files before sharing them, as response bodies may
+still contain personal data.
+
+The recorded command line is read from command.json; arguments after "--"
+replace it, e.g. to render the same responses in another output format.
+
+Examples:
+  spreaker episodes list 12345 --dump-http ./bug-report
+  spreaker replay ./bug-report
+  spreaker replay ./bug-report -- episodes list 12345 -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runReplay,
+	}
+
+	return cmd
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+
+	data, err := os.ReadFile(filepath.Join(dir, dumpManifest))
+	if err != nil {
+		return fmt.Errorf("not an HTTP dump: %w", err)
+	}
+	var info dumpInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return fmt.Errorf("invalid %s: %w", dumpManifest, err)
+	}
+
+	replayArgs := info.Args
+	if len(args) > 1 {
+		replayArgs = args[1:]
+	}
+	if len(replayArgs) == 0 || replayArgs[0] == "replay" {
+		return fmt.Errorf("%s does not record a command to replay", dumpManifest)
+	}
+
+	transport, err := api.NewReplayer(dir)
+	if err != nil {
+		return err
+	}
+	replayTransport = transport
+	defer func() { replayTransport = nil }()
+
+	if info.Version != "" && info.Version != cmd.Root().Version {
+		fmt.Fprintf(os.Stderr, "Warning: dump was recorded with version %s, replaying with %s\n", info.Version, cmd.Root().Version)
+	}
+
+	root := newRootCmd(cmd.Root().Version)
+	root.SetArgs(replayArgs)
+	return root.ExecuteContext(cmd.Context())
+}
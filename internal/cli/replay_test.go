@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDumpArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"no dump flags", []string{"shows", "get", "1"}, []string{"shows", "get", "1"}},
+		{"separate value", []string{"shows", "get", "1", "--dump-http", "dir", "-o", "json"}, []string{"shows", "get", "1", "-o", "json"}},
+		{"inline value", []string{"--dump-http=dir", "me"}, []string{"me"}},
+		{"token removed", []string{"me", "--token", "secret", "--token=secret"}, []string{"me"}},
+		{"headers removed", []string{"me", "--header", "X-Api-Key: secret", "--header=X-Tenant: acme"}, []string{"me"}},
+		{"after separator kept", []string{"api", "--", "--token"}, []string{"api", "--", "--token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dumpArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dumpArgs(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
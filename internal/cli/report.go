@@ -0,0 +1,227 @@
+/*
+report.go - Periodic reports
+
+report composes documents from several endpoints, such as a weekly digest
+meant to be emailed, rather than printing data in the selected output
+format.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/mailer"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// digestMessageEpisodes is the number of latest episodes whose messages
+// are included in the weekly digest.
+const digestMessageEpisodes = 5
+
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Compose reports to read or email",
+		Long: `Compose reports that combine statistics, episodes and messages into a
+Markdown or HTML document.
+
+Example:
+  spreaker report weekly --show 12345 --format html --smtp`,
+	}
+
+	cmd.AddCommand(newReportWeeklyCmd())
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// report weekly
+// -----------------------------------------------------------------------------
+
+func newReportWeeklyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "weekly",
+		Short: "Compose a weekly digest of a show",
+		Long: `Compose a digest of a show's last seven complete days (UTC): plays
+compared with the week before, new followers of the show's author, the
+top episodes by plays, and messages posted on the latest episodes.
+
+The digest is printed to stdout. With --smtp it is emailed instead, through
+the server and to the recipients set in the smtp config keys, so a cron
+job can deliver it every week:
+
+  spreaker config set smtp.host smtp.example.com
+  spreaker config set smtp.username me@example.com
+  spreaker config set smtp.password <password>
+  spreaker config set smtp.from "Podcast Reports <me@example.com>"
+  spreaker config set smtp.to "me@example.com,cohost@example.com"
+
+  # Every Monday at 8:00
+  0 8 * * 1 spreaker report weekly --show 12345 --format html --smtp
+
+Example:
+  spreaker report weekly --show 12345
+  spreaker report weekly --format html > weekly.html`,
+		Args: cobra.NoArgs,
+		RunE: runReportWeekly,
+	}
+
+	cmd.Flags().Int("show", 0, "Show ID (default: default_show_id)")
+	cmd.Flags().String("format", report.DigestMarkdown, "Document format: md or html")
+	cmd.Flags().Bool("smtp", false, "Email the digest with the smtp config instead of printing it")
+
+	return cmd
+}
+
+func runReportWeekly(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if format != report.DigestMarkdown && format != report.DigestHTML {
+		return fmt.Errorf("invalid format %q (must be md or html)", format)
+	}
+	send, _ := cmd.Flags().GetBool("smtp")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if send {
+		if err := checkSMTPConfig(cfg.SMTP); err != nil {
+			return err
+		}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	showID, _ := cmd.Flags().GetInt("show")
+	if showID == 0 {
+		if showID, err = resolveShowID(cmd, client, nil); err != nil {
+			return err
+		}
+	}
+
+	digest, err := buildWeeklyDigest(cmd, client, showID)
+	if err != nil {
+		return err
+	}
+	body, err := digest.Render(format)
+	if err != nil {
+		return err
+	}
+
+	if !send {
+		_, err := fmt.Fprint(os.Stdout, body)
+		return err
+	}
+
+	contentType := mailer.ContentTypeText
+	if format == report.DigestHTML {
+		contentType = mailer.ContentTypeHTML
+	}
+	err = mailer.Send(mailer.Server{
+		Host:     cfg.SMTP.Host,
+		Port:     cfg.SMTP.Port,
+		Username: cfg.SMTP.Username,
+		Password: cfg.SMTP.Password,
+	}, mailer.Message{
+		From:        cfg.SMTP.From,
+		To:          cfg.SMTP.To,
+		Subject:     digest.Subject(),
+		ContentType: contentType,
+		Body:        body,
+		Date:        time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintSuccess(i18n.T("Sent the weekly report of %s to %s", digest.ShowTitle, strings.Join(cfg.SMTP.To, ", ")))
+	return nil
+}
+
+// checkSMTPConfig reports the first smtp key --smtp needs that is not set.
+func checkSMTPConfig(smtp config.SMTPConfig) error {
+	missing := ""
+	switch {
+	case smtp.Host == "":
+		missing = "smtp.host"
+	case smtp.From == "":
+		missing = "smtp.from"
+	case len(smtp.To) == 0:
+		missing = "smtp.to"
+	default:
+		return nil
+	}
+	return fmt.Errorf("%s is not set: run 'spreaker config set %s <value>'", missing, missing)
+}
+
+// buildWeeklyDigest fetches the data of the weekly digest of a show.
+func buildWeeklyDigest(cmd *cobra.Command, client *api.Client, showID int) (*report.WeeklyDigest, error) {
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, previousFrom, _ := report.WeekWindows(time.Now())
+	week := api.StatisticsParams{From: from.Format(report.DateLayout), To: to.Format(report.DateLayout), Group: "day"}
+
+	plays, err := client.GetShowPlayStatistics(showID, api.StatisticsParams{
+		From:  previousFrom.Format(report.DateLayout),
+		To:    week.To,
+		Group: "day",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plays: %w", err)
+	}
+
+	var followers []models.FollowersStatistics
+	if show.AuthorID != 0 {
+		if followers, err = client.GetUserFollowersStatistics(show.AuthorID, week); err != nil {
+			return nil, fmt.Errorf("failed to fetch followers: %w", err)
+		}
+	}
+
+	totals, err := client.GetAllShowEpisodesPlayTotals(showID, api.StatisticsParams{From: week.From, To: week.To})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch episode plays: %w", err)
+	}
+
+	episodes, err := client.GetShowEpisodesUpTo(showID, digestMessageEpisodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+	titles := make(map[int]string, len(episodes))
+	ids := make([]int, len(episodes))
+	for i, e := range episodes {
+		titles[e.EpisodeID] = e.Title
+		ids[i] = e.EpisodeID
+	}
+	pages, err := api.FetchAll(cmd.Context(), ids, api.DefaultConcurrency, func(id int) ([]models.Message, error) {
+		page, err := client.GetEpisodeMessages(id, api.PaginationParams{Limit: api.MaxPageLimit})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch messages for episode %d: %w", id, err)
+		}
+		return page.Items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var messages []models.Message
+	for _, page := range pages {
+		messages = append(messages, page...)
+	}
+
+	return report.BuildWeeklyDigest(show, from, to, plays, followers, totals, messages, titles), nil
+}
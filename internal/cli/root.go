@@ -12,15 +12,46 @@ package cli
 
 import (
 	"context"
+	"errors"
 
 	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
 )
 
 var rootCmd *cobra.Command
 
+// ExitError is returned by commands that need a specific process exit code,
+// e.g. to signal a condition to scripts rather than a failure.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// Execute runs the CLI. A returned error has already been reported on
+// stderr in the selected output format; callers only set the exit code.
 func Execute(ctx context.Context, version string) error {
 	rootCmd = newRootCmd(version)
-	return rootCmd.ExecuteContext(ctx)
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	closePager()
+	finishHTTPDump()
+	// A change queued while offline is not a failure, but it has not
+	// happened yet either.
+	var queued *api.QueuedError
+	if errors.As(err, &queued) {
+		getFormatter(cmd).PrintWarning(err.Error())
+		return &ExitError{Code: queuedExitCode, Err: err}
+	}
+	if err != nil {
+		formatter := getFormatter(cmd)
+		formatter.PrintError(err)
+	}
+	return err
 }
 
 // newRootCmd creates the root command with all subcommands registered.
@@ -47,20 +78,45 @@ Get started:
 
 	// Global flags are available to ALL subcommands.
 	// PersistentFlags() makes them "inherited" by children.
-	cmd.PersistentFlags().StringP("output", "o", "", "Output format: table, json, plain")
+	cmd.PersistentFlags().StringP("output", "o", "", "Output format: table, wide, json, plain, ci")
 	cmd.PersistentFlags().String("token", "", "API token (overrides config) — INSECURE: visible in process listings, prefer SPREAKER_TOKEN env var")
 	cmd.PersistentFlags().MarkHidden("token")
+	cmd.PersistentFlags().Bool("refresh-identity", false, "Ask the API which account the token acts as instead of using the cached answer")
 	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Only print command data, warnings and errors")
+	cmd.PersistentFlags().Bool("no-pager", false, "Do not pipe table output through a pager")
+	cmd.PersistentFlags().Int("max-width", 0, "Width long text such as titles is truncated at in tables (also: max_width config key)")
+	cmd.PersistentFlags().String("sort", "", "Sort list output by a field, as field or field:desc, e.g. plays:desc")
+	cmd.PersistentFlags().Bool("human", false, "Abbreviate counts in tables, e.g. 12.4k (also: number_format config key)")
+	cmd.PersistentFlags().Bool("totals", false, "End stats tables with TOTAL and AVG rows")
+	cmd.PersistentFlags().StringSlice("columns", nil, "Columns to show in list tables, e.g. id,title,plays,published")
+	cmd.PersistentFlags().Bool("with-meta", false, "Wrap JSON lists with pagination metadata (next_url, has_more)")
+	cmd.PersistentFlags().Bool("no-input", false, "Never prompt for input; fail instead (for scripts)")
+	cmd.PersistentFlags().Bool("strict", false, "Fail on unknown API response fields and warn about missing ones")
+	cmd.PersistentFlags().Bool("fail-fast", false, "Abort multi-page listings on the first failed page instead of keeping partial results")
+	cmd.PersistentFlags().String("page-state", "", "Save the position of multi-page listings to this file and resume from it")
+	cmd.PersistentFlags().Bool("read-only", false, "Refuse API requests that change data (also: read_only config key)")
+	cmd.PersistentFlags().String("locale", "", "Locale of API data such as category and language names, e.g. it_IT (also: locale config key)")
+	cmd.PersistentFlags().StringArray("header", nil, "Add a header to every API request, as 'Name: value' (repeatable; also: custom_headers config key)")
+	cmd.PersistentFlags().String("dump-http", "", "Write sanitized API requests and responses to this directory (see 'spreaker replay')")
 
 	cmd.AddCommand(
 		newLoginCmd(),
+		newLogoutCmd(),
+		newAuthCmd(),
 		newMeCmd(),
 
 		newUsersCmd(),
 		newShowsCmd(),
 		newEpisodesCmd(),
+		newPublishCmd(),
+		newAudioCmd(),
 
 		newStatsCmd(),
+		newSyncCmd(),
+		newLocalCmd(),
+		newReportCmd(),
+		newNetworkCmd(),
 
 		newSearchCmd(),
 		newExploreCmd(),
@@ -72,6 +128,19 @@ Get started:
 
 		newMiscCmd(),
 		newConfigCmd(),
+		newUpgradeCmd(),
+		newVersionCmd(),
+		newReplayCmd(),
+		newDebugCmd(),
+		newHistoryCmd(),
+		newUndoCmd(),
+		newQueueCmd(),
+		newJobsCmd(),
+
+		newOpenCmd(),
+		newMonitorCmd(),
+		newServeCmd(),
+		newAPICmd(),
 	)
 
 	return cmd
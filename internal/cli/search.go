@@ -6,9 +6,16 @@ This file contains all commands for searching shows and episodes.
 package cli
 
 import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newSearchCmd() *cobra.Command {
@@ -18,6 +25,7 @@ func newSearchCmd() *cobra.Command {
 		Long: `Search for shows and episodes on Spreaker.
 
 Examples:
+  spreaker search all "tech podcast"
   spreaker search shows "tech podcast"
   spreaker search episodes "artificial intelligence"
   spreaker search user-shows 12345 "interview"
@@ -25,6 +33,7 @@ Examples:
 	}
 
 	cmd.AddCommand(
+		newSearchAllCmd(),
 		newSearchShowsCmd(),
 		newSearchEpisodesCmd(),
 		newSearchUserShowsCmd(),
@@ -35,6 +44,170 @@ Examples:
 	return cmd
 }
 
+// -----------------------------------------------------------------------------
+// search all
+// -----------------------------------------------------------------------------
+
+// searchTypes are the result types searched by search all.
+var searchTypes = []string{"shows", "episodes"}
+
+func newSearchAllCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "all <query>",
+		Short: "Search shows and episodes at once",
+		Long: `Search shows and episodes concurrently and show the results in one
+sectioned view.
+
+With --select, pick one of the results interactively to see its details,
+as with "shows get" or "episodes get".
+
+Examples:
+  spreaker search all "tech podcast"
+  spreaker search all "interview" --type episodes --limit 5
+  spreaker search all "history" --select`,
+		Args: cobra.ExactArgs(1),
+		RunE: runSearchAll,
+	}
+
+	cmd.Flags().IntP("limit", "l", 10, "Maximum number of results per type")
+	cmd.Flags().String("filter", "", "Filter: listenable (default) or editable")
+	cmd.Flags().StringSlice("type", searchTypes, "Result types to search: shows, episodes")
+	cmd.Flags().BoolP("select", "s", false, "Pick a result interactively and show its details")
+
+	return cmd
+}
+
+func runSearchAll(cmd *cobra.Command, args []string) error {
+	query := args[0]
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	filter, _ := cmd.Flags().GetString("filter")
+	if err := validateFilter(filter); err != nil {
+		return err
+	}
+	types, _ := cmd.Flags().GetStringSlice("type")
+	wantShows, wantEpisodes, err := parseSearchTypes(types)
+	if err != nil {
+		return err
+	}
+
+	selectResult, _ := cmd.Flags().GetBool("select")
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	if selectResult && (noInput || !isInteractive()) {
+		return fmt.Errorf("--select needs an interactive terminal")
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	search := api.SearchParams{Query: query, Filter: filter}
+	pagination := api.PaginationParams{Limit: limit}
+
+	var results api.SearchResults
+	var showsErr, episodesErr error
+	var wg sync.WaitGroup
+	if wantShows {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var r *api.PaginatedResult[models.Show]
+			if r, showsErr = client.SearchShows(search, pagination); showsErr == nil {
+				results.Shows = r.Items
+			}
+		}()
+	}
+	if wantEpisodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var r *api.PaginatedResult[models.Episode]
+			if r, episodesErr = client.SearchEpisodes(search, pagination); episodesErr == nil {
+				results.Episodes = r.Items
+			}
+		}()
+	}
+	wg.Wait()
+
+	if showsErr != nil {
+		return fmt.Errorf("failed to search shows: %w", showsErr)
+	}
+	if episodesErr != nil {
+		return fmt.Errorf("failed to search episodes: %w", episodesErr)
+	}
+
+	formatter := getFormatter(cmd)
+
+	if len(results.Shows) == 0 && len(results.Episodes) == 0 && (selectResult || !formatter.IsJSON()) {
+		formatter.PrintMessage(i18n.T("No results found."))
+		return nil
+	}
+
+	if !selectResult {
+		formatter.PrintSearchResults(&results)
+		return nil
+	}
+
+	kind, id, err := pickSearchResult(&results)
+	if err != nil {
+		return err
+	}
+	if kind == "show" {
+		return runShowsGet(cmd, []string{strconv.Itoa(id)})
+	}
+	return runEpisodesGet(cmd, []string{strconv.Itoa(id)})
+}
+
+// parseSearchTypes validates the --type values of search all.
+func parseSearchTypes(types []string) (shows, episodes bool, err error) {
+	for _, t := range types {
+		switch t {
+		case "shows", "show":
+			shows = true
+		case "episodes", "episode":
+			episodes = true
+		default:
+			return false, false, fmt.Errorf("invalid type %q: must be shows or episodes", t)
+		}
+	}
+	if !shows && !episodes {
+		return false, false, fmt.Errorf("--type needs at least one of: shows, episodes")
+	}
+	return shows, episodes, nil
+}
+
+// pickSearchResult lets the user choose one of the results interactively.
+// It returns "show" or "episode" and the chosen ID.
+func pickSearchResult(r *api.SearchResults) (string, int, error) {
+	type choice struct {
+		kind string
+		id   int
+	}
+	var options []string
+	choices := make(map[string]choice)
+	for _, s := range r.Shows {
+		option := fmt.Sprintf("%s %s (%d)", i18n.T("Show:"), s.Title, s.ShowID)
+		options = append(options, option)
+		choices[option] = choice{"show", s.ShowID}
+	}
+	for _, e := range r.Episodes {
+		option := fmt.Sprintf("%s %s (%d)", i18n.T("Episode:"), e.Title, e.EpisodeID)
+		options = append(options, option)
+		choices[option] = choice{"episode", e.EpisodeID}
+	}
+
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Select a result"))
+	if err != nil {
+		return "", 0, fmt.Errorf("selection cancelled: %w", err)
+	}
+	c := choices[selected]
+	return c.kind, c.id, nil
+}
+
 // -----------------------------------------------------------------------------
 // search shows
 // -----------------------------------------------------------------------------
@@ -77,15 +250,16 @@ func runSearchShows(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No shows found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No shows found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more results available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more results available, use --limit to see more)"))
 	}
 
 	return nil
@@ -133,15 +307,16 @@ func runSearchEpisodes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No episodes found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more results available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more results available, use --limit to see more)"))
 	}
 
 	return nil
@@ -194,15 +369,16 @@ func runSearchUserShows(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No shows found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No shows found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more results available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more results available, use --limit to see more)"))
 	}
 
 	return nil
@@ -255,15 +431,16 @@ func runSearchUserEpisodes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No episodes found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more results available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more results available, use --limit to see more)"))
 	}
 
 	return nil
@@ -316,15 +493,16 @@ func runSearchShowEpisodes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No episodes found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more results available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more results available, use --limit to see more)"))
 	}
 
 	return nil
@@ -0,0 +1,31 @@
+package cli
+
+import "testing"
+
+func TestParseSearchTypes(t *testing.T) {
+	tests := []struct {
+		name         string
+		types        []string
+		wantShows    bool
+		wantEpisodes bool
+		wantErr      bool
+	}{
+		{"both", []string{"shows", "episodes"}, true, true, false},
+		{"shows only", []string{"shows"}, true, false, false},
+		{"singular", []string{"episode"}, false, true, false},
+		{"unknown", []string{"users"}, false, false, true},
+		{"empty", nil, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shows, episodes, err := parseSearchTypes(tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSearchTypes(%v) error = %v, wantErr %v", tt.types, err, tt.wantErr)
+			}
+			if shows != tt.wantShows || episodes != tt.wantEpisodes {
+				t.Errorf("parseSearchTypes(%v) = %v, %v; want %v, %v", tt.types, shows, episodes, tt.wantShows, tt.wantEpisodes)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+/*
+serve.go - Long-running server modes
+
+These commands expose the CLI's Spreaker access to other local programs.
+*/
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/grpcserver"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/mcp"
+	"github.com/G10xy/spreaker-and-go/internal/server"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run local servers backed by your Spreaker account",
+		Long: `Run local servers that use your stored Spreaker credentials, so other
+tools can integrate without handling OAuth themselves.
+
+Examples:
+  spreaker serve api                  # REST API on 127.0.0.1:8080
+  spreaker serve api --listen :9000   # Listen on all interfaces
+  spreaker serve grpc                 # gRPC on 127.0.0.1:50051
+  spreaker serve mcp                  # MCP server over stdio`,
+		Annotations: map[string]string{noPagerAnnotation: ""},
+	}
+
+	cmd.AddCommand(
+		newServeAPICmd(),
+		newServeGRPCCmd(),
+		newServeMCPCmd(),
+	)
+
+	return cmd
+}
+
+// -----------------------------------------------------------------------------
+// serve api
+// -----------------------------------------------------------------------------
+
+func newServeAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve a local JSON REST API proxying to Spreaker",
+		Long: `Serve a local JSON REST API that proxies to Spreaker with your stored credentials.
+
+Endpoints:
+  GET  /me
+  GET  /shows                          ?limit= &offset=
+  GET  /shows/{id}
+  GET  /shows/{id}/episodes            ?limit= &offset=
+  POST /shows/{id}/episodes            multipart: media_file, title, description, tags, ...
+  GET  /episodes/{id}
+  GET  /stats/me
+  GET  /stats/shows/{id}
+  GET  /stats/shows/{id}/plays         ?from= &to= &group=
+  GET  /stats/shows/{id}/episodes      ?from= &to= &limit= &offset=
+  GET  /stats/episodes/{id}
+  GET  /stats/episodes/{id}/plays      ?from= &to= &group=
+
+Anyone who can reach the server acts as you, so it listens on localhost by
+default. Browser requests are refused unless their origin is passed with
+--allow-origin. Requests must be addressed to localhost, a loopback address
+or the --listen address; pass other names the server is reached by, e.g.
+when listening on all interfaces, with --allow-host.
+
+Examples:
+  spreaker serve api
+  spreaker serve api --listen :8080
+  spreaker serve api --allow-origin http://localhost:3000
+  spreaker serve api --listen :8080 --allow-host podcast-box.lan`,
+		RunE: runServeAPI,
+	}
+
+	cmd.Flags().String("listen", "127.0.0.1:8080", "Address to listen on")
+	cmd.Flags().StringSlice("allow-origin", nil, "Browser origins allowed to call the API (comma-separated)")
+	cmd.Flags().StringSlice("allow-host", nil, "Host names the API may be reached by, besides localhost and the --listen address (comma-separated)")
+
+	return cmd
+}
+
+func runServeAPI(cmd *cobra.Command, args []string) error {
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	listen, _ := cmd.Flags().GetString("listen")
+	origins, _ := cmd.Flags().GetStringSlice("allow-origin")
+	hosts, _ := cmd.Flags().GetStringSlice("allow-host")
+	if host, _, err := net.SplitHostPort(listen); err == nil && host != "" {
+		hosts = append(hosts, host)
+	}
+
+	formatter := getFormatter(cmd)
+
+	handler := server.New(client, server.Options{
+		AllowedOrigins: origins,
+		AllowedHosts:   hosts,
+		OnEpisodeUploaded: func(ctx context.Context, episode *models.Episode) {
+			runEpisodePublishedHook(cmd, formatter, episode)
+		},
+	})
+
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	if !isLoopback(ln.Addr()) {
+		formatter.PrintWarning(i18n.T("Listening on %s: anyone who can reach this address can act on your Spreaker account", ln.Addr()))
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Shut down gracefully when the command context is cancelled (Ctrl+C).
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	formatter.PrintMessage(i18n.T("Serving Spreaker API on http://%s (press Ctrl+C to stop)", ln.Addr()))
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// serve grpc
+// -----------------------------------------------------------------------------
+
+func newServeGRPCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grpc",
+		Short: "Serve a local gRPC API proxying to Spreaker",
+		Long: `Serve the read endpoints of "serve api" as the gRPC service
+spreaker.v1.Spreaker, so tools in other languages get typed access to your
+shows, episodes and statistics.
+
+Messages mirror the Spreaker data types; generate clients from the
+definitions printed by --print-proto. The server speaks gRPC over HTTP/2
+without TLS (clients must use plaintext/insecure credentials) and does not
+support compression. Uploads are only available through "serve api".
+
+Methods:
+  GetMe, ListShows, GetShow, ListShowEpisodes, GetEpisode,
+  GetMyStatistics, GetShowStatistics, GetShowPlays,
+  ListShowEpisodesPlayTotals, GetEpisodeStatistics, GetEpisodePlays
+
+Anyone who can reach the server acts as you, so it listens on localhost by
+default.
+
+Examples:
+  spreaker serve grpc
+  spreaker serve grpc --listen :50051
+  spreaker serve grpc --print-proto > spreaker.proto`,
+		Args: cobra.NoArgs,
+		RunE: runServeGRPC,
+	}
+
+	cmd.Flags().String("listen", "127.0.0.1:50051", "Address to listen on")
+	cmd.Flags().Bool("print-proto", false, "Print the service's .proto definitions and exit")
+
+	return cmd
+}
+
+func runServeGRPC(cmd *cobra.Command, args []string) error {
+	if printProto, _ := cmd.Flags().GetBool("print-proto"); printProto {
+		return grpcserver.New(nil).Proto(cmd.OutOrStdout())
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	listen, _ := cmd.Flags().GetString("listen")
+	formatter := getFormatter(cmd)
+
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	if !isLoopback(ln.Addr()) {
+		formatter.PrintWarning(i18n.T("Listening on %s: anyone who can reach this address can act on your Spreaker account", ln.Addr()))
+	}
+
+	// gRPC clients connect with HTTP/2 directly, without TLS.
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Handler:           grpcserver.New(client),
+		Protocols:         protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+
+	formatter.PrintMessage(i18n.T("Serving Spreaker gRPC API on %s (press Ctrl+C to stop)", ln.Addr()))
+
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// isLoopback reports whether addr only accepts local connections.
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// -----------------------------------------------------------------------------
+// serve mcp
+// -----------------------------------------------------------------------------
+
+func newServeMCPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve Spreaker operations as MCP tools over stdio",
+		Long: `Run a Model Context Protocol (MCP) server on stdin/stdout, exposing Spreaker
+operations as tools for AI assistants and automation frameworks.
+
+Tools: get_me, list_shows, get_show, list_episodes, get_episode,
+get_show_statistics, get_show_plays, get_episode_statistics.
+
+Tools that change your account (upload_episode) are only offered with
+--allow-writes.
+
+Example client configuration:
+  {"command": "spreaker", "args": ["serve", "mcp"]}
+
+Examples:
+  spreaker serve mcp
+  spreaker serve mcp --allow-writes`,
+		RunE: runServeMCP,
+	}
+
+	cmd.Flags().Bool("allow-writes", false, "Expose tools that modify your account (upload_episode)")
+
+	return cmd
+}
+
+func runServeMCP(cmd *cobra.Command, args []string) error {
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	allowWrites, _ := cmd.Flags().GetBool("allow-writes")
+
+	// stdout carries the protocol, so hook warnings must only go to stderr.
+	formatter := getFormatter(cmd)
+
+	srv := mcp.New(client, mcp.Options{
+		Name:        "spreaker",
+		Version:     cmd.Root().Version,
+		AllowWrites: allowWrites,
+		OnEpisodeUploaded: func(ctx context.Context, episode *models.Episode) {
+			runEpisodePublishedHook(cmd, formatter, episode)
+		},
+	})
+
+	return srv.Serve(cmd.Context(), os.Stdin, os.Stdout)
+}
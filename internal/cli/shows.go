@@ -7,10 +7,23 @@ package cli
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/linkcheck"
+	"github.com/G10xy/spreaker-and-go/internal/opml"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/internal/rss"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newShowsCmd() *cobra.Command {
@@ -22,7 +35,13 @@ func newShowsCmd() *cobra.Command {
 Examples:
   spreaker shows list              # List all your shows
   spreaker shows get 12345         # Get details of a show
-  spreaker shows delete 12345      # Delete a show`,
+  spreaker shows delete 12345      # Delete a show
+  spreaker shows audit 12345       # Check episodes for missing metadata
+  spreaker shows check-links 12345 # Find broken links in show notes
+  spreaker shows diff-feed 12345 https://example.com/feed.xml  # Compare with another host
+  spreaker shows reviews 12345     # Summarize listener likes and messages
+  spreaker shows export-opml       # Export favorites for a podcast app
+  spreaker shows export-feed 12345 # RSS feed for a self-hosted mirror`,
 	}
 
 	cmd.AddCommand(
@@ -31,7 +50,14 @@ Examples:
 		newShowsCreateCmd(),
 		newShowsUpdateCmd(),
 		newShowsDeleteCmd(),
+		newShowsAuditCmd(),
+		newShowsCheckLinksCmd(),
+		newShowsDiffFeedCmd(),
+		newShowsReviewsCmd(),
 		newShowsFavoritesCmd(),
+		newShowsExportOPMLCmd(),
+		newShowsExportFeedCmd(),
+		newShowsImportOPMLCmd(),
 		newShowsFavoriteCmd(),
 		newShowsUnfavoriteCmd(),
 	)
@@ -72,14 +98,15 @@ func runShowsList(cmd *cobra.Command, args []string) error {
 	formatter := getFormatter(cmd)
 
 	if len(result.Items) == 0 {
-		formatter.PrintMessage("No shows found.")
+		formatter.PrintMessage(i18n.T("No shows found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more shows available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more shows available, use --limit to see more)"))
 	}
 
 	return nil
@@ -119,6 +146,376 @@ func runShowsGet(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// shows audit
+// -----------------------------------------------------------------------------
+
+func newShowsAuditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit <show-id>",
+		Short: "Check a show's episodes for missing or inconsistent metadata",
+		Long: `Scan every episode of a show and list the problems found as a checklist:
+
+  - missing description
+  - missing tags
+  - not marked explicit although the show is explicit
+  - zero duration or failed audio encoding
+  - missing image
+
+With --fix, issues that need no input are corrected: episodes of an
+explicit show are marked explicit. Everything else is left for you to fix.
+
+Examples:
+  spreaker shows audit 12345
+  spreaker shows audit 12345 --fix
+  spreaker shows audit 12345 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShowsAudit,
+	}
+
+	cmd.Flags().Bool("fix", false, "Correct issues that need no input")
+
+	return cmd
+}
+
+func runShowsAudit(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return err
+	}
+
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+	// The audit checks descriptions and tags, which episode lists lack.
+	if episodes, err = episodeDetails(cmd.Context(), client, episodes, api.DefaultConcurrency); err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+
+	a := report.AuditShow(show, episodes)
+
+	if fix, _ := cmd.Flags().GetBool("fix"); fix {
+		explicit := true
+		for n := range a.Issues {
+			issue := &a.Issues[n]
+			if issue.Code != report.IssueNotExplicit {
+				continue
+			}
+			if _, err := client.UpdateEpisode(issue.EpisodeID, api.UpdateEpisodeParams{Explicit: &explicit}); err != nil {
+				formatter.PrintWarning(i18n.T("Failed to fix episode %d: %v", issue.EpisodeID, err))
+				continue
+			}
+			issue.Fixed = true
+		}
+	}
+
+	formatter.PrintShowAudit(a)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// shows check-links
+// -----------------------------------------------------------------------------
+
+func newShowsCheckLinksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-links <show-id>",
+		Short: "Find broken links in episode descriptions and chapters",
+		Long: `Check every link in a show's episode descriptions and chapter URLs.
+
+Links are requested concurrently with HEAD requests (falling back to GET
+for servers that reject HEAD) and those that fail or return an error
+status are listed per episode.
+
+Examples:
+  spreaker shows check-links 12345
+  spreaker shows check-links 12345 --concurrency 16 --link-timeout 5s
+  spreaker shows check-links 12345 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShowsCheckLinks,
+	}
+
+	cmd.Flags().Int("concurrency", linkcheck.DefaultWorkers, "Number of links to check in parallel")
+	cmd.Flags().Duration("link-timeout", 10*time.Second, "Timeout for each link check")
+
+	return cmd
+}
+
+func runShowsCheckLinks(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+	linkTimeout, _ := cmd.Flags().GetDuration("link-timeout")
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+	// Episode lists lack descriptions.
+	if episodes, err = episodeDetails(cmd.Context(), client, episodes, api.DefaultConcurrency); err != nil {
+		return err
+	}
+
+	chapters, err := api.FetchAll(cmd.Context(), episodes, api.DefaultConcurrency, func(e models.Episode) ([]models.Chapter, error) {
+		chapters, err := client.GetAllEpisodeChapters(e.EpisodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch chapters for episode %d: %w", e.EpisodeID, err)
+		}
+		return chapters, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var links []linkcheck.Link
+	for i, e := range episodes {
+		for _, u := range linkcheck.Extract(e.Description) {
+			links = append(links, linkcheck.Link{EpisodeID: e.EpisodeID, Title: e.Title, Source: "description", URL: u})
+		}
+
+		for _, ch := range chapters[i] {
+			if ch.ExternalURL != "" {
+				links = append(links, linkcheck.Link{EpisodeID: e.EpisodeID, Title: e.Title, Source: "chapter: " + ch.Title, URL: ch.ExternalURL})
+			}
+		}
+	}
+
+	formatter := getFormatter(cmd)
+
+	if len(links) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No links found."))
+		return nil
+	}
+
+	formatter.PrintMessage(i18n.T("Checking %d links in %d episodes...", len(links), len(episodes)))
+
+	// Links point at third-party sites, so they are checked with a plain
+	// client rather than the API client, which carries the bearer token.
+	broken := linkcheck.Check(&http.Client{Timeout: linkTimeout, Transport: api.Transport}, links, concurrency)
+
+	if len(broken) == 0 && !formatter.IsJSON() {
+		formatter.PrintSuccess(i18n.T("All %d links are working.", len(links)))
+		return nil
+	}
+
+	formatter.PrintBrokenLinks(broken)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// shows diff-feed
+// -----------------------------------------------------------------------------
+
+// feedDiffExitCode is returned by shows diff-feed when the show and the
+// feed differ, so sync scripts can act on it.
+const feedDiffExitCode = 2
+
+func newShowsDiffFeedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-feed <show-id> <rss-url>",
+		Short: "Compare a show's episodes with an RSS feed hosted elsewhere",
+		Long: `Compare a show's episodes with the items of an external RSS feed, such as
+the same podcast simulcast on another host, and list what is out of sync:
+
+  - episodes missing from the feed
+  - feed items missing on Spreaker
+  - episodes published at a different time
+  - episodes of a different duration
+
+Episodes are matched by title, ignoring case and spacing. Publish dates
+and durations are only compared when both sides have them, and small
+differences are allowed (see --date-tolerance and --duration-tolerance).
+
+The command exits with status 2 when differences are found.
+
+Examples:
+  spreaker shows diff-feed 12345 https://feeds.example.com/morning-coffee.xml
+  spreaker shows diff-feed 12345 https://feeds.example.com/morning-coffee.xml --date-tolerance 1h
+  spreaker shows diff-feed 12345 https://feeds.example.com/morning-coffee.xml --output json`,
+		Args: cobra.ExactArgs(2),
+		RunE: runShowsDiffFeed,
+	}
+
+	cmd.Flags().Duration("date-tolerance", 24*time.Hour, "Publish date difference allowed between matched episodes")
+	cmd.Flags().Duration("duration-tolerance", 5*time.Second, "Duration difference allowed between matched episodes")
+	cmd.Flags().Duration("feed-timeout", 30*time.Second, "Timeout for fetching the feed")
+
+	return cmd
+}
+
+func runShowsDiffFeed(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+	feedURL := args[1]
+
+	var tol report.FeedTolerance
+	tol.Published, _ = cmd.Flags().GetDuration("date-tolerance")
+	tol.Duration, _ = cmd.Flags().GetDuration("duration-tolerance")
+	feedTimeout, _ := cmd.Flags().GetDuration("feed-timeout")
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	// The feed is on a third-party host, so it is fetched with a plain
+	// client rather than the API client, which carries the bearer token.
+	feed, err := rss.Fetch(cmd.Context(), &http.Client{Timeout: feedTimeout, Transport: api.Transport}, feedURL)
+	if err != nil {
+		return err
+	}
+
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return err
+	}
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+
+	d := report.CompareFeed(show, episodes, feedURL, feed, tol)
+	getFormatter(cmd).PrintFeedDiff(d)
+
+	if len(d.Differences) > 0 {
+		return &ExitError{
+			Code: feedDiffExitCode,
+			Err:  fmt.Errorf("%d difference(s) between the show and the feed", len(d.Differences)),
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// shows reviews
+// -----------------------------------------------------------------------------
+
+func newShowsReviewsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reviews <show-id>",
+		Aliases: []string{"feedback"},
+		Short:   "Summarize listener feedback on a show",
+		Long: `Build a feedback report for a show from its episode likes and messages.
+Spreaker has no ratings or reviews, so listener sentiment is estimated from
+message text (English and Italian) and is only a rough indication.
+
+Messages are read from the most recently published episodes that have
+any (--episodes, 0 = all). Your own replies are not counted.
+
+Examples:
+  spreaker shows reviews 12345
+  spreaker shows reviews 12345 --episodes 0 --recent 20
+  spreaker shows reviews 12345 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShowsReviews,
+	}
+
+	cmd.Flags().Int("episodes", 20, "Number of recent episodes to read messages from (0 = all)")
+	cmd.Flags().Int("recent", 10, "Number of recent messages to show")
+
+	return cmd
+}
+
+func runShowsReviews(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	maxEpisodes, _ := cmd.Flags().GetInt("episodes")
+	recent, _ := cmd.Flags().GetInt("recent")
+	if maxEpisodes < 0 || recent < 0 {
+		return fmt.Errorf("--episodes and --recent must not be negative")
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return err
+	}
+
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+
+	withMessages := episodesWithMessages(episodes, maxEpisodes)
+	fetched, err := api.FetchAll(cmd.Context(), withMessages, api.DefaultConcurrency, func(e models.Episode) ([]models.Message, error) {
+		msgs, err := client.GetAllEpisodeMessages(e.EpisodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch messages for episode %d: %w", e.EpisodeID, err)
+		}
+		return msgs, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	messages := make(map[int][]models.Message, len(withMessages))
+	for i, e := range withMessages {
+		messages[e.EpisodeID] = fetched[i]
+	}
+
+	getFormatter(cmd).PrintShowFeedback(report.BuildFeedback(show, episodes, messages, recent))
+	return nil
+}
+
+// episodesWithMessages returns up to max episodes that have messages, most
+// recently published first (0 = no limit).
+func episodesWithMessages(episodes []models.Episode, max int) []models.Episode {
+	var withMessages []models.Episode
+	for _, e := range episodes {
+		if e.MessagesCount > 0 {
+			withMessages = append(withMessages, e)
+		}
+	}
+
+	sort.SliceStable(withMessages, func(i, j int) bool {
+		return publishedAt(withMessages[i]).After(publishedAt(withMessages[j]))
+	})
+	if max > 0 && len(withMessages) > max {
+		withMessages = withMessages[:max]
+	}
+	return withMessages
+}
+
+func publishedAt(e models.Episode) time.Time {
+	if e.PublishedAt == nil {
+		return time.Time{}
+	}
+	return e.PublishedAt.Time
+}
+
 // -----------------------------------------------------------------------------
 // shows delete
 // -----------------------------------------------------------------------------
@@ -129,7 +526,11 @@ func newShowsDeleteCmd() *cobra.Command {
 		Short: "Delete a show",
 		Long: `Delete a show permanently.
 
-WARNING: This action cannot be undone. All episodes in the show will also be deleted.`,
+WARNING: This action cannot be undone. All episodes in the show will also be deleted.
+
+You are asked to confirm as set by confirm.delete: with "title" you have
+to type the show's title, like GitHub asks before deleting a repository.
+--force skips the confirmation.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runShowsDelete,
 	}
@@ -146,28 +547,23 @@ func runShowsDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Check if --force flag was provided
-	force, _ := cmd.Flags().GetBool("force")
-	if !force {
-		prompt := fmt.Sprintf("Are you sure you want to delete show %d? [y/N]: ", showID)
-		if !confirmAction(prompt) {
-			formatter := getFormatter(cmd)
-			formatter.PrintMessage("Cancelled.")
-			return nil
-		}
-	}
-
 	client, err := getClient(cmd)
 	if err != nil {
 		return err
 	}
 
+	prompt := fmt.Sprintf("Are you sure you want to delete show %d?", showID)
+	ok, err := confirm(cmd, actionDelete, prompt, showTitle(client, showID))
+	if !ok {
+		return err
+	}
+
 	if err := client.DeleteShow(showID); err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Show %d deleted", showID))
+	formatter.PrintSuccess(i18n.T("Show %d deleted", showID))
 	return nil
 }
 
@@ -224,7 +620,7 @@ func runShowsCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Show created with ID %d", show.ShowID))
+	formatter.PrintSuccess(i18n.T("Show created with ID %d", show.ShowID))
 	formatter.PrintShow(show)
 	return nil
 }
@@ -251,6 +647,7 @@ Examples:
 	cmd.Flags().String("language", "", "Language code (e.g., en, it, es)")
 	cmd.Flags().Int("category", 0, "Category ID")
 	cmd.Flags().Bool("explicit", false, "Mark as explicit content")
+	cmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
 
 	return cmd
 }
@@ -289,13 +686,18 @@ func runShowsUpdate(cmd *cobra.Command, args []string) error {
 		params.Explicit = &val
 	}
 
+	ok, err := confirm(cmd, actionUpdate, fmt.Sprintf("Update show %d?", showID), showTitle(client, showID))
+	if !ok {
+		return err
+	}
+
 	show, err := client.UpdateShow(showID, params)
 	if err != nil {
 		return err
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess("Show updated")
+	formatter.PrintSuccess(i18n.T("Show updated"))
 	formatter.PrintShow(show)
 	return nil
 }
@@ -322,7 +724,7 @@ func runShowsFavorites(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -335,20 +737,381 @@ func runShowsFavorites(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No favorite shows.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No favorite shows."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more shows available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more shows available, use --limit to see more)"))
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// shows export-opml
+// -----------------------------------------------------------------------------
+
+func newShowsExportOPMLCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-opml",
+		Short: "Export shows as an OPML subscription list",
+		Long: `Export your favorite shows (default) or your own shows (--mine) as an
+OPML file with each show's RSS feed, to import them into any podcast app.
+
+Use --out - to write the OPML to stdout.
+
+Examples:
+  spreaker shows export-opml --out shows.opml
+  spreaker shows export-opml --mine --out my-shows.opml
+  spreaker shows export-opml --out - > favorites.opml`,
+		Args: cobra.NoArgs,
+		RunE: runShowsExportOPML,
+	}
+
+	cmd.Flags().Bool("favorites", false, "Export your favorite shows (default)")
+	cmd.Flags().Bool("mine", false, "Export your own shows")
+	cmd.MarkFlagsMutuallyExclusive("favorites", "mine")
+	cmd.Flags().String("out", "shows.opml", "Path of the OPML file to write, or - for stdout")
+
+	return cmd
+}
+
+func runShowsExportOPML(cmd *cobra.Command, args []string) error {
+	mine, _ := cmd.Flags().GetBool("mine")
+	outPath, _ := cmd.Flags().GetString("out")
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	userID, err := getMyUserID(cmd, client)
+	if err != nil {
+		return err
+	}
+
+	var shows []models.Show
+	title := "Spreaker favorites"
+	if mine {
+		shows, err = client.GetAllUserShows(userID)
+		title = "Spreaker shows"
+	} else {
+		shows, err = client.GetAllFavoriteShows(userID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch shows: %w", err)
+	}
+
+	formatter := getFormatter(cmd)
+
+	if len(shows) == 0 {
+		formatter.PrintMessage(i18n.T("No shows found."))
+		return nil
+	}
+
+	if outPath == "-" {
+		return opml.Write(os.Stdout, title, shows, time.Now())
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	if err := opml.Write(f, title, shows, time.Now()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	formatter.PrintSuccess(i18n.T("Exported %d shows to %s", len(shows), outPath))
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// shows export-feed
+// -----------------------------------------------------------------------------
+
+// feedConfigFile is the default feed.yaml, next to the config file.
+const feedConfigFile = "feed.yaml"
+
+func newShowsExportFeedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-feed <show-id>",
+		Short: "Generate an RSS feed of a show with Podcasting 2.0 tags",
+		Long: `Generate an RSS feed of a show's published episodes, for mirroring the show
+on a self-hosted feed.
+
+Podcasting 2.0 tags are added from feed.yaml, next to the config file (see
+"spreaker config path") or given with --feed-config, per show ID:
+
+  shows:
+    12345:
+      persons:                       # podcast:person
+        - {name: Alice Rossi, role: host, href: "https://alice.example.com"}
+        - {name: Bob Bianchi, role: guest}
+      funding:                       # podcast:funding
+        url: "https://ko-fi.com/morningcoffee"
+        text: Support the show
+      transcripts:                   # podcast:transcript
+        dir: ./transcripts           # relative to feed.yaml
+        base_url: "https://example.com/transcripts/"
+        language: en
+      audio_base_url: "https://example.com/audio/"
+
+Transcripts are the files in transcripts.dir named after the episode ID or
+its audio file, such as 67890.vtt or "Episode 1.srt" (.vtt, .srt, .json,
+.html and .txt). Enclosures point at Spreaker, or with audio_base_url at the
+file names "episodes download-all" saves the episodes under.
+
+Use --out - to write the feed to stdout.
+
+Examples:
+  spreaker shows export-feed 12345
+  spreaker shows export-feed 12345 --out site/feed.xml
+  spreaker shows export-feed 12345 --feed-config ./feed.yaml --out -`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShowsExportFeed,
+	}
+
+	cmd.Flags().String("out", "feed.xml", "Path of the RSS file to write, or - for stdout")
+	cmd.Flags().String("feed-config", "", "Podcasting 2.0 settings (default: feed.yaml next to the config file)")
+
+	return cmd
+}
+
+func runShowsExportFeed(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+	outPath, _ := cmd.Flags().GetString("out")
+
+	feedCfg, err := loadFeedConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return err
+	}
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episodes: %w", err)
+	}
+
+	cfg := feedCfg.Shows[showID]
+	if outPath == "-" {
+		return rss.Write(os.Stdout, show, episodes, cfg)
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	if err := rss.Write(f, show, episodes, cfg); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	getFormatter(cmd).PrintSuccess(i18n.T("Exported the feed of %s to %s", show.Title, outPath))
+	return nil
+}
+
+// loadFeedConfig reads --feed-config, or the default feed.yaml if it
+// exists. Without either, the feed has no Podcasting 2.0 tags.
+func loadFeedConfig(cmd *cobra.Command) (*rss.Config, error) {
+	path, _ := cmd.Flags().GetString("feed-config")
+	if path == "" {
+		var err error
+		if path, err = config.DataFilePath(feedConfigFile); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return &rss.Config{}, nil
+		}
+	}
+	return rss.LoadConfig(path)
+}
+
+// -----------------------------------------------------------------------------
+// shows import-opml
+// -----------------------------------------------------------------------------
+
+func newShowsImportOPMLCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-opml <file.opml>",
+		Short: "Favorite the shows of an OPML subscription list",
+		Long: `Read an OPML file exported from a podcast app and add each podcast to
+your Spreaker favorites.
+
+Spreaker feed URLs are used directly; other podcasts are searched by
+title and favorited when exactly one show has the same title. When the
+match is ambiguous you are asked to pick one in a terminal; otherwise
+(or with --no-input) the podcast is skipped.
+
+Examples:
+  spreaker shows import-opml subscriptions.opml
+  spreaker shows import-opml subscriptions.opml --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runShowsImportOPML,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show the matches without adding favorites")
+
+	return cmd
+}
+
+func runShowsImportOPML(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", args[0], err)
+	}
+	feeds, err := opml.Read(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	noInput, _ := cmd.Flags().GetBool("no-input")
+	interactive := !noInput && isInteractive()
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	userID, err := getMyUserID(cmd, client)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+
+	if len(feeds) == 0 {
+		formatter.PrintMessage(i18n.T("No feeds found in %s.", args[0]))
+		return nil
+	}
+
+	added, skipped := 0, 0
+	for n, feed := range feeds {
+		prefix := fmt.Sprintf("[%d/%d] %s", n+1, len(feeds), feed.Title)
+
+		show, err := matchOPMLFeed(client, feed, interactive)
+		if err != nil {
+			formatter.PrintWarning(i18n.T("%s: %v", prefix, err))
+			skipped++
+			continue
+		}
+		if show == nil {
+			formatter.PrintWarning(i18n.T("%s: no matching show on Spreaker, skipped", prefix))
+			skipped++
+			continue
+		}
+
+		if dryRun {
+			formatter.PrintMessage(i18n.T("%s: would favorite %s (%d)", prefix, show.Title, show.ShowID))
+			continue
+		}
+		if err := client.AddShowToFavorites(userID, show.ShowID); err != nil {
+			formatter.PrintWarning(i18n.T("%s: failed to favorite show %d: %v", prefix, show.ShowID, err))
+			skipped++
+			continue
+		}
+		formatter.PrintMessage(i18n.T("%s: favorited %s (%d)", prefix, show.Title, show.ShowID))
+		added++
 	}
 
+	if !dryRun {
+		formatter.PrintSuccess(i18n.T("Added %d of %d shows to favorites (%d skipped)", added, len(feeds), skipped))
+	}
 	return nil
 }
 
+// matchOPMLFeed finds the Spreaker show for an OPML feed, or nil if there
+// is none. Ambiguous title matches are resolved interactively when allowed.
+func matchOPMLFeed(client *api.Client, feed opml.Feed, interactive bool) (*models.Show, error) {
+	if id, ok := opml.SpreakerShowID(feed.XMLURL); ok {
+		return client.GetShow(id)
+	}
+	if feed.Title == "" {
+		return nil, nil
+	}
+
+	result, err := client.SearchShows(api.SearchParams{Query: feed.Title}, api.PaginationParams{Limit: 10})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := sameTitleShows(feed.Title, result.Items)
+	switch {
+	case len(candidates) == 1:
+		return &candidates[0], nil
+	case len(candidates) == 0:
+		candidates = result.Items
+	}
+	if len(candidates) == 0 || !interactive {
+		return nil, nil
+	}
+	return pickOPMLMatch(feed.Title, candidates)
+}
+
+// sameTitleShows returns the shows whose title equals title, ignoring case
+// and surrounding spaces.
+func sameTitleShows(title string, shows []models.Show) []models.Show {
+	var matches []models.Show
+	for _, s := range shows {
+		if strings.EqualFold(strings.TrimSpace(s.Title), strings.TrimSpace(title)) {
+			matches = append(matches, s)
+		}
+	}
+	return matches
+}
+
+// pickOPMLMatch asks the user which show matches a feed; choosing the skip
+// option returns nil.
+func pickOPMLMatch(title string, shows []models.Show) (*models.Show, error) {
+	skip := i18n.T("(skip)")
+	options := []string{skip}
+	byOption := make(map[string]*models.Show, len(shows))
+	for i := range shows {
+		s := &shows[i]
+		option := fmt.Sprintf("%s (%d)", s.Title, s.ShowID)
+		if s.Author != nil && s.Author.Fullname != "" {
+			option = fmt.Sprintf("%s — %s (%d)", s.Title, s.Author.Fullname, s.ShowID)
+		}
+		options = append(options, option)
+		byOption[option] = s
+	}
+
+	selected, err := pterm.DefaultInteractiveSelect.
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Which show is %q?", title))
+	if err != nil {
+		return nil, fmt.Errorf("selection cancelled: %w", err)
+	}
+	return byOption[selected], nil
+}
+
 // -----------------------------------------------------------------------------
 // shows favorite
 // -----------------------------------------------------------------------------
@@ -373,7 +1136,7 @@ func runShowsFavorite(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -383,7 +1146,7 @@ func runShowsFavorite(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Show %d added to favorites", showID))
+	formatter.PrintSuccess(i18n.T("Show %d added to favorites", showID))
 	return nil
 }
 
@@ -411,7 +1174,7 @@ func runShowsUnfavorite(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -421,6 +1184,6 @@ func runShowsUnfavorite(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Show %d removed from favorites", showID))
+	formatter.PrintSuccess(i18n.T("Show %d removed from favorites", showID))
 	return nil
 }
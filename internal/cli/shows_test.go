@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestEpisodesWithMessages(t *testing.T) {
+	at := func(day int) *models.CustomTime {
+		return &models.CustomTime{Time: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)}
+	}
+	episodes := []models.Episode{
+		{EpisodeID: 1, MessagesCount: 3, PublishedAt: at(1)},
+		{EpisodeID: 2, MessagesCount: 0, PublishedAt: at(2)},
+		{EpisodeID: 3, MessagesCount: 1, PublishedAt: at(3)},
+		{EpisodeID: 4, MessagesCount: 2},
+	}
+
+	tests := []struct {
+		name string
+		max  int
+		want []int
+	}{
+		{"all", 0, []int{3, 1, 4}},
+		{"limited", 2, []int{3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := episodesWithMessages(episodes, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d episodes, want %d", len(got), len(tt.want))
+			}
+			for i, e := range got {
+				if e.EpisodeID != tt.want[i] {
+					t.Errorf("episode %d = %d, want %d", i, e.EpisodeID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSameTitleShows(t *testing.T) {
+	shows := []models.Show{
+		{ShowID: 1, Title: "The Daily"},
+		{ShowID: 2, Title: "the daily "},
+		{ShowID: 3, Title: "The Daily Show"},
+	}
+
+	got := sameTitleShows("The Daily", shows)
+	if len(got) != 2 || got[0].ShowID != 1 || got[1].ShowID != 2 {
+		t.Errorf("sameTitleShows() = %+v, want shows 1 and 2", got)
+	}
+	if got := sameTitleShows("Unknown", shows); len(got) != 0 {
+		t.Errorf("sameTitleShows(Unknown) = %+v, want none", got)
+	}
+}
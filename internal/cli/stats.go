@@ -6,9 +6,16 @@ This file contains all commands for viewing statistics
 package cli
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/output"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newStatsCmd() *cobra.Command {
@@ -25,9 +32,29 @@ Overall statistics:
 Time-series statistics (require --from and --to):
   spreaker stats plays 12345 --from 2024-01-01 --to 2024-01-31
   spreaker stats devices 12345 --from 2024-01-01 --to 2024-01-31
-  spreaker stats listeners 12345 --from 2024-01-01 --to 2024-01-31`,
+  spreaker stats listeners 12345 --from 2024-01-01 --to 2024-01-31
+
+Reports (combine statistics with episode data):
+  spreaker stats listening-time 12345 --from 2024-01-01 --to 2024-01-31
+  spreaker stats alerts 12345 --threshold 50%
+  spreaker stats decay 67890 67891
+  spreaker stats funnel 12345 --from 2024-01-01 --to 2024-01-31
+
+Dates may also be today, yesterday or Nd (N days ago). With --tz, or the
+timezone config key, they are days in that time zone rather than UTC:
+  spreaker stats plays 12345 --from 7d --to yesterday --tz Europe/Rome
+
+Campaigns are named date windows set with 'spreaker config set'. plays,
+likes and listeners compare a campaign with the window before it:
+  spreaker config set campaigns.launch 2024-03-01..2024-03-31
+  spreaker stats plays 12345 --campaign launch`,
+		PersistentPreRunE: resolveStatsDates,
+		PersistentPostRun: printStatsTimezone,
 	}
 
+	cmd.PersistentFlags().String("tz", "", "Time zone of --from/--to dates, e.g. Europe/Rome (default: timezone config key, else UTC)")
+	cmd.PersistentFlags().String("campaign", "", "Use the date window of a campaign from the config instead of --from/--to")
+
 	cmd.AddCommand(
 		// Overall statistics
 		newStatsMeCmd(),
@@ -62,11 +89,118 @@ Time-series statistics (require --from and --to):
 		newStatsGeoUserCmd(),
 		// Listeners statistics
 		newStatsListenersCmd(),
+		// Derived reports
+		newStatsListeningTimeCmd(),
+		newStatsAlertsCmd(),
+		newStatsEngagementCmd(),
+		newStatsFunnelCmd(),
+		newStatsDecayCmd(),
+		newStatsCampaignsCmd(),
 	)
 
 	return cmd
 }
 
+// -----------------------------------------------------------------------------
+// Time zones
+// -----------------------------------------------------------------------------
+
+// statsLoc is the time zone stats dates are interpreted in, or nil when
+// none is configured and dates are passed to the API unchanged.
+var statsLoc *time.Location
+
+// statsWindow is the date window a stats command requested in statsLoc,
+// noted after its output.
+var statsWindow *struct{ Zone, From, To string }
+
+// resolveStatsDates resolves relative --from/--to values and, when a time
+// zone is set, maps the local days to the UTC days the API buckets by.
+func resolveStatsDates(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("tz")
+	if name == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		name = cfg.Timezone
+	}
+	loc, err := output.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q (must be an IANA name like Europe/Rome, or local)", name)
+	}
+	statsLoc, statsWindow, statsCampaign = loc, nil, nil
+
+	name, _ = cmd.Flags().GetString("campaign")
+	if name != "" {
+		if err := applyCampaign(cmd, name); err != nil {
+			return err
+		}
+	}
+
+	var window [2]string
+	for i, flag := range []string{"from", "to"} {
+		f := cmd.Flags().Lookup(flag)
+		if f == nil || f.Value.String() == "" {
+			continue
+		}
+		d, err := report.ParseDate(f.Value.String(), time.Now(), statsZone())
+		if err != nil {
+			return fmt.Errorf("--%s: %w", flag, err)
+		}
+		window[i] = statsAPIDay(d)
+		if err := cmd.Flags().Set(flag, window[i]); err != nil {
+			return err
+		}
+	}
+	if window[0] != "" || window[1] != "" {
+		noteStatsWindow(window[0], window[1])
+	}
+	if statsCampaign != nil {
+		return noteCampaignWindow(window[0], window[1])
+	}
+	return nil
+}
+
+// statsZone is the zone relative dates such as "yesterday" are computed in:
+// the configured one, else the system zone.
+func statsZone() *time.Location {
+	if statsLoc == nil {
+		return time.Local
+	}
+	return statsLoc
+}
+
+// statsAPIDay formats a calendar day of statsZone as the day to request.
+func statsAPIDay(d time.Time) string {
+	if statsLoc == nil {
+		return d.Format(report.DateLayout)
+	}
+	return report.APIDay(d)
+}
+
+// noteStatsWindow records the requested window for printStatsTimezone.
+func noteStatsWindow(from, to string) {
+	if statsLoc == nil {
+		return
+	}
+	name := statsLoc.String()
+	if statsLoc == time.Local {
+		name = "local"
+	}
+	statsWindow = &struct{ Zone, From, To string }{
+		Zone: name + " (" + report.UTCOffset(time.Now(), statsLoc) + ")",
+		From: from,
+		To:   to,
+	}
+}
+
+func printStatsTimezone(cmd *cobra.Command, args []string) {
+	if statsWindow == nil {
+		return
+	}
+	getFormatter(cmd).PrintStatsTimezone(statsWindow.Zone, statsWindow.From, statsWindow.To)
+}
+
 // -----------------------------------------------------------------------------
 // stats me
 // -----------------------------------------------------------------------------
@@ -215,7 +349,16 @@ func runStatsPlays(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 	formatter.PrintPlayStatistics(stats)
-	return nil
+
+	plays, downloads := report.PlaysSeries(stats)
+	return printCampaignComparison(cmd, []report.MetricSeries{plays, downloads}, func(from, to string) ([]report.MetricSeries, error) {
+		previous, err := client.GetShowPlayStatistics(showID, api.StatisticsParams{From: from, To: to, Group: group})
+		if err != nil {
+			return nil, err
+		}
+		plays, downloads := report.PlaysSeries(previous)
+		return []report.MetricSeries{plays, downloads}, nil
+	})
 }
 
 // -----------------------------------------------------------------------------
@@ -249,7 +392,7 @@ func runStatsPlaysUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -350,7 +493,7 @@ func runStatsShowsTotals(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -364,6 +507,7 @@ func runStatsShowsTotals(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShowsPlayTotals(result.Items)
 	return nil
 }
@@ -414,6 +558,7 @@ func runStatsEpisodesTotals(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodesPlayTotals(result.Items)
 	return nil
 }
@@ -466,7 +611,14 @@ func runStatsLikes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 	formatter.PrintLikesStatistics(stats)
-	return nil
+
+	return printCampaignComparison(cmd, []report.MetricSeries{report.LikesSeries(stats)}, func(from, to string) ([]report.MetricSeries, error) {
+		previous, err := client.GetShowLikesStatistics(showID, api.StatisticsParams{From: from, To: to, Group: group})
+		if err != nil {
+			return nil, err
+		}
+		return []report.MetricSeries{report.LikesSeries(previous)}, nil
+	})
 }
 
 // -----------------------------------------------------------------------------
@@ -500,7 +652,7 @@ func runStatsLikesUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -601,7 +753,7 @@ func runStatsFollowers(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -700,7 +852,7 @@ func runStatsSourcesUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -844,7 +996,7 @@ func runStatsDevicesUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -984,7 +1136,7 @@ func runStatsOSUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -1057,7 +1209,16 @@ func newStatsGeoCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "geo <show-id>",
 		Short: "Show geographic breakdown for a show",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the share of plays by country and city for a show.
+
+--country narrows the breakdown to the cities within one country, and
+--top keeps the largest entries. A PLAYS column is added when the API
+reports absolute counts.
+
+Example:
+  spreaker stats geo 12345 --from 2024-01-01 --to 2024-01-31 --top 10
+  spreaker stats geo 12345 --from 2024-01-01 --to 2024-01-31 --country US`,
+		Args: cobra.ExactArgs(1),
 		RunE:  runStatsGeo,
 	}
 
@@ -1065,6 +1226,7 @@ func newStatsGeoCmd() *cobra.Command {
 	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, required)")
 	cmd.MarkFlagRequired("from")
 	cmd.MarkFlagRequired("to")
+	addGeoFlags(cmd)
 
 	return cmd
 }
@@ -1091,6 +1253,11 @@ func runStatsGeo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	stats, err = applyGeoFlags(cmd, stats)
+	if err != nil {
+		return err
+	}
+
 	formatter := getFormatter(cmd)
 	formatter.PrintGeographicStatistics(stats)
 	return nil
@@ -1111,6 +1278,7 @@ func newStatsGeoUserCmd() *cobra.Command {
 	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, required)")
 	cmd.MarkFlagRequired("from")
 	cmd.MarkFlagRequired("to")
+	addGeoFlags(cmd)
 
 	return cmd
 }
@@ -1124,7 +1292,7 @@ func runStatsGeoUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -1137,11 +1305,39 @@ func runStatsGeoUser(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	stats, err = applyGeoFlags(cmd, stats)
+	if err != nil {
+		return err
+	}
+
 	formatter := getFormatter(cmd)
 	formatter.PrintGeographicStatistics(stats)
 	return nil
 }
 
+func addGeoFlags(cmd *cobra.Command) {
+	cmd.Flags().String("country", "", "Show the cities within this country (code or name, e.g. US)")
+	cmd.Flags().Int("top", 0, "Show only the N largest countries and cities (0 for all)")
+}
+
+// applyGeoFlags narrows a geographic breakdown to the --country and --top flags.
+func applyGeoFlags(cmd *cobra.Command, stats *models.GeographicStatistics) (*models.GeographicStatistics, error) {
+	country, _ := cmd.Flags().GetString("country")
+	top, _ := cmd.Flags().GetInt("top")
+	if top < 0 {
+		return nil, fmt.Errorf("invalid top %d: must be 0 or more", top)
+	}
+
+	if country != "" {
+		narrowed, ok := stats.InCountry(country)
+		if !ok {
+			return nil, fmt.Errorf("no city breakdown for country %q: it has no plays in this period, or the API does not report the country of cities", country)
+		}
+		stats = narrowed
+	}
+	return stats.Top(top), nil
+}
+
 // -----------------------------------------------------------------------------
 // stats listeners (show only)
 // -----------------------------------------------------------------------------
@@ -1189,5 +1385,12 @@ func runStatsListeners(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 	formatter.PrintListenersStatistics(stats)
-	return nil
+
+	return printCampaignComparison(cmd, []report.MetricSeries{report.ListenersSeries(stats)}, func(from, to string) ([]report.MetricSeries, error) {
+		previous, err := client.GetShowListenersStatistics(showID, api.StatisticsParams{From: from, To: to, Group: group})
+		if err != nil {
+			return nil, err
+		}
+		return []report.MetricSeries{report.ListenersSeries(previous)}, nil
+	})
 }
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+)
+
+// campaignWindow is a campaign selected with --campaign, as the API days
+// of its window and of the window of equal length before it.
+type campaignWindow struct {
+	Name                     string
+	From, To                 time.Time
+	PreviousFrom, PreviousTo time.Time
+}
+
+// statsCampaign is the campaign of the running stats command, if any.
+var statsCampaign *campaignWindow
+
+// applyCampaign sets --from and --to to the window of the named campaign,
+// before resolveStatsDates maps them to API days.
+func applyCampaign(cmd *cobra.Command, name string) error {
+	from, to := cmd.Flags().Lookup("from"), cmd.Flags().Lookup("to")
+	if from == nil || to == nil {
+		return fmt.Errorf("--campaign is not supported by '%s': it has no date range", cmd.CommandPath())
+	}
+	if from.Changed || to.Changed {
+		return fmt.Errorf("--campaign cannot be combined with --from or --to")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	c, ok := cfg.CampaignNamed(name)
+	if !ok {
+		return fmt.Errorf("no campaign named %q: add one with 'spreaker config set campaigns.%s FROM..TO'", name, name)
+	}
+	if _, _, err := c.Window(); err != nil {
+		return fmt.Errorf("campaign %s: %w", name, err)
+	}
+
+	if err := cmd.Flags().Set("from", c.From); err != nil {
+		return err
+	}
+	if err := cmd.Flags().Set("to", c.To); err != nil {
+		return err
+	}
+	statsCampaign = &campaignWindow{Name: name}
+	return nil
+}
+
+// noteCampaignWindow records the API days of the campaign window and of
+// the window it is compared with.
+func noteCampaignWindow(from, to string) error {
+	var err error
+	if statsCampaign.From, err = time.Parse(report.DateLayout, from); err != nil {
+		return err
+	}
+	if statsCampaign.To, err = time.Parse(report.DateLayout, to); err != nil {
+		return err
+	}
+	statsCampaign.PreviousFrom, statsCampaign.PreviousTo = report.PreviousWindow(statsCampaign.From, statsCampaign.To)
+	return nil
+}
+
+// printCampaignComparison compares the metrics of the campaign window with
+// those of the window before it, when --campaign is set. fetch returns the
+// metrics of a window in the order of current.
+func printCampaignComparison(cmd *cobra.Command, current []report.MetricSeries, fetch func(from, to string) ([]report.MetricSeries, error)) error {
+	c := statsCampaign
+	if c == nil {
+		return nil
+	}
+
+	previous, err := fetch(c.PreviousFrom.Format(report.DateLayout), c.PreviousTo.Format(report.DateLayout))
+	if err != nil {
+		return fmt.Errorf("failed to fetch the window before the campaign: %w", err)
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintCampaignComparison(report.CompareCampaign(c.Name, c.From, c.To, c.PreviousFrom, c.PreviousTo, current, previous))
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// stats campaigns
+// -----------------------------------------------------------------------------
+
+func newStatsCampaignsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "campaigns",
+		Short: "List the campaigns defined in the config",
+		Long: `List the campaigns defined in the config: named date windows that
+stats commands use with --campaign instead of --from and --to.
+
+plays, likes and listeners of a show also compare the campaign with the
+window of equal length just before it.
+
+Example:
+  spreaker config set campaigns.launch 2024-03-01..2024-03-31
+  spreaker stats campaigns
+  spreaker stats plays 12345 --campaign launch`,
+		Args: cobra.NoArgs,
+		RunE: runStatsCampaigns,
+	}
+}
+
+func runStatsCampaigns(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Campaigns))
+	for name := range cfg.Campaigns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	campaigns := make([]report.Campaign, 0, len(names))
+	for _, name := range names {
+		c := cfg.Campaigns[name]
+		campaign := report.Campaign{Name: name, From: c.From, To: c.To}
+		if from, to, err := c.Window(); err == nil {
+			campaign.Days = int(to.Sub(from).Hours()/24) + 1
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintCampaigns(campaigns)
+	return nil
+}
@@ -0,0 +1,463 @@
+/*
+stats_reports.go - Derived statistics reports
+
+These commands combine several API endpoints (statistics plus episode
+metadata) through the internal/report package, rather than printing a
+single endpoint's response.
+*/
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// -----------------------------------------------------------------------------
+// stats listening-time
+// -----------------------------------------------------------------------------
+
+func newStatsListeningTimeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "listening-time <show-id>",
+		Short: "Estimate total hours listened per episode",
+		Long: `Estimate total listening time for a show's episodes over a date range.
+
+Hours are estimated as plays × episode duration × completion, where
+--completion is the assumed average fraction of an episode that listeners
+hear (1.0 means every play is listened to the end). Episodes are ranked
+by estimated hours.
+
+Example:
+  spreaker stats listening-time 12345 --from 2024-01-01 --to 2024-01-31
+  spreaker stats listening-time 12345 --from 2024-01-01 --to 2024-01-31 --completion 0.6`,
+		Args: cobra.ExactArgs(1),
+		RunE: runStatsListeningTime,
+	}
+
+	cmd.Flags().String("from", "", "Start date (YYYY-MM-DD, required)")
+	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, required)")
+	cmd.Flags().Float64("completion", 1.0, "Assumed average completion rate (0-1]")
+
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runStatsListeningTime(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	completion, _ := cmd.Flags().GetFloat64("completion")
+	if completion <= 0 || completion > 1 {
+		return fmt.Errorf("invalid completion %v: must be greater than 0 and at most 1", completion)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	totals, err := client.GetAllShowEpisodesPlayTotals(showID, api.StatisticsParams{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return err
+	}
+
+	episodes, err := client.GetAllShowEpisodes(showID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch episode durations: %w", err)
+	}
+
+	r := report.BuildListeningTime(totals, episodes, completion)
+	r.From, r.To = from, to
+
+	formatter := getFormatter(cmd)
+	formatter.PrintListeningTime(r)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// stats engagement
+// -----------------------------------------------------------------------------
+
+func newStatsEngagementCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "engagement <show-id>",
+		Short: "Rank episodes by plays and likes over a date range",
+		Long: `Join the play totals of every episode with its likes over the same date
+range, and report likes per play for each episode.
+
+Likes are fetched per episode, --concurrency requests at a time, so the
+report stays fast for shows with hundreds of episodes. Rows are always
+ranked the same way, whatever order the requests complete in.
+
+Example:
+  spreaker stats engagement 12345 --from 2024-01-01 --to 2024-01-31
+  spreaker stats engagement 12345 --from 2024-01-01 --to 2024-01-31 --concurrency 4`,
+		Args: cobra.ExactArgs(1),
+		RunE: runStatsEngagement,
+	}
+
+	cmd.Flags().String("from", "", "Start date (YYYY-MM-DD, required)")
+	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, required)")
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of per-episode requests to run at once")
+
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runStatsEngagement(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	params := api.StatisticsParams{From: from, To: to}
+	totals, err := client.GetAllShowEpisodesPlayTotals(showID, params)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, len(totals))
+	for i, t := range totals {
+		ids[i] = t.EpisodeID
+	}
+	likes, err := api.FetchAll(cmd.Context(), ids, concurrency, func(id int) ([]models.LikesStatistics, error) {
+		stats, err := client.GetEpisodeLikesStatistics(id, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch likes for episode %d: %w", id, err)
+		}
+		return stats, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	likesByEpisode := make(map[int][]models.LikesStatistics, len(ids))
+	for i, id := range ids {
+		likesByEpisode[id] = likes[i]
+	}
+
+	r := report.BuildEngagement(totals, likesByEpisode)
+	r.From, r.To = from, to
+
+	formatter := getFormatter(cmd)
+	formatter.PrintEngagement(r)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// stats funnel
+// -----------------------------------------------------------------------------
+
+func newStatsFunnelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "funnel <show-id>",
+		Short: "Combine a show's sources, devices and countries in one report",
+		Long: `Report where a show's plays come from, on which devices and in which
+countries over the same date range. The three breakdowns are fetched
+concurrently.
+
+The API reports each breakdown separately, so plays per device, and per
+country when the API only reports shares, are estimated from the total
+plays of the sources breakdown (marked ~). The breakdowns cannot be
+crossed: the API does not say which sources mobile listeners use.
+
+Example:
+  spreaker stats funnel 12345 --from 2024-01-01 --to 2024-01-31
+  spreaker stats funnel 12345 --from 30d --to yesterday -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runStatsFunnel,
+	}
+
+	cmd.Flags().String("from", "", "Start date (YYYY-MM-DD, required)")
+	cmd.Flags().String("to", "", "End date (YYYY-MM-DD, required)")
+
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runStatsFunnel(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	params := api.StatisticsParams{From: from, To: to}
+	var (
+		sources            *models.SourcesStatistics
+		devices            []models.DeviceStatistics
+		geo                *models.GeographicStatistics
+		devicesErr, geoErr error
+		wg                 sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		devices, devicesErr = client.GetShowDevicesStatistics(showID, params)
+	}()
+	go func() {
+		defer wg.Done()
+		geo, geoErr = client.GetShowGeographicStatistics(showID, params)
+	}()
+	sources, err = client.GetShowSourcesStatistics(showID, params)
+	wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch sources: %w", err)
+	}
+	if devicesErr != nil {
+		return fmt.Errorf("failed to fetch devices: %w", devicesErr)
+	}
+	if geoErr != nil {
+		return fmt.Errorf("failed to fetch countries: %w", geoErr)
+	}
+
+	r := report.BuildFunnel(sources, devices, geo)
+	r.From, r.To = from, to
+
+	formatter := getFormatter(cmd)
+	formatter.PrintFunnel(r)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// stats alerts
+// -----------------------------------------------------------------------------
+
+// alertsExitCode is the exit code used when at least one metric trips the threshold,
+// distinct from 1 (command failure) so cron jobs can tell the two apart.
+const alertsExitCode = 2
+
+func newStatsAlertsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alerts <show-id>",
+		Short: "Detect unusual spikes or drops in show activity",
+		Long: `Compare the most recent complete day or week against the trailing average
+and report spikes and drops in plays, downloads, likes and listeners.
+
+The command exits with status 2 when any metric changes by at least
+--threshold, so it can be scheduled with cron to notify you of unusual
+activity. Other errors exit with status 1.
+
+Example:
+  spreaker stats alerts 12345 --threshold 50%
+  spreaker stats alerts 12345 --period week --baseline 4 --threshold 30%`,
+		Args: cobra.ExactArgs(1),
+		RunE: runStatsAlerts,
+	}
+
+	cmd.Flags().String("threshold", "50%", "Minimum change from the baseline to report, in percent")
+	cmd.Flags().String("period", "day", "Period to compare: day or week")
+	cmd.Flags().Int("baseline", 7, "Number of preceding periods averaged as the baseline")
+
+	return cmd
+}
+
+func runStatsAlerts(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	thresholdFlag, _ := cmd.Flags().GetString("threshold")
+	threshold, err := parseThreshold(thresholdFlag)
+	if err != nil {
+		return err
+	}
+
+	period, _ := cmd.Flags().GetString("period")
+	var periodDays int
+	switch period {
+	case "day":
+		periodDays = 1
+	case "week":
+		periodDays = 7
+	default:
+		return fmt.Errorf("invalid period %q: must be day or week", period)
+	}
+
+	baseline, _ := cmd.Flags().GetInt("baseline")
+	if baseline < 1 {
+		return fmt.Errorf("invalid baseline %d: must be at least 1", baseline)
+	}
+
+	// Today is still in progress, so the most recent complete period ends
+	// yesterday, in the podcaster's time zone when one is set.
+	// Statistics are keyed by the API's days, so the window is built on those.
+	yesterday, _ := report.ParseDate("yesterday", time.Now(), statsZone())
+	end, _ := time.Parse(report.DateLayout, statsAPIDay(yesterday))
+	start := end.AddDate(0, 0, -(periodDays*(baseline+1) - 1))
+	params := api.StatisticsParams{
+		From:  start.Format(report.DateLayout),
+		To:    end.Format(report.DateLayout),
+		Group: "day",
+	}
+	noteStatsWindow(params.From, params.To)
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	playStats, err := client.GetShowPlayStatistics(showID, params)
+	if err != nil {
+		return err
+	}
+	likesStats, err := client.GetShowLikesStatistics(showID, params)
+	if err != nil {
+		return err
+	}
+	listenersStats, err := client.GetShowListenersStatistics(showID, params)
+	if err != nil {
+		return err
+	}
+
+	plays, downloads := report.PlaysSeries(playStats)
+	series := []report.MetricSeries{
+		plays,
+		downloads,
+		report.LikesSeries(likesStats),
+		report.ListenersSeries(listenersStats),
+	}
+
+	alerts := report.DetectAnomalies(series, end, periodDays, baseline, threshold)
+	alerts.Period = period
+
+	formatter := getFormatter(cmd)
+	formatter.PrintStatsAlerts(alerts)
+
+	if alerts.Triggered > 0 {
+		return &ExitError{
+			Code: alertsExitCode,
+			Err:  fmt.Errorf("%d metric(s) changed by at least %g%%", alerts.Triggered, threshold),
+		}
+	}
+	return nil
+}
+
+// parseThreshold parses a percentage such as "50%" or "50" into 50.
+func parseThreshold(s string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid threshold %q: must be a positive percentage (e.g. 50%%)", s)
+	}
+	return value, nil
+}
+
+// -----------------------------------------------------------------------------
+// stats decay
+// -----------------------------------------------------------------------------
+
+func newStatsDecayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decay <episode-id>...",
+		Short: "Show how episodes accrue plays after publishing",
+		Long: `Fetch the daily plays of episodes since they were published, and report
+the plays of their first day, week and month with the share of lifetime
+plays each accounts for. Comparing episodes shows which keep being played
+long after release.
+
+Days are counted from the publish day, as day 1, in UTC. A milestone the
+episode has not reached yet is marked as still growing.
+
+Example:
+  spreaker stats decay 67890
+  spreaker stats decay 67890 67891 67892 -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runStatsDecay,
+	}
+
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of episodes to fetch at once")
+
+	return cmd
+}
+
+func runStatsDecay(cmd *cobra.Command, args []string) error {
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := parseEpisodeID(arg)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	today := time.Now()
+	decays, err := api.FetchAll(cmd.Context(), ids, concurrency, func(id int) (report.EpisodeDecay, error) {
+		episode, err := client.GetEpisode(id)
+		if err != nil {
+			return report.EpisodeDecay{}, err
+		}
+		if episode.PublishedAt == nil || episode.PublishedAt.IsZero() {
+			return report.EpisodeDecay{}, fmt.Errorf("episode %d is not published yet", id)
+		}
+
+		stats, err := client.GetEpisodePlayStatistics(id, api.StatisticsParams{
+			From:  episode.PublishedAt.UTC().Format(report.DateLayout),
+			To:    today.UTC().Format(report.DateLayout),
+			Group: "day",
+		})
+		if err != nil {
+			return report.EpisodeDecay{}, fmt.Errorf("failed to fetch plays for episode %d: %w", id, err)
+		}
+		return report.BuildDecay(episode, episode.PublishedAt.Time, today, stats), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	formatter.PrintDecay(decays)
+	return nil
+}
@@ -0,0 +1,354 @@
+/*
+sync.go - Local catalog synchronization
+
+sync copies data from the API into the local catalog next to the config
+file, so it can be queried with the local commands without calling the
+API again.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// catalogDir is the directory of the local catalog, next to the config file.
+const catalogDir = "catalog"
+
+// syncWindowDays is the number of days requested per statistics call.
+const syncWindowDays = 90
+
+func newSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Copy data from the API into the local catalog",
+		Long: `Copy data from the API into the local catalog, so it can be queried
+offline with the local commands.
+
+Example:
+  spreaker sync stats 12345
+  spreaker local stats 12345 --group week --average 4
+  spreaker sync messages
+  spreaker local messages search refund`,
+	}
+
+	cmd.AddCommand(newSyncStatsCmd())
+	cmd.AddCommand(newSyncMessagesCmd())
+
+	return cmd
+}
+
+// catalogPath returns the catalog file of a show's statistics.
+func catalogPath(showID int) (string, error) {
+	return config.DataFilePath(filepath.Join(catalogDir, fmt.Sprintf("stats-%d.json", showID)))
+}
+
+// messagesCatalogPath returns the catalog file of a show's messages.
+func messagesCatalogPath(showID int) (string, error) {
+	return config.DataFilePath(filepath.Join(catalogDir, fmt.Sprintf("messages-%d.json", showID)))
+}
+
+// -----------------------------------------------------------------------------
+// sync stats
+// -----------------------------------------------------------------------------
+
+func newSyncStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats <show-id>",
+		Short: "Store a show's daily plays, likes and followers locally",
+		Long: `Store a show's daily plays, downloads, likes and followers in the local
+catalog.
+
+The first sync fetches the days since --from. Later syncs only fetch the
+days since the last stored day, which is fetched again as it may have been
+incomplete. Followers are those of the show's author, as the API reports
+followers per user. Progress is saved after each batch of days, so an
+interrupted sync resumes where it stopped.
+
+--csv imports days from a CSV file instead of the API, such as history
+exported from the Spreaker dashboard. Its first row names the columns:
+date and any of plays, downloads, likes and followers. Missing columns and
+empty cells keep the stored values.
+
+Example:
+  spreaker sync stats 12345
+  spreaker sync stats 12345 --from 2023-01-01 --full
+  spreaker sync stats 12345 --csv history.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: notifyDone(runSyncStats),
+	}
+
+	cmd.Flags().String("from", "365d", "First day of the first sync (YYYY-MM-DD, today, yesterday or Nd)")
+	cmd.Flags().Bool("full", false, "Fetch again every day since --from, not only new days")
+	cmd.Flags().String("csv", "", "Import days from this CSV file instead of the API")
+	cmd.MarkFlagsMutuallyExclusive("csv", "from")
+	cmd.MarkFlagsMutuallyExclusive("csv", "full")
+	addNotifyFlag(cmd)
+
+	return cmd
+}
+
+func runSyncStats(cmd *cobra.Command, args []string) error {
+	showID, err := parseShowID(args[0])
+	if err != nil {
+		return err
+	}
+
+	if csvPath, _ := cmd.Flags().GetString("csv"); csvPath != "" {
+		return importStatsCSV(cmd, showID, csvPath)
+	}
+
+	fromFlag, _ := cmd.Flags().GetString("from")
+	full, _ := cmd.Flags().GetBool("full")
+
+	now := time.Now()
+	start, err := report.ParseDate(fromFlag, now, time.UTC)
+	if err != nil {
+		return fmt.Errorf("--from: %w", err)
+	}
+	end, _ := report.ParseDate("today", now, time.UTC)
+
+	path, err := catalogPath(showID)
+	if err != nil {
+		return err
+	}
+	stats, err := catalog.Load(path, showID)
+	if err != nil {
+		return err
+	}
+	if last := stats.LastDate(); last != "" && !full {
+		if start, err = time.Parse(report.DateLayout, last); err != nil {
+			return fmt.Errorf("invalid date %q in stats catalog", last)
+		}
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	show, err := client.GetShow(showID)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+	spinner := formatter.StartSpinner(i18n.T("Syncing statistics for %s...", show.Title))
+
+	added := 0
+	for from := start; !from.After(end); from = from.AddDate(0, 0, syncWindowDays) {
+		to := from.AddDate(0, 0, syncWindowDays-1)
+		if to.After(end) {
+			to = end
+		}
+
+		days, err := fetchCatalogDays(client, showID, show.AuthorID, from, to)
+		if err != nil {
+			formatter.CancelSpinner(spinner)
+			return fmt.Errorf("sync stopped at %s: %w", from.Format(report.DateLayout), err)
+		}
+
+		added += stats.Merge(days)
+		stats.SyncedAt = now.UTC()
+		if err := stats.Save(path); err != nil {
+			formatter.CancelSpinner(spinner)
+			return fmt.Errorf("sync stopped at %s: %w", from.Format(report.DateLayout), err)
+		}
+	}
+
+	formatter.StopSpinner(spinner, true, i18n.T("Synced %d new day(s) for %s, stored through %s", added, show.Title, stats.LastDate()))
+	return nil
+}
+
+// importStatsCSV merges the days of a CSV file into a show's catalog.
+func importStatsCSV(cmd *cobra.Command, showID int, csvPath string) error {
+	f, err := os.Open(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer f.Close()
+
+	path, err := catalogPath(showID)
+	if err != nil {
+		return err
+	}
+	stats, err := catalog.Load(path, showID)
+	if err != nil {
+		return err
+	}
+
+	added, err := stats.MergeCSV(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", csvPath, err)
+	}
+	if err := stats.Save(path); err != nil {
+		return err
+	}
+
+	getFormatter(cmd).PrintSuccess(i18n.T("Imported %d new day(s) for show %d, stored through %s", added, showID, stats.LastDate()))
+	return nil
+}
+
+// fetchCatalogDays fetches the daily statistics stored in the catalog for
+// the days from from to to. authorID 0 skips followers.
+func fetchCatalogDays(client *api.Client, showID, authorID int, from, to time.Time) ([]catalog.Day, error) {
+	params := api.StatisticsParams{
+		From:  from.Format(report.DateLayout),
+		To:    to.Format(report.DateLayout),
+		Group: "day",
+	}
+
+	plays, err := client.GetShowPlayStatistics(showID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plays: %w", err)
+	}
+	likes, err := client.GetShowLikesStatistics(showID, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch likes: %w", err)
+	}
+
+	var followers []models.FollowersStatistics
+	if authorID != 0 {
+		if followers, err = client.GetUserFollowersStatistics(authorID, params); err != nil {
+			return nil, fmt.Errorf("failed to fetch followers: %w", err)
+		}
+	}
+
+	return catalog.BuildDays(from, to, plays, likes, followers), nil
+}
+
+// -----------------------------------------------------------------------------
+// sync messages
+// -----------------------------------------------------------------------------
+
+func newSyncMessagesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "messages [show-id...]",
+		Short: "Store the messages of a show's episodes locally",
+		Long: `Store the listener messages of every episode of the given shows, or of
+all your shows, in the local catalog, so they can be searched with
+'spreaker local messages search'.
+
+Each sync replaces a show's stored messages, so deleted messages do not
+linger. Shows are saved one at a time, so an interrupted sync keeps the
+shows already synced.
+
+Example:
+  spreaker sync messages
+  spreaker sync messages 12345 --concurrency 4`,
+		RunE: notifyDone(runSyncMessages),
+	}
+
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of episodes to fetch at once")
+	addNotifyFlag(cmd)
+
+	return cmd
+}
+
+func runSyncMessages(cmd *cobra.Command, args []string) error {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	var shows []models.Show
+	if len(args) == 0 {
+		userID, err := getMyUserID(cmd, client)
+		if err != nil {
+			return err
+		}
+		if shows, err = client.GetAllUserShows(userID); err != nil {
+			return err
+		}
+	}
+	for _, arg := range args {
+		showID, err := parseShowID(arg)
+		if err != nil {
+			return err
+		}
+		show, err := client.GetShow(showID)
+		if err != nil {
+			return err
+		}
+		shows = append(shows, *show)
+	}
+
+	formatter := getFormatter(cmd)
+	total := 0
+	for _, show := range shows {
+		spinner := formatter.StartSpinner(i18n.T("Syncing messages for %s...", show.Title))
+		stored, err := syncShowMessages(cmd, client, show, concurrency)
+		if err != nil {
+			formatter.CancelSpinner(spinner)
+			return fmt.Errorf("sync of %s failed: %w", show.Title, err)
+		}
+		formatter.StopSpinner(spinner, true, i18n.T("Stored %d message(s) for %s", stored, show.Title))
+		total += stored
+	}
+
+	if len(shows) > 1 {
+		formatter.PrintSuccess(i18n.T("Stored %d message(s) for %d shows", total, len(shows)))
+	}
+	return nil
+}
+
+// syncShowMessages fetches the messages of every episode of show, replaces
+// the show's stored messages with them and returns how many were stored.
+func syncShowMessages(cmd *cobra.Command, client *api.Client, show models.Show, concurrency int) (int, error) {
+	path, err := messagesCatalogPath(show.ShowID)
+	if err != nil {
+		return 0, err
+	}
+
+	episodes, err := client.GetAllShowEpisodes(show.ShowID)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]int, len(episodes))
+	for i, e := range episodes {
+		ids[i] = e.EpisodeID
+	}
+
+	lists, err := api.FetchAll(cmd.Context(), ids, concurrency, func(id int) ([]models.Message, error) {
+		messages, err := client.GetAllEpisodeMessages(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch messages of episode %d: %w", id, err)
+		}
+		return messages, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	stored := &catalog.ShowMessages{
+		ShowID:    show.ShowID,
+		ShowTitle: show.Title,
+		SyncedAt:  time.Now().UTC(),
+		Messages:  []catalog.Message{},
+	}
+	for i, messages := range lists {
+		for _, m := range messages {
+			stored.Messages = append(stored.Messages, catalog.Message{Message: m, EpisodeTitle: episodes[i].Title})
+		}
+	}
+	if err := stored.Save(path); err != nil {
+		return 0, err
+	}
+	return len(stored.Messages), nil
+}
@@ -1,16 +1,23 @@
 /*
 tags.go - Tag-based discovery commands
 
-Commands for discovering episodes by tag/hashtag.
+Commands for discovering episodes by tag/hashtag, and for analyzing the
+tags of your own catalog.
 */
 package cli
 
 import (
 	"fmt"
-	
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 	
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newTagsCmd() *cobra.Command {
@@ -22,10 +29,14 @@ func newTagsCmd() *cobra.Command {
 Examples:
   spreaker tags episodes "breaking news"
   spreaker tags episodes tech
-  spreaker tags episodes "machine learning" --limit 50`,
+  spreaker tags episodes "machine learning" --limit 50
+  spreaker tags stats --min-count 3`,
 	}
 
-	cmd.AddCommand(newTagsEpisodesCmd())
+	cmd.AddCommand(
+		newTagsEpisodesCmd(),
+		newTagsStatsCmd(),
+	)
 
 	return cmd
 }
@@ -67,16 +78,149 @@ func runTagsEpisodes(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage(fmt.Sprintf("No episodes found with tag '%s'.", tagName))
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No episodes found with tag '%s'.", tagName))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintEpisodes(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more episodes available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more episodes available, use --limit to see more)"))
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// tags stats
+// -----------------------------------------------------------------------------
+
+func newTagsStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [show-id...]",
+		Short: "Rank the tags of your episodes by plays",
+		Long: `Aggregate the tags of your episodes: how many episodes use each tag, their
+plays over --from to --to, and the average plays per episode, so you can
+see which topics perform best.
+
+Every show of your account is included unless show IDs are given. Tags
+differing only in case are counted as one.
+
+Sort with --sort: average (average plays per episode, the default), plays
+(total plays), count (episodes using the tag) or tag (by name).
+
+Examples:
+  spreaker tags stats
+  spreaker tags stats 12345 --min-count 3
+  spreaker tags stats --from 90d --sort plays`,
+		RunE: runTagsStats,
+	}
+
+	cmd.Flags().String("from", "365d", "Start of the plays period (YYYY-MM-DD, today, yesterday or Nd)")
+	cmd.Flags().String("to", "today", "End of the plays period (YYYY-MM-DD, today, yesterday or Nd)")
+	cmd.Flags().Int("min-count", 1, "Only list tags used by at least this many episodes")
+	cmd.Flags().String("sort", "average", "Sort by average, plays, count or tag")
+	cmd.Flags().Int("concurrency", api.DefaultConcurrency, "Number of shows and episodes to fetch at once")
+
+	return cmd
+}
+
+func runTagsStats(cmd *cobra.Command, args []string) error {
+	minCount, _ := cmd.Flags().GetInt("min-count")
+	sortBy, _ := cmd.Flags().GetString("sort")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if !slices.Contains(report.TagSorts, sortBy) {
+		return fmt.Errorf("invalid sort %q: must be one of %s", sortBy, strings.Join(report.TagSorts, ", "))
+	}
+	if concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: must be at least 1", concurrency)
+	}
+
+	var window [2]string
+	now := time.Now()
+	for i, flag := range []string{"from", "to"} {
+		value, _ := cmd.Flags().GetString(flag)
+		d, err := report.ParseDate(value, now, time.UTC)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", flag, err)
+		}
+		window[i] = report.APIDay(d)
+	}
+
+	showIDs := make([]int, len(args))
+	for i, arg := range args {
+		id, err := parseShowID(arg)
+		if err != nil {
+			return err
+		}
+		showIDs[i] = id
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(showIDs) == 0 {
+		userID, err := getMyUserID(cmd, client)
+		if err != nil {
+			return err
+		}
+		shows, err := client.GetAllUserShows(userID)
+		if err != nil {
+			return err
+		}
+		for _, show := range shows {
+			showIDs = append(showIDs, show.ShowID)
+		}
+	}
+
+	type showTags struct {
+		episodes []models.Episode
+		totals   []models.EpisodePlayTotals
+	}
+	params := api.StatisticsParams{From: window[0], To: window[1]}
+	fetched, err := api.FetchAll(cmd.Context(), showIDs, concurrency, func(id int) (showTags, error) {
+		episodes, err := client.GetAllShowEpisodes(id)
+		if err != nil {
+			return showTags{}, fmt.Errorf("failed to fetch episodes of show %d: %w", id, err)
+		}
+		totals, err := client.GetAllShowEpisodesPlayTotals(id, params)
+		if err != nil {
+			return showTags{}, fmt.Errorf("failed to fetch plays of show %d: %w", id, err)
+		}
+		return showTags{episodes, totals}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var episodes []models.Episode
+	plays := make(map[int]int)
+	for _, c := range fetched {
+		episodes = append(episodes, c.episodes...)
+		for _, t := range c.totals {
+			plays[t.EpisodeID] = t.PlaysCount
+		}
+	}
+	// Episode lists lack tags.
+	if episodes, err = episodeDetails(cmd.Context(), client, episodes, concurrency); err != nil {
+		return err
+	}
+
+	r, err := report.BuildTagStats(episodes, plays, minCount, sortBy)
+	if err != nil {
+		return err
 	}
+	r.From, r.To = window[0], window[1]
 
+	formatter := getFormatter(cmd)
+	if len(r.Tags) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No tags found."))
+		return nil
+	}
+	formatter.PrintTagStats(r)
 	return nil
 }
@@ -0,0 +1,111 @@
+/*
+upgrade.go - Self-update command
+
+upgrade replaces the running binary with the latest GitHub release.
+*/
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/update"
+)
+
+func newUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade spreaker to the latest release",
+		Long: `Check GitHub for the latest release and, if it is newer than this
+version, replace the spreaker executable with the release binary for
+this platform.
+
+The download is verified against the release's SHA-256 checksums before
+the executable is replaced; on any failure the installed version is kept.
+
+Examples:
+  spreaker upgrade --check   # only report whether an update is available
+  spreaker upgrade
+  spreaker upgrade --force   # reinstall, or upgrade a development build`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{noPagerAnnotation: ""},
+		RunE:        runUpgrade,
+	}
+
+	cmd.Flags().Bool("check", false, "Only report whether a newer release is available")
+	cmd.Flags().Bool("force", false, "Install the latest release even if it is not newer")
+
+	return cmd
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	checkOnly, _ := cmd.Flags().GetBool("check")
+	force, _ := cmd.Flags().GetBool("force")
+	current := cmd.Root().Version
+
+	formatter := getFormatter(cmd)
+	client := &http.Client{Timeout: api.DownloadTimeout, Transport: api.Transport}
+
+	rel, err := update.Latest(cmd.Context(), client)
+	if err != nil {
+		return err
+	}
+
+	// Development builds have no comparable version.
+	newer := true
+	if cmp, err := update.CompareVersions(current, rel.TagName); err == nil {
+		newer = cmp < 0
+	} else if !force && !checkOnly {
+		return fmt.Errorf("cannot compare development build %q with %s; use --force to install it", current, rel.TagName)
+	}
+
+	formatter.PrintKeyValue([][2]string{
+		{"Current:", current},
+		{"Latest:", rel.TagName},
+	})
+
+	if checkOnly {
+		if newer {
+			formatter.PrintMessage(i18n.T("A new version is available: run 'spreaker upgrade' (%s)", rel.HTMLURL))
+		} else {
+			formatter.PrintSuccess(i18n.T("spreaker is up to date"))
+		}
+		return nil
+	}
+	if !newer && !force {
+		formatter.PrintSuccess(i18n.T("spreaker is up to date"))
+		return nil
+	}
+
+	name := update.CurrentAssetName()
+	asset, ok := rel.Asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no binary for this platform (%s)", rel.TagName, name)
+	}
+	sum, err := update.Checksum(cmd.Context(), client, rel, name)
+	if err != nil {
+		return err
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot locate the spreaker executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	spinner := formatter.StartSpinner(i18n.T("Downloading %s...", name))
+	if err := update.Install(cmd.Context(), client, asset, sum, exePath); err != nil {
+		formatter.CancelSpinner(spinner)
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+	formatter.StopSpinner(spinner, true, i18n.T("Upgraded %s from %s to %s", exePath, current, rel.TagName))
+	return nil
+}
@@ -6,11 +6,19 @@ This file contains all commands related to users
 package cli
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 
 	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
 func newUsersCmd() *cobra.Command {
@@ -23,6 +31,9 @@ Examples:
   spreaker users get 12345              # Get a user's profile
   spreaker users shows 12345            # List a user's shows
   spreaker users followers 12345        # List a user's followers
+  spreaker users export-followers 12345 --out followers.csv
+  spreaker users mutuals 12345          # Users who follow each other with 12345
+  spreaker users favorites 12345        # List a user's favorite shows
   spreaker users follow 12345           # Follow a user
   spreaker users block 12345            # Block a user`,
 	}
@@ -33,6 +44,10 @@ Examples:
 		newUsersShowsCmd(),
 		newUsersFollowersCmd(),
 		newUsersFollowingsCmd(),
+		newUsersFavoritesCmd(),
+		newUsersExportFollowersCmd(),
+		newUsersMutualsCmd(),
+		newUsersNotFollowingBackCmd(),
 		newUsersFollowCmd(),
 		newUsersUnfollowCmd(),
 		newUsersBlocksCmd(),
@@ -112,7 +127,7 @@ func runUsersUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current user ID from cached config
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -147,7 +162,7 @@ func runUsersUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess("Profile updated")
+	formatter.PrintSuccess(i18n.T("Profile updated"))
 	formatter.PrintUser(user)
 	return nil
 }
@@ -188,15 +203,16 @@ func runUsersShows(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No shows found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No shows found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintShows(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more shows available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more shows available, use --limit to see more)"))
 	}
 
 	return nil
@@ -238,20 +254,322 @@ func runUsersFollowers(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No followers found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No followers found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintUsers(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more followers available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more followers available, use --limit to see more)"))
 	}
 
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// users export-followers
+// -----------------------------------------------------------------------------
+
+// usersCSVHeader is the header row of user CSV exports
+// (users export-followers, episodes liked-by --csv).
+var usersCSVHeader = []string{"user_id", "username", "fullname", "followers_count", "followings_count", "profile_url"}
+
+func newUsersExportFollowersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-followers <user-id>",
+		Short: "Export all of a user's followers to CSV",
+		Long: `Export a user's complete follower list to a CSV file.
+
+Every page of followers is fetched and written as it arrives, one row per
+follower with user ID, username, full name, follower counts and profile URL.
+
+With --resume, an interrupted export continues where it stopped: followers
+already in the file are kept and skipped, and new rows are appended.
+
+Examples:
+  spreaker users export-followers 12345 --out followers.csv
+  spreaker users export-followers 12345 --out followers.csv --resume`,
+		Args: cobra.ExactArgs(1),
+		RunE: runUsersExportFollowers,
+	}
+
+	cmd.Flags().String("out", "followers.csv", "Path of the CSV file to write")
+	cmd.Flags().Bool("resume", false, "Continue an interrupted export, appending to the existing file")
+
+	return cmd
+}
+
+func runUsersExportFollowers(cmd *cobra.Command, args []string) error {
+	userID, err := parseUserID(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	outPath, _ := cmd.Flags().GetString("out")
+	resume, _ := cmd.Flags().GetBool("resume")
+
+	seen := make(map[int]bool)
+	if resume {
+		seen, err = readExportedUserIDs(outPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(outPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outPath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", outPath, err)
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		if err := w.Write(usersCSVHeader); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outPath, err)
+		}
+	}
+
+	formatter := getFormatter(cmd)
+	if len(seen) > 0 {
+		formatter.PrintMessage(i18n.T("Resuming export after %d followers", len(seen)))
+	}
+
+	// Followers already in the file are skipped via the offset; seen guards
+	// against duplicates when the list shifted since the last run.
+	written := 0
+	offset := len(seen)
+	for {
+		result, err := client.GetUserFollowers(userID, api.PaginationParams{Limit: api.MaxPageLimit, Offset: offset})
+		if err != nil {
+			return fmt.Errorf("failed to fetch followers: %w", err)
+		}
+
+		for _, u := range result.Items {
+			if seen[u.UserID] {
+				continue
+			}
+			seen[u.UserID] = true
+			if err := w.Write(userCSVRow(u)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outPath, err)
+			}
+			written++
+		}
+
+		// Flush every page so an interrupted export can be resumed.
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outPath, err)
+		}
+
+		offset += len(result.Items)
+		if !result.HasMore || len(result.Items) == 0 {
+			break
+		}
+		formatter.PrintMessage(i18n.T("Exported %d followers...", len(seen)))
+	}
+
+	formatter.PrintSuccess(i18n.T("Exported %d followers to %s (%d total)", written, outPath, len(seen)))
+	return nil
+}
+
+func userCSVRow(u models.User) []string {
+	return []string{
+		strconv.Itoa(u.UserID),
+		u.Username,
+		u.Fullname,
+		strconv.Itoa(u.FollowersCount),
+		strconv.Itoa(u.FollowingsCount),
+		u.SiteURL,
+	}
+}
+
+// writeUsersCSV writes users to a new CSV file at path, replacing any
+// existing file.
+func writeUsersCSV(path string, users []models.User) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(usersCSVHeader)
+	for _, u := range users {
+		w.Write(userCSVRow(u))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// readExportedUserIDs returns the user IDs already present in a followers CSV.
+// A missing file yields an empty set.
+func readExportedUserIDs(path string) (map[int]bool, error) {
+	ids := make(map[int]bool)
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(usersCSVHeader)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot resume from %s: %w", path, err)
+		}
+		if line == 1 && record[0] == usersCSVHeader[0] {
+			continue
+		}
+		id, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("cannot resume from %s: invalid user ID %q on line %d", path, record[0], line)
+		}
+		ids[id] = true
+	}
+
+	return ids, nil
+}
+
+// -----------------------------------------------------------------------------
+// users mutuals / users not-following-back
+// -----------------------------------------------------------------------------
+
+func newUsersMutualsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "mutuals [user-id]",
+		Short: "List users who follow and are followed by a user",
+		Long: `List the users that both follow and are followed by a user.
+Defaults to the logged-in user when no user ID is given.
+
+The complete follower and following lists are fetched, so this can take
+a while for large accounts.
+
+Examples:
+  spreaker users mutuals
+  spreaker users mutuals 12345`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsersSocialGraph(cmd, args, mutualUsers, i18n.T("No mutual followers found."))
+		},
+	}
+}
+
+func newUsersNotFollowingBackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "not-following-back [user-id]",
+		Short: "List users a user follows who don't follow back",
+		Long: `List the users that a user follows but who do not follow them back.
+Defaults to the logged-in user when no user ID is given.
+
+The complete follower and following lists are fetched, so this can take
+a while for large accounts.
+
+Examples:
+  spreaker users not-following-back
+  spreaker users not-following-back 12345`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsersSocialGraph(cmd, args, notFollowingBack, i18n.T("Everyone followed follows back."))
+		},
+	}
+}
+
+// runUsersSocialGraph fetches a user's followers and followings and prints
+// the users selected by combine.
+func runUsersSocialGraph(cmd *cobra.Command, args []string, combine func(followers, followings []models.User) []models.User, emptyMsg string) error {
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	var userID int
+	if len(args) == 1 {
+		userID, err = parseUserID(args[0])
+	} else {
+		userID, err = getMyUserID(cmd, client)
+	}
+	if err != nil {
+		return err
+	}
+
+	followers, followings, err := client.GetUserSocialGraph(userID)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+
+	users := combine(followers, followings)
+	if len(users) == 0 {
+		formatter.PrintMessage(emptyMsg)
+		return nil
+	}
+
+	formatter.PrintUsers(users)
+	return nil
+}
+
+// mutualUsers returns the followings that also appear among the followers,
+// in following order.
+func mutualUsers(followers, followings []models.User) []models.User {
+	ids := userIDSet(followers)
+	var users []models.User
+	for _, u := range followings {
+		if ids[u.UserID] {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+// notFollowingBack returns the followings that do not appear among the followers.
+func notFollowingBack(followers, followings []models.User) []models.User {
+	ids := userIDSet(followers)
+	var users []models.User
+	for _, u := range followings {
+		if !ids[u.UserID] {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+func userIDSet(users []models.User) map[int]bool {
+	ids := make(map[int]bool, len(users))
+	for _, u := range users {
+		ids[u.UserID] = true
+	}
+	return ids
+}
+
 // -----------------------------------------------------------------------------
 // users followings
 // -----------------------------------------------------------------------------
@@ -288,20 +606,171 @@ func runUsersFollowings(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No followings found.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No followings found."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintUsers(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more users available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more users available, use --limit to see more)"))
 	}
 
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// users favorites
+// -----------------------------------------------------------------------------
+
+func newUsersFavoritesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "favorites <user-id>",
+		Short: "List a user's favorite shows",
+		Long: `List the shows a user has added to their favorites.
+
+With --select, pick shows from the list that are not among your favorites
+yet and add them to yours. --select needs a terminal; it cannot be used
+with --no-input.
+
+Examples:
+  spreaker users favorites 12345
+  spreaker users favorites 12345 --all --output json
+  spreaker users favorites 12345 --all --select`,
+		Args: cobra.ExactArgs(1),
+		RunE: runUsersFavorites,
+	}
+
+	cmd.Flags().IntP("limit", "l", 20, "Maximum number of shows to list")
+	cmd.Flags().Bool("all", false, "Fetch all shows, following pagination")
+	cmd.Flags().BoolP("select", "s", false, "Pick shows from the list and add them to your favorites")
+
+	return cmd
+}
+
+func runUsersFavorites(cmd *cobra.Command, args []string) error {
+	userID, err := parseUserID(args[0])
+	if err != nil {
+		return err
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	selectShows, _ := cmd.Flags().GetBool("select")
+	if noInput, _ := cmd.Flags().GetBool("no-input"); selectShows && (noInput || !isInteractive()) {
+		return errors.New("--select needs a terminal to pick shows")
+	}
+
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	formatter := getFormatter(cmd)
+
+	var shows []models.Show
+	var partial error
+	hasMore := false
+	if all {
+		shows, err = client.GetAllFavoriteShows(userID)
+		if partial, err = splitPartial(err); err != nil {
+			return err
+		}
+	} else {
+		result, err := client.GetFavoriteShows(userID, api.PaginationParams{Limit: limit})
+		if err != nil {
+			return err
+		}
+		shows, hasMore = result.Items, result.HasMore
+		formatter.SetPage(result.NextURL, result.HasMore)
+	}
+
+	if len(shows) == 0 && (selectShows || !formatter.IsJSON()) {
+		formatter.PrintMessage(i18n.T("No favorite shows."))
+		return partial
+	}
+
+	if !selectShows {
+		formatter.PrintShows(shows)
+		if hasMore && !formatter.WithMeta() {
+			formatter.PrintMessage(i18n.T("\n(more shows available, use --limit or --all to see more)"))
+		}
+		return partial
+	}
+
+	myID, err := getMyUserID(cmd, client)
+	if err != nil {
+		return err
+	}
+	mine, err := client.GetAllFavoriteShows(myID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch your favorite shows: %w", err)
+	}
+	candidates := notFavorited(shows, mine)
+	if len(candidates) == 0 {
+		formatter.PrintMessage(i18n.T("All %d shows are among your favorites already.", len(shows)))
+		return partial
+	}
+
+	chosen, err := chooseShows(candidates)
+	if err != nil {
+		return err
+	}
+	if len(chosen) == 0 {
+		formatter.PrintMessage(i18n.T("No shows chosen."))
+		return partial
+	}
+	for i, show := range chosen {
+		if err := client.AddShowToFavorites(myID, show.ShowID); err != nil {
+			return fmt.Errorf("added %d of %d shows to favorites: %w", i, len(chosen), err)
+		}
+	}
+
+	formatter.PrintSuccess(i18n.T("Added %d shows to your favorites", len(chosen)))
+	return partial
+}
+
+// notFavorited returns the shows that are not among mine, in order.
+func notFavorited(shows, mine []models.Show) []models.Show {
+	have := make(map[int]bool, len(mine))
+	for _, s := range mine {
+		have[s.ShowID] = true
+	}
+	var out []models.Show
+	for _, s := range shows {
+		if !have[s.ShowID] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// chooseShows lets the user pick shows to add to their favorites, none
+// selected by default.
+func chooseShows(shows []models.Show) ([]models.Show, error) {
+	options := make([]string, len(shows))
+	byOption := make(map[string]models.Show, len(shows))
+	for i, s := range shows {
+		options[i] = fmt.Sprintf("%-9d %s", s.ShowID, s.Title)
+		byOption[options[i]] = s
+	}
+	chosen, err := pterm.DefaultInteractiveMultiselect.
+		WithOptions(options).
+		WithMaxHeight(15).
+		Show(i18n.T("Select the shows to add to your favorites"))
+	if err != nil {
+		return nil, fmt.Errorf("show selection cancelled: %w", err)
+	}
+
+	picked := make([]models.Show, 0, len(chosen))
+	for _, option := range chosen {
+		picked = append(picked, byOption[option])
+	}
+	return picked, nil
+}
+
 // -----------------------------------------------------------------------------
 // users follow
 // -----------------------------------------------------------------------------
@@ -326,7 +795,7 @@ func runUsersFollow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -336,7 +805,7 @@ func runUsersFollow(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Now following user %d", followingID))
+	formatter.PrintSuccess(i18n.T("Now following user %d", followingID))
 	return nil
 }
 
@@ -364,7 +833,7 @@ func runUsersUnfollow(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -374,7 +843,7 @@ func runUsersUnfollow(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Unfollowed user %d", followingID))
+	formatter.PrintSuccess(i18n.T("Unfollowed user %d", followingID))
 	return nil
 }
 
@@ -400,7 +869,7 @@ func runUsersBlocks(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -413,15 +882,16 @@ func runUsersBlocks(cmd *cobra.Command, args []string) error {
 
 	formatter := getFormatter(cmd)
 
-	if len(result.Items) == 0 {
-		formatter.PrintMessage("No blocked users.")
+	if len(result.Items) == 0 && !formatter.IsJSON() {
+		formatter.PrintMessage(i18n.T("No blocked users."))
 		return nil
 	}
 
+	formatter.SetPage(result.NextURL, result.HasMore)
 	formatter.PrintUsers(result.Items)
 
-	if result.HasMore {
-		formatter.PrintMessage("\n(more users available, use --limit to see more)")
+	if result.HasMore && !formatter.WithMeta() {
+		formatter.PrintMessage(i18n.T("\n(more users available, use --limit to see more)"))
 	}
 
 	return nil
@@ -451,7 +921,7 @@ func runUsersBlock(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -461,7 +931,7 @@ func runUsersBlock(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Blocked user %d", blockedID))
+	formatter.PrintSuccess(i18n.T("Blocked user %d", blockedID))
 	return nil
 }
 
@@ -489,7 +959,7 @@ func runUsersUnblock(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	userID, err := getMyUserID()
+	userID, err := getMyUserID(cmd, client)
 	if err != nil {
 		return err
 	}
@@ -499,6 +969,6 @@ func runUsersUnblock(cmd *cobra.Command, args []string) error {
 	}
 
 	formatter := getFormatter(cmd)
-	formatter.PrintSuccess(fmt.Sprintf("Unblocked user %d", blockedID))
+	formatter.PrintSuccess(i18n.T("Unblocked user %d", blockedID))
 	return nil
 }
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// ---------------------------------------------------------------------------
+// readExportedUserIDs
+// ---------------------------------------------------------------------------
+
+func TestReadExportedUserIDs(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file", func(t *testing.T) {
+		ids, err := readExportedUserIDs(filepath.Join(dir, "missing.csv"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 0 {
+			t.Errorf("got %d IDs, want 0", len(ids))
+		}
+	})
+
+	t.Run("header and rows", func(t *testing.T) {
+		path := filepath.Join(dir, "followers.csv")
+		data := "user_id,username,fullname,followers_count,followings_count,profile_url\n" +
+			"1,alice,Alice,10,2,https://www.spreaker.com/user/alice\n" +
+			"2,bob,\"Bob, Jr.\",0,0,https://www.spreaker.com/user/bob\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		ids, err := readExportedUserIDs(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 2 || !ids[1] || !ids[2] {
+			t.Errorf("ids = %v, want {1, 2}", ids)
+		}
+	})
+
+	t.Run("truncated row", func(t *testing.T) {
+		path := filepath.Join(dir, "broken.csv")
+		data := "user_id,username,fullname,followers_count,followings_count,profile_url\n1,ali\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := readExportedUserIDs(path); err == nil {
+			t.Fatal("expected error for truncated row")
+		}
+	})
+}
+
+// ---------------------------------------------------------------------------
+// writeUsersCSV
+// ---------------------------------------------------------------------------
+
+func TestWriteUsersCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "likers.csv")
+	users := []models.User{
+		{UserID: 1, Username: "alice", Fullname: "Alice"},
+		{UserID: 2, Username: "bob", Fullname: "Bob, Jr."},
+	}
+
+	if err := writeUsersCSV(path, users); err != nil {
+		t.Fatalf("writeUsersCSV() error = %v", err)
+	}
+
+	ids, err := readExportedUserIDs(path)
+	if err != nil {
+		t.Fatalf("readExportedUserIDs() error = %v", err)
+	}
+	if len(ids) != 2 || !ids[1] || !ids[2] {
+		t.Errorf("ids = %v, want {1, 2}", ids)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// mutualUsers / notFollowingBack
+// ---------------------------------------------------------------------------
+
+func TestSocialGraphSets(t *testing.T) {
+	followers := []models.User{{UserID: 1}, {UserID: 2}, {UserID: 3}}
+	followings := []models.User{{UserID: 3}, {UserID: 4}, {UserID: 1}}
+
+	ids := func(users []models.User) []int {
+		var out []int
+		for _, u := range users {
+			out = append(out, u.UserID)
+		}
+		return out
+	}
+
+	if got := ids(mutualUsers(followers, followings)); !reflect.DeepEqual(got, []int{3, 1}) {
+		t.Errorf("mutualUsers = %v, want [3 1]", got)
+	}
+	if got := ids(notFollowingBack(followers, followings)); !reflect.DeepEqual(got, []int{4}) {
+		t.Errorf("notFollowingBack = %v, want [4]", got)
+	}
+	if got := mutualUsers(nil, followings); len(got) != 0 {
+		t.Errorf("mutualUsers with no followers = %v, want empty", got)
+	}
+}
+
+func TestNotFavorited(t *testing.T) {
+	shows := []models.Show{{ShowID: 1}, {ShowID: 2}, {ShowID: 3}}
+	mine := []models.Show{{ShowID: 2}, {ShowID: 9}}
+
+	var got []int
+	for _, s := range notFavorited(shows, mine) {
+		got = append(got, s.ShowID)
+	}
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("notFavorited = %v, want [1 3]", got)
+	}
+	if got := notFavorited(shows[1:2], mine); len(got) != 0 {
+		t.Errorf("notFavorited of a favorite = %v, want empty", got)
+	}
+}
+
+func TestUsersFavoritesSelectNeedsTerminal(t *testing.T) {
+	cmd := newRootCmd("test")
+	cmd.SetArgs([]string{"users", "favorites", "12345", "--select", "--no-input"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if err := cmd.Execute(); err == nil || err.Error() != "--select needs a terminal to pick shows" {
+		t.Errorf("error = %v, want --select needs a terminal", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+version.go - Version command
+
+version prints build information and checks that the API version the
+client is pinned to is still served.
+*/
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/buildinfo"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+)
+
+func newVersionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version, build and API compatibility information",
+		Long: `Show the CLI version, commit, build date and Go version, then probe the
+Spreaker API to check that the pinned API version (v2) is still current.
+
+The probe needs no token. It reports the API version the server announces,
+if any, and whether the pinned paths are deprecated (Deprecation and Sunset
+headers) or no longer answer.
+
+Examples:
+  spreaker version
+  spreaker version --offline   # build information only
+  spreaker version -o json`,
+		Args: cobra.NoArgs,
+		RunE: runVersion,
+	}
+
+	cmd.Flags().Bool("offline", false, "Do not probe the API")
+
+	return cmd
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	offline, _ := cmd.Flags().GetBool("offline")
+
+	formatter := getFormatter(cmd)
+	info := buildinfo.Read(cmd.Root().Version)
+
+	var compat *api.APICompatibility
+	if !offline {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		compat, err = api.NewClientWithOptions("", cfg.APIURL, 0).CheckCompatibility()
+		if err != nil {
+			// Build information is still useful without a network.
+			formatter.PrintWarning(i18n.T("Could not reach the API: %v", err))
+		}
+	}
+
+	formatter.PrintVersion(info, compat)
+
+	if compat != nil && !compat.Current {
+		formatter.PrintWarning(i18n.T("The API no longer fully supports %s; check for a newer release with 'spreaker upgrade --check'", compat.PinnedVersion))
+	}
+	return nil
+}
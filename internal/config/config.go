@@ -5,32 +5,255 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // For Viper map config file keys
 type Config struct {
-	Token string `mapstructure:"token"`
+	Token string `mapstructure:"token" desc:"API token (set by login)"`
+
+	// RotationTokens are further tokens of the same account. Requests
+	// rotate over them and Token, moving on when one is rate limited.
+	RotationTokens []string `mapstructure:"rotation_tokens" desc:"Further tokens of the same account, used in turn when one is rate limited"`
 
 	// UserID is the authenticated user's ID, cached at login time.
-	UserID int `mapstructure:"user_id"`
+	UserID int `mapstructure:"user_id" desc:"Authenticated user ID (set by login)"`
+
+	// IdentityTTL is how long the account a --token or SPREAKER_TOKEN acts
+	// as stays cached, as a Go duration such as 24h; 0 asks the API each run.
+	IdentityTTL string `mapstructure:"identity_ttl" desc:"How long the account of a --token or SPREAKER_TOKEN stays cached, e.g. 24h (0: ask the API each run)"`
+
+	DefaultShowID int `mapstructure:"default_show_id" desc:"Show used when no show ID is given"`
+
+	// NetworkShows are the shows the network commands cover; empty means
+	// all of the user's shows.
+	NetworkShows []int `mapstructure:"network_shows" desc:"Shows covered by the network commands (default: all your shows)"`
+
+	// OutputFormat controls how results are displayed: "table", "wide", "json", "plain", "ci"
+	OutputFormat string `mapstructure:"output_format" desc:"Output format: table, wide, json, plain, ci"`
+
+	APIURL string `mapstructure:"api_url" desc:"API base URL (for debugging/testing)"`
+
+	// Language selects the language of CLI messages and table headers: "en", "it"
+	Language string `mapstructure:"language" desc:"Language for messages and table headers: en, it"`
+
+	// Locale selects the language of localized API data, such as category
+	// and language names, e.g. "it_IT". It is independent of Language.
+	Locale string `mapstructure:"locale" desc:"Locale of API data such as category and language names, e.g. it_IT"`
+
+	// DateFormat is the Go time layout used for dates in tables (default "2006-01-02 15:04:05").
+	DateFormat string `mapstructure:"date_format" desc:"Go time layout for dates in tables"`
+
+	// RelativeDates renders dates in tables as "3 days ago" instead of using DateFormat.
+	RelativeDates bool `mapstructure:"relative_dates" desc:"Show dates in tables as \"3 days ago\""`
+
+	// Truncate shortens long text, such as titles, in table cells; false
+	// always shows it in full, as the wide output format does.
+	Truncate bool `mapstructure:"truncate" desc:"Truncate long text in table cells; false shows it in full"`
+
+	// MaxWidth is the width long text is truncated at in table cells.
+	// 0 uses each column's default width, grown to fit wide terminals.
+	MaxWidth int `mapstructure:"max_width" desc:"Width long text is truncated at in table cells (0: fit the terminal)"`
+
+	// NumberFormat is how counts such as plays are written in tables:
+	// raw (1234567, the default), grouped (1,234,567) or human (1.2M).
+	NumberFormat string `mapstructure:"number_format" desc:"Counts in tables: raw, grouped (1,234,567) or human (1.2M)"`
+
+	// Timezone is the IANA zone dates are shown in, and stats --from/--to
+	// dates are given in, or "local" for the system zone.
+	// Empty keeps each field's default (UTC for API timestamps).
+	Timezone string `mapstructure:"timezone" desc:"Time zone for dates in tables and stats date ranges: an IANA name or local"`
+
+	// Pager is the program long table output is piped through on a terminal.
+	// Empty uses $PAGER, then less; "off" disables paging.
+	Pager string `mapstructure:"pager" desc:"Program used to page long table output; off disables paging"`
+
+	// ReadOnly makes the client refuse every API request that could change
+	// data, e.g. for shared analytics tokens or demo environments.
+	ReadOnly bool `mapstructure:"read_only" desc:"Refuse API requests that change data (POST, PUT, DELETE)"`
+
+	// OfflineQueue queues messages, likes, favorites and metadata updates
+	// that fail because the API is unreachable, for "queue flush".
+	OfflineQueue bool `mapstructure:"offline_queue" desc:"Queue changes made while the API is unreachable, for 'spreaker queue flush'"`
+
+	Hooks HooksConfig `mapstructure:"hooks"`
+
+	// Confirm sets which kinds of commands ask before changing data.
+	Confirm ConfirmConfig `mapstructure:"confirm"`
+
+	// SMTP is the mail server "report weekly --smtp" sends digests through.
+	SMTP SMTPConfig `mapstructure:"smtp"`
 
-	DefaultShowID int `mapstructure:"default_show_id"`
+	// Transcribe configures "episodes transcribe".
+	Transcribe TranscribeConfig `mapstructure:"transcribe"`
 
-	// OutputFormat controls how results are displayed: "table", "json", "plain"
-	OutputFormat string `mapstructure:"output_format"`
+	// Shows holds per-show upload defaults, keyed by show ID.
+	Shows map[string]ShowDefaults `mapstructure:"shows" key:"show-id"`
 
-	APIURL string `mapstructure:"api_url"`
+	// Campaigns holds named date windows for statistics, keyed by name.
+	Campaigns map[string]Campaign `mapstructure:"campaigns" key:"name"`
+
+	// CustomHeaders are added to every API request, keyed by header name,
+	// e.g. tenant or tracing headers a gateway requires.
+	CustomHeaders map[string]string `mapstructure:"custom_headers" key:"header" desc:"Header added to every API request"`
+}
+
+// HooksConfig holds user-defined actions triggered by CLI events.
+// Each value is either a shell command or an http(s) webhook URL.
+type HooksConfig struct {
+	// EpisodePublished runs after an episode is successfully uploaded or published.
+	EpisodePublished string `mapstructure:"episode_published" desc:"Shell command or webhook URL run after an episode upload"`
+}
+
+// Confirmation policies: ask yes or no, don't ask, or have the user type
+// the title of the show or episode, like GitHub asks before deleting a
+// repository.
+const (
+	ConfirmAlways = "always"
+	ConfirmNever  = "never"
+	ConfirmTitle  = "title"
+)
+
+// ConfirmPolicies are the accepted confirm.* values.
+var ConfirmPolicies = []string{ConfirmAlways, ConfirmNever, ConfirmTitle}
+
+// ConfirmConfig holds the confirmation policy of each kind of command.
+// --force skips the confirmation whatever the policy.
+type ConfirmConfig struct {
+	Delete string `mapstructure:"delete" desc:"Confirm deletions: always, never, or title (type the show or episode title)"`
+	Update string `mapstructure:"update" desc:"Confirm show and episode updates: always, never, or title"`
+	Upload string `mapstructure:"upload" desc:"Confirm episode uploads and publishes: always, never, or title"`
+}
+
+// Policy returns the policy of an action ("delete", "update" or
+// "upload"). Empty values select the default: deletions are confirmed,
+// updates and uploads are not.
+func (c ConfirmConfig) Policy(action string) string {
+	var policy string
+	switch action {
+	case "delete":
+		policy = c.Delete
+		if policy == "" {
+			policy = ConfirmAlways
+		}
+	case "update":
+		policy = c.Update
+	case "upload":
+		policy = c.Upload
+	}
+	if policy == "" {
+		return ConfirmNever
+	}
+	return policy
+}
+
+// IdentityCacheTTL returns identity_ttl as a duration. An empty or invalid
+// value gives the default of a day.
+func (c *Config) IdentityCacheTTL() time.Duration {
+	if c.IdentityTTL == "" {
+		return 24 * time.Hour
+	}
+	ttl, err := time.ParseDuration(c.IdentityTTL)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return ttl
+}
+
+// SMTPConfig holds the mail server and addresses used to email reports.
+// The connection is upgraded with STARTTLS when the server offers it.
+type SMTPConfig struct {
+	Host     string   `mapstructure:"host" desc:"SMTP server host name"`
+	Port     int      `mapstructure:"port" desc:"SMTP server port"`
+	Username string   `mapstructure:"username" desc:"SMTP user name; empty sends without authentication"`
+	Password string   `mapstructure:"password" desc:"SMTP password"`
+	From     string   `mapstructure:"from" desc:"Sender address of report emails"`
+	To       []string `mapstructure:"to" desc:"Recipient addresses of report emails"`
+}
+
+// Transcription engines.
+const (
+	EngineWhisperCpp = "whisper-cpp"
+	EngineOpenAI     = "openai"
+)
+
+// TranscribeEngines are the accepted transcribe.engine values.
+var TranscribeEngines = []string{EngineWhisperCpp, EngineOpenAI}
+
+// TranscribeConfig holds the transcription engine "episodes transcribe"
+// uses and its settings: a local whisper.cpp program, or the OpenAI API.
+type TranscribeConfig struct {
+	Engine         string `mapstructure:"engine" desc:"Default transcription engine: whisper-cpp or openai"`
+	Language       string `mapstructure:"language" desc:"Spoken language as an ISO 639-1 code, e.g. it; empty detects it"`
+	WhisperCommand string `mapstructure:"whisper_command" desc:"whisper.cpp program (default: whisper-cli)"`
+	WhisperModel   string `mapstructure:"whisper_model" desc:"Path of the whisper.cpp model file, e.g. ggml-base.bin"`
+	OpenAIKey      string `mapstructure:"openai_api_key" desc:"OpenAI API key (default: $OPENAI_API_KEY)"`
+	OpenAIModel    string `mapstructure:"openai_model" desc:"OpenAI transcription model (default: whisper-1)"`
+}
+
+// ShowDefaults are applied to "episodes upload" for a show unless
+// overridden by flags. Nil booleans leave the flag default in place.
+type ShowDefaults struct {
+	Tags              []string `mapstructure:"tags" desc:"Tags added to every upload"`
+	Explicit          *bool    `mapstructure:"explicit" desc:"Default for --explicit"`
+	Downloadable      *bool    `mapstructure:"downloadable" desc:"Default for --downloadable"`
+	DescriptionFooter string   `mapstructure:"description_footer" desc:"Text appended to every episode description"`
+	Preprocess        []string `mapstructure:"preprocess" desc:"ffmpeg steps run on the audio file before upload"`
+}
+
+// Campaign is a named date window, such as a launch or an ad push, that
+// "stats ... --campaign" uses instead of --from and --to.
+type Campaign struct {
+	From string `mapstructure:"from" desc:"First day of the campaign (YYYY-MM-DD)"`
+	To   string `mapstructure:"to" desc:"Last day of the campaign (YYYY-MM-DD)"`
+}
+
+// Window parses the campaign's dates. Both must be set, and From must not
+// be after To.
+func (c Campaign) Window() (from, to time.Time, err error) {
+	if c.From == "" || c.To == "" {
+		return from, to, errors.New("campaign needs both from and to dates")
+	}
+	if from, err = time.Parse(time.DateOnly, c.From); err != nil {
+		return from, to, fmt.Errorf("invalid from date %q (must be YYYY-MM-DD)", c.From)
+	}
+	if to, err = time.Parse(time.DateOnly, c.To); err != nil {
+		return from, to, fmt.Errorf("invalid to date %q (must be YYYY-MM-DD)", c.To)
+	}
+	if from.After(to) {
+		return from, to, fmt.Errorf("from date %s is after to date %s", c.From, c.To)
+	}
+	return from, to, nil
+}
+
+// CampaignNamed returns the campaign with the given name. Names are not
+// case-sensitive, as the config file stores them in lower case.
+func (c *Config) CampaignNamed(name string) (Campaign, bool) {
+	campaign, ok := c.Campaigns[strings.ToLower(name)]
+	return campaign, ok
+}
+
+// ShowDefaultsFor returns the upload defaults configured for a show.
+func (c *Config) ShowDefaultsFor(showID int) ShowDefaults {
+	return c.Shows[strconv.Itoa(showID)]
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		Token:         "",
 		UserID:        0,
+		IdentityTTL:   "24h",
 		DefaultShowID: 0,
 		OutputFormat:  "table",
 		APIURL:        "https://api.spreaker.com",
+		Language:      "en",
+		Truncate:      true,
+		SMTP:          SMTPConfig{Port: 587},
+		Confirm:       ConfirmConfig{Delete: ConfirmAlways, Update: ConfirmNever, Upload: ConfirmNever},
 	}
 }
 
@@ -82,10 +305,29 @@ func Load() (*Config, error) {
 	viper.AutomaticEnv() 
 
 	viper.SetDefault("token", cfg.Token)
+	viper.SetDefault("rotation_tokens", cfg.RotationTokens)
 	viper.SetDefault("user_id", cfg.UserID)
+	viper.SetDefault("identity_ttl", cfg.IdentityTTL)
 	viper.SetDefault("default_show_id", cfg.DefaultShowID)
+	viper.SetDefault("network_shows", cfg.NetworkShows)
 	viper.SetDefault("output_format", cfg.OutputFormat)
 	viper.SetDefault("api_url", cfg.APIURL)
+	viper.SetDefault("language", cfg.Language)
+	viper.SetDefault("locale", cfg.Locale)
+	viper.SetDefault("date_format", cfg.DateFormat)
+	viper.SetDefault("relative_dates", cfg.RelativeDates)
+	viper.SetDefault("truncate", cfg.Truncate)
+	viper.SetDefault("max_width", cfg.MaxWidth)
+	viper.SetDefault("number_format", cfg.NumberFormat)
+	viper.SetDefault("timezone", cfg.Timezone)
+	viper.SetDefault("read_only", cfg.ReadOnly)
+	viper.SetDefault("offline_queue", cfg.OfflineQueue)
+	viper.SetDefault("smtp.port", cfg.SMTP.Port)
+	viper.SetDefault("pager", cfg.Pager)
+	viper.SetDefault("hooks.episode_published", cfg.Hooks.EpisodePublished)
+	viper.SetDefault("confirm.delete", cfg.Confirm.Delete)
+	viper.SetDefault("confirm.update", cfg.Confirm.Update)
+	viper.SetDefault("confirm.upload", cfg.Confirm.Upload)
 
 	// Try to read the config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -97,6 +339,14 @@ func Load() (*Config, error) {
 		// File not found is fine, continue with defaults + env vars
 	}
 
+	// Unquoted YAML dates such as "from: 2024-03-01" are read as timestamps;
+	// campaign dates are kept as YYYY-MM-DD strings.
+	for _, key := range viper.AllKeys() {
+		if t, ok := viper.Get(key).(time.Time); ok && strings.HasPrefix(key, "campaigns.") {
+			viper.Set(key, t.Format(time.DateOnly))
+		}
+	}
+
 	// Unmarshal the combined configuration into our struct
 	if err := viper.Unmarshal(cfg); err != nil {
 		return cfg, fmt.Errorf("error parsing config: %w", err)
@@ -105,8 +355,15 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// Save writes the given configuration to the config file.
+// Save writes the given configuration to the config file. It refuses to
+// write a configuration that fails Validate.
 func Save(cfg *Config) error {
+	for _, p := range cfg.Validate() {
+		if p.Severity == SeverityError {
+			return fmt.Errorf("invalid config: %s", p)
+		}
+	}
+
 	dir, err := configDir()
 	if err != nil {
 		return err
@@ -117,11 +374,68 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("could not create config directory: %w", err)
 	}
 
-	viper.Set("token", cfg.Token)
-	viper.Set("user_id", cfg.UserID)
-	viper.Set("default_show_id", cfg.DefaultShowID)
-	viper.Set("output_format", cfg.OutputFormat)
-	viper.Set("api_url", cfg.APIURL)
+	// A fresh instance, so keys removed from cfg (e.g. by Unset) are not
+	// written back from the file read by Load.
+	v := viper.New()
+	v.Set("token", cfg.Token)
+	v.Set("rotation_tokens", cfg.RotationTokens)
+	v.Set("user_id", cfg.UserID)
+	v.Set("identity_ttl", cfg.IdentityTTL)
+	v.Set("default_show_id", cfg.DefaultShowID)
+	v.Set("network_shows", cfg.NetworkShows)
+	v.Set("output_format", cfg.OutputFormat)
+	v.Set("api_url", cfg.APIURL)
+	v.Set("language", cfg.Language)
+	v.Set("locale", cfg.Locale)
+	v.Set("date_format", cfg.DateFormat)
+	v.Set("relative_dates", cfg.RelativeDates)
+	v.Set("truncate", cfg.Truncate)
+	v.Set("max_width", cfg.MaxWidth)
+	v.Set("number_format", cfg.NumberFormat)
+	v.Set("timezone", cfg.Timezone)
+	v.Set("pager", cfg.Pager)
+	v.Set("read_only", cfg.ReadOnly)
+	v.Set("offline_queue", cfg.OfflineQueue)
+	v.Set("hooks.episode_published", cfg.Hooks.EpisodePublished)
+	v.Set("confirm.delete", cfg.Confirm.Delete)
+	v.Set("confirm.update", cfg.Confirm.Update)
+	v.Set("confirm.upload", cfg.Confirm.Upload)
+	v.Set("smtp.host", cfg.SMTP.Host)
+	v.Set("smtp.port", cfg.SMTP.Port)
+	v.Set("smtp.username", cfg.SMTP.Username)
+	v.Set("smtp.password", cfg.SMTP.Password)
+	v.Set("smtp.from", cfg.SMTP.From)
+	v.Set("smtp.to", cfg.SMTP.To)
+	v.Set("transcribe.engine", cfg.Transcribe.Engine)
+	v.Set("transcribe.language", cfg.Transcribe.Language)
+	v.Set("transcribe.whisper_command", cfg.Transcribe.WhisperCommand)
+	v.Set("transcribe.whisper_model", cfg.Transcribe.WhisperModel)
+	v.Set("transcribe.openai_api_key", cfg.Transcribe.OpenAIKey)
+	v.Set("transcribe.openai_model", cfg.Transcribe.OpenAIModel)
+
+	// Set show defaults key by key so the YAML keys match the mapstructure tags.
+	for id, d := range cfg.Shows {
+		prefix := "shows." + id + "."
+		v.Set(prefix+"tags", d.Tags)
+		v.Set(prefix+"description_footer", d.DescriptionFooter)
+		v.Set(prefix+"preprocess", d.Preprocess)
+		if d.Explicit != nil {
+			v.Set(prefix+"explicit", *d.Explicit)
+		}
+		if d.Downloadable != nil {
+			v.Set(prefix+"downloadable", *d.Downloadable)
+		}
+	}
+
+	for name, c := range cfg.Campaigns {
+		prefix := "campaigns." + name + "."
+		v.Set(prefix+"from", c.From)
+		v.Set(prefix+"to", c.To)
+	}
+
+	for name, value := range cfg.CustomHeaders {
+		v.Set("custom_headers."+name, value)
+	}
 
 	configPath, err := configFilePath()
 	if err != nil {
@@ -136,7 +450,7 @@ func Save(cfg *Config) error {
 	}
 	defer f.Close()
 
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	if err := v.WriteConfigAs(configPath); err != nil {
 		return fmt.Errorf("could not write config file: %w", err)
 	}
 
@@ -186,3 +500,13 @@ func ConfigFilePath() string {
 	}
 	return path
 }
+
+// DataFilePath returns the path of a file kept next to the config file,
+// such as state saved by long-running commands.
+func DataFilePath(name string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -29,17 +31,43 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestConfirmConfig_Policy(t *testing.T) {
+	var empty ConfirmConfig
+	if got := empty.Policy("delete"); got != ConfirmAlways {
+		t.Errorf("default delete policy = %q, want %q", got, ConfirmAlways)
+	}
+	if got := empty.Policy("upload"); got != ConfirmNever {
+		t.Errorf("default upload policy = %q, want %q", got, ConfirmNever)
+	}
+
+	c := ConfirmConfig{Delete: ConfirmTitle, Update: ConfirmAlways}
+	if got := c.Policy("delete"); got != ConfirmTitle {
+		t.Errorf("delete policy = %q, want %q", got, ConfirmTitle)
+	}
+	if got := c.Policy("update"); got != ConfirmAlways {
+		t.Errorf("update policy = %q, want %q", got, ConfirmAlways)
+	}
+}
+
 func TestSaveAndLoad_RoundTrip(t *testing.T) {
 	resetViper()
 	tmpDir := t.TempDir()
 	t.Setenv("SPREAKER_CONFIG_DIR", tmpDir)
 
+	explicit := true
 	original := &Config{
-		Token:         "test-token-123",
-		UserID:        42,
-		DefaultShowID: 99,
-		OutputFormat:  "json",
-		APIURL:        "https://custom.api.com",
+		Token:          "test-token-123",
+		RotationTokens: []string{"spare-1", "spare-2"},
+		UserID:         42,
+		DefaultShowID:  99,
+		OutputFormat:   "json",
+		APIURL:         "https://custom.api.com",
+		Hooks:          HooksConfig{EpisodePublished: "echo published"},
+		Shows: map[string]ShowDefaults{
+			"12345": {Tags: []string{"tech", "news"}, Explicit: &explicit, DescriptionFooter: "Support us!"},
+		},
+		NetworkShows:  []int{12345, 678},
+		CustomHeaders: map[string]string{"x-tenant": "acme"},
 	}
 
 	if err := Save(original); err != nil {
@@ -55,6 +83,9 @@ func TestSaveAndLoad_RoundTrip(t *testing.T) {
 	if loaded.Token != original.Token {
 		t.Errorf("Token = %q, want %q", loaded.Token, original.Token)
 	}
+	if !reflect.DeepEqual(loaded.RotationTokens, original.RotationTokens) {
+		t.Errorf("RotationTokens = %v, want %v", loaded.RotationTokens, original.RotationTokens)
+	}
 	if loaded.UserID != original.UserID {
 		t.Errorf("UserID = %d, want %d", loaded.UserID, original.UserID)
 	}
@@ -67,6 +98,30 @@ func TestSaveAndLoad_RoundTrip(t *testing.T) {
 	if loaded.APIURL != original.APIURL {
 		t.Errorf("APIURL = %q, want %q", loaded.APIURL, original.APIURL)
 	}
+	if loaded.Hooks.EpisodePublished != original.Hooks.EpisodePublished {
+		t.Errorf("Hooks.EpisodePublished = %q, want %q", loaded.Hooks.EpisodePublished, original.Hooks.EpisodePublished)
+	}
+
+	if !reflect.DeepEqual(loaded.NetworkShows, original.NetworkShows) {
+		t.Errorf("NetworkShows = %v, want %v", loaded.NetworkShows, original.NetworkShows)
+	}
+	if !reflect.DeepEqual(loaded.CustomHeaders, original.CustomHeaders) {
+		t.Errorf("CustomHeaders = %v, want %v", loaded.CustomHeaders, original.CustomHeaders)
+	}
+
+	show := loaded.ShowDefaultsFor(12345)
+	if len(show.Tags) != 2 || show.Tags[1] != "news" {
+		t.Errorf("Shows[12345].Tags = %v, want [tech news]", show.Tags)
+	}
+	if show.Explicit == nil || !*show.Explicit {
+		t.Errorf("Shows[12345].Explicit = %v, want true", show.Explicit)
+	}
+	if show.Downloadable != nil {
+		t.Errorf("Shows[12345].Downloadable = %v, want unset", *show.Downloadable)
+	}
+	if show.DescriptionFooter != "Support us!" {
+		t.Errorf("Shows[12345].DescriptionFooter = %q", show.DescriptionFooter)
+	}
 }
 
 func TestSaveToken_PreservesOtherFields(t *testing.T) {
@@ -183,3 +238,31 @@ func TestConfigDir_RelativePath_Error(t *testing.T) {
 		t.Fatal("expected error for relative SPREAKER_CONFIG_DIR")
 	}
 }
+
+func TestRedactedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yamlData := "token: abc123456\nrotation_tokens: [t1, t2]\nsmtp:\n  host: mail.example.com\n  password: hunter22\ncustom_headers:\n  x-api-key: k\nlanguage: it\n"
+	if err := os.WriteFile(path, []byte(yamlData), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := RedactedFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, secret := range []string{"abc123456", "t1", "hunter22", "x-api-key: k"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("redacted file contains %q:\n%s", secret, out)
+		}
+	}
+	for _, kept := range []string{"mail.example.com", "language: it", "x-api-key: REDACTED"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("redacted file lacks %q:\n%s", kept, out)
+		}
+	}
+
+	if data, err := RedactedFile(filepath.Join(t.TempDir(), "missing.yaml")); data != nil || err != nil {
+		t.Errorf("RedactedFile(missing) = %q, %v", data, err)
+	}
+}
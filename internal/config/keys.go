@@ -0,0 +1,226 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// Key Metadata
+// -----------------------------------------------------------------------------
+
+// ShowKeyPrefix is the documented form of per-show keys, e.g.
+// "shows.<show-id>.tags" stands for "shows.12345.tags".
+const ShowKeyPrefix = "shows.<show-id>."
+
+// CampaignKeyPrefix is the documented form of campaign keys, e.g.
+// "campaigns.<name>.from" stands for "campaigns.launch.from".
+const CampaignKeyPrefix = "campaigns.<name>."
+
+// HeaderKey is the documented form of custom header keys, e.g.
+// "custom_headers.<header>" stands for "custom_headers.X-Tenant".
+const HeaderKey = "custom_headers.<header>"
+
+// valueMaps maps each map-of-values key to the documented form of its keys.
+var valueMaps = map[string]string{
+	"custom_headers": HeaderKey,
+}
+
+// sectionPrefixes maps each map-of-sections key to the documented prefix
+// of its keys.
+var sectionPrefixes = map[string]string{
+	"shows":     ShowKeyPrefix,
+	"campaigns": CampaignKeyPrefix,
+}
+
+// KeyInfo describes a configuration key. The list is generated from the
+// mapstructure and desc tags of Config, so new settings show up in
+// "config keys" and "config validate" without further changes.
+type KeyInfo struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+
+	// index locates the field in Config; nil for keys of map sections
+	// such as shows.<show-id>.
+	index []int
+}
+
+// Keys returns every supported configuration key, in Config field order.
+func Keys() []KeyInfo {
+	var keys []KeyInfo
+	defaults := reflect.ValueOf(*DefaultConfig())
+	collectKeys(reflect.TypeOf(Config{}), defaults, "", []int{}, &keys)
+	return keys
+}
+
+// collectKeys appends the keys of the fields of t. index is nil inside
+// map sections, whose fields are not fields of Config.
+func collectKeys(t reflect.Type, defaults reflect.Value, prefix string, index []int, keys *[]KeyInfo) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("mapstructure")
+		if name == "" {
+			continue
+		}
+		var fieldIndex []int
+		if index != nil {
+			fieldIndex = append(append([]int{}, index...), i)
+		}
+
+		switch {
+		case f.Type.Kind() == reflect.Struct:
+			collectKeys(f.Type, defaults.Field(i), prefix+name+".", fieldIndex, keys)
+		case f.Type.Kind() == reflect.Map && f.Type.Elem().Kind() == reflect.Struct:
+			// The key tag names the placeholder of the section name.
+			sectionPrefix := prefix + name + ".<" + f.Tag.Get("key") + ">."
+			collectKeys(f.Type.Elem(), reflect.Zero(f.Type.Elem()), sectionPrefix, nil, keys)
+		case f.Type.Kind() == reflect.Map:
+			*keys = append(*keys, KeyInfo{
+				Key:         prefix + name + ".<" + f.Tag.Get("key") + ">",
+				Type:        typeName(f.Type.Elem()),
+				Description: f.Tag.Get("desc"),
+			})
+		default:
+			*keys = append(*keys, KeyInfo{
+				Key:         prefix + name,
+				Type:        typeName(f.Type),
+				Default:     defaultString(defaults.Field(i)),
+				Description: f.Tag.Get("desc"),
+				index:       fieldIndex,
+			})
+		}
+	}
+}
+
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64:
+		return "int"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// defaultString formats a default value; unset strings, lists and optional
+// booleans are empty, while numbers and booleans show their zero value.
+func defaultString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Ptr:
+		if v.IsZero() {
+			return ""
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// LookupKey returns the metadata of a key. Keys of map sections may be
+// given with a real section name, e.g. "shows.12345.tags".
+func LookupKey(key string) (KeyInfo, bool) {
+	if parts := strings.Split(key, "."); len(parts) == 3 && sectionPrefixes[parts[0]] != "" {
+		key = sectionPrefixes[parts[0]] + parts[2]
+	} else if len(parts) == 2 && valueMaps[parts[0]] != "" {
+		key = valueMaps[parts[0]]
+	}
+	for _, k := range Keys() {
+		if k.Key == key {
+			return k, true
+		}
+	}
+	return KeyInfo{}, false
+}
+
+// Unset resets a key to its default. "shows.<show-id>.<field>" clears one
+// upload default and "shows.<show-id>" removes all of a show's defaults.
+// "campaigns.<name>" removes a campaign. "custom_headers.<header>" removes
+// one header and "custom_headers" all of them.
+func Unset(cfg *Config, key string) error {
+	if strings.HasPrefix(key, "shows.") {
+		return unsetShowKey(cfg, key)
+	}
+	if strings.HasPrefix(key, "campaigns.") {
+		return unsetCampaign(cfg, key)
+	}
+	if name, ok := strings.CutPrefix(key, "custom_headers"); ok && (name == "" || name[0] == '.') {
+		return unsetHeader(cfg, strings.TrimPrefix(name, "."))
+	}
+
+	info, ok := LookupKey(key)
+	if !ok {
+		return fmt.Errorf("unknown key: %s", key)
+	}
+	def := reflect.ValueOf(DefaultConfig()).Elem().FieldByIndex(info.index)
+	reflect.ValueOf(cfg).Elem().FieldByIndex(info.index).Set(def)
+	return nil
+}
+
+func unsetShowKey(cfg *Config, key string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("unknown key: %s (expected shows.<show-id> or shows.<show-id>.<field>)", key)
+	}
+	id := parts[1]
+	d, ok := cfg.Shows[id]
+	if !ok {
+		return fmt.Errorf("no defaults configured for show %s", id)
+	}
+
+	if len(parts) == 2 {
+		delete(cfg.Shows, id)
+		return nil
+	}
+
+	if _, ok := LookupKey(key); !ok {
+		return fmt.Errorf("unknown key: %s", key)
+	}
+	v := reflect.ValueOf(&d).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("mapstructure") == parts[2] {
+			v.Field(i).Set(reflect.Zero(t.Field(i).Type))
+		}
+	}
+
+	if v.IsZero() {
+		delete(cfg.Shows, id)
+	} else {
+		cfg.Shows[id] = d
+	}
+	return nil
+}
+
+func unsetCampaign(cfg *Config, key string) error {
+	parts := strings.Split(key, ".")
+	if len(parts) != 2 {
+		return fmt.Errorf("cannot unset %s: a campaign needs both dates, unset campaigns.<name> to remove it", key)
+	}
+	name := strings.ToLower(parts[1])
+	if _, ok := cfg.Campaigns[name]; !ok {
+		return fmt.Errorf("no campaign named %q", parts[1])
+	}
+	delete(cfg.Campaigns, name)
+	return nil
+}
+
+func unsetHeader(cfg *Config, name string) error {
+	if name == "" {
+		cfg.CustomHeaders = nil
+		return nil
+	}
+	for k := range cfg.CustomHeaders {
+		if strings.EqualFold(k, name) {
+			delete(cfg.CustomHeaders, k)
+			return nil
+		}
+	}
+	return fmt.Errorf("no custom header named %q", name)
+}
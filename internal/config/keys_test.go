@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	want := map[string]KeyInfo{
+		"output_format":            {Type: "string", Default: "table"},
+		"default_show_id":          {Type: "int", Default: "0"},
+		"relative_dates":           {Type: "bool", Default: "false"},
+		"hooks.episode_published":  {Type: "string", Default: ""},
+		"shows.<show-id>.tags":     {Type: "list", Default: ""},
+		"shows.<show-id>.explicit": {Type: "bool", Default: ""},
+		"campaigns.<name>.from":    {Type: "string", Default: ""},
+		"custom_headers.<header>":  {Type: "string", Default: ""},
+		"network_shows":            {Type: "list", Default: ""},
+	}
+
+	got := make(map[string]KeyInfo)
+	for _, k := range Keys() {
+		if k.Description == "" {
+			t.Errorf("key %s has no description", k.Key)
+		}
+		got[k.Key] = k
+	}
+
+	for key, w := range want {
+		k, ok := got[key]
+		if !ok {
+			t.Errorf("Keys() is missing %s", key)
+			continue
+		}
+		if k.Type != w.Type || k.Default != w.Default {
+			t.Errorf("%s: type %q default %q, want type %q default %q", key, k.Type, k.Default, w.Type, w.Default)
+		}
+	}
+}
+
+func TestUnset(t *testing.T) {
+	explicit := true
+	newConfig := func() *Config {
+		return &Config{
+			OutputFormat:  "json",
+			DefaultShowID: 12,
+			Hooks:         HooksConfig{EpisodePublished: "echo"},
+			Shows: map[string]ShowDefaults{
+				"12": {Tags: []string{"a"}, Explicit: &explicit},
+				"13": {DescriptionFooter: "footer"},
+			},
+			Campaigns:     map[string]Campaign{"launch": {From: "2024-03-01", To: "2024-03-15"}},
+			CustomHeaders: map[string]string{"x-tenant": "acme", "x-trace": "1"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		check   func(*Config) bool
+		wantErr string
+	}{
+		{"top-level to default", "output_format", func(c *Config) bool { return c.OutputFormat == "table" }, ""},
+		{"int to zero", "default_show_id", func(c *Config) bool { return c.DefaultShowID == 0 }, ""},
+		{"nested key", "hooks.episode_published", func(c *Config) bool { return c.Hooks.EpisodePublished == "" }, ""},
+		{"show field", "shows.12.tags", func(c *Config) bool {
+			d, ok := c.Shows["12"]
+			return ok && d.Tags == nil && d.Explicit != nil
+		}, ""},
+		{"last show field removes section", "shows.13.description_footer", func(c *Config) bool { _, ok := c.Shows["13"]; return !ok }, ""},
+		{"whole show section", "shows.12", func(c *Config) bool { _, ok := c.Shows["12"]; return !ok }, ""},
+		{"unknown key", "nope", nil, "unknown key"},
+		{"unknown show field", "shows.12.nope", nil, "unknown key"},
+		{"unconfigured show", "shows.99.tags", nil, "no defaults"},
+		{"campaign", "campaigns.Launch", func(c *Config) bool { _, ok := c.Campaigns["launch"]; return !ok }, ""},
+		{"campaign date", "campaigns.launch.from", nil, "needs both dates"},
+		{"unknown campaign", "campaigns.nope", nil, "no campaign"},
+		{"custom header", "custom_headers.X-Tenant", func(c *Config) bool { return len(c.CustomHeaders) == 1 && c.CustomHeaders["x-trace"] == "1" }, ""},
+		{"all custom headers", "custom_headers", func(c *Config) bool { return c.CustomHeaders == nil }, ""},
+		{"unknown custom header", "custom_headers.x-nope", nil, "no custom header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			err := Unset(cfg, tt.key)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Unset(%q) error = %v, want %q", tt.key, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unset(%q) error = %v", tt.key, err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("Unset(%q) left %+v", tt.key, cfg)
+			}
+		})
+	}
+}
+
+func TestSave_DropsUnsetShowDefaults(t *testing.T) {
+	resetViper()
+	tmpDir := t.TempDir()
+	t.Setenv("SPREAKER_CONFIG_DIR", tmpDir)
+
+	if err := Save(&Config{Shows: map[string]ShowDefaults{"12": {DescriptionFooter: "footer"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	resetViper()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Unset(cfg, "shows.12"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "footer") {
+		t.Errorf("unset show defaults were written back:\n%s", data)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+// secretKeys are the keys whose values are credentials. Hook commands and
+// webhook URLs often embed tokens, so they count as secrets too, as do the
+// values of custom headers, which gateways use for authentication.
+var secretKeys = []string{"token", "rotation_tokens", "smtp.password", "hooks.episode_published", "transcribe.openai_api_key", "custom_headers.*"}
+
+// IsSecretKey reports whether the value of key, in dotted form, is secret.
+func IsSecretKey(key string) bool {
+	for _, k := range secretKeys {
+		if prefix, ok := strings.CutSuffix(k, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// Secrets returns the secret values of the configuration, so they can be
+// scrubbed from text such as logs.
+func (c *Config) Secrets() []string {
+	secrets := append([]string{c.Token, c.SMTP.Password, c.Hooks.EpisodePublished, c.Transcribe.OpenAIKey}, c.RotationTokens...)
+	for _, value := range c.CustomHeaders {
+		secrets = append(secrets, value)
+	}
+	out := secrets[:0]
+	for _, s := range secrets {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// RedactedFile returns the config file at path as YAML with the values of
+// secret keys replaced by REDACTED, e.g. to attach it to a bug report.
+// Unknown keys are kept, so typos remain visible. A missing file yields
+// nil.
+func RedactedFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	redact(raw, "")
+	return yaml.Marshal(raw)
+}
+
+func redact(m map[string]interface{}, prefix string) {
+	for key, value := range m {
+		full := prefix + key
+		if IsSecretKey(full) && value != nil {
+			m[key] = api.Redacted
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			redact(nested, full+".")
+		}
+	}
+}
@@ -0,0 +1,446 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+)
+
+// -----------------------------------------------------------------------------
+// Validation
+// -----------------------------------------------------------------------------
+
+// Problem severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Problem is a single issue found in the configuration.
+type Problem struct {
+	Key      string `json:"key"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+func (p Problem) String() string {
+	return p.Key + ": " + p.Message
+}
+
+// Checks for values whose rules live in packages above config. The cli
+// package sets them; Validate skips a nil check.
+var (
+	// CheckHeader checks a custom header name and value.
+	CheckHeader func(name, value string) error
+	// CheckPreprocess checks the preprocess steps of a show.
+	CheckPreprocess func(steps []string) error
+)
+
+// OutputFormats are the accepted output_format values.
+var OutputFormats = []string{"table", "wide", "json", "plain", "ci"}
+
+// NumberFormats are the accepted number_format values.
+var NumberFormats = []string{"raw", "grouped", "human"}
+
+// valueKind is the YAML type expected for a config key.
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindBool
+	kindList
+)
+
+// keySchema maps every key, with nested keys in dotted form, to its type;
+// sectionSchemas does the same for the keys of each map section, such as
+// shows.<show-id>, keyed by the section's key.
+var keySchema, sectionSchemas = schemas()
+
+func schemas() (map[string]valueKind, map[string]map[string]valueKind) {
+	kinds := map[string]valueKind{"string": kindString, "int": kindInt, "bool": kindBool, "list": kindList}
+	keys := make(map[string]valueKind)
+	sections := make(map[string]map[string]valueKind)
+keys:
+	for _, k := range Keys() {
+		for _, key := range valueMaps {
+			if k.Key == key {
+				continue keys
+			}
+		}
+		for section, prefix := range sectionPrefixes {
+			if field, ok := strings.CutPrefix(k.Key, prefix); ok {
+				if sections[section] == nil {
+					sections[section] = make(map[string]valueKind)
+				}
+				sections[section][field] = kinds[k.Type]
+				continue keys
+			}
+		}
+		keys[k.Key] = kinds[k.Type]
+	}
+	return keys, sections
+}
+
+// validSectionName reports whether name can name a section of the given
+// map key: a show ID for shows, a word for campaigns.
+func validSectionName(section, name string) bool {
+	if section == "shows" {
+		n, err := strconv.Atoi(name)
+		return err == nil && n > 0
+	}
+	return campaignNameRE.MatchString(name)
+}
+
+// sectionNameRule describes valid section names in error messages.
+func sectionNameRule(section string) string {
+	if section == "shows" {
+		return "section name must be a show ID"
+	}
+	return "campaign name may only contain letters, digits, - and _"
+}
+
+var campaignNameRE = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var localeRE = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?$`)
+
+// ValidLocale reports whether locale looks like an API locale: a language
+// code, optionally followed by a country code, as in "it" or "it_IT".
+func ValidLocale(locale string) bool {
+	return localeRE.MatchString(locale)
+}
+
+// ValidAPIURL reports whether raw can be the API base URL: an HTTPS URL,
+// or an HTTP one on a loopback or private host, such as a local mock.
+func ValidAPIURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "https":
+		return true
+	case "http":
+		host := u.Hostname()
+		if strings.EqualFold(host, "localhost") {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && (ip.IsLoopback() || ip.IsPrivate())
+	}
+	return false
+}
+
+// Validate checks the values of a loaded configuration. Empty values are
+// valid: they select the built-in default.
+func (c *Config) Validate() []Problem {
+	var problems []Problem
+	fail := func(key, format string, args ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+	}
+
+	if c.OutputFormat != "" && !contains(OutputFormats, c.OutputFormat) {
+		fail("output_format", "invalid format %q (must be %s)", c.OutputFormat, strings.Join(OutputFormats, ", "))
+	}
+	if c.APIURL != "" && !ValidAPIURL(c.APIURL) {
+		fail("api_url", "must be a valid HTTPS URL (HTTP only for local hosts), got %q", c.APIURL)
+	}
+	if c.Language != "" && !i18n.IsSupported(c.Language) {
+		fail("language", "invalid language %q (must be one of: %s)", c.Language, strings.Join(i18n.SupportedLanguages(), ", "))
+	}
+	if c.Locale != "" && !ValidLocale(c.Locale) {
+		fail("locale", "invalid locale %q (must look like it_IT or en)", c.Locale)
+	}
+	if c.Timezone != "" && !strings.EqualFold(c.Timezone, "local") {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			fail("timezone", "invalid timezone %q (must be an IANA name like Europe/Rome, or local)", c.Timezone)
+		}
+	}
+	if c.DefaultShowID < 0 {
+		fail("default_show_id", "must not be negative, got %d", c.DefaultShowID)
+	}
+	for _, id := range c.NetworkShows {
+		if id <= 0 {
+			fail("network_shows", "show IDs must be positive, got %d", id)
+		}
+	}
+	for _, policy := range []struct{ key, value string }{
+		{"confirm.delete", c.Confirm.Delete},
+		{"confirm.update", c.Confirm.Update},
+		{"confirm.upload", c.Confirm.Upload},
+	} {
+		if policy.value != "" && !contains(ConfirmPolicies, policy.value) {
+			fail(policy.key, "invalid policy %q (must be %s)", policy.value, strings.Join(ConfirmPolicies, ", "))
+		}
+	}
+	if c.MaxWidth < 0 {
+		fail("max_width", "must not be negative, got %d", c.MaxWidth)
+	}
+	if c.IdentityTTL != "" {
+		if ttl, err := time.ParseDuration(c.IdentityTTL); err != nil || ttl < 0 {
+			fail("identity_ttl", "invalid duration %q (must be like 24h or 30m, or 0)", c.IdentityTTL)
+		}
+	}
+	if c.NumberFormat != "" && !contains(NumberFormats, c.NumberFormat) {
+		fail("number_format", "invalid format %q (must be %s)", c.NumberFormat, strings.Join(NumberFormats, ", "))
+	}
+	if c.SMTP.Port < 0 || c.SMTP.Port > 65535 {
+		fail("smtp.port", "must be a port number up to 65535, got %d", c.SMTP.Port)
+	}
+	if c.SMTP.From != "" {
+		if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+			fail("smtp.from", "invalid email address %q", c.SMTP.From)
+		}
+	}
+	for _, to := range c.SMTP.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			fail("smtp.to", "invalid email address %q", to)
+		}
+	}
+	if c.Transcribe.Engine != "" && !contains(TranscribeEngines, c.Transcribe.Engine) {
+		fail("transcribe.engine", "invalid engine %q (must be %s)", c.Transcribe.Engine, strings.Join(TranscribeEngines, ", "))
+	}
+
+	headers := make([]string, 0, len(c.CustomHeaders))
+	for name := range c.CustomHeaders {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	if CheckHeader != nil {
+		for _, name := range headers {
+			if err := CheckHeader(name, c.CustomHeaders[name]); err != nil {
+				fail("custom_headers."+name, "%v", err)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(c.Shows))
+	for id := range c.Shows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !validSectionName("shows", id) {
+			fail("shows."+id, "%s", sectionNameRule("shows"))
+			continue
+		}
+		if CheckPreprocess == nil {
+			continue
+		}
+		if err := CheckPreprocess(c.Shows[id].Preprocess); err != nil {
+			fail("shows."+id+".preprocess", "%v", err)
+		}
+	}
+
+	names := make([]string, 0, len(c.Campaigns))
+	for name := range c.Campaigns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := "campaigns." + name
+		if !validSectionName("campaigns", name) {
+			fail(key, "%s", sectionNameRule("campaigns"))
+			continue
+		}
+		if _, _, err := c.Campaigns[name].Window(); err != nil {
+			fail(key, "%v", err)
+		}
+	}
+
+	return problems
+}
+
+// ValidateFile type-checks the keys of a config file without loading it
+// into Config, so it also catches keys Load would silently ignore.
+// Unknown keys are warnings; values of the wrong type are errors.
+func ValidateFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return []Problem{{Key: "(file)", Message: fmt.Sprintf("invalid YAML: %v", err), Severity: SeverityError}}, nil
+	}
+
+	var problems []Problem
+	checkKeys(raw, "", &problems)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+	return problems, nil
+}
+
+func checkKeys(m map[string]interface{}, prefix string, problems *[]Problem) {
+	for key, value := range m {
+		full := prefix + key
+
+		if schema, ok := sectionSchemas[full]; ok {
+			checkSection(full, value, schema, problems)
+			continue
+		}
+		if _, ok := valueMaps[full]; ok {
+			checkValueMap(full, value, problems)
+			continue
+		}
+		if kind, ok := keySchema[full]; ok {
+			checkKind(full, value, kind, problems)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok && hasSchemaPrefix(full+".") {
+			checkKeys(nested, full+".", problems)
+			continue
+		}
+		*problems = append(*problems, Problem{Key: full, Message: "unknown key", Severity: SeverityWarning})
+	}
+}
+
+// checkSection checks a map section such as shows: a map of section names,
+// each holding keys of schema.
+func checkSection(section string, value interface{}, schema map[string]valueKind, problems *[]Problem) {
+	if value == nil {
+		return
+	}
+	sections, ok := value.(map[string]interface{})
+	if !ok {
+		*problems = append(*problems, Problem{Key: section, Message: "must be a map of " + section, Severity: SeverityError})
+		return
+	}
+	for name, fields := range sections {
+		prefix := section + "." + name
+		if !validSectionName(section, name) {
+			*problems = append(*problems, Problem{Key: prefix, Message: sectionNameRule(section), Severity: SeverityError})
+			continue
+		}
+		m, ok := fields.(map[string]interface{})
+		if !ok {
+			if fields != nil {
+				*problems = append(*problems, Problem{Key: prefix, Message: "must be a map of keys", Severity: SeverityError})
+			}
+			continue
+		}
+		for field, v := range m {
+			kind, ok := schema[field]
+			if !ok {
+				*problems = append(*problems, Problem{Key: prefix + "." + field, Message: "unknown key", Severity: SeverityWarning})
+				continue
+			}
+			checkKind(prefix+"."+field, v, kind, problems)
+		}
+	}
+}
+
+// checkValueMap checks a map of values such as custom_headers: a map of
+// names to strings.
+func checkValueMap(key string, value interface{}, problems *[]Problem) {
+	if value == nil {
+		return
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		*problems = append(*problems, Problem{Key: key, Message: "must be a map of names to values", Severity: SeverityError})
+		return
+	}
+	for name, v := range m {
+		checkKind(key+"."+name, v, kindString, problems)
+	}
+}
+
+// checkKind reports a value that Load cannot convert to the expected type.
+// Like viper, quoted numbers and booleans are accepted.
+func checkKind(key string, value interface{}, kind valueKind, problems *[]Problem) {
+	if value == nil {
+		return
+	}
+
+	var want string
+	ok := true
+	switch kind {
+	case kindString:
+		want = "a string"
+		switch value.(type) {
+		case string, int, float64, bool:
+		case time.Time:
+			// Load converts unquoted campaign dates back to YYYY-MM-DD.
+			ok = strings.HasPrefix(key, "campaigns.")
+		default:
+			ok = false
+		}
+	case kindInt:
+		want = "an integer"
+		switch v := value.(type) {
+		case int:
+		case string:
+			_, err := strconv.Atoi(strings.TrimSpace(v))
+			ok = err == nil
+		default:
+			ok = false
+		}
+	case kindBool:
+		want = "true or false"
+		switch v := value.(type) {
+		case bool:
+		case string:
+			_, err := strconv.ParseBool(v)
+			ok = err == nil
+		default:
+			ok = false
+		}
+	case kindList:
+		want = "a list of strings"
+		switch v := value.(type) {
+		case string:
+		case []interface{}:
+			for _, item := range v {
+				if _, isMap := item.(map[string]interface{}); isMap {
+					ok = false
+				}
+			}
+		default:
+			ok = false
+		}
+	}
+
+	if !ok {
+		*problems = append(*problems, Problem{Key: key, Message: fmt.Sprintf("must be %s, got %v", want, value), Severity: SeverityError})
+	}
+}
+
+func hasSchemaPrefix(prefix string) bool {
+	for key := range keySchema {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrors reports whether any problem is an error rather than a warning.
+func HasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	CheckHeader = api.CheckHeader
+	CheckPreprocess = func(steps []string) error {
+		_, err := audio.ParseSteps(steps)
+		return err
+	}
+	t.Cleanup(func() { CheckHeader, CheckPreprocess = nil, nil })
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantKeys []string
+	}{
+		{"defaults", *DefaultConfig(), nil},
+		{"empty values", Config{}, nil},
+		{"bad output format", Config{OutputFormat: "xml"}, []string{"output_format"}},
+		{"http api url", Config{APIURL: "http://api.spreaker.com"}, []string{"api_url"}},
+		{"local http api url", Config{APIURL: "http://localhost:8080"}, nil},
+		{"loopback http api url", Config{APIURL: "http://127.0.0.1:8080/mock"}, nil},
+		{"private http api url", Config{APIURL: "http://192.168.1.20"}, nil},
+		{"ftp api url", Config{APIURL: "ftp://localhost"}, []string{"api_url"}},
+		{"bad language", Config{Language: "xx"}, []string{"language"}},
+		{"locale", Config{Locale: "it_IT"}, nil},
+		{"bad locale", Config{Locale: "italian"}, []string{"locale"}},
+		{"local timezone", Config{Timezone: "Local"}, nil},
+		{"bad timezone", Config{Timezone: "Mars/Olympus"}, []string{"timezone"}},
+		{"negative max width", Config{MaxWidth: -1}, []string{"max_width"}},
+		{"bad number format", Config{NumberFormat: "roman"}, []string{"number_format"}},
+		{"identity ttl off", Config{IdentityTTL: "0"}, nil},
+		{"bad identity ttl", Config{IdentityTTL: "a day"}, []string{"identity_ttl"}},
+		{"negative identity ttl", Config{IdentityTTL: "-1h"}, []string{"identity_ttl"}},
+		{"negative show id", Config{DefaultShowID: -1}, []string{"default_show_id"}},
+		{"bad show section", Config{Shows: map[string]ShowDefaults{"12": {}, "abc": {}}}, []string{"shows.abc"}},
+		{"preprocess", Config{Shows: map[string]ShowDefaults{
+			"12": {Preprocess: []string{"trim-silence", "normalize:-16LUFS", "add-intro:intro.mp3"}},
+			"13": {Preprocess: []string{"normalize:loud"}},
+		}}, []string{"shows.13.preprocess"}},
+		{"campaigns", Config{Campaigns: map[string]Campaign{
+			"launch":   {From: "2024-03-01", To: "2024-03-15"},
+			"reversed": {From: "2024-03-15", To: "2024-03-01"},
+			"open":     {From: "2024-03-01"},
+			"bad date": {From: "2024-03-01", To: "2024-03-15"},
+		}}, []string{"campaigns.bad date", "campaigns.open", "campaigns.reversed"}},
+		{"smtp", Config{SMTP: SMTPConfig{Port: 465, From: "Podcast <me@example.com>", To: []string{"a@example.com"}}}, nil},
+		{"confirm", Config{Confirm: ConfirmConfig{Delete: "title", Update: "always"}}, nil},
+		{"bad confirm", Config{Confirm: ConfirmConfig{Delete: "yes", Upload: "sometimes"}}, []string{"confirm.delete", "confirm.upload"}},
+		{"custom headers", Config{CustomHeaders: map[string]string{"x-tenant": "acme", "authorization": "x", "bad name": "x"}}, []string{"custom_headers.authorization", "custom_headers.bad name"}},
+		{"bad smtp", Config{SMTP: SMTPConfig{Port: 70000, From: "me", To: []string{"a@example.com", "b"}}}, []string{"smtp.port", "smtp.from", "smtp.to"}},
+		{"transcribe", Config{Transcribe: TranscribeConfig{Engine: "openai"}}, nil},
+		{"bad transcribe", Config{Transcribe: TranscribeConfig{Engine: "whisper"}}, []string{"transcribe.engine"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			for _, p := range tt.cfg.Validate() {
+				keys = append(keys, p.Key)
+			}
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("Validate() keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `token: abc
+default_show_id: abc
+user_id: "42"
+relative_dates: maybe
+output_fromat: json
+hooks:
+  episode_published: ./announce.sh
+  episode_deleted: ./x.sh
+shows:
+  "123":
+    tags: [tech, news]
+    explicit: yes
+    footer: oops
+  mine:
+    tags: x
+campaigns:
+  launch:
+    from: 2024-03-01
+    to: 2024-03-15
+    budget: 100
+custom_headers:
+  x-tenant: acme
+  x-list: [a, b]
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, len(problems))
+	for i, p := range problems {
+		got[i] = p.Severity + " " + p.Key
+	}
+	want := []string{
+		"error custom_headers.x-list",
+		"error default_show_id",
+		"warning hooks.episode_deleted",
+		"warning output_fromat",
+		"error relative_dates",
+		"error shows.123.explicit", // YAML 1.2: yes is a string, not a boolean
+		"warning shows.123.footer",
+		"error shows.mine",
+	}
+	want = append([]string{"warning campaigns.launch.budget"}, want...)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateFile() = %v, want %v", got, want)
+	}
+	if !HasErrors(problems) {
+		t.Error("HasErrors() = false, want true")
+	}
+}
+
+func TestValidateFile_InvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("token: [unclosed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "invalid YAML") {
+		t.Errorf("ValidateFile() = %v, want one invalid YAML problem", problems)
+	}
+}
+
+func TestSave_RejectsInvalidConfig(t *testing.T) {
+	resetViper()
+	tmpDir := t.TempDir()
+	t.Setenv("SPREAKER_CONFIG_DIR", tmpDir)
+
+	err := Save(&Config{OutputFormat: "xml"})
+	if err == nil || !strings.Contains(err.Error(), "output_format") {
+		t.Fatalf("Save() error = %v, want output_format error", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "config.yaml")); !os.IsNotExist(err) {
+		t.Error("Save() wrote an invalid config")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Package filename turns episode and show titles into file names that are valid
+on Windows, macOS and Linux, and picks free names when titles collide.
+
+It is used by episodes download and download-all.
+*/
+package filename
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxName is the longest name, in bytes, Sanitize returns. File systems
+	// allow 255; the rest is left for an extension and a " (2)" suffix.
+	MaxName = 200
+
+	// MaxPath is the longest path Join returns: Windows' MAX_PATH of 260
+	// characters, less the terminating NUL.
+	MaxPath = 259
+)
+
+// reservedNames are device names Windows does not allow as a file name,
+// with or without an extension.
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// Sanitize returns name as a file name without an extension. It is
+// normalized to NFC, so the same title gives the same name on every
+// system; path separators and colons become "-", other characters Windows
+// rejects and control characters are dropped, runs of spaces are collapsed,
+// and leading and trailing dots and spaces are trimmed. Windows device
+// names get a "_" prefix, and long names are cut to MaxName bytes. If
+// nothing is left, fallback is returned.
+func Sanitize(name, fallback string) string {
+	var b strings.Builder
+	for _, r := range norm.NFC.String(name) {
+		switch {
+		case r == '/' || r == '\\' || r == ':':
+			b.WriteRune('-')
+		case strings.ContainsRune(`*?"<>|`, r):
+		case r == '\t' || r == '\n':
+			b.WriteRune(' ')
+		case unicode.IsControl(r) || r == utf8.RuneError:
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	s := trim(strings.Join(strings.Fields(b.String()), " "))
+	s = trim(cut(s, MaxName))
+	if s == "" {
+		return fallback
+	}
+	if reserved(s) {
+		s = "_" + s
+	}
+	return s
+}
+
+// trim removes the leading and trailing dots and spaces: Windows drops
+// trailing ones, and a leading dot hides the file elsewhere.
+func trim(s string) string {
+	return strings.Trim(s, ". ")
+}
+
+// cut shortens s to at most n bytes without splitting a character.
+func cut(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// reserved reports whether name is a Windows device name, such as "CON" or
+// "nul.mp3".
+func reserved(name string) bool {
+	base, _, _ := strings.Cut(name, ".")
+	return reservedNames[strings.ToUpper(strings.TrimSpace(base))]
+}
+
+// Join returns the path of name plus ext in dir, shortening name so the
+// path fits in MaxPath. A directory too long for any name is left to the
+// file system to reject.
+func Join(dir, name, ext string) string {
+	path := filepath.Join(dir, name+ext)
+	if over := len(path) - MaxPath; over > 0 && over < len(name) {
+		if short := trim(cut(name, len(name)-over)); short != "" {
+			path = filepath.Join(dir, short+ext)
+		}
+	}
+	return path
+}
+
+// Set hands out distinct paths. Paths are compared case-insensitively, as
+// the default Windows and macOS file systems do.
+type Set struct {
+	taken map[string]bool
+}
+
+// NewSet returns an empty Set.
+func NewSet() *Set {
+	return &Set{taken: make(map[string]bool)}
+}
+
+// Claim returns the path of name plus ext in dir (see Join), or if another
+// Claim returned it already or exists reports it as taken, the first free
+// "name (2)", "name (3)" and so on. exists may be nil.
+func (s *Set) Claim(dir, name, ext string, exists func(path string) bool) string {
+	for n := 1; ; n++ {
+		candidate := name
+		if n > 1 {
+			candidate = fmt.Sprintf("%s (%d)", name, n)
+		}
+		path := Join(dir, candidate, ext)
+		key := strings.ToLower(path)
+		if s.taken[key] || (exists != nil && exists(path)) {
+			continue
+		}
+		s.taken[key] = true
+		return path
+	}
+}
+
+// Unique returns a path of name plus ext in dir that does not exist yet.
+func Unique(dir, name, ext string) string {
+	return NewSet().Claim(dir, name, ext, Exists)
+}
+
+// Exists reports whether something exists at path.
+func Exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
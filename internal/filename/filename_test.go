@@ -0,0 +1,87 @@
+package filename
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Episode 1", "Episode 1"},
+		{"separators", `News: a/b\c`, "News- a-b-c"},
+		{"dropped", `What? "Yes" <no> *|*`, "What Yes no"},
+		{"whitespace", "Line one\n\tLine  two", "Line one Line two"},
+		{"control", "Bell\x07ring", "Bellring"},
+		{"trailing dots and spaces", "Wait for it... ", "Wait for it"},
+		{"leading dot", ".hidden", "hidden"},
+		{"reserved", "CON", "_CON"},
+		{"reserved lower case", "nul", "_nul"},
+		{"reserved with extension", "aux.notes", "_aux.notes"},
+		{"reserved prefix only", "Console", "Console"},
+		{"NFD to NFC", "Café", "Café"},
+		{"empty", " ... ", "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in, "fallback"); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeLength(t *testing.T) {
+	got := Sanitize(strings.Repeat("è", MaxName), "x")
+	if len(got) > MaxName || !utf8.ValidString(got) {
+		t.Errorf("Sanitize() = %d bytes, valid UTF-8 %v; want at most %d valid bytes", len(got), utf8.ValidString(got), MaxName)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got, want := Join("out", "Episode", ".mp3"), filepath.Join("out", "Episode.mp3"); got != want {
+		t.Errorf("Join() = %q, want %q", got, want)
+	}
+
+	dir := strings.Repeat("d", 150)
+	got := Join(dir, strings.Repeat("n", MaxName), ".mp3")
+	if len(got) != MaxPath || !strings.HasSuffix(got, "n.mp3") {
+		t.Errorf("Join() = %d bytes %q, want %d bytes ending in .mp3", len(got), got, MaxPath)
+	}
+}
+
+func TestSetClaim(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Taken.mp3"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSet()
+	claims := []struct {
+		name   string
+		exists func(string) bool
+		want   string
+	}{
+		{"Episode", nil, "Episode.mp3"},
+		{"Episode", nil, "Episode (2).mp3"},
+		{"EPISODE", nil, "EPISODE (3).mp3"},
+		{"Taken", nil, "Taken.mp3"},
+		{"Taken", Exists, "Taken (2).mp3"},
+	}
+	for _, c := range claims {
+		if got := s.Claim(dir, c.name, ".mp3", c.exists); got != filepath.Join(dir, c.want) {
+			t.Errorf("Claim(%q) = %q, want %q", c.name, filepath.Base(got), c.want)
+		}
+	}
+
+	if got := Unique(dir, "Taken", ".mp3"); got != filepath.Join(dir, "Taken (2).mp3") {
+		t.Errorf("Unique() = %q, want Taken (2).mp3", filepath.Base(got))
+	}
+}
@@ -0,0 +1,425 @@
+/*
+Package grpcserver mirrors the read endpoints of the local REST proxy
+(internal/server) as a gRPC service, so tooling in other languages can
+consume Spreaker data with types generated from the service definition.
+
+Messages are the types of pkg/models, described by internal/protogen;
+Proto returns the .proto file to generate clients from. The server speaks
+gRPC over HTTP/2 without TLS, as the REST proxy speaks plain HTTP, and
+supports unary calls without compression, which is all the service
+needs. Episode uploads are only available through the REST proxy.
+*/
+package grpcserver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/protogen"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Package and Service name the service: methods are called at
+// /spreaker.v1.Spreaker/<Method>.
+const (
+	Package = "spreaker.v1"
+	Service = "Spreaker"
+)
+
+// maxMessageSize bounds the size of a request message (4 MB, the default
+// of gRPC implementations).
+const maxMessageSize = 4 << 20
+
+// Status codes of the gRPC protocol used by the server.
+const (
+	codeOK               = 0
+	codeUnknown          = 2
+	codeInvalidArgument  = 3
+	codeNotFound         = 5
+	codePermissionDenied = 7
+	codeResourceExhaust  = 8
+	codeUnimplemented    = 12
+	codeInternal         = 13
+	codeUnavailable      = 14
+	codeUnauthenticated  = 16
+)
+
+// -----------------------------------------------------------------------------
+// Messages
+// -----------------------------------------------------------------------------
+
+// Empty is the request of methods without parameters.
+type Empty struct{}
+
+// IDRequest selects a show or an episode.
+type IDRequest struct {
+	ID int `json:"id" proto:"1"`
+}
+
+// ListRequest pages through a list. Zero values use the API defaults.
+type ListRequest struct {
+	Limit  int `json:"limit" proto:"1"`
+	Offset int `json:"offset" proto:"2"`
+}
+
+// ShowListRequest pages through the episodes of a show.
+type ShowListRequest struct {
+	ID     int `json:"id" proto:"1"`
+	Limit  int `json:"limit" proto:"2"`
+	Offset int `json:"offset" proto:"3"`
+}
+
+// StatisticsRequest selects the statistics of a show or an episode over a
+// period, as the from, to and group query parameters of the REST proxy.
+type StatisticsRequest struct {
+	ID    int    `json:"id" proto:"1"`
+	From  string `json:"from" proto:"2"`
+	To    string `json:"to" proto:"3"`
+	Group string `json:"group" proto:"4"`
+}
+
+// PlayTotalsRequest pages through the play totals of a show's episodes
+// over a period.
+type PlayTotalsRequest struct {
+	ID     int    `json:"id" proto:"1"`
+	From   string `json:"from" proto:"2"`
+	To     string `json:"to" proto:"3"`
+	Group  string `json:"group" proto:"4"`
+	Limit  int    `json:"limit" proto:"5"`
+	Offset int    `json:"offset" proto:"6"`
+}
+
+// ShowPage is a page of shows.
+type ShowPage struct {
+	Items   []models.Show `json:"items" proto:"1"`
+	HasMore bool          `json:"has_more" proto:"2"`
+}
+
+// EpisodePage is a page of episodes.
+type EpisodePage struct {
+	Items   []models.Episode `json:"items" proto:"1"`
+	HasMore bool             `json:"has_more" proto:"2"`
+}
+
+// EpisodePlayTotalsPage is a page of per-episode play totals.
+type EpisodePlayTotalsPage struct {
+	Items   []models.EpisodePlayTotals `json:"items" proto:"1"`
+	HasMore bool                       `json:"has_more" proto:"2"`
+}
+
+// PlayStatisticsList is a series of play counts.
+type PlayStatisticsList struct {
+	Items []models.PlayStatistics `json:"items" proto:"1"`
+}
+
+// -----------------------------------------------------------------------------
+// Server
+// -----------------------------------------------------------------------------
+
+// Server is an http.Handler serving the Spreaker gRPC service.
+type Server struct {
+	client   *api.Client
+	registry *protogen.Registry
+	service  protogen.Service
+	methods  map[string]method
+}
+
+type method struct {
+	request  *protogen.Message
+	response *protogen.Message
+	call     func(req any) (any, error)
+}
+
+// New creates a Server that forwards calls through client.
+func New(client *api.Client) *Server {
+	s := &Server{
+		client:   client,
+		registry: protogen.NewRegistry(),
+		service:  protogen.Service{Name: Service},
+		methods:  make(map[string]method),
+	}
+
+	handle(s, "GetMe", func(*Empty) (*models.User, error) {
+		return s.client.GetMe()
+	})
+
+	handle(s, "ListShows", func(req *ListRequest) (*ShowPage, error) {
+		pagination, err := paginationParams(req.Limit, req.Offset)
+		if err != nil {
+			return nil, err
+		}
+		result, err := s.client.GetMyShows(pagination)
+		if err != nil {
+			return nil, err
+		}
+		return &ShowPage{Items: result.Items, HasMore: result.HasMore}, nil
+	})
+	handle(s, "GetShow", func(req *IDRequest) (*models.Show, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		return s.client.GetShow(req.ID)
+	})
+
+	handle(s, "ListShowEpisodes", func(req *ShowListRequest) (*EpisodePage, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		pagination, err := paginationParams(req.Limit, req.Offset)
+		if err != nil {
+			return nil, err
+		}
+		result, err := s.client.GetShowEpisodes(req.ID, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return &EpisodePage{Items: result.Items, HasMore: result.HasMore}, nil
+	})
+	handle(s, "GetEpisode", func(req *IDRequest) (*models.Episode, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		return s.client.GetEpisode(req.ID)
+	})
+
+	handle(s, "GetMyStatistics", func(*Empty) (*models.UserOverallStatistics, error) {
+		return s.client.GetMyStatistics()
+	})
+	handle(s, "GetShowStatistics", func(req *IDRequest) (*models.ShowOverallStatistics, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		return s.client.GetShowStatistics(req.ID)
+	})
+	handle(s, "GetShowPlays", func(req *StatisticsRequest) (*PlayStatisticsList, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		plays, err := s.client.GetShowPlayStatistics(req.ID, statisticsParams(req))
+		if err != nil {
+			return nil, err
+		}
+		return &PlayStatisticsList{Items: plays}, nil
+	})
+	handle(s, "ListShowEpisodesPlayTotals", func(req *PlayTotalsRequest) (*EpisodePlayTotalsPage, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		pagination, err := paginationParams(req.Limit, req.Offset)
+		if err != nil {
+			return nil, err
+		}
+		params := api.StatisticsParams{From: req.From, To: req.To, Group: req.Group}
+		result, err := s.client.GetShowEpisodesPlayTotals(req.ID, params, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return &EpisodePlayTotalsPage{Items: result.Items, HasMore: result.HasMore}, nil
+	})
+	handle(s, "GetEpisodeStatistics", func(req *IDRequest) (*models.EpisodeOverallStatistics, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		return s.client.GetEpisodeStatistics(req.ID)
+	})
+	handle(s, "GetEpisodePlays", func(req *StatisticsRequest) (*PlayStatisticsList, error) {
+		if err := validID(req.ID); err != nil {
+			return nil, err
+		}
+		plays, err := s.client.GetEpisodePlayStatistics(req.ID, statisticsParams(req))
+		if err != nil {
+			return nil, err
+		}
+		return &PlayStatisticsList{Items: plays}, nil
+	})
+
+	return s
+}
+
+// handle registers a method of the service. The request and response
+// types must be describable by protogen, which the tests check.
+func handle[Req, Resp any](s *Server, name string, call func(*Req) (*Resp, error)) {
+	request, err := s.registry.Describe(reflect.TypeOf((*Req)(nil)))
+	if err != nil {
+		panic(err)
+	}
+	response, err := s.registry.Describe(reflect.TypeOf((*Resp)(nil)))
+	if err != nil {
+		panic(err)
+	}
+
+	s.service.Methods = append(s.service.Methods, protogen.Method{Name: name, Request: request, Response: response})
+	s.methods["/"+Package+"."+Service+"/"+name] = method{
+		request:  request,
+		response: response,
+		call: func(req any) (any, error) {
+			return call(req.(*Req))
+		},
+	}
+}
+
+// Proto writes the .proto file of the service.
+func (s *Server) Proto(w io.Writer) error {
+	return protogen.Render(w, Package, []protogen.Service{s.service}, s.registry.Messages())
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc+proto")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+	m, ok := s.methods[r.URL.Path]
+	if !ok {
+		writeStatus(w, codeUnimplemented, fmt.Sprintf("unknown method %s", r.URL.Path))
+		return
+	}
+
+	data, err := readMessage(r.Body)
+	if err != nil {
+		writeStatus(w, codeInvalidArgument, err.Error())
+		return
+	}
+	req := m.request.New()
+	if err := protogen.Unmarshal(m.request, data, req); err != nil {
+		writeStatus(w, codeInvalidArgument, err.Error())
+		return
+	}
+
+	resp, err := m.call(req)
+	if err != nil {
+		writeStatus(w, statusCode(err), err.Error())
+		return
+	}
+	out, err := protogen.Marshal(m.response, resp)
+	if err != nil {
+		writeStatus(w, codeInternal, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(frame(out))
+	writeStatus(w, codeOK, "")
+}
+
+// readMessage reads the single length-prefixed message of a unary call.
+func readMessage(body io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, errors.New("missing request message")
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("request message of %d bytes exceeds %d", size, maxMessageSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return nil, errors.New("truncated request message")
+	}
+	return data, nil
+}
+
+// frame prefixes a message with the uncompressed flag and its length.
+func frame(data []byte) []byte {
+	b := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(b[1:], uint32(len(data)))
+	return append(b, data...)
+}
+
+// writeStatus ends the call with the status in the trailers.
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set("Grpc-Message", percentEncode(message))
+	}
+}
+
+// statusCode maps an error of a call to a gRPC status code.
+func statusCode(err error) int {
+	var argErr *argumentError
+	if errors.As(err, &argErr) {
+		return codeInvalidArgument
+	}
+	if api.IsNetworkError(err) {
+		return codeUnavailable
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		return codeUnknown
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusBadRequest:
+		return codeInvalidArgument
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return codeUnauthenticated
+	case apiErr.StatusCode == http.StatusForbidden:
+		return codePermissionDenied
+	case apiErr.StatusCode == http.StatusNotFound:
+		return codeNotFound
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		return codeResourceExhaust
+	case apiErr.StatusCode >= 500:
+		return codeUnavailable
+	}
+	return codeUnknown
+}
+
+// percentEncode escapes a status message as the gRPC protocol requires.
+func percentEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// -----------------------------------------------------------------------------
+// Request helpers
+// -----------------------------------------------------------------------------
+
+// argumentError is an invalid request field.
+type argumentError struct {
+	msg string
+}
+
+func (e *argumentError) Error() string { return e.msg }
+
+func validID(id int) error {
+	if id <= 0 {
+		return &argumentError{fmt.Sprintf("invalid ID: %d", id)}
+	}
+	return nil
+}
+
+func paginationParams(limit, offset int) (api.PaginationParams, error) {
+	if limit < 0 || limit > api.MaxPageLimit {
+		return api.PaginationParams{}, &argumentError{fmt.Sprintf("invalid limit %d: must be between 1 and %d", limit, api.MaxPageLimit)}
+	}
+	if offset < 0 {
+		return api.PaginationParams{}, &argumentError{fmt.Sprintf("invalid offset %d", offset)}
+	}
+	return api.PaginationParams{Limit: limit, Offset: offset}, nil
+}
+
+func statisticsParams(req *StatisticsRequest) api.StatisticsParams {
+	return api.StatisticsParams{From: req.From, To: req.To, Group: req.Group}
+}
@@ -0,0 +1,177 @@
+package grpcserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/protogen"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// upstream fakes the Spreaker API: show 12 exists, others are not found.
+func upstream(t *testing.T) *httptest.Server {
+	t.Helper()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v2/shows/12" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"error": map[string]interface{}{"messages": []string{"Show not found"}, "code": 404}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": map[string]interface{}{"show": map[string]interface{}{"show_id": 12, "title": "Test Show"}},
+		})
+	}))
+	t.Cleanup(up.Close)
+	return up
+}
+
+// serve starts the gRPC server over unencrypted HTTP/2, as serve grpc does.
+func serve(t *testing.T) (*Server, *httptest.Server, *http.Client) {
+	t.Helper()
+	client := api.NewClient("test-token")
+	client.BaseURL = upstream(t).URL
+	s := New(client)
+
+	ts := httptest.NewUnstartedServer(s)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	transport := &http.Transport{Protocols: new(http.Protocols)}
+	transport.Protocols.SetUnencryptedHTTP2(true)
+	return s, ts, &http.Client{Transport: transport}
+}
+
+// call makes a unary call and returns the response message and status.
+func call(t *testing.T, hc *http.Client, url, method string, req []byte) ([]byte, string, string) {
+	t.Helper()
+	httpReq, _ := http.NewRequest(http.MethodPost, url+"/spreaker.v1.Spreaker/"+method, bytes.NewReader(frame(req)))
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	resp, err := hc.Do(httpReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("protocol = %s, want HTTP/2", resp.Proto)
+	}
+	var msg []byte
+	if len(body) >= 5 {
+		msg = body[5:]
+	}
+	return msg, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+}
+
+func TestServer_GetShow(t *testing.T) {
+	s, ts, hc := serve(t)
+
+	req, _ := protogen.Marshal(s.methods["/spreaker.v1.Spreaker/GetShow"].request, IDRequest{ID: 12})
+	msg, status, message := call(t, hc, ts.URL, "GetShow", req)
+	if status != "0" {
+		t.Fatalf("status = %s (%s)", status, message)
+	}
+
+	var show models.Show
+	if err := protogen.Unmarshal(s.methods["/spreaker.v1.Spreaker/GetShow"].response, msg, &show); err != nil {
+		t.Fatal(err)
+	}
+	if show.ShowID != 12 || show.Title != "Test Show" {
+		t.Errorf("show = %+v", show)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	s, ts, hc := serve(t)
+	idRequest := s.methods["/spreaker.v1.Spreaker/GetShow"].request
+
+	notFound, _ := protogen.Marshal(idRequest, IDRequest{ID: 404})
+	tests := []struct {
+		method string
+		req    []byte
+		want   string
+	}{
+		{"GetShow", notFound, "5"},
+		{"GetShow", nil, "3"},
+		{"UploadEpisode", nil, "12"},
+	}
+	for _, tt := range tests {
+		_, status, message := call(t, hc, ts.URL, tt.method, tt.req)
+		if status != tt.want {
+			t.Errorf("%s: status = %s (%s), want %s", tt.method, status, message, tt.want)
+		}
+	}
+}
+
+func TestServer_Proto(t *testing.T) {
+	s := New(api.NewClient("test-token"))
+
+	var b strings.Builder
+	if err := s.Proto(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package spreaker.v1;",
+		"rpc GetShow(IDRequest) returns (Show);",
+		"rpc ListShowEpisodes(ShowListRequest) returns (EpisodePage);",
+		"message Episode {",
+		"  repeated Show items = 1;",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("proto is missing %q:\n%s", want, b.String())
+		}
+	}
+}
+
+// TestServer_ProtoGolden pins the whole .proto file: clients generate stubs
+// from it, so a field number must never change. After adding a field, run
+//
+//	UPDATE=1 go test ./internal/grpcserver -run TestServer_ProtoGolden
+//
+// and check that the diff only adds lines.
+func TestServer_ProtoGolden(t *testing.T) {
+	s := New(api.NewClient("test-token"))
+
+	var b bytes.Buffer
+	if err := s.Proto(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "spreaker.proto.golden")
+	if os.Getenv("UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with UPDATE=1 to create it)", err)
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("proto differs from %s (run with UPDATE=1 to accept it)\n--- got ---\n%s\n--- want ---\n%s", path, b.Bytes(), want)
+	}
+}
+
+func TestPercentEncode(t *testing.T) {
+	if got := percentEncode("100% done\nnext é"); got != "100%25 done%0Anext %C3%A9" {
+		t.Errorf("percentEncode() = %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Package hooks runs user-configured actions in response to CLI events.
+
+A hook target is either:
+  - an http(s) URL: the event payload is POSTed as JSON (webhook)
+  - anything else: executed as a shell command with the payload on stdin
+*/
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event names passed to hook commands via SPREAKER_HOOK_EVENT.
+const (
+	EventEpisodePublished = "episode_published"
+	EventMonitorMatch     = "monitor_match"
+)
+
+// DefaultTimeout bounds how long a single hook may run.
+const DefaultTimeout = 30 * time.Second
+
+// Run invokes the hook target with the JSON-encoded payload.
+// An empty target is a no-op.
+func Run(ctx context.Context, event, target string, payload interface{}) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	if isWebhook(target) {
+		return postWebhook(ctx, event, target, body)
+	}
+	return runCommand(ctx, event, target, body)
+}
+
+func isWebhook(target string) bool {
+	return strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://")
+}
+
+// postWebhook sends the payload to a webhook URL.
+func postWebhook(ctx context.Context, event, target string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "spreaker-cli/1.0")
+	req.Header.Set("X-Spreaker-Event", event)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// runCommand executes a shell command with the payload on stdin.
+func runCommand(ctx context.Context, event, command string, body []byte) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	// Hook output goes to stderr so it never corrupts machine-readable stdout.
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "SPREAKER_HOOK_EVENT="+event)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook command failed: %w", err)
+	}
+	return nil
+}
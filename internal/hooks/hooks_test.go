@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRun_EmptyTargetIsNoop(t *testing.T) {
+	if err := Run(context.Background(), EventEpisodePublished, "  ", map[string]int{"episode_id": 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRun_Webhook(t *testing.T) {
+	var gotEvent string
+	var gotBody map[string]int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		gotEvent = r.Header.Get("X-Spreaker-Event")
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	err := Run(context.Background(), EventEpisodePublished, srv.URL, map[string]int{"episode_id": 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotEvent != EventEpisodePublished {
+		t.Errorf("X-Spreaker-Event = %q, want %q", gotEvent, EventEpisodePublished)
+	}
+	if gotBody["episode_id"] != 42 {
+		t.Errorf("episode_id = %d, want 42", gotBody["episode_id"])
+	}
+}
+
+func TestRun_WebhookErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := Run(context.Background(), EventEpisodePublished, srv.URL, nil); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+}
+
+func TestRun_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell command test requires sh")
+	}
+
+	out := filepath.Join(t.TempDir(), "payload.json")
+	err := Run(context.Background(), EventEpisodePublished, "cat > "+out, map[string]int{"episode_id": 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"episode_id":7}` {
+		t.Errorf("payload = %s", data)
+	}
+}
+
+func TestRun_CommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell command test requires sh")
+	}
+
+	if err := Run(context.Background(), EventEpisodePublished, "exit 3", nil); err == nil {
+		t.Fatal("expected error for non-zero exit")
+	}
+}
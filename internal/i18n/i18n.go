@@ -0,0 +1,110 @@
+/*
+Package i18n translates user-facing CLI messages and table headers.
+
+English is the source language: message IDs are the English strings
+themselves, so untranslated messages fall back to English automatically.
+Catalogs for other languages live in locales/<lang>.json and are embedded
+in the binary.
+
+Usage:
+
+	i18n.SetLanguage("it")
+	fmt.Println(i18n.T("Episode %d deleted", 42)) // "Episodio 42 eliminato"
+*/
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLanguage is the source language of all message IDs.
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// catalog holds the active translations. A nil map means English.
+var catalog atomic.Pointer[map[string]string]
+
+// SupportedLanguages returns the language codes that can be passed to SetLanguage.
+func SupportedLanguages() []string {
+	langs := []string{DefaultLanguage}
+	entries, _ := localesFS.ReadDir("locales")
+	for _, e := range entries {
+		langs = append(langs, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return langs
+}
+
+// IsSupported reports whether lang (e.g. "it" or "it_IT") has a catalog.
+func IsSupported(lang string) bool {
+	code := normalize(lang)
+	for _, l := range SupportedLanguages() {
+		if l == code {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLanguage activates the catalog for lang. Regional variants such as
+// "it_IT" or "it-IT" select the base language. An empty value selects English.
+func SetLanguage(lang string) error {
+	code := normalize(lang)
+	if code == "" || code == DefaultLanguage {
+		catalog.Store(nil)
+		return nil
+	}
+
+	data, err := localesFS.ReadFile("locales/" + code + ".json")
+	if err != nil {
+		catalog.Store(nil)
+		return fmt.Errorf("unsupported language %q (available: %s)", lang, strings.Join(SupportedLanguages(), ", "))
+	}
+
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		catalog.Store(nil)
+		return fmt.Errorf("invalid catalog for %q: %w", code, err)
+	}
+
+	catalog.Store(&messages)
+	return nil
+}
+
+// T translates msg into the active language and formats it with args.
+// If no translation exists, msg is used as-is.
+func T(msg string, args ...interface{}) string {
+	if m := catalog.Load(); m != nil {
+		if translated, ok := (*m)[msg]; ok && translated != "" {
+			msg = translated
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Label translates a key-value label such as "  On Demand:", preserving
+// leading indentation and the trailing colon.
+func Label(label string) string {
+	trimmed := strings.TrimLeft(label, " ")
+	indent := label[:len(label)-len(trimmed)]
+	if strings.HasSuffix(trimmed, ":") {
+		return indent + T(strings.TrimSuffix(trimmed, ":")) + ":"
+	}
+	return indent + T(trimmed)
+}
+
+func normalize(lang string) string {
+	code := strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(code, "_-"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
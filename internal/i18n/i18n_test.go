@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestT_DefaultLanguage(t *testing.T) {
+	if err := SetLanguage(""); err != nil {
+		t.Fatal(err)
+	}
+	if got := T("Episode %d deleted", 42); got != "Episode 42 deleted" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestT_Italian(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+
+	for _, lang := range []string{"it", "it_IT", "IT-it"} {
+		if err := SetLanguage(lang); err != nil {
+			t.Fatalf("SetLanguage(%q): %v", lang, err)
+		}
+		if got := T("Episode %d deleted", 42); got != "Episodio 42 eliminato" {
+			t.Errorf("SetLanguage(%q): got %q", lang, got)
+		}
+	}
+
+	// Missing translations fall back to the English message ID.
+	if got := T("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("fallback = %q", got)
+	}
+}
+
+func TestSetLanguage_Unsupported(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+
+	err := SetLanguage("xx")
+	if err == nil {
+		t.Fatal("expected error for unsupported language")
+	}
+	if !strings.Contains(err.Error(), "it") {
+		t.Errorf("error should list available languages: %v", err)
+	}
+	if got := T("Cancelled."); got != "Cancelled." {
+		t.Errorf("unsupported language should fall back to English, got %q", got)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+	SetLanguage("it")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Plays:", "Ascolti:"},
+		{"  On Demand:", "  On demand:"},
+		{"Unknown:", "Unknown:"},
+		{"Title", "Titolo"},
+	}
+	for _, tt := range tests {
+		if got := Label(tt.in); got != tt.want {
+			t.Errorf("Label(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	if !IsSupported("en") || !IsSupported("it_IT") {
+		t.Error("en and it should be supported")
+	}
+	if IsSupported("xx") {
+		t.Error("xx should not be supported")
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Package ical writes publishing calendars in iCalendar (RFC 5545), the
+format calendar apps import and subscribe to.
+*/
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Event statuses: published episodes are confirmed, scheduled ones may
+// still move.
+const (
+	StatusPublished = "CONFIRMED"
+	StatusScheduled = "TENTATIVE"
+)
+
+// defaultDuration is the length of events of episodes without a duration.
+const defaultDuration = 30 * time.Minute
+
+// timeLayout is the UTC date-time format of iCalendar.
+const timeLayout = "20060102T150405Z"
+
+// maxLine is the longest content line in octets, excluding the CRLF.
+const maxLine = 75
+
+// Event is a calendar entry for one episode.
+type Event struct {
+	EpisodeID int
+	Title     string
+	URL       string
+	Start     time.Time
+	Duration  time.Duration
+	Status    string
+}
+
+// Events returns an event for each published or scheduled episode, at its
+// publish or scheduled time. Drafts without a date are left out.
+func Events(episodes []models.Episode) []Event {
+	events := make([]Event, 0, len(episodes))
+	for _, e := range episodes {
+		ev := Event{
+			EpisodeID: e.EpisodeID,
+			Title:     e.Title,
+			URL:       e.SiteURL,
+			Duration:  time.Duration(e.Duration) * time.Millisecond,
+		}
+		switch {
+		case e.PublishedAt != nil && !e.PublishedAt.IsZero():
+			ev.Start, ev.Status = e.PublishedAt.Time, StatusPublished
+		case e.AutoPublishedAt != nil && !e.AutoPublishedAt.IsZero():
+			ev.Start, ev.Status = e.AutoPublishedAt.Time, StatusScheduled
+		default:
+			continue
+		}
+		if ev.Duration <= 0 {
+			ev.Duration = defaultDuration
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+// Write writes a calendar named name with the given events. stamp is the
+// time the calendar is generated.
+func Write(w io.Writer, name string, events []Event, stamp time.Time) error {
+	var b strings.Builder
+	line := func(property, value string) {
+		b.WriteString(fold(property + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//spreaker-cli//Publishing Calendar//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", escape(name))
+	for _, ev := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("episode-%d@spreaker.com", ev.EpisodeID))
+		line("DTSTAMP", stamp.UTC().Format(timeLayout))
+		line("DTSTART", ev.Start.UTC().Format(timeLayout))
+		line("DTEND", ev.Start.Add(ev.Duration).UTC().Format(timeLayout))
+		line("SUMMARY", escape(ev.Title))
+		line("STATUS", ev.Status)
+		if ev.Status == StatusScheduled {
+			line("CATEGORIES", "Scheduled")
+		} else {
+			line("CATEGORIES", "Published")
+		}
+		if ev.URL != "" {
+			line("URL", ev.URL)
+			line("DESCRIPTION", escape(ev.URL))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold splits a content line into lines of at most maxLine octets, each
+// continuation starting with a space, without splitting UTF-8 characters.
+func fold(s string) string {
+	if len(s) <= maxLine {
+		return s
+	}
+	var b strings.Builder
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLine - 1 // the leading space counts
+	}
+	b.WriteString(s)
+	return b.String()
+}
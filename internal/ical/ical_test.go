@@ -0,0 +1,74 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestEvents(t *testing.T) {
+	published := &models.CustomTime{Time: time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)}
+	scheduled := &models.CustomTime{Time: time.Date(2024, 5, 8, 8, 0, 0, 0, time.UTC)}
+
+	events := Events([]models.Episode{
+		{EpisodeID: 1, Title: "Out", PublishedAt: published, Duration: 90 * 60 * 1000},
+		{EpisodeID: 2, Title: "Next", AutoPublishedAt: scheduled},
+		{EpisodeID: 3, Title: "Draft"},
+	})
+
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want 2", events)
+	}
+	if events[0].Status != StatusPublished || events[0].Duration != 90*time.Minute {
+		t.Errorf("published event = %+v", events[0])
+	}
+	if events[1].Status != StatusScheduled || !events[1].Start.Equal(scheduled.Time) || events[1].Duration != defaultDuration {
+		t.Errorf("scheduled event = %+v", events[1])
+	}
+}
+
+func TestWrite(t *testing.T) {
+	events := []Event{{
+		EpisodeID: 7,
+		Title:     "Ep. 7; news, " + strings.Repeat("è", 40),
+		URL:       "https://www.spreaker.com/episode/7",
+		Start:     time.Date(2024, 5, 8, 10, 0, 0, 0, time.FixedZone("CEST", 2*3600)),
+		Duration:  time.Hour,
+		Status:    StatusScheduled,
+	}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "Tech Talk", events, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Tech Talk\r\n",
+		"UID:episode-7@spreaker.com\r\n",
+		"DTSTAMP:20240501T000000Z\r\n",
+		"DTSTART:20240508T080000Z\r\n",
+		"DTEND:20240508T090000Z\r\n",
+		"SUMMARY:Ep. 7\\; news\\, ",
+		"STATUS:TENTATIVE\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar missing %q:\n%s", want, out)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, strings.Repeat("è", 40)) {
+		t.Error("folding split a character")
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLine {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Package identity caches which account a token belongs to, so commands can
+name the acting user without asking the API each time.
+
+Tokens are never stored: entries are keyed by a hash of the token.
+*/
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Identity is the account a token acts as.
+type Identity struct {
+	UserID   int       `json:"user_id"`
+	Username string    `json:"username"`
+	Fullname string    `json:"fullname"`
+	Resolved time.Time `json:"resolved"`
+}
+
+// Cache maps token hashes to the identity they were resolved to.
+type Cache struct {
+	Tokens map[string]Identity `json:"tokens"`
+}
+
+// Key returns the hash a token is cached under.
+func Key(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Load reads the cache from path. A missing file is an empty cache.
+func Load(path string) (*Cache, error) {
+	c := &Cache{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity cache: %w", err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid identity cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cache to path, replacing the file atomically.
+func (c *Cache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save identity cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save identity cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save identity cache: %w", err)
+	}
+	return nil
+}
+
+// Get returns the identity cached for token, if it was resolved less than
+// ttl ago. A ttl of 0 or less never finds one.
+func (c *Cache) Get(token string, ttl time.Duration) (Identity, bool) {
+	id, ok := c.Tokens[Key(token)]
+	if !ok || time.Since(id.Resolved) >= ttl {
+		return Identity{}, false
+	}
+	return id, true
+}
+
+// Put caches the identity of token.
+func (c *Cache) Put(token string, id Identity) {
+	if c.Tokens == nil {
+		c.Tokens = make(map[string]Identity)
+	}
+	c.Tokens[Key(token)] = id
+}
@@ -0,0 +1,52 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identities.json")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("secret-token", time.Hour); ok {
+		t.Error("empty cache has an identity")
+	}
+
+	want := Identity{UserID: 42, Username: "alice", Fullname: "Alice", Resolved: time.Now().Add(-time.Hour).UTC().Round(0)}
+	c.Put("secret-token", want)
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("the cache file holds the token")
+	}
+
+	c, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Get("secret-token", 2*time.Hour); !ok || !got.Resolved.Equal(want.Resolved) || got.UserID != want.UserID {
+		t.Errorf("Get() = %+v, %v, want %+v", got, ok, want)
+	}
+	if _, ok := c.Get("secret-token", 30*time.Minute); ok {
+		t.Error("expired identity returned")
+	}
+	if _, ok := c.Get("secret-token", 0); ok {
+		t.Error("identity returned with caching off")
+	}
+	if _, ok := c.Get("other-token", 2*time.Hour); ok {
+		t.Error("other token has an identity")
+	}
+}
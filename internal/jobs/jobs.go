@@ -0,0 +1,364 @@
+/*
+Package jobs keeps CLI commands scheduled to run later, such as an upload
+at a set time, for users without cron or another scheduler.
+
+Jobs are kept in a JSON file, changed under a lock file so that runners
+and the commands editing the queue do not overwrite each other. A runner
+claims a job by saving it as running, with its process ID, before it
+starts the command, so no other runner starts it too. A job left running
+by a runner that was killed is recovered by the next runner, as a failed
+attempt. A failed run is retried after a delay that
+doubles at each attempt, until the job has used up its retries: retried
+commands run again from the start, so they should be safe to repeat.
+*/
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Job statuses.
+const (
+	// StatusPending: the job waits for its time, or for a retry.
+	StatusPending = "pending"
+	// StatusRunning: a runner has claimed the job and is running it.
+	StatusRunning = "running"
+	// StatusDone: the job ran successfully.
+	StatusDone = "done"
+	// StatusFailed: every attempt failed; the job is not run again.
+	StatusFailed = "failed"
+)
+
+// Defaults of new jobs.
+const (
+	DefaultRetries    = 3
+	DefaultRetryDelay = 5 * time.Minute
+)
+
+// Job is a scheduled command. Command holds its arguments, without the
+// program name, e.g. ["episodes", "upload", "12345", "ep.mp3"].
+type Job struct {
+	ID         int        `json:"id"`
+	Command    []string   `json:"command"`
+	At         time.Time  `json:"at"`
+	Added      time.Time  `json:"added"`
+	Status     string     `json:"status"`
+	Retries    int        `json:"retries"`
+	RetryDelay int        `json:"retry_delay_seconds"`
+	Attempts   int        `json:"attempts"`
+	LastRun    *time.Time `json:"last_run,omitempty"`
+	LastError  string     `json:"last_error,omitempty"`
+
+	// ClaimedAt and RunnerPID record the runner of a running job.
+	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+	RunnerPID int        `json:"runner_pid,omitempty"`
+}
+
+// CommandLine returns the job's command as it would be typed in a shell.
+func (j Job) CommandLine() string {
+	return FormatCommand(j.Command)
+}
+
+// Record records the outcome of a run that ended at now. A failed job is
+// scheduled again after its retry delay, doubled for each earlier
+// attempt, until it has run Retries+1 times.
+func (j *Job) Record(now time.Time, err error) {
+	j.Attempts++
+	j.LastRun = &now
+	j.ClaimedAt, j.RunnerPID = nil, 0
+	if err == nil {
+		j.Status, j.LastError = StatusDone, ""
+		return
+	}
+
+	j.LastError = err.Error()
+	if j.Attempts > j.Retries {
+		j.Status = StatusFailed
+		return
+	}
+	delay := time.Duration(j.RetryDelay) * time.Second << (j.Attempts - 1)
+	j.Status, j.At = StatusPending, now.Add(delay)
+}
+
+// Queue is the list of jobs, in the order they were added.
+type Queue struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// Load reads the queue at path. A missing file yields an empty queue.
+func Load(path string) (*Queue, error) {
+	q := &Queue{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jobs: %w", err)
+	}
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("invalid jobs file %s: %w", path, err)
+	}
+	return q, nil
+}
+
+// Save writes the queue to path, replacing the file atomically.
+func (q *Queue) Save(path string) error {
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save jobs: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save jobs: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save jobs: %w", err)
+	}
+	return nil
+}
+
+// Lock timing: Update waits up to lockWait for the lock, and breaks a lock
+// older than staleLock, left by a process that was killed while holding
+// it. The lock is only held while the queue is read and written, never
+// while a job runs.
+var (
+	lockWait  = 10 * time.Second
+	staleLock = time.Minute
+)
+
+// Update loads the queue at path, calls fn and saves the queue unless fn
+// fails, holding the lock file path+".lock" throughout.
+func Update(path string, fn func(q *Queue) error) error {
+	unlock, err := lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	q, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(q); err != nil {
+		return err
+	}
+	return q.Save(path)
+}
+
+// lock creates the lock file at path, waiting while another process holds
+// it, and returns a func that removes it.
+func lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to lock jobs: %w", err)
+	}
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock jobs: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("jobs are locked by another process; remove %s if none is running", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Claim marks the job with the given ID as running by the process pid if
+// it is pending and due at now, and reports whether it did. A job claimed
+// by another runner meanwhile is no longer pending.
+func (q *Queue) Claim(id int, now time.Time, pid int) bool {
+	j := q.Get(id)
+	if j == nil || j.Status != StatusPending || j.At.After(now) {
+		return false
+	}
+	j.Status, j.ClaimedAt, j.RunnerPID = StatusRunning, &now, pid
+	return true
+}
+
+// Unclaim returns a running job to pending without counting an attempt,
+// e.g. when its runner was interrupted.
+func (j *Job) Unclaim() {
+	j.Status, j.ClaimedAt, j.RunnerPID = StatusPending, nil, 0
+}
+
+// errRunnerStopped is recorded for a job whose runner stopped before the
+// job finished.
+var errRunnerStopped = errors.New("the runner stopped before the job finished")
+
+// Recover records a failed attempt for each running job whose runner is
+// gone, so it is retried or fails instead of staying running forever: a
+// job claimed before now minus timeout, by when its runner would have
+// stopped it, or by a process alive reports as exited. It returns the
+// recovered jobs.
+func (q *Queue) Recover(now time.Time, timeout time.Duration, alive func(pid int) bool) []Job {
+	var recovered []Job
+	for i := range q.Jobs {
+		j := &q.Jobs[i]
+		if j.Status != StatusRunning {
+			continue
+		}
+		stale := j.ClaimedAt == nil || now.Sub(*j.ClaimedAt) > timeout
+		if !stale && (j.RunnerPID == 0 || alive(j.RunnerPID)) {
+			continue
+		}
+		j.Record(now, errRunnerStopped)
+		recovered = append(recovered, *j)
+	}
+	return recovered
+}
+
+// Add appends a pending job with the next ID and returns it.
+func (q *Queue) Add(j Job) Job {
+	j.ID = 1
+	if n := len(q.Jobs); n > 0 {
+		j.ID = q.Jobs[n-1].ID + 1
+	}
+	j.Status = StatusPending
+	q.Jobs = append(q.Jobs, j)
+	return j
+}
+
+// Get returns the job with the given ID, or nil.
+func (q *Queue) Get(id int) *Job {
+	for i := range q.Jobs {
+		if q.Jobs[i].ID == id {
+			return &q.Jobs[i]
+		}
+	}
+	return nil
+}
+
+// Remove drops the job with the given ID and reports whether it was
+// queued.
+func (q *Queue) Remove(id int) bool {
+	for i, j := range q.Jobs {
+		if j.ID == id {
+			q.Jobs = append(q.Jobs[:i], q.Jobs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Due returns the pending jobs whose time has come at now, earliest
+// first.
+func (q *Queue) Due(now time.Time) []Job {
+	var due []Job
+	for _, j := range q.Jobs {
+		if j.Status == StatusPending && !j.At.After(now) {
+			due = append(due, j)
+		}
+	}
+	sort.SliceStable(due, func(i, k int) bool { return due[i].At.Before(due[k].At) })
+	return due
+}
+
+// timeLayouts are the formats ParseAt accepts, besides durations.
+var timeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// ParseAt parses the time of a job: a date and time such as
+// "2024-07-01 09:00" in loc (unless it has an offset), or a duration from
+// now such as "90m".
+func ParseAt(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid time %q: must not be negative", s)
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use YYYY-MM-DD HH:MM, or a duration such as 90m)", s)
+}
+
+// SplitCommand splits a command line into arguments like a shell does
+// for words, single and double quotes and backslashes, without expanding
+// anything.
+func SplitCommand(s string) ([]string, error) {
+	var (
+		args    []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
+
+// FormatCommand joins arguments into a command line SplitCommand reads
+// back, quoting those that need it.
+func FormatCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && !strings.ContainsAny(a, " \t\n'\"\\$`*?&|;<>()#~") {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	q, err := Load(path)
+	if err != nil || len(q.Jobs) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v", q, err)
+	}
+	q.Add(Job{Command: []string{"me"}, At: now.Add(time.Hour)})
+	q.Add(Job{Command: []string{"episodes", "list"}, At: now})
+	q.Add(Job{Command: []string{"shows", "list"}, At: now.Add(-time.Hour)})
+	if err := q.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Jobs) != 3 || q.Jobs[2].ID != 3 || q.Jobs[2].Status != StatusPending {
+		t.Fatalf("jobs = %+v", q.Jobs)
+	}
+
+	due := q.Due(now)
+	if len(due) != 2 || due[0].ID != 3 || due[1].ID != 2 {
+		t.Errorf("Due() = %+v, want jobs 3 and 2", due)
+	}
+	q.Get(3).Status = StatusDone
+	if due := q.Due(now); len(due) != 1 || due[0].ID != 2 {
+		t.Errorf("Due() after a run = %+v, want job 2", due)
+	}
+
+	if !q.Remove(1) || q.Remove(1) || q.Get(1) != nil || len(q.Jobs) != 2 {
+		t.Errorf("Remove(1) left %+v", q.Jobs)
+	}
+}
+
+func TestQueue_Claim(t *testing.T) {
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	q := &Queue{}
+	q.Add(Job{Command: []string{"me"}, At: now})
+	q.Add(Job{Command: []string{"me"}, At: now.Add(time.Hour)})
+
+	if !q.Claim(1, now, 42) || q.Get(1).Status != StatusRunning || q.Get(1).RunnerPID != 42 || !q.Get(1).ClaimedAt.Equal(now) {
+		t.Fatalf("Claim(1) left %+v", q.Get(1))
+	}
+	if q.Claim(1, now, 43) {
+		t.Error("a running job was claimed twice")
+	}
+	if q.Claim(2, now, 42) || q.Claim(3, now, 42) {
+		t.Error("a job that is not due, or missing, was claimed")
+	}
+	if due := q.Due(now); len(due) != 0 {
+		t.Errorf("Due() = %+v, want no running job", due)
+	}
+}
+
+func TestQueue_Recover(t *testing.T) {
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	q := &Queue{}
+	for range 4 {
+		q.Add(Job{Command: []string{"me"}, At: now.Add(-2 * time.Hour), Retries: 1, RetryDelay: 60})
+	}
+	q.Claim(1, now.Add(-time.Minute), 10) // running, runner alive
+	q.Claim(2, now.Add(-time.Minute), 20) // runner killed
+	q.Claim(3, now.Add(-2*time.Hour), 10) // runner alive, past the timeout
+	q.Get(4).Status = StatusRunning       // claimed before claims were recorded
+	alive := func(pid int) bool { return pid == 10 }
+
+	recovered := q.Recover(now, time.Hour, alive)
+
+	if len(recovered) != 3 || recovered[0].ID != 2 || recovered[1].ID != 3 || recovered[2].ID != 4 {
+		t.Fatalf("Recover() = %+v, want jobs 2, 3 and 4", recovered)
+	}
+	if j := q.Get(1); j.Status != StatusRunning || j.Attempts != 0 {
+		t.Errorf("job 1 = %+v, want it still running", j)
+	}
+	for _, id := range []int{2, 3, 4} {
+		j := q.Get(id)
+		if j.Status != StatusPending || j.Attempts != 1 || j.RunnerPID != 0 || j.ClaimedAt != nil || j.LastError == "" {
+			t.Errorf("job %d = %+v, want a pending retry after one failed attempt", id, j)
+		}
+	}
+
+	// A recovered job that has used up its retries fails.
+	q.Get(2).At = now
+	q.Claim(2, now, 20)
+	q.Recover(now, time.Hour, alive)
+	if j := q.Get(2); j.Status != StatusFailed || j.Attempts != 2 {
+		t.Errorf("job 2 = %+v, want failed after its last attempt", j)
+	}
+}
+
+func TestUpdate_Lock(t *testing.T) {
+	wait := lockWait
+	t.Cleanup(func() { lockWait = wait })
+	lockWait = 100 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	add := func(q *Queue) error {
+		q.Add(Job{Command: []string{"me"}})
+		return nil
+	}
+
+	// Held by another process.
+	lockPath := path + ".lock"
+	if err := os.WriteFile(lockPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(path, add); err == nil {
+		t.Fatal("Update() ran while the queue was locked")
+	}
+
+	// Left by a process that was killed.
+	old := time.Now().Add(-2 * staleLock)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(path, add); err != nil {
+		t.Fatalf("Update() with a stale lock = %v", err)
+	}
+	if _, err := os.Stat(lockPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("lock file left after Update(): %v", err)
+	}
+
+	// A failing fn leaves the queue alone.
+	if err := Update(path, func(q *Queue) error { q.Jobs = nil; return errors.New("no") }); err == nil {
+		t.Error("Update() hid the error of fn")
+	}
+	if q, err := Load(path); err != nil || len(q.Jobs) != 1 {
+		t.Errorf("queue = %+v, %v; want the one job", q, err)
+	}
+}
+
+func TestJob_Record(t *testing.T) {
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	j := Job{Status: StatusPending, Retries: 2, RetryDelay: 60}
+	fail := errors.New("exit status 1")
+
+	j.Record(now, fail)
+	if j.Status != StatusPending || !j.At.Equal(now.Add(time.Minute)) || j.LastError != "exit status 1" {
+		t.Errorf("after the first failure: %+v", j)
+	}
+	j.Record(now, fail)
+	if j.Status != StatusPending || !j.At.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("after the second failure: %+v", j)
+	}
+	j.Record(now, fail)
+	if j.Status != StatusFailed || j.Attempts != 3 {
+		t.Errorf("after the last failure: %+v", j)
+	}
+
+	ok := Job{Status: StatusPending, LastError: "earlier"}
+	ok.Record(now, nil)
+	if ok.Status != StatusDone || ok.LastError != "" || ok.LastRun == nil || !ok.LastRun.Equal(now) {
+		t.Errorf("after a success: %+v", ok)
+	}
+}
+
+func TestParseAt(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Skip("no time zone data")
+	}
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-07-01 09:00", time.Date(2024, 7, 1, 9, 0, 0, 0, rome)},
+		{"2024-07-01T09:00:30", time.Date(2024, 7, 1, 9, 0, 30, 0, rome)},
+		{"2024-07-01T09:00:00Z", now},
+		{"90m", now.Add(90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		got, err := ParseAt(tt.in, now, rome)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseAt(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"tomorrow", "-5m", "2024-07-01"} {
+		if _, err := ParseAt(in, now, rome); err == nil {
+			t.Errorf("ParseAt(%q): want error", in)
+		}
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{`episodes upload 12345 ep.mp3`, []string{"episodes", "upload", "12345", "ep.mp3"}},
+		{`episodes upload ep.mp3 --title "Episode 42: The Answer"`, []string{"episodes", "upload", "ep.mp3", "--title", "Episode 42: The Answer"}},
+		{`messages post 3 'It''s "live"' a\ b ""`, []string{"messages", "post", "3", `Its "live"`, "a b", ""}},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommand(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommand(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+		if back, _ := SplitCommand(FormatCommand(got)); !reflect.DeepEqual(back, tt.want) {
+			t.Errorf("SplitCommand(FormatCommand(%q)) = %q", got, back)
+		}
+	}
+
+	if _, err := SplitCommand(`episodes upload --title "open`); err == nil {
+		t.Error("SplitCommand() with an open quote: want error")
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Package journal records the changes the CLI makes to an account, so the
+last one can be inspected and undone.
+
+Each request that changed data is an entry, with the values it replaced
+when they could be fetched first. Toggles such as favorites and likes,
+and updates of shows, episodes and profiles with a snapshot, can be
+reversed; uploads and deletions cannot.
+*/
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxEntries bounds the number of entries kept; older ones are dropped.
+const MaxEntries = 500
+
+// Entry is a request that changed data.
+type Entry struct {
+	ID      int               `json:"id"`
+	Time    time.Time         `json:"time"`
+	Command string            `json:"command"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Before  map[string]string `json:"before,omitempty"`
+
+	// Undone is set once the entry has been reversed; UndoOf is the ID of
+	// the entry an undo request reversed.
+	Undone bool `json:"undone,omitempty"`
+	UndoOf int  `json:"undo_of,omitempty"`
+}
+
+// Reversal is the request that reverses an entry.
+type Reversal struct {
+	Method string
+	Path   string
+	Fields map[string]string
+}
+
+func (r Reversal) String() string {
+	return r.Method + " " + r.Path
+}
+
+// togglePath matches the relations set with PUT and cleared with DELETE.
+var togglePath = regexp.MustCompile(`^/users/\d+/(favorites|likes|bookmarks|followings|blocks)/\d+$`)
+
+// updatePath matches the resources updated with a form POST.
+var updatePath = regexp.MustCompile(`^/(shows|episodes|users)/\d+$`)
+
+// IsToggle reports whether a request sets or clears a favorite, like,
+// bookmark, follow or block.
+func IsToggle(method, path string) bool {
+	return (method == http.MethodPut || method == http.MethodDelete) && togglePath.MatchString(path)
+}
+
+// IsUpdate reports whether a request updates a show, episode or user, so
+// a snapshot of the fields it changes makes it reversible.
+func IsUpdate(method, path string) bool {
+	return method == http.MethodPost && updatePath.MatchString(path)
+}
+
+// Reverse returns the request that undoes the entry. Undo requests
+// themselves, and updates without a complete snapshot, are not reversible.
+func (e Entry) Reverse() (Reversal, bool) {
+	if e.UndoOf != 0 {
+		return Reversal{}, false
+	}
+	switch {
+	case IsToggle(e.Method, e.Path) && e.Method == http.MethodPut:
+		return Reversal{Method: http.MethodDelete, Path: e.Path}, true
+	case IsToggle(e.Method, e.Path):
+		return Reversal{Method: http.MethodPut, Path: e.Path}, true
+	case IsUpdate(e.Method, e.Path) && len(e.Fields) > 0:
+		for field := range e.Fields {
+			if _, ok := e.Before[field]; !ok {
+				return Reversal{}, false
+			}
+		}
+		return Reversal{Method: http.MethodPost, Path: e.Path, Fields: e.Before}, true
+	}
+	return Reversal{}, false
+}
+
+// Snapshot returns the values of fields in resource, a show, episode or
+// user as returned by the API, formatted as form fields. Fields missing
+// from resource are left out.
+func Snapshot(resource map[string]interface{}, fields map[string]string) map[string]string {
+	before := make(map[string]string, len(fields))
+	for field := range fields {
+		value, ok := resource[field]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case nil:
+			before[field] = ""
+		case string:
+			before[field] = v
+		case bool:
+			before[field] = strconv.FormatBool(v)
+		case float64:
+			before[field] = strconv.FormatFloat(v, 'f', -1, 64)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			before[field] = strings.Join(items, ",")
+		}
+	}
+	return before
+}
+
+// Journal is the list of recorded entries, oldest first.
+type Journal struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads the journal at path. A missing file yields an empty journal.
+func Load(path string) (*Journal, error) {
+	j := &Journal{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("invalid journal %s: %w", path, err)
+	}
+	return j, nil
+}
+
+// Save writes the journal to path, replacing the file atomically.
+func (j *Journal) Save(path string) error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save journal: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save journal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save journal: %w", err)
+	}
+	return nil
+}
+
+// Add appends an entry with the next ID, dropping the oldest entries
+// beyond MaxEntries, and returns it.
+func (j *Journal) Add(e Entry) Entry {
+	e.ID = 1
+	if n := len(j.Entries); n > 0 {
+		e.ID = j.Entries[n-1].ID + 1
+	}
+	j.Entries = append(j.Entries, e)
+	if len(j.Entries) > MaxEntries {
+		j.Entries = j.Entries[len(j.Entries)-MaxEntries:]
+	}
+	return e
+}
+
+// Get returns the entry with the given ID.
+func (j *Journal) Get(id int) (*Entry, bool) {
+	for i := range j.Entries {
+		if j.Entries[i].ID == id {
+			return &j.Entries[i], true
+		}
+	}
+	return nil, false
+}
+
+// LastReversible returns the latest entry that can be reversed and has
+// not been, skipping undo requests, so repeated undos walk back through
+// the journal.
+func (j *Journal) LastReversible() (*Entry, bool) {
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		e := &j.Entries[i]
+		if e.Undone {
+			continue
+		}
+		if _, ok := e.Reverse(); ok {
+			return e, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,101 @@
+package journal
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		want  Reversal
+		ok    bool
+	}{
+		{"favorite", Entry{Method: "PUT", Path: "/users/1/favorites/12"}, Reversal{Method: "DELETE", Path: "/users/1/favorites/12"}, true},
+		{"unlike", Entry{Method: "DELETE", Path: "/users/1/likes/3"}, Reversal{Method: "PUT", Path: "/users/1/likes/3"}, true},
+		{"update with snapshot", Entry{
+			Method: "POST", Path: "/episodes/3",
+			Fields: map[string]string{"title": "New"},
+			Before: map[string]string{"title": "Old"},
+		}, Reversal{Method: "POST", Path: "/episodes/3", Fields: map[string]string{"title": "Old"}}, true},
+		{"update without snapshot", Entry{Method: "POST", Path: "/shows/2", Fields: map[string]string{"title": "New"}}, Reversal{}, false},
+		{"delete episode", Entry{Method: "DELETE", Path: "/episodes/3"}, Reversal{}, false},
+		{"upload", Entry{Method: "POST", Path: "/shows/2/episodes", Fields: map[string]string{"title": "Ep"}}, Reversal{}, false},
+		{"undo", Entry{Method: "DELETE", Path: "/users/1/likes/3", UndoOf: 4}, Reversal{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.entry.Reverse()
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reverse() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	resource := map[string]interface{}{
+		"title":            "Old",
+		"description":      nil,
+		"tags":             []interface{}{"tech", "weekly"},
+		"explicit":         false,
+		"category_id":      float64(21),
+		"download_enabled": true,
+	}
+	fields := map[string]string{"title": "", "description": "", "tags": "", "explicit": "", "category_id": "", "missing": ""}
+
+	want := map[string]string{"title": "Old", "description": "", "tags": "tech,weekly", "explicit": "false", "category_id": "21"}
+	if got := Snapshot(resource, fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+
+	j, err := Load(path)
+	if err != nil || len(j.Entries) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v", j, err)
+	}
+	if _, ok := j.LastReversible(); ok {
+		t.Error("empty journal has a reversible entry")
+	}
+
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	j.Add(Entry{Time: now, Method: "PUT", Path: "/users/1/likes/3"})
+	j.Add(Entry{Time: now, Method: "DELETE", Path: "/episodes/9"})
+	if err := j.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := j.LastReversible()
+	if !ok || e.ID != 1 {
+		t.Fatalf("LastReversible() = %+v, %v; want entry 1", e, ok)
+	}
+	e.Undone = true
+	j.Add(Entry{Method: "DELETE", Path: "/users/1/likes/3", UndoOf: 1})
+	if e, ok := j.LastReversible(); ok {
+		t.Errorf("LastReversible() = %+v after undo, want none", e)
+	}
+	if got, _ := j.Get(1); !got.Undone {
+		t.Error("entry 1 not marked undone")
+	}
+}
+
+func TestJournal_MaxEntries(t *testing.T) {
+	var j Journal
+	for i := 0; i < MaxEntries+5; i++ {
+		j.Add(Entry{Method: "PUT", Path: "/users/1/likes/3"})
+	}
+	if len(j.Entries) != MaxEntries || j.Entries[0].ID != 6 {
+		t.Errorf("kept %d entries from ID %d, want %d from 6", len(j.Entries), j.Entries[0].ID, MaxEntries)
+	}
+}
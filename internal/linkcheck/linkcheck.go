@@ -0,0 +1,129 @@
+/*
+Package linkcheck finds URLs in free text and checks whether they still resolve.
+
+It is used by shows check-links to keep old show notes and chapter links healthy.
+*/
+package linkcheck
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultWorkers is the number of links checked in parallel by default.
+const DefaultWorkers = 8
+
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+// Extract returns the distinct http(s) URLs in text, in order of appearance.
+// Trailing punctuation that usually ends a sentence rather than a URL is dropped.
+func Extract(text string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, u := range urlPattern.FindAllString(text, -1) {
+		u = strings.TrimRight(u, ".,;:!?)]}")
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// Link is a URL found on an episode, with where it was found.
+type Link struct {
+	EpisodeID int    `json:"episode_id"`
+	Title     string `json:"title"`
+	Source    string `json:"source"` // "description" or "chapter: <title>"
+	URL       string `json:"url"`
+}
+
+// BrokenLink is a Link that failed its check.
+type BrokenLink struct {
+	Link
+	Status int    `json:"status,omitempty"` // HTTP status, 0 if the request failed
+	Error  string `json:"error"`
+}
+
+// Check requests every link with up to workers concurrent requests and
+// returns those that are broken, in the order given. Each distinct URL is
+// requested once. A link is broken if the request fails or the final
+// response status is 400 or above.
+func Check(client *http.Client, links []Link, workers int) []BrokenLink {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var urls []string
+	errs := make(map[string]*BrokenLink)
+	for _, l := range links {
+		if _, ok := errs[l.URL]; !ok {
+			errs[l.URL] = nil
+			urls = append(urls, l.URL)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range jobs {
+				status, err := checkURL(client, u)
+				if err == nil {
+					continue
+				}
+				mu.Lock()
+				errs[u] = &BrokenLink{Status: status, Error: err.Error()}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, u := range urls {
+		jobs <- u
+	}
+	close(jobs)
+	wg.Wait()
+
+	var broken []BrokenLink
+	for _, l := range links {
+		if b := errs[l.URL]; b != nil {
+			broken = append(broken, BrokenLink{Link: l, Status: b.Status, Error: b.Error})
+		}
+	}
+	return broken
+}
+
+// checkURL sends a HEAD request, falling back to GET for servers that
+// do not support HEAD.
+func checkURL(client *http.Client, u string) (int, error) {
+	status, err := request(client, http.MethodHead, u)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = request(client, http.MethodGet, u)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if status >= 400 {
+		return status, fmt.Errorf("%d %s", status, http.StatusText(status))
+	}
+	return status, nil
+}
+
+func request(client *http.Client, method, u string) (int, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,68 @@
+package linkcheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	text := `Links: https://example.com/a, (see http://example.org/b).
+<a href="https://example.com/a">again</a> and https://example.net/c?x=1!`
+
+	got := Extract(text)
+	want := []string{"https://example.com/a", "http://example.org/b", "https://example.net/c?x=1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract() = %v, want %v", got, want)
+	}
+
+	if got := Extract("no links here"); len(got) != 0 {
+		t.Errorf("Extract() = %v, want none", got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var heads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/gone":
+			if r.Method == http.MethodHead {
+				heads++
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	links := []Link{
+		{EpisodeID: 1, Source: "description", URL: srv.URL + "/ok"},
+		{EpisodeID: 1, Source: "description", URL: srv.URL + "/gone"},
+		{EpisodeID: 2, Source: "chapter: Intro", URL: srv.URL + "/gone"},
+		{EpisodeID: 2, Source: "description", URL: srv.URL + "/nohead"},
+		{EpisodeID: 3, Source: "description", URL: "http://127.0.0.1:0/unreachable"},
+	}
+
+	broken := Check(srv.Client(), links, 2)
+
+	if len(broken) != 3 {
+		t.Fatalf("got %d broken links, want 3: %+v", len(broken), broken)
+	}
+	if broken[0].EpisodeID != 1 || broken[0].Status != http.StatusNotFound {
+		t.Errorf("broken[0] = %+v, want episode 1 with status 404", broken[0])
+	}
+	if broken[1].EpisodeID != 2 || broken[1].Source != "chapter: Intro" {
+		t.Errorf("broken[1] = %+v, want episode 2 chapter link", broken[1])
+	}
+	if broken[2].EpisodeID != 3 || broken[2].Status != 0 || broken[2].Error == "" {
+		t.Errorf("broken[2] = %+v, want request error for episode 3", broken[2])
+	}
+	if heads != 1 {
+		t.Errorf("HEAD /gone requested %d times, want 1", heads)
+	}
+}
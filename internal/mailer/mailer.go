@@ -0,0 +1,117 @@
+/*
+Package mailer sends reports by email through an SMTP server.
+
+smtp.SendMail upgrades the connection with STARTTLS when the server offers
+it, and refuses to send credentials over an unencrypted connection except
+to localhost.
+*/
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPort is the submission port used when Server.Port is 0.
+const DefaultPort = 587
+
+// Content types of message bodies.
+const (
+	ContentTypeText = "text/plain"
+	ContentTypeHTML = "text/html"
+)
+
+// Server is an SMTP server. An empty Username sends without authentication.
+type Server struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// Message is a single-part email.
+type Message struct {
+	From        string
+	To          []string
+	Subject     string
+	ContentType string
+	Body        string
+	Date        time.Time
+}
+
+// Bytes encodes the message as RFC 5322 text, with a UTF-8 quoted-printable
+// body so any line length and character set survive transport.
+func (m Message) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", m.Date.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", m.ContentType+"; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(strings.ReplaceAll(m.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Send delivers the message through the server.
+func Send(s Server, m Message) error {
+	if s.Host == "" {
+		return fmt.Errorf("no SMTP host configured")
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", m.From, err)
+	}
+	to := make([]string, len(m.To))
+	for i, addr := range m.To {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", addr, err)
+		}
+		to[i] = parsed.Address
+	}
+
+	body, err := m.Bytes()
+	if err != nil {
+		return err
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(s.Host, strconv.Itoa(port)), auth, from.Address, to, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
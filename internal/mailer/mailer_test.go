@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	m := Message{
+		From:        "Podcast <me@example.com>",
+		To:          []string{"a@example.com", "b@example.com"},
+		Subject:     "Riepilogo settimanale – 12345",
+		ContentType: ContentTypeHTML,
+		Body:        "<p>Più ascolti</p>\n" + strings.Repeat("x", 100),
+		Date:        time.Date(2024, 3, 4, 8, 0, 0, 0, time.UTC),
+	}
+
+	data, err := m.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+
+	for _, want := range []string{
+		"From: Podcast <me@example.com>\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: =?utf-8?q?Riepilogo_settimanale_=E2=80=93_12345?=\r\n",
+		"Date: Mon, 04 Mar 2024 08:00:00 +0000\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n",
+		"\r\n\r\n<p>Pi=C3=B9 ascolti</p>\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("message missing %q:\n%s", want, got)
+		}
+	}
+	for _, line := range strings.Split(got, "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("line longer than 76 characters: %q", line)
+		}
+	}
+}
+
+func TestSend_Validation(t *testing.T) {
+	m := Message{From: "me@example.com", To: []string{"a@example.com"}}
+
+	if err := Send(Server{}, m); err == nil {
+		t.Error("Send without host succeeded")
+	}
+	if err := Send(Server{Host: "localhost"}, Message{From: "me@example.com"}); err == nil {
+		t.Error("Send without recipients succeeded")
+	}
+	if err := Send(Server{Host: "localhost"}, Message{From: "me", To: m.To}); err == nil || !strings.Contains(err.Error(), "invalid sender") {
+		t.Errorf("Send with a bad sender: err = %v", err)
+	}
+}
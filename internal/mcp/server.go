@@ -0,0 +1,272 @@
+/*
+Package mcp implements a Model Context Protocol server over stdio.
+
+The server speaks newline-delimited JSON-RPC 2.0 and exposes Spreaker API
+operations as tools, so assistants and automation frameworks can drive
+Spreaker with the CLI's configured token. Only read-only tools are listed
+unless the server is created with write access.
+*/
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// ProtocolVersion is the MCP revision implemented by this server.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessageSize bounds a single JSON-RPC message read from the client (4 MB).
+const maxMessageSize = 4 << 20
+
+// Options configures a Server.
+type Options struct {
+	// Name and Version are reported to the client during initialization.
+	Name    string
+	Version string
+
+	// AllowWrites exposes tools that modify the account (e.g. upload_episode).
+	AllowWrites bool
+
+	// OnEpisodeUploaded is called after a successful upload, e.g. to run
+	// the episode_published hook. Optional.
+	OnEpisodeUploaded func(ctx context.Context, episode *models.Episode)
+}
+
+// Server dispatches MCP requests to the Spreaker API client.
+type Server struct {
+	client *api.Client
+	opts   Options
+	tools  []tool
+
+	mu  sync.Mutex // serializes writes to out
+	out io.Writer
+}
+
+// New creates a Server that calls the Spreaker API through client.
+func New(client *api.Client, opts Options) *Server {
+	s := &Server{client: client, opts: opts}
+	for _, t := range allTools() {
+		if t.write && !opts.AllowWrites {
+			continue
+		}
+		s.tools = append(s.tools, t)
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from in and writes responses to out until in is
+// exhausted or ctx is cancelled. Requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+
+	// Read in a separate goroutine so cancellation (Ctrl+C) is not blocked
+	// on a pending read from stdin.
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			if len(line) == 0 {
+				continue
+			}
+
+			var req request
+			if err := json.Unmarshal(line, &req); err != nil {
+				s.writeError(json.RawMessage("null"), codeParseError, "parse error")
+				continue
+			}
+			s.handle(ctx, req)
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req request) {
+	// Requests without an ID are notifications and get no response.
+	isNotification := len(req.ID) == 0
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if !isNotification {
+			s.writeError(req.ID, codeInvalidRequest, "invalid request")
+		}
+		return
+	}
+
+	var (
+		result interface{}
+		rpcErr *rpcError
+	)
+	switch req.Method {
+	case "initialize":
+		result = s.initialize()
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = s.listTools()
+	case "tools/call":
+		result, rpcErr = s.callTool(ctx, req.Params)
+	default:
+		if isNotification {
+			// e.g. notifications/initialized: nothing to do
+			return
+		}
+		rpcErr = &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+
+	if isNotification {
+		return
+	}
+	if rpcErr != nil {
+		s.writeError(req.ID, rpcErr.Code, rpcErr.Message)
+		return
+	}
+	s.write(response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"protocolVersion": ProtocolVersion,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]string{
+			"name":    s.opts.Name,
+			"version": s.opts.Version,
+		},
+	}
+}
+
+func (s *Server) listTools() interface{} {
+	list := make([]map[string]interface{}, len(s.tools))
+	for i, t := range s.tools {
+		list[i] = map[string]interface{}{
+			"name":        t.name,
+			"description": t.description,
+			"inputSchema": t.schema,
+		}
+	}
+	return map[string]interface{}{"tools": list}
+}
+
+// callTool runs a tool. API failures are reported as tool results with
+// isError set, so the model can see and react to them; only malformed
+// calls are JSON-RPC errors.
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid params"}
+	}
+
+	var t *tool
+	for i := range s.tools {
+		if s.tools[i].name == params.Name {
+			t = &s.tools[i]
+			break
+		}
+	}
+	if t == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+	}
+
+	args := arguments{}
+	if len(params.Arguments) > 0 && string(params.Arguments) != "null" {
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "arguments must be an object"}
+		}
+	}
+
+	value, err := t.run(ctx, s, args)
+	if err != nil {
+		return toolResult(err.Error(), true), nil
+	}
+
+	text, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return toolResult(err.Error(), true), nil
+	}
+	return toolResult(string(text), false), nil
+}
+
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func (s *Server) writeError(id json.RawMessage, code int, msg string) {
+	s.write(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}})
+}
+
+func (s *Server) write(resp response) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Write(append(data, '\n'))
+}
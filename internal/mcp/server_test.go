@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+// serve runs the server over the given newline-delimited requests and
+// returns the decoded responses.
+func serve(t *testing.T, s *Server, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var responses []map[string]interface{}
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func testClient(t *testing.T) *api.Client {
+	t.Helper()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v2/shows/404" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"error": map[string]interface{}{"messages": []string{"Show not found"}}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": map[string]interface{}{"show": map[string]interface{}{"show_id": 12, "title": "Test Show"}},
+		})
+	}))
+	t.Cleanup(up.Close)
+
+	c := api.NewClient("test-token")
+	c.BaseURL = up.URL
+	return c
+}
+
+func TestServe_InitializeAndNotification(t *testing.T) {
+	s := New(testClient(t), Options{Name: "spreaker", Version: "test"})
+
+	responses := serve(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	)
+
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2 (notifications get none)", len(responses))
+	}
+	result := responses[0]["result"].(map[string]interface{})
+	if result["protocolVersion"] != ProtocolVersion {
+		t.Errorf("protocolVersion = %v", result["protocolVersion"])
+	}
+}
+
+func TestServe_ToolsListHidesWritesByDefault(t *testing.T) {
+	list := func(opts Options) []string {
+		responses := serve(t, New(testClient(t), opts), `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		var names []string
+		for _, tl := range responses[0]["result"].(map[string]interface{})["tools"].([]interface{}) {
+			names = append(names, tl.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+
+	if names := list(Options{}); strings.Contains(strings.Join(names, ","), "upload_episode") {
+		t.Errorf("upload_episode listed without AllowWrites: %v", names)
+	}
+	if names := list(Options{AllowWrites: true}); !strings.Contains(strings.Join(names, ","), "upload_episode") {
+		t.Errorf("upload_episode missing with AllowWrites: %v", names)
+	}
+}
+
+func TestServe_ToolsCall(t *testing.T) {
+	s := New(testClient(t), Options{})
+
+	responses := serve(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_show","arguments":{"show_id":12}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_show","arguments":{"show_id":404}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_show","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"upload_episode","arguments":{}}}`,
+	)
+
+	toolResult := func(resp map[string]interface{}) (string, bool) {
+		result := resp["result"].(map[string]interface{})
+		text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+		return text, result["isError"].(bool)
+	}
+
+	if text, isErr := toolResult(responses[0]); isErr || !strings.Contains(text, `"show_id": 12`) {
+		t.Errorf("get_show: isError=%v text=%s", isErr, text)
+	}
+	if text, isErr := toolResult(responses[1]); !isErr || !strings.Contains(text, "Show not found") {
+		t.Errorf("get_show 404: isError=%v text=%s", isErr, text)
+	}
+	if _, isErr := toolResult(responses[2]); !isErr {
+		t.Error("get_show without show_id should be a tool error")
+	}
+	if responses[3]["error"] == nil {
+		t.Error("upload_episode without AllowWrites should be a JSON-RPC error")
+	}
+}
+
+func TestServe_InvalidMessages(t *testing.T) {
+	s := New(testClient(t), Options{})
+
+	responses := serve(t, s,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"nope"}`,
+	)
+
+	codes := []float64{codeParseError, codeMethodNotFound}
+	for i, want := range codes {
+		errObj, ok := responses[i]["error"].(map[string]interface{})
+		if !ok || errObj["code"].(float64) != want {
+			t.Errorf("response %d: error = %v, want code %v", i, responses[i]["error"], want)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+// tool is an operation exposed to MCP clients.
+type tool struct {
+	name        string
+	description string
+	schema      map[string]interface{}
+	write       bool // modifies the account; requires Options.AllowWrites
+	run         func(ctx context.Context, s *Server, args arguments) (interface{}, error)
+}
+
+// arguments are the decoded "arguments" object of a tools/call request.
+type arguments map[string]interface{}
+
+// id returns a required positive integer argument.
+func (a arguments) id(key string) (int, error) {
+	n, ok := a[key].(float64)
+	if !ok || n <= 0 || n != math.Trunc(n) {
+		return 0, fmt.Errorf("%s is required and must be a positive integer", key)
+	}
+	return int(n), nil
+}
+
+// string returns an optional string argument.
+func (a arguments) string(key string) string {
+	s, _ := a[key].(string)
+	return s
+}
+
+// bool returns an optional boolean argument, or def when absent.
+func (a arguments) bool(key string, def bool) bool {
+	if b, ok := a[key].(bool); ok {
+		return b
+	}
+	return def
+}
+
+// pagination returns the optional limit and offset arguments.
+func (a arguments) pagination() api.PaginationParams {
+	var p api.PaginationParams
+	if n, ok := a["limit"].(float64); ok && n > 0 {
+		p.Limit = int(math.Min(n, api.MaxPageLimit))
+	}
+	if n, ok := a["offset"].(float64); ok && n > 0 {
+		p.Offset = int(n)
+	}
+	return p
+}
+
+// statistics returns the optional from, to and group arguments.
+func (a arguments) statistics() api.StatisticsParams {
+	return api.StatisticsParams{
+		From:  a.string("from"),
+		To:    a.string("to"),
+		Group: a.string("group"),
+	}
+}
+
+// objectSchema builds a JSON Schema object with the given properties.
+func objectSchema(required []string, props map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func prop(typ, description string) map[string]interface{} {
+	return map[string]interface{}{"type": typ, "description": description}
+}
+
+var (
+	paginationProps = map[string]interface{}{
+		"limit":  prop("integer", fmt.Sprintf("Maximum number of items (1-%d)", api.MaxPageLimit)),
+		"offset": prop("integer", "Number of items to skip"),
+	}
+	dateRangeProps = map[string]interface{}{
+		"from":  prop("string", "Start date (YYYY-MM-DD)"),
+		"to":    prop("string", "End date (YYYY-MM-DD)"),
+		"group": prop("string", "Group by: day, week or month"),
+	}
+)
+
+func merge(maps ...map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// page is the result shape of paginated tools.
+type page struct {
+	Items   interface{} `json:"items"`
+	HasMore bool        `json:"has_more"`
+}
+
+func allTools() []tool {
+	return []tool{
+		{
+			name:        "get_me",
+			description: "Get the authenticated user's profile.",
+			schema:      objectSchema(nil, map[string]interface{}{}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				return s.client.GetMe()
+			},
+		},
+		{
+			name:        "list_shows",
+			description: "List the authenticated user's shows.",
+			schema:      objectSchema(nil, paginationProps),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				result, err := s.client.GetMyShows(args.pagination())
+				if err != nil {
+					return nil, err
+				}
+				return page{Items: result.Items, HasMore: result.HasMore}, nil
+			},
+		},
+		{
+			name:        "get_show",
+			description: "Get details of a show.",
+			schema: objectSchema([]string{"show_id"}, map[string]interface{}{
+				"show_id": prop("integer", "Show ID"),
+			}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				showID, err := args.id("show_id")
+				if err != nil {
+					return nil, err
+				}
+				return s.client.GetShow(showID)
+			},
+		},
+		{
+			name:        "list_episodes",
+			description: "List the episodes of a show, newest first.",
+			schema: objectSchema([]string{"show_id"}, merge(paginationProps, map[string]interface{}{
+				"show_id": prop("integer", "Show ID"),
+			})),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				showID, err := args.id("show_id")
+				if err != nil {
+					return nil, err
+				}
+				result, err := s.client.GetShowEpisodes(showID, args.pagination())
+				if err != nil {
+					return nil, err
+				}
+				return page{Items: result.Items, HasMore: result.HasMore}, nil
+			},
+		},
+		{
+			name:        "get_episode",
+			description: "Get details of an episode.",
+			schema: objectSchema([]string{"episode_id"}, map[string]interface{}{
+				"episode_id": prop("integer", "Episode ID"),
+			}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				episodeID, err := args.id("episode_id")
+				if err != nil {
+					return nil, err
+				}
+				return s.client.GetEpisode(episodeID)
+			},
+		},
+		{
+			name:        "get_show_statistics",
+			description: "Get overall statistics (plays, likes, followers) of a show.",
+			schema: objectSchema([]string{"show_id"}, map[string]interface{}{
+				"show_id": prop("integer", "Show ID"),
+			}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				showID, err := args.id("show_id")
+				if err != nil {
+					return nil, err
+				}
+				return s.client.GetShowStatistics(showID)
+			},
+		},
+		{
+			name:        "get_show_plays",
+			description: "Get a show's plays and downloads over time.",
+			schema: objectSchema([]string{"show_id", "from", "to"}, merge(dateRangeProps, map[string]interface{}{
+				"show_id": prop("integer", "Show ID"),
+			})),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				showID, err := args.id("show_id")
+				if err != nil {
+					return nil, err
+				}
+				return s.client.GetShowPlayStatistics(showID, args.statistics())
+			},
+		},
+		{
+			name:        "get_episode_statistics",
+			description: "Get overall statistics of an episode.",
+			schema: objectSchema([]string{"episode_id"}, map[string]interface{}{
+				"episode_id": prop("integer", "Episode ID"),
+			}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				episodeID, err := args.id("episode_id")
+				if err != nil {
+					return nil, err
+				}
+				return s.client.GetEpisodeStatistics(episodeID)
+			},
+		},
+		{
+			name:        "upload_episode",
+			description: "Upload a local audio file as a new episode of a show.",
+			write:       true,
+			schema: objectSchema([]string{"show_id", "title", "media_file"}, map[string]interface{}{
+				"show_id":          prop("integer", "Show ID"),
+				"title":            prop("string", "Episode title"),
+				"media_file":       prop("string", "Path to the audio file on this machine"),
+				"description":      prop("string", "Episode description"),
+				"explicit":         prop("boolean", "Contains explicit content"),
+				"download_enabled": prop("boolean", "Allow downloads (default true)"),
+				"hidden":           prop("boolean", "Upload as a private episode"),
+			}),
+			run: func(ctx context.Context, s *Server, args arguments) (interface{}, error) {
+				showID, err := args.id("show_id")
+				if err != nil {
+					return nil, err
+				}
+				episode, err := s.client.UploadEpisode(showID, api.UploadEpisodeParams{
+					Title:           args.string("title"),
+					MediaFile:       args.string("media_file"),
+					Description:     args.string("description"),
+					Explicit:        args.bool("explicit", false),
+					DownloadEnabled: args.bool("download_enabled", true),
+					Hidden:          args.bool("hidden", false),
+				})
+				if err != nil {
+					return nil, err
+				}
+				if s.opts.OnEpisodeUploaded != nil {
+					s.opts.OnEpisodeUploaded(ctx, episode)
+				}
+				return episode, nil
+			},
+		},
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Package monitor tracks tag and keyword searches over time and reports the
+episodes that were not seen in earlier runs.
+
+Seen episode IDs are kept per search in a JSON state file, so monitoring
+survives restarts.
+*/
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Source kinds.
+const (
+	KindTag   = "tag"
+	KindQuery = "query"
+)
+
+// MaxSeen bounds the number of episode IDs remembered per source.
+const MaxSeen = 1000
+
+// Source is a search that is monitored: episodes with a tag, or episodes
+// matching a search query.
+type Source struct {
+	Kind string `json:"kind"`
+	Term string `json:"term"`
+}
+
+// Key identifies the source in the state file.
+func (s Source) Key() string {
+	return s.Kind + ":" + s.Term
+}
+
+// Match is the notification payload for new episodes found for a source.
+type Match struct {
+	Source   Source           `json:"source"`
+	Episodes []models.Episode `json:"episodes"`
+}
+
+// State holds the episode IDs already seen, per source key.
+type State struct {
+	Seen map[string][]int `json:"seen"`
+}
+
+// LoadState reads the state file at path. A missing file yields an empty state.
+func LoadState(path string) (*State, error) {
+	s := &State{Seen: make(map[string][]int)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monitor state: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid monitor state %s: %w", path, err)
+	}
+	if s.Seen == nil {
+		s.Seen = make(map[string][]int)
+	}
+	return s, nil
+}
+
+// Save writes the state to path, replacing the file atomically.
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save monitor state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save monitor state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save monitor state: %w", err)
+	}
+	return nil
+}
+
+// Update records the episodes found for src and returns those not seen
+// before. The first results for a new source are the baseline: they are
+// recorded but not returned, and baseline is true.
+func (s *State) Update(src Source, episodes []models.Episode) (fresh []models.Episode, baseline bool) {
+	key := src.Key()
+	seenIDs, known := s.Seen[key]
+
+	seen := make(map[int]bool, len(seenIDs))
+	for _, id := range seenIDs {
+		seen[id] = true
+	}
+
+	for _, e := range episodes {
+		if seen[e.EpisodeID] {
+			continue
+		}
+		seen[e.EpisodeID] = true
+		seenIDs = append(seenIDs, e.EpisodeID)
+		if known {
+			fresh = append(fresh, e)
+		}
+	}
+
+	if len(seenIDs) > MaxSeen {
+		seenIDs = seenIDs[len(seenIDs)-MaxSeen:]
+	}
+	if seenIDs == nil {
+		seenIDs = []int{}
+	}
+	s.Seen[key] = seenIDs
+
+	return fresh, !known
+}
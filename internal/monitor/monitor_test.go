@@ -0,0 +1,92 @@
+package monitor
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func episodes(ids ...int) []models.Episode {
+	out := make([]models.Episode, len(ids))
+	for i, id := range ids {
+		out[i] = models.Episode{EpisodeID: id}
+	}
+	return out
+}
+
+func ids(eps []models.Episode) []int {
+	var out []int
+	for _, e := range eps {
+		out = append(out, e.EpisodeID)
+	}
+	return out
+}
+
+func TestStateUpdate(t *testing.T) {
+	s := &State{Seen: make(map[string][]int)}
+	src := Source{Kind: KindTag, Term: "my brand"}
+
+	fresh, baseline := s.Update(src, episodes(1, 2))
+	if !baseline || len(fresh) != 0 {
+		t.Fatalf("first update = %v, baseline %v; want no episodes and baseline", ids(fresh), baseline)
+	}
+
+	fresh, baseline = s.Update(src, episodes(3, 1, 4))
+	if baseline || !reflect.DeepEqual(ids(fresh), []int{3, 4}) {
+		t.Errorf("second update = %v, baseline %v; want [3 4]", ids(fresh), baseline)
+	}
+
+	// An episode that dropped out of the results is still remembered.
+	if fresh, _ = s.Update(src, episodes(2)); len(fresh) != 0 {
+		t.Errorf("third update = %v, want none", ids(fresh))
+	}
+
+	// Sources are tracked separately.
+	if _, baseline = s.Update(Source{Kind: KindQuery, Term: "my brand"}, episodes(1)); !baseline {
+		t.Error("query source should start with its own baseline")
+	}
+}
+
+func TestStateUpdate_MaxSeen(t *testing.T) {
+	s := &State{Seen: map[string][]int{"tag:x": {}}}
+	many := make([]int, MaxSeen+10)
+	for i := range many {
+		many[i] = i + 1
+	}
+	s.Update(Source{Kind: KindTag, Term: "x"}, episodes(many...))
+
+	seen := s.Seen["tag:x"]
+	if len(seen) != MaxSeen || seen[0] != 11 {
+		t.Errorf("kept %d IDs starting at %d, want %d starting at 11", len(seen), seen[0], MaxSeen)
+	}
+}
+
+func TestStateSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "monitor-state.json")
+
+	s, err := LoadState(path)
+	if err != nil || len(s.Seen) != 0 {
+		t.Fatalf("LoadState(missing) = %v, %v; want empty state", s, err)
+	}
+
+	s.Update(Source{Kind: KindTag, Term: "x"}, episodes(1, 2))
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Seen, s.Seen) {
+		t.Errorf("loaded %v, want %v", loaded.Seen, s.Seen)
+	}
+}
+
+func TestAppleScriptString(t *testing.T) {
+	if got, want := appleScriptString(`say "hi" \ bye`), `"say \"hi\" \\ bye"`; got != want {
+		t.Errorf("appleScriptString() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Package opml reads and writes podcast subscription lists in OPML, the
+format podcast apps use to import and export subscriptions.
+*/
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+type document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    head     `xml:"head"`
+	Body    body     `xml:"body"`
+}
+
+type head struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated"`
+}
+
+type body struct {
+	Outlines []outline `xml:"outline"`
+}
+
+type outline struct {
+	Type     string    `xml:"type,attr"`
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr"`
+	XMLURL   string    `xml:"xmlUrl,attr"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []outline `xml:"outline"`
+}
+
+// Feed is a subscription read from an OPML file.
+type Feed struct {
+	Title  string
+	XMLURL string
+}
+
+// Write writes an OPML subscription list with one RSS outline per show.
+func Write(w io.Writer, title string, shows []models.Show, created time.Time) error {
+	doc := document{
+		Version: "2.0",
+		Head: head{
+			Title:       title,
+			DateCreated: created.UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, s := range shows {
+		doc.Body.Outlines = append(doc.Body.Outlines, outline{
+			Type:    "rss",
+			Text:    s.Title,
+			Title:   s.Title,
+			XMLURL:  s.FeedURL(),
+			HTMLURL: s.PageURL(),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode OPML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Read parses an OPML file and returns its feeds in document order,
+// including feeds nested in folder outlines. Outlines without a feed URL
+// are skipped.
+func Read(r io.Reader) ([]Feed, error) {
+	var doc document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid OPML: %w", err)
+	}
+
+	var feeds []Feed
+	var walk func(outlines []outline)
+	walk = func(outlines []outline) {
+		for _, o := range outlines {
+			if o.XMLURL != "" {
+				title := o.Title
+				if title == "" {
+					title = o.Text
+				}
+				feeds = append(feeds, Feed{Title: strings.TrimSpace(title), XMLURL: strings.TrimSpace(o.XMLURL)})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+
+	return feeds, nil
+}
+
+var spreakerFeedRe = regexp.MustCompile(`^https?://(?:www\.|api\.)?spreaker\.com/show/(\d+)/episodes/feed`)
+
+// SpreakerShowID returns the show ID of a Spreaker RSS feed URL, such as
+// those written by Write.
+func SpreakerShowID(feedURL string) (int, bool) {
+	m := spreakerFeedRe.FindStringSubmatch(feedURL)
+	if m == nil {
+		return 0, false
+	}
+	id, err := strconv.Atoi(m[1])
+	return id, err == nil
+}
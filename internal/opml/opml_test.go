@@ -0,0 +1,118 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	shows := []models.Show{
+		{ShowID: 1, Title: "Tech & Talk", SiteURL: "https://www.spreaker.com/podcast/tech--1"},
+		{ShowID: 2, Title: "News"},
+	}
+	created := time.Date(2024, 5, 4, 18, 0, 0, 0, time.UTC)
+
+	if err := Write(&buf, "My favorites", shows, created); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Error("output should start with the XML header")
+	}
+
+	var doc document
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Version != "2.0" || doc.Head.Title != "My favorites" || doc.Head.DateCreated != "Sat, 04 May 2024 18:00:00 +0000" {
+		t.Errorf("unexpected head: version %q, %+v", doc.Version, doc.Head)
+	}
+	if len(doc.Body.Outlines) != 2 {
+		t.Fatalf("got %d outlines, want 2", len(doc.Body.Outlines))
+	}
+
+	first := doc.Body.Outlines[0]
+	if first.Type != "rss" || first.Text != "Tech & Talk" || first.XMLURL != "https://www.spreaker.com/show/1/episodes/feed" || first.HTMLURL != "https://www.spreaker.com/podcast/tech--1" {
+		t.Errorf("unexpected outline %+v", first)
+	}
+}
+
+func TestRead(t *testing.T) {
+	data := `<?xml version="1.0"?>
+<opml version="1.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Tech" title="Tech">
+      <outline type="rss" text="Tech &amp; Talk" xmlUrl="https://www.spreaker.com/show/1/episodes/feed"/>
+      <outline type="rss" text="Other" title="Other Show" xmlUrl=" https://feeds.example.com/other.xml "/>
+    </outline>
+    <outline type="link" text="No feed"/>
+  </body>
+</opml>`
+
+	feeds, err := Read(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := []Feed{
+		{Title: "Tech & Talk", XMLURL: "https://www.spreaker.com/show/1/episodes/feed"},
+		{Title: "Other Show", XMLURL: "https://feeds.example.com/other.xml"},
+	}
+	if len(feeds) != len(want) {
+		t.Fatalf("got %d feeds, want %d: %+v", len(feeds), len(want), feeds)
+	}
+	for i := range want {
+		if feeds[i] != want[i] {
+			t.Errorf("feed %d = %+v, want %+v", i, feeds[i], want[i])
+		}
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	if _, err := Read(strings.NewReader("not xml")); err == nil {
+		t.Error("expected error for invalid OPML")
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, "t", []models.Show{{ShowID: 7, Title: "Seven"}}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	feeds, err := Read(&buf)
+	if err != nil || len(feeds) != 1 {
+		t.Fatalf("Read() = %v, %v", feeds, err)
+	}
+	if id, ok := SpreakerShowID(feeds[0].XMLURL); !ok || id != 7 {
+		t.Errorf("SpreakerShowID(%q) = %d, %v; want 7", feeds[0].XMLURL, id, ok)
+	}
+}
+
+func TestSpreakerShowID(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   int
+		wantOK bool
+	}{
+		{"https://www.spreaker.com/show/123/episodes/feed", 123, true},
+		{"http://spreaker.com/show/45/episodes/feed?x=1", 45, true},
+		{"https://api.spreaker.com/show/6/episodes/feed", 6, true},
+		{"https://feeds.example.com/show/1/episodes/feed", 0, false},
+		{"https://www.spreaker.com/podcast/foo", 0, false},
+	}
+
+	for _, tt := range tests {
+		id, ok := SpreakerShowID(tt.url)
+		if id != tt.want || ok != tt.wantOK {
+			t.Errorf("SpreakerShowID(%q) = %d, %v; want %d, %v", tt.url, id, ok, tt.want, tt.wantOK)
+		}
+	}
+}
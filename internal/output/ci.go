@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// GitHub Actions ("ci") Output
+// -----------------------------------------------------------------------------
+//
+// The ci format renders tables as Markdown and messages as workflow commands
+// (::notice::, ::warning::, ::error::) so results show up as annotations in the
+// Actions UI. When GITHUB_STEP_SUMMARY is set, Markdown output is also appended
+// to the job's step summary.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+
+// printWorkflowCommand writes a workflow command such as "::error::msg".
+func printWorkflowCommand(w io.Writer, command, msg string) {
+	fmt.Fprintf(w, "::%s::%s\n", command, escapeWorkflowData(msg))
+}
+
+// escapeWorkflowData escapes characters that would otherwise end or corrupt
+// a workflow command.
+func escapeWorkflowData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// renderMarkdownTable renders a Markdown table and mirrors it to the step summary.
+func (f *Formatter) renderMarkdownTable(header []string, rows [][]string) {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(escapeMarkdownCells(header), " | ") + " |\n")
+	seps := make([]string, len(header))
+	for i := range seps {
+		seps[i] = "---"
+	}
+	b.WriteString("| " + strings.Join(seps, " | ") + " |\n")
+	for _, row := range rows {
+		b.WriteString("| " + strings.Join(escapeMarkdownCells(row), " | ") + " |\n")
+	}
+
+	fmt.Fprint(f.writer, b.String())
+	f.appendStepSummary(b.String() + "\n")
+}
+
+// renderMarkdownSection renders a Markdown heading and mirrors it to the step summary.
+func (f *Formatter) renderMarkdownSection(title string) {
+	line := "### " + title + "\n"
+	fmt.Fprint(f.writer, line)
+	f.appendStepSummary(line + "\n")
+}
+
+func escapeMarkdownCells(cells []string) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		c = strings.ReplaceAll(c, "|", "\\|")
+		out[i] = strings.ReplaceAll(c, "\n", " ")
+	}
+	return out
+}
+
+// appendStepSummary appends Markdown to $GITHUB_STEP_SUMMARY if it is set.
+func (f *Formatter) appendStepSummary(md string) {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(md)
+}
@@ -0,0 +1,201 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// -----------------------------------------------------------------------------
+// Column registry
+// -----------------------------------------------------------------------------
+
+// column describes one column of a list table for items of type T.
+// Default tables show the columns without wide set; -o wide shows all of
+// them, and --columns picks any of them by key, in the order given.
+type column[T any] struct {
+	key    string // name used with --columns
+	header string
+	wide   bool // only shown with -o wide
+	value  func(f *Formatter, item T) string
+}
+
+// SetColumns selects the columns of list tables by key (see --columns).
+// An empty list keeps the default columns.
+func (f *Formatter) SetColumns(keys []string) {
+	f.columns = nil
+	for _, k := range keys {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			f.columns = append(f.columns, k)
+		}
+	}
+}
+
+// renderColumns renders items as a table using the columns selected by
+// --columns, -o wide or the defaults. Unknown column keys are reported
+// with the list of available ones and skipped.
+func renderColumns[T any](f *Formatter, cols []column[T], items []T) {
+	selected := selectColumns(f, cols)
+
+	header := make([]string, len(selected))
+	for i, c := range selected {
+		header[i] = c.header
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(selected))
+		for j, c := range selected {
+			row[j] = c.value(f, item)
+		}
+		rows[i] = row
+	}
+	f.renderTable(header, rows)
+}
+
+func selectColumns[T any](f *Formatter, cols []column[T]) []column[T] {
+	var selected []column[T]
+
+	if len(f.columns) > 0 {
+		byKey := make(map[string]column[T], len(cols))
+		for _, c := range cols {
+			byKey[c.key] = c
+		}
+		var unknown []string
+		for _, k := range f.columns {
+			if c, ok := byKey[k]; ok {
+				selected = append(selected, c)
+			} else {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) > 0 {
+			keys := make([]string, len(cols))
+			for i, c := range cols {
+				keys[i] = c.key
+			}
+			f.PrintWarning(i18n.T("unknown columns: %s (available: %s)", strings.Join(unknown, ", "), strings.Join(keys, ", ")))
+		}
+		if len(selected) > 0 {
+			return selected
+		}
+	}
+
+	for _, c := range cols {
+		if !c.wide || f.format == FormatWide {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// clip truncates s for a table cell whose default width is max, except
+// in wide output or with truncation off (see SetTruncation).
+func (f *Formatter) clip(s string, max int) string {
+	if f.format == FormatWide || f.noTruncate {
+		return s
+	}
+	return truncate(s, f.cellWidth(max))
+}
+
+// cellWidth returns the width of a table cell whose default width is max.
+func (f *Formatter) cellWidth(max int) int {
+	if f.maxWidth > 0 {
+		return f.maxWidth
+	}
+	if f.termWidth > baseTermWidth {
+		return max * f.termWidth / baseTermWidth
+	}
+	return max
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// -----------------------------------------------------------------------------
+// Per-model columns
+// -----------------------------------------------------------------------------
+
+var userColumns = []column[models.User]{
+	{key: "id", header: "ID", value: func(f *Formatter, u models.User) string { return fmt.Sprintf("%d", u.UserID) }},
+	{key: "username", header: "USERNAME", value: func(f *Formatter, u models.User) string { return u.Username }},
+	{key: "name", header: "NAME", value: func(f *Formatter, u models.User) string { return f.clip(u.Fullname, 30) }},
+	{key: "followers", header: "FOLLOWERS", value: func(f *Formatter, u models.User) string { return f.num(u.FollowersCount) }},
+	{key: "followings", header: "FOLLOWING", wide: true, value: func(f *Formatter, u models.User) string { return f.num(u.FollowingsCount) }},
+	{key: "kind", header: "KIND", wide: true, value: func(f *Formatter, u models.User) string { return orDash(u.Kind) }},
+	{key: "plan", header: "PLAN", wide: true, value: func(f *Formatter, u models.User) string { return orDash(u.Plan) }},
+	{key: "url", header: "URL", wide: true, value: func(f *Formatter, u models.User) string { return orDash(u.SiteURL) }},
+}
+
+var showColumns = []column[models.Show]{
+	{key: "id", header: "ID", value: func(f *Formatter, s models.Show) string { return fmt.Sprintf("%d", s.ShowID) }},
+	{key: "title", header: "TITLE", value: func(f *Formatter, s models.Show) string { return f.clip(s.Title, 40) }},
+	{key: "episodes", header: "EPISODES", value: func(f *Formatter, s models.Show) string { return f.num(s.EpisodesCount) }},
+	{key: "followers", header: "FOLLOWERS", value: func(f *Formatter, s models.Show) string { return f.num(s.FollowersCount) }},
+	{key: "plays", header: "PLAYS", value: func(f *Formatter, s models.Show) string { return f.num(s.PlayCount) }},
+	{key: "language", header: "LANGUAGE", wide: true, value: func(f *Formatter, s models.Show) string { return orDash(s.Language) }},
+	{key: "explicit", header: "EXPLICIT", wide: true, value: func(f *Formatter, s models.Show) string { return fmt.Sprintf("%v", s.Explicit) }},
+	{key: "last_episode", header: "LAST EPISODE", wide: true, value: func(f *Formatter, s models.Show) string {
+		if s.LastEpisodeAt == nil {
+			return "-"
+		}
+		return f.formatTime(s.LastEpisodeAt.Time, time.UTC, time.DateTime)
+	}},
+	{key: "url", header: "URL", wide: true, value: func(f *Formatter, s models.Show) string { return orDash(s.SiteURL) }},
+}
+
+var episodeColumns = []column[models.Episode]{
+	{key: "id", header: "ID", value: func(f *Formatter, e models.Episode) string { return fmt.Sprintf("%d", e.EpisodeID) }},
+	{key: "title", header: "TITLE", value: func(f *Formatter, e models.Episode) string { return f.clip(e.Title, 35) }},
+	{key: "duration", header: "DURATION", value: func(f *Formatter, e models.Episode) string { return formatDuration(e.Duration) }},
+	{key: "plays", header: "PLAYS", value: func(f *Formatter, e models.Episode) string { return f.num(e.PlayCount) }},
+	{key: "status", header: "STATUS", value: func(f *Formatter, e models.Episode) string { return e.EncodingStatus }},
+	{key: "published", header: "PUBLISHED", value: func(f *Formatter, e models.Episode) string {
+		if e.PublishedAt == nil {
+			return "-"
+		}
+		return f.formatTime(e.PublishedAt.Time, time.UTC, time.DateTime)
+	}},
+	{key: "show_id", header: "SHOW ID", wide: true, value: func(f *Formatter, e models.Episode) string { return fmt.Sprintf("%d", e.ShowID) }},
+	{key: "likes", header: "LIKES", wide: true, value: func(f *Formatter, e models.Episode) string { return f.num(e.LikesCount) }},
+	{key: "messages", header: "MESSAGES", wide: true, value: func(f *Formatter, e models.Episode) string { return f.num(e.MessagesCount) }},
+	{key: "explicit", header: "EXPLICIT", wide: true, value: func(f *Formatter, e models.Episode) string { return fmt.Sprintf("%v", e.Explicit) }},
+	{key: "hidden", header: "HIDDEN", wide: true, value: func(f *Formatter, e models.Episode) string { return fmt.Sprintf("%v", e.Hidden) }},
+	{key: "downloads", header: "DOWNLOADS", wide: true, value: func(f *Formatter, e models.Episode) string { return fmt.Sprintf("%v", e.DownloadEnabled) }},
+	{key: "tags", header: "TAGS", wide: true, value: func(f *Formatter, e models.Episode) string { return orDash(strings.Join(e.Tags, ",")) }},
+	{key: "url", header: "URL", wide: true, value: func(f *Formatter, e models.Episode) string { return orDash(e.SiteURL) }},
+}
+
+var chapterColumns = []column[models.Chapter]{
+	{key: "id", header: "ID", value: func(f *Formatter, c models.Chapter) string { return fmt.Sprintf("%d", c.ChapterID) }},
+	{key: "starts_at", header: "STARTS AT (ms)", value: func(f *Formatter, c models.Chapter) string { return fmt.Sprintf("%d", c.StartsAt) }},
+	{key: "time", header: "TIME", value: func(f *Formatter, c models.Chapter) string {
+		totalSeconds := c.StartsAt / 1000
+		return fmt.Sprintf("%d:%02d", totalSeconds/60, totalSeconds%60)
+	}},
+	{key: "title", header: "TITLE", value: func(f *Formatter, c models.Chapter) string { return f.clip(c.Title, 40) }},
+	{key: "url", header: "URL", value: func(f *Formatter, c models.Chapter) string { return f.clip(orDash(c.ExternalURL), 40) }},
+	{key: "image", header: "IMAGE", wide: true, value: func(f *Formatter, c models.Chapter) string { return orDash(c.ImageURL) }},
+}
+
+var messageColumns = []column[models.Message]{
+	{key: "id", header: "ID", value: func(f *Formatter, m models.Message) string { return fmt.Sprintf("%d", m.MessageID) }},
+	{key: "author", header: "AUTHOR", value: func(f *Formatter, m models.Message) string {
+		author := m.AuthorFullname
+		if m.AuthorIsOwner {
+			author = author + " ★"
+		}
+		return f.clip(author, 20)
+	}},
+	{key: "username", header: "USERNAME", value: func(f *Formatter, m models.Message) string { return "@" + m.AuthorUsername }},
+	{key: "date", header: "DATE", value: func(f *Formatter, m models.Message) string { return f.timestamp(m.CreatedAt) }},
+	{key: "message", header: "MESSAGE", value: func(f *Formatter, m models.Message) string { return f.clip(m.Text, MessagePreviewLength) }},
+	{key: "episode_id", header: "EPISODE ID", wide: true, value: func(f *Formatter, m models.Message) string { return fmt.Sprintf("%d", m.EpisodeID) }},
+	{key: "app", header: "APP", wide: true, value: func(f *Formatter, m models.Message) string { return orDash(m.AppName) }},
+}
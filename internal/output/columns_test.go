@@ -0,0 +1,69 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func headerLine(out string) string {
+	line, _, _ := strings.Cut(out, "\n")
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func TestPrintEpisodes_Columns(t *testing.T) {
+	episodes := []models.Episode{{EpisodeID: 7, Title: "A very long episode title that would normally be truncated", PlayCount: 42, Tags: []string{"news"}}}
+
+	t.Run("default", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.PrintEpisodes(episodes)
+		if got := headerLine(buf.String()); got != "ID TITLE DURATION PLAYS STATUS PUBLISHED" {
+			t.Errorf("header = %q", got)
+		}
+	})
+
+	t.Run("wide", func(t *testing.T) {
+		f, buf := newTestFormatter("wide")
+		f.PrintEpisodes(episodes)
+		out := buf.String()
+		if !strings.Contains(headerLine(out), "TAGS") {
+			t.Errorf("wide header missing TAGS: %q", headerLine(out))
+		}
+		if !strings.Contains(out, episodes[0].Title) {
+			t.Error("wide output should not truncate the title")
+		}
+	})
+
+	t.Run("custom order", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.SetColumns([]string{"plays", " ID ", "tags"})
+		f.PrintEpisodes(episodes)
+		out := buf.String()
+		if got := headerLine(out); got != "PLAYS ID TAGS" {
+			t.Errorf("header = %q, want %q", got, "PLAYS ID TAGS")
+		}
+		if !strings.Contains(out, "42") || !strings.Contains(out, "news") {
+			t.Errorf("output missing selected values:\n%s", out)
+		}
+	})
+
+	t.Run("unknown only falls back to defaults", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.SetColumns([]string{"nope"})
+		f.PrintEpisodes(episodes)
+		if got := headerLine(buf.String()); got != "ID TITLE DURATION PLAYS STATUS PUBLISHED" {
+			t.Errorf("header = %q", got)
+		}
+	})
+}
+
+func TestPrintUsers_Columns(t *testing.T) {
+	f, buf := newTestFormatter("table")
+	f.SetColumns([]string{"username", "followings"})
+	f.PrintUsers([]models.User{{UserID: 1, Username: "jane", FollowingsCount: 3}})
+
+	if got := headerLine(buf.String()); got != "USERNAME FOLLOWING" {
+		t.Errorf("header = %q, want %q", got, "USERNAME FOLLOWING")
+	}
+}
@@ -3,20 +3,38 @@ Package output handles formatting and displaying results to the terminal.
 
 It supports multiple output formats:
   - table: Human-readable aligned columns (default)
+  - wide:  Table with every available column of list outputs
   - json:  Machine-readable JSON output
   - plain: Simple text, one item per line
+  - ci:    GitHub Actions workflow commands and Markdown tables
 */
 package output
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+	"github.com/G10xy/spreaker-and-go/internal/buildinfo"
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+	"github.com/G10xy/spreaker-and-go/internal/jobs"
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+	"github.com/G10xy/spreaker-and-go/internal/linkcheck"
+	"github.com/G10xy/spreaker-and-go/internal/refdata"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/internal/spool"
+	"github.com/G10xy/spreaker-and-go/internal/text"
 	"github.com/G10xy/spreaker-and-go/pkg/models"
 	"github.com/pterm/pterm"
 )
@@ -26,8 +44,10 @@ type Format string
 
 const (
 	FormatTable Format = "table"
+	FormatWide  Format = "wide" // table with every column
 	FormatJSON  Format = "json"
 	FormatPlain Format = "plain"
+	FormatCI    Format = "ci"
 )
 
 var rgbPalette = []pterm.RGB{
@@ -42,9 +62,49 @@ var rgbPalette = []pterm.RGB{
 }
 
 type Formatter struct {
-	format Format
-	writer io.Writer
-	color  bool
+	format    Format
+	writer    io.Writer
+	errWriter io.Writer // warnings and errors; see diagnostic
+	color     bool
+
+	withMeta bool
+	page     *pageMeta
+
+	// columns selects list table columns by key; see SetColumns.
+	columns []string
+
+	// quiet suppresses info and success messages; see SetQuiet.
+	quiet bool
+
+	// paged is set when writer is a Pager; spinners and progress bars
+	// then draw on the terminal directly.
+	paged bool
+
+	// Date rendering in tables; see SetDateOptions.
+	dateLayout    string
+	relativeDates bool
+	location      *time.Location
+	now           func() time.Time
+
+	// sort orders list output; see SetSort.
+	sort *SortSpec
+
+	// totals ends stats tables with TOTAL and AVG rows; see SetTotals.
+	totals bool
+
+	// numbers is the format of counts in tables; see SetNumberFormat.
+	numbers string
+
+	// Truncation of long table cells; see SetTruncation.
+	noTruncate bool
+	maxWidth   int
+	termWidth  int
+}
+
+// pageMeta is the pagination metadata attached to the next JSON list output.
+type pageMeta struct {
+	NextURL string `json:"next_url"`
+	HasMore bool   `json:"has_more"`
 }
 
 // New creates a new Formatter with the specified format and color support.
@@ -52,21 +112,97 @@ func New(format string, color bool) *Formatter {
 	f := Format(strings.ToLower(strings.TrimSpace(format)))
 
 	switch f {
-	case FormatTable, FormatJSON, FormatPlain:
+	case FormatTable, FormatWide, FormatJSON, FormatPlain, FormatCI:
 	default:
 		f = FormatTable
 	}
 
-	// Only enable color for table format
-	if f != FormatTable {
+	// Only enable color for table formats
+	if f != FormatTable && f != FormatWide {
 		color = false
 	}
 
 	return &Formatter{
-		format: f,
-		writer: os.Stdout,
-		color:  color,
+		format:    f,
+		writer:    os.Stdout,
+		errWriter: os.Stderr,
+		color:     color,
+		now:       time.Now,
+	}
+}
+
+// SetWithMeta enables wrapping JSON lists with pagination metadata.
+func (f *Formatter) SetWithMeta(withMeta bool) {
+	f.withMeta = withMeta
+}
+
+// IsJSON reports whether output is JSON. List commands then print an empty
+// list rather than a "nothing found" message, so scripts can parse it.
+func (f *Formatter) IsJSON() bool {
+	return f.format == FormatJSON
+}
+
+// WithMeta reports whether JSON lists are wrapped with pagination metadata.
+func (f *Formatter) WithMeta() bool {
+	return f.withMeta && f.format == FormatJSON
+}
+
+// SetPager sends the formatter's output through p (see Pager).
+func (f *Formatter) SetPager(p *Pager) {
+	f.writer = p
+	f.paged = true
+}
+
+// liveWriter is where animated output (spinners, progress bars) is drawn.
+func (f *Formatter) liveWriter() io.Writer {
+	if f.paged {
+		return os.Stdout
+	}
+	return f.writer
+}
+
+// SetDateOptions configures how dates are rendered in tables. An empty
+// layout keeps each field's default layout; relative renders dates as
+// "3 days ago" instead; a nil loc keeps each field's default time zone.
+// JSON and plain output always keep the API's raw values.
+func (f *Formatter) SetDateOptions(layout string, relative bool, loc *time.Location) {
+	f.dateLayout = layout
+	f.relativeDates = relative
+	f.location = loc
+}
+
+// baseTermWidth is the terminal width the default cell widths of tables
+// are chosen for.
+const baseTermWidth = 80
+
+// SetTruncation configures how long text, such as titles, is truncated in
+// table cells. Each column has a default width that fits an 80-column
+// terminal: maxWidth, if positive, replaces it; otherwise it grows with a
+// wider termWidth, the width of the terminal (0 when not writing to one).
+// With enabled false, cells are never truncated, as in wide output.
+func (f *Formatter) SetTruncation(enabled bool, maxWidth, termWidth int) {
+	f.noTruncate = !enabled
+	f.maxWidth = maxWidth
+	f.termWidth = termWidth
+}
+
+// LoadLocation resolves a configured time zone: an IANA name, or "local"
+// for the system zone. An empty name returns a nil location.
+func LoadLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return nil, nil
+	case "local":
+		return time.Local, nil
 	}
+	return time.LoadLocation(name)
+}
+
+// SetPage records pagination metadata for the next list printed. When
+// WithMeta is enabled, that list is output as
+// {"items": [...], "next_url": "...", "has_more": true}.
+func (f *Formatter) SetPage(nextURL string, hasMore bool) {
+	f.page = &pageMeta{NextURL: nextURL, HasMore: hasMore}
 }
 
 func (f *Formatter) tabw() *tabwriter.Writer {
@@ -90,6 +226,7 @@ func (f *Formatter) PrintUser(user *models.User) {
 }
 
 func (f *Formatter) PrintUsers(users []models.User) {
+	users = sorted(f, users)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(users)
@@ -109,8 +246,8 @@ func (f *Formatter) printUserTable(user *models.User) {
 		{"Name:", user.Fullname},
 		{"Kind:", user.Kind},
 		{"Plan:", user.Plan},
-		{"Followers:", fmt.Sprintf("%d", user.FollowersCount)},
-		{"Following:", fmt.Sprintf("%d", user.FollowingsCount)},
+		{"Followers:", f.num(user.FollowersCount)},
+		{"Following:", f.num(user.FollowingsCount)},
 		{"URL:", user.SiteURL},
 	}
 
@@ -126,17 +263,7 @@ func (f *Formatter) printUserTable(user *models.User) {
 }
 
 func (f *Formatter) printUsersTable(users []models.User) {
-	header := []string{"ID", "USERNAME", "NAME", "FOLLOWERS"}
-	rows := make([][]string, len(users))
-	for i, u := range users {
-		rows[i] = []string{
-			fmt.Sprintf("%d", u.UserID),
-			u.Username,
-			truncate(u.Fullname, 30),
-			fmt.Sprintf("%d", u.FollowersCount),
-		}
-	}
-	f.renderTable(header, rows)
+	renderColumns(f, userColumns, users)
 }
 
 // -----------------------------------------------------------------------------
@@ -156,6 +283,7 @@ func (f *Formatter) PrintShow(show *models.Show) {
 }
 
 func (f *Formatter) PrintShows(shows []models.Show) {
+	shows = sorted(f, shows)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(shows)
@@ -173,9 +301,9 @@ func (f *Formatter) printShowTable(show *models.Show) {
 		{"ID:", fmt.Sprintf("%d", show.ShowID)},
 		{"Title:", show.Title},
 		{"Language:", show.Language},
-		{"Episodes:", fmt.Sprintf("%d", show.EpisodesCount)},
-		{"Followers:", fmt.Sprintf("%d", show.FollowersCount)},
-		{"Plays:", fmt.Sprintf("%d", show.PlayCount)},
+		{"Episodes:", f.num(show.EpisodesCount)},
+		{"Followers:", f.num(show.FollowersCount)},
+		{"Plays:", f.num(show.PlayCount)},
 		{"Explicit:", fmt.Sprintf("%v", show.Explicit)},
 		{"URL:", show.SiteURL},
 	}
@@ -189,25 +317,21 @@ func (f *Formatter) printShowTable(show *models.Show) {
 	}
 
 	if show.LastEpisodeAt != nil {
-		pairs = append(pairs, [2]string{"Last Episode:", show.LastEpisodeAt.Format(time.DateTime)})
+		pairs = append(pairs, [2]string{"Last Episode:", f.formatTime(show.LastEpisodeAt.Time, time.UTC, time.DateTime)})
+	}
+
+	// The API exposes only the owning author; shows have no collaborator list.
+	if show.Author != nil {
+		pairs = append(pairs, [2]string{"Owner:", fmt.Sprintf("%s (@%s)", show.Author.Fullname, show.Author.Username)})
+	} else if show.AuthorID != 0 {
+		pairs = append(pairs, [2]string{"Owner:", fmt.Sprintf("%d", show.AuthorID)})
 	}
 
 	f.PrintKeyValue(pairs)
 }
 
 func (f *Formatter) printShowsTable(shows []models.Show) {
-	header := []string{"ID", "TITLE", "EPISODES", "FOLLOWERS", "PLAYS"}
-	rows := make([][]string, len(shows))
-	for i, s := range shows {
-		rows[i] = []string{
-			fmt.Sprintf("%d", s.ShowID),
-			truncate(s.Title, 40),
-			fmt.Sprintf("%d", s.EpisodesCount),
-			fmt.Sprintf("%d", s.FollowersCount),
-			fmt.Sprintf("%d", s.PlayCount),
-		}
-	}
-	f.renderTable(header, rows)
+	renderColumns(f, showColumns, shows)
 }
 
 // -----------------------------------------------------------------------------
@@ -225,7 +349,90 @@ func (f *Formatter) PrintEpisode(episode *models.Episode) {
 	}
 }
 
+// PrintEpisodeFull prints an episode with the technical details of its
+// audio file; media is nil when they could not be retrieved.
+func (f *Formatter) PrintEpisodeFull(episode *models.Episode, media *api.MediaInfo) {
+	switch f.format {
+	case FormatJSON:
+		type mediaDetails struct {
+			*api.MediaInfo
+			Bitrate int `json:"bitrate_kbps,omitempty"`
+		}
+		out := struct {
+			*models.Episode
+			Media *mediaDetails `json:"media,omitempty"`
+		}{Episode: episode}
+		if media != nil {
+			out.Media = &mediaDetails{MediaInfo: media, Bitrate: media.Bitrate(episode.Duration)}
+		}
+		f.printJSON(out)
+	case FormatPlain:
+		fmt.Fprintf(f.writer, "%d\t%s", episode.EpisodeID, episode.Title)
+		if media != nil {
+			fmt.Fprintf(f.writer, "\t%s\t%d\t%d", media.ContentType, media.Size, media.Bitrate(episode.Duration))
+		}
+		fmt.Fprintln(f.writer)
+	default:
+		f.printEpisodeTable(episode)
+		fmt.Fprintln(f.writer)
+		f.renderSection("Media")
+		pairs := [][2]string{{"Waveform:", orDash(episode.WaveformURL)}}
+		if media != nil {
+			bitrate := "-"
+			if kbps := media.Bitrate(episode.Duration); kbps > 0 {
+				bitrate = fmt.Sprintf("%d kbps", kbps)
+			}
+			pairs = append(pairs,
+				[2]string{"Type:", orDash(media.ContentType)},
+				[2]string{"Size:", formatSize(media.Size)},
+				[2]string{"Bitrate:", bitrate},
+				[2]string{"File:", media.URL},
+			)
+		}
+		f.PrintKeyValue(pairs)
+	}
+}
+
+// PrintEpisodesDuration prints the number of episodes and their total and
+// average duration after an episode list. It is not part of JSON or plain
+// output.
+func (f *Formatter) PrintEpisodesDuration(episodes []models.Episode) {
+	if f.format == FormatJSON || f.format == FormatPlain || len(episodes) == 0 {
+		return
+	}
+	total := 0
+	for _, e := range episodes {
+		total += e.Duration
+	}
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Episodes:", f.num(len(episodes))},
+		{"Total Duration:", formatDuration(total)},
+		{"Average Duration:", formatDuration(total / len(episodes))},
+	})
+}
+
+// formatSize renders a size in bytes with a decimal unit, e.g. "12.3 MB",
+// or "-" when it is unknown.
+func formatSize(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	if n < 1000 {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, unit := float64(n)/1000, "kB"
+	for _, u := range []string{"MB", "GB"} {
+		if size < 1000 {
+			break
+		}
+		size, unit = size/1000, u
+	}
+	return fmt.Sprintf("%.1f %s", size, unit)
+}
+
 func (f *Formatter) PrintEpisodes(episodes []models.Episode) {
+	episodes = sorted(f, episodes)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(episodes)
@@ -244,8 +451,8 @@ func (f *Formatter) printEpisodeTable(episode *models.Episode) {
 		{"Title:", episode.Title},
 		{"Show ID:", fmt.Sprintf("%d", episode.ShowID)},
 		{"Duration:", formatDuration(episode.Duration)},
-		{"Plays:", fmt.Sprintf("%d", episode.PlayCount)},
-		{"Likes:", fmt.Sprintf("%d", episode.LikesCount)},
+		{"Plays:", f.num(episode.PlayCount)},
+		{"Likes:", f.num(episode.LikesCount)},
 		{"Status:", episode.EncodingStatus},
 		{"Explicit:", fmt.Sprintf("%v", episode.Explicit)},
 		{"Downloads:", fmt.Sprintf("%v", episode.DownloadEnabled)},
@@ -253,7 +460,7 @@ func (f *Formatter) printEpisodeTable(episode *models.Episode) {
 	}
 
 	if episode.PublishedAt != nil {
-		pairs = append(pairs, [2]string{"Published:", episode.PublishedAt.Format(time.DateTime)})
+		pairs = append(pairs, [2]string{"Published:", f.formatTime(episode.PublishedAt.Time, time.UTC, time.DateTime)})
 	}
 
 	if len(episode.Tags) > 0 {
@@ -272,23 +479,7 @@ func (f *Formatter) printEpisodeTable(episode *models.Episode) {
 }
 
 func (f *Formatter) printEpisodesTable(episodes []models.Episode) {
-	header := []string{"ID", "TITLE", "DURATION", "PLAYS", "STATUS", "PUBLISHED"}
-	rows := make([][]string, len(episodes))
-	for i, e := range episodes {
-		published := "-"
-		if e.PublishedAt != nil {
-			published = e.PublishedAt.Format(time.DateTime)
-		}
-		rows[i] = []string{
-			fmt.Sprintf("%d", e.EpisodeID),
-			truncate(e.Title, 35),
-			formatDuration(e.Duration),
-			fmt.Sprintf("%d", e.PlayCount),
-			e.EncodingStatus,
-			published,
-		}
-	}
-	f.renderTable(header, rows)
+	renderColumns(f, episodeColumns, episodes)
 }
 
 // -----------------------------------------------------------------------------
@@ -310,10 +501,10 @@ func (f *Formatter) PrintStatistics(stats *models.Statistics) {
 
 func (f *Formatter) printStatisticsTable(stats *models.Statistics) {
 	f.PrintKeyValue([][2]string{
-		{"Plays:", fmt.Sprintf("%d", stats.Plays)},
-		{"Downloads:", fmt.Sprintf("%d", stats.Downloads)},
-		{"Likes:", fmt.Sprintf("%d", stats.Likes)},
-		{"Messages:", fmt.Sprintf("%d", stats.Messages)},
+		{"Plays:", f.num(stats.Plays)},
+		{"Downloads:", f.num(stats.Downloads)},
+		{"Likes:", f.num(stats.Likes)},
+		{"Messages:", f.num(stats.Messages)},
 	})
 }
 
@@ -321,35 +512,126 @@ func (f *Formatter) printStatisticsTable(stats *models.Statistics) {
 // Generic Output
 // -----------------------------------------------------------------------------
 
+// Level is the severity of a message printed outside of command data.
+type Level int
+
+const (
+	LevelInfo    Level = iota // progress and hints, e.g. "Uploading..."
+	LevelSuccess              // confirmation that an action completed
+	LevelWarn
+	LevelError
+)
+
+// SetQuiet suppresses info and success messages (and spinners and progress
+// bars), keeping command data, warnings and errors.
+func (f *Formatter) SetQuiet(quiet bool) {
+	f.quiet = quiet
+}
+
+// enabled reports whether messages of the given level are printed.
+func (f *Formatter) enabled(level Level) bool {
+	return !f.quiet || level >= LevelWarn
+}
+
+// Print prints a message at the given level.
+func (f *Formatter) Print(level Level, msg string) {
+	switch level {
+	case LevelSuccess:
+		f.PrintSuccess(msg)
+	case LevelWarn:
+		f.PrintWarning(msg)
+	case LevelError:
+		f.PrintError(errors.New(msg))
+	default:
+		f.PrintMessage(msg)
+	}
+}
+
+// messageWriter is where info and success messages go: stderr in JSON
+// mode, so stdout only carries JSON documents.
+func (f *Formatter) messageWriter() io.Writer {
+	if f.format == FormatJSON {
+		return f.errWriter
+	}
+	return f.writer
+}
+
+// PrintMessage prints an informational message (LevelInfo).
 func (f *Formatter) PrintMessage(msg string) {
+	if !f.enabled(LevelInfo) {
+		return
+	}
+	if f.format == FormatCI {
+		fmt.Fprintln(f.writer, msg)
+		return
+	}
 	if f.color {
 		pterm.Info.WithWriter(f.writer).Println(msg)
 	} else {
-		fmt.Fprintln(f.writer, msg)
+		fmt.Fprintln(f.messageWriter(), msg)
 	}
 }
 
+// PrintError reports an error on stderr (see diagnostic).
 func (f *Formatter) PrintError(err error) {
-	if f.color {
-		pterm.Error.WithWriter(os.Stderr).Println(err.Error())
-	} else {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-	}
+	f.diagnostic(LevelError, err)
 }
 
 func (f *Formatter) PrintSuccess(msg string) {
+	if !f.enabled(LevelSuccess) {
+		return
+	}
+	if f.format == FormatCI {
+		printWorkflowCommand(f.writer, "notice", msg)
+		return
+	}
 	if f.color {
 		pterm.Success.WithWriter(f.writer).Println(msg)
 	} else {
-		fmt.Fprintf(f.writer, "✓ %s\n", msg)
+		fmt.Fprintf(f.messageWriter(), "✓ %s\n", msg)
 	}
 }
 
+// PrintWarning reports a warning on stderr (see diagnostic).
 func (f *Formatter) PrintWarning(msg string) {
-	if f.color {
-		pterm.Warning.WithWriter(os.Stderr).Println(msg)
-	} else {
-		fmt.Fprintf(os.Stderr, "WARNING: %s\n", msg)
+	f.diagnostic(LevelWarn, errors.New(msg))
+}
+
+// diagnosticJSON is the object written to stderr for warnings and errors
+// in JSON mode. API errors include the HTTP status and Spreaker error code.
+type diagnosticJSON struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	Status  int    `json:"status,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// diagnostic writes a warning or error to stderr, never to the data
+// output, so stdout stays parseable: GitHub Actions commands in ci mode,
+// one JSON object per line in json mode, and "Error: ..." / "Warning: ..."
+// lines otherwise.
+func (f *Formatter) diagnostic(level Level, err error) {
+	name, prefix := "error", "Error"
+	if level == LevelWarn {
+		name, prefix = "warning", "Warning"
+	}
+
+	switch {
+	case f.format == FormatCI:
+		printWorkflowCommand(f.errWriter, name, err.Error())
+	case f.format == FormatJSON:
+		d := diagnosticJSON{Level: name, Message: err.Error()}
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) {
+			d.Status, d.Code = apiErr.StatusCode, apiErr.Code
+		}
+		json.NewEncoder(f.errWriter).Encode(d)
+	case f.color && level == LevelWarn:
+		pterm.Warning.WithWriter(f.errWriter).Println(err.Error())
+	case f.color:
+		pterm.Error.WithWriter(f.errWriter).Println(err.Error())
+	default:
+		fmt.Fprintf(f.errWriter, "%s: %v\n", prefix, err)
 	}
 }
 
@@ -358,7 +640,18 @@ func (f *Formatter) PrintWarning(msg string) {
 // -----------------------------------------------------------------------------
 
 // renderTable renders a list table with a header row.
+// Header cells are translated into the active language.
 func (f *Formatter) renderTable(header []string, rows [][]string) {
+	translated := make([]string, len(header))
+	for i, h := range header {
+		translated[i] = i18n.T(h)
+	}
+	header = translated
+
+	if f.format == FormatCI {
+		f.renderMarkdownTable(header, rows)
+		return
+	}
 	if f.color {
 		coloredHeader := make([]string, len(header))
 		for i, h := range header {
@@ -383,7 +676,22 @@ func (f *Formatter) renderTable(header []string, rows [][]string) {
 }
 
 // PrintKeyValue renders a detail view with key-value pairs.
+// Keys are translated into the active language.
 func (f *Formatter) PrintKeyValue(pairs [][2]string) {
+	translated := make([][2]string, len(pairs))
+	for i, p := range pairs {
+		translated[i] = [2]string{i18n.Label(p[0]), p[1]}
+	}
+	pairs = translated
+
+	if f.format == FormatCI {
+		rows := make([][]string, len(pairs))
+		for i, p := range pairs {
+			rows[i] = []string{strings.TrimSuffix(strings.TrimSpace(p[0]), ":"), p[1]}
+		}
+		f.renderMarkdownTable([]string{"Field", "Value"}, rows)
+		return
+	}
 	if f.color {
 		data := pterm.TableData{}
 		for i, p := range pairs {
@@ -401,6 +709,11 @@ func (f *Formatter) PrintKeyValue(pairs [][2]string) {
 
 // renderSection renders a section header.
 func (f *Formatter) renderSection(title string) {
+	title = i18n.T(title)
+	if f.format == FormatCI {
+		f.renderMarkdownSection(title)
+		return
+	}
 	if f.color {
 		pterm.DefaultSection.WithWriter(f.writer).Println(title)
 	} else {
@@ -410,37 +723,51 @@ func (f *Formatter) renderSection(title string) {
 
 // StartSpinner starts a spinner with the given message. Returns nil if color is disabled.
 func (f *Formatter) StartSpinner(msg string) *pterm.SpinnerPrinter {
+	if !f.enabled(LevelInfo) {
+		return nil
+	}
 	if !f.color {
 		fmt.Fprintln(f.writer, msg)
 		return nil
 	}
-	spinner, _ := pterm.DefaultSpinner.WithWriter(f.writer).Start(msg)
+	spinner, _ := pterm.DefaultSpinner.WithWriter(f.liveWriter()).Start(msg)
 	return spinner
 }
 
-// StopSpinner stops a spinner with a success or failure message.
+// StopSpinner stops a spinner with a success or failure message. Callers
+// that return the failure use CancelSpinner instead, so it is not printed
+// twice.
 func (f *Formatter) StopSpinner(spinner *pterm.SpinnerPrinter, success bool, msg string) {
 	if spinner == nil {
 		if success {
 			f.PrintSuccess(msg)
 		} else {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+			f.PrintError(errors.New(msg))
 		}
 		return
 	}
 	if success {
 		spinner.Success(msg)
 	} else {
-		spinner.Fail(msg)
+		spinner.Stop()
+		f.PrintError(errors.New(msg))
+	}
+}
+
+// CancelSpinner stops a spinner after a failure without printing anything:
+// the command returns the error, which is reported once when it exits.
+func (f *Formatter) CancelSpinner(spinner *pterm.SpinnerPrinter) {
+	if spinner != nil {
+		spinner.Stop()
 	}
 }
 
 // StartProgressBar starts a progress bar. Returns nil if color is disabled.
 func (f *Formatter) StartProgressBar(total int, title string) *pterm.ProgressbarPrinter {
-	if !f.color {
+	if !f.color || !f.enabled(LevelInfo) {
 		return nil
 	}
-	bar, _ := pterm.DefaultProgressbar.WithTotal(total).WithTitle(title).WithWriter(f.writer).Start()
+	bar, _ := pterm.DefaultProgressbar.WithTotal(total).WithTitle(title).WithWriter(f.liveWriter()).Start()
 	return bar
 }
 
@@ -449,11 +776,97 @@ func (f *Formatter) StartProgressBar(total int, title string) *pterm.Progressbar
 // -----------------------------------------------------------------------------
 
 func (f *Formatter) printJSON(v interface{}) {
+	// An empty list is [], not null.
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	if f.page != nil && f.WithMeta() {
+		v = struct {
+			Items interface{} `json:"items"`
+			pageMeta
+		}{Items: v, pageMeta: *f.page}
+	}
+	f.page = nil
+
 	encoder := json.NewEncoder(f.writer)
 	encoder.SetIndent("", "  ")
 	encoder.Encode(v)
 }
 
+// MessagePreviewLength is the number of characters of message text shown in lists.
+const MessagePreviewLength = 50
+
+// formatTime renders a timestamp for tables using the configured date
+// options, falling back to the field's default zone and layout.
+func (f *Formatter) formatTime(t time.Time, defaultLoc *time.Location, defaultLayout string) string {
+	if f.relativeDates {
+		return relativeTime(t, f.now())
+	}
+
+	loc, layout := defaultLoc, defaultLayout
+	if f.location != nil {
+		loc = f.location
+	}
+	if f.dateLayout != "" {
+		layout = f.dateLayout
+	}
+	return t.In(loc).Format(layout)
+}
+
+// localTime renders an API timestamp string ("2006-01-02 15:04:05", UTC),
+// by default in local time without seconds. Unparseable values are
+// returned unchanged.
+func (f *Formatter) localTime(s string) string {
+	t, err := models.ParseTime(s)
+	if err != nil {
+		return s
+	}
+	return f.timestamp(t)
+}
+
+// timestamp renders a parsed API timestamp like localTime. A missing
+// timestamp renders as the raw value, if any.
+func (f *Formatter) timestamp(t models.CustomTime) string {
+	if t.IsZero() {
+		return t.Raw
+	}
+	return f.formatTime(t.Time, time.Local, "2006-01-02 15:04")
+}
+
+// relativeTime describes t relative to now, e.g. "3 days ago" or "in 2 hours".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return i18n.T("in %d "+unit, n)
+	}
+	return i18n.T("%d "+unit+" ago", n)
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -485,88 +898,134 @@ func formatDuration(ms int) string {
 
 // PrintUserStatistics prints user overall statistics.
 func (f *Formatter) PrintUserStatistics(stats *models.UserOverallStatistics) {
+	ratios := report.UserRatios(stats)
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(stats)
+		f.printJSON(withRatios{stats, ratios})
 	case FormatPlain:
-		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d followers=%d shows=%d episodes=%d\n",
+		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d followers=%d shows=%d episodes=%d %s\n",
 			stats.PlaysCount, stats.DownloadsCount, stats.LikesCount,
-			stats.FollowersCount, stats.ShowsCount, stats.EpisodesCount)
+			stats.FollowersCount, stats.ShowsCount, stats.EpisodesCount, plainRatios(ratios))
 	default:
-		f.printUserStatisticsTable(stats)
+		f.printUserStatisticsTable(stats, ratios)
 	}
 }
 
-func (f *Formatter) printUserStatisticsTable(stats *models.UserOverallStatistics) {
+func (f *Formatter) printUserStatisticsTable(stats *models.UserOverallStatistics, ratios report.Ratios) {
 	f.renderSection("Overall Statistics")
-	f.PrintKeyValue([][2]string{
-		{"Total Plays:", fmt.Sprintf("%d", stats.PlaysCount)},
-		{"  On Demand:", fmt.Sprintf("%d", stats.PlaysOndemandCount)},
-		{"  Live:", fmt.Sprintf("%d", stats.PlaysLiveCount)},
-		{"Downloads:", fmt.Sprintf("%d", stats.DownloadsCount)},
-		{"Likes:", fmt.Sprintf("%d", stats.LikesCount)},
-		{"Followers:", fmt.Sprintf("%d", stats.FollowersCount)},
-		{"Shows:", fmt.Sprintf("%d", stats.ShowsCount)},
-		{"Episodes:", fmt.Sprintf("%d", stats.EpisodesCount)},
-	})
+	f.PrintKeyValue(append([][2]string{
+		{"Total Plays:", f.num(stats.PlaysCount)},
+		{"  On Demand:", f.num(stats.PlaysOndemandCount)},
+		{"  Live:", f.num(stats.PlaysLiveCount)},
+		{"Downloads:", f.num(stats.DownloadsCount)},
+		{"Likes:", f.num(stats.LikesCount)},
+		{"Followers:", f.num(stats.FollowersCount)},
+		{"Shows:", f.num(stats.ShowsCount)},
+		{"Episodes:", f.num(stats.EpisodesCount)},
+	}, ratioPairs(ratios)...))
 }
 
 // PrintShowStatistics prints show overall statistics.
 func (f *Formatter) PrintShowStatistics(stats *models.ShowOverallStatistics) {
+	ratios := report.ShowRatios(stats)
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(stats)
+		f.printJSON(withRatios{stats, ratios})
 	case FormatPlain:
-		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d episodes=%d\n",
-			stats.PlaysCount, stats.DownloadsCount, stats.LikesCount, stats.EpisodesCount)
+		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d episodes=%d %s\n",
+			stats.PlaysCount, stats.DownloadsCount, stats.LikesCount, stats.EpisodesCount, plainRatios(ratios))
 	default:
-		f.printShowStatisticsTable(stats)
+		f.printShowStatisticsTable(stats, ratios)
 	}
 }
 
-func (f *Formatter) printShowStatisticsTable(stats *models.ShowOverallStatistics) {
+func (f *Formatter) printShowStatisticsTable(stats *models.ShowOverallStatistics, ratios report.Ratios) {
 	if stats.Title != "" {
 		f.PrintKeyValue([][2]string{{"Show:", stats.Title}})
 	}
 	f.renderSection("Overall Statistics")
-	f.PrintKeyValue([][2]string{
-		{"Total Plays:", fmt.Sprintf("%d", stats.PlaysCount)},
-		{"  On Demand:", fmt.Sprintf("%d", stats.PlaysOndemandCount)},
-		{"  Live:", fmt.Sprintf("%d", stats.PlaysLiveCount)},
-		{"Downloads:", fmt.Sprintf("%d", stats.DownloadsCount)},
-		{"Likes:", fmt.Sprintf("%d", stats.LikesCount)},
-		{"Episodes:", fmt.Sprintf("%d", stats.EpisodesCount)},
-	})
+	f.PrintKeyValue(append([][2]string{
+		{"Total Plays:", f.num(stats.PlaysCount)},
+		{"  On Demand:", f.num(stats.PlaysOndemandCount)},
+		{"  Live:", f.num(stats.PlaysLiveCount)},
+		{"Downloads:", f.num(stats.DownloadsCount)},
+		{"Likes:", f.num(stats.LikesCount)},
+		{"Episodes:", f.num(stats.EpisodesCount)},
+	}, ratioPairs(ratios)...))
 }
 
 // PrintEpisodeStatistics prints episode overall statistics.
 func (f *Formatter) PrintEpisodeStatistics(stats *models.EpisodeOverallStatistics) {
+	ratios := report.EpisodeRatios(stats)
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(stats)
+		f.printJSON(withRatios{stats, ratios})
 	case FormatPlain:
-		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d messages=%d\n",
-			stats.PlaysCount, stats.DownloadsCount, stats.LikesCount, stats.MessagesCount)
+		fmt.Fprintf(f.writer, "plays=%d downloads=%d likes=%d messages=%d %s\n",
+			stats.PlaysCount, stats.DownloadsCount, stats.LikesCount, stats.MessagesCount, plainRatios(ratios))
 	default:
-		f.printEpisodeStatisticsTable(stats)
+		f.printEpisodeStatisticsTable(stats, ratios)
 	}
 }
 
-func (f *Formatter) printEpisodeStatisticsTable(stats *models.EpisodeOverallStatistics) {
+func (f *Formatter) printEpisodeStatisticsTable(stats *models.EpisodeOverallStatistics, ratios report.Ratios) {
 	f.renderSection("Overall Statistics")
-	f.PrintKeyValue([][2]string{
-		{"Total Plays:", fmt.Sprintf("%d", stats.PlaysCount)},
-		{"  On Demand:", fmt.Sprintf("%d", stats.PlaysOndemandCount)},
-		{"  Live:", fmt.Sprintf("%d", stats.PlaysLiveCount)},
-		{"Downloads:", fmt.Sprintf("%d", stats.DownloadsCount)},
-		{"Likes:", fmt.Sprintf("%d", stats.LikesCount)},
-		{"Messages:", fmt.Sprintf("%d", stats.MessagesCount)},
-		{"Chapters:", fmt.Sprintf("%d", stats.ChaptersCount)},
-	})
+	f.PrintKeyValue(append([][2]string{
+		{"Total Plays:", f.num(stats.PlaysCount)},
+		{"  On Demand:", f.num(stats.PlaysOndemandCount)},
+		{"  Live:", f.num(stats.PlaysLiveCount)},
+		{"Downloads:", f.num(stats.DownloadsCount)},
+		{"Likes:", f.num(stats.LikesCount)},
+		{"Messages:", f.num(stats.MessagesCount)},
+		{"Chapters:", f.num(stats.ChaptersCount)},
+	}, ratioPairs(ratios)...))
+}
+
+// withRatios adds engagement ratios to the JSON of overall statistics,
+// next to the API's own fields.
+type withRatios struct {
+	statistics any
+	ratios     report.Ratios
+}
+
+func (w withRatios) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	for _, v := range []any{w.statistics, w.ratios} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// ratioPairs returns the detail rows of engagement ratios.
+func ratioPairs(r report.Ratios) [][2]string {
+	pairs := [][2]string{
+		{"Likes/Play:", fmt.Sprintf("%.1f%%", r.LikesPerPlay)},
+		{"Downloads/Play:", fmt.Sprintf("%.1f%%", r.DownloadsPerPlay)},
+	}
+	if r.MessagesPer1k != nil {
+		pairs = append(pairs, [2]string{"Messages/1k Plays:", fmt.Sprintf("%.1f", *r.MessagesPer1k)})
+	}
+	return pairs
+}
+
+// plainRatios formats engagement ratios as key=value pairs.
+func plainRatios(r report.Ratios) string {
+	s := fmt.Sprintf("likes_per_play=%.1f%% downloads_per_play=%.1f%%", r.LikesPerPlay, r.DownloadsPerPlay)
+	if r.MessagesPer1k != nil {
+		s += fmt.Sprintf(" messages_per_1k=%.1f", *r.MessagesPer1k)
+	}
+	return s
 }
 
 // PrintPlayStatistics prints time-series play statistics.
 func (f *Formatter) PrintPlayStatistics(stats []models.PlayStatistics) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -584,18 +1043,26 @@ func (f *Formatter) printPlayStatisticsTable(stats []models.PlayStatistics) {
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
 		rows[i] = []string{
-			s.Date,
-			fmt.Sprintf("%d", s.PlaysCount),
-			fmt.Sprintf("%d", s.PlaysOndemandCount),
-			fmt.Sprintf("%d", s.PlaysLiveCount),
-			fmt.Sprintf("%d", s.DownloadsCount),
+			s.Date.String(),
+			f.num(s.PlaysCount),
+			f.num(s.PlaysOndemandCount),
+			f.num(s.PlaysLiveCount),
+			f.num(s.DownloadsCount),
 		}
 	}
+	rows = append(rows, totalRows(f, stats,
+		nil,
+		func(s models.PlayStatistics) int { return s.PlaysCount },
+		func(s models.PlayStatistics) int { return s.PlaysOndemandCount },
+		func(s models.PlayStatistics) int { return s.PlaysLiveCount },
+		func(s models.PlayStatistics) int { return s.DownloadsCount },
+	)...)
 	f.renderTable(header, rows)
 }
 
 // PrintDeviceStatistics prints device breakdown statistics.
 func (f *Formatter) PrintDeviceStatistics(stats []models.DeviceStatistics) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -617,39 +1084,57 @@ func (f *Formatter) printDeviceStatisticsTable(stats []models.DeviceStatistics)
 	f.renderTable(header, rows)
 }
 
-// PrintGeographicStatistics prints geographic breakdown statistics.
+// PrintGeographicStatistics prints geographic breakdown statistics. Play
+// counts are included when the API reported them.
 func (f *Formatter) PrintGeographicStatistics(stats *models.GeographicStatistics) {
+	counts := stats.HasCounts()
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
 	case FormatPlain:
-		for _, c := range stats.Country {
-			fmt.Fprintf(f.writer, "country\t%s\t%.1f%%\n", c.Name, c.Percentage)
-		}
-		for _, c := range stats.City {
-			fmt.Fprintf(f.writer, "city\t%s\t%.1f%%\n", c.Name, c.Percentage)
+		for _, kind := range []struct {
+			name string
+			list []models.GeoStatistics
+		}{{"country", stats.Country}, {"city", stats.City}} {
+			for _, c := range kind.list {
+				if counts {
+					fmt.Fprintf(f.writer, "%s\t%s\t%.1f%%\t%d\n", kind.name, c.Name, c.Percentage, c.PlaysCount)
+				} else {
+					fmt.Fprintf(f.writer, "%s\t%s\t%.1f%%\n", kind.name, c.Name, c.Percentage)
+				}
+			}
 		}
 	default:
-		f.printGeographicStatisticsTable(stats)
+		f.printGeographicStatisticsTable(stats, counts)
 	}
 }
 
-func (f *Formatter) printGeographicStatisticsTable(stats *models.GeographicStatistics) {
+func (f *Formatter) printGeographicStatisticsTable(stats *models.GeographicStatistics, counts bool) {
 	f.renderSection("By Country")
-	countryRows := make([][]string, len(stats.Country))
-	for i, c := range stats.Country {
-		countryRows[i] = []string{c.Name, fmt.Sprintf("%.1f%%", c.Percentage)}
-	}
-	f.renderTable([]string{"COUNTRY", "PERCENTAGE"}, countryRows)
+	f.renderTable(geoHeader("COUNTRY", counts), f.geoRows(stats.Country, counts))
 
 	fmt.Fprintln(f.writer)
 
 	f.renderSection("By City")
-	cityRows := make([][]string, len(stats.City))
-	for i, c := range stats.City {
-		cityRows[i] = []string{c.Name, fmt.Sprintf("%.1f%%", c.Percentage)}
+	f.renderTable(geoHeader("CITY", counts), f.geoRows(stats.City, counts))
+}
+
+func geoHeader(name string, counts bool) []string {
+	if counts {
+		return []string{name, "PERCENTAGE", "PLAYS"}
+	}
+	return []string{name, "PERCENTAGE"}
+}
+
+func (f *Formatter) geoRows(list []models.GeoStatistics, counts bool) [][]string {
+	rows := make([][]string, len(list))
+	for i, c := range list {
+		rows[i] = []string{c.Name, fmt.Sprintf("%.1f%%", c.Percentage)}
+		if counts {
+			rows[i] = append(rows[i], f.num(c.PlaysCount))
+		}
 	}
-	f.renderTable([]string{"CITY", "PERCENTAGE"}, cityRows)
+	return rows
 }
 
 // PrintSourcesStatistics prints sources breakdown statistics.
@@ -670,13 +1155,14 @@ func (f *Formatter) printSourcesStatisticsTable(stats *models.SourcesStatistics)
 	header := []string{"SOURCE", "PLAYS", "PERCENTAGE"}
 	rows := make([][]string, len(stats.Overall))
 	for i, s := range stats.Overall {
-		rows[i] = []string{s.Name, fmt.Sprintf("%d", s.PlaysCount), fmt.Sprintf("%d%%", s.Percentage)}
+		rows[i] = []string{s.Name, f.num(s.PlaysCount), fmt.Sprintf("%d%%", s.Percentage)}
 	}
 	f.renderTable(header, rows)
 }
 
 // PrintListenersStatistics prints time-series listeners statistics.
 func (f *Formatter) PrintListenersStatistics(stats []models.ListenersStatistics) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -693,13 +1179,15 @@ func (f *Formatter) printListenersStatisticsTable(stats []models.ListenersStatis
 	header := []string{"DATE", "LISTENERS"}
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
-		rows[i] = []string{s.Date, fmt.Sprintf("%d", s.ListenersCount)}
+		rows[i] = []string{s.Date.String(), f.num(s.ListenersCount)}
 	}
+	rows = append(rows, totalRows(f, stats, nil, func(s models.ListenersStatistics) int { return s.ListenersCount })...)
 	f.renderTable(header, rows)
 }
 
 // PrintShowsPlayTotals prints play totals for each show.
 func (f *Formatter) PrintShowsPlayTotals(stats []models.ShowPlayTotals) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -713,23 +1201,34 @@ func (f *Formatter) PrintShowsPlayTotals(stats []models.ShowPlayTotals) {
 }
 
 func (f *Formatter) printShowsPlayTotalsTable(stats []models.ShowPlayTotals) {
-	header := []string{"SHOW ID", "TITLE", "PLAYS", "ON DEMAND", "LIVE", "DOWNLOADS"}
+	header := []string{"SHOW ID", "TITLE", "PLAYS", "ON DEMAND", "LIVE", "DOWNLOADS", "DOWNLOADS/PLAY"}
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
 		rows[i] = []string{
 			fmt.Sprintf("%d", s.ShowID),
-			truncate(s.Title, 30),
-			fmt.Sprintf("%d", s.PlaysCount),
-			fmt.Sprintf("%d", s.PlaysOndemandCount),
-			fmt.Sprintf("%d", s.PlaysLiveCount),
-			fmt.Sprintf("%d", s.DownloadsCount),
+			f.clip(s.Title, 30),
+			f.num(s.PlaysCount),
+			f.num(s.PlaysOndemandCount),
+			f.num(s.PlaysLiveCount),
+			f.num(s.DownloadsCount),
+			fmt.Sprintf("%.1f%%", report.PerPlay(s.DownloadsCount, s.PlaysCount)),
 		}
 	}
+	rows = append(rows, totalRows(f, stats,
+		nil,
+		nil,
+		func(s models.ShowPlayTotals) int { return s.PlaysCount },
+		func(s models.ShowPlayTotals) int { return s.PlaysOndemandCount },
+		func(s models.ShowPlayTotals) int { return s.PlaysLiveCount },
+		func(s models.ShowPlayTotals) int { return s.DownloadsCount },
+		nil,
+	)...)
 	f.renderTable(header, rows)
 }
 
 // PrintEpisodesPlayTotals prints play totals for each episode.
 func (f *Formatter) PrintEpisodesPlayTotals(stats []models.EpisodePlayTotals) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -743,23 +1242,34 @@ func (f *Formatter) PrintEpisodesPlayTotals(stats []models.EpisodePlayTotals) {
 }
 
 func (f *Formatter) printEpisodesPlayTotalsTable(stats []models.EpisodePlayTotals) {
-	header := []string{"EPISODE ID", "TITLE", "PLAYS", "ON DEMAND", "LIVE", "DOWNLOADS"}
+	header := []string{"EPISODE ID", "TITLE", "PLAYS", "ON DEMAND", "LIVE", "DOWNLOADS", "DOWNLOADS/PLAY"}
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
 		rows[i] = []string{
 			fmt.Sprintf("%d", s.EpisodeID),
-			truncate(s.Title, 30),
-			fmt.Sprintf("%d", s.PlaysCount),
-			fmt.Sprintf("%d", s.PlaysOndemandCount),
-			fmt.Sprintf("%d", s.PlaysLiveCount),
-			fmt.Sprintf("%d", s.DownloadsCount),
+			f.clip(s.Title, 30),
+			f.num(s.PlaysCount),
+			f.num(s.PlaysOndemandCount),
+			f.num(s.PlaysLiveCount),
+			f.num(s.DownloadsCount),
+			fmt.Sprintf("%.1f%%", report.PerPlay(s.DownloadsCount, s.PlaysCount)),
 		}
 	}
+	rows = append(rows, totalRows(f, stats,
+		nil,
+		nil,
+		func(s models.EpisodePlayTotals) int { return s.PlaysCount },
+		func(s models.EpisodePlayTotals) int { return s.PlaysOndemandCount },
+		func(s models.EpisodePlayTotals) int { return s.PlaysLiveCount },
+		func(s models.EpisodePlayTotals) int { return s.DownloadsCount },
+		nil,
+	)...)
 	f.renderTable(header, rows)
 }
 
 // PrintLikesStatistics prints time-series likes statistics.
 func (f *Formatter) PrintLikesStatistics(stats []models.LikesStatistics) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -776,13 +1286,15 @@ func (f *Formatter) printLikesStatisticsTable(stats []models.LikesStatistics) {
 	header := []string{"DATE", "LIKES"}
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
-		rows[i] = []string{s.Date, fmt.Sprintf("%d", s.LikesCount)}
+		rows[i] = []string{s.Date.String(), f.num(s.LikesCount)}
 	}
+	rows = append(rows, totalRows(f, stats, nil, func(s models.LikesStatistics) int { return s.LikesCount })...)
 	f.renderTable(header, rows)
 }
 
 // PrintFollowersStatistics prints time-series followers statistics.
 func (f *Formatter) PrintFollowersStatistics(stats []models.FollowersStatistics) {
+	stats = sorted(f, stats)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(stats)
@@ -799,8 +1311,9 @@ func (f *Formatter) printFollowersStatisticsTable(stats []models.FollowersStatis
 	header := []string{"DATE", "FOLLOWERS"}
 	rows := make([][]string, len(stats))
 	for i, s := range stats {
-		rows[i] = []string{s.Date, fmt.Sprintf("%d", s.FollowersCount)}
+		rows[i] = []string{s.Date.String(), f.num(s.FollowersCount)}
 	}
+	rows = append(rows, totalRows(f, stats, nil, func(s models.FollowersStatistics) int { return s.FollowersCount })...)
 	f.renderTable(header, rows)
 }
 
@@ -839,105 +1352,1181 @@ func (f *Formatter) printOSStatisticsTable(stats *models.OSStatisticsBreakdown)
 	f.renderTable([]string{"OS", "PERCENTAGE"}, mobileRows)
 }
 
-// PrintExploreShows prints a list of shows from explore endpoints.
-func (f *Formatter) PrintExploreShows(shows []models.ExploreShow) {
+// PrintListeningTime prints estimated listening hours per episode, ranked.
+func (f *Formatter) PrintListeningTime(r *report.ListeningTime) {
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(shows)
+		f.printJSON(r)
 	case FormatPlain:
-		for _, s := range shows {
-			fmt.Fprintf(f.writer, "%d\t%s\n", s.ShowID, s.Title)
+		for _, e := range r.Episodes {
+			fmt.Fprintf(f.writer, "%d\t%s\t%d\t%.1f\n", e.EpisodeID, e.Title, e.PlaysCount, e.ListeningHours)
 		}
 	default:
-		f.printExploreShowsTable(shows)
+		f.printListeningTimeTable(r)
 	}
 }
 
-func (f *Formatter) printExploreShowsTable(shows []models.ExploreShow) {
-	header := []string{"ID", "TITLE", "URL"}
-	rows := make([][]string, len(shows))
-	for i, s := range shows {
+func (f *Formatter) printListeningTimeTable(r *report.ListeningTime) {
+	header := []string{"RANK", "EPISODE ID", "TITLE", "PLAYS", "DURATION", "HOURS"}
+	rows := make([][]string, len(r.Episodes))
+	for i, e := range r.Episodes {
+		duration := "-"
+		if e.Duration > 0 {
+			duration = formatDuration(e.Duration)
+		}
 		rows[i] = []string{
-			fmt.Sprintf("%d", s.ShowID),
-			truncate(s.Title, 40),
-			s.SiteURL,
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("%d", e.EpisodeID),
+			f.clip(e.Title, 30),
+			f.num(e.PlaysCount),
+			duration,
+			fmt.Sprintf("%.1f", e.ListeningHours),
 		}
 	}
 	f.renderTable(header, rows)
-}
-
 
-// -----------------------------------------------------------------------------
-// Miscellaneous Output
-// -----------------------------------------------------------------------------
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Total Plays:", f.num(r.PlaysCount)},
+		{"Total Hours:", fmt.Sprintf("%.1f", r.ListeningHours)},
+		{"Completion:", fmt.Sprintf("%.0f%%", r.Completion*100)},
+	})
+}
 
-func (f *Formatter) PrintCategories(categories []models.Category) {
+// PrintLocalStats prints statistics stored in the local catalog, with the
+// moving average of plays when window is above 0.
+func (f *Formatter) PrintLocalStats(rows []catalog.Row, window int) {
+	rows = sorted(f, rows)
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(categories)
+		f.printJSON(rows)
 	case FormatPlain:
-		for _, c := range categories {
-			fmt.Fprintf(f.writer, "%d\t%s\t%d\n", c.CategoryID, c.Name, c.Level)
+		for _, r := range rows {
+			fmt.Fprintf(f.writer, "%s\t%d\t%d\t%d\t%d\n", r.Date, r.Plays, r.Downloads, r.Likes, r.Followers)
 		}
 	default:
-		f.printCategoriesTable(categories)
+		f.printLocalStatsTable(rows, window)
 	}
 }
 
-func (f *Formatter) printCategoriesTable(categories []models.Category) {
-	header := []string{"ID", "NAME", "LEVEL"}
-	rows := make([][]string, len(categories))
-	for i, c := range categories {
-		name := c.Name
-		if c.Level == 2 {
-			name = "  └─ " + name
+func (f *Formatter) printLocalStatsTable(rows []catalog.Row, window int) {
+	header := []string{"DATE", "PLAYS", "DOWNLOADS", "LIKES", "FOLLOWERS"}
+	if window > 0 {
+		header = append(header, "PLAYS AVG")
+	}
+	table := make([][]string, len(rows))
+	for i, r := range rows {
+		table[i] = []string{
+			r.Date,
+			f.num(r.Plays),
+			f.num(r.Downloads),
+			f.num(r.Likes),
+			f.num(r.Followers),
 		}
-		rows[i] = []string{
-			fmt.Sprintf("%d", c.CategoryID),
-			name,
-			fmt.Sprintf("%d", c.Level),
+		if window > 0 {
+			avg := "-"
+			if r.PlaysAvg != nil {
+				avg = fmt.Sprintf("%.1f", *r.PlaysAvg)
+			}
+			table[i] = append(table[i], avg)
 		}
 	}
-	f.renderTable(header, rows)
+	sums := []func(catalog.Row) int{
+		nil,
+		func(r catalog.Row) int { return r.Plays },
+		func(r catalog.Row) int { return r.Downloads },
+		func(r catalog.Row) int { return r.Likes },
+		func(r catalog.Row) int { return r.Followers },
+	}
+	if window > 0 {
+		sums = append(sums, nil)
+	}
+	table = append(table, totalRows(f, rows, sums...)...)
+	f.renderTable(header, table)
 }
 
-func (f *Formatter) PrintGooglePlayCategories(categories []models.GooglePlayCategory) {
+// PrintStatsTimezone notes the time zone stats dates were given in and the
+// UTC days requested from the API. It is only printed with table output, so
+// JSON and plain output stay parseable.
+func (f *Formatter) PrintStatsTimezone(zone, from, to string) {
+	if f.format == FormatJSON || f.format == FormatPlain {
+		return
+	}
+	fmt.Fprintln(f.writer)
+	f.PrintMessage(i18n.T("Dates are days in %s, requested as UTC days %s to %s", zone, orDash(from), orDash(to)))
+}
+
+// PrintEngagement prints plays and likes per episode, ranked by plays.
+func (f *Formatter) PrintEngagement(r *report.Engagement) {
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(categories)
+		f.printJSON(r)
 	case FormatPlain:
-		for _, c := range categories {
-			fmt.Fprintf(f.writer, "%d\t%s\n", c.CategoryID, c.Name)
+		for _, e := range r.Episodes {
+			fmt.Fprintf(f.writer, "%d\t%s\t%d\t%d\t%d\t%.1f\n", e.EpisodeID, e.Title, e.PlaysCount, e.DownloadsCount, e.LikesCount, e.LikesPerPlay)
 		}
 	default:
-		f.printGooglePlayCategoriesTable(categories)
+		f.printEngagementTable(r)
 	}
 }
 
-func (f *Formatter) printGooglePlayCategoriesTable(categories []models.GooglePlayCategory) {
-	header := []string{"ID", "NAME"}
-	rows := make([][]string, len(categories))
-	for i, c := range categories {
-		rows[i] = []string{fmt.Sprintf("%d", c.CategoryID), c.Name}
+func (f *Formatter) printEngagementTable(r *report.Engagement) {
+	header := []string{"RANK", "EPISODE ID", "TITLE", "PLAYS", "DOWNLOADS", "LIKES", "LIKES/PLAY"}
+	rows := make([][]string, len(r.Episodes))
+	for i, e := range r.Episodes {
+		rows[i] = []string{
+			fmt.Sprintf("%d", i+1),
+			fmt.Sprintf("%d", e.EpisodeID),
+			f.clip(e.Title, 30),
+			f.num(e.PlaysCount),
+			f.num(e.DownloadsCount),
+			f.num(e.LikesCount),
+			fmt.Sprintf("%.1f%%", e.LikesPerPlay),
+		}
 	}
 	f.renderTable(header, rows)
+
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Period:", fmt.Sprintf("%s → %s", r.From, r.To)},
+		{"Total Plays:", f.num(r.PlaysCount)},
+		{"Total Likes:", f.num(r.LikesCount)},
+	})
 }
 
-func (f *Formatter) PrintLanguages(languages []models.Language) {
+// PrintFunnel prints where a show's plays come from, on which devices and
+// in which countries.
+func (f *Formatter) PrintFunnel(r *report.Funnel) {
 	switch f.format {
 	case FormatJSON:
-		f.printJSON(languages)
+		f.printJSON(r)
 	case FormatPlain:
-		for _, l := range languages {
-			fmt.Fprintf(f.writer, "%s\t%s\n", l.Code, l.Name)
+		for _, section := range []struct {
+			name   string
+			shares []report.Share
+		}{{"source", r.Sources}, {"device", r.Devices}, {"country", r.Countries}} {
+			for _, s := range section.shares {
+				fmt.Fprintf(f.writer, "%s\t%s\t%.1f%%\t%d\n", section.name, s.Name, s.Percentage, s.PlaysCount)
+			}
 		}
 	default:
-		f.printLanguagesTable(languages)
+		f.printFunnelTable(r)
 	}
 }
 
-func (f *Formatter) printLanguagesTable(languages []models.Language) {
-	header := []string{"CODE", "LANGUAGE"}
+func (f *Formatter) printFunnelTable(r *report.Funnel) {
+	f.PrintKeyValue([][2]string{
+		{"Period:", fmt.Sprintf("%s → %s", r.From, r.To)},
+		{"Total Plays:", f.num(r.PlaysCount)},
+	})
+
+	for _, section := range []struct {
+		title, name string
+		shares      []report.Share
+	}{
+		{"By Source", "SOURCE", r.Sources},
+		{"By Device", "DEVICE", r.Devices},
+		{"By Country", "COUNTRY", r.Countries},
+	} {
+		fmt.Fprintln(f.writer)
+		f.renderSection(section.title)
+		rows := make([][]string, len(section.shares))
+		for i, s := range section.shares {
+			plays := f.num(s.PlaysCount)
+			if s.Estimated {
+				plays = "~" + plays
+			}
+			rows[i] = []string{s.Name, fmt.Sprintf("%.1f%%", s.Percentage), plays}
+		}
+		f.renderTable([]string{section.name, "PERCENTAGE", "PLAYS"}, rows)
+	}
+
+	estimated := false
+	for _, s := range slices.Concat(r.Devices, r.Countries) {
+		estimated = estimated || s.Estimated
+	}
+	if !estimated {
+		return
+	}
+	fmt.Fprintln(f.writer)
+	f.PrintMessage(i18n.T("~ plays are estimated from shares: the API reports sources, devices and countries separately."))
+}
+
+// PrintTagStats prints how the episodes of each tag performed.
+func (f *Formatter) PrintTagStats(r *report.TagStats) {
+	switch f.format {
+	case FormatJSON:
+		out := *r
+		if out.Tags == nil {
+			out.Tags = []report.TagStat{}
+		}
+		f.printJSON(&out)
+	case FormatPlain:
+		for _, t := range r.Tags {
+			fmt.Fprintf(f.writer, "%s\t%d\t%d\t%.1f\n", t.Tag, t.EpisodesCount, t.PlaysCount, t.AveragePlays)
+		}
+	default:
+		f.printTagStatsTable(r)
+	}
+}
+
+func (f *Formatter) printTagStatsTable(r *report.TagStats) {
+	header := []string{"TAG", "EPISODES", "PLAYS", "AVG PLAYS"}
+	rows := make([][]string, len(r.Tags))
+	for i, t := range r.Tags {
+		rows[i] = []string{
+			f.clip(t.Tag, 30),
+			f.num(t.EpisodesCount),
+			f.num(t.PlaysCount),
+			fmt.Sprintf("%.1f", t.AveragePlays),
+		}
+	}
+	f.renderTable(header, rows)
+
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Period:", fmt.Sprintf("%s → %s", r.From, r.To)},
+		{"Episodes:", f.num(r.EpisodesCount)},
+		{"Untagged:", f.num(r.UntaggedCount)},
+	})
+}
+
+// PrintNetworkStats prints the statistics of each show of a network and
+// their total.
+func (f *Formatter) PrintNetworkStats(r report.NetworkStats) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(r)
+	case FormatPlain:
+		for _, s := range r.Shows {
+			fmt.Fprintf(f.writer, "%d\t%s\t%d\t%d\t%d\t%.1f\n", s.ShowID, s.Title, s.Plays, s.Downloads, s.Likes, s.Share)
+		}
+	default:
+		f.printNetworkStatsTable(r)
+	}
+}
+
+func (f *Formatter) printNetworkStatsTable(r report.NetworkStats) {
+	header := []string{"ID", "SHOW", "EPISODES", "FOLLOWERS", "PLAYS", "DOWNLOADS", "LIKES", "SHARE"}
+	row := func(id, title string, s report.NetworkShow) []string {
+		return []string{
+			id,
+			title,
+			f.num(s.Episodes),
+			f.num(s.Followers),
+			f.num(s.Plays),
+			f.num(s.Downloads),
+			f.num(s.Likes),
+			fmt.Sprintf("%.1f%%", s.Share),
+		}
+	}
+	rows := make([][]string, 0, len(r.Shows)+1)
+	for _, s := range r.Shows {
+		rows = append(rows, row(fmt.Sprintf("%d", s.ShowID), f.clip(s.Title, 30), s))
+	}
+	rows = append(rows, row("", i18n.T("Total"), r.Total))
+	f.renderTable(header, rows)
+
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Period:", fmt.Sprintf("%s → %s", r.From, r.To)},
+		{"Shows:", fmt.Sprintf("%d", len(r.Shows))},
+	})
+}
+
+// PrintTagSuggestions prints suggested tags, best first.
+func (f *Formatter) PrintTagSuggestions(suggestions []text.Suggestion) {
+	suggestions = sorted(f, suggestions)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(suggestions)
+	case FormatPlain:
+		for _, s := range suggestions {
+			fmt.Fprintf(f.writer, "%s\t%g\t%t\n", s.Tag, s.Score, s.Known)
+		}
+	default:
+		header := []string{"TAG", "SCORE", "USED BEFORE"}
+		rows := make([][]string, len(suggestions))
+		for i, s := range suggestions {
+			used := "-"
+			if s.Known {
+				used = i18n.T("yes")
+			}
+			rows[i] = []string{s.Tag, fmt.Sprintf("%g", s.Score), used}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintStatsAlerts prints the comparison of each metric against its baseline.
+func (f *Formatter) PrintStatsAlerts(a *report.Alerts) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(a)
+	case FormatPlain:
+		for _, m := range a.Metrics {
+			fmt.Fprintf(f.writer, "%s\t%d\t%.1f\t%.1f\t%s\n", m.Metric, m.Current, m.Baseline, m.ChangePercent, m.Status)
+		}
+	default:
+		f.printStatsAlertsTable(a)
+	}
+}
+
+func (f *Formatter) printStatsAlertsTable(a *report.Alerts) {
+	f.PrintKeyValue([][2]string{
+		{"Period:", fmt.Sprintf("%s → %s", a.From, a.To)},
+		{"Threshold:", fmt.Sprintf("%g%%", a.Threshold)},
+	})
+	fmt.Fprintln(f.writer)
+
+	header := []string{"METRIC", "CURRENT", "BASELINE", "CHANGE", "STATUS"}
+	rows := make([][]string, len(a.Metrics))
+	for i, m := range a.Metrics {
+		rows[i] = []string{
+			i18n.T(m.Metric),
+			fmt.Sprintf("%d", m.Current),
+			fmt.Sprintf("%.1f", m.Baseline),
+			fmt.Sprintf("%+.1f%%", m.ChangePercent),
+			i18n.T(m.Status),
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+// PrintCampaigns prints the campaigns defined in the config.
+func (f *Formatter) PrintCampaigns(campaigns []report.Campaign) {
+	campaigns = sorted(f, campaigns)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(campaigns)
+	case FormatPlain:
+		for _, c := range campaigns {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\t%d\n", c.Name, c.From, c.To, c.Days)
+		}
+	default:
+		if len(campaigns) == 0 {
+			f.PrintMessage(i18n.T("No campaigns defined. Add one with 'spreaker config set campaigns.<name> FROM..TO'"))
+			return
+		}
+		header := []string{"NAME", "FROM", "TO", "DAYS"}
+		rows := make([][]string, len(campaigns))
+		for i, c := range campaigns {
+			rows[i] = []string{c.Name, orDash(c.From), orDash(c.To), fmt.Sprintf("%d", c.Days)}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintHistory prints journal entries, newest first as given.
+func (f *Formatter) PrintHistory(entries []journal.Entry) {
+	entries = sorted(f, entries)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(entries)
+	case FormatPlain:
+		for _, e := range entries {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\t%s %s\t%s\n", e.ID, e.Time.Format(time.RFC3339), e.Command, e.Method, e.Path, undoStatus(e))
+		}
+	default:
+		if len(entries) == 0 {
+			f.PrintMessage(i18n.T("No changes recorded yet."))
+			return
+		}
+		header := []string{"ID", "DATE", "COMMAND", "REQUEST", "UNDO"}
+		rows := make([][]string, len(entries))
+		for i, e := range entries {
+			rows[i] = []string{
+				fmt.Sprintf("%d", e.ID),
+				f.formatTime(e.Time, time.Local, "2006-01-02 15:04"),
+				e.Command,
+				e.Method + " " + e.Path,
+				undoStatus(e),
+			}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintQueue prints the changes queued while offline, oldest first.
+func (f *Formatter) PrintQueue(requests []spool.Request) {
+	requests = sorted(f, requests)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(requests)
+	case FormatPlain:
+		for _, r := range requests {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\t%s %s\n", r.ID, r.Queued.Format(time.RFC3339), r.Command, r.Method, r.Path)
+		}
+	default:
+		if len(requests) == 0 {
+			f.PrintMessage(i18n.T("The queue is empty."))
+			return
+		}
+		header := []string{"ID", "DATE", "COMMAND", "REQUEST"}
+		rows := make([][]string, len(requests))
+		for i, r := range requests {
+			rows[i] = []string{
+				fmt.Sprintf("%d", r.ID),
+				f.formatTime(r.Queued, time.Local, "2006-01-02 15:04"),
+				r.Command,
+				r.Method + " " + r.Path,
+			}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintQueueFlush prints the outcome of each queued change.
+func (f *Formatter) PrintQueueFlush(results []spool.Result) {
+	results = sorted(f, results)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(results)
+	case FormatPlain:
+		for _, r := range results {
+			fmt.Fprintf(f.writer, "%d\t%s %s\t%s\t%s\n", r.ID, r.Method, r.Path, r.Status, r.Reason)
+		}
+	default:
+		header := []string{"ID", "REQUEST", "STATUS", "REASON"}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{fmt.Sprintf("%d", r.ID), r.Method + " " + r.Path, i18n.T(r.Status), orDash(r.Reason)}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintEpisodeActions prints the outcome of an action, such as a like, on
+// each of several episodes.
+func (f *Formatter) PrintEpisodeActions(results []api.EpisodeActionResult) {
+	results = sorted(f, results)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(results)
+	case FormatPlain:
+		for _, r := range results {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\n", r.EpisodeID, r.Status, r.Reason)
+		}
+	default:
+		header := []string{"EPISODE", "STATUS", "REASON"}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{fmt.Sprintf("%d", r.EpisodeID), i18n.T(r.Status), orDash(r.Reason)}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintJobs prints scheduled jobs.
+func (f *Formatter) PrintJobs(list []jobs.Job) {
+	list = sorted(f, list)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(list)
+	case FormatPlain:
+		for _, j := range list {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\t%d\t%s\t%s\n", j.ID, j.At.Format(time.RFC3339), j.Status, j.Attempts, j.CommandLine(), j.LastError)
+		}
+	default:
+		if len(list) == 0 {
+			f.PrintMessage(i18n.T("No jobs scheduled."))
+			return
+		}
+		header := []string{"ID", "AT", "STATUS", "ATTEMPTS", "COMMAND", "LAST ERROR"}
+		rows := make([][]string, len(list))
+		for i, j := range list {
+			rows[i] = []string{
+				fmt.Sprintf("%d", j.ID),
+				f.formatTime(j.At, time.Local, "2006-01-02 15:04"),
+				i18n.T(j.Status),
+				fmt.Sprintf("%d/%d", j.Attempts, j.Retries+1),
+				f.clip(j.CommandLine(), 60),
+				orDash(f.clip(j.LastError, 40)),
+			}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// undoStatus describes whether a journal entry can be undone.
+func undoStatus(e journal.Entry) string {
+	switch {
+	case e.UndoOf != 0:
+		return i18n.T("undo of #%d", e.UndoOf)
+	case e.Undone:
+		return i18n.T("undone")
+	}
+	if _, ok := e.Reverse(); ok {
+		return i18n.T("yes")
+	}
+	return "-"
+}
+
+// PrintCampaignComparison prints a campaign's metrics against the window
+// before it, after the statistics of the campaign window. Like
+// PrintStatsTimezone it is skipped in JSON and plain output, which stay a
+// single parseable document.
+func (f *Formatter) PrintCampaignComparison(c *report.CampaignComparison) {
+	if f.format == FormatJSON || f.format == FormatPlain {
+		return
+	}
+	fmt.Fprintln(f.writer)
+	f.PrintKeyValue([][2]string{
+		{"Campaign:", fmt.Sprintf("%s (%s → %s)", c.Campaign, c.From, c.To)},
+		{"Compared with:", fmt.Sprintf("%s → %s", c.PreviousFrom, c.PreviousTo)},
+	})
+	fmt.Fprintln(f.writer)
+
+	header := []string{"METRIC", "CAMPAIGN", "PREVIOUS", "CHANGE"}
+	rows := make([][]string, len(c.Metrics))
+	for i, m := range c.Metrics {
+		rows[i] = []string{
+			i18n.T(m.Metric),
+			f.num(m.Campaign),
+			f.num(m.Previous),
+			fmt.Sprintf("%+.1f%%", m.ChangePercent),
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+// PrintDecay prints the plays of each episode at the decay milestones.
+// Milestones an episode has not reached yet are starred in tables.
+func (f *Formatter) PrintDecay(decays []report.EpisodeDecay) {
+	decays = sorted(f, decays)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(decays)
+	case FormatPlain:
+		for _, d := range decays {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\t%d", d.EpisodeID, d.Title, d.PublishedAt, d.LifetimePlays)
+			for _, m := range d.Milestones {
+				fmt.Fprintf(f.writer, "\t%d\t%.1f", m.Plays, m.Percent)
+			}
+			fmt.Fprintln(f.writer)
+		}
+	default:
+		f.printDecayTable(decays)
+	}
+}
+
+func (f *Formatter) printDecayTable(decays []report.EpisodeDecay) {
+	header := []string{"EPISODE ID", "TITLE", "PUBLISHED", "DAYS LIVE"}
+	for _, day := range report.DecayMilestones {
+		header = append(header, i18n.T("DAY %d", day))
+	}
+	header = append(header, "LIFETIME")
+
+	growing := false
+	rows := make([][]string, len(decays))
+	for i, d := range decays {
+		row := []string{
+			fmt.Sprintf("%d", d.EpisodeID),
+			f.clip(d.Title, 30),
+			d.PublishedAt,
+			fmt.Sprintf("%d", d.DaysLive),
+		}
+		for _, m := range d.Milestones {
+			cell := fmt.Sprintf("%s (%.1f%%)", f.num(m.Plays), m.Percent)
+			if !m.Reached {
+				cell += " *"
+				growing = true
+			}
+			row = append(row, cell)
+		}
+		rows[i] = append(row, f.num(d.LifetimePlays))
+	}
+	f.renderTable(header, rows)
+
+	if growing {
+		fmt.Fprintln(f.writer)
+		f.PrintMessage(i18n.T("* still growing: the episode is younger than this milestone"))
+	}
+}
+
+// PrintShowAudit prints the issues found by shows audit as a checklist.
+// Fixed issues are ticked; the rest are marked as fixable with --fix or manual.
+func (f *Formatter) PrintShowAudit(a *report.ShowAudit) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(a)
+	case FormatPlain:
+		for _, i := range a.Issues {
+			fmt.Fprintf(f.writer, "%d\t%s\t%v\n", i.EpisodeID, i.Code, i.Fixed)
+		}
+	default:
+		f.printShowAuditTable(a)
+	}
+}
+
+func (f *Formatter) printShowAuditTable(a *report.ShowAudit) {
+	f.PrintKeyValue([][2]string{
+		{"Show:", a.Title},
+		{"Episodes:", fmt.Sprintf("%d", a.Episodes)},
+		{"Issues:", fmt.Sprintf("%d", len(a.Issues))},
+	})
+
+	if len(a.Issues) == 0 {
+		return
+	}
+	fmt.Fprintln(f.writer)
+
+	header := []string{"", "EPISODE ID", "TITLE", "ISSUE", "FIX"}
+	rows := make([][]string, len(a.Issues))
+	for n, i := range a.Issues {
+		check, fix := "[ ]", i18n.T("manual")
+		switch {
+		case i.Fixed:
+			check, fix = "[x]", i18n.T("fixed")
+		case i.Fixable:
+			fix = "--fix"
+		}
+		rows[n] = []string{
+			check,
+			fmt.Sprintf("%d", i.EpisodeID),
+			f.clip(i.Title, 30),
+			i18n.T(i.Message),
+			fix,
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+// feedDifferenceLabels describe the kinds of report.FeedDifference.
+var feedDifferenceLabels = map[string]string{
+	report.FeedMissingOnSpreaker: "Missing on Spreaker",
+	report.FeedMissingInFeed:     "Missing in feed",
+	report.FeedPublished:         "Publish date differs",
+	report.FeedDuration:          "Duration differs",
+}
+
+// PrintFeedDiff prints the differences found by shows diff-feed.
+func (f *Formatter) PrintFeedDiff(d *report.FeedDiff) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(d)
+	case FormatPlain:
+		for _, x := range d.Differences {
+			fmt.Fprintf(f.writer, "%s\t%d\t%s\t%s\t%s\n", x.Kind, x.EpisodeID, x.Title, x.Spreaker, x.Feed)
+		}
+	default:
+		f.PrintKeyValue([][2]string{
+			{"Show:", d.Title},
+			{"Feed:", orDash(d.FeedTitle)},
+			{"Episodes:", i18n.T("%d on Spreaker, %d in the feed, %d matched", d.Episodes, d.FeedItems, d.Matched)},
+			{"Differences:", fmt.Sprintf("%d", len(d.Differences))},
+		})
+		if len(d.Differences) == 0 {
+			return
+		}
+		fmt.Fprintln(f.writer)
+
+		header := []string{"DIFFERENCE", "EPISODE ID", "TITLE", "SPREAKER", "FEED"}
+		rows := make([][]string, len(d.Differences))
+		for n, x := range d.Differences {
+			id := "-"
+			if x.EpisodeID != 0 {
+				id = fmt.Sprintf("%d", x.EpisodeID)
+			}
+			rows[n] = []string{
+				i18n.T(feedDifferenceLabels[x.Kind]),
+				id,
+				f.clip(x.Title, 40),
+				orDash(x.Spreaker),
+				orDash(x.Feed),
+			}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// audioCheckLabels describe the checks of audio.Check.
+var audioCheckLabels = map[string]string{
+	audio.CheckLoudness:        "Loudness",
+	audio.CheckTruePeak:        "True peak",
+	audio.CheckClipping:        "Clipping",
+	audio.CheckLeadingSilence:  "Leading silence",
+	audio.CheckTrailingSilence: "Trailing silence",
+}
+
+// PrintAudioCheck prints the measurements of an audio file and the
+// problems found against the podcast norms.
+func (f *Formatter) PrintAudioCheck(r *audio.Report) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(r)
+	case FormatPlain:
+		for _, p := range r.Problems {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\n", p.Check, p.Value, p.Expected)
+		}
+	default:
+		f.PrintKeyValue([][2]string{
+			{"File:", r.File},
+			{"Duration:", formatDuration(int(r.Duration * 1000))},
+			{"Loudness:", fmt.Sprintf("%.1f LUFS", r.IntegratedLUFS)},
+			{"Loudness range:", fmt.Sprintf("%.1f LU", r.LoudnessRange)},
+			{"True peak:", fmt.Sprintf("%.1f dBTP", r.TruePeak)},
+			{"Sample peak:", fmt.Sprintf("%.1f dBFS", r.SamplePeak)},
+			{"Clipped samples:", fmt.Sprintf("%d", r.ClippedSamples)},
+			{"Leading silence:", fmt.Sprintf("%.1fs", r.LeadingSilence)},
+			{"Trailing silence:", fmt.Sprintf("%.1fs", r.TrailingSilence)},
+		})
+		fmt.Fprintln(f.writer)
+		if len(r.Problems) == 0 {
+			f.PrintSuccess(i18n.T("Within podcast norms"))
+			return
+		}
+
+		header := []string{"CHECK", "VALUE", "EXPECTED"}
+		rows := make([][]string, len(r.Problems))
+		for n, p := range r.Problems {
+			rows[n] = []string{i18n.T(audioCheckLabels[p.Check]), p.Value, p.Expected}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintSearchResults prints the results of search all, one section per type.
+func (f *Formatter) PrintSearchResults(r *api.SearchResults) {
+	switch f.format {
+	case FormatJSON:
+		out := *r
+		if out.Shows == nil {
+			out.Shows = []models.Show{}
+		}
+		if out.Episodes == nil {
+			out.Episodes = []models.Episode{}
+		}
+		f.printJSON(&out)
+	case FormatPlain:
+		for _, s := range r.Shows {
+			fmt.Fprintf(f.writer, "show\t%d\t%s\n", s.ShowID, s.Title)
+		}
+		for _, e := range r.Episodes {
+			fmt.Fprintf(f.writer, "episode\t%d\t%s\n", e.EpisodeID, e.Title)
+		}
+	default:
+		if len(r.Shows) > 0 {
+			f.renderSection("Shows")
+			f.printShowsTable(r.Shows)
+		}
+		if len(r.Episodes) > 0 {
+			if len(r.Shows) > 0 {
+				fmt.Fprintln(f.writer)
+			}
+			f.renderSection("Episodes")
+			f.printEpisodesTable(r.Episodes)
+		}
+	}
+}
+
+// PrintShowFeedback prints the feedback report of shows reviews: totals and
+// sentiment, likes and messages per episode, then recent listener messages.
+func (f *Formatter) PrintShowFeedback(r *report.ShowFeedback) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(r)
+	case FormatPlain:
+		for _, e := range r.Episodes {
+			fmt.Fprintf(f.writer, "%d\t%d\t%d\t%d\t%d\n", e.EpisodeID, e.LikesCount, e.MessagesCount, e.Sentiment.Positive, e.Sentiment.Negative)
+		}
+	default:
+		f.printShowFeedbackTable(r)
+	}
+}
+
+func (f *Formatter) printShowFeedbackTable(r *report.ShowFeedback) {
+	f.PrintKeyValue([][2]string{
+		{"Show:", r.Title},
+		{"Likes:", f.num(r.LikesCount)},
+		{"Messages:", f.num(r.MessagesCount)},
+		{"Sentiment:", i18n.T("%d positive, %d negative, %d neutral", r.Sentiment.Positive, r.Sentiment.Negative, r.Sentiment.Neutral)},
+	})
+
+	if len(r.Episodes) > 0 {
+		fmt.Fprintln(f.writer)
+		f.renderSection("Episodes")
+		header := []string{"ID", "TITLE", "LIKES", "MESSAGES", "POSITIVE", "NEGATIVE"}
+		rows := make([][]string, len(r.Episodes))
+		for n, e := range r.Episodes {
+			rows[n] = []string{
+				fmt.Sprintf("%d", e.EpisodeID),
+				f.clip(e.Title, 40),
+				f.num(e.LikesCount),
+				f.num(e.MessagesCount),
+				f.num(e.Sentiment.Positive),
+				f.num(e.Sentiment.Negative),
+			}
+		}
+		f.renderTable(header, rows)
+	}
+
+	if len(r.Recent) > 0 {
+		fmt.Fprintln(f.writer)
+		f.renderSection("Recent Messages")
+		header := []string{"DATE", "EPISODE ID", "AUTHOR", "SENTIMENT", "MESSAGE"}
+		rows := make([][]string, len(r.Recent))
+		for n, m := range r.Recent {
+			rows[n] = []string{
+				f.localTime(m.CreatedAt),
+				fmt.Sprintf("%d", m.EpisodeID),
+				orDash(m.Author),
+				i18n.T(m.Sentiment),
+				f.clip(m.Text, MessagePreviewLength),
+			}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintBrokenLinks prints the links that failed shows check-links, per episode.
+func (f *Formatter) PrintBrokenLinks(links []linkcheck.BrokenLink) {
+	links = sorted(f, links)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(links)
+	case FormatPlain:
+		for _, l := range links {
+			fmt.Fprintf(f.writer, "%d\t%s\t%s\n", l.EpisodeID, l.URL, l.Error)
+		}
+	default:
+		f.printBrokenLinksTable(links)
+	}
+}
+
+func (f *Formatter) printBrokenLinksTable(links []linkcheck.BrokenLink) {
+	header := []string{"EPISODE ID", "TITLE", "SOURCE", "URL", "ERROR"}
+	rows := make([][]string, len(links))
+	for i, l := range links {
+		rows[i] = []string{
+			fmt.Sprintf("%d", l.EpisodeID),
+			f.clip(l.Title, 30),
+			f.clip(l.Source, 25),
+			l.URL,
+			f.clip(l.Error, 40),
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+// PrintExploreShows prints a list of shows from explore endpoints.
+func (f *Formatter) PrintExploreShows(shows []models.ExploreShow) {
+	shows = sorted(f, shows)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(shows)
+	case FormatPlain:
+		for _, s := range shows {
+			fmt.Fprintf(f.writer, "%d\t%s\n", s.ShowID, s.Title)
+		}
+	default:
+		f.printExploreShowsTable(shows)
+	}
+}
+
+func (f *Formatter) printExploreShowsTable(shows []models.ExploreShow) {
+	header := []string{"ID", "TITLE", "URL"}
+	rows := make([][]string, len(shows))
+	for i, s := range shows {
+		rows[i] = []string{
+			fmt.Sprintf("%d", s.ShowID),
+			f.clip(s.Title, 40),
+			s.SiteURL,
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+
+// -----------------------------------------------------------------------------
+// API Diagnostics Output
+// -----------------------------------------------------------------------------
+
+// PrintShapeChecks prints the result of validating responses against models.
+func (f *Formatter) PrintShapeChecks(checks []api.ShapeCheck) {
+	checks = sorted(f, checks)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(checks)
+	case FormatPlain:
+		for _, c := range checks {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\n", c.Endpoint, shapeCheckStatus(c), shapeCheckDetails(c))
+		}
+	default:
+		f.printShapeChecksTable(checks)
+	}
+}
+
+func (f *Formatter) printShapeChecksTable(checks []api.ShapeCheck) {
+	header := []string{"ENDPOINT", "STATUS", "DETAILS"}
+	rows := make([][]string, len(checks))
+	for i, c := range checks {
+		rows[i] = []string{c.Endpoint, i18n.T(shapeCheckStatus(c)), shapeCheckDetails(c)}
+	}
+	f.renderTable(header, rows)
+}
+
+func shapeCheckStatus(c api.ShapeCheck) string {
+	switch {
+	case c.Error != "":
+		return "error"
+	case c.OK():
+		return "ok"
+	default:
+		return "changed"
+	}
+}
+
+func shapeCheckDetails(c api.ShapeCheck) string {
+	if c.Error != "" {
+		return c.Error
+	}
+	var parts []string
+	if len(c.Unknown) > 0 {
+		parts = append(parts, "unknown: "+strings.Join(c.Unknown, ", "))
+	}
+	if len(c.Missing) > 0 {
+		parts = append(parts, "missing: "+strings.Join(c.Missing, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// PrintAuthStatus prints the account a token belongs to, where the token
+// came from, and its expiry and scopes when it reports them.
+func (f *Formatter) PrintAuthStatus(user *models.User, source string, info api.TokenInfo) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(struct {
+			UserID   int           `json:"user_id"`
+			Username string        `json:"username"`
+			Source   string        `json:"source"`
+			Token    api.TokenInfo `json:"token"`
+			CanWrite bool          `json:"can_write"`
+		}{user.UserID, user.Username, source, info, info.CanWrite()})
+	case FormatPlain:
+		expires := ""
+		if info.Expires != nil {
+			expires = info.Expires.Format(time.RFC3339)
+		}
+		fmt.Fprintf(f.writer, "%d\t%s\t%s\t%s\t%s\n", user.UserID, user.Username, source, expires, strings.Join(info.Scopes, " "))
+	default:
+		expires, refresh := i18n.T("not reported"), "-"
+		if info.Expires != nil {
+			expires = f.formatTime(*info.Expires, time.Local, "2006-01-02 15:04")
+			refresh = relativeTime(*info.Expires, f.now())
+		}
+		scopes, write := i18n.T("not reported"), "-"
+		if info.Scopes != nil {
+			scopes, write = orDash(strings.Join(info.Scopes, ", ")), i18n.T("yes")
+			if !info.CanWrite() {
+				write = i18n.T("no")
+			}
+		}
+		f.PrintKeyValue([][2]string{
+			{"User:", fmt.Sprintf("%s (@%s)", user.Fullname, user.Username)},
+			{"User ID:", fmt.Sprintf("%d", user.UserID)},
+			{"Token Source:", source},
+			{"Expires:", expires},
+			{"Refresh:", refresh},
+			{"Scopes:", scopes},
+			{"Write Access:", write},
+		})
+	}
+}
+
+// PrintVersion prints build information and, when the API was probed, its
+// compatibility with the pinned API version.
+func (f *Formatter) PrintVersion(info buildinfo.Info, compat *api.APICompatibility) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(struct {
+			Build buildinfo.Info        `json:"build"`
+			API   *api.APICompatibility `json:"api,omitempty"`
+		}{info, compat})
+	case FormatPlain:
+		fmt.Fprintln(f.writer, info.Version)
+	default:
+		commit := orDash(info.ShortCommit())
+		if info.Modified {
+			commit += " (modified)"
+		}
+		f.PrintKeyValue([][2]string{
+			{"Version:", info.Version},
+			{"Commit:", commit},
+			{"Built:", orDash(info.Date)},
+			{"Go:", info.GoVersion},
+			{"Platform:", info.Platform},
+		})
+		if compat == nil {
+			return
+		}
+		fmt.Fprintln(f.writer)
+		f.PrintKeyValue([][2]string{
+			{"API URL:", compat.BaseURL},
+			{"Pinned Version:", compat.PinnedVersion},
+			{"Server Version:", orDash(compat.ServerVersion)},
+			{"Compatibility:", compatStatus(compat)},
+		})
+	}
+}
+
+func compatStatus(c *api.APICompatibility) string {
+	switch {
+	case c.Current:
+		return i18n.T("current")
+	case c.Sunset != "":
+		return i18n.T("deprecated, removed after %s", c.Sunset)
+	case c.Deprecation != "":
+		return i18n.T("deprecated")
+	default:
+		return i18n.T("unavailable (HTTP %d)", c.StatusCode)
+	}
+}
+
+// PrintConfigKeys prints the supported configuration keys.
+func (f *Formatter) PrintConfigKeys(keys []config.KeyInfo) {
+	keys = sorted(f, keys)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(keys)
+	case FormatPlain:
+		for _, k := range keys {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\n", k.Key, k.Type, k.Default)
+		}
+	default:
+		header := []string{"KEY", "TYPE", "DEFAULT", "DESCRIPTION"}
+		rows := make([][]string, len(keys))
+		for i, k := range keys {
+			rows[i] = []string{k.Key, k.Type, orDash(k.Default), k.Description}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// PrintConfigProblems prints the result of "config validate".
+func (f *Formatter) PrintConfigProblems(problems []config.Problem) {
+	problems = sorted(f, problems)
+	switch f.format {
+	case FormatJSON:
+		if problems == nil {
+			problems = []config.Problem{}
+		}
+		f.printJSON(problems)
+	case FormatPlain:
+		for _, p := range problems {
+			fmt.Fprintf(f.writer, "%s\t%s\t%s\n", p.Severity, p.Key, p.Message)
+		}
+	default:
+		header := []string{"SEVERITY", "KEY", "PROBLEM"}
+		rows := make([][]string, len(problems))
+		for i, p := range problems {
+			rows[i] = []string{i18n.T(p.Severity), p.Key, p.Message}
+		}
+		f.renderTable(header, rows)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Miscellaneous Output
+// -----------------------------------------------------------------------------
+
+func (f *Formatter) PrintCategories(categories []models.Category) {
+	categories = sorted(f, categories)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(categories)
+	case FormatPlain:
+		for _, c := range categories {
+			fmt.Fprintf(f.writer, "%d\t%s\t%d\n", c.CategoryID, c.Name, c.Level)
+		}
+	default:
+		f.printCategoriesTable(categories)
+	}
+}
+
+func (f *Formatter) printCategoriesTable(categories []models.Category) {
+	header := []string{"ID", "NAME", "LEVEL"}
+	rows := make([][]string, len(categories))
+	for i, c := range categories {
+		name := c.Name
+		if c.Level == 2 {
+			name = "  └─ " + name
+		}
+		rows[i] = []string{
+			fmt.Sprintf("%d", c.CategoryID),
+			name,
+			fmt.Sprintf("%d", c.Level),
+		}
+	}
+	f.renderTable(header, rows)
+}
+
+// PrintCategoryTree prints categories as the two-level hierarchy. Plain
+// output lists each category with its parent's ID, 0 for top-level ones.
+func (f *Formatter) PrintCategoryTree(tree []refdata.CategoryNode) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(tree)
+	case FormatPlain:
+		for _, node := range tree {
+			fmt.Fprintf(f.writer, "%d\t%d\t%s\n", node.CategoryID, 0, node.Name)
+			for _, c := range node.Children {
+				fmt.Fprintf(f.writer, "%d\t%d\t%s\n", c.CategoryID, node.CategoryID, c.Name)
+			}
+		}
+	default:
+		for _, node := range tree {
+			fmt.Fprintf(f.writer, "%s (%d)\n", node.Name, node.CategoryID)
+			for i, c := range node.Children {
+				branch := "├─"
+				if i == len(node.Children)-1 {
+					branch = "└─"
+				}
+				fmt.Fprintf(f.writer, "%s %s (%d)\n", branch, c.Name, c.CategoryID)
+			}
+		}
+	}
+}
+
+func (f *Formatter) PrintGooglePlayCategories(categories []models.GooglePlayCategory) {
+	categories = sorted(f, categories)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(categories)
+	case FormatPlain:
+		for _, c := range categories {
+			fmt.Fprintf(f.writer, "%d\t%s\n", c.CategoryID, c.Name)
+		}
+	default:
+		f.printGooglePlayCategoriesTable(categories)
+	}
+}
+
+func (f *Formatter) printGooglePlayCategoriesTable(categories []models.GooglePlayCategory) {
+	header := []string{"ID", "NAME"}
+	rows := make([][]string, len(categories))
+	for i, c := range categories {
+		rows[i] = []string{fmt.Sprintf("%d", c.CategoryID), c.Name}
+	}
+	f.renderTable(header, rows)
+}
+
+func (f *Formatter) PrintLanguages(languages []models.Language) {
+	languages = sorted(f, languages)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(languages)
+	case FormatPlain:
+		for _, l := range languages {
+			fmt.Fprintf(f.writer, "%s\t%s\n", l.Code, l.Name)
+		}
+	default:
+		f.printLanguagesTable(languages)
+	}
+}
+
+func (f *Formatter) printLanguagesTable(languages []models.Language) {
+	header := []string{"CODE", "LANGUAGE"}
 	rows := make([][]string, len(languages))
 	for i, l := range languages {
 		rows[i] = []string{l.Code, l.Name}
@@ -951,6 +2540,7 @@ func (f *Formatter) printLanguagesTable(languages []models.Language) {
 // -----------------------------------------------------------------------------
 
 func (f *Formatter) PrintCuepoints(cuepoints []models.Cuepoint) {
+	cuepoints = sorted(f, cuepoints)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(cuepoints)
@@ -984,6 +2574,7 @@ func (f *Formatter) printCuepointsTable(cuepoints []models.Cuepoint) {
 // -----------------------------------------------------------------------------
 
 func (f *Formatter) PrintChapters(chapters []models.Chapter) {
+	chapters = sorted(f, chapters)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(chapters)
@@ -997,27 +2588,7 @@ func (f *Formatter) PrintChapters(chapters []models.Chapter) {
 }
 
 func (f *Formatter) printChaptersTable(chapters []models.Chapter) {
-	header := []string{"ID", "STARTS AT (ms)", "TIME", "TITLE", "URL"}
-	rows := make([][]string, len(chapters))
-	for i, c := range chapters {
-		totalSeconds := c.StartsAt / 1000
-		minutes := totalSeconds / 60
-		seconds := totalSeconds % 60
-
-		url := c.ExternalURL
-		if url == "" {
-			url = "-"
-		}
-
-		rows[i] = []string{
-			fmt.Sprintf("%d", c.ChapterID),
-			fmt.Sprintf("%d", c.StartsAt),
-			fmt.Sprintf("%d:%02d", minutes, seconds),
-			truncate(c.Title, 40),
-			truncate(url, 40),
-		}
-	}
-	f.renderTable(header, rows)
+	renderColumns(f, chapterColumns, chapters)
 }
 
 // -----------------------------------------------------------------------------
@@ -1025,6 +2596,7 @@ func (f *Formatter) printChaptersTable(chapters []models.Chapter) {
 // -----------------------------------------------------------------------------
 
 func (f *Formatter) PrintMessages(messages []models.Message) {
+	messages = sorted(f, messages)
 	switch f.format {
 	case FormatJSON:
 		f.printJSON(messages)
@@ -1033,7 +2605,7 @@ func (f *Formatter) PrintMessages(messages []models.Message) {
 			fmt.Fprintf(f.writer, "%d\t%s\t%s\t%s\n",
 				m.MessageID,
 				m.AuthorUsername,
-				m.CreatedAt,
+				m.CreatedAt.Format(time.DateTime),
 				m.Text,
 			)
 		}
@@ -1043,25 +2615,68 @@ func (f *Formatter) PrintMessages(messages []models.Message) {
 }
 
 func (f *Formatter) printMessagesTable(messages []models.Message) {
-	header := []string{"ID", "AUTHOR", "DATE", "MESSAGE"}
-	rows := make([][]string, len(messages))
-	for i, m := range messages {
-		date := m.CreatedAt
-		if len(date) > 10 {
-			date = date[:10]
-		}
+	renderColumns(f, messageColumns, messages)
+}
 
-		author := m.AuthorFullname
-		if m.AuthorIsOwner {
-			author = author + " ★"
+// PrintMessageMatches prints messages found in the local catalog, with a
+// snippet of the text around the match.
+func (f *Formatter) PrintMessageMatches(matches []catalog.Match) {
+	matches = sorted(f, matches)
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(matches)
+	case FormatPlain:
+		for _, m := range matches {
+			fmt.Fprintf(f.writer, "%d\t%d\t%d\t%s\t%s\t%s\n",
+				m.MessageID, m.ShowID, m.EpisodeID, m.AuthorUsername, m.CreatedAt.Format(time.DateTime), m.Text)
 		}
-
-		rows[i] = []string{
-			fmt.Sprintf("%d", m.MessageID),
-			truncate(author, 20),
-			date,
-			truncate(m.Text, 50),
+	default:
+		rows := make([][]string, len(matches))
+		for i, m := range matches {
+			rows[i] = []string{
+				fmt.Sprintf("%d", m.MessageID),
+				f.timestamp(m.CreatedAt),
+				f.clip(m.ShowTitle, 20),
+				f.clip(m.EpisodeTitle, 25),
+				"@" + m.AuthorUsername,
+				f.clip(m.Snippet, MessagePreviewLength),
+			}
 		}
+		f.renderTable([]string{"ID", "DATE", "SHOW", "EPISODE", "USERNAME", "MESSAGE"}, rows)
 	}
-	f.renderTable(header, rows)
-}
\ No newline at end of file
+}
+
+// PrintEpisodeMessage prints a single message with its full text.
+func (f *Formatter) PrintEpisodeMessage(m *models.Message) {
+	switch f.format {
+	case FormatJSON:
+		f.printJSON(m)
+	case FormatPlain:
+		fmt.Fprintf(f.writer, "%d\t%s\t%s\t%s\n", m.MessageID, m.AuthorUsername, m.CreatedAt.Format(time.DateTime), m.Text)
+	default:
+		f.printEpisodeMessageTable(m)
+	}
+}
+
+func (f *Formatter) printEpisodeMessageTable(m *models.Message) {
+	author := m.AuthorFullname
+	if m.AuthorIsOwner {
+		author = author + " ★"
+	}
+
+	pairs := [][2]string{
+		{"ID:", fmt.Sprintf("%d", m.MessageID)},
+		{"Episode ID:", fmt.Sprintf("%d", m.EpisodeID)},
+		{"Author:", author},
+		{"Username:", "@" + m.AuthorUsername},
+		{"URL:", m.AuthorSiteURL},
+		{"Date:", f.timestamp(m.CreatedAt)},
+	}
+	if m.AppName != "" {
+		pairs = append(pairs, [2]string{"App:", m.AppName})
+	}
+	f.PrintKeyValue(pairs)
+
+	fmt.Fprintln(f.writer)
+	fmt.Fprintln(f.writer, m.Text)
+}
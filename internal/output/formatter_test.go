@@ -3,9 +3,21 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/buildinfo"
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/refdata"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/internal/text"
 	"github.com/G10xy/spreaker-and-go/pkg/models"
 )
 
@@ -36,6 +48,35 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestFormatter_Clip(t *testing.T) {
+	title := strings.Repeat("x", 100)
+	tests := []struct {
+		name      string
+		format    string
+		enabled   bool
+		maxWidth  int
+		termWidth int
+		want      int
+	}{
+		{"default", "table", true, 0, 0, 30},
+		{"80-column terminal", "table", true, 0, 80, 30},
+		{"wide terminal", "table", true, 0, 160, 60},
+		{"max width", "table", true, 50, 160, 50},
+		{"truncation off", "table", false, 10, 0, 100},
+		{"wide format", "wide", true, 10, 0, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(tt.format, false)
+			f.SetTruncation(tt.enabled, tt.maxWidth, tt.termWidth)
+			if got := len(f.clip(title, 30)); got != tt.want {
+				t.Errorf("clip() length = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // formatDuration
 // ---------------------------------------------------------------------------
@@ -61,6 +102,90 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// localTime / relativeTime / SetDateOptions
+// ---------------------------------------------------------------------------
+
+func TestLocalTime(t *testing.T) {
+	orig := time.Local
+	time.Local = time.FixedZone("UTC+2", 2*60*60)
+	defer func() { time.Local = orig }()
+
+	f, _ := newTestFormatter("table")
+	if got := f.localTime("2024-01-15 22:30:45"); got != "2024-01-16 00:30" {
+		t.Errorf("localTime() = %q, want %q", got, "2024-01-16 00:30")
+	}
+	if got := f.localTime("not a date"); got != "not a date" {
+		t.Errorf("localTime() = %q, want input unchanged", got)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"seconds", now.Add(-30 * time.Second), "just now"},
+		{"one minute", now.Add(-time.Minute), "1 minute ago"},
+		{"hours", now.Add(-5 * time.Hour), "5 hours ago"},
+		{"days", now.AddDate(0, 0, -3), "3 days ago"},
+		{"months", now.AddDate(0, -2, 0), "2 months ago"},
+		{"years", now.AddDate(-1, 0, -1), "1 year ago"},
+		{"future", now.Add(49 * time.Hour), "in 2 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeTime(tt.t, now); got != tt.want {
+				t.Errorf("relativeTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDateOptions(t *testing.T) {
+	published := &models.CustomTime{Time: time.Date(2024, 3, 7, 9, 30, 0, 0, time.UTC)}
+	episodes := []models.Episode{{EpisodeID: 1, Title: "Ep", PublishedAt: published}}
+
+	t.Run("default", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.PrintEpisodes(episodes)
+		if !strings.Contains(buf.String(), "2024-03-07 09:30:00") {
+			t.Errorf("output missing UTC date, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("layout and zone", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.SetDateOptions("02 Jan 2006 15:04", false, time.FixedZone("UTC+1", 60*60))
+		f.PrintEpisodes(episodes)
+		if !strings.Contains(buf.String(), "07 Mar 2024 10:30") {
+			t.Errorf("output missing formatted date, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("relative", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		f.now = func() time.Time { return published.AddDate(0, 0, 3) }
+		f.SetDateOptions("", true, nil)
+		f.PrintEpisodes(episodes)
+		if !strings.Contains(buf.String(), "3 days ago") {
+			t.Errorf("output missing relative date, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("json keeps raw value", func(t *testing.T) {
+		f, buf := newTestFormatter("json")
+		f.SetDateOptions("", true, nil)
+		f.PrintEpisodes(episodes)
+		if strings.Contains(buf.String(), "ago") {
+			t.Errorf("json output should not use relative dates, got:\n%s", buf.String())
+		}
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Formatter creation
 // ---------------------------------------------------------------------------
@@ -75,6 +200,8 @@ func TestNew_FormatSelection(t *testing.T) {
 		{"plain", FormatPlain},
 		{"INVALID", FormatTable},
 		{"  JSON  ", FormatJSON},
+		{"ci", FormatCI},
+		{"wide", FormatWide},
 	}
 
 	for _, tt := range tests {
@@ -142,6 +269,15 @@ func TestPrintUser_Table(t *testing.T) {
 // PrintShows
 // ---------------------------------------------------------------------------
 
+func TestPrintShow_TableOwner(t *testing.T) {
+	f, buf := newTestFormatter("table")
+	f.PrintShow(&models.Show{ShowID: 1, Title: "Show1", Author: &models.User{Fullname: "Jane Doe", Username: "jane"}})
+
+	if out := buf.String(); !strings.Contains(out, "Jane Doe (@jane)") {
+		t.Errorf("table output missing owner, got:\n%s", out)
+	}
+}
+
 func TestPrintShows_Table(t *testing.T) {
 	f, buf := newTestFormatter("table")
 	shows := []models.Show{
@@ -173,6 +309,44 @@ func TestPrintShows_JSON(t *testing.T) {
 	}
 }
 
+func TestPrintShows_JSONWithMeta(t *testing.T) {
+	f, buf := newTestFormatter("json")
+	f.SetWithMeta(true)
+	f.SetPage("https://api.spreaker.com/v2/users/1/shows?offset=2", true)
+	f.PrintShows([]models.Show{{ShowID: 1}, {ShowID: 2}})
+
+	var decoded struct {
+		Items   []map[string]interface{} `json:"items"`
+		NextURL string                   `json:"next_url"`
+		HasMore bool                     `json:"has_more"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Items) != 2 || !decoded.HasMore || decoded.NextURL == "" {
+		t.Errorf("got %+v, want 2 items with pagination metadata", decoded)
+	}
+
+	// Metadata applies to one list only.
+	buf.Reset()
+	f.PrintShows([]models.Show{{ShowID: 3}})
+	var plain []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &plain); err != nil {
+		t.Errorf("second list should not be wrapped: %v", err)
+	}
+}
+
+func TestPrintShows_EmptyJSONWithMeta(t *testing.T) {
+	f, buf := newTestFormatter("json")
+	f.SetWithMeta(true)
+	f.SetPage("", false)
+	f.PrintShows(nil)
+
+	if got, want := buf.String(), "{\n  \"items\": [],\n  \"next_url\": \"\",\n  \"has_more\": false\n}\n"; got != want {
+		t.Errorf("empty list = %q, want %q", got, want)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // PrintMessage / PrintSuccess / PrintError
 // ---------------------------------------------------------------------------
@@ -185,6 +359,65 @@ func TestPrintMessage(t *testing.T) {
 	}
 }
 
+func TestSetQuiet(t *testing.T) {
+	f, buf := newTestFormatter("table")
+	f.SetQuiet(true)
+
+	f.Print(LevelInfo, "uploading")
+	f.Print(LevelSuccess, "done")
+	if s := f.StartSpinner("working"); s != nil {
+		t.Error("StartSpinner should return nil in quiet mode")
+	}
+	f.PrintUsers([]models.User{{UserID: 1, Username: "jane"}})
+
+	out := buf.String()
+	if strings.Contains(out, "uploading") || strings.Contains(out, "done") || strings.Contains(out, "working") {
+		t.Errorf("quiet output should not contain messages, got %q", out)
+	}
+	if !strings.Contains(out, "jane") {
+		t.Errorf("quiet output should keep data, got %q", out)
+	}
+}
+
+func TestPrintError_Stderr(t *testing.T) {
+	apiErr := &api.APIError{StatusCode: 404, Code: 1001, Messages: []string{"not found"}}
+
+	t.Run("table", func(t *testing.T) {
+		f, buf := newTestFormatter("table")
+		errBuf := &bytes.Buffer{}
+		f.errWriter = errBuf
+
+		f.PrintError(errors.New("boom"))
+		f.PrintWarning("careful")
+
+		if buf.Len() != 0 {
+			t.Errorf("stdout should be empty, got %q", buf.String())
+		}
+		if got := errBuf.String(); got != "Error: boom\nWarning: careful\n" {
+			t.Errorf("stderr = %q", got)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		f, buf := newTestFormatter("json")
+		errBuf := &bytes.Buffer{}
+		f.errWriter = errBuf
+
+		f.PrintError(fmt.Errorf("fetch failed: %w", apiErr))
+
+		if buf.Len() != 0 {
+			t.Errorf("stdout should be empty, got %q", buf.String())
+		}
+		var d map[string]interface{}
+		if err := json.Unmarshal(errBuf.Bytes(), &d); err != nil {
+			t.Fatalf("stderr is not JSON: %v (%q)", err, errBuf.String())
+		}
+		if d["level"] != "error" || d["status"] != float64(404) || d["code"] != float64(1001) {
+			t.Errorf("diagnostic = %v", d)
+		}
+	})
+}
+
 func TestPrintSuccess(t *testing.T) {
 	f, buf := newTestFormatter("table")
 	f.PrintSuccess("done")
@@ -193,3 +426,403 @@ func TestPrintSuccess(t *testing.T) {
 		t.Errorf("expected ✓ prefix, got %q", out)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// CI (GitHub Actions) format
+// ---------------------------------------------------------------------------
+
+func TestPrintShows_CI(t *testing.T) {
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+
+	f, buf := newTestFormatter("ci")
+	f.PrintShows([]models.Show{{ShowID: 1, Title: "A | B"}})
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "| ID | TITLE |") {
+		t.Errorf("expected Markdown header, got %q", out)
+	}
+	if !strings.Contains(out, `A \| B`) {
+		t.Errorf("pipe not escaped: %q", out)
+	}
+
+	data, err := os.ReadFile(summary)
+	if err != nil {
+		t.Fatalf("step summary not written: %v", err)
+	}
+	if !strings.Contains(string(data), "| 1 |") {
+		t.Errorf("step summary = %q", data)
+	}
+}
+
+func TestPrintSuccess_CI(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+	f, buf := newTestFormatter("ci")
+	f.PrintSuccess("uploaded\n50% done")
+	want := "::notice::uploaded%0A50%25 done\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// PrintShowFeedback
+// ---------------------------------------------------------------------------
+
+func TestPrintShowFeedback_Table(t *testing.T) {
+	f, buf := newTestFormatter("table")
+	f.PrintShowFeedback(&report.ShowFeedback{
+		Title:      "My Show",
+		LikesCount: 7,
+		Sentiment:  report.SentimentCounts{Positive: 2, Negative: 1},
+		Episodes:   []report.EpisodeFeedback{{EpisodeID: 10, Title: "First", LikesCount: 7}},
+		Recent:     []report.FeedbackMessage{{EpisodeID: 10, Author: "Ann", Text: "Loved it", Sentiment: report.SentimentPositive}},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"2 positive, 1 negative, 0 neutral", "First", "Loved it", "Ann"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// PrintSearchResults
+// ---------------------------------------------------------------------------
+
+func TestPrintSearchResults_Plain(t *testing.T) {
+	f, buf := newTestFormatter("plain")
+	f.PrintSearchResults(&api.SearchResults{
+		Shows:    []models.Show{{ShowID: 1, Title: "Show1"}},
+		Episodes: []models.Episode{{EpisodeID: 2, Title: "Ep2"}},
+	})
+
+	if got, want := buf.String(), "show\t1\tShow1\nepisode\t2\tEp2\n"; got != want {
+		t.Errorf("plain output = %q, want %q", got, want)
+	}
+}
+
+func TestPrintSearchResults_TableSections(t *testing.T) {
+	f, buf := newTestFormatter("table")
+	f.PrintSearchResults(&api.SearchResults{Episodes: []models.Episode{{EpisodeID: 2, Title: "Ep2"}}})
+
+	out := buf.String()
+	if strings.Contains(out, "=== Shows ===") || !strings.Contains(out, "=== Episodes ===") {
+		t.Errorf("want only the Episodes section, got:\n%s", out)
+	}
+}
+
+func TestPrintConfigProblems(t *testing.T) {
+	problems := []config.Problem{
+		{Key: "output_format", Message: "invalid format \"xml\"", Severity: config.SeverityError},
+		{Key: "output_fromat", Message: "unknown key", Severity: config.SeverityWarning},
+	}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintConfigProblems(problems)
+	want := "error\toutput_format\tinvalid format \"xml\"\nwarning\toutput_fromat\tunknown key\n"
+	if got := buf.String(); got != want {
+		t.Errorf("plain output = %q, want %q", got, want)
+	}
+
+	f, buf = newTestFormatter("json")
+	f.PrintConfigProblems(nil)
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("json output for no problems = %q, want []", got)
+	}
+}
+
+func TestPrintVersion(t *testing.T) {
+	info := buildinfo.Info{Version: "v1.2.3", Commit: "0123456789abcdef", GoVersion: "go1.25.0", Platform: "linux/amd64"}
+	compat := &api.APICompatibility{BaseURL: "https://api.spreaker.com", PinnedVersion: "v2", Sunset: "2027-01-01"}
+
+	f, buf := newTestFormatter("table")
+	f.PrintVersion(info, compat)
+	out := buf.String()
+	for _, want := range []string{"v1.2.3", "0123456789ab", "go1.25.0", "deprecated, removed after 2027-01-01"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+
+	f, buf = newTestFormatter("plain")
+	f.PrintVersion(info, nil)
+	if got := buf.String(); got != "v1.2.3\n" {
+		t.Errorf("plain output = %q, want the version only", got)
+	}
+
+	f, buf = newTestFormatter("json")
+	f.PrintVersion(info, nil)
+	if out := buf.String(); !strings.Contains(out, `"build"`) || strings.Contains(out, `"api"`) {
+		t.Errorf("json output = %s", out)
+	}
+}
+
+func TestPrintStatsTimezone(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"table", "Dates are days in Europe/Rome (UTC+02:00), requested as UTC days 2024-01-01 to 2024-01-31"},
+		{"json", ""},
+		{"plain", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, buf := newTestFormatter(tt.format)
+			f.PrintStatsTimezone("Europe/Rome (UTC+02:00)", "2024-01-01", "2024-01-31")
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintGeographicStatistics(t *testing.T) {
+	withCounts := &models.GeographicStatistics{
+		Country: []models.GeoStatistics{{Name: "Italy", Percentage: 60, PlaysCount: 120}},
+		City:    []models.GeoStatistics{{Name: "Rome", Percentage: 30, PlaysCount: 60}},
+	}
+	withoutCounts := &models.GeographicStatistics{
+		Country: []models.GeoStatistics{{Name: "Italy", Percentage: 60}},
+	}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintGeographicStatistics(withCounts)
+	if got, want := buf.String(), "country\tItaly\t60.0%\t120\ncity\tRome\t30.0%\t60\n"; got != want {
+		t.Errorf("plain with counts = %q, want %q", got, want)
+	}
+
+	f, buf = newTestFormatter("table")
+	f.PrintGeographicStatistics(withCounts)
+	if !strings.Contains(buf.String(), "PLAYS") {
+		t.Errorf("table with counts has no PLAYS column:\n%s", buf)
+	}
+
+	f, buf = newTestFormatter("table")
+	f.PrintGeographicStatistics(withoutCounts)
+	if strings.Contains(buf.String(), "PLAYS") {
+		t.Errorf("table without counts has a PLAYS column:\n%s", buf)
+	}
+}
+
+func TestPrintEpisodeStatisticsRatios(t *testing.T) {
+	stats := &models.EpisodeOverallStatistics{PlaysCount: 1000, LikesCount: 20, DownloadsCount: 100, MessagesCount: 2}
+
+	f, buf := newTestFormatter("json")
+	f.PrintEpisodeStatistics(stats)
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf)
+	}
+	if got["plays_count"] != float64(1000) || got["likes_per_play_percent"] != float64(2) || got["messages_per_1k_plays"] != float64(2) {
+		t.Errorf("json = %v", got)
+	}
+
+	f, buf = newTestFormatter("plain")
+	f.PrintEpisodeStatistics(stats)
+	if want := "plays=1000 downloads=100 likes=20 messages=2 likes_per_play=2.0% downloads_per_play=10.0% messages_per_1k=2.0\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintDecay(t *testing.T) {
+	decays := []report.EpisodeDecay{{
+		EpisodeID:     7,
+		Title:         "Pilot",
+		PublishedAt:   "2024-03-01",
+		DaysLive:      20,
+		LifetimePlays: 100,
+		Milestones: []report.DecayMilestone{
+			{Day: 1, Plays: 40, Percent: 40, Reached: true},
+			{Day: 7, Plays: 80, Percent: 80, Reached: true},
+			{Day: 30, Plays: 100, Percent: 100},
+		},
+	}}
+
+	f, buf := newTestFormatter("table")
+	f.PrintDecay(decays)
+	out := buf.String()
+	for _, want := range []string{"DAY 30", "80 (80.0%)", "100 (100.0%) *", "still growing"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+
+	f, buf = newTestFormatter("plain")
+	f.PrintDecay(decays)
+	if want := "7\tPilot\t2024-03-01\t100\t40\t40.0\t80\t80.0\t100\t100.0\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintFunnel(t *testing.T) {
+	r := &report.Funnel{
+		From: "2024-01-01", To: "2024-01-31", PlaysCount: 100,
+		Sources: []report.Share{{Name: "Apple", Percentage: 100, PlaysCount: 100}},
+		Devices: []report.Share{{Name: "Mobile", Percentage: 80, PlaysCount: 80, Estimated: true}, {Name: "Desktop", Percentage: 20, PlaysCount: 20, Estimated: true}},
+	}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintFunnel(r)
+	want := "source\tApple\t100.0%\t100\ndevice\tMobile\t80.0%\t80\ndevice\tDesktop\t20.0%\t20\n"
+	if got := buf.String(); got != want {
+		t.Errorf("plain = %q, want %q", got, want)
+	}
+
+	f, buf = newTestFormatter("table")
+	f.PrintFunnel(r)
+	for _, s := range []string{"Mobile", "Desktop", "~80", "~20", "estimated"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("table has no %q:\n%s", s, buf)
+		}
+	}
+	if strings.Contains(buf.String(), "MOBILE") {
+		t.Errorf("table crosses sources with devices:\n%s", buf)
+	}
+}
+
+func TestPrintCategoryTree(t *testing.T) {
+	tree := []refdata.CategoryNode{{
+		Category: models.Category{CategoryID: 1, Name: "Arts", Level: 1},
+		Children: []models.Category{{CategoryID: 2, Name: "Books", Level: 2}, {CategoryID: 3, Name: "Design", Level: 2}},
+	}}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintCategoryTree(tree)
+	if want := "1\t0\tArts\n2\t1\tBooks\n3\t1\tDesign\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+
+	f, buf = newTestFormatter("table")
+	f.PrintCategoryTree(tree)
+	if want := "Arts (1)\n├─ Books (2)\n└─ Design (3)\n"; buf.String() != want {
+		t.Errorf("table = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTagStats(t *testing.T) {
+	r := &report.TagStats{
+		From: "2024-01-01", To: "2024-12-31", EpisodesCount: 3,
+		Tags: []report.TagStat{{Tag: "tech", EpisodesCount: 2, PlaysCount: 150, AveragePlays: 75}},
+	}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintTagStats(r)
+	if want := "tech\t2\t150\t75.0\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTagSuggestions(t *testing.T) {
+	suggestions := []text.Suggestion{{Tag: "Cooking", Score: 6, Known: true}, {Tag: "pasta", Score: 1.5}}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintTagSuggestions(suggestions)
+	if want := "Cooking\t6\ttrue\npasta\t1.5\tfalse\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintMessageMatches(t *testing.T) {
+	matches := []catalog.Match{{
+		ShowID:  1,
+		Message: catalog.Message{Message: models.Message{MessageID: 7, EpisodeID: 3, AuthorUsername: "ann", CreatedAt: apiTime("2024-01-01 10:00:00"), Text: "refund please"}},
+		Snippet: "refund please",
+	}}
+
+	f, buf := newTestFormatter("plain")
+	f.PrintMessageMatches(matches)
+	if want := "7\t1\t3\tann\t2024-01-01 10:00:00\trefund please\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintNetworkStats(t *testing.T) {
+	stats := report.BuildNetworkStats("2024-01-01", "2024-01-31", []report.NetworkShow{
+		{ShowID: 1, Title: "A", Plays: 30, Downloads: 2, Likes: 1},
+		{ShowID: 2, Title: "B", Plays: 10},
+	})
+
+	f, buf := newTestFormatter("plain")
+	f.PrintNetworkStats(stats)
+	if want := "1\tA\t30\t2\t1\t75.0\n2\tB\t10\t0\t0\t25.0\n"; buf.String() != want {
+		t.Errorf("plain = %q, want %q", buf.String(), want)
+	}
+}
+
+// apiTime parses an API timestamp for test fixtures.
+func apiTime(s string) models.CustomTime {
+	t, err := models.ParseTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestPrintEpisodeFull(t *testing.T) {
+	episode := &models.Episode{EpisodeID: 7, Title: "Pilot", Duration: 60000, WaveformURL: "https://cdn.example.com/7.json"}
+	media := &api.MediaInfo{URL: "https://cdn.example.com/7.mp3", ContentType: "audio/mpeg", Size: 960000}
+
+	f, buf := newTestFormatter("json")
+	f.PrintEpisodeFull(episode, media)
+	var decoded struct {
+		EpisodeID   int    `json:"episode_id"`
+		WaveformURL string `json:"waveform_url"`
+		Media       struct {
+			Size    int64 `json:"size"`
+			Bitrate int   `json:"bitrate_kbps"`
+		} `json:"media"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v\noutput: %s", err, buf.String())
+	}
+	if decoded.EpisodeID != 7 || decoded.WaveformURL == "" || decoded.Media.Size != 960000 || decoded.Media.Bitrate != 128 {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	f, buf = newTestFormatter("table")
+	f.PrintEpisodeFull(episode, media)
+	for _, want := range []string{"Pilot", "audio/mpeg", "960.0 kB", "128 kbps", "7.json"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("table output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for n, want := range map[int64]string{0: "-", 512: "512 B", 1500: "1.5 kB", 48_200_000: "48.2 MB", 2_000_000_000: "2.0 GB"} {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestPrintPlayStatistics_Totals(t *testing.T) {
+	stats := []models.PlayStatistics{
+		{PlaysCount: 10, PlaysOndemandCount: 8, PlaysLiveCount: 2, DownloadsCount: 4},
+		{PlaysCount: 5, PlaysOndemandCount: 5, DownloadsCount: 1},
+	}
+	for _, format := range []string{"table", "plain", "json"} {
+		t.Run(format, func(t *testing.T) {
+			f, buf := newTestFormatter(format)
+			f.SetTotals(true)
+			f.PrintPlayStatistics(stats)
+			out := buf.String()
+			if format != "table" {
+				if strings.Contains(out, "TOTAL") {
+					t.Errorf("%s output has a totals row:\n%s", format, out)
+				}
+				return
+			}
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if got := strings.Join(strings.Fields(lines[len(lines)-2]), " "); got != "TOTAL 15 13 2 5" {
+				t.Errorf("TOTAL row = %q", got)
+			}
+			if got := strings.Join(strings.Fields(lines[len(lines)-1]), " "); got != "AVG 7.5 6.5 1.0 2.5" {
+				t.Errorf("AVG row = %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,491 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/internal/audio"
+	"github.com/G10xy/spreaker-and-go/internal/buildinfo"
+	"github.com/G10xy/spreaker-and-go/internal/catalog"
+	"github.com/G10xy/spreaker-and-go/internal/config"
+	"github.com/G10xy/spreaker-and-go/internal/jobs"
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+	"github.com/G10xy/spreaker-and-go/internal/linkcheck"
+	"github.com/G10xy/spreaker-and-go/internal/refdata"
+	"github.com/G10xy/spreaker-and-go/internal/report"
+	"github.com/G10xy/spreaker-and-go/internal/spool"
+	"github.com/G10xy/spreaker-and-go/internal/text"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// ---------------------------------------------------------------------------
+// Golden files
+//
+// Every Print* method is rendered in every output format and compared with
+// testdata/golden/<method>.<format>.golden. After an intended change to the
+// output, regenerate the files and review the diff:
+//
+//	UPDATE=1 go test ./internal/output -run TestGolden
+//
+// A new Print* method needs a case in goldenCases (TestGoldenCoverage fails
+// without one); a new format needs adding to goldenFormats.
+// ---------------------------------------------------------------------------
+
+var goldenFormats = []Format{FormatTable, FormatWide, FormatJSON, FormatPlain, FormatCI}
+
+// goldenNow is the fixed "now" golden output is rendered at.
+var goldenNow = time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+// goldenFormatter returns a formatter with deterministic output: no color,
+// UTC dates and a fixed clock. Stdout and stderr go to separate buffers.
+func goldenFormatter(format Format) (f *Formatter, stdout, stderr *bytes.Buffer) {
+	f = New(string(format), false)
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	f.writer, f.errWriter = stdout, stderr
+	f.SetDateOptions("", false, time.UTC)
+	f.now = func() time.Time { return goldenNow }
+	return f, stdout, stderr
+}
+
+// golden compares got with the named golden file, or rewrites the file when
+// the UPDATE environment variable is set.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+
+	if os.Getenv("UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with UPDATE=1 to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with UPDATE=1 to accept it)\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for _, c := range goldenCases() {
+		for _, format := range goldenFormats {
+			name := c.name + "." + string(format)
+			t.Run(name, func(t *testing.T) {
+				f, stdout, stderr := goldenFormatter(format)
+				c.print(f)
+
+				got := stdout.Bytes()
+				if stderr.Len() > 0 {
+					got = append(got, "-- stderr --\n"...)
+					got = append(got, stderr.Bytes()...)
+				}
+				golden(t, name, got)
+			})
+		}
+	}
+}
+
+// TestGoldenCoverage fails when a Print* method has no golden case.
+func TestGoldenCoverage(t *testing.T) {
+	covered := make(map[string]bool)
+	for _, c := range goldenCases() {
+		covered[strings.SplitN(c.name, "_", 2)[0]] = true
+	}
+
+	var missing []string
+	typ := reflect.TypeOf(&Formatter{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		name := typ.Method(i).Name
+		if strings.HasPrefix(name, "Print") && !covered[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Errorf("no golden case for: %s", strings.Join(missing, ", "))
+	}
+}
+
+// goldenCase renders one Print* method. The name is the method name,
+// optionally followed by _<variant> for further cases of the same method.
+type goldenCase struct {
+	name  string
+	print func(f *Formatter)
+}
+
+func apiDate(s string) models.Date {
+	d, err := models.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func goldenCases() []goldenCase {
+	published := apiTime("2024-03-01 09:30:00")
+	created := apiTime("2023-06-10 18:00:00")
+
+	user := models.User{
+		UserID: 101, Fullname: "Alice Rossi", Username: "alice", Kind: "user", Plan: "anchor",
+		FollowersCount: 42, FollowingsCount: 7, SiteURL: "https://www.spreaker.com/user/alice",
+		Description: "Podcaster from Rome", Location: "Rome",
+	}
+	show := models.Show{
+		ShowID: 201, Title: "Morning Coffee", Description: "Daily news with a cup of coffee.",
+		SiteURL: "https://www.spreaker.com/show/morning-coffee", Author: &user, AuthorID: user.UserID,
+		Category: &models.Category{CategoryID: 12, Name: "News"}, CategoryID: 12, Language: "en",
+		EpisodesCount: 120, FollowersCount: 830, PlayCount: 15400, LikesCount: 310,
+		LastEpisodeAt: &published, CreatedAt: &created,
+	}
+	episode := models.Episode{
+		EpisodeID: 301, Title: "Episode 120: Spring Forward", Description: "Clocks change and so do we.",
+		ShowID: show.ShowID, AuthorID: user.UserID, SiteURL: "https://www.spreaker.com/episode/301",
+		Duration: 1834000, PlayCount: 512, LikesCount: 24, MessagesCount: 3,
+		Tags: []string{"news", "spring"}, PublishedAt: &published, EncodingStatus: "done",
+		DownloadEnabled: true,
+	}
+	episodes := []models.Episode{
+		episode,
+		{EpisodeID: 302, Title: "Episode 121: A very long title that needs to be clipped in narrow tables",
+			ShowID: show.ShowID, Duration: 65000, PlayCount: 0, Hidden: true},
+	}
+	message := models.Message{
+		MessageID: 401, EpisodeID: episode.EpisodeID, Text: "Great episode, thanks!",
+		CreatedAt: apiTime("2024-03-02 08:15:00"), AuthorID: 102, AuthorUsername: "bob",
+		AuthorFullname: "Bob Bianchi",
+	}
+	plays := 12.5
+
+	return []goldenCase{
+		{"PrintUser", func(f *Formatter) { f.PrintUser(&user) }},
+		{"PrintUsers", func(f *Formatter) {
+			f.PrintUsers([]models.User{user, {UserID: 102, Fullname: "Bob Bianchi", Username: "bob"}})
+		}},
+		{"PrintUsers_empty", func(f *Formatter) { f.PrintUsers(nil) }},
+		{"PrintShow", func(f *Formatter) { f.PrintShow(&show) }},
+		{"PrintShows", func(f *Formatter) {
+			f.PrintShows([]models.Show{show, {ShowID: 202, Title: "Night Owls", Explicit: true}})
+		}},
+		{"PrintEpisode", func(f *Formatter) { f.PrintEpisode(&episode) }},
+		{"PrintEpisodeFull", func(f *Formatter) {
+			f.PrintEpisodeFull(&episode, &api.MediaInfo{URL: "https://cdn.example.com/301.mp3", ContentType: "audio/mpeg", Size: 29344000})
+		}},
+		{"PrintEpisodes", func(f *Formatter) { f.PrintEpisodes(episodes) }},
+		{"PrintEpisodes_page", func(f *Formatter) {
+			f.SetWithMeta(true)
+			f.SetPage("https://api.spreaker.com/v2/shows/201/episodes?last_id=302", true)
+			f.PrintEpisodes(episodes)
+		}},
+		{"PrintEpisodesDuration", func(f *Formatter) { f.PrintEpisodesDuration(episodes) }},
+		{"PrintStatistics", func(f *Formatter) {
+			f.PrintStatistics(&models.Statistics{Plays: 1500, Downloads: 320, Likes: 45, Messages: 6})
+		}},
+		{"Print", func(f *Formatter) {
+			f.Print(LevelInfo, "Fetching episodes...")
+			f.Print(LevelSuccess, "Episode updated")
+			f.Print(LevelWarn, "Tag list truncated")
+			f.Print(LevelError, "upload failed")
+		}},
+		{"PrintMessage", func(f *Formatter) { f.PrintMessage("Uploading episode...") }},
+		{"PrintError", func(f *Formatter) {
+			f.PrintError(&api.APIError{StatusCode: 404, Code: 404, Messages: []string{"Episode not found"}})
+		}},
+		{"PrintError_plain", func(f *Formatter) { f.PrintError(errors.New("connection refused")) }},
+		{"PrintSuccess", func(f *Formatter) { f.PrintSuccess("Episode 301 deleted") }},
+		{"PrintWarning", func(f *Formatter) { f.PrintWarning("no default show set") }},
+		{"PrintKeyValue", func(f *Formatter) {
+			f.PrintKeyValue([][2]string{{"Show", "Morning Coffee"}, {"Episodes", "120"}})
+		}},
+		{"PrintUserStatistics", func(f *Formatter) {
+			f.PrintUserStatistics(&models.UserOverallStatistics{
+				PlaysCount: 15400, PlaysOndemandCount: 15000, PlaysLiveCount: 400, ShowsCount: 2,
+				EpisodesCount: 140, LikesCount: 310, DownloadsCount: 2300, FollowersCount: 42, User: &user,
+			})
+		}},
+		{"PrintShowStatistics", func(f *Formatter) {
+			f.PrintShowStatistics(&models.ShowOverallStatistics{
+				Title: show.Title, PlaysCount: 15400, PlaysOndemandCount: 15000, PlaysLiveCount: 400,
+				EpisodesCount: 120, DownloadsCount: 2300, LikesCount: 310, Show: &show,
+			})
+		}},
+		{"PrintEpisodeStatistics", func(f *Formatter) {
+			f.PrintEpisodeStatistics(&models.EpisodeOverallStatistics{
+				PlaysCount: 512, PlaysOndemandCount: 500, PlaysLiveCount: 12, ChaptersCount: 4,
+				MessagesCount: 3, LikesCount: 24, DownloadsCount: 80, Episode: &episode,
+			})
+		}},
+		{"PrintPlayStatistics", func(f *Formatter) {
+			f.PrintPlayStatistics([]models.PlayStatistics{
+				{Date: apiDate("2024-03-01"), PlaysCount: 120, PlaysOndemandCount: 118, PlaysLiveCount: 2, DownloadsCount: 30},
+				{Date: apiDate("2024-03-02"), PlaysCount: 95, PlaysOndemandCount: 95, DownloadsCount: 21},
+			})
+		}},
+		{"PrintDeviceStatistics", func(f *Formatter) {
+			f.PrintDeviceStatistics([]models.DeviceStatistics{{Name: "Mobile", Percentage: 71.5}, {Name: "Desktop", Percentage: 28.5}})
+		}},
+		{"PrintGeographicStatistics", func(f *Formatter) {
+			f.PrintGeographicStatistics(&models.GeographicStatistics{
+				Country: []models.GeoStatistics{{Name: "Italy", Code: "IT", Percentage: 80, PlaysCount: 400}, {Name: "Spain", Code: "ES", Percentage: 20, PlaysCount: 100}},
+				City:    []models.GeoStatistics{{Name: "Rome", Country: "Italy", Percentage: 45.2, PlaysCount: 226}},
+			})
+		}},
+		{"PrintSourcesStatistics", func(f *Formatter) {
+			f.PrintSourcesStatistics(&models.SourcesStatistics{
+				Overall: []models.SourceOverall{{Name: "Apple Podcasts", PlaysCount: 300, Percentage: 60}, {Name: "Spotify", PlaysCount: 200, Percentage: 40}},
+			})
+		}},
+		{"PrintListenersStatistics", func(f *Formatter) {
+			f.PrintListenersStatistics([]models.ListenersStatistics{{Date: apiDate("2024-03-01"), ListenersCount: 88}, {Date: apiDate("2024-03-02"), ListenersCount: 91}})
+		}},
+		{"PrintShowsPlayTotals", func(f *Formatter) {
+			f.PrintShowsPlayTotals([]models.ShowPlayTotals{
+				{ShowID: 201, Title: "Morning Coffee", PlaysCount: 15400, PlaysOndemandCount: 15000, PlaysLiveCount: 400, DownloadsCount: 2300},
+				{ShowID: 203, Title: "Old Show", PlaysCount: 12, PlaysOndemandCount: 12, IsDeleted: true},
+			})
+		}},
+		{"PrintEpisodesPlayTotals", func(f *Formatter) {
+			f.PrintEpisodesPlayTotals([]models.EpisodePlayTotals{
+				{EpisodeID: 301, Title: episode.Title, PlaysCount: 512, PlaysOndemandCount: 500, PlaysLiveCount: 12, DownloadsCount: 80},
+				{EpisodeID: 299, Title: "Moved away", PlaysCount: 40, PlaysOndemandCount: 40, IsTransferred: true},
+			})
+		}},
+		{"PrintLikesStatistics", func(f *Formatter) {
+			f.PrintLikesStatistics([]models.LikesStatistics{{Date: apiDate("2024-03-01"), LikesCount: 5}, {Date: apiDate("2024-03-02"), LikesCount: 2}})
+		}},
+		{"PrintFollowersStatistics", func(f *Formatter) {
+			f.PrintFollowersStatistics([]models.FollowersStatistics{{Date: apiDate("2024-03-01"), FollowersCount: 830}, {Date: apiDate("2024-03-02"), FollowersCount: 834}})
+		}},
+		{"PrintOSStatistics", func(f *Formatter) {
+			f.PrintOSStatistics(&models.OSStatisticsBreakdown{
+				Desktop: []models.OSStatistics{{Name: "Windows", Percentage: 55}, {Name: "macOS", Percentage: 45}},
+				Mobile:  []models.OSStatistics{{Name: "iOS", Percentage: 62.5}, {Name: "Android", Percentage: 37.5}},
+			})
+		}},
+		{"PrintListeningTime", func(f *Formatter) {
+			f.PrintListeningTime(&report.ListeningTime{
+				From: "2024-03-01", To: "2024-03-14", Completion: 0.75, PlaysCount: 512, ListeningHours: 195.6,
+				Episodes: []report.EpisodeListeningTime{{EpisodeID: 301, Title: episode.Title, PlaysCount: 512, Duration: 1834000, ListeningHours: 195.6}},
+			})
+		}},
+		{"PrintLocalStats", func(f *Formatter) {
+			f.PrintLocalStats([]catalog.Row{
+				{Date: "2024-03-01", Plays: 120, Downloads: 30, Likes: 5, Followers: 830},
+				{Date: "2024-03-02", Plays: 95, Downloads: 21, Likes: 2, Followers: 834, PlaysAvg: &plays},
+			}, 7)
+		}},
+		{"PrintStatsTimezone", func(f *Formatter) { f.PrintStatsTimezone("Europe/Rome", "2024-02-29", "2024-03-14") }},
+		{"PrintEngagement", func(f *Formatter) {
+			f.PrintEngagement(&report.Engagement{
+				From: "2024-03-01", To: "2024-03-14", PlaysCount: 512, LikesCount: 24,
+				Episodes: []report.EpisodeEngagement{{EpisodeID: 301, Title: episode.Title, PlaysCount: 512, DownloadsCount: 80, LikesCount: 24, LikesPerPlay: 4.69}},
+			})
+		}},
+		{"PrintFunnel", func(f *Formatter) {
+			f.PrintFunnel(&report.Funnel{
+				From: "2024-03-01", To: "2024-03-14", PlaysCount: 500,
+				Sources:   []report.Share{{Name: "Spotify", Percentage: 60, PlaysCount: 300}},
+				Devices:   []report.Share{{Name: "Mobile", Percentage: 70, PlaysCount: 350, Estimated: true}},
+				Countries: []report.Share{{Name: "Italy", Percentage: 80, PlaysCount: 400}},
+			})
+		}},
+		{"PrintTagStats", func(f *Formatter) {
+			f.PrintTagStats(&report.TagStats{
+				From: "2024-01-01", To: "2024-03-14", EpisodesCount: 12, UntaggedCount: 2,
+				Tags: []report.TagStat{{Tag: "news", EpisodesCount: 10, PlaysCount: 4000, AveragePlays: 400}},
+			})
+		}},
+		{"PrintNetworkStats", func(f *Formatter) {
+			f.PrintNetworkStats(report.NetworkStats{
+				From: "2024-03-01", To: "2024-03-14",
+				Shows: []report.NetworkShow{{ShowID: 201, Title: "Morning Coffee", Episodes: 120, Followers: 830, Plays: 1500, Downloads: 300, Likes: 40, Share: 75}},
+				Total: report.NetworkShow{Title: "Total", Episodes: 160, Followers: 1000, Plays: 2000, Downloads: 400, Likes: 50, Share: 100},
+			})
+		}},
+		{"PrintTagSuggestions", func(f *Formatter) {
+			f.PrintTagSuggestions([]text.Suggestion{{Tag: "coffee", Score: 0.92, Known: true}, {Tag: "daylight", Score: 0.41}})
+		}},
+		{"PrintStatsAlerts", func(f *Formatter) {
+			f.PrintStatsAlerts(&report.Alerts{
+				Period: "week", From: "2024-03-08", To: "2024-03-14", Threshold: 30, Triggered: 1,
+				Metrics: []report.MetricAlert{
+					{Metric: "plays", Current: 300, Baseline: 520, ChangePercent: -42.3, Status: "drop"},
+					{Metric: "likes", Current: 20, Baseline: 21, ChangePercent: -4.8, Status: "ok"},
+				},
+			})
+		}},
+		{"PrintCampaigns", func(f *Formatter) {
+			f.PrintCampaigns([]report.Campaign{{Name: "spring", From: "2024-03-01", To: "2024-03-31", Days: 31}})
+		}},
+		{"PrintHistory", func(f *Formatter) {
+			f.PrintHistory([]journal.Entry{
+				{ID: 1, Time: time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC), Command: "episodes update", Method: "POST", Path: "/episodes/301", Fields: map[string]string{"title": "Spring Forward"}},
+				{ID: 2, Time: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), Command: "undo", Method: "POST", Path: "/episodes/301", UndoOf: 1},
+			})
+		}},
+		{"PrintQueue", func(f *Formatter) {
+			f.PrintQueue([]spool.Request{
+				{ID: 1, Queued: time.Date(2024, 3, 14, 7, 30, 0, 0, time.UTC), Command: "episodes update", Method: "POST", Path: "/episodes/301", Fields: map[string]string{"hidden": "true"}},
+			})
+		}},
+		{"PrintQueueFlush", func(f *Formatter) {
+			f.PrintQueueFlush([]spool.Result{
+				{Request: spool.Request{ID: 1, Command: "episodes update", Method: "POST", Path: "/episodes/301"}, Status: "sent"},
+				{Request: spool.Request{ID: 2, Command: "episodes delete", Method: "DELETE", Path: "/episodes/302"}, Status: "failed", Reason: "404 Not Found"},
+			})
+		}},
+		{"PrintEpisodeActions", func(f *Formatter) {
+			f.PrintEpisodeActions([]api.EpisodeActionResult{
+				{EpisodeID: 301, Status: "done"},
+				{EpisodeID: 302, Status: "failed", Reason: "spreaker API error 404: Episode not found"},
+			})
+		}},
+		{"PrintJobs", func(f *Formatter) {
+			f.PrintJobs([]jobs.Job{
+				{ID: 1, Command: []string{"episodes", "upload", "12345", "ep42.mp3", "--title", "Episode 42"}, At: time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC), Status: "done", Retries: 3, Attempts: 1},
+				{ID: 2, Command: []string{"episodes", "update", "301", "--hidden=false"}, At: time.Date(2024, 7, 2, 9, 10, 0, 0, time.UTC), Status: "pending", Retries: 3, Attempts: 1, LastError: "exit status 1: Error: 503 Service Unavailable"},
+			})
+		}},
+		{"PrintJobs_empty", func(f *Formatter) { f.PrintJobs(nil) }},
+		{"PrintCampaignComparison", func(f *Formatter) {
+			f.PrintCampaignComparison(&report.CampaignComparison{
+				Campaign: "spring", From: "2024-03-01", To: "2024-03-14", PreviousFrom: "2024-02-16", PreviousTo: "2024-02-29",
+				Metrics: []report.CampaignMetric{{Metric: "plays", Campaign: 1500, Previous: 1200, ChangePercent: 25}},
+			})
+		}},
+		{"PrintDecay", func(f *Formatter) {
+			f.PrintDecay([]report.EpisodeDecay{{
+				EpisodeID: 301, Title: episode.Title, PublishedAt: "2024-03-01", DaysLive: 14, LifetimePlays: 512,
+				Milestones: []report.DecayMilestone{{Day: 1, Plays: 200, Percent: 39.1, Reached: true}, {Day: 30, Reached: false}},
+			}})
+		}},
+		{"PrintShowAudit", func(f *Formatter) {
+			f.PrintShowAudit(&report.ShowAudit{
+				ShowID: 201, Title: "Morning Coffee", Episodes: 120,
+				Issues: []report.AuditIssue{{EpisodeID: 302, Title: "Episode 121", Code: "missing_description", Message: "episode has no description", Fixable: false}},
+			})
+		}},
+		{"PrintFeedDiff", func(f *Formatter) {
+			f.PrintFeedDiff(&report.FeedDiff{
+				ShowID: 201, Title: "Morning Coffee", FeedURL: "https://mirror.example.com/feed.xml", FeedTitle: "Morning Coffee (mirror)",
+				Episodes: 3, FeedItems: 3, Matched: 2,
+				Differences: []report.FeedDifference{
+					{Kind: report.FeedPublished, EpisodeID: 301, Title: episode.Title, Spreaker: "2024-03-01 09:30:00", Feed: "2024-03-03 09:30:00"},
+					{Kind: report.FeedDuration, EpisodeID: 300, Title: "Episode 119", Spreaker: "30m34s", Feed: "29m2s"},
+					{Kind: report.FeedMissingInFeed, EpisodeID: 302, Title: "Episode 121"},
+					{Kind: report.FeedMissingOnSpreaker, Title: "Episode 118"},
+				},
+			})
+		}},
+		{"PrintFeedDiff_none", func(f *Formatter) {
+			f.PrintFeedDiff(&report.FeedDiff{ShowID: 201, Title: "Morning Coffee", Episodes: 3, FeedItems: 3, Matched: 3, Differences: []report.FeedDifference{}})
+		}},
+		{"PrintAudioCheck", func(f *Formatter) {
+			f.PrintAudioCheck(audio.Check(&audio.Analysis{
+				File: "episode.mp3", Duration: 1834.5, IntegratedLUFS: -19.4, LoudnessRange: 6.1,
+				TruePeak: 0.4, SamplePeak: 0, ClippedSamples: 14, LeadingSilence: 3.2, TrailingSilence: 1.2,
+			}, audio.DefaultNorms))
+		}},
+		{"PrintAudioCheck_ok", func(f *Formatter) {
+			f.PrintAudioCheck(audio.Check(&audio.Analysis{
+				File: "episode.mp3", Duration: 1834.5, IntegratedLUFS: -16.2, LoudnessRange: 5.4,
+				TruePeak: -1.3, SamplePeak: -1.6, LeadingSilence: 0.4, TrailingSilence: 0.8,
+			}, audio.DefaultNorms))
+		}},
+		{"PrintSearchResults", func(f *Formatter) {
+			f.PrintSearchResults(&api.SearchResults{Shows: []models.Show{show}, Episodes: episodes[:1]})
+		}},
+		{"PrintShowFeedback", func(f *Formatter) {
+			f.PrintShowFeedback(&report.ShowFeedback{
+				ShowID: 201, Title: "Morning Coffee", LikesCount: 24, MessagesCount: 3,
+				Sentiment: report.SentimentCounts{Positive: 2, Neutral: 1},
+				Episodes:  []report.EpisodeFeedback{{EpisodeID: 301, Title: episode.Title, LikesCount: 24, MessagesCount: 3, Sentiment: report.SentimentCounts{Positive: 2, Neutral: 1}}},
+				Recent:    []report.FeedbackMessage{{EpisodeID: 301, Author: "bob", Text: "Great episode, thanks!", CreatedAt: "2024-03-02 08:15:00", Sentiment: "positive"}},
+			})
+		}},
+		{"PrintBrokenLinks", func(f *Formatter) {
+			f.PrintBrokenLinks([]linkcheck.BrokenLink{
+				{Link: linkcheck.Link{EpisodeID: 301, Title: episode.Title, Source: "description", URL: "https://example.com/gone"}, Status: 404},
+				{Link: linkcheck.Link{EpisodeID: 302, Title: "Episode 121", Source: "chapter: Intro", URL: "https://unreachable.invalid"}, Error: "no such host"},
+			})
+		}},
+		{"PrintExploreShows", func(f *Formatter) {
+			f.PrintExploreShows([]models.ExploreShow{{ShowID: 201, Title: "Morning Coffee", SiteURL: show.SiteURL, AuthorID: 101}})
+		}},
+		{"PrintShapeChecks", func(f *Formatter) {
+			f.PrintShapeChecks([]api.ShapeCheck{
+				{Endpoint: "/me"},
+				{Endpoint: "/shows/201", Unknown: []string{"new_field"}, Missing: []string{"explicit"}},
+				{Endpoint: "/episodes/301", Error: "timeout"},
+			})
+		}},
+		{"PrintAuthStatus", func(f *Formatter) {
+			expires := goldenNow.Add(72 * time.Hour)
+			f.PrintAuthStatus(&user, "config file", api.TokenInfo{JWT: true, Expires: &expires, Scopes: []string{"read"}})
+		}},
+		{"PrintAuthStatus_opaque", func(f *Formatter) {
+			f.PrintAuthStatus(&user, "SPREAKER_TOKEN environment variable", api.TokenInfo{})
+		}},
+		{"PrintVersion", func(f *Formatter) {
+			f.PrintVersion(
+				buildinfo.Info{Version: "1.4.0", Commit: "abc1234", Date: "2024-03-01", GoVersion: "go1.22.1", Platform: "linux/amd64"},
+				&api.APICompatibility{BaseURL: "https://api.spreaker.com/v2", PinnedVersion: "v2", ServerVersion: "v2", StatusCode: 200, Current: true},
+			)
+		}},
+		{"PrintConfigKeys", func(f *Formatter) {
+			f.PrintConfigKeys([]config.KeyInfo{
+				{Key: "output_format", Type: "string", Default: "table", Description: "Default output format"},
+				{Key: "default_show_id", Type: "int", Default: "0", Description: "Show used when --show is omitted"},
+			})
+		}},
+		{"PrintConfigProblems", func(f *Formatter) {
+			f.PrintConfigProblems([]config.Problem{
+				{Key: "output_format", Message: `invalid format "xml"`, Severity: config.SeverityError},
+				{Key: "colour", Message: "unknown key", Severity: config.SeverityWarning},
+			})
+		}},
+		{"PrintCategories", func(f *Formatter) {
+			f.PrintCategories([]models.Category{{CategoryID: 12, Name: "News", Permalink: "news", Level: 1}, {CategoryID: 13, Name: "Politics", Permalink: "politics", Level: 2}})
+		}},
+		{"PrintCategoryTree", func(f *Formatter) {
+			f.PrintCategoryTree([]refdata.CategoryNode{{
+				Category: models.Category{CategoryID: 12, Name: "News", Level: 1},
+				Children: []models.Category{{CategoryID: 13, Name: "Politics", Level: 2}},
+			}})
+		}},
+		{"PrintGooglePlayCategories", func(f *Formatter) {
+			f.PrintGooglePlayCategories([]models.GooglePlayCategory{{CategoryID: 5, Name: "News & Politics", Level: 1}})
+		}},
+		{"PrintLanguages", func(f *Formatter) {
+			f.PrintLanguages([]models.Language{{Code: "en", Name: "English"}, {Code: "it", Name: "Italian"}})
+		}},
+		{"PrintCuepoints", func(f *Formatter) {
+			f.PrintCuepoints([]models.Cuepoint{{Timecode: 0, AdsMaxCount: 1}, {Timecode: 905000, AdsMaxCount: 2}})
+		}},
+		{"PrintChapters", func(f *Formatter) {
+			f.PrintChapters([]models.Chapter{{ChapterID: 1, StartsAt: 0, Title: "Intro"}, {ChapterID: 2, StartsAt: 125000, Title: "Headlines", ExternalURL: "https://example.com/news"}})
+		}},
+		{"PrintMessages", func(f *Formatter) { f.PrintMessages([]models.Message{message}) }},
+		{"PrintMessageMatches", func(f *Formatter) {
+			f.PrintMessageMatches([]catalog.Match{{
+				ShowID: 201, ShowTitle: "Morning Coffee",
+				Message: catalog.Message{Message: message, EpisodeTitle: episode.Title},
+				Snippet: "Great [episode], thanks!",
+			}})
+		}},
+		{"PrintEpisodeMessage", func(f *Formatter) { f.PrintEpisodeMessage(&message) }},
+	}
+}
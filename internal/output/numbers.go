@@ -0,0 +1,84 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// Number formatting
+// -----------------------------------------------------------------------------
+
+// Number formats for counts in tables (see SetNumberFormat).
+const (
+	NumbersRaw     = "raw"     // 1234567
+	NumbersGrouped = "grouped" // 1,234,567
+	NumbersHuman   = "human"   // 1.2M
+)
+
+// SetNumberFormat selects how counts such as plays and followers are
+// written in tables: NumbersRaw (the default), NumbersGrouped or
+// NumbersHuman. JSON and plain output always carry raw numbers.
+func (f *Formatter) SetNumberFormat(format string) {
+	f.numbers = format
+}
+
+// num formats a count for a table cell.
+func (f *Formatter) num(n int) string {
+	switch f.numbers {
+	case NumbersGrouped:
+		return groupDigits(strconv.Itoa(n))
+	case NumbersHuman:
+		return humanNumber(float64(n))
+	}
+	return strconv.Itoa(n)
+}
+
+// numf formats a fractional count, such as an average, for a table cell.
+func (f *Formatter) numf(v float64) string {
+	switch f.numbers {
+	case NumbersGrouped:
+		s := fmt.Sprintf("%.1f", v)
+		whole, frac, _ := strings.Cut(s, ".")
+		return groupDigits(whole) + "." + frac
+	case NumbersHuman:
+		return humanNumber(v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+// groupDigits inserts thousands separators into an integer, e.g.
+// "-1234567" becomes "-1,234,567".
+func groupDigits(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String()
+}
+
+// humanNumber abbreviates a number with a k, M or B suffix and one
+// decimal, e.g. 12400 becomes "12.4k". Numbers below 1,000 keep their
+// digits, with one decimal if they have a fraction.
+func humanNumber(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	units := []string{"", "k", "M", "B"}
+	i := 0
+	// 999,950 rounds to 1M rather than 1000.0k.
+	for v >= 999.95 && i < len(units)-1 {
+		v /= 1000
+		i++
+	}
+	return sign + strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0") + units[i]
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestFormatter_Num(t *testing.T) {
+	tests := []struct {
+		format string
+		n      int
+		want   string
+	}{
+		{NumbersRaw, 1234567, "1234567"},
+		{NumbersGrouped, 1234567, "1,234,567"},
+		{NumbersGrouped, -1234, "-1,234"},
+		{NumbersGrouped, 999, "999"},
+		{NumbersHuman, 999, "999"},
+		{NumbersHuman, 1000, "1k"},
+		{NumbersHuman, 12400, "12.4k"},
+		{NumbersHuman, 999999, "1M"},
+		{NumbersHuman, 1234567, "1.2M"},
+		{NumbersHuman, 3200000000, "3.2B"},
+	}
+	for _, tt := range tests {
+		f, _ := newTestFormatter("table")
+		f.SetNumberFormat(tt.format)
+		if got := f.num(tt.n); got != tt.want {
+			t.Errorf("num(%d) with %s = %q, want %q", tt.n, tt.format, got, tt.want)
+		}
+	}
+
+	f, _ := newTestFormatter("table")
+	f.SetNumberFormat(NumbersGrouped)
+	if got := f.numf(12345.67); got != "12,345.7" {
+		t.Errorf("numf() = %q, want 12,345.7", got)
+	}
+}
+
+func TestPrintShows_NumberFormat(t *testing.T) {
+	shows := []models.Show{{ShowID: 1234567, Title: "News", PlayCount: 12400}}
+	for _, tt := range []struct{ format, want, notWant string }{
+		{"table", "12.4k", "12400"},
+		{"plain", "News", "12.4k"},
+		{"json", "12400", "12.4k"},
+	} {
+		f, buf := newTestFormatter(tt.format)
+		f.SetNumberFormat(NumbersHuman)
+		f.PrintShows(shows)
+		out := buf.String()
+		if !strings.Contains(out, tt.want) || strings.Contains(out, tt.notWant) {
+			t.Errorf("%s output:\n%s\nwant %q and not %q", tt.format, out, tt.want, tt.notWant)
+		}
+		if !strings.Contains(out, "1234567") {
+			t.Errorf("%s output changed the ID:\n%s", tt.format, out)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultPager is used when neither the pager config key nor $PAGER is set.
+const DefaultPager = "less"
+
+// Pager is an io.Writer that pipes output through a pager program, like git.
+// The pager is started on the first write, so commands that print nothing
+// (or only prompt) never start it. If the pager cannot be started, output
+// goes to stdout instead.
+type Pager struct {
+	command string
+	cmd     *exec.Cmd
+	pipe    io.WriteCloser
+	out     io.Writer
+}
+
+// NewPager returns a Pager that runs command (split on whitespace, no shell).
+func NewPager(command string) *Pager {
+	return &Pager{command: command}
+}
+
+func (p *Pager) Write(b []byte) (int, error) {
+	if p.out == nil {
+		p.start()
+	}
+	return p.out.Write(b)
+}
+
+func (p *Pager) start() {
+	p.out = os.Stdout
+
+	args := strings.Fields(p.command)
+	if len(args) == 0 {
+		return
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Like git: quit if the output fits on one screen, keep colors, and
+	// leave the output on screen when the pager exits.
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	pipe, err := cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		return
+	}
+	p.cmd, p.pipe, p.out = cmd, pipe, pipe
+}
+
+// Close ends the output and waits for the user to quit the pager. Output
+// written after Close goes straight to stdout, so the pager can be closed
+// before a prompt while formatters still hold it.
+func (p *Pager) Close() error {
+	if p.cmd == nil {
+		return nil
+	}
+	p.pipe.Close()
+	err := p.cmd.Wait()
+	p.cmd, p.pipe, p.out = nil, nil, os.Stdout
+	return err
+}
+
+// ResolvePager returns the pager command to use: the configured one, then
+// $PAGER, then DefaultPager. It returns "" when paging is turned off with
+// "off" or "cat".
+func ResolvePager(configured string) string {
+	command := configured
+	if command == "" {
+		command = os.Getenv("PAGER")
+	}
+	if command == "" {
+		command = DefaultPager
+	}
+	switch strings.TrimSpace(command) {
+	case "off", "cat", "":
+		return ""
+	}
+	return command
+}
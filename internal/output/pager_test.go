@@ -0,0 +1,53 @@
+package output
+
+import "testing"
+
+func TestResolvePager(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		env        string
+		want       string
+	}{
+		{"config wins", "less -S", "more", "less -S"},
+		{"env fallback", "", "more", "more"},
+		{"default", "", "", DefaultPager},
+		{"config off", "off", "more", ""},
+		{"env cat", "", "cat", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAGER", tt.env)
+			if got := ResolvePager(tt.configured); got != tt.want {
+				t.Errorf("ResolvePager(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPager_FallsBackWhenPagerMissing(t *testing.T) {
+	p := NewPager("spreaker-no-such-pager-binary")
+	if _, err := p.Write([]byte("")); err != nil {
+		t.Fatalf("Write() error = %v, want fallback to stdout", err)
+	}
+	if p.cmd != nil {
+		t.Error("pager process should not be running")
+	}
+	if err := p.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestPager_WriteAfterClose(t *testing.T) {
+	p := NewPager("cat")
+	if _, err := p.Write([]byte("")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := p.Write([]byte("")); err != nil {
+		t.Errorf("Write() after Close error = %v, want output on stdout", err)
+	}
+}
@@ -0,0 +1,206 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/i18n"
+)
+
+// -----------------------------------------------------------------------------
+// List sorting
+// -----------------------------------------------------------------------------
+
+// SortSpec orders list output by a field (see --sort).
+type SortSpec struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a --sort value, "field", "field:asc" or "field:desc".
+func ParseSort(s string) (SortSpec, error) {
+	field, order, _ := strings.Cut(strings.TrimSpace(s), ":")
+	spec := SortSpec{Field: strings.ToLower(strings.TrimSpace(field))}
+	if spec.Field == "" {
+		return spec, fmt.Errorf("invalid sort %q: missing field", s)
+	}
+	switch strings.ToLower(strings.TrimSpace(order)) {
+	case "", "asc":
+	case "desc":
+		spec.Desc = true
+	default:
+		return spec, fmt.Errorf("invalid sort %q: order must be asc or desc", s)
+	}
+	return spec, nil
+}
+
+// SetSort orders the items of list output, in every format, by a field of
+// their JSON form. A nil spec keeps the API's order.
+func (f *Formatter) SetSort(spec *SortSpec) {
+	f.sort = spec
+}
+
+// sorted returns items ordered by the --sort field, or items itself
+// without one. The field is matched by JSON name, e.g. plays_count, also
+// without its _count or _at suffix, e.g. plays or published; "count" and
+// "id" pick the first count and ID fields. Unknown fields are reported
+// with the list of available ones.
+func sorted[T any](f *Formatter, items []T) []T {
+	if f.sort == nil || len(items) < 2 {
+		return items
+	}
+	t := reflect.TypeOf(items).Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return items
+	}
+	index, ok := sortField(t, f.sort.Field)
+	if !ok {
+		f.PrintWarning(i18n.T("unknown sort field: %s (available: %s)", f.sort.Field, strings.Join(sortFields(t), ", ")))
+		return items
+	}
+
+	out := append([]T(nil), items...)
+	key := func(i int) reflect.Value {
+		v := reflect.ValueOf(out[i])
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		return v.FieldByIndex(index)
+	}
+	desc := f.sort.Desc
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := key(i), key(j)
+		// Missing values come last in either order.
+		if missing(a) || missing(b) {
+			return !missing(a) && missing(b)
+		}
+		if desc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+	return out
+}
+
+// sortField returns the index of the field of struct type t that name
+// selects.
+func sortField(t reflect.Type, name string) ([]int, bool) {
+	fields := reflect.VisibleFields(t)
+	match := func(ok func(json string) bool) ([]int, bool) {
+		for _, sf := range fields {
+			if json := jsonName(sf); json != "" && sortable(sf.Type) && ok(json) {
+				return sf.Index, true
+			}
+		}
+		return nil, false
+	}
+	if index, ok := match(func(json string) bool { return json == name }); ok {
+		return index, true
+	}
+	for _, suffix := range []string{"_count", "_at"} {
+		if index, ok := match(func(json string) bool { return json == name+suffix }); ok {
+			return index, true
+		}
+	}
+	if name == "count" || name == "id" {
+		return match(func(json string) bool { return strings.HasSuffix(json, "_"+name) })
+	}
+	return nil, false
+}
+
+// sortFields lists the fields of struct type t that --sort accepts.
+func sortFields(t reflect.Type) []string {
+	var names []string
+	for _, sf := range reflect.VisibleFields(t) {
+		if json := jsonName(sf); json != "" && sortable(sf.Type) {
+			names = append(names, json)
+		}
+	}
+	return names
+}
+
+// jsonName returns the JSON name of an exported field, or "" if it has
+// none.
+func jsonName(sf reflect.StructField) string {
+	if !sf.IsExported() || sf.Anonymous {
+		return ""
+	}
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(sf.Name)
+	}
+	return name
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// sortable reports whether values of type t can be ordered: numbers,
+// strings, booleans and times, or pointers to them.
+func sortable(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	case reflect.Struct:
+		_, ok := asTime(reflect.Zero(t))
+		return ok
+	}
+	return false
+}
+
+// asTime returns the time a time.Time value holds, or one that embeds it
+// such as models.CustomTime.
+func asTime(v reflect.Value) (time.Time, bool) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time), true
+	}
+	if v.Kind() == reflect.Struct {
+		if sf, ok := v.Type().FieldByName("Time"); ok && sf.Anonymous && sf.Type == timeType {
+			return v.FieldByIndex(sf.Index).Interface().(time.Time), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// missing reports whether a sort value is absent: a nil pointer.
+func missing(v reflect.Value) bool {
+	return !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil())
+}
+
+// less compares two values of a sortable type.
+func less(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Pointer {
+		a, b = a.Elem(), b.Elem()
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return strings.ToLower(a.String()) < strings.ToLower(b.String())
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	ta, _ := asTime(a)
+	tb, _ := asTime(b)
+	return ta.Before(tb)
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want SortSpec
+	}{
+		{"plays", SortSpec{Field: "plays"}},
+		{"Plays:DESC", SortSpec{Field: "plays", Desc: true}},
+		{" title:asc ", SortSpec{Field: "title"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseSort(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSort(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", ":desc", "plays:up"} {
+		if _, err := ParseSort(in); err == nil {
+			t.Errorf("ParseSort(%q): want error", in)
+		}
+	}
+}
+
+func TestSorted(t *testing.T) {
+	day := func(d int) *models.CustomTime {
+		return &models.CustomTime{Time: time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)}
+	}
+	episodes := []models.Episode{
+		{EpisodeID: 1, Title: "beta", PlayCount: 10, PublishedAt: day(2)},
+		{EpisodeID: 2, Title: "Alpha", PlayCount: 30},
+		{EpisodeID: 3, Title: "gamma", PlayCount: 20, PublishedAt: day(1)},
+	}
+	ids := func(list []models.Episode) []int {
+		var out []int
+		for _, e := range list {
+			out = append(out, e.EpisodeID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		sort string
+		want []int
+	}{
+		{"plays:desc", []int{2, 3, 1}},
+		{"plays_count", []int{1, 3, 2}},
+		{"title", []int{2, 1, 3}},
+		{"published", []int{3, 1, 2}},
+		{"published:desc", []int{1, 3, 2}},
+		{"id:desc", []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		f, _ := newTestFormatter("table")
+		spec, err := ParseSort(tt.sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.SetSort(&spec)
+		if got := ids(sorted(f, episodes)); !equalInts(got, tt.want) {
+			t.Errorf("sort %s = %v, want %v", tt.sort, got, tt.want)
+		}
+	}
+	if got := ids(episodes); !equalInts(got, []int{1, 2, 3}) {
+		t.Errorf("sorted modified its input: %v", got)
+	}
+
+	f, _ := newTestFormatter("table")
+	errBuf := &bytes.Buffer{}
+	f.errWriter = errBuf
+	f.SetSort(&SortSpec{Field: "nope"})
+	if got := ids(sorted(f, episodes)); !equalInts(got, []int{1, 2, 3}) {
+		t.Errorf("unknown field reordered the list: %v", got)
+	}
+	if !strings.Contains(errBuf.String(), "unknown sort field: nope") {
+		t.Errorf("missing warning, got %q", errBuf.String())
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
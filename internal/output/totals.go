@@ -0,0 +1,38 @@
+package output
+
+import "github.com/G10xy/spreaker-and-go/internal/i18n"
+
+// -----------------------------------------------------------------------------
+// Totals rows
+// -----------------------------------------------------------------------------
+
+// SetTotals ends time-series and totals tables with a TOTAL and an AVG row
+// (see --totals). JSON and plain output are never changed.
+func (f *Formatter) SetTotals(enabled bool) {
+	f.totals = enabled
+}
+
+// totalRows returns the TOTAL and AVG rows that end a stats table of items,
+// or nil without --totals. sums holds one function per table column giving
+// the value of an item in that column, or nil for columns that are not
+// summed such as dates and titles. The first column holds the row labels.
+func totalRows[T any](f *Formatter, items []T, sums ...func(T) int) [][]string {
+	if !f.totals || len(items) == 0 || len(sums) == 0 {
+		return nil
+	}
+	total := make([]string, len(sums))
+	avg := make([]string, len(sums))
+	total[0], avg[0] = i18n.T("TOTAL"), i18n.T("AVG")
+	for i, sum := range sums[1:] {
+		if sum == nil {
+			continue
+		}
+		n := 0
+		for _, item := range items {
+			n += sum(item)
+		}
+		total[i+1] = f.num(n)
+		avg[i+1] = f.numf(float64(n) / float64(len(items)))
+	}
+	return [][]string{total, avg}
+}
@@ -0,0 +1,334 @@
+/*
+Package protogen derives protocol buffer messages from Go structs, so the
+types of pkg/models can be served over gRPC without generated code.
+
+A Registry describes structs as messages: fields are named after their
+JSON tags, with embedded structs flattened as in JSON. Every field carries
+its number in a proto tag, so adding or moving a Go field never renumbers
+the others:
+
+	Hidden bool `json:"hidden" proto:"23"`
+
+Numbers of removed fields are reserved with a tag on a blank field, so
+they are not reused by mistake:
+
+	_ struct{} `json:"-" proto:"reserved=24,25,26"`
+
+The same descriptions render the .proto file and drive Marshal and
+Unmarshal, so the definitions and the wire format always agree.
+
+Go types map to proto3 types as follows: integers to int64, floats to
+double, bool, string, slices to repeated fields, structs and pointers to
+structs to messages, and times (time.Time or structs embedding it, such as
+models.CustomTime) to RFC 3339 strings.
+*/
+package protogen
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind is the proto3 type of a field.
+type Kind int
+
+const (
+	KindInt64 Kind = iota
+	KindDouble
+	KindBool
+	KindString
+	KindMessage
+)
+
+// Field is a field of a message.
+type Field struct {
+	Name     string
+	Number   int
+	Kind     Kind
+	Repeated bool
+
+	// Message is the type of a KindMessage field.
+	Message *Message
+
+	index []int // path of the Go field, through embedded structs
+	time  bool  // a time encoded as a string
+}
+
+// Message describes a Go struct as a protocol buffer message.
+type Message struct {
+	Name   string
+	Fields []Field
+
+	// Reserved lists the numbers of removed fields.
+	Reserved []int
+
+	typ reflect.Type
+}
+
+// New returns a pointer to a new zero value of the message's struct.
+func (m *Message) New() any {
+	return reflect.New(m.typ).Interface()
+}
+
+// Method is an RPC of a service.
+type Method struct {
+	Name     string
+	Request  *Message
+	Response *Message
+}
+
+// Service is a gRPC service.
+type Service struct {
+	Name    string
+	Methods []Method
+}
+
+// Registry holds the messages described so far.
+type Registry struct {
+	messages map[reflect.Type]*Message
+	order    []*Message
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{messages: make(map[reflect.Type]*Message)}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Describe returns the message of a struct type, or of the struct a
+// pointer type points to, describing nested structs as needed.
+func (r *Registry) Describe(t reflect.Type) (*Message, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isTime(t) {
+		return nil, fmt.Errorf("protogen: %s is not a struct", t)
+	}
+	if m, ok := r.messages[t]; ok {
+		return m, nil
+	}
+
+	for _, other := range r.order {
+		if other.Name == t.Name() {
+			return nil, fmt.Errorf("protogen: %s and %s are both named %s", other.typ, t, t.Name())
+		}
+	}
+
+	// Registered before its fields, so recursive types terminate.
+	m := &Message{Name: t.Name(), typ: t}
+	r.messages[t] = m
+	r.order = append(r.order, m)
+
+	err := r.addFields(m, t, nil)
+	if err == nil {
+		err = checkNumbers(m)
+	}
+	if err != nil {
+		delete(r.messages, t)
+		r.order = r.order[:len(r.order)-1]
+		return nil, err
+	}
+	return m, nil
+}
+
+// Messages returns the described messages in the order they were first
+// described.
+func (r *Registry) Messages() []*Message {
+	return r.order
+}
+
+// addFields appends the fields of t, found at index in m's struct.
+func (r *Registry) addFields(m *Message, t reflect.Type, index []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		path := append(append([]int(nil), index...), i)
+
+		if sf.Name == "_" {
+			if list, ok := strings.CutPrefix(sf.Tag.Get("proto"), "reserved="); ok {
+				for _, s := range strings.Split(list, ",") {
+					n, err := strconv.Atoi(strings.TrimSpace(s))
+					if err != nil {
+						return fmt.Errorf("protogen: %s has an invalid reserved number %q", t.Name(), s)
+					}
+					m.Reserved = append(m.Reserved, n)
+				}
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// As in encoding/json, the exported fields of embedded structs
+		// are promoted even when the struct type is unexported.
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct && !isTime(sf.Type) {
+			if err := r.addFields(m, sf.Type, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		number, err := strconv.Atoi(sf.Tag.Get("proto"))
+		if err != nil {
+			return fmt.Errorf("protogen: field %s.%s has no proto number", t.Name(), sf.Name)
+		}
+
+		f := Field{Name: name, Number: number, index: path}
+		ft := sf.Type
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() != reflect.Uint8 {
+			f.Repeated = true
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		switch {
+		case isTime(ft):
+			f.Kind, f.time = KindString, true
+		case ft.Kind() == reflect.Struct:
+			nested, err := r.Describe(ft)
+			if err != nil {
+				return err
+			}
+			f.Kind, f.Message = KindMessage, nested
+		default:
+			kind, ok := scalarKind(ft.Kind())
+			if !ok {
+				return fmt.Errorf("protogen: field %s.%s has unsupported type %s", t.Name(), sf.Name, sf.Type)
+			}
+			f.Kind = kind
+		}
+		m.Fields = append(m.Fields, f)
+	}
+	return nil
+}
+
+// checkNumbers reports numbers that are out of range, or used by two
+// fields or by a field and a reserved entry.
+func checkNumbers(m *Message) error {
+	seen := make(map[int]string)
+	check := func(n int, what string) error {
+		if n < 1 || n > 1<<29-1 || (n >= 19000 && n <= 19999) {
+			return fmt.Errorf("protogen: %s.%s has invalid number %d", m.Name, what, n)
+		}
+		if other, ok := seen[n]; ok {
+			return fmt.Errorf("protogen: %s.%s and %s.%s both use number %d", m.Name, other, m.Name, what, n)
+		}
+		seen[n] = what
+		return nil
+	}
+	for _, n := range m.Reserved {
+		if err := check(n, "reserved"); err != nil {
+			return err
+		}
+	}
+	for _, f := range m.Fields {
+		if err := check(f.Number, f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scalarKind(k reflect.Kind) (Kind, bool) {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return KindInt64, true
+	case reflect.Float32, reflect.Float64:
+		return KindDouble, true
+	case reflect.Bool:
+		return KindBool, true
+	case reflect.String:
+		return KindString, true
+	}
+	return 0, false
+}
+
+// isTime reports whether t is time.Time or a struct embedding it.
+func isTime(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && f.Type == timeType {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the proto3 type of a field.
+func (f Field) typeName() string {
+	switch f.Kind {
+	case KindInt64:
+		return "int64"
+	case KindDouble:
+		return "double"
+	case KindBool:
+		return "bool"
+	case KindMessage:
+		return f.Message.Name
+	default:
+		return "string"
+	}
+}
+
+// Render writes a proto3 file declaring the services and messages.
+func Render(w io.Writer, pkg string, services []Service, messages []*Message) error {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by spreaker. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", pkg)
+
+	for _, s := range services {
+		fmt.Fprintf(&b, "\nservice %s {\n", s.Name)
+		for _, m := range s.Methods {
+			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s);\n", m.Name, m.Request.Name, m.Response.Name)
+		}
+		b.WriteString("}\n")
+	}
+
+	for _, m := range messages {
+		fmt.Fprintf(&b, "\nmessage %s {\n", m.Name)
+		if len(m.Reserved) > 0 {
+			numbers := make([]string, len(m.Reserved))
+			for i, n := range m.Reserved {
+				numbers[i] = strconv.Itoa(n)
+			}
+			fmt.Fprintf(&b, "  reserved %s;\n", strings.Join(numbers, ", "))
+		}
+		for _, f := range m.Fields {
+			label := ""
+			if f.Repeated {
+				label = "repeated "
+			}
+			fmt.Fprintf(&b, "  %s%s %s = %d;", label, f.typeName(), f.Name, f.Number)
+			if f.time {
+				b.WriteString(" // RFC 3339")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,187 @@
+package protogen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+type inner struct {
+	Name string `json:"name" proto:"1"`
+}
+
+type embedded struct {
+	Flag bool `json:"flag" proto:"8"`
+}
+
+type sample struct {
+	_        struct{}          `json:"-" proto:"reserved=9,10"`
+	ID       int               `json:"id" proto:"1"`
+	Score    float64           `json:"score" proto:"2"`
+	Tags     []string          `json:"tags,omitempty" proto:"3"`
+	Counts   []int             `json:"counts" proto:"4"`
+	Inner    *inner            `json:"inner" proto:"5"`
+	Items    []inner           `json:"items" proto:"6"`
+	At       models.CustomTime `json:"at" proto:"11"`
+	Skipped  string            `json:"-"`
+	internal string
+	embedded
+}
+
+func TestRender(t *testing.T) {
+	r := NewRegistry()
+	m, err := r.Describe(reflect.TypeOf(&sample{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := Render(&b, "test.v1", []Service{{Name: "Test", Methods: []Method{{Name: "Get", Request: m, Response: m}}}}, r.Messages()); err != nil {
+		t.Fatal(err)
+	}
+	want := `syntax = "proto3";
+
+package test.v1;
+
+service Test {
+  rpc Get(sample) returns (sample);
+}
+
+message sample {
+  reserved 9, 10;
+  int64 id = 1;
+  double score = 2;
+  repeated string tags = 3;
+  repeated int64 counts = 4;
+  inner inner = 5;
+  repeated inner items = 6;
+  string at = 11; // RFC 3339
+  bool flag = 8;
+}
+
+message inner {
+  string name = 1;
+}
+`
+	if got := b.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("Render() =\n%s\nwant suffix\n%s", got, want)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	r := NewRegistry()
+	m, err := r.Describe(reflect.TypeOf(sample{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := sample{
+		ID:       -3,
+		Score:    1.5,
+		Tags:     []string{"a", ""},
+		Counts:   []int{1, 300},
+		Inner:    &inner{Name: "x"},
+		Items:    []inner{{Name: "y"}, {}},
+		At:       models.CustomTime{Time: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)},
+		Skipped:  "not sent",
+		embedded: embedded{Flag: true},
+	}
+	data, err := Marshal(m, &in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out sample
+	if err := Unmarshal(m, data, &out); err != nil {
+		t.Fatal(err)
+	}
+	in.Skipped = ""
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
+
+func TestMarshalWire(t *testing.T) {
+	r := NewRegistry()
+	m, err := r.Describe(reflect.TypeOf(inner{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := Marshal(m, inner{Name: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x0a, 2, 'h', 'i'}; !reflect.DeepEqual(data, want) {
+		t.Errorf("Marshal() = % x, want % x", data, want)
+	}
+	if data, _ := Marshal(m, inner{}); len(data) != 0 {
+		t.Errorf("Marshal(zero) = % x, want empty", data)
+	}
+}
+
+func TestUnmarshalSkipsUnknownFields(t *testing.T) {
+	r := NewRegistry()
+	m, err := r.Describe(reflect.TypeOf(inner{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Field 2 (varint 7) and field 3 (fixed32) are unknown.
+	data := []byte{0x10, 7, 0x1d, 1, 2, 3, 4, 0x0a, 1, 'z'}
+	var out inner
+	if err := Unmarshal(m, data, &out); err != nil || out.Name != "z" {
+		t.Errorf("Unmarshal() = %+v, %v", out, err)
+	}
+	if err := Unmarshal(m, []byte{0x0a, 5, 'z'}, &out); err == nil {
+		t.Error("truncated message decoded without error")
+	}
+}
+
+func TestDescribeModels(t *testing.T) {
+	r := NewRegistry()
+	for _, v := range []any{models.User{}, models.Show{}, models.Episode{}, models.PlayStatistics{}} {
+		if _, err := r.Describe(reflect.TypeOf(v)); err != nil {
+			t.Errorf("Describe(%T) = %v", v, err)
+		}
+	}
+
+	if _, err := r.Describe(reflect.TypeOf(struct {
+		M map[string]int `json:"m"`
+	}{})); err == nil {
+		t.Error("map field described without error")
+	}
+}
+
+func TestDescribeNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"missing", struct {
+			A int `json:"a"`
+		}{}},
+		{"duplicate", struct {
+			A int `json:"a" proto:"1"`
+			B int `json:"b" proto:"1"`
+		}{}},
+		{"reserved", struct {
+			_ struct{} `json:"-" proto:"reserved=2"`
+			A int      `json:"a" proto:"2"`
+		}{}},
+		{"zero", struct {
+			A int `json:"a" proto:"0"`
+		}{}},
+		{"implementation range", struct {
+			A int `json:"a" proto:"19000"`
+		}{}},
+	}
+	for _, tt := range tests {
+		if _, err := NewRegistry().Describe(reflect.TypeOf(tt.v)); err == nil {
+			t.Errorf("%s: described without error", tt.name)
+		}
+	}
+}
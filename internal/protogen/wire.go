@@ -0,0 +1,324 @@
+package protogen
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Wire types of the protocol buffer encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Marshal encodes v, a struct described by m or a pointer to one, in the
+// protocol buffer wire format. As in proto3, zero values are omitted.
+func Marshal(m *Message, v any) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Type() != m.typ {
+		return nil, fmt.Errorf("protogen: cannot marshal %s as %s", rv.Type(), m.Name)
+	}
+	return appendMessage(nil, m, rv), nil
+}
+
+func appendMessage(b []byte, m *Message, v reflect.Value) []byte {
+	for _, f := range m.Fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if !f.Repeated {
+			b = appendValue(b, f, fv)
+			continue
+		}
+		if fv.Len() == 0 {
+			continue
+		}
+		if f.Kind == KindString || f.Kind == KindMessage {
+			for i := 0; i < fv.Len(); i++ {
+				b = appendValue(b, f, fv.Index(i))
+			}
+			continue
+		}
+		// Repeated numbers are packed into a single record.
+		var packed []byte
+		for i := 0; i < fv.Len(); i++ {
+			packed = appendScalar(packed, f.Kind, fv.Index(i))
+		}
+		b = appendTag(b, f.Number, wireBytes)
+		b = appendBytes(b, packed)
+	}
+	return b
+}
+
+// appendValue appends a single value of f, unless it is a zero scalar or a
+// nil message.
+func appendValue(b []byte, f Field, v reflect.Value) []byte {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return b
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case f.time:
+		t := timeOf(v)
+		if t.IsZero() {
+			return b
+		}
+		return appendString(appendTag(b, f.Number, wireBytes), t.UTC().Format(time.RFC3339))
+	case f.Kind == KindMessage:
+		return appendBytes(appendTag(b, f.Number, wireBytes), appendMessage(nil, f.Message, v))
+	case f.Kind == KindString:
+		if v.Len() == 0 && !f.Repeated {
+			return b
+		}
+		return appendString(appendTag(b, f.Number, wireBytes), v.String())
+	}
+
+	if v.IsZero() {
+		return b
+	}
+	wire := wireVarint
+	if f.Kind == KindDouble {
+		wire = wireFixed64
+	}
+	return appendScalar(appendTag(b, f.Number, wire), f.Kind, v)
+}
+
+func appendScalar(b []byte, kind Kind, v reflect.Value) []byte {
+	switch kind {
+	case KindDouble:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float()))
+	case KindBool:
+		if v.Bool() {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	}
+	if v.CanUint() {
+		return binary.AppendUvarint(b, v.Uint())
+	}
+	// int64 encodes negative numbers as their two's complement.
+	return binary.AppendUvarint(b, uint64(v.Int()))
+}
+
+func appendTag(b []byte, number, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(number)<<3|uint64(wire))
+}
+
+func appendBytes(b, data []byte) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(data))), data...)
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(s))), s...)
+}
+
+// timeOf returns the time.Time of a time field.
+func timeOf(v reflect.Value) time.Time {
+	if t, ok := v.Interface().(time.Time); ok {
+		return t
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if t, ok := v.Field(i).Interface().(time.Time); ok {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// fieldByIndex returns the field at index, or false when an embedded
+// pointer on the way is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// errTruncated is returned for data that ends in the middle of a field.
+var errTruncated = errors.New("protogen: truncated message")
+
+// Unmarshal decodes data in the protocol buffer wire format into v, a
+// pointer to the struct described by m. Unknown fields are skipped.
+func Unmarshal(m *Message, data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Type() != m.typ {
+		return fmt.Errorf("protogen: cannot unmarshal %s into %T", m.Name, v)
+	}
+	return decodeMessage(m, data, rv.Elem())
+}
+
+func decodeMessage(m *Message, data []byte, v reflect.Value) error {
+	fields := make(map[int]Field, len(m.Fields))
+	for _, f := range m.Fields {
+		fields[f.Number] = f
+	}
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		number, wire := int(tag>>3), int(tag&7)
+
+		var raw []byte
+		var num uint64
+		switch wire {
+		case wireVarint:
+			num, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			num, n = binary.LittleEndian.Uint64(data), 8
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			num, n = uint64(binary.LittleEndian.Uint32(data)), 4
+		case wireBytes:
+			size, s := binary.Uvarint(data)
+			if s <= 0 || uint64(len(data)-s) < size {
+				return errTruncated
+			}
+			raw, n = data[s:s+int(size)], s+int(size)
+		default:
+			return fmt.Errorf("protogen: unsupported wire type %d", wire)
+		}
+		data = data[n:]
+
+		f, ok := fields[number]
+		if !ok {
+			continue
+		}
+		fv := allocField(v, f.index)
+		if err := decodeField(f, fv, wire, num, raw); err != nil {
+			return fmt.Errorf("protogen: field %s.%s: %w", m.Name, f.Name, err)
+		}
+	}
+	return nil
+}
+
+func decodeField(f Field, v reflect.Value, wire int, num uint64, raw []byte) error {
+	if !f.Repeated {
+		return decodeValue(f, v, wire, num, raw)
+	}
+
+	// Packed numbers hold several values in one record.
+	if wire == wireBytes && f.Kind != KindString && f.Kind != KindMessage {
+		for len(raw) > 0 {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if f.Kind == KindDouble {
+				if len(raw) < 8 {
+					return errTruncated
+				}
+				setNumber(elem, f.Kind, binary.LittleEndian.Uint64(raw))
+				raw = raw[8:]
+			} else {
+				x, n := binary.Uvarint(raw)
+				if n <= 0 {
+					return errTruncated
+				}
+				setNumber(elem, f.Kind, x)
+				raw = raw[n:]
+			}
+			v.Set(reflect.Append(v, elem))
+		}
+		return nil
+	}
+
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if err := decodeValue(f, elem, wire, num, raw); err != nil {
+		return err
+	}
+	v.Set(reflect.Append(v, elem))
+	return nil
+}
+
+func decodeValue(f Field, v reflect.Value, wire int, num uint64, raw []byte) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	wantBytes := f.Kind == KindString || f.Kind == KindMessage
+	if wantBytes != (wire == wireBytes) {
+		return fmt.Errorf("wire type %d does not match %s", wire, f.typeName())
+	}
+
+	switch {
+	case f.time:
+		t, err := time.Parse(time.RFC3339, string(raw))
+		if err != nil {
+			return err
+		}
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).Type() == timeType {
+				v.Field(i).Set(reflect.ValueOf(t))
+			}
+		}
+		return nil
+	case f.Kind == KindMessage:
+		return decodeMessage(f.Message, raw, v)
+	case f.Kind == KindString:
+		v.SetString(string(raw))
+		return nil
+	}
+	setNumber(v, f.Kind, num)
+	return nil
+}
+
+func setNumber(v reflect.Value, kind Kind, x uint64) {
+	switch kind {
+	case KindDouble:
+		v.SetFloat(math.Float64frombits(x))
+	case KindBool:
+		v.SetBool(x != 0)
+	default:
+		if v.CanUint() {
+			v.SetUint(x)
+		} else {
+			v.SetInt(int64(x))
+		}
+	}
+}
+
+// allocField returns the field at index, allocating embedded pointers on
+// the way.
+func allocField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
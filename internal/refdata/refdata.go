@@ -0,0 +1,283 @@
+/*
+Package refdata caches the API's reference data, the show categories and
+languages, per locale next to the config file. It changes rarely, so
+commands read it from the cache while it is fresh, can list it offline,
+and resolve category names to IDs without calling the API.
+*/
+package refdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// DefaultTTL is how long cached reference data is used before it is
+// fetched again.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// ErrNotCached is returned offline for a locale that was never fetched.
+var ErrNotCached = errors.New("not cached")
+
+// Entry is the reference data of one locale and when it was fetched.
+type Entry[T any] struct {
+	Fetched time.Time `json:"fetched"`
+	Items   T         `json:"items"`
+}
+
+// Cache holds the categories and languages by locale. The API's default
+// locale is cached under "".
+type Cache struct {
+	Categories map[string]Entry[[]models.Category] `json:"categories,omitempty"`
+	Languages  map[string]Entry[map[string]string] `json:"languages,omitempty"`
+
+	changed bool
+}
+
+// Options controls when cached data is used.
+type Options struct {
+	// TTL is the age past which cached data is fetched again; zero means
+	// DefaultTTL.
+	TTL time.Duration
+
+	// Offline uses cached data of any age and never fetches.
+	Offline bool
+
+	// Refresh fetches the data even if the cache is fresh.
+	Refresh bool
+
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+// Load reads the cache at path. A missing file yields an empty cache.
+func Load(path string) (*Cache, error) {
+	c := &Cache{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reference data cache: %w", err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid reference data cache %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cache to path if it changed since it was loaded,
+// replacing the file atomically.
+func (c *Cache) Save(path string) error {
+	if !c.changed {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save reference data cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save reference data cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save reference data cache: %w", err)
+	}
+	return nil
+}
+
+// GetCategories returns the categories of locale from the cache, or
+// fetches and caches them.
+func (c *Cache) GetCategories(locale string, opts Options, fetch func(locale string) ([]models.Category, error)) ([]models.Category, error) {
+	if c.Categories == nil {
+		c.Categories = make(map[string]Entry[[]models.Category])
+	}
+	items, changed, err := lookup(c.Categories, locale, opts, fetch)
+	c.changed = c.changed || changed
+	if err != nil {
+		return nil, fmt.Errorf("categories of locale %q: %w", locale, err)
+	}
+	return items, nil
+}
+
+// GetLanguages returns the languages of locale, by code, from the cache,
+// or fetches and caches them.
+func (c *Cache) GetLanguages(locale string, opts Options, fetch func(locale string) (map[string]string, error)) (map[string]string, error) {
+	if c.Languages == nil {
+		c.Languages = make(map[string]Entry[map[string]string])
+	}
+	items, changed, err := lookup(c.Languages, locale, opts, fetch)
+	c.changed = c.changed || changed
+	if err != nil {
+		return nil, fmt.Errorf("languages of locale %q: %w", locale, err)
+	}
+	return items, nil
+}
+
+// lookup returns the entry of locale when it can be used, or fetches and
+// stores it, reporting whether entries changed.
+func lookup[T any](entries map[string]Entry[T], locale string, opts Options, fetch func(string) (T, error)) (T, bool, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	entry, ok := entries[locale]
+	switch {
+	case opts.Offline && ok:
+		return entry.Items, false, nil
+	case opts.Offline:
+		return entry.Items, false, ErrNotCached
+	case ok && !opts.Refresh && now().Sub(entry.Fetched) < ttl:
+		return entry.Items, false, nil
+	}
+
+	items, err := fetch(locale)
+	if err != nil {
+		return items, false, err
+	}
+	entries[locale] = Entry[T]{Fetched: now().UTC(), Items: items}
+	return items, true, nil
+}
+
+// LanguageList returns languages as a list sorted by code.
+func LanguageList(languages map[string]string) []models.Language {
+	list := make([]models.Language, 0, len(languages))
+	for code, name := range languages {
+		list = append(list, models.Language{Code: code, Name: name})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// FindCategory returns the categories named name, ignoring case, or whose
+// permalink is name, and reports whether there were any. When there are
+// none, it returns those whose name contains name, or failing that, those
+// whose name is within a few typos of it.
+func FindCategory(categories []models.Category, name string) (matches []models.Category, exact bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	var partial, similar []models.Category
+	for _, c := range categories {
+		lower := strings.ToLower(c.Name)
+		switch {
+		case lower == name || strings.EqualFold(c.Permalink, name):
+			matches = append(matches, c)
+		case strings.Contains(lower, name):
+			partial = append(partial, c)
+		case distance(lower, name) <= maxTypos(name):
+			similar = append(similar, c)
+		}
+	}
+	switch {
+	case len(matches) > 0:
+		return matches, true
+	case len(partial) > 0:
+		return partial, false
+	}
+	return similar, false
+}
+
+// maxTypos is the number of typos tolerated in a name: one per four
+// letters, at least one.
+func maxTypos(name string) int {
+	return max(1, len([]rune(name))/4)
+}
+
+// distance returns the edit distance between a and b, counting swapped
+// adjacent letters as one edit (optimal string alignment).
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// CategoryNode is a top-level category with its subcategories.
+type CategoryNode struct {
+	models.Category
+	Children []models.Category `json:"children,omitempty"`
+}
+
+// CategoryTree groups categories into the two-level hierarchy. The API
+// lists each subcategory after its parent, so subcategories belong to the
+// top-level category before them; those without one become roots.
+func CategoryTree(categories []models.Category) []CategoryNode {
+	var tree []CategoryNode
+	for _, c := range categories {
+		if c.Level > 1 && len(tree) > 0 && tree[len(tree)-1].Level == 1 {
+			last := &tree[len(tree)-1]
+			last.Children = append(last.Children, c)
+			continue
+		}
+		tree = append(tree, CategoryNode{Category: c})
+	}
+	return tree
+}
+
+// FilterCategories returns the categories matching search, or the
+// subcategories of parent when it is not zero, keeping the order of the
+// API. A category matches if its name contains search, ignoring case, or
+// its parent's does; the parents of matching subcategories are kept, so
+// the result still forms a tree.
+func FilterCategories(categories []models.Category, search string, parent int) []models.Category {
+	search = strings.ToLower(strings.TrimSpace(search))
+	matches := func(c models.Category) bool {
+		return strings.Contains(strings.ToLower(c.Name), search)
+	}
+
+	var filtered []models.Category
+	for _, node := range CategoryTree(categories) {
+		if parent != 0 && node.CategoryID != parent {
+			continue
+		}
+
+		parentMatches := parent == 0 && matches(node.Category)
+		var children []models.Category
+		for _, child := range node.Children {
+			if parentMatches || matches(child) {
+				children = append(children, child)
+			}
+		}
+
+		if parent == 0 && (parentMatches || len(children) > 0) {
+			filtered = append(filtered, node.Category)
+		}
+		filtered = append(filtered, children...)
+	}
+	return filtered
+}
@@ -0,0 +1,137 @@
+package refdata
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "refdata.json")
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := Options{Now: func() time.Time { return now }}
+
+	fetches := 0
+	fetch := func(locale string) ([]models.Category, error) {
+		fetches++
+		return []models.Category{{CategoryID: 7, Name: "True Crime " + locale}}, nil
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCategories("it_IT", Options{Offline: true}, fetch); !errors.Is(err, ErrNotCached) {
+		t.Errorf("offline lookup of an empty cache = %v, want ErrNotCached", err)
+	}
+	if got, err := c.GetCategories("it_IT", opts, fetch); err != nil || got[0].Name != "True Crime it_IT" {
+		t.Fatalf("GetCategories() = %+v, %v", got, err)
+	}
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(DefaultTTL - time.Hour)
+	c.GetCategories("it_IT", opts, fetch)
+	if fetches != 1 {
+		t.Errorf("fresh cache fetched again: %d fetches", fetches)
+	}
+	c.GetCategories("it_IT", Options{Refresh: true, Now: opts.Now}, fetch)
+	if fetches != 2 {
+		t.Errorf("refresh did not fetch: %d fetches", fetches)
+	}
+
+	now = now.Add(DefaultTTL)
+	if _, err := c.GetCategories("it_IT", Options{Offline: true}, fetch); err != nil || fetches != 2 {
+		t.Errorf("offline lookup of stale data = %v after %d fetches", err, fetches)
+	}
+	c.GetCategories("it_IT", opts, fetch)
+	if fetches != 3 {
+		t.Errorf("stale cache not fetched again: %d fetches", fetches)
+	}
+}
+
+func TestFindCategory(t *testing.T) {
+	categories := []models.Category{
+		{CategoryID: 1, Name: "True Crime", Permalink: "true-crime"},
+		{CategoryID: 2, Name: "Comedy"},
+		{CategoryID: 3, Name: "Comedy Interviews"},
+	}
+
+	tests := []struct {
+		name  string
+		want  []int
+		exact bool
+	}{
+		{"true crime", []int{1}, true},
+		{"true-crime", []int{1}, true},
+		{"Comedy", []int{2}, true},
+		{"interview", []int{3}, false},
+		{"com", []int{2, 3}, false},
+		{"Comdey", []int{2}, false},
+		{"tru crim", []int{1}, false},
+		{"news", nil, false},
+	}
+	for _, tt := range tests {
+		got, exact := FindCategory(categories, tt.name)
+		var ids []int
+		for _, c := range got {
+			ids = append(ids, c.CategoryID)
+		}
+		if len(ids) != len(tt.want) || exact != tt.exact {
+			t.Errorf("FindCategory(%q) = %v, %v, want %v, %v", tt.name, ids, exact, tt.want, tt.exact)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.want[i] {
+				t.Errorf("FindCategory(%q) = %v, want %v", tt.name, ids, tt.want)
+			}
+		}
+	}
+}
+
+func TestFilterCategories(t *testing.T) {
+	categories := []models.Category{
+		{CategoryID: 1, Name: "Arts", Level: 1},
+		{CategoryID: 2, Name: "Books", Level: 2},
+		{CategoryID: 3, Name: "Design", Level: 2},
+		{CategoryID: 4, Name: "True Crime", Level: 1},
+		{CategoryID: 5, Name: "Society", Level: 1},
+		{CategoryID: 6, Name: "Crime Stories", Level: 2},
+	}
+
+	tests := []struct {
+		search string
+		parent int
+		want   []int
+	}{
+		{"", 0, []int{1, 2, 3, 4, 5, 6}},
+		{"arts", 0, []int{1, 2, 3}},
+		{"crime", 0, []int{4, 5, 6}},
+		{"", 1, []int{2, 3}},
+		{"des", 1, []int{3}},
+		{"", 4, nil},
+	}
+	for _, tt := range tests {
+		var ids []int
+		for _, c := range FilterCategories(categories, tt.search, tt.parent) {
+			ids = append(ids, c.CategoryID)
+		}
+		if !slices.Equal(ids, tt.want) {
+			t.Errorf("FilterCategories(%q, %d) = %v, want %v", tt.search, tt.parent, ids, tt.want)
+		}
+	}
+
+	tree := CategoryTree(categories)
+	if len(tree) != 3 || len(tree[0].Children) != 2 || len(tree[1].Children) != 0 || tree[2].Children[0].CategoryID != 6 {
+		t.Errorf("CategoryTree() = %+v", tree)
+	}
+}
@@ -0,0 +1,133 @@
+package report
+
+import (
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Alert statuses reported for each metric.
+const (
+	StatusOK    = "ok"
+	StatusSpike = "spike"
+	StatusDrop  = "drop"
+)
+
+// DateLayout is the date format used by the statistics endpoints.
+const DateLayout = "2006-01-02"
+
+// MetricSeries is a daily time series for a single metric, keyed by date (YYYY-MM-DD).
+type MetricSeries struct {
+	Metric string
+	Daily  map[string]int
+}
+
+// MetricAlert compares the current period of one metric against its baseline.
+type MetricAlert struct {
+	Metric        string  `json:"metric"`
+	Current       int     `json:"current"`
+	Baseline      float64 `json:"baseline"`
+	ChangePercent float64 `json:"change_percent"`
+	Status        string  `json:"status"`
+}
+
+// Alerts is the result of comparing the most recent period against the trailing average.
+type Alerts struct {
+	Period    string        `json:"period"`
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	Threshold float64       `json:"threshold_percent"`
+	Metrics   []MetricAlert `json:"metrics"`
+	Triggered int           `json:"triggered"`
+}
+
+// PlaysSeries extracts the plays and downloads daily series from play statistics.
+func PlaysSeries(stats []models.PlayStatistics) (plays, downloads MetricSeries) {
+	plays = MetricSeries{Metric: "plays", Daily: make(map[string]int, len(stats))}
+	downloads = MetricSeries{Metric: "downloads", Daily: make(map[string]int, len(stats))}
+	for _, s := range stats {
+		plays.Daily[s.Date.String()] += s.PlaysCount
+		downloads.Daily[s.Date.String()] += s.DownloadsCount
+	}
+	return plays, downloads
+}
+
+// LikesSeries extracts the likes daily series from likes statistics.
+func LikesSeries(stats []models.LikesStatistics) MetricSeries {
+	series := MetricSeries{Metric: "likes", Daily: make(map[string]int, len(stats))}
+	for _, s := range stats {
+		series.Daily[s.Date.String()] += s.LikesCount
+	}
+	return series
+}
+
+// ListenersSeries extracts the listeners daily series from listeners statistics.
+func ListenersSeries(stats []models.ListenersStatistics) MetricSeries {
+	series := MetricSeries{Metric: "listeners", Daily: make(map[string]int, len(stats))}
+	for _, s := range stats {
+		series.Daily[s.Date.String()] += s.ListenersCount
+	}
+	return series
+}
+
+// DetectAnomalies sums each series over the period of periodDays ending on end
+// (inclusive) and compares it with the average of the baselinePeriods periods
+// before it. A metric is a spike or drop when it differs from the baseline by
+// at least threshold percent. A metric with a zero baseline and a non-zero
+// current value counts as a 100% spike.
+func DetectAnomalies(series []MetricSeries, end time.Time, periodDays, baselinePeriods int, threshold float64) *Alerts {
+	start := end.AddDate(0, 0, -(periodDays - 1))
+	a := &Alerts{
+		From:      start.Format(DateLayout),
+		To:        end.Format(DateLayout),
+		Threshold: threshold,
+		Metrics:   make([]MetricAlert, 0, len(series)),
+	}
+
+	for _, s := range series {
+		current := sumDays(s.Daily, start, periodDays)
+
+		total := 0
+		for i := 1; i <= baselinePeriods; i++ {
+			total += sumDays(s.Daily, start.AddDate(0, 0, -i*periodDays), periodDays)
+		}
+		baseline := 0.0
+		if baselinePeriods > 0 {
+			baseline = float64(total) / float64(baselinePeriods)
+		}
+
+		m := MetricAlert{
+			Metric:   s.Metric,
+			Current:  current,
+			Baseline: baseline,
+			Status:   StatusOK,
+		}
+		switch {
+		case baseline > 0:
+			m.ChangePercent = (float64(current) - baseline) / baseline * 100
+		case current > 0:
+			m.ChangePercent = 100
+		}
+
+		if m.ChangePercent >= threshold && current > 0 {
+			m.Status = StatusSpike
+		} else if -m.ChangePercent >= threshold {
+			m.Status = StatusDrop
+		}
+		if m.Status != StatusOK {
+			a.Triggered++
+		}
+		a.Metrics = append(a.Metrics, m)
+	}
+
+	return a
+}
+
+// sumDays sums the values of days consecutive dates starting at from.
+func sumDays(daily map[string]int, from time.Time, days int) int {
+	sum := 0
+	for i := 0; i < days; i++ {
+		sum += daily[from.AddDate(0, 0, i).Format(DateLayout)]
+	}
+	return sum
+}
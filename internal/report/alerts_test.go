@@ -0,0 +1,69 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	end := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	daily := func(values ...int) map[string]int {
+		// values[0] is the oldest day, ending on end.
+		m := make(map[string]int)
+		for i, v := range values {
+			m[end.AddDate(0, 0, i-len(values)+1).Format(DateLayout)] = v
+		}
+		return m
+	}
+
+	series := []MetricSeries{
+		{Metric: "plays", Daily: daily(10, 10, 10, 10, 30)},
+		{Metric: "likes", Daily: daily(4, 4, 4, 4, 1)},
+		{Metric: "listeners", Daily: daily(10, 10, 10, 10, 12)},
+		{Metric: "downloads", Daily: daily(0, 0, 0, 0, 0)},
+	}
+
+	a := DetectAnomalies(series, end, 1, 4, 50)
+
+	if a.From != "2024-01-10" || a.To != "2024-01-10" {
+		t.Errorf("period = %s..%s, want 2024-01-10..2024-01-10", a.From, a.To)
+	}
+
+	want := map[string]string{
+		"plays":     StatusSpike,
+		"likes":     StatusDrop,
+		"listeners": StatusOK,
+		"downloads": StatusOK,
+	}
+	for _, m := range a.Metrics {
+		if m.Status != want[m.Metric] {
+			t.Errorf("%s: status = %s, want %s (change %.1f%%)", m.Metric, m.Status, want[m.Metric], m.ChangePercent)
+		}
+	}
+	if a.Triggered != 2 {
+		t.Errorf("Triggered = %d, want 2", a.Triggered)
+	}
+}
+
+func TestDetectAnomalies_Weekly(t *testing.T) {
+	end := time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)
+	daily := make(map[string]int)
+	// Two baseline weeks of 1 play/day, current week of 2 plays/day.
+	for i := 0; i < 21; i++ {
+		v := 1
+		if i < 7 {
+			v = 2
+		}
+		daily[end.AddDate(0, 0, -i).Format(DateLayout)] = v
+	}
+
+	a := DetectAnomalies([]MetricSeries{{Metric: "plays", Daily: daily}}, end, 7, 2, 50)
+
+	m := a.Metrics[0]
+	if m.Current != 14 || m.Baseline != 7 {
+		t.Errorf("current/baseline = %d/%v, want 14/7", m.Current, m.Baseline)
+	}
+	if m.Status != StatusSpike || a.From != "2024-01-08" {
+		t.Errorf("status = %s from %s, want spike from 2024-01-08", m.Status, a.From)
+	}
+}
@@ -0,0 +1,91 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Audit issue codes, one per kind of episode inconsistency.
+const (
+	IssueMissingDescription = "missing_description"
+	IssueMissingTags        = "missing_tags"
+	IssueNotExplicit        = "not_explicit"
+	IssueZeroDuration       = "zero_duration"
+	IssueEncodingFailed     = "encoding_failed"
+	IssueMissingImage       = "missing_image"
+)
+
+// AuditIssue is a single problem found on an episode.
+// Fixable issues can be corrected without user input (see shows audit --fix).
+type AuditIssue struct {
+	EpisodeID int    `json:"episode_id"`
+	Title     string `json:"title"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Fixable   bool   `json:"fixable"`
+	Fixed     bool   `json:"fixed"`
+}
+
+// ShowAudit is the result of checking every episode of a show.
+type ShowAudit struct {
+	ShowID   int          `json:"show_id"`
+	Title    string       `json:"title"`
+	Episodes int          `json:"episodes"`
+	Issues   []AuditIssue `json:"issues"`
+}
+
+// AuditShow checks a show's episodes for missing metadata and failed
+// encodings. Episodes of an explicit show that are not flagged explicit
+// are reported as fixable; the reverse is allowed, since a clean show may
+// have the odd explicit episode. Issues are listed in episode order.
+func AuditShow(show *models.Show, episodes []models.Episode) *ShowAudit {
+	a := &ShowAudit{
+		ShowID:   show.ShowID,
+		Title:    show.Title,
+		Episodes: len(episodes),
+		Issues:   []AuditIssue{},
+	}
+
+	for _, e := range episodes {
+		add := func(code, message string, fixable bool) {
+			a.Issues = append(a.Issues, AuditIssue{
+				EpisodeID: e.EpisodeID,
+				Title:     e.Title,
+				Code:      code,
+				Message:   message,
+				Fixable:   fixable,
+			})
+		}
+
+		if strings.TrimSpace(e.Description) == "" {
+			add(IssueMissingDescription, "Missing description", false)
+		}
+		if len(e.Tags) == 0 {
+			add(IssueMissingTags, "Missing tags", false)
+		}
+		if show.Explicit && !e.Explicit {
+			add(IssueNotExplicit, "Not marked explicit but the show is", true)
+		}
+		if isEncodingFailed(e.EncodingStatus) {
+			add(IssueEncodingFailed, "Audio encoding failed", false)
+		} else if e.Duration == 0 && !isEncodingPending(e.EncodingStatus) {
+			add(IssueZeroDuration, "Zero duration", false)
+		}
+		if e.ImageURL == "" {
+			add(IssueMissingImage, "Missing image", false)
+		}
+	}
+
+	return a
+}
+
+func isEncodingFailed(status string) bool {
+	s := strings.ToLower(status)
+	return strings.Contains(s, "fail") || strings.Contains(s, "error")
+}
+
+func isEncodingPending(status string) bool {
+	s := strings.ToLower(status)
+	return strings.Contains(s, "process") || strings.Contains(s, "pending") || strings.Contains(s, "queue")
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestAuditShow(t *testing.T) {
+	show := &models.Show{ShowID: 1, Title: "Show", Explicit: true}
+	complete := models.Episode{
+		EpisodeID:   10,
+		Description: "notes",
+		Tags:        []string{"news"},
+		Explicit:    true,
+		Duration:    60000,
+		ImageURL:    "https://example.com/a.jpg",
+	}
+
+	bare := models.Episode{EpisodeID: 11, Description: "  "}
+	failed := complete
+	failed.EpisodeID, failed.Duration, failed.EncodingStatus = 12, 0, "FAILED"
+	processing := complete
+	processing.EpisodeID, processing.Duration, processing.EncodingStatus = 13, 0, "PROCESSING"
+
+	a := AuditShow(show, []models.Episode{complete, bare, failed, processing})
+
+	type key struct {
+		id   int
+		code string
+	}
+	got := make(map[key]AuditIssue)
+	for _, i := range a.Issues {
+		got[key{i.EpisodeID, i.Code}] = i
+	}
+
+	want := []key{
+		{11, IssueMissingDescription},
+		{11, IssueMissingTags},
+		{11, IssueNotExplicit},
+		{11, IssueZeroDuration},
+		{11, IssueMissingImage},
+		{12, IssueEncodingFailed},
+	}
+	if len(a.Issues) != len(want) {
+		t.Errorf("got %d issues, want %d: %+v", len(a.Issues), len(want), a.Issues)
+	}
+	for _, k := range want {
+		if _, ok := got[k]; !ok {
+			t.Errorf("missing issue %s on episode %d", k.code, k.id)
+		}
+	}
+	if !got[key{11, IssueNotExplicit}].Fixable {
+		t.Error("not_explicit should be fixable")
+	}
+	if got[key{11, IssueMissingTags}].Fixable {
+		t.Error("missing_tags should not be fixable")
+	}
+}
+
+func TestAuditShow_CleanShowAllowsExplicitEpisodes(t *testing.T) {
+	show := &models.Show{ShowID: 1}
+	ep := models.Episode{EpisodeID: 1, Description: "d", Tags: []string{"t"}, Explicit: true, Duration: 1, ImageURL: "i"}
+
+	if a := AuditShow(show, []models.Episode{ep}); len(a.Issues) != 0 {
+		t.Errorf("got issues %+v, want none", a.Issues)
+	}
+}
@@ -0,0 +1,81 @@
+package report
+
+import "time"
+
+// Campaign is a named date window as listed by stats campaigns. Days is 0
+// when the window is invalid.
+type Campaign struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	Days int    `json:"days"`
+}
+
+// CampaignMetric compares one metric over a campaign with the window before it.
+type CampaignMetric struct {
+	Metric        string  `json:"metric"`
+	Campaign      int     `json:"campaign"`
+	Previous      int     `json:"previous"`
+	ChangePercent float64 `json:"change_percent"`
+}
+
+// CampaignComparison benchmarks a campaign window against the preceding
+// window of equal length.
+type CampaignComparison struct {
+	Campaign     string           `json:"campaign"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	PreviousFrom string           `json:"previous_from"`
+	PreviousTo   string           `json:"previous_to"`
+	Metrics      []CampaignMetric `json:"metrics"`
+}
+
+// PreviousWindow returns the window of as many days as from..to (inclusive)
+// that ends the day before from.
+func PreviousWindow(from, to time.Time) (time.Time, time.Time) {
+	days := int(to.Sub(from).Hours()/24+0.5) + 1
+	return from.AddDate(0, 0, -days), from.AddDate(0, 0, -1)
+}
+
+// CompareCampaign sums each metric over the campaign and the previous
+// window. current and previous hold the same metrics in the same order, as
+// fetched for each window, so every value counts whatever the grouping. A
+// metric that was zero before the campaign and is not during it counts as
+// a 100% change.
+func CompareCampaign(name string, from, to, previousFrom, previousTo time.Time, current, previous []MetricSeries) *CampaignComparison {
+	c := &CampaignComparison{
+		Campaign:     name,
+		From:         from.Format(DateLayout),
+		To:           to.Format(DateLayout),
+		PreviousFrom: previousFrom.Format(DateLayout),
+		PreviousTo:   previousTo.Format(DateLayout),
+		Metrics:      make([]CampaignMetric, 0, len(current)),
+	}
+
+	for i, s := range current {
+		m := CampaignMetric{
+			Metric:   s.Metric,
+			Campaign: sumAll(s.Daily),
+		}
+		if i < len(previous) {
+			m.Previous = sumAll(previous[i].Daily)
+		}
+		switch {
+		case m.Previous > 0:
+			m.ChangePercent = float64(m.Campaign-m.Previous) / float64(m.Previous) * 100
+		case m.Campaign > 0:
+			m.ChangePercent = 100
+		}
+		c.Metrics = append(c.Metrics, m)
+	}
+
+	return c
+}
+
+func sumAll(values map[string]int) int {
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
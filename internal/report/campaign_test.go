@@ -0,0 +1,61 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousWindow(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	prevFrom, prevTo := PreviousWindow(from, to)
+	if got := prevFrom.Format(DateLayout); got != "2024-02-20" {
+		t.Errorf("previous from = %s, want 2024-02-20", got)
+	}
+	if got := prevTo.Format(DateLayout); got != "2024-02-29" {
+		t.Errorf("previous to = %s, want 2024-02-29", got)
+	}
+
+	prevFrom, prevTo = PreviousWindow(from, from)
+	if prevFrom != prevTo || prevTo.Format(DateLayout) != "2024-02-29" {
+		t.Errorf("previous window of one day = %s..%s, want 2024-02-29", prevFrom.Format(DateLayout), prevTo.Format(DateLayout))
+	}
+}
+
+func TestCompareCampaign(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	prevFrom, prevTo := PreviousWindow(from, to)
+
+	current := []MetricSeries{
+		{Metric: "plays", Daily: map[string]int{"2024-03-01": 30, "2024-03-02": 30}},
+		{Metric: "likes", Daily: map[string]int{"2024-03-01": 2}},
+		{Metric: "downloads", Daily: map[string]int{}},
+	}
+	previous := []MetricSeries{
+		{Metric: "plays", Daily: map[string]int{"2024-02-28": 20, "2024-02-29": 20}},
+		{Metric: "likes", Daily: map[string]int{}},
+		{Metric: "downloads", Daily: map[string]int{}},
+	}
+
+	c := CompareCampaign("launch", from, to, prevFrom, prevTo, current, previous)
+
+	if c.Campaign != "launch" || c.PreviousFrom != "2024-02-28" || c.PreviousTo != "2024-02-29" {
+		t.Errorf("comparison = %+v", c)
+	}
+
+	want := []CampaignMetric{
+		{Metric: "plays", Campaign: 60, Previous: 40, ChangePercent: 50},
+		{Metric: "likes", Campaign: 2, Previous: 0, ChangePercent: 100},
+		{Metric: "downloads", Campaign: 0, Previous: 0, ChangePercent: 0},
+	}
+	if len(c.Metrics) != len(want) {
+		t.Fatalf("metrics = %+v, want %d", c.Metrics, len(want))
+	}
+	for i, m := range c.Metrics {
+		if m != want[i] {
+			t.Errorf("metric %d = %+v, want %+v", i, m, want[i])
+		}
+	}
+}
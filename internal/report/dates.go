@@ -0,0 +1,55 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDate parses a statistics date in loc: "YYYY-MM-DD", "today",
+// "yesterday" or "Nd" (N days before today). It returns midnight of that
+// calendar day in loc.
+func ParseDate(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch v {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	if n, ok := strings.CutSuffix(v, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days < 0 {
+			return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, today, yesterday or Nd", value)
+		}
+		return today.AddDate(0, 0, -days), nil
+	}
+
+	d, err := time.ParseInLocation(DateLayout, v, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, today, yesterday or Nd", value)
+	}
+	return d, nil
+}
+
+// APIDay returns the statistics day to request for the calendar day of d
+// in d's location. The API buckets statistics by UTC day, so a local day
+// maps to the UTC day it overlaps most: the one containing local noon.
+func APIDay(d time.Time) string {
+	noon := time.Date(d.Year(), d.Month(), d.Day(), 12, 0, 0, 0, d.Location())
+	return noon.UTC().Format(DateLayout)
+}
+
+// UTCOffset formats the offset of loc at t, e.g. "UTC+02:00".
+func UTCOffset(t time.Time, loc *time.Location) string {
+	_, offset := t.In(loc).Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
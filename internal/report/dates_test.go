@@ -0,0 +1,85 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	// 00:30 on 16 Oct in Rome is still 15 Oct in UTC.
+	now := time.Date(2024, 10, 15, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		loc     *time.Location
+		want    string
+		wantErr bool
+	}{
+		{"2024-01-31", rome, "2024-01-31", false},
+		{"today", rome, "2024-10-16", false},
+		{"yesterday", rome, "2024-10-15", false},
+		{"yesterday", time.UTC, "2024-10-14", false},
+		{"7d", rome, "2024-10-09", false},
+		{" Today ", rome, "2024-10-16", false},
+		{"0d", rome, "2024-10-16", false},
+		{"-1d", rome, "", true},
+		{"31/01/2024", rome, "", true},
+		{"soon", rome, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.loc.String(), func(t *testing.T) {
+			got, err := ParseDate(tt.value, now, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Format(DateLayout) != tt.want {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.value, got.Format(DateLayout), tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIDay(t *testing.T) {
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{"UTC", "2024-03-10"},
+		{"Europe/Rome", "2024-03-10"},
+		{"America/Los_Angeles", "2024-03-10"},
+		{"Pacific/Kiritimati", "2024-03-09"}, // UTC+14: local noon is the previous UTC day
+		{"Pacific/Pago_Pago", "2024-03-10"},  // UTC-11
+	}
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+		d := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+		if got := APIDay(d); got != tt.want {
+			t.Errorf("APIDay(2024-03-10 in %s) = %s, want %s", tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestUTCOffset(t *testing.T) {
+	at := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		loc  *time.Location
+		want string
+	}{
+		{time.UTC, "UTC+00:00"},
+		{time.FixedZone("", 2*3600), "UTC+02:00"},
+		{time.FixedZone("", -(9*3600 + 30*60)), "UTC-09:30"},
+	}
+	for _, tt := range tests {
+		if got := UTCOffset(at, tt.loc); got != tt.want {
+			t.Errorf("UTCOffset = %s, want %s", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// DecayMilestones are the days since publish reported by BuildDecay.
+var DecayMilestones = []int{1, 7, 30}
+
+// DecayMilestone is the plays of an episode's first Day days, counting the
+// publish day as day 1. Reached is false while the episode is younger than
+// Day days, when Plays is still growing.
+type DecayMilestone struct {
+	Day     int     `json:"day"`
+	Plays   int     `json:"plays"`
+	Percent float64 `json:"percent_of_lifetime"`
+	Reached bool    `json:"reached"`
+}
+
+// EpisodeDecay is how an episode's plays accrued since it was published.
+type EpisodeDecay struct {
+	EpisodeID     int              `json:"episode_id"`
+	Title         string           `json:"title"`
+	PublishedAt   string           `json:"published_at"`
+	DaysLive      int              `json:"days_live"`
+	LifetimePlays int              `json:"lifetime_plays"`
+	Milestones    []DecayMilestone `json:"milestones"`
+}
+
+// BuildDecay sums the daily plays of an episode, from its publish day
+// through today, at each of DecayMilestones. Days are UTC days, as the API
+// buckets statistics by UTC day.
+func BuildDecay(episode *models.Episode, published, today time.Time, stats []models.PlayStatistics) EpisodeDecay {
+	start := utcDay(published)
+	d := EpisodeDecay{
+		EpisodeID:   episode.EpisodeID,
+		Title:       episode.Title,
+		PublishedAt: start.Format(DateLayout),
+		DaysLive:    int(utcDay(today).Sub(start).Hours()/24) + 1,
+		Milestones:  make([]DecayMilestone, len(DecayMilestones)),
+	}
+
+	plays, _ := PlaysSeries(stats)
+	for date, n := range plays.Daily {
+		day, err := time.Parse(DateLayout, date)
+		if err != nil || day.Before(start) {
+			continue
+		}
+		d.LifetimePlays += n
+		age := int(day.Sub(start).Hours()/24) + 1
+		for i, milestone := range DecayMilestones {
+			if age <= milestone {
+				d.Milestones[i].Plays += n
+			}
+		}
+	}
+
+	for i, milestone := range DecayMilestones {
+		m := &d.Milestones[i]
+		m.Day = milestone
+		m.Reached = d.DaysLive >= milestone
+		m.Percent = PerPlay(m.Plays, d.LifetimePlays)
+	}
+	return d
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
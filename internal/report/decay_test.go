@@ -0,0 +1,55 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildDecay(t *testing.T) {
+	episode := &models.Episode{EpisodeID: 7, Title: "Pilot"}
+	published := time.Date(2024, 3, 1, 18, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	stats := []models.PlayStatistics{
+		{Date: statsDay("2024-02-29"), PlaysCount: 99}, // before publish: ignored
+		{Date: statsDay("2024-03-01"), PlaysCount: 40},
+		{Date: statsDay("2024-03-02"), PlaysCount: 20},
+		{Date: statsDay("2024-03-07"), PlaysCount: 20},
+		{Date: statsDay("2024-03-08"), PlaysCount: 10},
+		{Date: statsDay("2024-03-20 00:00:00"), PlaysCount: 10},
+	}
+
+	d := BuildDecay(episode, published, today, stats)
+
+	if d.PublishedAt != "2024-03-01" || d.DaysLive != 20 || d.LifetimePlays != 100 {
+		t.Errorf("decay = %+v, want published 2024-03-01, 20 days live, 100 plays", d)
+	}
+
+	want := []DecayMilestone{
+		{Day: 1, Plays: 40, Percent: 40, Reached: true},
+		{Day: 7, Plays: 80, Percent: 80, Reached: true},
+		{Day: 30, Plays: 100, Percent: 100, Reached: false},
+	}
+	if len(d.Milestones) != len(want) {
+		t.Fatalf("milestones = %+v", d.Milestones)
+	}
+	for i, m := range d.Milestones {
+		if m != want[i] {
+			t.Errorf("milestone %d = %+v, want %+v", i, m, want[i])
+		}
+	}
+}
+
+func TestBuildDecay_NoPlays(t *testing.T) {
+	published := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	d := BuildDecay(&models.Episode{}, published, published, nil)
+
+	if d.DaysLive != 1 || d.LifetimePlays != 0 {
+		t.Errorf("decay = %+v, want 1 day live and no plays", d)
+	}
+	if m := d.Milestones[0]; !m.Reached || m.Percent != 0 {
+		t.Errorf("day 1 = %+v, want reached with 0%%", m)
+	}
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// EpisodeEngagement joins an episode's play totals with its likes over the
+// same period.
+type EpisodeEngagement struct {
+	EpisodeID      int     `json:"episode_id"`
+	Title          string  `json:"title"`
+	PlaysCount     int     `json:"plays_count"`
+	DownloadsCount int     `json:"downloads_count"`
+	LikesCount     int     `json:"likes_count"`
+	LikesPerPlay   float64 `json:"likes_per_play"` // percent; 0 without plays
+}
+
+// Engagement summarizes plays and likes per episode across a show.
+type Engagement struct {
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	PlaysCount int                 `json:"plays_count"`
+	LikesCount int                 `json:"likes_count"`
+	Episodes   []EpisodeEngagement `json:"episodes"`
+}
+
+// BuildEngagement joins play totals with each episode's daily likes, keyed
+// by episode ID. Episodes are ranked by plays, then likes, descending;
+// ties keep the order of totals.
+func BuildEngagement(totals []models.EpisodePlayTotals, likes map[int][]models.LikesStatistics) *Engagement {
+	r := &Engagement{Episodes: make([]EpisodeEngagement, 0, len(totals))}
+
+	for _, t := range totals {
+		e := EpisodeEngagement{
+			EpisodeID:      t.EpisodeID,
+			Title:          t.Title,
+			PlaysCount:     t.PlaysCount,
+			DownloadsCount: t.DownloadsCount,
+		}
+		for _, day := range likes[t.EpisodeID] {
+			e.LikesCount += day.LikesCount
+		}
+		e.LikesPerPlay = PerPlay(e.LikesCount, e.PlaysCount)
+
+		r.Episodes = append(r.Episodes, e)
+		r.PlaysCount += e.PlaysCount
+		r.LikesCount += e.LikesCount
+	}
+
+	sort.SliceStable(r.Episodes, func(i, j int) bool {
+		a, b := r.Episodes[i], r.Episodes[j]
+		if a.PlaysCount != b.PlaysCount {
+			return a.PlaysCount > b.PlaysCount
+		}
+		return a.LikesCount > b.LikesCount
+	})
+
+	return r
+}
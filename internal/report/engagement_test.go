@@ -0,0 +1,42 @@
+package report
+
+import (
+	"math"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildEngagement(t *testing.T) {
+	totals := []models.EpisodePlayTotals{
+		{EpisodeID: 1, Title: "Quiet", PlaysCount: 10, DownloadsCount: 2},
+		{EpisodeID: 2, Title: "Popular", PlaysCount: 200, DownloadsCount: 50},
+		{EpisodeID: 3, Title: "Tied, more likes", PlaysCount: 10},
+		{EpisodeID: 4, Title: "Unplayed"},
+	}
+	likes := map[int][]models.LikesStatistics{
+		1: {{Date: statsDay("2024-01-01"), LikesCount: 1}},
+		2: {{Date: statsDay("2024-01-01"), LikesCount: 4}, {Date: statsDay("2024-01-02"), LikesCount: 6}},
+		3: {{Date: statsDay("2024-01-02"), LikesCount: 3}},
+		4: {{Date: statsDay("2024-01-02"), LikesCount: 1}},
+	}
+
+	r := BuildEngagement(totals, likes)
+
+	if r.PlaysCount != 220 || r.LikesCount != 15 {
+		t.Errorf("totals = %d plays, %d likes; want 220, 15", r.PlaysCount, r.LikesCount)
+	}
+
+	wantOrder := []int{2, 3, 1, 4}
+	for i, id := range wantOrder {
+		if r.Episodes[i].EpisodeID != id {
+			t.Errorf("rank %d: episode %d, want %d", i+1, r.Episodes[i].EpisodeID, id)
+		}
+	}
+	if got := r.Episodes[0].LikesPerPlay; math.Abs(got-5) > 1e-9 {
+		t.Errorf("LikesPerPlay = %v, want 5", got)
+	}
+	if got := r.Episodes[3].LikesPerPlay; got != 0 {
+		t.Errorf("LikesPerPlay without plays = %v, want 0", got)
+	}
+}
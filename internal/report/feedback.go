@@ -0,0 +1,175 @@
+package report
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Message sentiments, as classified by Sentiment.
+const (
+	SentimentPositive = "positive"
+	SentimentNegative = "negative"
+	SentimentNeutral  = "neutral"
+)
+
+// Spreaker has no ratings or reviews, so listener sentiment is estimated
+// from message text with a small English and Italian word list.
+var (
+	positiveWords = wordSet(
+		"amazing", "awesome", "best", "brilliant", "enjoy", "enjoyed", "excellent",
+		"fantastic", "favorite", "favourite", "good", "great", "helpful", "interesting",
+		"love", "loved", "nice", "perfect", "thanks", "thank", "wonderful",
+		"bello", "bella", "bravo", "brava", "bravi", "complimenti", "fantastico",
+		"grazie", "interessante", "ottimo", "ottima", "piaciuto", "stupendo",
+	)
+	negativeWords = wordSet(
+		"annoying", "awful", "bad", "boring", "broken", "disappointed", "disappointing",
+		"hate", "horrible", "poor", "terrible", "unfollow", "unsubscribe", "waste",
+		"worst", "wrong",
+		"brutto", "brutta", "deludente", "noioso", "noiosa", "pessimo", "pessima",
+		"peccato", "sbagliato",
+	)
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// Sentiment classifies a message by counting positive and negative words.
+// Ties, including messages with neither, are neutral.
+func Sentiment(text string) string {
+	score := 0
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		switch {
+		case positiveWords[w]:
+			score++
+		case negativeWords[w]:
+			score--
+		}
+	}
+
+	switch {
+	case score > 0:
+		return SentimentPositive
+	case score < 0:
+		return SentimentNegative
+	default:
+		return SentimentNeutral
+	}
+}
+
+// SentimentCounts is the number of messages of each sentiment.
+type SentimentCounts struct {
+	Positive int `json:"positive"`
+	Negative int `json:"negative"`
+	Neutral  int `json:"neutral"`
+}
+
+func (c *SentimentCounts) add(sentiment string) {
+	switch sentiment {
+	case SentimentPositive:
+		c.Positive++
+	case SentimentNegative:
+		c.Negative++
+	default:
+		c.Neutral++
+	}
+}
+
+// EpisodeFeedback is the listener feedback on a single episode.
+type EpisodeFeedback struct {
+	EpisodeID     int             `json:"episode_id"`
+	Title         string          `json:"title"`
+	LikesCount    int             `json:"likes_count"`
+	MessagesCount int             `json:"messages_count"`
+	Sentiment     SentimentCounts `json:"sentiment"`
+}
+
+// FeedbackMessage is a listener message with its estimated sentiment.
+type FeedbackMessage struct {
+	EpisodeID int    `json:"episode_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+	Sentiment string `json:"sentiment"`
+}
+
+// ShowFeedback aggregates likes and messages across a show's episodes.
+type ShowFeedback struct {
+	ShowID        int               `json:"show_id"`
+	Title         string            `json:"title"`
+	LikesCount    int               `json:"likes_count"`
+	MessagesCount int               `json:"messages_count"`
+	Sentiment     SentimentCounts   `json:"sentiment"`
+	Episodes      []EpisodeFeedback `json:"episodes"`
+	Recent        []FeedbackMessage `json:"recent_messages"`
+}
+
+// BuildFeedback joins episode like and message counts with the messages
+// fetched per episode. Messages written by the show's owner are replies,
+// not feedback, and are skipped. Episodes are ranked by likes, descending,
+// and only the latest recent listener messages are kept, newest first.
+func BuildFeedback(show *models.Show, episodes []models.Episode, messages map[int][]models.Message, recent int) *ShowFeedback {
+	r := &ShowFeedback{
+		ShowID:   show.ShowID,
+		Title:    show.Title,
+		Episodes: make([]EpisodeFeedback, 0, len(episodes)),
+		Recent:   []FeedbackMessage{},
+	}
+
+	var all []FeedbackMessage
+	for _, e := range episodes {
+		ef := EpisodeFeedback{
+			EpisodeID:     e.EpisodeID,
+			Title:         e.Title,
+			LikesCount:    e.LikesCount,
+			MessagesCount: e.MessagesCount,
+		}
+
+		for _, m := range messages[e.EpisodeID] {
+			if m.AuthorIsOwner {
+				continue
+			}
+			s := Sentiment(m.Text)
+			ef.Sentiment.add(s)
+			r.Sentiment.add(s)
+			all = append(all, FeedbackMessage{
+				EpisodeID: e.EpisodeID,
+				Author:    m.AuthorFullname,
+				Text:      m.Text,
+				CreatedAt: m.CreatedAt.Format(time.DateTime),
+				Sentiment: s,
+			})
+		}
+
+		r.LikesCount += ef.LikesCount
+		r.MessagesCount += ef.MessagesCount
+		r.Episodes = append(r.Episodes, ef)
+	}
+
+	sort.SliceStable(r.Episodes, func(i, j int) bool {
+		return r.Episodes[i].LikesCount > r.Episodes[j].LikesCount
+	})
+
+	// created_at is "2006-01-02 15:04:05", so it sorts as a string.
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].CreatedAt > all[j].CreatedAt
+	})
+	if len(all) > recent {
+		all = all[:recent]
+	}
+	r.Recent = append(r.Recent, all...)
+
+	return r
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestSentiment(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Great episode, thanks!", SentimentPositive},
+		{"Boring. Worst one so far", SentimentNegative},
+		{"When is the next one?", SentimentNeutral},
+		{"Good guest but bad audio", SentimentNeutral},
+		{"Complimenti, puntata bellissima e interessante", SentimentPositive},
+		{"Che peccato, audio pessimo", SentimentNegative},
+		{"", SentimentNeutral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Sentiment(tt.text); got != tt.want {
+				t.Errorf("Sentiment(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFeedback(t *testing.T) {
+	show := &models.Show{ShowID: 1, Title: "My Show"}
+	episodes := []models.Episode{
+		{EpisodeID: 10, Title: "First", LikesCount: 2, MessagesCount: 2},
+		{EpisodeID: 20, Title: "Second", LikesCount: 5, MessagesCount: 2},
+	}
+	messages := map[int][]models.Message{
+		10: {
+			{Text: "Loved it", CreatedAt: apiTime("2024-01-01 10:00:00"), AuthorFullname: "Ann"},
+			{Text: "Thanks!", CreatedAt: apiTime("2024-01-02 10:00:00"), AuthorIsOwner: true},
+		},
+		20: {
+			{Text: "Terrible audio", CreatedAt: apiTime("2024-02-01 10:00:00"), AuthorFullname: "Bob"},
+			{Text: "Where can I find the notes?", CreatedAt: apiTime("2024-01-15 10:00:00"), AuthorFullname: "Cy"},
+		},
+	}
+
+	r := BuildFeedback(show, episodes, messages, 2)
+
+	if r.LikesCount != 7 || r.MessagesCount != 4 {
+		t.Errorf("totals = %d likes, %d messages; want 7, 4", r.LikesCount, r.MessagesCount)
+	}
+	if want := (SentimentCounts{Positive: 1, Negative: 1, Neutral: 1}); r.Sentiment != want {
+		t.Errorf("Sentiment = %+v, want %+v (owner replies skipped)", r.Sentiment, want)
+	}
+	if r.Episodes[0].EpisodeID != 20 {
+		t.Errorf("first episode = %d, want most liked (20)", r.Episodes[0].EpisodeID)
+	}
+	if len(r.Recent) != 2 || r.Recent[0].Author != "Bob" || r.Recent[1].Author != "Cy" {
+		t.Errorf("Recent = %+v, want Bob then Cy", r.Recent)
+	}
+}
+
+func TestBuildFeedback_NoMessages(t *testing.T) {
+	r := BuildFeedback(&models.Show{ShowID: 1}, nil, nil, 5)
+	if r.Episodes == nil || r.Recent == nil {
+		t.Error("Episodes and Recent should be empty slices, not nil")
+	}
+}
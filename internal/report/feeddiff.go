@@ -0,0 +1,124 @@
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/rss"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Feed difference kinds.
+const (
+	FeedMissingOnSpreaker = "missing_on_spreaker"
+	FeedMissingInFeed     = "missing_in_feed"
+	FeedPublished         = "published"
+	FeedDuration          = "duration"
+)
+
+// FeedDifference is an episode that is missing on one side, or whose
+// publish date or duration differs between the show and the feed.
+type FeedDifference struct {
+	Kind      string `json:"kind"`
+	EpisodeID int    `json:"episode_id,omitempty"` // 0 for episodes missing on Spreaker
+	Title     string `json:"title"`
+	Spreaker  string `json:"spreaker,omitempty"`
+	Feed      string `json:"feed,omitempty"`
+}
+
+// FeedDiff is the result of comparing a show with an external feed.
+type FeedDiff struct {
+	ShowID      int              `json:"show_id"`
+	Title       string           `json:"title"`
+	FeedURL     string           `json:"feed_url"`
+	FeedTitle   string           `json:"feed_title"`
+	Episodes    int              `json:"episodes"`
+	FeedItems   int              `json:"feed_items"`
+	Matched     int              `json:"matched"`
+	Differences []FeedDifference `json:"differences"`
+}
+
+// FeedTolerance is how far the publish date and duration of a matched
+// episode may differ before it is reported.
+type FeedTolerance struct {
+	Published time.Duration
+	Duration  time.Duration
+}
+
+// CompareFeed matches a show's episodes with the items of a feed by title,
+// ignoring case and spacing, and lists the differences: episodes of the
+// show first, in show order, then feed items missing on Spreaker. Dates
+// and durations only missing on one side are not compared.
+func CompareFeed(show *models.Show, episodes []models.Episode, feedURL string, feed *rss.Feed, tol FeedTolerance) *FeedDiff {
+	d := &FeedDiff{
+		ShowID:      show.ShowID,
+		Title:       show.Title,
+		FeedURL:     feedURL,
+		FeedTitle:   feed.Title,
+		Episodes:    len(episodes),
+		FeedItems:   len(feed.Items),
+		Differences: []FeedDifference{},
+	}
+
+	// Items by title, in feed order, so episodes sharing a title are
+	// matched in turn.
+	byTitle := make(map[string][]int)
+	for i, item := range feed.Items {
+		key := titleKey(item.Title)
+		byTitle[key] = append(byTitle[key], i)
+	}
+	matched := make([]bool, len(feed.Items))
+
+	for _, e := range episodes {
+		key := titleKey(e.Title)
+		if len(byTitle[key]) == 0 {
+			d.Differences = append(d.Differences, FeedDifference{Kind: FeedMissingInFeed, EpisodeID: e.EpisodeID, Title: e.Title})
+			continue
+		}
+		i := byTitle[key][0]
+		byTitle[key] = byTitle[key][1:]
+		matched[i] = true
+		d.Matched++
+
+		item := feed.Items[i]
+		if e.PublishedAt != nil && !item.Published.IsZero() && absDuration(e.PublishedAt.Sub(item.Published)) > tol.Published {
+			d.Differences = append(d.Differences, FeedDifference{
+				Kind: FeedPublished, EpisodeID: e.EpisodeID, Title: e.Title,
+				Spreaker: e.PublishedAt.UTC().Format(time.DateTime),
+				Feed:     item.Published.UTC().Format(time.DateTime),
+			})
+		}
+		if e.Duration > 0 && item.Duration > 0 && absDuration(time.Duration(e.Duration-item.Duration)*time.Millisecond) > tol.Duration {
+			d.Differences = append(d.Differences, FeedDifference{
+				Kind: FeedDuration, EpisodeID: e.EpisodeID, Title: e.Title,
+				Spreaker: msDuration(e.Duration),
+				Feed:     msDuration(item.Duration),
+			})
+		}
+	}
+
+	for i, item := range feed.Items {
+		if !matched[i] {
+			d.Differences = append(d.Differences, FeedDifference{Kind: FeedMissingOnSpreaker, Title: item.Title})
+		}
+	}
+	return d
+}
+
+// titleKey is the form titles are matched in: lower case, with runs of
+// spaces collapsed.
+func titleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// msDuration renders milliseconds as a duration to the second, like "30m34s".
+func msDuration(ms int) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/rss"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestCompareFeed(t *testing.T) {
+	at := func(s string) *models.CustomTime {
+		ct, err := models.ParseTime(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ct
+	}
+	date := func(s string) time.Time {
+		return at(s).Time
+	}
+
+	show := &models.Show{ShowID: 1, Title: "Show"}
+	episodes := []models.Episode{
+		{EpisodeID: 10, Title: "Episode 1", PublishedAt: at("2024-03-01 06:00:00"), Duration: 60000},
+		{EpisodeID: 11, Title: "Episode  2", PublishedAt: at("2024-03-08 06:00:00"), Duration: 60000},
+		{EpisodeID: 12, Title: "Bonus", PublishedAt: at("2024-03-09 06:00:00")},
+		{EpisodeID: 13, Title: "Draft"},
+	}
+	feed := &rss.Feed{Title: "Mirror", Items: []rss.Item{
+		{Title: "episode 2", Published: date("2024-03-10 06:00:00"), Duration: 61000},
+		{Title: "Episode 1", Published: date("2024-03-01 07:00:00"), Duration: 90000},
+		{Title: "Bonus", Duration: 5000},
+		{Title: "Only upstream"},
+	}}
+
+	d := CompareFeed(show, episodes, "https://example.com/feed", feed, FeedTolerance{Published: 24 * time.Hour, Duration: 5 * time.Second})
+
+	want := []FeedDifference{
+		{Kind: FeedDuration, EpisodeID: 10, Title: "Episode 1", Spreaker: "1m0s", Feed: "1m30s"},
+		{Kind: FeedPublished, EpisodeID: 11, Title: "Episode  2", Spreaker: "2024-03-08 06:00:00", Feed: "2024-03-10 06:00:00"},
+		{Kind: FeedMissingInFeed, EpisodeID: 13, Title: "Draft"},
+		{Kind: FeedMissingOnSpreaker, Title: "Only upstream"},
+	}
+	if !reflect.DeepEqual(d.Differences, want) {
+		t.Errorf("Differences = %+v\nwant %+v", d.Differences, want)
+	}
+	if d.Matched != 3 || d.Episodes != 4 || d.FeedItems != 4 || d.FeedTitle != "Mirror" {
+		t.Errorf("CompareFeed() = %+v", d)
+	}
+}
+
+func TestCompareFeedDuplicateTitles(t *testing.T) {
+	show := &models.Show{ShowID: 1}
+	episodes := []models.Episode{{EpisodeID: 1, Title: "Trailer"}, {EpisodeID: 2, Title: "Trailer"}}
+	feed := &rss.Feed{Items: []rss.Item{{Title: "Trailer"}}}
+
+	d := CompareFeed(show, episodes, "", feed, FeedTolerance{})
+	if d.Matched != 1 || len(d.Differences) != 1 || d.Differences[0].EpisodeID != 2 {
+		t.Errorf("CompareFeed() = %+v, want episode 2 missing in feed", d)
+	}
+}
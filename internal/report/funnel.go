@@ -0,0 +1,63 @@
+package report
+
+import (
+	"math"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Share is one entry of a breakdown: its share of plays and the plays it
+// accounts for.
+type Share struct {
+	Name       string  `json:"name"`
+	Percentage float64 `json:"percentage"`
+	PlaysCount int     `json:"plays_count"`
+
+	// Estimated is set when the API only reported the share, so the plays
+	// are the share of the total.
+	Estimated bool `json:"estimated,omitempty"`
+}
+
+// Funnel combines where a show's plays come from, on which devices and in
+// which countries over the same period. The API reports each breakdown on
+// its own, so they cannot be crossed.
+type Funnel struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	PlaysCount int    `json:"plays_count"`
+
+	Sources   []Share `json:"sources"`
+	Devices   []Share `json:"devices"`
+	Countries []Share `json:"countries"`
+}
+
+// BuildFunnel combines the sources, devices and geographic breakdowns of
+// the same period. Total plays are those of the sources breakdown, the
+// only one with absolute counts for every entry.
+func BuildFunnel(sources *models.SourcesStatistics, devices []models.DeviceStatistics, geo *models.GeographicStatistics) *Funnel {
+	f := &Funnel{}
+
+	for _, s := range sources.Overall {
+		f.PlaysCount += s.PlaysCount
+	}
+	for _, s := range sources.Overall {
+		f.Sources = append(f.Sources, Share{Name: s.Name, Percentage: float64(s.Percentage), PlaysCount: s.PlaysCount})
+	}
+	for _, d := range devices {
+		f.Devices = append(f.Devices, Share{Name: d.Name, Percentage: d.Percentage, PlaysCount: shareOf(f.PlaysCount, d.Percentage), Estimated: true})
+	}
+	for _, c := range geo.Country {
+		share := Share{Name: c.Name, Percentage: c.Percentage, PlaysCount: c.PlaysCount}
+		if c.PlaysCount == 0 {
+			share.PlaysCount, share.Estimated = shareOf(f.PlaysCount, c.Percentage), true
+		}
+		f.Countries = append(f.Countries, share)
+	}
+
+	return f
+}
+
+// shareOf returns percentage percent of plays, rounded.
+func shareOf(plays int, percentage float64) int {
+	return int(math.Round(float64(plays) * percentage / 100))
+}
@@ -0,0 +1,34 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildFunnel(t *testing.T) {
+	sources := &models.SourcesStatistics{Overall: []models.SourceOverall{
+		{Name: "Apple Podcasts", PlaysCount: 600, Percentage: 60},
+		{Name: "Spreaker", PlaysCount: 400, Percentage: 40},
+	}}
+	devices := []models.DeviceStatistics{{Name: "Mobile", Percentage: 75}, {Name: "Desktop", Percentage: 25}}
+	geo := &models.GeographicStatistics{Country: []models.GeoStatistics{
+		{Name: "Italy", Percentage: 80, PlaysCount: 790},
+		{Name: "Spain", Percentage: 20},
+	}}
+
+	f := BuildFunnel(sources, devices, geo)
+
+	if f.PlaysCount != 1000 {
+		t.Errorf("PlaysCount = %d, want 1000", f.PlaysCount)
+	}
+	if d := f.Devices[0]; d.PlaysCount != 750 || !d.Estimated {
+		t.Errorf("mobile = %+v, want 750 estimated plays", d)
+	}
+	if c := f.Countries[0]; c.PlaysCount != 790 || c.Estimated {
+		t.Errorf("Italy = %+v, want the reported 790 plays", c)
+	}
+	if c := f.Countries[1]; c.PlaysCount != 200 || !c.Estimated {
+		t.Errorf("Spain = %+v, want 200 estimated plays", c)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Package report builds derived reports by joining data from several API
+endpoints (e.g. statistics with episode metadata).
+
+Report functions are pure: they take already-fetched models and return
+computed results, so they can be tested without an API client.
+*/
+package report
+
+import (
+	"sort"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// EpisodeListeningTime is the estimated listening time of a single episode.
+type EpisodeListeningTime struct {
+	EpisodeID      int     `json:"episode_id"`
+	Title          string  `json:"title"`
+	PlaysCount     int     `json:"plays_count"`
+	Duration       int     `json:"duration"` // milliseconds
+	ListeningHours float64 `json:"listening_hours"`
+}
+
+// ListeningTime is an estimate of total hours listened across a show.
+type ListeningTime struct {
+	From           string                 `json:"from"`
+	To             string                 `json:"to"`
+	Completion     float64                `json:"completion"`
+	PlaysCount     int                    `json:"plays_count"`
+	ListeningHours float64                `json:"listening_hours"`
+	Episodes       []EpisodeListeningTime `json:"episodes"`
+}
+
+// BuildListeningTime estimates listening hours as plays × duration × completion,
+// where completion is the assumed average fraction of an episode listened to
+// (1.0 = every play listened to the end). Episodes are ranked by hours, descending.
+// Episodes with no known duration (e.g. deleted ones) count as zero hours.
+func BuildListeningTime(totals []models.EpisodePlayTotals, episodes []models.Episode, completion float64) *ListeningTime {
+	durations := make(map[int]int, len(episodes))
+	for _, e := range episodes {
+		durations[e.EpisodeID] = e.Duration
+	}
+
+	r := &ListeningTime{
+		Completion: completion,
+		Episodes:   make([]EpisodeListeningTime, 0, len(totals)),
+	}
+
+	for _, t := range totals {
+		duration := durations[t.EpisodeID]
+		hours := float64(t.PlaysCount) * float64(duration) / 3_600_000 * completion
+
+		r.Episodes = append(r.Episodes, EpisodeListeningTime{
+			EpisodeID:      t.EpisodeID,
+			Title:          t.Title,
+			PlaysCount:     t.PlaysCount,
+			Duration:       duration,
+			ListeningHours: hours,
+		})
+		r.PlaysCount += t.PlaysCount
+		r.ListeningHours += hours
+	}
+
+	sort.SliceStable(r.Episodes, func(i, j int) bool {
+		return r.Episodes[i].ListeningHours > r.Episodes[j].ListeningHours
+	})
+
+	return r
+}
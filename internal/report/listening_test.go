@@ -0,0 +1,49 @@
+package report
+
+import (
+	"math"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildListeningTime(t *testing.T) {
+	totals := []models.EpisodePlayTotals{
+		{EpisodeID: 1, Title: "Short", PlaysCount: 10},
+		{EpisodeID: 2, Title: "Long", PlaysCount: 4},
+		{EpisodeID: 3, Title: "Deleted", PlaysCount: 100},
+	}
+	episodes := []models.Episode{
+		{EpisodeID: 1, Duration: 30 * 60 * 1000},     // 30 min
+		{EpisodeID: 2, Duration: 2 * 60 * 60 * 1000}, // 2 h
+	}
+
+	r := BuildListeningTime(totals, episodes, 1.0)
+
+	if r.PlaysCount != 114 {
+		t.Errorf("PlaysCount = %d, want 114", r.PlaysCount)
+	}
+	if math.Abs(r.ListeningHours-13) > 1e-9 {
+		t.Errorf("ListeningHours = %v, want 13", r.ListeningHours)
+	}
+
+	wantOrder := []int{2, 1, 3}
+	for i, id := range wantOrder {
+		if r.Episodes[i].EpisodeID != id {
+			t.Errorf("rank %d: episode %d, want %d", i+1, r.Episodes[i].EpisodeID, id)
+		}
+	}
+	if r.Episodes[2].ListeningHours != 0 {
+		t.Errorf("episode without duration should have 0 hours, got %v", r.Episodes[2].ListeningHours)
+	}
+}
+
+func TestBuildListeningTime_Completion(t *testing.T) {
+	totals := []models.EpisodePlayTotals{{EpisodeID: 1, PlaysCount: 2}}
+	episodes := []models.Episode{{EpisodeID: 1, Duration: 60 * 60 * 1000}}
+
+	r := BuildListeningTime(totals, episodes, 0.5)
+	if math.Abs(r.ListeningHours-1) > 1e-9 {
+		t.Errorf("ListeningHours = %v, want 1", r.ListeningHours)
+	}
+}
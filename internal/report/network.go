@@ -0,0 +1,75 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// NetworkShow is one show of a network and its statistics over a window.
+type NetworkShow struct {
+	ShowID    int    `json:"show_id"`
+	Title     string `json:"title"`
+	Episodes  int    `json:"episodes_count"`
+	Followers int    `json:"followers_count"`
+	Plays     int    `json:"plays"`
+	Downloads int    `json:"downloads"`
+	Likes     int    `json:"likes"`
+
+	// Share is the percentage of the network's plays in the window.
+	Share float64 `json:"plays_share"`
+}
+
+// NetworkStats is the statistics of a set of shows, busiest show first,
+// with their total.
+type NetworkStats struct {
+	From  string        `json:"from"`
+	To    string        `json:"to"`
+	Shows []NetworkShow `json:"shows"`
+	Total NetworkShow   `json:"total"`
+}
+
+// NewNetworkShow sums the daily plays and likes of show.
+func NewNetworkShow(show models.Show, plays []models.PlayStatistics, likes []models.LikesStatistics) NetworkShow {
+	s := NetworkShow{
+		ShowID:    show.ShowID,
+		Title:     show.Title,
+		Episodes:  show.EpisodesCount,
+		Followers: show.FollowersCount,
+	}
+	for _, p := range plays {
+		s.Plays += p.PlaysCount
+		s.Downloads += p.DownloadsCount
+	}
+	for _, l := range likes {
+		s.Likes += l.LikesCount
+	}
+	return s
+}
+
+// BuildNetworkStats totals shows and sets each show's share of the plays.
+// Shows are sorted by plays, then by title.
+func BuildNetworkStats(from, to string, shows []NetworkShow) NetworkStats {
+	stats := NetworkStats{From: from, To: to, Shows: append([]NetworkShow{}, shows...)}
+	for _, s := range shows {
+		stats.Total.Episodes += s.Episodes
+		stats.Total.Followers += s.Followers
+		stats.Total.Plays += s.Plays
+		stats.Total.Downloads += s.Downloads
+		stats.Total.Likes += s.Likes
+	}
+	if stats.Total.Plays > 0 {
+		stats.Total.Share = 100
+		for i := range stats.Shows {
+			stats.Shows[i].Share = float64(stats.Shows[i].Plays) * 100 / float64(stats.Total.Plays)
+		}
+	}
+
+	sort.SliceStable(stats.Shows, func(i, j int) bool {
+		if stats.Shows[i].Plays != stats.Shows[j].Plays {
+			return stats.Shows[i].Plays > stats.Shows[j].Plays
+		}
+		return stats.Shows[i].Title < stats.Shows[j].Title
+	})
+	return stats
+}
@@ -0,0 +1,32 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildNetworkStats(t *testing.T) {
+	a := NewNetworkShow(models.Show{ShowID: 1, Title: "A", EpisodesCount: 10, FollowersCount: 5},
+		[]models.PlayStatistics{{PlaysCount: 10, DownloadsCount: 2}, {PlaysCount: 15, DownloadsCount: 1}},
+		[]models.LikesStatistics{{LikesCount: 3}})
+	b := NewNetworkShow(models.Show{ShowID: 2, Title: "B", EpisodesCount: 4}, []models.PlayStatistics{{PlaysCount: 75}}, nil)
+	c := NewNetworkShow(models.Show{ShowID: 3, Title: "C"}, nil, nil)
+
+	stats := BuildNetworkStats("2024-01-01", "2024-01-31", []NetworkShow{a, c, b})
+
+	if len(stats.Shows) != 3 || stats.Shows[0].ShowID != 2 || stats.Shows[1].ShowID != 1 || stats.Shows[2].ShowID != 3 {
+		t.Fatalf("shows = %+v", stats.Shows)
+	}
+	if s := stats.Shows[1]; s.Plays != 25 || s.Downloads != 3 || s.Likes != 3 || s.Share != 25 {
+		t.Errorf("show A = %+v", s)
+	}
+	want := NetworkShow{Episodes: 14, Followers: 5, Plays: 100, Downloads: 3, Likes: 3, Share: 100}
+	if stats.Total != want {
+		t.Errorf("total = %+v, want %+v", stats.Total, want)
+	}
+
+	if empty := BuildNetworkStats("", "", []NetworkShow{c}); empty.Total.Share != 0 || empty.Shows[0].Share != 0 {
+		t.Errorf("stats without plays = %+v", empty)
+	}
+}
@@ -0,0 +1,46 @@
+package report
+
+import "github.com/G10xy/spreaker-and-go/pkg/models"
+
+// Ratios are engagement metrics relative to plays, so episodes and shows
+// with audiences of different sizes can be compared. All are 0 without plays.
+type Ratios struct {
+	LikesPerPlay     float64  `json:"likes_per_play_percent"`
+	DownloadsPerPlay float64  `json:"downloads_per_play_percent"`
+	MessagesPer1k    *float64 `json:"messages_per_1k_plays,omitempty"` // only where messages are counted
+}
+
+// PerPlay returns count as a percentage of plays, or 0 without plays.
+func PerPlay(count, plays int) float64 {
+	if plays <= 0 {
+		return 0
+	}
+	return float64(count) / float64(plays) * 100
+}
+
+// UserRatios computes the engagement ratios of a user's overall statistics.
+func UserRatios(s *models.UserOverallStatistics) Ratios {
+	return Ratios{
+		LikesPerPlay:     PerPlay(s.LikesCount, s.PlaysCount),
+		DownloadsPerPlay: PerPlay(s.DownloadsCount, s.PlaysCount),
+	}
+}
+
+// ShowRatios computes the engagement ratios of a show's overall statistics.
+func ShowRatios(s *models.ShowOverallStatistics) Ratios {
+	return Ratios{
+		LikesPerPlay:     PerPlay(s.LikesCount, s.PlaysCount),
+		DownloadsPerPlay: PerPlay(s.DownloadsCount, s.PlaysCount),
+	}
+}
+
+// EpisodeRatios computes the engagement ratios of an episode's overall
+// statistics, including messages per thousand plays.
+func EpisodeRatios(s *models.EpisodeOverallStatistics) Ratios {
+	messages := PerPlay(s.MessagesCount, s.PlaysCount) * 10
+	return Ratios{
+		LikesPerPlay:     PerPlay(s.LikesCount, s.PlaysCount),
+		DownloadsPerPlay: PerPlay(s.DownloadsCount, s.PlaysCount),
+		MessagesPer1k:    &messages,
+	}
+}
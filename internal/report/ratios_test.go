@@ -0,0 +1,37 @@
+package report
+
+import (
+	"math"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestEpisodeRatios(t *testing.T) {
+	r := EpisodeRatios(&models.EpisodeOverallStatistics{
+		PlaysCount:     2000,
+		LikesCount:     50,
+		DownloadsCount: 500,
+		MessagesCount:  3,
+	})
+
+	if math.Abs(r.LikesPerPlay-2.5) > 1e-9 {
+		t.Errorf("LikesPerPlay = %v, want 2.5", r.LikesPerPlay)
+	}
+	if math.Abs(r.DownloadsPerPlay-25) > 1e-9 {
+		t.Errorf("DownloadsPerPlay = %v, want 25", r.DownloadsPerPlay)
+	}
+	if r.MessagesPer1k == nil || math.Abs(*r.MessagesPer1k-1.5) > 1e-9 {
+		t.Errorf("MessagesPer1k = %v, want 1.5", r.MessagesPer1k)
+	}
+}
+
+func TestShowRatios(t *testing.T) {
+	r := ShowRatios(&models.ShowOverallStatistics{LikesCount: 4, DownloadsCount: 2})
+	if r.LikesPerPlay != 0 || r.DownloadsPerPlay != 0 {
+		t.Errorf("ratios without plays = %+v, want zeros", r)
+	}
+	if r.MessagesPer1k != nil {
+		t.Errorf("MessagesPer1k = %v, want none for shows", *r.MessagesPer1k)
+	}
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// TagSorts are the orders BuildTagStats can rank tags by.
+var TagSorts = []string{"average", "plays", "count", "tag"}
+
+// TagStat is how the episodes with one tag performed.
+type TagStat struct {
+	Tag           string  `json:"tag"`
+	EpisodesCount int     `json:"episodes_count"`
+	PlaysCount    int     `json:"plays_count"`
+	AveragePlays  float64 `json:"average_plays"`
+}
+
+// TagStats aggregates the tags of a catalog's episodes over a period.
+type TagStats struct {
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	EpisodesCount int       `json:"episodes_count"`
+	UntaggedCount int       `json:"untagged_count"`
+	Tags          []TagStat `json:"tags"`
+}
+
+// BuildTagStats counts, for each tag, the episodes using it and their
+// plays, given by episode ID. Tags differing only in case or surrounding
+// spaces are the same tag, shown as first spelled. Tags used by fewer than
+// minCount episodes are left out. Tags are ranked by sortBy, one of
+// TagSorts, with ties broken by name.
+func BuildTagStats(episodes []models.Episode, plays map[int]int, minCount int, sortBy string) (*TagStats, error) {
+	less, err := tagOrder(sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TagStats{EpisodesCount: len(episodes)}
+	byKey := make(map[string]*TagStat)
+	var order []string
+	for _, e := range episodes {
+		seen := make(map[string]bool)
+		for _, tag := range e.Tags {
+			tag = strings.TrimSpace(tag)
+			key := strings.ToLower(tag)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			s, ok := byKey[key]
+			if !ok {
+				s = &TagStat{Tag: tag}
+				byKey[key] = s
+				order = append(order, key)
+			}
+			s.EpisodesCount++
+			s.PlaysCount += plays[e.EpisodeID]
+		}
+		if len(seen) == 0 {
+			r.UntaggedCount++
+		}
+	}
+
+	r.Tags = []TagStat{}
+	for _, key := range order {
+		s := byKey[key]
+		if s.EpisodesCount < minCount {
+			continue
+		}
+		s.AveragePlays = float64(s.PlaysCount) / float64(s.EpisodesCount)
+		r.Tags = append(r.Tags, *s)
+	}
+	sort.SliceStable(r.Tags, func(i, j int) bool {
+		a, b := r.Tags[i], r.Tags[j]
+		if less(a, b) != less(b, a) {
+			return less(a, b)
+		}
+		return strings.ToLower(a.Tag) < strings.ToLower(b.Tag)
+	})
+
+	return r, nil
+}
+
+// tagOrder returns the ranking of sortBy: tags first by name, the others
+// highest first.
+func tagOrder(sortBy string) (func(a, b TagStat) bool, error) {
+	switch sortBy {
+	case "average":
+		return func(a, b TagStat) bool { return a.AveragePlays > b.AveragePlays }, nil
+	case "plays":
+		return func(a, b TagStat) bool { return a.PlaysCount > b.PlaysCount }, nil
+	case "count":
+		return func(a, b TagStat) bool { return a.EpisodesCount > b.EpisodesCount }, nil
+	case "tag":
+		return func(a, b TagStat) bool { return strings.ToLower(a.Tag) < strings.ToLower(b.Tag) }, nil
+	}
+	return nil, fmt.Errorf("invalid sort %q: must be one of %s", sortBy, strings.Join(TagSorts, ", "))
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestBuildTagStats(t *testing.T) {
+	episodes := []models.Episode{
+		{EpisodeID: 1, Tags: []string{"Tech", "news"}},
+		{EpisodeID: 2, Tags: []string{"tech ", "TECH"}},
+		{EpisodeID: 3, Tags: []string{"news"}},
+		{EpisodeID: 4},
+	}
+	plays := map[int]int{1: 100, 2: 50, 3: 10, 4: 999}
+
+	r, err := BuildTagStats(episodes, plays, 1, "average")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.EpisodesCount != 4 || r.UntaggedCount != 1 || len(r.Tags) != 2 {
+		t.Fatalf("stats = %+v", r)
+	}
+	if got := r.Tags[0]; got.Tag != "Tech" || got.EpisodesCount != 2 || got.PlaysCount != 150 || got.AveragePlays != 75 {
+		t.Errorf("tags[0] = %+v", got)
+	}
+	if got := r.Tags[1]; got.Tag != "news" || got.PlaysCount != 110 || got.AveragePlays != 55 {
+		t.Errorf("tags[1] = %+v", got)
+	}
+
+	r, _ = BuildTagStats(episodes, plays, 1, "tag")
+	if r.Tags[0].Tag != "news" {
+		t.Errorf("sorted by tag = %+v", r.Tags)
+	}
+
+	r, _ = BuildTagStats(episodes, plays, 3, "count")
+	if len(r.Tags) != 0 {
+		t.Errorf("min count 3 = %+v", r.Tags)
+	}
+
+	if _, err := BuildTagStats(episodes, plays, 1, "random"); err == nil {
+		t.Error("invalid sort accepted")
+	}
+}
@@ -0,0 +1,199 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Digest formats.
+const (
+	DigestMarkdown = "md"
+	DigestHTML     = "html"
+)
+
+// Limits of the lists in a weekly digest.
+const (
+	digestTopEpisodes = 5
+	digestMessages    = 10
+)
+
+// WeeklyDigest summarizes a show's last seven complete days for email.
+type WeeklyDigest struct {
+	ShowID        int             `json:"show_id"`
+	ShowTitle     string          `json:"show_title"`
+	From          string          `json:"from"`
+	To            string          `json:"to"`
+	NewFollowers  int             `json:"new_followers"`
+	Plays         int             `json:"plays"`
+	PreviousPlays int             `json:"previous_plays"`
+	ChangePercent float64         `json:"change_percent"`
+	TopEpisodes   []DigestEpisode `json:"top_episodes"`
+	Messages      []DigestMessage `json:"messages"`
+}
+
+// DigestEpisode is an episode ranked by its plays during the week.
+type DigestEpisode struct {
+	EpisodeID int    `json:"episode_id"`
+	Title     string `json:"title"`
+	Plays     int    `json:"plays"`
+}
+
+// DigestMessage is a listener message posted during the week.
+type DigestMessage struct {
+	EpisodeTitle string `json:"episode_title"`
+	Author       string `json:"author"`
+	Text         string `json:"text"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// WeekWindows returns the last seven complete UTC days before now, and the
+// seven days before them.
+func WeekWindows(now time.Time) (from, to, previousFrom, previousTo time.Time) {
+	to = utcDay(now).AddDate(0, 0, -1)
+	from = to.AddDate(0, 0, -6)
+	previousFrom, previousTo = PreviousWindow(from, to)
+	return from, to, previousFrom, previousTo
+}
+
+// BuildWeeklyDigest builds the digest of the week from..to. plays covers
+// both that week and the one before it; followers and totals cover the
+// week. messages may hold older messages, which are left out; titles maps
+// their episode IDs to titles.
+func BuildWeeklyDigest(show *models.Show, from, to time.Time, plays []models.PlayStatistics, followers []models.FollowersStatistics, totals []models.EpisodePlayTotals, messages []models.Message, titles map[int]string) *WeeklyDigest {
+	d := &WeeklyDigest{
+		ShowID:      show.ShowID,
+		ShowTitle:   show.Title,
+		From:        from.Format(DateLayout),
+		To:          to.Format(DateLayout),
+		TopEpisodes: []DigestEpisode{},
+		Messages:    []DigestMessage{},
+	}
+
+	for _, f := range followers {
+		d.NewFollowers += f.FollowersCount
+	}
+
+	for _, p := range plays {
+		day := p.Date.Time
+		if day.IsZero() || day.After(to) {
+			continue
+		}
+		if day.Before(from) {
+			d.PreviousPlays += p.PlaysCount
+		} else {
+			d.Plays += p.PlaysCount
+		}
+	}
+	switch {
+	case d.PreviousPlays > 0:
+		d.ChangePercent = float64(d.Plays-d.PreviousPlays) / float64(d.PreviousPlays) * 100
+	case d.Plays > 0:
+		d.ChangePercent = 100
+	}
+
+	ranked := append([]models.EpisodePlayTotals(nil), totals...)
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].PlaysCount > ranked[j].PlaysCount })
+	for _, t := range ranked {
+		if t.PlaysCount == 0 || len(d.TopEpisodes) == digestTopEpisodes {
+			break
+		}
+		d.TopEpisodes = append(d.TopEpisodes, DigestEpisode{EpisodeID: t.EpisodeID, Title: t.Title, Plays: t.PlaysCount})
+	}
+
+	end := to.AddDate(0, 0, 1)
+	recent := make([]models.Message, 0, len(messages))
+	for _, m := range messages {
+		if !m.CreatedAt.Before(from) && m.CreatedAt.Before(end) {
+			recent = append(recent, m)
+		}
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].CreatedAt.After(recent[j].CreatedAt.Time) })
+	for _, m := range recent {
+		if len(d.Messages) == digestMessages {
+			break
+		}
+		author := m.AuthorFullname
+		if author == "" {
+			author = m.AuthorUsername
+		}
+		d.Messages = append(d.Messages, DigestMessage{
+			EpisodeTitle: titles[m.EpisodeID],
+			Author:       author,
+			Text:         m.Text,
+			CreatedAt:    m.CreatedAt.Format(time.DateTime),
+		})
+	}
+
+	return d
+}
+
+// Subject is the email subject of the digest.
+func (d *WeeklyDigest) Subject() string {
+	return fmt.Sprintf("%s: weekly report %s to %s", d.ShowTitle, d.From, d.To)
+}
+
+// Render formats the digest as Markdown or HTML.
+func (d *WeeklyDigest) Render(format string) (string, error) {
+	switch format {
+	case DigestMarkdown:
+		return d.markdown(), nil
+	case DigestHTML:
+		var buf bytes.Buffer
+		if err := digestHTML.Execute(&buf, d); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("invalid format %q (must be %s or %s)", format, DigestMarkdown, DigestHTML)
+	}
+}
+
+func (d *WeeklyDigest) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Subject())
+	fmt.Fprintf(&b, "- **Plays:** %d (%+.1f%% vs %d the week before)\n", d.Plays, d.ChangePercent, d.PreviousPlays)
+	fmt.Fprintf(&b, "- **New followers:** %d\n", d.NewFollowers)
+
+	b.WriteString("\n## Top episodes\n\n")
+	if len(d.TopEpisodes) == 0 {
+		b.WriteString("No plays this week.\n")
+	}
+	for i, e := range d.TopEpisodes {
+		fmt.Fprintf(&b, "%d. %s: %d plays\n", i+1, e.Title, e.Plays)
+	}
+
+	b.WriteString("\n## Recent messages\n\n")
+	if len(d.Messages) == 0 {
+		b.WriteString("No new messages this week.\n")
+	}
+	for _, m := range d.Messages {
+		fmt.Fprintf(&b, "> %s\n>\n> %s on %s, %s\n\n", strings.ReplaceAll(m.Text, "\n", "\n> "), m.Author, m.EpisodeTitle, m.CreatedAt)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+var digestHTML = template.Must(template.New("weekly").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body style="font-family: sans-serif">
+<h1>{{.Subject}}</h1>
+<ul>
+<li><strong>Plays:</strong> {{.Plays}} ({{printf "%+.1f" .ChangePercent}}% vs {{.PreviousPlays}} the week before)</li>
+<li><strong>New followers:</strong> {{.NewFollowers}}</li>
+</ul>
+<h2>Top episodes</h2>
+{{if .TopEpisodes}}<ol>
+{{range .TopEpisodes}}<li>{{.Title}}: {{.Plays}} plays</li>
+{{end}}</ol>{{else}}<p>No plays this week.</p>{{end}}
+<h2>Recent messages</h2>
+{{range .Messages}}<blockquote><p>{{.Text}}</p><footer>{{.Author}} on {{.EpisodeTitle}}, {{.CreatedAt}}</footer></blockquote>
+{{else}}<p>No new messages this week.</p>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestWeekWindows(t *testing.T) {
+	from, to, prevFrom, prevTo := WeekWindows(time.Date(2024, 3, 11, 7, 0, 0, 0, time.UTC))
+
+	got := []string{from.Format(DateLayout), to.Format(DateLayout), prevFrom.Format(DateLayout), prevTo.Format(DateLayout)}
+	want := []string{"2024-03-04", "2024-03-10", "2024-02-26", "2024-03-03"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("windows = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestBuildWeeklyDigest(t *testing.T) {
+	from, to, _, _ := WeekWindows(time.Date(2024, 3, 11, 7, 0, 0, 0, time.UTC))
+	show := &models.Show{ShowID: 12, Title: "Tech Talk"}
+
+	plays := []models.PlayStatistics{
+		{Date: statsDay("2024-02-28"), PlaysCount: 40},
+		{Date: statsDay("2024-03-04"), PlaysCount: 30},
+		{Date: statsDay("2024-03-10"), PlaysCount: 30},
+		{Date: statsDay("2024-03-11"), PlaysCount: 99}, // today: incomplete, left out
+	}
+	followers := []models.FollowersStatistics{{Date: statsDay("2024-03-05"), FollowersCount: 2}, {Date: statsDay("2024-03-06"), FollowersCount: 1}}
+	totals := []models.EpisodePlayTotals{
+		{EpisodeID: 1, Title: "Old", PlaysCount: 5},
+		{EpisodeID: 2, Title: "New", PlaysCount: 50},
+		{EpisodeID: 3, Title: "Silent", PlaysCount: 0},
+	}
+	messages := []models.Message{
+		{EpisodeID: 2, Text: "Great one", AuthorFullname: "Ada", CreatedAt: apiTime("2024-03-09 10:00:00")},
+		{EpisodeID: 2, Text: "Too old", AuthorUsername: "bob", CreatedAt: apiTime("2024-03-01 10:00:00")},
+		{EpisodeID: 1, Text: "Thanks", AuthorUsername: "carol", CreatedAt: apiTime("2024-03-10 23:59:59")},
+	}
+	titles := map[int]string{1: "Old", 2: "New"}
+
+	d := BuildWeeklyDigest(show, from, to, plays, followers, totals, messages, titles)
+
+	if d.Plays != 60 || d.PreviousPlays != 40 || d.ChangePercent != 50 || d.NewFollowers != 3 {
+		t.Errorf("digest = %+v", d)
+	}
+	if len(d.TopEpisodes) != 2 || d.TopEpisodes[0].Title != "New" {
+		t.Errorf("top episodes = %+v, want New then Old", d.TopEpisodes)
+	}
+	if len(d.Messages) != 2 || d.Messages[0].Author != "carol" || d.Messages[1].EpisodeTitle != "New" {
+		t.Errorf("messages = %+v, want carol's then Ada's", d.Messages)
+	}
+
+	md, err := d.Render(DigestMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Tech Talk: weekly report 2024-03-04 to 2024-03-10", "**Plays:** 60 (+50.0% vs 40", "1. New: 50 plays", "> Great one"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	d.Messages[0].Text = "<script>"
+	html, err := d.Render(DigestHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "&lt;script&gt;") || strings.Contains(html, "<script>") {
+		t.Errorf("html does not escape message text:\n%s", html)
+	}
+
+	if _, err := d.Render("pdf"); err == nil {
+		t.Error("Render(pdf) succeeded")
+	}
+}
+
+// statsDay parses a statistics day for test fixtures.
+func statsDay(s string) models.Date {
+	d, err := models.ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// apiTime parses an API timestamp for test fixtures.
+func apiTime(s string) models.CustomTime {
+	t, err := models.ParseTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,94 @@
+package rss
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Config is a feed.yaml file: the Podcasting 2.0 settings of each show,
+// keyed by show ID.
+//
+//	shows:
+//	  12345:
+//	    persons:
+//	      - {name: Alice Rossi, role: host, href: "https://alice.example.com"}
+//	    funding: {url: "https://ko-fi.com/morningcoffee", text: Support the show}
+//	    transcripts: {dir: ./transcripts, base_url: "https://example.com/transcripts/"}
+//	    audio_base_url: "https://example.com/audio/"
+type Config struct {
+	Shows map[int]ShowConfig `yaml:"shows"`
+}
+
+// ShowConfig holds the feed settings of a show.
+type ShowConfig struct {
+	Persons     []Person          `yaml:"persons"`
+	Funding     *Funding          `yaml:"funding"`
+	Transcripts *TranscriptConfig `yaml:"transcripts"`
+
+	// AudioBaseURL is where a mirror hosts the files saved by episodes
+	// download-all; enclosures then point there instead of at Spreaker.
+	AudioBaseURL string `yaml:"audio_base_url"`
+}
+
+// Person is a podcast:person of the show, such as a host or guest.
+type Person struct {
+	Name  string `yaml:"name"`
+	Role  string `yaml:"role"`
+	Group string `yaml:"group"`
+	Href  string `yaml:"href"`
+	Img   string `yaml:"img"`
+}
+
+// Funding is the podcast:funding link of the show.
+type Funding struct {
+	URL  string `yaml:"url"`
+	Text string `yaml:"text"`
+}
+
+// TranscriptConfig locates transcript sidecar files: Dir is searched for
+// files named after each episode, and BaseURL is where they are served.
+type TranscriptConfig struct {
+	Dir      string `yaml:"dir"`
+	BaseURL  string `yaml:"base_url"`
+	Language string `yaml:"language"`
+}
+
+// LoadConfig reads and validates a feed.yaml file. A relative transcript
+// directory is resolved against the file's directory.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed config: %w", err)
+	}
+
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("invalid feed config %s: %w", path, err)
+	}
+
+	for id, s := range c.Shows {
+		for i, p := range s.Persons {
+			if p.Name == "" {
+				return nil, fmt.Errorf("invalid feed config %s: show %d: person %d has no name", path, id, i+1)
+			}
+		}
+		if s.Funding != nil && s.Funding.URL == "" {
+			return nil, fmt.Errorf("invalid feed config %s: show %d: funding needs a url", path, id)
+		}
+		if t := s.Transcripts; t != nil {
+			if t.Dir == "" || t.BaseURL == "" {
+				return nil, fmt.Errorf("invalid feed config %s: show %d: transcripts need a dir and a base_url", path, id)
+			}
+			if !filepath.IsAbs(t.Dir) {
+				t.Dir = filepath.Join(filepath.Dir(path), t.Dir)
+			}
+		}
+	}
+	return &c, nil
+}
@@ -0,0 +1,138 @@
+/*
+Package rss reads and writes podcast RSS feeds.
+
+Parse reads the channel title and, for each item, the fields needed to
+match it with a Spreaker episode; shows diff-feed uses it to compare a show
+with a feed hosted elsewhere. Write generates a feed of a show with
+Podcasting 2.0 tags configured in a feed.yaml file (see Config), for
+shows export-feed.
+*/
+package rss
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Feed is a parsed podcast feed.
+type Feed struct {
+	Title string
+	Items []Item
+}
+
+// Item is an episode of a feed.
+type Item struct {
+	GUID         string
+	Title        string
+	Link         string
+	Published    time.Time // zero if missing or unparseable
+	Duration     int       // milliseconds, 0 if missing or unparseable
+	EnclosureURL string
+}
+
+type document struct {
+	Channel struct {
+		Title string `xml:"title"`
+		Items []struct {
+			GUID      string `xml:"guid"`
+			Title     string `xml:"title"`
+			Link      string `xml:"link"`
+			PubDate   string `xml:"pubDate"`
+			Duration  string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+			Enclosure struct {
+				URL string `xml:"url,attr"`
+			} `xml:"enclosure"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Parse reads an RSS 2.0 feed.
+func Parse(r io.Reader) (*Feed, error) {
+	var doc document
+	dec := xml.NewDecoder(r)
+	// Feeds are often served as ISO-8859-1 or windows-1252; their titles
+	// are compared, not displayed, so the bytes are read as they are.
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid RSS feed: %w", err)
+	}
+
+	feed := &Feed{Title: strings.TrimSpace(doc.Channel.Title)}
+	for _, it := range doc.Channel.Items {
+		item := Item{
+			GUID:         strings.TrimSpace(it.GUID),
+			Title:        strings.TrimSpace(it.Title),
+			Link:         strings.TrimSpace(it.Link),
+			Duration:     ParseDuration(it.Duration),
+			EnclosureURL: strings.TrimSpace(it.Enclosure.URL),
+		}
+		item.Published, _ = ParseDate(it.PubDate)
+		feed.Items = append(feed.Items, item)
+	}
+	return feed, nil
+}
+
+// Fetch downloads and parses the feed at url.
+func Fetch(ctx context.Context, client *http.Client, url string) (*Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch feed: %s", resp.Status)
+	}
+	return Parse(resp.Body)
+}
+
+// dateLayouts are the pubDate layouts seen in the wild: RFC 822 with a
+// numeric or named zone, with or without the day of the week and seconds.
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	time.RFC3339,
+}
+
+// ParseDate parses an RSS pubDate.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// ParseDuration parses an itunes:duration, given as seconds or as
+// [[HH:]MM:]SS, into milliseconds. Invalid values give 0.
+func ParseDuration(s string) int {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	var seconds float64
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return int(seconds * 1000)
+}
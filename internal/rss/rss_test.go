@@ -0,0 +1,103 @@
+package rss
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>Morning Coffee</title>
+    <item>
+      <guid>ep-2</guid>
+      <title> Episode 2 </title>
+      <pubDate>Fri, 08 Mar 2024 06:00:00 +0000</pubDate>
+      <itunes:duration>30:34</itunes:duration>
+      <enclosure url="https://cdn.example.com/2.mp3" type="audio/mpeg" length="1"/>
+    </item>
+    <item>
+      <title>Episode 1</title>
+      <pubDate>not a date</pubDate>
+      <itunes:duration>1834</itunes:duration>
+    </item>
+  </channel>
+</rss>`
+
+func TestParse(t *testing.T) {
+	feed, err := Parse(strings.NewReader(testFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feed.Title != "Morning Coffee" || len(feed.Items) != 2 {
+		t.Fatalf("Parse() = %q with %d items, want Morning Coffee with 2", feed.Title, len(feed.Items))
+	}
+
+	first := feed.Items[0]
+	if first.GUID != "ep-2" || first.Title != "Episode 2" || first.Duration != 1834000 || first.EnclosureURL != "https://cdn.example.com/2.mp3" {
+		t.Errorf("Items[0] = %+v", first)
+	}
+	if want := time.Date(2024, 3, 8, 6, 0, 0, 0, time.UTC); !first.Published.Equal(want) {
+		t.Errorf("Items[0].Published = %v, want %v", first.Published, want)
+	}
+	if second := feed.Items[1]; !second.Published.IsZero() || second.Duration != 1834000 {
+		t.Errorf("Items[1] = %+v, want no date and 1834000ms", second)
+	}
+
+	if _, err := Parse(strings.NewReader("<html>")); err == nil {
+		t.Error("Parse() of invalid XML: want error")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2024, 3, 8, 6, 0, 0, 0, time.UTC)
+	for _, s := range []string{
+		"Fri, 08 Mar 2024 06:00:00 +0000",
+		"Fri, 8 Mar 2024 07:00:00 +0100",
+		"8 Mar 2024 06:00:00 +0000",
+		"2024-03-08T06:00:00Z",
+	} {
+		got, err := ParseDate(s)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]int{
+		"1834":     1834000,
+		"30:34":    1834000,
+		"01:02:03": 3723000,
+		"":         0,
+		"abc":      0,
+		"-5":       0,
+	}
+	for s, want := range tests {
+		if got := ParseDuration(s); got != want {
+			t.Errorf("ParseDuration(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testFeed))
+	}))
+	defer srv.Close()
+
+	feed, err := Fetch(t.Context(), srv.Client(), srv.URL+"/feed")
+	if err != nil || len(feed.Items) != 2 {
+		t.Fatalf("Fetch() = %+v, %v", feed, err)
+	}
+	if _, err := Fetch(t.Context(), srv.Client(), srv.URL+"/missing"); err == nil {
+		t.Error("Fetch() of a 404: want error")
+	}
+}
@@ -0,0 +1,209 @@
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/filename"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// Feed namespaces.
+const (
+	NamespaceITunes  = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+	NamespacePodcast = "https://podcastindex.org/namespace/1.0"
+)
+
+// encoding/xml does not write namespace prefixes, so the prefixed names
+// below are spelled out and the namespaces declared on the root element.
+
+type outDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	ITunes  string     `xml:"xmlns:itunes,attr"`
+	Podcast string     `xml:"xmlns:podcast,attr"`
+	Channel outChannel `xml:"channel"`
+}
+
+type outChannel struct {
+	Title       string      `xml:"title"`
+	Link        string      `xml:"link"`
+	Description string      `xml:"description"`
+	Language    string      `xml:"language,omitempty"`
+	Author      string      `xml:"itunes:author,omitempty"`
+	Image       *outImage   `xml:"itunes:image"`
+	Explicit    bool        `xml:"itunes:explicit"`
+	Funding     *outFunding `xml:"podcast:funding"`
+	Persons     []outPerson `xml:"podcast:person"`
+	Items       []outItem   `xml:"item"`
+}
+
+type outImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type outFunding struct {
+	URL  string `xml:"url,attr"`
+	Text string `xml:",chardata"`
+}
+
+type outPerson struct {
+	Role  string `xml:"role,attr,omitempty"`
+	Group string `xml:"group,attr,omitempty"`
+	Img   string `xml:"img,attr,omitempty"`
+	Href  string `xml:"href,attr,omitempty"`
+	Name  string `xml:",chardata"`
+}
+
+type outItem struct {
+	Title       string          `xml:"title"`
+	Link        string          `xml:"link"`
+	Description string          `xml:"description"`
+	GUID        outGUID         `xml:"guid"`
+	PubDate     string          `xml:"pubDate"`
+	Enclosure   outEnclosure    `xml:"enclosure"`
+	Duration    int             `xml:"itunes:duration,omitempty"`
+	Explicit    bool            `xml:"itunes:explicit"`
+	Image       *outImage       `xml:"itunes:image"`
+	Transcripts []outTranscript `xml:"podcast:transcript"`
+}
+
+type outGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type outEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+type outTranscript struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"language,attr,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty"`
+}
+
+// transcriptTypes maps transcript file extensions to their MIME type, in
+// the order they are listed in the feed.
+var transcriptTypes = []struct{ ext, mime string }{
+	{".vtt", "text/vtt"},
+	{".srt", "application/x-subrip"},
+	{".json", "application/json"},
+	{".html", "text/html"},
+	{".txt", "text/plain"},
+}
+
+// Write writes an RSS feed of the show's published, visible episodes, in
+// the order given, with the Podcasting 2.0 tags of cfg.
+//
+// Enclosures point at Spreaker, or with cfg.AudioBaseURL at the file names
+// episodes download-all gives the episodes. Transcripts are the files in
+// cfg.Transcripts.Dir named after the episode ID or its audio file, such as
+// 12345.vtt or "Episode 1.srt".
+func Write(w io.Writer, show *models.Show, episodes []models.Episode, cfg ShowConfig) error {
+	ch := outChannel{
+		Title:       show.Title,
+		Link:        show.PageURL(),
+		Description: show.Description,
+		Language:    show.Language,
+		Explicit:    show.Explicit,
+	}
+	if show.Author != nil {
+		ch.Author = show.Author.Fullname
+	}
+	if img := firstNonEmpty(show.ImageOriginalURL, show.ImageURL); img != "" {
+		ch.Image = &outImage{Href: img}
+	}
+	if cfg.Funding != nil {
+		ch.Funding = &outFunding{URL: cfg.Funding.URL, Text: cfg.Funding.Text}
+	}
+	for _, p := range cfg.Persons {
+		ch.Persons = append(ch.Persons, outPerson{Role: p.Role, Group: p.Group, Img: p.Img, Href: p.Href, Name: p.Name})
+	}
+
+	// Audio file names are claimed for every episode, as download-all
+	// does, so that titles shared with hidden episodes number the same.
+	names := filename.NewSet()
+	for _, e := range episodes {
+		audio := filepath.Base(names.Claim("", filename.Sanitize(e.Title, fmt.Sprintf("episode_%d", e.EpisodeID)), ".mp3", nil))
+		if e.Hidden || e.PublishedAt == nil {
+			continue
+		}
+
+		item := outItem{
+			Title:       e.Title,
+			Link:        e.PageURL(),
+			Description: e.Description,
+			GUID:        outGUID{Value: fmt.Sprintf("spreaker-episode-%d", e.EpisodeID)},
+			PubDate:     e.PublishedAt.UTC().Format(time.RFC1123Z),
+			Enclosure:   outEnclosure{URL: e.PublicDownloadURL(), Type: "audio/mpeg"},
+			Duration:    e.Duration / 1000,
+			Explicit:    e.Explicit,
+		}
+		if cfg.AudioBaseURL != "" {
+			item.Enclosure.URL = joinURL(cfg.AudioBaseURL, audio)
+		}
+		if img := firstNonEmpty(e.ImageOriginalURL, e.ImageURL); img != "" {
+			item.Image = &outImage{Href: img}
+		}
+		if t := cfg.Transcripts; t != nil {
+			item.Transcripts = findTranscripts(t, e.EpisodeID, strings.TrimSuffix(audio, ".mp3"))
+		}
+		ch.Items = append(ch.Items, item)
+	}
+
+	doc := outDocument{Version: "2.0", ITunes: NamespaceITunes, Podcast: NamespacePodcast, Channel: ch}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode feed: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// findTranscripts returns the transcripts of an episode found in t.Dir,
+// named after its ID or its audio file.
+func findTranscripts(t *TranscriptConfig, episodeID int, audioName string) []outTranscript {
+	var found []outTranscript
+	for _, typ := range transcriptTypes {
+		for _, base := range []string{fmt.Sprintf("%d", episodeID), audioName} {
+			name := base + typ.ext
+			if !filename.Exists(filepath.Join(t.Dir, name)) {
+				continue
+			}
+			tr := outTranscript{URL: joinURL(t.BaseURL, name), Type: typ.mime, Language: t.Language}
+			if typ.ext == ".vtt" || typ.ext == ".srt" {
+				tr.Rel = "captions"
+			}
+			found = append(found, tr)
+			break
+		}
+	}
+	return found
+}
+
+// joinURL appends an escaped file name to a base URL.
+func joinURL(base, name string) string {
+	return strings.TrimSuffix(base, "/") + "/" + url.PathEscape(name)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package rss
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"10.vtt", "Episode 1.json", "11.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	published, err := models.ParseTime("2024-03-01 06:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	show := &models.Show{ShowID: 1, Title: "Morning Coffee", Author: &models.User{Fullname: "Alice Rossi"}}
+	episodes := []models.Episode{
+		{EpisodeID: 10, Title: "Episode 1", PublishedAt: &published, Duration: 61500},
+		{EpisodeID: 11, Title: "Hidden", PublishedAt: &published, Hidden: true},
+		{EpisodeID: 12, Title: "Draft"},
+	}
+	cfg := ShowConfig{
+		Persons:      []Person{{Name: "Alice Rossi", Role: "host", Href: "https://alice.example.com"}},
+		Funding:      &Funding{URL: "https://ko-fi.com/coffee", Text: "Support the show"},
+		Transcripts:  &TranscriptConfig{Dir: dir, BaseURL: "https://example.com/t/", Language: "en"},
+		AudioBaseURL: "https://example.com/audio",
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, show, episodes, cfg); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		`xmlns:podcast="https://podcastindex.org/namespace/1.0"`,
+		`<itunes:author>Alice Rossi</itunes:author>`,
+		`<podcast:funding url="https://ko-fi.com/coffee">Support the show</podcast:funding>`,
+		`<podcast:person role="host" href="https://alice.example.com">Alice Rossi</podcast:person>`,
+		`<guid isPermaLink="false">spreaker-episode-10</guid>`,
+		`<pubDate>Fri, 01 Mar 2024 06:00:00 +0000</pubDate>`,
+		`<enclosure url="https://example.com/audio/Episode%201.mp3" type="audio/mpeg" length="0"></enclosure>`,
+		`<itunes:duration>61</itunes:duration>`,
+		`<podcast:transcript url="https://example.com/t/10.vtt" type="text/vtt" language="en" rel="captions"></podcast:transcript>`,
+		`<podcast:transcript url="https://example.com/t/Episode%201.json" type="application/json" language="en"></podcast:transcript>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("feed is missing %s\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "<item>"); n != 1 {
+		t.Errorf("feed has %d items, want only the published, visible one", n)
+	}
+
+	// The written feed reads back.
+	feed, err := Parse(strings.NewReader(got))
+	if err != nil || len(feed.Items) != 1 || feed.Items[0].Duration != 61000 {
+		t.Errorf("Parse(Write()) = %+v, %v", feed, err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feed.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`shows:
+  12345:
+    persons:
+      - {name: Alice, role: host}
+    funding: {url: "https://ko-fi.com/x"}
+    transcripts: {dir: transcripts, base_url: "https://example.com/t/"}
+`)
+	c, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := c.Shows[12345]
+	if len(s.Persons) != 1 || s.Funding.URL != "https://ko-fi.com/x" || s.Transcripts.Dir != filepath.Join(dir, "transcripts") {
+		t.Errorf("LoadConfig() = %+v", s)
+	}
+
+	for _, bad := range []string{
+		"shows:\n  1:\n    persons:\n      - {role: host}\n",
+		"shows:\n  1:\n    funding: {text: Thanks}\n",
+		"shows:\n  1:\n    transcripts: {dir: t}\n",
+		"shows:\n  1:\n    unknown: true\n",
+	} {
+		write(bad)
+		if _, err := LoadConfig(path); err == nil {
+			t.Errorf("LoadConfig(%q): want error", bad)
+		}
+	}
+}
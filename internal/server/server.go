@@ -0,0 +1,448 @@
+/*
+Package server exposes a small local REST API that proxies to Spreaker
+using the CLI's stored credentials, so local tools and dashboards can
+read shows, episodes and statistics (and upload episodes) without
+handling OAuth themselves.
+
+Every response is JSON. Errors use the shape {"error": "message"} and
+carry the Spreaker status code when the upstream API rejected the call.
+
+Browser requests (those with an Origin header) are refused unless the
+origin is explicitly allowed, so a web page cannot use the server to act
+on the user's account. Requests for a Host other than localhost, a
+loopback address or an allowed host are refused too, so a web page cannot
+reach the server through DNS rebinding, where its own domain is made to
+resolve to 127.0.0.1 and its requests count as same-origin.
+*/
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// maxUploadSize bounds the size of an uploaded media file (512 MB).
+const maxUploadSize = 512 << 20
+
+// Options configures a Server.
+type Options struct {
+	// AllowedOrigins lists browser origins (e.g. "http://localhost:3000")
+	// permitted to call the API. Requests from other origins are rejected.
+	AllowedOrigins []string
+
+	// AllowedHosts lists host names or addresses, without a port, that
+	// requests may be addressed to besides localhost and loopback
+	// addresses, such as the address the server listens on.
+	AllowedHosts []string
+
+	// OnEpisodeUploaded is called after a successful upload, e.g. to run
+	// the episode_published hook. Optional.
+	OnEpisodeUploaded func(ctx context.Context, episode *models.Episode)
+}
+
+// Server is an http.Handler proxying a subset of the Spreaker API.
+type Server struct {
+	client *api.Client
+	opts   Options
+	mux    *http.ServeMux
+}
+
+// New creates a Server that forwards requests through client.
+func New(client *api.Client, opts Options) *Server {
+	s := &Server{
+		client: client,
+		opts:   opts,
+		mux:    http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("GET /me", s.handleMe)
+
+	s.mux.HandleFunc("GET /shows", s.handleListShows)
+	s.mux.HandleFunc("GET /shows/{id}", s.handleGetShow)
+	s.mux.HandleFunc("GET /shows/{id}/episodes", s.handleListShowEpisodes)
+	s.mux.HandleFunc("POST /shows/{id}/episodes", s.handleUploadEpisode)
+
+	s.mux.HandleFunc("GET /episodes/{id}", s.handleGetEpisode)
+
+	s.mux.HandleFunc("GET /stats/me", s.handleMyStatistics)
+	s.mux.HandleFunc("GET /stats/shows/{id}", s.handleShowStatistics)
+	s.mux.HandleFunc("GET /stats/shows/{id}/plays", s.handleShowPlays)
+	s.mux.HandleFunc("GET /stats/shows/{id}/episodes", s.handleShowEpisodesPlayTotals)
+	s.mux.HandleFunc("GET /stats/episodes/{id}", s.handleEpisodeStatistics)
+	s.mux.HandleFunc("GET /stats/episodes/{id}/plays", s.handleEpisodePlays)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.hostAllowed(r.Host) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !s.originAllowed(origin) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("origin %q is not allowed", origin))
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// hostAllowed reports whether host, the Host header with an optional port,
+// is localhost, a loopback address or one of the allowed hosts.
+func (s *Server) hostAllowed(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	for _, h := range s.opts.AllowedHosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) originAllowed(origin string) bool {
+	for _, o := range s.opts.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// Users and shows
+// -----------------------------------------------------------------------------
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
+	user, err := s.client.GetMe()
+	respond(w, user, err)
+}
+
+func (s *Server) handleListShows(w http.ResponseWriter, r *http.Request) {
+	pagination, err := paginationParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.GetMyShows(pagination)
+	respondPage(w, result, err)
+}
+
+func (s *Server) handleGetShow(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	show, err := s.client.GetShow(showID)
+	respond(w, show, err)
+}
+
+// -----------------------------------------------------------------------------
+// Episodes
+// -----------------------------------------------------------------------------
+
+func (s *Server) handleListShowEpisodes(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	pagination, err := paginationParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.GetShowEpisodes(showID, pagination)
+	respondPage(w, result, err)
+}
+
+func (s *Server) handleGetEpisode(w http.ResponseWriter, r *http.Request) {
+	episodeID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	episode, err := s.client.GetEpisode(episodeID)
+	respond(w, episode, err)
+}
+
+// handleUploadEpisode accepts a multipart form with a "media_file" file part
+// and the same fields as `spreaker episodes upload`: title, description,
+// tags (comma-separated), explicit, download_enabled (default true), hidden,
+// auto_published_at.
+func (s *Server) handleUploadEpisode(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid multipart form: %w", err))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("media_file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.New("media_file is required"))
+		return
+	}
+	defer file.Close()
+
+	// The API client uploads from a path, so stage the file on disk,
+	// keeping its extension for content-type detection.
+	tmpDir, err := os.MkdirTemp("", "spreaker-upload-")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpPath := filepath.Join(tmpDir, "media"+filepath.Ext(filepath.Base(header.Filename)))
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read media_file: %w", err))
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	params := api.UploadEpisodeParams{
+		Title:           r.FormValue("title"),
+		MediaFile:       tmpPath,
+		Description:     r.FormValue("description"),
+		AutoPublishedAt: r.FormValue("auto_published_at"),
+		Explicit:        formBool(r, "explicit", false),
+		DownloadEnabled: formBool(r, "download_enabled", true),
+		Hidden:          formBool(r, "hidden", false),
+	}
+	if tags := r.FormValue("tags"); tags != "" {
+		for _, t := range strings.Split(tags, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				params.Tags = append(params.Tags, t)
+			}
+		}
+	}
+	if params.Title == "" {
+		writeError(w, http.StatusBadRequest, errors.New("title is required"))
+		return
+	}
+
+	episode, err := s.client.UploadEpisode(showID, params)
+	if err != nil {
+		respond(w, nil, err)
+		return
+	}
+
+	if s.opts.OnEpisodeUploaded != nil {
+		s.opts.OnEpisodeUploaded(r.Context(), episode)
+	}
+	writeJSON(w, http.StatusCreated, episode)
+}
+
+// -----------------------------------------------------------------------------
+// Statistics
+// -----------------------------------------------------------------------------
+
+func (s *Server) handleMyStatistics(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.client.GetMyStatistics()
+	respond(w, stats, err)
+}
+
+func (s *Server) handleShowStatistics(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := s.client.GetShowStatistics(showID)
+	respond(w, stats, err)
+}
+
+func (s *Server) handleShowPlays(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := s.client.GetShowPlayStatistics(showID, statisticsParams(r))
+	respond(w, stats, err)
+}
+
+func (s *Server) handleShowEpisodesPlayTotals(w http.ResponseWriter, r *http.Request) {
+	showID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	pagination, err := paginationParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.client.GetShowEpisodesPlayTotals(showID, statisticsParams(r), pagination)
+	respondPage(w, result, err)
+}
+
+func (s *Server) handleEpisodeStatistics(w http.ResponseWriter, r *http.Request) {
+	episodeID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := s.client.GetEpisodeStatistics(episodeID)
+	respond(w, stats, err)
+}
+
+func (s *Server) handleEpisodePlays(w http.ResponseWriter, r *http.Request) {
+	episodeID, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	stats, err := s.client.GetEpisodePlayStatistics(episodeID, statisticsParams(r))
+	respond(w, stats, err)
+}
+
+// -----------------------------------------------------------------------------
+// Request and response helpers
+// -----------------------------------------------------------------------------
+
+// page is the JSON shape of a paginated list. The upstream next_url is not
+// exposed: clients page with ?limit= and ?offset= instead.
+type page[T any] struct {
+	Items   []T  `json:"items"`
+	HasMore bool `json:"has_more"`
+}
+
+func respondPage[T any](w http.ResponseWriter, result *api.PaginatedResult[T], err error) {
+	if err != nil {
+		respond(w, nil, err)
+		return
+	}
+	items := result.Items
+	if items == nil {
+		items = []T{}
+	}
+	writeJSON(w, http.StatusOK, page[T]{Items: items, HasMore: result.HasMore})
+}
+
+// respond writes v as JSON, or maps err to an error response.
+func respond(w http.ResponseWriter, v interface{}, err error) {
+	if err != nil {
+		status := http.StatusBadGateway
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode >= 400 {
+			status = apiErr.StatusCode
+		}
+		writeError(w, status, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func pathID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID: %s", r.PathValue("id"))
+	}
+	return id, nil
+}
+
+func paginationParams(r *http.Request) (api.PaginationParams, error) {
+	var p api.PaginationParams
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > api.MaxPageLimit {
+			return p, fmt.Errorf("invalid limit %q: must be between 1 and %d", v, api.MaxPageLimit)
+		}
+		p.Limit = limit
+	}
+	if v := q.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return p, fmt.Errorf("invalid offset %q", v)
+		}
+		p.Offset = offset
+	}
+	return p, nil
+}
+
+func statisticsParams(r *http.Request) api.StatisticsParams {
+	q := r.URL.Query()
+	return api.StatisticsParams{
+		From:  q.Get("from"),
+		To:    q.Get("to"),
+		Group: q.Get("group"),
+	}
+}
+
+// formBool parses a boolean form field, returning def when it is absent or invalid.
+func formBool(r *http.Request, key string, def bool) bool {
+	v, err := strconv.ParseBool(r.FormValue(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
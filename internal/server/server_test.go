@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/G10xy/spreaker-and-go/internal/api"
+)
+
+// upstream fakes the Spreaker API, recording the last request path.
+func upstream(t *testing.T, lastPath *string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*lastPath = r.Method + " " + r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/shows/404":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"error": map[string]interface{}{"messages": []string{"Show not found"}, "code": 404}},
+			})
+		case strings.HasPrefix(r.URL.Path, "/v2/shows/") && r.Method == http.MethodPost:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"episode": map[string]interface{}{"episode_id": 7, "title": r.FormValue("title")}},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"response": map[string]interface{}{"show": map[string]interface{}{"show_id": 12, "title": "Test Show"}},
+			})
+		}
+	}))
+}
+
+func testServer(t *testing.T, up *httptest.Server, opts Options) *Server {
+	t.Helper()
+	client := api.NewClient("test-token")
+	client.BaseURL = up.URL
+	// The host of requests made by httptest.NewRequest.
+	opts.AllowedHosts = append(opts.AllowedHosts, "example.com")
+	return New(client, opts)
+}
+
+func TestServer_GetShow(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{})
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shows/12", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if lastPath != "GET /v2/shows/12" {
+		t.Errorf("upstream request = %q", lastPath)
+	}
+	var show struct {
+		ShowID int `json:"show_id"`
+	}
+	json.NewDecoder(rec.Body).Decode(&show)
+	if show.ShowID != 12 {
+		t.Errorf("show_id = %d, want 12", show.ShowID)
+	}
+}
+
+func TestServer_UpstreamErrorStatus(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{})
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shows/404", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"error"`) {
+		t.Errorf("body = %s, want error field", rec.Body)
+	}
+}
+
+func TestServer_InvalidID(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{})
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/shows/abc", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+	if lastPath != "" {
+		t.Errorf("upstream should not be called, got %q", lastPath)
+	}
+}
+
+func TestServer_RejectsUnknownHost(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{AllowedHosts: []string{"192.168.1.5"}})
+
+	tests := []struct {
+		host string
+		want int
+	}{
+		{"localhost:8080", http.StatusOK},
+		{"LOCALHOST", http.StatusOK},
+		{"127.0.0.1:8080", http.StatusOK},
+		{"[::1]:8080", http.StatusOK},
+		{"192.168.1.5:8080", http.StatusOK},
+		{"rebind.evil.example:8080", http.StatusForbidden},
+		{"localhost.evil.example", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		lastPath = ""
+		req := httptest.NewRequest(http.MethodGet, "/shows/12", nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("Host %s: status = %d, want %d", tt.host, rec.Code, tt.want)
+		}
+		if tt.want == http.StatusForbidden && lastPath != "" {
+			t.Errorf("Host %s: upstream called with %q", tt.host, lastPath)
+		}
+	}
+}
+
+func TestServer_RejectsUnknownOrigin(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{AllowedOrigins: []string{"http://localhost:3000"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/shows/12", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("unknown origin: status = %d, want 403", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/shows/12", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("allowed origin: status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+}
+
+func TestServer_UploadEpisode(t *testing.T) {
+	var lastPath string
+	up := upstream(t, &lastPath)
+	defer up.Close()
+
+	s := testServer(t, up, Options{})
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("title", "Pilot")
+	fw, _ := mw.CreateFormFile("media_file", "pilot.mp3")
+	fw.Write([]byte("ID3 fake audio"))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/shows/12/episodes", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if lastPath != "POST /v2/shows/12/episodes" {
+		t.Errorf("upstream request = %q", lastPath)
+	}
+	if !strings.Contains(rec.Body.String(), `"title":"Pilot"`) {
+		t.Errorf("body = %s, want uploaded episode", rec.Body)
+	}
+}
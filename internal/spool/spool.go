@@ -0,0 +1,145 @@
+/*
+Package spool keeps requests that change data and could not be sent
+because the API was unreachable, so they can be sent once back online.
+
+Only requests that are safe to send late are queued: messages, favorites,
+likes, bookmarks, follows and blocks, and show, episode and profile
+updates. Uploads and deletions are not.
+*/
+package spool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/internal/journal"
+)
+
+// Flush outcomes of a request.
+const (
+	// StatusSent: the request was sent.
+	StatusSent = "sent"
+	// StatusApplied: an update whose values the resource already has was
+	// not sent again.
+	StatusApplied = "already applied"
+	// StatusConflict: the API rejected the request, e.g. because its
+	// episode was deleted meanwhile. It is dropped from the spool.
+	StatusConflict = "conflict"
+	// StatusPending: the API is still unreachable; the request is kept.
+	StatusPending = "pending"
+)
+
+// messagePath matches the requests that post a message on an episode.
+var messagePath = regexp.MustCompile(`^/episodes/\d+/messages$`)
+
+// Queueable reports whether a request may be queued.
+func Queueable(method, path string) bool {
+	return journal.IsToggle(method, path) ||
+		journal.IsUpdate(method, path) ||
+		(method == http.MethodPost && messagePath.MatchString(path))
+}
+
+// Request is a queued request.
+type Request struct {
+	ID      int               `json:"id"`
+	Queued  time.Time         `json:"queued"`
+	Command string            `json:"command"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	Key     string            `json:"key,omitempty"`
+}
+
+// Result is the outcome of flushing a request.
+type Result struct {
+	Request
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Spool is the list of queued requests, oldest first.
+type Spool struct {
+	Requests []Request `json:"requests"`
+}
+
+// Load reads the spool at path. A missing file yields an empty spool.
+func Load(path string) (*Spool, error) {
+	s := &Spool{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid queue %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the spool to path, replacing the file atomically.
+func (s *Spool) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to save queue: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save queue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save queue: %w", err)
+	}
+	return nil
+}
+
+// Add appends a request with the next ID and returns it.
+func (s *Spool) Add(r Request) Request {
+	r.ID = 1
+	if n := len(s.Requests); n > 0 {
+		r.ID = s.Requests[n-1].ID + 1
+	}
+	s.Requests = append(s.Requests, r)
+	return r
+}
+
+// Remove drops the request with the given ID and reports whether it was
+// queued.
+func (s *Spool) Remove(id int) bool {
+	for i, r := range s.Requests {
+		if r.ID == id {
+			s.Requests = append(s.Requests[:i], s.Requests[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Applied reports whether resource, a show, episode or user as returned
+// by the API, already has every value of an update's fields. An update
+// without fields is never applied, as there is nothing to compare.
+func Applied(resource map[string]interface{}, fields map[string]string) bool {
+	if len(fields) == 0 {
+		return false
+	}
+	current := journal.Snapshot(resource, fields)
+	for field, value := range fields {
+		if v, ok := current[field]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package spool
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestQueueable(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"POST", "/episodes/3/messages", true},
+		{"PUT", "/users/1/likes/3", true},
+		{"DELETE", "/users/1/favorites/12", true},
+		{"POST", "/episodes/3", true},
+		{"POST", "/shows/12/episodes", false},
+		{"DELETE", "/episodes/3", false},
+		{"DELETE", "/episodes/3/messages/9", false},
+		{"POST", "/episodes/3/chapters", false},
+	}
+	for _, tt := range tests {
+		if got := Queueable(tt.method, tt.path); got != tt.want {
+			t.Errorf("Queueable(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSpool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+
+	s, err := Load(path)
+	if err != nil || len(s.Requests) != 0 {
+		t.Fatalf("Load(missing) = %+v, %v", s, err)
+	}
+	s.Add(Request{Method: "PUT", Path: "/users/1/likes/3"})
+	s.Add(Request{Method: "POST", Path: "/episodes/3/messages", Body: []byte(`{"text":"hi"}`)})
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Requests) != 2 || s.Requests[1].ID != 2 {
+		t.Fatalf("requests = %+v", s.Requests)
+	}
+	var body bytes.Buffer
+	if err := json.Compact(&body, s.Requests[1].Body); err != nil || body.String() != `{"text":"hi"}` {
+		t.Errorf("body = %s, %v", s.Requests[1].Body, err)
+	}
+	if !s.Remove(1) || s.Remove(1) || len(s.Requests) != 1 {
+		t.Errorf("Remove(1) left %+v", s.Requests)
+	}
+	if r := s.Add(Request{}); r.ID != 3 {
+		t.Errorf("ID after removal = %d, want 3", r.ID)
+	}
+}
+
+func TestApplied(t *testing.T) {
+	resource := map[string]interface{}{"title": "New", "tags": []interface{}{"a", "b"}}
+
+	if !Applied(resource, map[string]string{"title": "New", "tags": "a,b"}) {
+		t.Error("matching update not applied")
+	}
+	if Applied(resource, map[string]string{"title": "Other"}) {
+		t.Error("different title reported as applied")
+	}
+	if Applied(resource, map[string]string{"hidden": "true"}) {
+		t.Error("unknown field reported as applied")
+	}
+	if Applied(resource, nil) {
+		t.Error("update without fields reported as applied")
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Package text extracts keywords from free text, such as episode titles and
+descriptions, to suggest tags.
+
+Extraction is simple and language-light: words are split on anything that
+is not a letter or digit, common English and Italian stop words and short
+or numeric words are dropped, and the rest are scored by frequency, with
+title words weighing more than description words. Pairs of words that
+recur together ("machine learning") are kept as phrases.
+*/
+package text
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// titleWeight is how many description words a title word is worth.
+const titleWeight = 3
+
+// minPhraseScore is the score a pair of words needs to be kept as a
+// phrase: twice in the description, or once in the title.
+const minPhraseScore = 2
+
+// Keyword is a candidate keyword and its score.
+type Keyword struct {
+	Term  string  `json:"term"`
+	Score float64 `json:"score"`
+}
+
+var (
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+	url     = regexp.MustCompile(`\b(?:https?://|www\.)\S+`)
+)
+
+// Keywords returns up to n keywords of a title and body, best first, with
+// ties in alphabetical order. n <= 0 returns them all.
+func Keywords(title, body string, n int) []Keyword {
+	scores := make(map[string]float64)
+	for _, part := range []struct {
+		text   string
+		weight float64
+	}{{title, titleWeight}, {body, 1}} {
+		for _, run := range runs(part.text) {
+			for i, word := range run {
+				scores[word] += part.weight
+				if i > 0 {
+					scores[run[i-1]+" "+word] += part.weight
+				}
+			}
+		}
+	}
+
+	keywords := make([]Keyword, 0, len(scores))
+	for term, score := range scores {
+		if strings.Contains(term, " ") && score < minPhraseScore {
+			continue
+		}
+		keywords = append(keywords, Keyword{Term: term, Score: score})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		return keywords[i].Term < keywords[j].Term
+	})
+
+	if n > 0 && len(keywords) > n {
+		keywords = keywords[:n]
+	}
+	return keywords
+}
+
+// runs splits text into lowercase words, grouped in runs of consecutive
+// keywords: stop words, short words and punctuation end a run, so phrases
+// only span words that were next to each other.
+func runs(text string) [][]string {
+	text = url.ReplaceAllString(htmlTag.ReplaceAllString(text, " "), " ")
+
+	var all [][]string
+	var run []string
+	flush := func() {
+		if len(run) > 0 {
+			all = append(all, run)
+			run = nil
+		}
+	}
+
+	for _, chunk := range strings.FieldsFunc(text, unicode.IsSpace) {
+		// Punctuation between words, e.g. "news, tech", ends the run.
+		words := strings.FieldsFunc(chunk, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+		})
+		breaks := len(words) != 1 || strings.IndexFunc(chunk, unicode.IsPunct) >= 0
+		for _, word := range words {
+			word = strings.ToLower(strings.Trim(word, "'’"))
+			if isKeyword(word) {
+				run = append(run, word)
+			} else {
+				flush()
+			}
+		}
+		if breaks {
+			flush()
+		}
+	}
+	flush()
+	return all
+}
+
+// isKeyword reports whether word can be a keyword: at least three letters
+// long, not only digits, and not a stop word.
+func isKeyword(word string) bool {
+	if len([]rune(word)) < 3 || stopWords[word] {
+		return false
+	}
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
+
+// Normalize returns the form tags are compared in: lowercase, with runs
+// of spaces, dashes and underscores as single spaces.
+func Normalize(tag string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_' || r == '#'
+	}), " ")
+}
@@ -0,0 +1,60 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestKeywords(t *testing.T) {
+	title := "Machine Learning for podcasters"
+	body := `<p>This week we talk about machine learning, transcription and
+the tools we use: https://example.com/tools. Transcription is 10x faster
+with machine learning.</p>`
+
+	got := Keywords(title, body, 4)
+	want := []Keyword{
+		{"learning", 5},
+		{"machine", 5},
+		{"machine learning", 5},
+		{"podcasters", 3},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Keywords() = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Keywords()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	for _, k := range Keywords(title, body, 0) {
+		switch k.Term {
+		case "the", "this", "com", "10x faster", "tools transcription", "learning transcription":
+			t.Errorf("unexpected keyword %+v", k)
+		}
+	}
+}
+
+func TestSuggestTags(t *testing.T) {
+	got := SuggestTags("Cooking pasta", "Pasta, pasta and more pasta. A recipe.",
+		[]string{"Recipe", "#Cooking"}, []string{"pasta"}, 0)
+
+	want := []Suggestion{
+		{Tag: "#Cooking", Score: 6, Known: true},
+		{Tag: "cooking pasta", Score: 3},
+		{Tag: "Recipe", Score: 2, Known: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SuggestTags() = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SuggestTags()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("  #True-Crime_stories "); got != "true crime stories" {
+		t.Errorf("Normalize() = %q", got)
+	}
+}
@@ -0,0 +1,43 @@
+package text
+
+// stopWords are common English and Italian words that make poor keywords.
+// Words shorter than three letters are dropped anyway and not listed.
+var stopWords = toSet(
+	// English
+	"about", "above", "after", "again", "against", "all", "also", "and", "any",
+	"are", "because", "been", "before", "being", "below", "between", "both",
+	"but", "can", "could", "did", "does", "doing", "down", "during", "each",
+	"episode", "episodes", "even", "every", "few", "for", "from", "further",
+	"get", "got", "had", "has", "have", "having", "her", "here", "hers",
+	"herself", "him", "himself", "his", "how", "into", "its", "itself",
+	"just", "let", "like", "make", "more", "most", "much", "must", "new",
+	"not", "now", "off", "once", "one", "only", "other", "our", "ours",
+	"ourselves", "out", "over", "own", "same", "she", "should", "some",
+	"such", "than", "that", "the", "their", "theirs", "them", "themselves",
+	"then", "there", "these", "they", "this", "those", "through", "today",
+	"too", "under", "until", "very", "was", "way", "we'll", "we're", "were",
+	"what", "when", "where", "which", "while", "who", "whom", "why", "will",
+	"with", "would", "you", "you'll", "you're", "your", "yours", "yourself",
+	"yourselves", "podcast", "podcasts", "show", "shows", "listen", "join",
+	"talk", "talks", "week", "welcome",
+
+	// Italian
+	"agli", "alla", "alle", "allo", "anche", "ancora", "che", "chi", "come",
+	"con", "cosa", "cui", "dagli", "dai", "dal", "dalla", "dalle", "degli",
+	"dei", "del", "dell", "della", "delle", "dello", "dentro", "dopo",
+	"dove", "essere", "fra", "gli", "hanno", "loro", "mentre", "mio",
+	"molto", "nei", "nel", "nella", "nelle", "nello", "noi", "non", "nostro",
+	"oggi", "ogni", "oltre", "per", "perché", "però", "più", "poi", "prima",
+	"puntata", "quale", "quando", "quello", "questa", "queste", "questi",
+	"questo", "sei", "senza", "siamo", "sia", "sono", "sotto", "sua", "sue",
+	"sugli", "sui", "sul", "sulla", "sulle", "suo", "tra", "tuo", "tutti",
+	"tutto", "una", "uno", "vostro",
+)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,57 @@
+package text
+
+import (
+	"sort"
+	"strings"
+)
+
+// knownBoost multiplies the score of keywords that are tags used before.
+const knownBoost = 2
+
+// Suggestion is a suggested tag.
+type Suggestion struct {
+	Tag   string  `json:"tag"`
+	Score float64 `json:"score"`
+
+	// Known is set when the tag was used before, in the spelling of that
+	// earlier use.
+	Known bool `json:"known"`
+}
+
+// SuggestTags returns up to n tags for a title and body, best first. With
+// known tags, keywords matching one of them take its spelling and score
+// higher. Tags in exclude, e.g. the episode's current tags, are left out.
+func SuggestTags(title, body string, known, exclude []string, n int) []Suggestion {
+	spelling := make(map[string]string, len(known))
+	for _, tag := range known {
+		if key := Normalize(tag); key != "" {
+			if _, ok := spelling[key]; !ok {
+				spelling[key] = strings.TrimSpace(tag)
+			}
+		}
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, tag := range exclude {
+		excluded[Normalize(tag)] = true
+	}
+
+	var suggestions []Suggestion
+	for _, k := range Keywords(title, body, 0) {
+		if excluded[k.Term] {
+			continue
+		}
+		s := Suggestion{Tag: k.Term, Score: k.Score}
+		if tag, ok := spelling[k.Term]; ok {
+			s.Tag, s.Score, s.Known = tag, s.Score*knownBoost, true
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if n > 0 && len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
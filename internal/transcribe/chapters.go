@@ -0,0 +1,148 @@
+package transcribe
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+// MaxChapterTitle is the longest chapter title the API accepts, in
+// characters.
+const MaxChapterTitle = 120
+
+// headingWords is the most words a cue may have to count as a spoken
+// heading, such as "Now, the news." or "Listener questions".
+const headingWords = 8
+
+// headingBonus is added to the silence before a heading-like cue when
+// ranking chapter boundaries, so a short pause before a heading beats a
+// longer pause mid-topic.
+const headingBonus = 2 * time.Second
+
+// ChapterOptions tune DetectChapters.
+type ChapterOptions struct {
+	// Marker is a prefix, such as "Chapter:", of cues that start a
+	// chapter; the rest of the cue is its title. When the transcript has
+	// marker cues, they alone make the chapters.
+	Marker string
+
+	// MinGap is the shortest silence between cues that can end a chapter.
+	MinGap time.Duration
+
+	// MinLength is the shortest chapter made from silences.
+	MinLength time.Duration
+
+	// Max is the most chapters made from silences, 0 for no limit.
+	Max int
+}
+
+// DetectChapters proposes chapters for a transcript. With marker cues the
+// chapters start at them. Otherwise they start at the longest silences,
+// preferring those followed by a short, heading-like cue, keeping chapters
+// at least MinLength apart; the first chapter starts at 0. Titles are
+// taken from the first cue of each chapter.
+func DetectChapters(cues []Cue, opts ChapterOptions) []models.Chapter {
+	if len(cues) == 0 {
+		return nil
+	}
+	if chapters := markerChapters(cues, opts.Marker); len(chapters) > 0 {
+		return chapters
+	}
+
+	type candidate struct {
+		cue   int
+		score time.Duration
+	}
+	var candidates []candidate
+	for i := 1; i < len(cues); i++ {
+		gap := time.Duration(cues[i].Start-cues[i-1].End) * time.Millisecond
+		if gap < opts.MinGap || gap <= 0 {
+			continue
+		}
+		if isHeading(cues[i].Text) {
+			gap += headingBonus
+		}
+		candidates = append(candidates, candidate{cue: i, score: gap})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	minLength := int(opts.MinLength / time.Millisecond)
+	end := cues[len(cues)-1].End
+	starts := []int{0}
+	chosen := []int{0}
+	for _, c := range candidates {
+		if opts.Max > 0 && len(chosen) >= opts.Max {
+			break
+		}
+		start := cues[c.cue].Start
+		if end-start < minLength || !farFrom(starts, start, minLength) {
+			continue
+		}
+		starts = append(starts, start)
+		chosen = append(chosen, c.cue)
+	}
+	sort.Ints(chosen)
+
+	chapters := make([]models.Chapter, len(chosen))
+	for i, cue := range chosen {
+		chapters[i] = models.Chapter{StartsAt: cues[cue].Start, Title: chapterTitle(cues[cue].Text)}
+	}
+	chapters[0].StartsAt = 0
+	return chapters
+}
+
+// markerChapters returns a chapter for each cue starting with marker. A
+// marker cue with no title takes the text of the next cue.
+func markerChapters(cues []Cue, marker string) []models.Chapter {
+	marker = strings.TrimSpace(marker)
+	if marker == "" {
+		return nil
+	}
+	var chapters []models.Chapter
+	for i, cue := range cues {
+		if len(cue.Text) < len(marker) || !strings.EqualFold(cue.Text[:len(marker)], marker) {
+			continue
+		}
+		title := strings.TrimSpace(cue.Text[len(marker):])
+		if title == "" && i+1 < len(cues) {
+			title = cues[i+1].Text
+		}
+		chapters = append(chapters, models.Chapter{StartsAt: cue.Start, Title: chapterTitle(title)})
+	}
+	return chapters
+}
+
+// farFrom reports whether start is at least min away from every one of starts.
+func farFrom(starts []int, start, min int) bool {
+	for _, s := range starts {
+		if d := start - s; d < min && d > -min {
+			return false
+		}
+	}
+	return true
+}
+
+func isHeading(text string) bool {
+	return len(strings.Fields(text)) <= headingWords
+}
+
+// chapterTitle makes a title of a cue: a heading-like cue whole, otherwise
+// its first words, without trailing punctuation.
+func chapterTitle(text string) string {
+	words := strings.Fields(text)
+	cut := len(words) > headingWords
+	if cut {
+		words = words[:headingWords]
+	}
+	title := strings.TrimRight(strings.Join(words, " "), ".,;:!?")
+	if cut {
+		title += "…"
+	}
+	if utf8.RuneCountInString(title) > MaxChapterTitle {
+		title = string([]rune(title)[:MaxChapterTitle-1]) + "…"
+	}
+	return title
+}
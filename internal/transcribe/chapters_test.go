@@ -0,0 +1,86 @@
+package transcribe
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G10xy/spreaker-and-go/pkg/models"
+)
+
+func TestParseVTT(t *testing.T) {
+	input := "\ufeffWEBVTT - episode 42\r\n\r\nNOTE generated by whisper\r\n\r\n" +
+		"1\n00:00:00.000 --> 00:00:02.500 align:start\n<v Alice>Hello and</v>\nwelcome.\n\n" +
+		"01:05.250 --> 01:07,000\n<i>Now, the news.</i>\n\n" +
+		"STYLE\n::cue { color: white }\n\n" +
+		"00:01:08.000 --> 00:01:09.000\n\n"
+
+	cues, err := ParseVTT(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Cue{
+		{Start: 0, End: 2500, Text: "Hello and welcome."},
+		{Start: 65250, End: 67000, Text: "Now, the news."},
+	}
+	if !reflect.DeepEqual(cues, want) {
+		t.Errorf("ParseVTT() = %+v, want %+v", cues, want)
+	}
+
+	for _, bad := range []string{"1\n00:00.000 --> 00:01.000\nHi\n", "WEBVTT\n\nxx:00.000 --> 00:01.000\nHi\n"} {
+		if _, err := ParseVTT(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseVTT(%q): want error", bad)
+		}
+	}
+}
+
+func TestDetectChapters(t *testing.T) {
+	s := func(seconds int) int { return seconds * 1000 }
+	cues := []Cue{
+		{Start: s(0), End: s(5), Text: "Welcome to Morning Coffee, the show about everything before nine."},
+		{Start: s(6), End: s(100), Text: "Today we talk about beans."},
+		// A long pause mid-topic.
+		{Start: s(105), End: s(200), Text: "And the beans are roasted at a very high temperature, you see."},
+		// A shorter pause before a heading.
+		{Start: s(204), End: s(206), Text: "Listener questions."},
+		{Start: s(206), End: s(400), Text: "Our first question comes from Rome."},
+		// Too close to the end for a chapter.
+		{Start: s(410), End: s(420), Text: "Goodbye."},
+	}
+
+	got := DetectChapters(cues, ChapterOptions{MinGap: 2 * time.Second, MinLength: time.Minute})
+	want := []models.Chapter{
+		{StartsAt: 0, Title: "Welcome to Morning Coffee, the show about everything…"},
+		{StartsAt: s(105), Title: "And the beans are roasted at a very…"},
+		{StartsAt: s(204), Title: "Listener questions"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectChapters() = %+v\nwant %+v", got, want)
+	}
+
+	// The heading outranks the longer pause, which is then too close.
+	got = DetectChapters(cues, ChapterOptions{MinGap: 2 * time.Second, MinLength: 100 * time.Second})
+	if len(got) != 2 || got[1].StartsAt != s(204) {
+		t.Errorf("DetectChapters() with a long minimum = %+v", got)
+	}
+
+	got = DetectChapters(cues, ChapterOptions{MinGap: 2 * time.Second, MinLength: time.Minute, Max: 2})
+	if len(got) != 2 {
+		t.Errorf("DetectChapters() with Max 2 = %+v", got)
+	}
+}
+
+func TestDetectChaptersMarkers(t *testing.T) {
+	cues := []Cue{
+		{Start: 0, End: 1000, Text: "Hello."},
+		{Start: 1000, End: 2000, Text: "chapter: The news"},
+		{Start: 60000, End: 61000, Text: "Chapter:"},
+		{Start: 61000, End: 62000, Text: "Weather."},
+	}
+	got := DetectChapters(cues, ChapterOptions{Marker: "Chapter:", MinGap: time.Second})
+	want := []models.Chapter{{StartsAt: 1000, Title: "The news"}, {StartsAt: 60000, Title: "Weather"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectChapters() = %+v, want %+v", got, want)
+	}
+}
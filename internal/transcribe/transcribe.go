@@ -0,0 +1,212 @@
+/*
+Package transcribe turns episode audio into WebVTT transcripts.
+
+Two engines are supported: WhisperCpp runs a local whisper.cpp program, so
+the audio never leaves the machine, and OpenAI sends it to the OpenAI
+transcription API. Both write the transcript as WebVTT, the caption format
+podcast players read from a podcast:transcript tag.
+
+ParseVTT reads such a transcript back, and DetectChapters proposes chapters
+from its silences and spoken headings, or from marker cues.
+*/
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Engine transcribes an audio file to a WebVTT file.
+type Engine interface {
+	Transcribe(ctx context.Context, audioPath, outPath string) error
+}
+
+// DefaultWhisperCommand is the whisper.cpp program run when
+// WhisperCpp.Command is empty.
+const DefaultWhisperCommand = "whisper-cli"
+
+// WhisperCpp runs the whisper.cpp command line program. Model is the path
+// of a ggml model file; Language is an ISO 639-1 code, or empty to let
+// whisper detect it.
+type WhisperCpp struct {
+	Command  string
+	Model    string
+	Language string
+}
+
+// Transcribe runs whisper.cpp on the audio file. whisper.cpp reads MP3
+// itself and names its output after -of, so it writes to a temporary
+// directory and the transcript is then moved to outPath.
+func (w WhisperCpp) Transcribe(ctx context.Context, audioPath, outPath string) error {
+	if w.Model == "" {
+		return errors.New("whisper-cpp needs a model file: set transcribe.whisper_model or pass --model")
+	}
+	command := w.Command
+	if command == "" {
+		command = DefaultWhisperCommand
+	}
+	language := w.Language
+	if language == "" {
+		language = "auto"
+	}
+
+	dir, err := os.MkdirTemp("", "spreaker-whisper-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "transcript")
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, "-m", w.Model, "-l", language, "-f", audioPath, "-ovtt", "-of", base, "-np")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("%s not found: install whisper.cpp or set transcribe.whisper_command", command)
+		}
+		return fmt.Errorf("%s failed: %w%s", command, err, lastLine(stderr.String()))
+	}
+
+	vtt, err := os.ReadFile(base + ".vtt")
+	if err != nil {
+		return fmt.Errorf("%s wrote no transcript: %w", command, err)
+	}
+	return os.WriteFile(outPath, vtt, 0o644)
+}
+
+// lastLine returns the last non-empty line of a program's output, as
+// ": line", for error messages.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return ": " + last
+	}
+	return ""
+}
+
+// OpenAI API defaults.
+const (
+	DefaultOpenAIURL   = "https://api.openai.com/v1"
+	DefaultOpenAIModel = "whisper-1"
+)
+
+// OpenAIMaxSize is the largest file the OpenAI transcription API accepts.
+const OpenAIMaxSize = 25 << 20
+
+// OpenAI sends the audio to the OpenAI transcription API. BaseURL and
+// Model default to DefaultOpenAIURL and DefaultOpenAIModel.
+type OpenAI struct {
+	Client   *http.Client
+	BaseURL  string
+	Key      string
+	Model    string
+	Language string
+}
+
+// Transcribe uploads the audio file and saves the returned WebVTT.
+func (o OpenAI) Transcribe(ctx context.Context, audioPath, outPath string) error {
+	if o.Key == "" {
+		return errors.New("openai needs an API key: set transcribe.openai_api_key or OPENAI_API_KEY")
+	}
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		return err
+	}
+	if info.Size() > OpenAIMaxSize {
+		return fmt.Errorf("the audio is %d MB, over the OpenAI limit of %d MB: use the whisper-cpp engine", info.Size()>>20, OpenAIMaxSize>>20)
+	}
+
+	body, contentType, err := o.form(audioPath)
+	if err != nil {
+		return err
+	}
+	baseURL := o.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultOpenAIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/audio/transcriptions", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+o.Key)
+	req.Header.Set("Content-Type", contentType)
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("transcription request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("transcription failed: %s%s", resp.Status, openAIError(data))
+	}
+	return os.WriteFile(outPath, data, 0o644)
+}
+
+// form builds the multipart request body.
+func (o OpenAI) form(audioPath string) (io.Reader, string, error) {
+	model := o.Model
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	f, err := os.Open(audioPath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fields := [][2]string{{"model", model}, {"response_format", "vtt"}}
+	if o.Language != "" {
+		fields = append(fields, [2]string{"language", o.Language})
+	}
+	for _, field := range fields {
+		if err := mw.WriteField(field[0], field[1]); err != nil {
+			return nil, "", err
+		}
+	}
+	part, err := mw.CreateFormFile("file", filepath.Base(audioPath))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, "", fmt.Errorf("failed to read audio: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, mw.FormDataContentType(), nil
+}
+
+// openAIError returns the message of an OpenAI error response, as ": message".
+func openAIError(data []byte) string {
+	var e struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &e) == nil && e.Error.Message != "" {
+		return ": " + e.Error.Message
+	}
+	return ""
+}
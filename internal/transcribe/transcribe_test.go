@@ -0,0 +1,102 @@
+package transcribe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const vtt = "WEBVTT\n\n00:00:00.000 --> 00:00:02.000\nHello and welcome.\n"
+
+func TestOpenAI(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "episode.mp3")
+	if err := os.WriteFile(audio, []byte("ID3 audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/transcriptions" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			t.Errorf("request %s with %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if r.FormValue("model") != "whisper-1" || r.FormValue("response_format") != "vtt" || r.FormValue("language") != "it" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		if _, header, err := r.FormFile("file"); err != nil || header.Filename != "episode.mp3" {
+			t.Errorf("file = %v, %v", header, err)
+		}
+		w.Write([]byte(vtt))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(dir, "transcript.vtt")
+	engine := OpenAI{Client: srv.Client(), BaseURL: srv.URL, Key: "sk-test", Language: "it"}
+	if err := engine.Transcribe(context.Background(), audio, out); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != vtt {
+		t.Errorf("transcript = %q", got)
+	}
+}
+
+func TestOpenAIError(t *testing.T) {
+	dir := t.TempDir()
+	audio := filepath.Join(dir, "episode.mp3")
+	if err := os.WriteFile(audio, []byte("ID3 audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"message": "Incorrect API key provided"}}`))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(dir, "transcript.vtt")
+	err := OpenAI{Client: srv.Client(), BaseURL: srv.URL, Key: "sk-bad"}.Transcribe(context.Background(), audio, out)
+	if err == nil || !strings.Contains(err.Error(), "Incorrect API key provided") {
+		t.Errorf("Transcribe() error = %v", err)
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Error("a failed transcription wrote a transcript")
+	}
+
+	if err := (OpenAI{}).Transcribe(context.Background(), audio, out); err == nil {
+		t.Error("Transcribe() without a key: want error")
+	}
+}
+
+func TestWhisperCpp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake whisper-cli is a shell script")
+	}
+	dir := t.TempDir()
+	// The fake writes the transcript where -of says, as whisper-cli does.
+	script := filepath.Join(dir, "whisper-cli")
+	fake := "#!/bin/sh\nwhile [ $# -gt 0 ]; do\n  if [ \"$1\" = -of ]; then out=\"$2\"; fi\n  shift\ndone\nprintf '" +
+		strings.ReplaceAll(vtt, "\n", `\n`) + "' > \"$out.vtt\"\n"
+	if err := os.WriteFile(script, []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "transcript.vtt")
+	engine := WhisperCpp{Command: script, Model: "ggml-base.bin"}
+	if err := engine.Transcribe(context.Background(), filepath.Join(dir, "episode.mp3"), out); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != vtt {
+		t.Errorf("transcript = %q", got)
+	}
+
+	if err := (WhisperCpp{Command: script}).Transcribe(context.Background(), "episode.mp3", out); err == nil {
+		t.Error("Transcribe() without a model: want error")
+	}
+	missing := WhisperCpp{Command: filepath.Join(dir, "missing"), Model: "ggml-base.bin"}
+	if err := missing.Transcribe(context.Background(), "episode.mp3", out); err == nil {
+		t.Error("Transcribe() with a missing command: want error")
+	}
+}
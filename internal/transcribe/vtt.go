@@ -0,0 +1,129 @@
+package transcribe
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Cue is a timed piece of text of a WebVTT transcript. Start and End are
+// in milliseconds.
+type Cue struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// vttTagRE matches cue text markup such as <v Alice>, <i> or <00:01.000>.
+var vttTagRE = regexp.MustCompile(`<[^>]*>`)
+
+// ParseVTT reads the cues of a WebVTT file. Comments, styles and regions
+// are skipped, and the text of each cue is joined into one line without
+// markup.
+func ParseVTT(r io.Reader) ([]Cue, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var block []string
+	var cues []Cue
+	header := false
+	flush := func() error {
+		defer func() { block = block[:0] }()
+		if len(block) == 0 {
+			return nil
+		}
+		if !header {
+			if !strings.HasPrefix(strings.TrimPrefix(block[0], "\ufeff"), "WEBVTT") {
+				return errors.New("not a WebVTT file: missing WEBVTT header")
+			}
+			header = true
+			return nil
+		}
+		for i, line := range block {
+			if !strings.Contains(line, "-->") {
+				continue
+			}
+			cue, err := parseTiming(line)
+			if err != nil {
+				return err
+			}
+			text := vttTagRE.ReplaceAllString(strings.Join(block[i+1:], " "), "")
+			cue.Text = strings.Join(strings.Fields(text), " ")
+			if cue.Text != "" {
+				cues = append(cues, cue)
+			}
+			break
+		}
+		return nil
+	}
+
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, errors.New("not a WebVTT file: missing WEBVTT header")
+	}
+	return cues, nil
+}
+
+// parseTiming parses a "00:01:02.500 --> 00:01:05.000 align:start" line.
+func parseTiming(line string) (Cue, error) {
+	from, rest, _ := strings.Cut(line, "-->")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return Cue{}, fmt.Errorf("invalid cue timing %q", line)
+	}
+	start, err := parseTimestamp(strings.TrimSpace(from))
+	if err != nil {
+		return Cue{}, err
+	}
+	end, err := parseTimestamp(fields[0])
+	if err != nil {
+		return Cue{}, err
+	}
+	return Cue{Start: start, End: end}, nil
+}
+
+// parseTimestamp parses a WebVTT timestamp, [HH:]MM:SS.mmm, into
+// milliseconds. A comma before the milliseconds, as in SRT, is accepted.
+func parseTimestamp(s string) (int, error) {
+	clock, frac, _ := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	ms := 0
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		ms = ms*60 + n
+	}
+	ms *= 1000
+	if frac != "" {
+		n, err := strconv.Atoi((frac + "00")[:3])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		ms += n
+	}
+	return ms, nil
+}
@@ -0,0 +1,270 @@
+/*
+Package update checks the project's GitHub releases for a newer version and
+replaces the running executable with the matching release binary.
+
+Release binaries are published by .github/workflows/release.yml together with
+a checksums.txt file of SHA-256 sums, which every download is verified against.
+*/
+package update
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Repo is the GitHub repository releases are published to.
+const Repo = "G10xy/spreaker-and-go"
+
+// ChecksumsAsset is the release asset listing the SHA-256 sum of every binary.
+const ChecksumsAsset = "checksums.txt"
+
+// APIBaseURL is the GitHub API root. A variable so tests can point it at a
+// local server.
+var APIBaseURL = "https://api.github.com"
+
+// Release is a published GitHub release.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+// Asset returns the release asset with the given name.
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Latest fetches the latest published (non-draft, non-prerelease) release.
+func Latest(ctx context.Context, client *http.Client) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", strings.TrimRight(APIBaseURL, "/"), Repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to check for releases: GitHub returned status %d", resp.StatusCode)
+	}
+
+	var rel Release
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &rel, nil
+}
+
+// AssetName returns the release binary name for a platform, matching the
+// names built by the release workflow, e.g. "spreaker-macos-arm64".
+func AssetName(goos, goarch string) string {
+	name := goos
+	if goos == "darwin" {
+		name = "macos"
+	}
+	name = "spreaker-" + name + "-" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// CurrentAssetName returns the release binary name for this platform.
+func CurrentAssetName() string {
+	return AssetName(runtime.GOOS, runtime.GOARCH)
+}
+
+// CompareVersions compares two "vMAJOR.MINOR.PATCH[-PRERELEASE]" versions
+// and returns -1, 0 or 1. A prerelease sorts before its release.
+func CompareVersions(a, b string) (int, error) {
+	aNums, aPre, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bNums, bPre, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range aNums {
+		if aNums[i] != bNums[i] {
+			if aNums[i] < bNums[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0, nil
+	case aPre == "":
+		return 1, nil
+	case bPre == "":
+		return -1, nil
+	case aPre < bPre:
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+func parseVersion(v string) ([3]int, string, error) {
+	var nums [3]int
+	core, pre, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(v), "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return nums, "", fmt.Errorf("invalid version %q", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nums, "", fmt.Errorf("invalid version %q", v)
+		}
+		nums[i] = n
+	}
+	return nums, pre, nil
+}
+
+// ParseChecksums parses a "sha256sum" style file: "<hex>  <file name>" per line.
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums, scanner.Err()
+}
+
+// Checksum downloads the release's checksums file and returns the SHA-256
+// sum listed for the named asset.
+func Checksum(ctx context.Context, client *http.Client, rel *Release, name string) (string, error) {
+	asset, ok := rel.Asset(ChecksumsAsset)
+	if !ok {
+		return "", fmt.Errorf("release %s has no %s; refusing to install an unverified binary", rel.TagName, ChecksumsAsset)
+	}
+
+	body, err := get(ctx, client, asset.BrowserDownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	sums, err := ParseChecksums(io.LimitReader(body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", ChecksumsAsset, err)
+	}
+	sum, ok := sums[name]
+	if !ok {
+		return "", fmt.Errorf("%s has no entry for %s", ChecksumsAsset, name)
+	}
+	return sum, nil
+}
+
+// ErrChecksumMismatch is returned when a downloaded binary does not match
+// the release checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Install downloads asset, verifies it against the expected SHA-256 sum and
+// atomically replaces the executable at exePath. The new binary is written
+// next to the executable first, so a failed download or verification leaves
+// the installed version untouched.
+func Install(ctx context.Context, client *http.Client, asset Asset, sum, exePath string) error {
+	body, err := get(ctx, client, asset.BrowserDownloadURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, ".spreaker-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); got != strings.ToLower(sum) {
+		return fmt.Errorf("%w for %s: got %s, want %s", ErrChecksumMismatch, asset.Name, got, sum)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+
+	return replace(tmp.Name(), exePath)
+}
+
+// replace moves newPath over exePath. A running executable cannot be
+// overwritten on Windows, but it can be renamed, so the old binary is moved
+// aside first and restored if the final rename fails.
+func replace(newPath, exePath string) error {
+	old := exePath + ".old"
+	os.Remove(old)
+	if err := os.Rename(exePath, old); err != nil {
+		return fmt.Errorf("cannot replace %s: %w", exePath, err)
+	}
+	if err := os.Rename(newPath, exePath); err != nil {
+		os.Rename(old, exePath)
+		return fmt.Errorf("cannot replace %s: %w", exePath, err)
+	}
+	// Still in use on Windows; removed by the next upgrade instead.
+	os.Remove(old)
+	return nil
+}
+
+func get(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,189 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, false},
+		{"1.2.3", "v1.2.3", 0, false},
+		{"v1.2.3", "v1.10.0", -1, false},
+		{"v2.0.0", "v1.99.99", 1, false},
+		{"v1.2", "v1.2.0", 0, false},
+		{"v1.3.0-rc1", "v1.3.0", -1, false},
+		{"v1.3.0", "v1.3.0-rc1", 1, false},
+		{"v1.3.0-rc1", "v1.3.0-rc2", -1, false},
+		{"dev", "v1.0.0", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompareVersions(%q, %q) error = %v, wantErr %v", tt.a, tt.b, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	tests := []struct {
+		goos, goarch, want string
+	}{
+		{"linux", "amd64", "spreaker-linux-amd64"},
+		{"darwin", "arm64", "spreaker-macos-arm64"},
+		{"windows", "amd64", "spreaker-windows-amd64.exe"},
+	}
+	for _, tt := range tests {
+		if got := AssetName(tt.goos, tt.goarch); got != tt.want {
+			t.Errorf("AssetName(%q, %q) = %q, want %q", tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	sums, err := ParseChecksums(strings.NewReader("ABC123  spreaker-linux-amd64\ndef456 *spreaker-windows-amd64.exe\n\nbogus line here\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sums["spreaker-linux-amd64"] != "abc123" || sums["spreaker-windows-amd64.exe"] != "def456" || len(sums) != 2 {
+		t.Errorf("ParseChecksums() = %v", sums)
+	}
+}
+
+// releaseServer serves a latest release with one binary and its checksums.
+func releaseServer(t *testing.T, binary, checksums string) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/" + Repo + "/releases/latest":
+			json.NewEncoder(w).Encode(Release{
+				TagName: "v9.9.9",
+				Assets: []Asset{
+					{Name: "spreaker-linux-amd64", BrowserDownloadURL: srv.URL + "/bin"},
+					{Name: ChecksumsAsset, BrowserDownloadURL: srv.URL + "/sums"},
+				},
+			})
+		case "/bin":
+			w.Write([]byte(binary))
+		case "/sums":
+			w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	base := APIBaseURL
+	APIBaseURL = srv.URL
+	t.Cleanup(func() { APIBaseURL = base })
+	return srv
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLatestAndInstall(t *testing.T) {
+	binary := "new binary"
+	releaseServer(t, binary, sha256Hex(binary)+"  spreaker-linux-amd64\n")
+	ctx := context.Background()
+
+	rel, err := Latest(ctx, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.TagName != "v9.9.9" {
+		t.Errorf("TagName = %q", rel.TagName)
+	}
+
+	sum, err := Checksum(ctx, http.DefaultClient, rel, "spreaker-linux-amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exe := filepath.Join(t.TempDir(), "spreaker")
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	asset, _ := rel.Asset("spreaker-linux-amd64")
+	if err := Install(ctx, http.DefaultClient, asset, sum, exe); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(exe)
+	if err != nil || string(data) != binary {
+		t.Errorf("executable = %q, %v; want %q", data, err, binary)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("leftover files after install: %v", entries)
+	}
+}
+
+func TestInstall_ChecksumMismatchKeepsExecutable(t *testing.T) {
+	releaseServer(t, "tampered binary", "")
+	ctx := context.Background()
+
+	rel, err := Latest(ctx, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(t.TempDir(), "spreaker")
+	if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	asset, _ := rel.Asset("spreaker-linux-amd64")
+	err = Install(ctx, http.DefaultClient, asset, sha256Hex("expected binary"), exe)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Install() error = %v, want ErrChecksumMismatch", err)
+	}
+
+	data, _ := os.ReadFile(exe)
+	if string(data) != "old binary" {
+		t.Errorf("executable was replaced: %q", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("leftover files after failed install: %v", entries)
+	}
+}
+
+func TestChecksum_MissingEntry(t *testing.T) {
+	releaseServer(t, "bin", sha256Hex("bin")+"  spreaker-macos-arm64\n")
+	ctx := context.Background()
+
+	rel, err := Latest(ctx, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Checksum(ctx, http.DefaultClient, rel, "spreaker-linux-amd64"); err == nil {
+		t.Error("Checksum() should fail without an entry for the asset")
+	}
+
+	rel.Assets = rel.Assets[:1]
+	if _, err := Checksum(ctx, http.DefaultClient, rel, "spreaker-linux-amd64"); err == nil {
+		t.Error("Checksum() should fail without a checksums asset")
+	}
+}
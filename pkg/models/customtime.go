@@ -1,27 +1,107 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 )
 
+// timeLayouts are the formats of API timestamps, all in UTC: Spreaker
+// sends "2006-01-02 15:04:05", statistics send bare days, and values this
+// package marshaled itself are RFC 3339.
+var timeLayouts = []string{time.DateTime, time.DateOnly, time.RFC3339}
+
+// parseTime parses an API timestamp in any of timeLayouts.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// unquote returns the string in a JSON value, and "" for null. The API
+// sends both null and "" for timestamps it does not have, so both unmarshal
+// to the zero time.
+func unquote(b []byte) string {
+	s := strings.Trim(string(b), "\"")
+	if s == "null" {
+		return ""
+	}
+	return s
+}
+
+// CustomTime is an API timestamp. It marshals as RFC 3339; Raw keeps the
+// value as the API sent it.
 type CustomTime struct {
-    time.Time
+	time.Time
+	Raw string `json:"-"`
 }
 
-const customLayout = "2006-01-02 15:04:05" // The format of your input string
+// ParseTime parses an API timestamp such as "2024-01-15 10:30:00".
+func ParseTime(s string) (CustomTime, error) {
+	t, err := parseTime(s)
+	if err != nil {
+		return CustomTime{}, err
+	}
+	return CustomTime{Time: t, Raw: s}, nil
+}
 
 func (ct *CustomTime) UnmarshalJSON(b []byte) error {
-    s := strings.Trim(string(b), "\"")
-    if s == "null" {
-        ct.Time = time.Time{}
-        return nil
-    }
-    t, err := time.Parse(customLayout, s)
-    if err != nil {
-        return err
-    }
-    ct.Time = t
-    return nil
+	s := unquote(b)
+	if s == "" {
+		*ct = CustomTime{}
+		return nil
+	}
+	t, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*ct = t
+	return nil
+}
+
+// Date is a day of a statistics series, in UTC. It marshals as
+// "2006-01-02", whether or not the API added a midnight time; Raw keeps
+// the value as the API sent it.
+type Date struct {
+	time.Time
+	Raw string `json:"-"`
 }
 
+// ParseDate parses a statistics day such as "2024-01-15".
+func ParseDate(s string) (Date, error) {
+	t, err := parseTime(s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t, Raw: s}, nil
+}
+
+// String returns the day as "2006-01-02", or the raw value of a zero date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return d.Raw
+	}
+	return d.Format(time.DateOnly)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	s := unquote(b)
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -29,9 +29,9 @@ func TestCustomTime_UnmarshalJSON(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "empty string",
-			input:   `""`,
-			wantErr: true,
+			name:  "empty string returns zero time",
+			input: `""`,
+			want:  time.Time{},
 		},
 	}
 
@@ -57,3 +57,81 @@ func TestCustomTime_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomTimeRaw(t *testing.T) {
+	var m Message
+	if err := json.Unmarshal([]byte(`{"created_at":"2024-01-15 10:30:00"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.CreatedAt.Raw != "2024-01-15 10:30:00" || m.CreatedAt.Hour() != 10 {
+		t.Errorf("CreatedAt = %v (raw %q)", m.CreatedAt.Time, m.CreatedAt.Raw)
+	}
+
+	// A message without a timestamp still decodes.
+	var empty Message
+	if err := json.Unmarshal([]byte(`{"message_id":1,"created_at":""}`), &empty); err != nil {
+		t.Fatalf("empty created_at: %v", err)
+	}
+	if !empty.CreatedAt.IsZero() || empty.MessageID != 1 {
+		t.Errorf("empty created_at = %+v", empty)
+	}
+
+	// Values marshaled by this package parse back to the same time.
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Message
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !back.CreatedAt.Equal(m.CreatedAt.Time) {
+		t.Errorf("round trip = %v, want %v", back.CreatedAt.Time, m.CreatedAt.Time)
+	}
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: `"2024-01-15"`, want: "2024-01-15"},
+		{input: `"2024-01-15 00:00:00"`, want: "2024-01-15"},
+		{input: `null`, want: ""},
+		{input: `""`, want: ""},
+		{input: `"yesterday"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var d Date
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.String() != tt.want {
+				t.Errorf("String() = %q, want %q", d.String(), tt.want)
+			}
+			if tt.want != "" && d.Location() != time.UTC {
+				t.Errorf("location = %v, want UTC", d.Location())
+			}
+		})
+	}
+
+	stats := PlayStatistics{PlaysCount: 3}
+	stats.Date, _ = ParseDate("2024-01-15 00:00:00")
+	data, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"date":"2024-01-15",`; string(data[:len(want)]) != want {
+		t.Errorf("Marshal() = %s, want date as a day", data)
+	}
+}
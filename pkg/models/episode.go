@@ -5,48 +5,58 @@ import (
 )
 
 type Episode struct {
-	EpisodeID int `json:"episode_id"`
+	// Numbers of removed fields, which must not be reused.
+	_ struct{} `json:"-" proto:"reserved=24,25,26"`
 
-	Title string `json:"title"`
+	EpisodeID int `json:"episode_id" proto:"1"`
 
-	Description string `json:"description"`
+	Title string `json:"title" proto:"2"`
 
-	ShowID int `json:"show_id"`
+	Description string `json:"description" proto:"3"`
 
-	Show *Show `json:"show,omitempty"`
+	ShowID int `json:"show_id" proto:"4"`
 
-	Author *User `json:"author,omitempty"`
+	Show *Show `json:"show,omitempty" proto:"5"`
 
-	AuthorID int `json:"author_id"`
-	SiteURL string `json:"site_url"`
+	Author *User `json:"author,omitempty" proto:"6"`
 
-	ImageURL string `json:"image_url"`
+	AuthorID int    `json:"author_id" proto:"7"`
+	SiteURL  string `json:"site_url" proto:"8"`
 
-	ImageOriginalURL string `json:"image_original_url"`
+	ImageURL string `json:"image_url" proto:"9"`
 
-	Duration int `json:"duration"`
+	ImageOriginalURL string `json:"image_original_url" proto:"10"`
 
-	PlayCount int `json:"plays_count"`
+	Duration int `json:"duration" proto:"11"`
 
-	LikesCount int `json:"likes_count"`
+	PlayCount int `json:"plays_count" proto:"12"`
 
-	MessagesCount int `json:"messages_count"`
+	LikesCount int `json:"likes_count" proto:"13"`
 
-	Tags []string `json:"tags,omitempty"`
+	MessagesCount int `json:"messages_count" proto:"14"`
 
-	PublishedAt *CustomTime `json:"published_at,omitempty"`
+	Tags []string `json:"tags,omitempty" proto:"15"`
 
-	EncodingStatus string `json:"encoding_status"`
+	PublishedAt *CustomTime `json:"published_at,omitempty" proto:"16"`
 
-	MediaURL string `json:"media_url,omitempty"`
+	// AutoPublishedAt is when a scheduled episode will be published.
+	AutoPublishedAt *CustomTime `json:"auto_published_at,omitempty" proto:"17"`
 
-	DownloadURL string `json:"download_url,omitempty"`
+	EncodingStatus string `json:"encoding_status" proto:"18"`
 
-	DownloadEnabled bool `json:"download_enabled"`
+	MediaURL string `json:"media_url,omitempty" proto:"19"`
 
-	Explicit bool `json:"explicit"`
+	DownloadURL string `json:"download_url,omitempty" proto:"20"`
 
-	Hidden bool `json:"hidden"`
+	// WaveformURL points at a JSON file of the audio's peak levels, which
+	// players draw as the progress bar.
+	WaveformURL string `json:"waveform_url,omitempty" proto:"27"`
+
+	DownloadEnabled bool `json:"download_enabled" proto:"21"`
+
+	Explicit bool `json:"explicit" proto:"22"`
+
+	Hidden bool `json:"hidden" proto:"23"`
 }
 
 type EpisodeResponse struct {
@@ -11,7 +11,7 @@ type Message struct {
 
 	Text string `json:"text"`
 
-	CreatedAt string `json:"created_at"`
+	CreatedAt CustomTime `json:"created_at"`
 
 	AuthorID int `json:"author_id"`
 
@@ -5,10 +5,10 @@ package models
 // -----------------------------------------------------------------------------
 
 type Category struct {
-	CategoryID int    `json:"category_id"`
-	Name       string `json:"name"`
-	Permalink  string `json:"permalink,omitempty"`
-	Level      int    `json:"level"` // 1 = top-level, 2 = subcategory
+	CategoryID int    `json:"category_id" proto:"1"`
+	Name       string `json:"name" proto:"2"`
+	Permalink  string `json:"permalink,omitempty" proto:"3"`
+	Level      int    `json:"level" proto:"4"` // 1 = top-level, 2 = subcategory
 }
 
 type CategoriesResponse struct {
@@ -1,42 +1,44 @@
 package models
 
-
 type Show struct {
-	ShowID int `json:"show_id"`
+	// Numbers of removed fields, which must not be reused.
+	_ struct{} `json:"-" proto:"reserved=19,20,21"`
+
+	ShowID int `json:"show_id" proto:"1"`
 
-	Title string `json:"title"`
+	Title string `json:"title" proto:"2"`
 
-	Description string `json:"description"`
+	Description string `json:"description" proto:"3"`
 
-	SiteURL string `json:"site_url"`
+	SiteURL string `json:"site_url" proto:"4"`
 
-	ImageURL string `json:"image_url"`
+	ImageURL string `json:"image_url" proto:"5"`
 
-	ImageOriginalURL string `json:"image_original_url"`
+	ImageOriginalURL string `json:"image_original_url" proto:"6"`
 
-	Author *User `json:"author,omitempty"`
+	Author *User `json:"author,omitempty" proto:"7"`
 
-	AuthorID int `json:"author_id"`
+	AuthorID int `json:"author_id" proto:"8"`
 
-	Category *Category `json:"category,omitempty"`
+	Category *Category `json:"category,omitempty" proto:"9"`
 
-	CategoryID int `json:"category_id"`
+	CategoryID int `json:"category_id" proto:"10"`
 
-	Language string `json:"language"`
+	Language string `json:"language" proto:"11"`
 
-	EpisodesCount int `json:"episodes_count"`
+	EpisodesCount int `json:"episodes_count" proto:"12"`
 
-	FollowersCount int `json:"followers_count"`
+	FollowersCount int `json:"followers_count" proto:"13"`
 
-	PlayCount int `json:"plays_count"`
+	PlayCount int `json:"plays_count" proto:"14"`
 
-	LikesCount int `json:"likes_count"`
+	LikesCount int `json:"likes_count" proto:"15"`
 
-	LastEpisodeAt *CustomTime `json:"last_episode_at,omitempty"`
+	LastEpisodeAt *CustomTime `json:"last_episode_at,omitempty" proto:"16"`
 
-	CreatedAt *CustomTime `json:"created_at,omitempty"`
+	CreatedAt *CustomTime `json:"created_at,omitempty" proto:"17"`
 
-	Explicit bool `json:"explicit"`
+	Explicit bool `json:"explicit" proto:"18"`
 }
 
 type ShowResponse struct {
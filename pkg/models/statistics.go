@@ -1,19 +1,25 @@
 package models
 
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
 // -----------------------------------------------------------------------------
 // Overall Statistics Models
 // -----------------------------------------------------------------------------
 
 type UserOverallStatistics struct {
-	PlaysCount         int   `json:"plays_count"`
-	PlaysOndemandCount int   `json:"plays_ondemand_count"`
-	PlaysLiveCount     int   `json:"plays_live_count"`
-	ShowsCount         int   `json:"shows_count"`
-	EpisodesCount      int   `json:"episodes_count"`
-	LikesCount         int   `json:"likes_count"`
-	DownloadsCount     int   `json:"downloads_count"`
-	FollowersCount     int   `json:"followers_count"`
-	User               *User `json:"user,omitempty"`
+	PlaysCount         int   `json:"plays_count" proto:"1"`
+	PlaysOndemandCount int   `json:"plays_ondemand_count" proto:"2"`
+	PlaysLiveCount     int   `json:"plays_live_count" proto:"3"`
+	ShowsCount         int   `json:"shows_count" proto:"4"`
+	EpisodesCount      int   `json:"episodes_count" proto:"5"`
+	LikesCount         int   `json:"likes_count" proto:"6"`
+	DownloadsCount     int   `json:"downloads_count" proto:"7"`
+	FollowersCount     int   `json:"followers_count" proto:"8"`
+	User               *User `json:"user,omitempty" proto:"9"`
 }
 
 type UserOverallStatisticsResponse struct {
@@ -21,14 +27,14 @@ type UserOverallStatisticsResponse struct {
 }
 
 type ShowOverallStatistics struct {
-	Title              string `json:"title,omitempty"`
-	PlaysCount         int    `json:"plays_count"`
-	PlaysOndemandCount int    `json:"plays_ondemand_count"`
-	PlaysLiveCount     int    `json:"plays_live_count"`
-	EpisodesCount      int    `json:"episodes_count"`
-	DownloadsCount     int    `json:"downloads_count"`
-	LikesCount         int    `json:"likes_count"`
-	Show               *Show  `json:"show,omitempty"`
+	Title              string `json:"title,omitempty" proto:"1"`
+	PlaysCount         int    `json:"plays_count" proto:"2"`
+	PlaysOndemandCount int    `json:"plays_ondemand_count" proto:"3"`
+	PlaysLiveCount     int    `json:"plays_live_count" proto:"4"`
+	EpisodesCount      int    `json:"episodes_count" proto:"5"`
+	DownloadsCount     int    `json:"downloads_count" proto:"6"`
+	LikesCount         int    `json:"likes_count" proto:"7"`
+	Show               *Show  `json:"show,omitempty" proto:"8"`
 }
 
 type ShowOverallStatisticsResponse struct {
@@ -36,14 +42,14 @@ type ShowOverallStatisticsResponse struct {
 }
 
 type EpisodeOverallStatistics struct {
-	PlaysCount         int      `json:"plays_count"`
-	PlaysOndemandCount int      `json:"plays_ondemand_count"`
-	PlaysLiveCount     int      `json:"plays_live_count"`
-	ChaptersCount      int      `json:"chapters_count"`
-	MessagesCount      int      `json:"messages_count"`
-	LikesCount         int      `json:"likes_count"`
-	DownloadsCount     int      `json:"downloads_count"`
-	Episode            *Episode `json:"episode,omitempty"`
+	PlaysCount         int      `json:"plays_count" proto:"1"`
+	PlaysOndemandCount int      `json:"plays_ondemand_count" proto:"2"`
+	PlaysLiveCount     int      `json:"plays_live_count" proto:"3"`
+	ChaptersCount      int      `json:"chapters_count" proto:"4"`
+	MessagesCount      int      `json:"messages_count" proto:"5"`
+	LikesCount         int      `json:"likes_count" proto:"6"`
+	DownloadsCount     int      `json:"downloads_count" proto:"7"`
+	Episode            *Episode `json:"episode,omitempty" proto:"8"`
 }
 
 type EpisodeOverallStatisticsResponse struct {
@@ -54,13 +60,12 @@ type EpisodeOverallStatisticsResponse struct {
 // Play Statistics Models (Time-series)
 // -----------------------------------------------------------------------------
 
-
 type PlayStatistics struct {
-	Date               string `json:"date"` // Format: YYYY-MM-DD
-	PlaysCount         int    `json:"plays_count"`
-	PlaysLiveCount     int    `json:"plays_live_count"`
-	PlaysOndemandCount int    `json:"plays_ondemand_count"`
-	DownloadsCount     int    `json:"downloads_count"`
+	Date               Date `json:"date" proto:"1"`
+	PlaysCount         int  `json:"plays_count" proto:"2"`
+	PlaysLiveCount     int  `json:"plays_live_count" proto:"3"`
+	PlaysOndemandCount int  `json:"plays_ondemand_count" proto:"4"`
+	DownloadsCount     int  `json:"downloads_count" proto:"5"`
 }
 
 type PlayStatisticsResponse struct {
@@ -79,14 +84,14 @@ type ShowPlayTotals struct {
 }
 
 type EpisodePlayTotals struct {
-	EpisodeID          int    `json:"episode_id"`
-	Title              string `json:"title"`
-	PlaysCount         int    `json:"plays_count"`
-	PlaysLiveCount     int    `json:"plays_live_count"`
-	PlaysOndemandCount int    `json:"plays_ondemand_count"`
-	DownloadsCount     int    `json:"downloads_count"`
-	IsDeleted          bool   `json:"is_deleted"`
-	IsTransferred      bool   `json:"is_transferred"`
+	EpisodeID          int    `json:"episode_id" proto:"1"`
+	Title              string `json:"title" proto:"2"`
+	PlaysCount         int    `json:"plays_count" proto:"3"`
+	PlaysLiveCount     int    `json:"plays_live_count" proto:"4"`
+	PlaysOndemandCount int    `json:"plays_ondemand_count" proto:"5"`
+	DownloadsCount     int    `json:"downloads_count" proto:"6"`
+	IsDeleted          bool   `json:"is_deleted" proto:"7"`
+	IsTransferred      bool   `json:"is_transferred" proto:"8"`
 }
 
 // -----------------------------------------------------------------------------
@@ -94,8 +99,8 @@ type EpisodePlayTotals struct {
 // -----------------------------------------------------------------------------
 
 type LikesStatistics struct {
-	Date       string `json:"date"` // Format: YYYY-MM-DD
-	LikesCount int    `json:"likes_count"`
+	Date       Date `json:"date"`
+	LikesCount int  `json:"likes_count"`
 }
 
 type LikesStatisticsResponse struct {
@@ -107,8 +112,8 @@ type LikesStatisticsResponse struct {
 // -----------------------------------------------------------------------------
 
 type FollowersStatistics struct {
-	Date           string `json:"date"` // Format: YYYY-MM-DD
-	FollowersCount int    `json:"followers_count"`
+	Date           Date `json:"date"`
+	FollowersCount int  `json:"followers_count"`
 }
 
 type FollowersStatisticsResponse struct {
@@ -174,6 +179,14 @@ type OSStatisticsResponse struct {
 type GeoStatistics struct {
 	Name       string  `json:"name"`
 	Percentage float64 `json:"percentage"`
+
+	// Code is the ISO 3166-1 alpha-2 code of a country entry, and Country
+	// the country code of a city entry, when the API reports them.
+	Code    string `json:"code,omitempty"`
+	Country string `json:"country,omitempty"`
+
+	// PlaysCount is the absolute number of plays, when the API reports it.
+	PlaysCount int `json:"plays_count,omitempty"`
 }
 
 type GeographicStatistics struct {
@@ -181,6 +194,63 @@ type GeographicStatistics struct {
 	City    []GeoStatistics `json:"city"`
 }
 
+// HasCounts reports whether the API returned absolute play counts.
+func (g *GeographicStatistics) HasCounts() bool {
+	for _, list := range [][]GeoStatistics{g.Country, g.City} {
+		for _, s := range list {
+			if s.PlaysCount > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InCountry narrows the breakdown to one country, given by code or name
+// (case-insensitive), and the cities within it. ok is false when the
+// country is not in the breakdown or the API did not report the country
+// of cities, so they cannot be told apart.
+func (g *GeographicStatistics) InCountry(country string) (result *GeographicStatistics, ok bool) {
+	var match *GeoStatistics
+	for i, c := range g.Country {
+		if strings.EqualFold(c.Code, country) || strings.EqualFold(c.Name, country) {
+			match = &g.Country[i]
+			break
+		}
+	}
+	if match == nil {
+		return nil, false
+	}
+
+	result = &GeographicStatistics{Country: []GeoStatistics{*match}, City: []GeoStatistics{}}
+	located := false
+	for _, c := range g.City {
+		if c.Country == "" {
+			continue
+		}
+		located = true
+		if strings.EqualFold(c.Country, match.Code) || strings.EqualFold(c.Country, match.Name) {
+			result.City = append(result.City, c)
+		}
+	}
+	return result, located
+}
+
+// Top keeps the n countries and n cities with the highest share. n of 0
+// or less keeps every entry.
+func (g *GeographicStatistics) Top(n int) *GeographicStatistics {
+	return &GeographicStatistics{Country: topGeo(g.Country, n), City: topGeo(g.City, n)}
+}
+
+func topGeo(list []GeoStatistics, n int) []GeoStatistics {
+	sorted := slices.Clone(list)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Percentage > sorted[j].Percentage })
+	if n > 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
 type GeographicStatisticsResponse struct {
 	Statistics GeographicStatistics `json:"statistics"`
 }
@@ -190,8 +260,8 @@ type GeographicStatisticsResponse struct {
 // -----------------------------------------------------------------------------
 
 type ListenersStatistics struct {
-	Date           string `json:"date"` // Format: YYYY-MM-DD
-	ListenersCount int    `json:"listeners_count"`
+	Date           Date `json:"date"`
+	ListenersCount int  `json:"listeners_count"`
 }
 
 type ListenersStatisticsResponse struct {
@@ -211,4 +281,4 @@ type Statistics struct {
 
 type StatisticsResponse struct {
 	Statistics Statistics `json:"statistics"`
-}
\ No newline at end of file
+}
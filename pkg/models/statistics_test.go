@@ -0,0 +1,86 @@
+package models
+
+import "testing"
+
+func TestGeographicStatistics_InCountry(t *testing.T) {
+	geo := &GeographicStatistics{
+		Country: []GeoStatistics{
+			{Name: "Italy", Code: "IT", Percentage: 60},
+			{Name: "United States", Code: "US", Percentage: 40},
+		},
+		City: []GeoStatistics{
+			{Name: "Rome", Country: "IT", Percentage: 30},
+			{Name: "New York", Country: "US", Percentage: 25},
+			{Name: "Milan", Country: "it", Percentage: 20},
+		},
+	}
+
+	tests := []struct {
+		country    string
+		wantOK     bool
+		wantCities []string
+	}{
+		{"US", true, []string{"New York"}},
+		{"italy", true, []string{"Rome", "Milan"}},
+		{"FR", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country, func(t *testing.T) {
+			got, ok := geo.InCountry(tt.country)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if len(got.Country) != 1 {
+				t.Errorf("countries = %+v, want one", got.Country)
+			}
+			if len(got.City) != len(tt.wantCities) {
+				t.Fatalf("cities = %+v, want %v", got.City, tt.wantCities)
+			}
+			for i, name := range tt.wantCities {
+				if got.City[i].Name != name {
+					t.Errorf("city %d = %s, want %s", i, got.City[i].Name, name)
+				}
+			}
+		})
+	}
+
+	t.Run("cities without country", func(t *testing.T) {
+		unlocated := &GeographicStatistics{Country: geo.Country, City: []GeoStatistics{{Name: "Rome", Percentage: 30}}}
+		if _, ok := unlocated.InCountry("IT"); ok {
+			t.Error("ok = true, want false when cities carry no country")
+		}
+	})
+}
+
+func TestGeographicStatistics_Top(t *testing.T) {
+	geo := &GeographicStatistics{
+		Country: []GeoStatistics{{Name: "A", Percentage: 10}, {Name: "B", Percentage: 50}, {Name: "C", Percentage: 40}},
+		City:    []GeoStatistics{{Name: "X", Percentage: 5}},
+	}
+
+	top := geo.Top(2)
+	if len(top.Country) != 2 || top.Country[0].Name != "B" || top.Country[1].Name != "C" {
+		t.Errorf("Top(2) countries = %+v", top.Country)
+	}
+	if len(top.City) != 1 {
+		t.Errorf("Top(2) cities = %+v", top.City)
+	}
+	if all := geo.Top(0); len(all.Country) != 3 {
+		t.Errorf("Top(0) countries = %+v, want all", all.Country)
+	}
+	if geo.Country[0].Name != "A" {
+		t.Error("Top reordered the original breakdown")
+	}
+}
+
+func TestGeographicStatistics_HasCounts(t *testing.T) {
+	if (&GeographicStatistics{Country: []GeoStatistics{{Name: "A"}}}).HasCounts() {
+		t.Error("HasCounts = true without counts")
+	}
+	if !(&GeographicStatistics{City: []GeoStatistics{{Name: "X", PlaysCount: 3}}}).HasCounts() {
+		t.Error("HasCounts = false with counts")
+	}
+}
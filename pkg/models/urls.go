@@ -0,0 +1,53 @@
+package models
+
+import "fmt"
+
+// SiteBaseURL is the root of the Spreaker website, including the creator CMS.
+const SiteBaseURL = "https://www.spreaker.com"
+
+// PageURL returns the show's public page. It falls back to a URL built from
+// the show ID when the API response has no site_url.
+func (s *Show) PageURL() string {
+	if s.SiteURL != "" {
+		return s.SiteURL
+	}
+	return fmt.Sprintf("%s/show/%d", SiteBaseURL, s.ShowID)
+}
+
+// StatsURL returns the show's statistics page in the Spreaker CMS.
+func (s *Show) StatsURL() string {
+	return fmt.Sprintf("%s/cms/statistics/shows/%d", SiteBaseURL, s.ShowID)
+}
+
+// FeedURL returns the show's RSS feed.
+func (s *Show) FeedURL() string {
+	return fmt.Sprintf("%s/show/%d/episodes/feed", SiteBaseURL, s.ShowID)
+}
+
+// PageURL returns the episode's public page, falling back to a URL built
+// from the episode ID.
+func (e *Episode) PageURL() string {
+	if e.SiteURL != "" {
+		return e.SiteURL
+	}
+	return fmt.Sprintf("%s/episode/%d", SiteBaseURL, e.EpisodeID)
+}
+
+// PageURL returns the user's public profile, falling back to a URL built
+// from the user ID.
+func (u *User) PageURL() string {
+	if u.SiteURL != "" {
+		return u.SiteURL
+	}
+	return fmt.Sprintf("%s/user/%d", SiteBaseURL, u.UserID)
+}
+
+// PublicDownloadURL returns a URL anyone can download the episode's audio
+// from: the API's download_url, or its download endpoint, which redirects
+// to the file for public episodes.
+func (e *Episode) PublicDownloadURL() string {
+	if e.DownloadURL != "" {
+		return e.DownloadURL
+	}
+	return fmt.Sprintf("https://api.spreaker.com/v2/episodes/%d/download", e.EpisodeID)
+}
@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"show site url", (&Show{ShowID: 1, SiteURL: "https://www.spreaker.com/podcast/my-show--1"}).PageURL(), "https://www.spreaker.com/podcast/my-show--1"},
+		{"show fallback", (&Show{ShowID: 1}).PageURL(), "https://www.spreaker.com/show/1"},
+		{"show feed", (&Show{ShowID: 1}).FeedURL(), "https://www.spreaker.com/show/1/episodes/feed"},
+		{"show stats", (&Show{ShowID: 1}).StatsURL(), "https://www.spreaker.com/cms/statistics/shows/1"},
+		{"episode site url", (&Episode{EpisodeID: 2, SiteURL: "https://www.spreaker.com/episode/ep--2"}).PageURL(), "https://www.spreaker.com/episode/ep--2"},
+		{"episode fallback", (&Episode{EpisodeID: 2}).PageURL(), "https://www.spreaker.com/episode/2"},
+		{"user fallback", (&User{UserID: 3}).PageURL(), "https://www.spreaker.com/user/3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
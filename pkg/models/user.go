@@ -1,41 +1,40 @@
 package models
 
 type User struct {
-	UserID int `json:"user_id"`
+	UserID int `json:"user_id" proto:"1"`
 
-	Fullname string `json:"fullname"`
+	Fullname string `json:"fullname" proto:"2"`
 
-	Username string `json:"username"`
+	Username string `json:"username" proto:"3"`
 
-	Description string `json:"description"`
+	Description string `json:"description" proto:"4"`
 
-	SiteURL string `json:"site_url"`
+	SiteURL string `json:"site_url" proto:"5"`
 
-	ImageURL string `json:"image_url"`
+	ImageURL string `json:"image_url" proto:"6"`
 
-	ImageOriginalURL string `json:"image_original_url"`
+	ImageOriginalURL string `json:"image_original_url" proto:"7"`
 
-	Kind string `json:"kind"`
+	Kind string `json:"kind" proto:"8"`
 
-	Plan string `json:"plan"`
+	Plan string `json:"plan" proto:"9"`
 
-	FollowersCount int `json:"followers_count"`
+	FollowersCount int `json:"followers_count" proto:"10"`
 
-	FollowingsCount int `json:"followings_count"`
+	FollowingsCount int `json:"followings_count" proto:"11"`
 
-	ContactEmail string `json:"contact_email,omitempty"`
+	ContactEmail string `json:"contact_email,omitempty" proto:"12"`
 
-	Gender string `json:"gender,omitempty"`
+	Gender string `json:"gender,omitempty" proto:"13"`
 
-	Birthday string `json:"birthday,omitempty"`
+	Birthday string `json:"birthday,omitempty" proto:"14"`
 
-	Location string `json:"location,omitempty"`
+	Location string `json:"location,omitempty" proto:"15"`
 
-	LocationLatitude float64 `json:"location_latitude,omitempty"`
-	LocationLongitude float64 `json:"location_longitude,omitempty"`
+	LocationLatitude  float64 `json:"location_latitude,omitempty" proto:"16"`
+	LocationLongitude float64 `json:"location_longitude,omitempty" proto:"17"`
 }
 
 type UserResponse struct {
 	User User `json:"user"`
 }
-